@@ -0,0 +1,45 @@
+// Service worker for Web Push notifications. The server sends a JSON payload
+// of { title, body, data } where data carries the workspace and channel IDs.
+
+self.addEventListener('push', (event) => {
+  if (!event.data) {
+    return;
+  }
+
+  let payload;
+  try {
+    payload = event.data.json();
+  } catch {
+    return;
+  }
+
+  event.waitUntil(
+    self.registration.showNotification(payload.title || 'Enzyme', {
+      body: payload.body,
+      icon: '/favicon.svg',
+      tag: payload.data?.channel_id || 'enzyme-notification',
+      data: payload.data,
+    }),
+  );
+});
+
+self.addEventListener('notificationclick', (event) => {
+  event.notification.close();
+
+  const data = event.notification.data || {};
+  const path =
+    data.workspace_id && data.channel_id
+      ? `/workspaces/${data.workspace_id}/channels/${data.channel_id}`
+      : '/';
+
+  event.waitUntil(
+    self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((windows) => {
+      for (const client of windows) {
+        if ('focus' in client) {
+          return client.focus().then((focused) => focused.navigate(path));
+        }
+      }
+      return self.clients.openWindow(path);
+    }),
+  );
+});
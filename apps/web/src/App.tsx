@@ -30,6 +30,9 @@ const ResetPasswordPage = lazy(() =>
 const VerifyEmailPage = lazy(() =>
   import('./pages/VerifyEmailPage').then((m) => ({ default: m.VerifyEmailPage })),
 );
+const UnsubscribePage = lazy(() =>
+  import('./pages/UnsubscribePage').then((m) => ({ default: m.UnsubscribePage })),
+);
 
 function PageSpinner() {
   return (
@@ -78,6 +81,14 @@ function App() {
               </Suspense>
             }
           />
+          <Route
+            path="/unsubscribe"
+            element={
+              <Suspense fallback={<PageSpinner />}>
+                <UnsubscribePage />
+              </Suspense>
+            }
+          />
           <Route
             path="/invites/:code"
             element={
@@ -11,6 +11,7 @@ export function RegisterForm() {
   const [confirmPassword, setConfirmPassword] = useState('');
   const [workspaceName, setWorkspaceName] = useState('');
   const [error, setError] = useState('');
+  const [awaitingApproval, setAwaitingApproval] = useState(false);
   const { register, isRegistering } = useAuth();
   const createWorkspace = useCreateWorkspace();
   const acceptInvite = useAcceptInvite();
@@ -39,18 +40,28 @@ export function RegisterForm() {
     }
 
     try {
-      await register({ email, password, display_name: displayName });
-
-      // Check for pending invite and auto-accept it
+      // The invite code lets the user register on servers that require one
       const pendingInvite = sessionStorage.getItem('pendingInvite');
+      const result = await register({
+        email,
+        password,
+        display_name: displayName,
+        ...(pendingInvite && { invite_code: pendingInvite }),
+      });
+      if (!('token' in result)) {
+        setAwaitingApproval(true);
+        return;
+      }
+
+      // Auto-accept the pending invite
       if (pendingInvite) {
         const { workspace } = await acceptInvite.mutateAsync(pendingInvite);
         sessionStorage.removeItem('pendingInvite');
         navigate(`/workspaces/${workspace.id}`, { replace: true });
       } else if (workspaceName.trim()) {
         // No pending invite - create a workspace and redirect to it
-        const result = await createWorkspace.mutateAsync({ name: workspaceName.trim() });
-        navigate(`/workspaces/${result.workspace.id}`, { replace: true });
+        const { workspace } = await createWorkspace.mutateAsync({ name: workspaceName.trim() });
+        navigate(`/workspaces/${workspace.id}`, { replace: true });
       }
     } catch (err) {
       if (err instanceof ApiError) {
@@ -63,6 +74,23 @@ export function RegisterForm() {
 
   const isSubmitting = isRegistering || createWorkspace.isPending || acceptInvite.isPending;
 
+  if (awaitingApproval) {
+    return (
+      <div className="w-full max-w-md text-center">
+        <h1 className="text-3xl font-bold text-gray-900 dark:text-white">Awaiting approval</h1>
+        <p className="mt-4 text-gray-600 dark:text-gray-400">
+          Your account has been created. A site admin needs to approve it before you can sign
+          in.
+        </p>
+        <p className="mt-6 text-sm">
+          <Link to="/login" className="font-medium text-blue-600 hover:text-blue-700">
+            Back to sign in
+          </Link>
+        </p>
+      </div>
+    );
+  }
+
   return (
     <div className="w-full max-w-md">
       <div className="mb-8 text-center">
@@ -13,6 +13,8 @@ import {
 } from '../ui';
 import { useChannelMembers, useAddChannelMember, useUpdateChannel } from '../../hooks/useChannels';
 import { useWorkspaceMembers } from '../../hooks/useWorkspaces';
+import { useServerInfo } from '../../hooks';
+import { ChannelEmailSettings } from './ChannelEmailSettings';
 import { cn } from '../../lib/utils';
 import type {
   ChannelMember,
@@ -23,7 +25,7 @@ import { ApiError } from '@enzyme/api-client';
 
 const validChannelName = /^[a-z0-9]+(-[a-z0-9]+)*$/;
 
-type TabId = 'about' | 'members' | 'add';
+type TabId = 'about' | 'members' | 'add' | 'email';
 
 interface ChannelDetailsModalProps {
   isOpen: boolean;
@@ -32,6 +34,7 @@ interface ChannelDetailsModalProps {
   workspaceId: string;
   canAddMembers: boolean;
   canEditChannel: boolean;
+  canManageEmail?: boolean;
   channel: ChannelWithMembership;
   defaultTab?: TabId;
 }
@@ -43,9 +46,11 @@ export function ChannelDetailsModal({
   workspaceId,
   canAddMembers,
   canEditChannel,
+  canManageEmail = false,
   channel,
   defaultTab = 'about',
 }: ChannelDetailsModalProps) {
+  const { inboundEmailEnabled } = useServerInfo();
   const { data: membersData, isLoading: membersLoading } = useChannelMembers(channelId);
   const { data: workspaceMembersData, isLoading: workspaceMembersLoading } =
     useWorkspaceMembers(workspaceId);
@@ -278,6 +283,7 @@ export function ChannelDetailsModal({
   );
 
   const isGroupDM = channel.type === 'group_dm';
+  const showEmailTab = canManageEmail && inboundEmailEnabled && !isDMChannel;
   const isLoading = membersLoading || (canAddMembers && workspaceMembersLoading);
 
   // For group DMs, default to members tab since there's no about tab
@@ -300,6 +306,7 @@ export function ChannelDetailsModal({
             {!isGroupDM && <Tab id="about">About</Tab>}
             <Tab id="members">Members ({members.length})</Tab>
             {canAddMembers && <Tab id="add">Add Members</Tab>}
+            {showEmailTab && <Tab id="email">Email</Tab>}
           </TabList>
           {!isGroupDM && (
             <TabPanel id="about" className="pt-4">
@@ -314,6 +321,11 @@ export function ChannelDetailsModal({
               {renderAddMemberList(nonMembers)}
             </TabPanel>
           )}
+          {showEmailTab && (
+            <TabPanel id="email" className="pt-4">
+              <ChannelEmailSettings channelId={channelId} />
+            </TabPanel>
+          )}
         </Tabs>
       )}
     </Modal>
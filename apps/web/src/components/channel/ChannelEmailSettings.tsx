@@ -0,0 +1,170 @@
+import { useState, useEffect } from 'react';
+import { Button, Spinner, toast, ConfirmDialog } from '../ui';
+import {
+  useChannelEmailAddress,
+  useUpdateChannelEmailAddress,
+  useRotateChannelEmailAddress,
+  useDeleteChannelEmailAddress,
+} from '../../hooks/useChannels';
+import { ApiError } from '@enzyme/api-client';
+
+interface ChannelEmailSettingsProps {
+  channelId: string;
+}
+
+const parseSenders = (value: string) =>
+  value
+    .split(/[\s,]+/)
+    .map((s) => s.trim())
+    .filter(Boolean);
+
+export function ChannelEmailSettings({ channelId }: ChannelEmailSettingsProps) {
+  const { data: address, isLoading } = useChannelEmailAddress(channelId);
+  const updateAddress = useUpdateChannelEmailAddress(channelId);
+  const rotateAddress = useRotateChannelEmailAddress(channelId);
+  const deleteAddress = useDeleteChannelEmailAddress(channelId);
+  const [senders, setSenders] = useState('');
+  const [error, setError] = useState<string | null>(null);
+  const [confirming, setConfirming] = useState<'rotate' | 'delete' | null>(null);
+
+  useEffect(() => {
+    setSenders(address?.allowed_senders.join('\n') ?? '');
+  }, [address?.allowed_senders]);
+
+  const handleSave = async () => {
+    setError(null);
+    try {
+      await updateAddress.mutateAsync(parseSenders(senders));
+      if (address) toast('Allowed senders saved', 'success');
+    } catch (err) {
+      setError(err instanceof ApiError ? err.message : 'Failed to save email address');
+    }
+  };
+
+  const handleConfirm = async () => {
+    try {
+      if (confirming === 'rotate') {
+        await rotateAddress.mutateAsync();
+        toast('Email address rotated', 'success');
+      } else {
+        await deleteAddress.mutateAsync();
+        toast('Email address removed', 'success');
+      }
+    } catch (err) {
+      toast(err instanceof ApiError ? err.message : 'Something went wrong', 'error');
+    }
+    setConfirming(null);
+  };
+
+  if (isLoading) {
+    return (
+      <div className="flex items-center justify-center py-8">
+        <Spinner />
+      </div>
+    );
+  }
+
+  if (!address) {
+    return (
+      <div className="space-y-4">
+        <p className="text-sm text-gray-600 dark:text-gray-400">
+          Give this channel an email address to let alerting systems and vendors post into it. Each
+          email becomes a message, with its attachments as files.
+        </p>
+        {error && <p className="text-xs text-red-500">{error}</p>}
+        <Button size="sm" onPress={handleSave} isLoading={updateAddress.isPending}>
+          Create email address
+        </Button>
+      </div>
+    );
+  }
+
+  const hasSendersChanged = parseSenders(senders).join('\n') !== address.allowed_senders.join('\n');
+
+  return (
+    <div className="space-y-4">
+      <div>
+        <label className="mb-1 block text-sm font-medium text-gray-700 dark:text-gray-300">
+          Email address
+        </label>
+        <div className="flex gap-2">
+          <input
+            type="text"
+            readOnly
+            value={address.address}
+            onFocus={(e) => e.target.select()}
+            className="w-full rounded-md border border-gray-300 bg-gray-50 px-3 py-2 font-mono text-sm text-gray-900 dark:border-gray-600 dark:bg-gray-900 dark:text-white"
+          />
+          <Button
+            size="sm"
+            variant="secondary"
+            onPress={() => {
+              navigator.clipboard.writeText(address.address);
+              toast('Email address copied to clipboard', 'success');
+            }}
+          >
+            Copy
+          </Button>
+        </div>
+        <p className="mt-1 text-xs text-gray-500 dark:text-gray-400">
+          Anyone who knows this address can post here unless senders are restricted below.
+        </p>
+      </div>
+      <div>
+        <label className="mb-1 block text-sm font-medium text-gray-700 dark:text-gray-300">
+          Allowed senders
+        </label>
+        <textarea
+          value={senders}
+          onChange={(e) => {
+            setSenders(e.target.value);
+            setError(null);
+          }}
+          placeholder={'alerts@example.com\nexample.org'}
+          className="w-full resize-none rounded-md border border-gray-300 bg-white px-3 py-2 text-sm text-gray-900 placeholder-gray-400 focus:border-transparent focus:ring-2 focus:ring-blue-500 focus:outline-none dark:border-gray-600 dark:bg-gray-800 dark:text-white dark:placeholder-gray-500"
+          rows={3}
+        />
+        <p className="mt-1 text-xs text-gray-500 dark:text-gray-400">
+          One address or domain per line. Leave empty to accept mail from anyone.
+        </p>
+      </div>
+      <div className="flex items-center justify-between gap-3">
+        <div className="flex gap-2">
+          <Button size="sm" variant="outline" onPress={() => setConfirming('rotate')}>
+            Rotate address
+          </Button>
+          <Button size="sm" variant="outline" onPress={() => setConfirming('delete')}>
+            Remove
+          </Button>
+        </div>
+        <div className="flex items-center gap-3">
+          {error && <p className="text-xs text-red-500">{error}</p>}
+          <Button
+            size="sm"
+            onPress={handleSave}
+            isLoading={updateAddress.isPending}
+            isDisabled={!hasSendersChanged}
+          >
+            Save
+          </Button>
+        </div>
+      </div>
+      {confirming && (
+        <ConfirmDialog
+          isOpen
+          onClose={() => setConfirming(null)}
+          onConfirm={handleConfirm}
+          title={confirming === 'rotate' ? 'Rotate email address' : 'Remove email address'}
+          description={
+            confirming === 'rotate'
+              ? 'The channel gets a new address. Mail sent to the current one will be rejected, so update every system that sends to it.'
+              : 'Mail sent to this address will be rejected. You can create a new address later.'
+          }
+          confirmLabel={confirming === 'rotate' ? 'Rotate' : 'Remove'}
+          variant="destructive"
+          isLoading={rotateAddress.isPending || deleteAddress.isPending}
+        />
+      )}
+    </div>
+  );
+}
@@ -1,6 +1,15 @@
-import { BellAlertIcon, BellIcon, BellSlashIcon } from '@heroicons/react/24/outline';
+import {
+  ArrowUturnLeftIcon,
+  BellAlertIcon,
+  BellIcon,
+  BellSlashIcon,
+} from '@heroicons/react/24/outline';
 import { SelectMenu, SelectMenuItem, Spinner, UnstyledButton } from '../ui';
-import { useChannelNotifications, useUpdateChannelNotifications } from '../../hooks';
+import {
+  useChannelNotifications,
+  useResetChannelNotifications,
+  useUpdateChannelNotifications,
+} from '../../hooks';
 import type { ChannelType, NotifyLevel } from '@enzyme/api-client';
 import { cn } from '../../lib/utils';
 
@@ -21,6 +30,7 @@ export function ChannelNotificationButton({
 }: ChannelNotificationButtonProps) {
   const { data, isLoading } = useChannelNotifications(channelId);
   const updateNotifications = useUpdateChannelNotifications(channelId);
+  const resetNotifications = useResetChannelNotifications(channelId);
 
   // Hide for DM channels
   if (channelType === 'dm' || channelType === 'group_dm') {
@@ -29,12 +39,19 @@ export function ChannelNotificationButton({
 
   const currentLevel = data?.preferences?.notify_level || 'all';
   const currentEmailEnabled = data?.preferences?.email_enabled ?? true;
+  const isInherited = data?.preferences?.inherited ?? true;
   const isMuted = currentLevel === 'none';
 
   const handleSelect = (key: React.Key) => {
+    if (key === 'default') {
+      resetNotifications.mutate();
+      return;
+    }
     updateNotifications.mutate({
       notify_level: key as NotifyLevel,
       email_enabled: currentEmailEnabled,
+      // Keep a push override, but let inherited push follow the defaults
+      push_enabled: isInherited ? undefined : data?.preferences?.push_enabled,
     });
   };
 
@@ -78,6 +95,14 @@ export function ChannelNotificationButton({
           {label}
         </SelectMenuItem>
       ))}
+      {!isInherited && (
+        <SelectMenuItem
+          id="default"
+          icon={<ArrowUturnLeftIcon className="h-4 w-4 text-gray-500 dark:text-gray-400" />}
+        >
+          Use default
+        </SelectMenuItem>
+      )}
     </SelectMenu>
   );
 }
@@ -176,6 +176,7 @@ export interface RichTextEditorRef {
   focus: () => void;
   isEmpty: () => boolean;
   getContent: () => string;
+  setContent: (content: string) => void;
   insertAtSymbol: () => void;
   insertHashSymbol: () => void;
   insertText: (text: string) => void;
@@ -722,6 +723,9 @@ export const RichTextEditor = forwardRef<RichTextEditorRef, RichTextEditorProps>
           if (!editor) return '';
           return toMrkdwn(editor.getJSON()).trim();
         },
+        setContent: (content: string) => {
+          editor?.commands.setContent(fromMrkdwn(content));
+        },
         insertAtSymbol,
         insertHashSymbol,
         insertText,
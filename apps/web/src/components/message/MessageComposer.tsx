@@ -27,7 +27,7 @@ import { useTypingUsers } from '../../lib/presenceStore';
 import { setEditingMessageId } from '../../lib/editingMessageStore';
 import { EMOJI_MAP } from '@enzyme/shared';
 import { cn } from '../../lib/utils';
-import type { MessageListResult } from '@enzyme/api-client';
+import { ApiError, type MessageListResult } from '@enzyme/api-client';
 import {
   LazyRichTextEditor,
   useEditorMembers,
@@ -36,7 +36,7 @@ import {
 } from '../editor';
 import { AddEmojiModal } from '../editor/AddEmojiModal';
 import { ScheduleMessageModal } from './ScheduleMessageModal';
-import { IconButton, toast } from '../ui';
+import { ConfirmDialog, IconButton, toast } from '../ui';
 
 export interface MessageComposerRef {
   focus: () => void;
@@ -53,6 +53,19 @@ interface MessageComposerProps {
   channelType?: string;
 }
 
+interface SendInput {
+  content?: string;
+  attachment_ids?: string[];
+  also_send_to_channel?: boolean;
+}
+
+// A message held back until the sender confirms its @here, @channel or
+// @everyone mention
+interface PendingMentionSend {
+  input: SendInput;
+  reason: string;
+}
+
 interface PendingAttachment {
   id: string;
   file: File;
@@ -84,6 +97,7 @@ export const MessageComposer = forwardRef<MessageComposerRef, MessageComposerPro
     const [isDragging, setIsDragging] = useState(false);
     const [addEmojiOpen, setAddEmojiOpen] = useState(false);
     const [scheduleModalOpen, setScheduleModalOpen] = useState(false);
+    const [pendingMentionSend, setPendingMentionSend] = useState<PendingMentionSend | null>(null);
     const editorRef = useRef<RichTextEditorRef>(null);
 
     useImperativeHandle(ref, () => ({
@@ -222,12 +236,18 @@ export const MessageComposer = forwardRef<MessageComposerRef, MessageComposerPro
 
       if (!canSend) return;
 
+      await send({
+        content: hasContent ? content : undefined,
+        attachment_ids: hasAttachments ? completedAttachmentIds : undefined,
+        ...(isThreadVariant && alsoSendToChannel ? { also_send_to_channel: true } : {}),
+      });
+    };
+
+    const send = async (input: SendInput, confirmMentions = false) => {
       try {
-        await activeMutation.mutateAsync({
-          content: hasContent ? content : undefined,
-          attachment_ids: hasAttachments ? completedAttachmentIds : undefined,
-          ...(isThreadVariant && alsoSendToChannel ? { also_send_to_channel: true } : {}),
-        });
+        await activeMutation.mutateAsync(
+          confirmMentions ? { ...input, confirm_mentions: true } : input,
+        );
 
         // Clear attachments and revoke preview URLs
         pendingAttachments.forEach((a) => {
@@ -236,11 +256,28 @@ export const MessageComposer = forwardRef<MessageComposerRef, MessageComposerPro
         setPendingAttachments([]);
         setAlsoSendToChannel(false);
         onStopTyping();
-      } catch {
-        // Error handling is done via toast in mutation
+      } catch (err) {
+        // Large audiences must be confirmed; other errors are handled via toast in mutation
+        if (err instanceof ApiError && err.code === 'CONFIRMATION_REQUIRED') {
+          setPendingMentionSend({ input, reason: err.message });
+        }
       }
     };
 
+    const handleConfirmMentions = async () => {
+      if (!pendingMentionSend) return;
+      await send(pendingMentionSend.input, true);
+      setPendingMentionSend(null);
+    };
+
+    const handleCancelMentions = () => {
+      // Give the message back so it can be edited
+      if (pendingMentionSend?.input.content) {
+        editorRef.current?.setContent(pendingMentionSend.input.content);
+      }
+      setPendingMentionSend(null);
+    };
+
     const handleFormSubmit = (e: FormEvent) => {
       e.preventDefault();
       const content = editorRef.current?.getContent() || '';
@@ -452,6 +489,15 @@ export const MessageComposer = forwardRef<MessageComposerRef, MessageComposerPro
           onClose={() => setScheduleModalOpen(false)}
           onSchedule={handleSchedule}
         />
+        <ConfirmDialog
+          isOpen={pendingMentionSend !== null}
+          onClose={handleCancelMentions}
+          onConfirm={handleConfirmMentions}
+          title="Notify everyone?"
+          description={`${pendingMentionSend?.reason}. Are you sure you want to send this message?`}
+          confirmLabel="Send"
+          isLoading={activeMutation.isPending}
+        />
       </div>
     );
   },
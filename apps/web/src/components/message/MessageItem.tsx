@@ -334,6 +334,11 @@ export function MessageItem({
               displayName={message.user_display_name || 'Unknown User'}
               onContextMenu={message.user_id ? onUserContextMenu : undefined}
             />
+            {message.type === 'bot' && (
+              <span className="rounded bg-gray-100 px-1 py-0.5 text-[10px] font-semibold text-gray-600 uppercase dark:bg-gray-700 dark:text-gray-400">
+                App
+              </span>
+            )}
             <span className="text-xs text-gray-500 dark:text-gray-400">
               {formatTime(message.created_at)}
             </span>
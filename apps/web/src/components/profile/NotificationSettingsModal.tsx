@@ -0,0 +1,164 @@
+import { useState } from 'react';
+import type { EmailDigest, NotificationSettings } from '@enzyme/api-client';
+import { useNotificationSettings, useUpdateNotificationSettings } from '../../hooks';
+import { Button, Input, Modal, RadioGroup, Radio, Spinner, ToggleButton, toast } from '../ui';
+import { cn } from '../../lib/utils';
+
+const WEEKDAYS = ['Sun', 'Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat'];
+
+interface NotificationSettingsModalProps {
+  isOpen: boolean;
+  onClose: () => void;
+}
+
+export function NotificationSettingsModal({ isOpen, onClose }: NotificationSettingsModalProps) {
+  const { data, isLoading } = useNotificationSettings();
+
+  return (
+    <Modal isOpen={isOpen} onClose={onClose} title="Notification settings">
+      {isLoading || !data ? (
+        <div className="flex justify-center py-8">
+          <Spinner size="lg" />
+        </div>
+      ) : (
+        <NotificationSettingsForm settings={data.settings} onClose={onClose} />
+      )}
+    </Modal>
+  );
+}
+
+interface NotificationSettingsFormProps {
+  settings: NotificationSettings;
+  onClose: () => void;
+}
+
+function NotificationSettingsForm({ settings, onClose }: NotificationSettingsFormProps) {
+  const schedule = settings.schedule;
+  // The form edits one set of hours shared by the selected days
+  const firstWindow = schedule?.windows[0];
+  const [keywords, setKeywords] = useState(settings.highlight_keywords.join(', '));
+  const [mode, setMode] = useState<'always' | 'schedule'>(schedule ? 'schedule' : 'always');
+  const [days, setDays] = useState<number[]>(
+    schedule ? schedule.windows.map((w) => w.day) : [1, 2, 3, 4, 5],
+  );
+  const [start, setStart] = useState(firstWindow?.start ?? '09:00');
+  const [end, setEnd] = useState(firstWindow?.end ?? '17:00');
+  const [emailDigest, setEmailDigest] = useState<EmailDigest>(settings.email_digest ?? 'immediate');
+  const timeZone = schedule?.time_zone ?? Intl.DateTimeFormat().resolvedOptions().timeZone;
+  const updateSettings = useUpdateNotificationSettings();
+
+  const toggleDay = (day: number, selected: boolean) => {
+    setDays((prev) => (selected ? [...new Set([...prev, day])] : prev.filter((d) => d !== day)));
+  };
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (mode === 'schedule' && days.length === 0) {
+      toast('Select at least one day', 'error');
+      return;
+    }
+    try {
+      await updateSettings.mutateAsync({
+        highlight_keywords: keywords
+          .split(',')
+          .map((k) => k.trim())
+          .filter(Boolean),
+        schedule:
+          mode === 'schedule'
+            ? {
+                time_zone: timeZone,
+                windows: [...days].sort((a, b) => a - b).map((day) => ({ day, start, end })),
+              }
+            : undefined,
+        email_digest: emailDigest,
+      });
+      toast('Notification settings saved', 'success');
+      onClose();
+    } catch (err) {
+      toast(err instanceof Error ? err.message : 'Failed to save notification settings', 'error');
+    }
+  };
+
+  return (
+    <form onSubmit={handleSubmit} className="space-y-5">
+      <div>
+        <Input
+          label="Highlight keywords"
+          value={keywords}
+          onChange={(e) => setKeywords(e.target.value)}
+          placeholder="outage, release"
+        />
+        <p className="mt-1 text-xs text-gray-500 dark:text-gray-400">
+          Separate with commas. You'll be notified when these words appear in your channels, as if
+          you were mentioned.
+        </p>
+      </div>
+
+      <RadioGroup
+        label="Send push and email notifications"
+        value={mode}
+        onChange={(value) => setMode(value as 'always' | 'schedule')}
+      >
+        <Radio value="always">At any time</Radio>
+        <Radio value="schedule">Only during working hours</Radio>
+      </RadioGroup>
+
+      {mode === 'schedule' && (
+        <div className="space-y-3">
+          <div className="flex gap-1">
+            {WEEKDAYS.map((label, day) => (
+              <ToggleButton
+                key={label}
+                isSelected={days.includes(day)}
+                onChange={(selected) => toggleDay(day, selected)}
+                className={({ isSelected }) =>
+                  cn(
+                    'flex-1 rounded-md border px-2 py-1 text-xs font-medium',
+                    isSelected
+                      ? 'border-blue-500 bg-blue-50 text-blue-700 dark:bg-blue-900/30 dark:text-blue-300'
+                      : 'border-gray-300 text-gray-600 dark:border-gray-600 dark:text-gray-300',
+                  )
+                }
+              >
+                {label}
+              </ToggleButton>
+            ))}
+          </div>
+          <div className="flex gap-3">
+            <Input
+              label="From"
+              type="time"
+              value={start}
+              onChange={(e) => setStart(e.target.value)}
+            />
+            <Input label="To" type="time" value={end} onChange={(e) => setEnd(e.target.value)} />
+          </div>
+          <p className="text-xs text-gray-500 dark:text-gray-400">
+            Times are in {timeZone}. Outside these hours, notifications still show in the app and
+            are sent to your devices when your hours start.
+          </p>
+        </div>
+      )}
+
+      <RadioGroup
+        label="Email me about missed notifications"
+        value={emailDigest}
+        onChange={(value) => setEmailDigest(value as EmailDigest)}
+      >
+        <Radio value="immediate">A few minutes after they arrive</Radio>
+        <Radio value="hourly">In an hourly digest</Radio>
+        <Radio value="daily">In a daily digest at 9:00</Radio>
+        <Radio value="never">Never</Radio>
+      </RadioGroup>
+
+      <div className="flex justify-end gap-2">
+        <Button type="button" variant="secondary" onPress={onClose}>
+          Cancel
+        </Button>
+        <Button type="submit" isLoading={updateSettings.isPending}>
+          Save
+        </Button>
+      </div>
+    </form>
+  );
+}
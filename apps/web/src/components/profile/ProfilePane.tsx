@@ -20,6 +20,7 @@ import { useBlocks, useBlockUser, useUnblockUser } from '../../hooks/useModerati
 import { useWorkspaceMembers } from '../../hooks/useWorkspaces';
 import { Button, UnstyledButton, IconButton, Input, Modal, Spinner, Tooltip, toast } from '../ui';
 import { cn } from '../../lib/utils';
+import { NotificationSettingsModal } from './NotificationSettingsModal';
 import { getInitials, getAvatarColor } from '@enzyme/shared';
 import { useUserPresence } from '../../lib/presenceStore';
 
@@ -104,6 +105,7 @@ function ViewProfile({ profile, isOwnProfile, onEdit }: ViewProfileProps) {
   const blockUserMutation = useBlockUser(workspaceId || '');
   const unblockUserMutation = useUnblockUser(workspaceId || '');
   const [showBlockConfirm, setShowBlockConfirm] = useState(false);
+  const [showNotificationSettings, setShowNotificationSettings] = useState(false);
   const isBlocked = blocksData?.blocks?.some((b) => b.blocked_id === profile.id) ?? false;
   const targetMember = membersData?.members?.find((m) => m.user_id === profile.id);
   const targetRole = targetMember?.role;
@@ -204,9 +206,22 @@ function ViewProfile({ profile, isOwnProfile, onEdit }: ViewProfileProps) {
 
       {/* Edit button (only for own profile) */}
       {isOwnProfile && (
-        <Button variant="secondary" className="w-full" onPress={onEdit}>
-          Edit Profile
-        </Button>
+        <div className="space-y-2">
+          <Button variant="secondary" className="w-full" onPress={onEdit}>
+            Edit Profile
+          </Button>
+          <Button
+            variant="secondary"
+            className="w-full"
+            onPress={() => setShowNotificationSettings(true)}
+          >
+            Notification Settings
+          </Button>
+          <NotificationSettingsModal
+            isOpen={showNotificationSettings}
+            onClose={() => setShowNotificationSettings(false)}
+          />
+        </div>
       )}
 
       {/* Block button (only for other profiles, hidden for banned users) */}
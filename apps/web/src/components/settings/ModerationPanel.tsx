@@ -352,6 +352,22 @@ function formatAction(action: string): string {
       return 'deleted a message';
     case 'channel.archived':
       return 'archived a channel';
+    case 'member.added':
+      return 'added a member';
+    case 'user.password_reset':
+      return "reset a user's password";
+    case 'user.email_verified':
+      return "verified a user's email";
+    case 'user.disabled':
+      return 'disabled a user';
+    case 'user.enabled':
+      return 'enabled a user';
+    case 'user.sessions_revoked':
+      return 'signed a user out everywhere';
+    case 'user.site_admin_granted':
+      return 'made a user a site admin';
+    case 'user.site_admin_revoked':
+      return "removed a user's site admin role";
     default:
       return action;
   }
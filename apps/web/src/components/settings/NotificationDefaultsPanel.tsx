@@ -0,0 +1,155 @@
+import type { NotificationDefaults, NotifyLevel } from '@enzyme/api-client';
+import {
+  useWorkspaceNotificationPreferences,
+  useUpdateNotificationDefaults,
+  useWorkspaceNotificationDefaults,
+  useUpdateWorkspaceNotificationDefaults,
+} from '../../hooks';
+import { Button, RadioGroup, Radio, Spinner, ToggleButton, toast } from '../ui';
+import { cn } from '../../lib/utils';
+
+interface NotificationDefaultsPanelProps {
+  workspaceId: string;
+  canManage: boolean;
+}
+
+export function NotificationDefaultsPanel({
+  workspaceId,
+  canManage,
+}: NotificationDefaultsPanelProps) {
+  const { data, isLoading } = useWorkspaceNotificationPreferences(workspaceId);
+  const updateDefaults = useUpdateNotificationDefaults(workspaceId);
+  const { data: workspaceData } = useWorkspaceNotificationDefaults(
+    canManage ? workspaceId : undefined,
+  );
+  const updateWorkspaceDefaults = useUpdateWorkspaceNotificationDefaults(workspaceId);
+
+  const onError = () => toast('Failed to update notification defaults', 'error');
+
+  if (isLoading || !data) {
+    return (
+      <div className="flex justify-center py-8">
+        <Spinner size="md" />
+      </div>
+    );
+  }
+
+  return (
+    <div className="space-y-8">
+      <section className="space-y-4">
+        <div>
+          <h3 className="text-sm font-medium text-gray-900 dark:text-white">Your defaults</h3>
+          <p className="mt-1 text-sm text-gray-600 dark:text-gray-400">
+            Channels use these unless you've changed notifications for the channel itself.
+            {!data.defaults_customized && " You're using the workspace's defaults."}
+          </p>
+        </div>
+        <DefaultsForm
+          defaults={data.defaults}
+          onChange={(defaults) => updateDefaults.mutate(defaults, { onError })}
+        />
+        {data.defaults_customized && (
+          <Button
+            variant="outline"
+            size="sm"
+            onPress={() => updateDefaults.mutate(null, { onError })}
+            isDisabled={updateDefaults.isPending}
+          >
+            Use workspace defaults
+          </Button>
+        )}
+      </section>
+
+      {canManage && workspaceData && (
+        <section className="space-y-4 border-t border-gray-200 pt-6 dark:border-gray-700">
+          <div>
+            <h3 className="text-sm font-medium text-gray-900 dark:text-white">
+              Defaults for members
+            </h3>
+            <p className="mt-1 text-sm text-gray-600 dark:text-gray-400">
+              Apply to members who haven't set their own, including new members.
+            </p>
+          </div>
+          <DefaultsForm
+            defaults={workspaceData.defaults}
+            onChange={(defaults) => updateWorkspaceDefaults.mutate(defaults, { onError })}
+          />
+        </section>
+      )}
+    </div>
+  );
+}
+
+function DefaultsForm({
+  defaults,
+  onChange,
+}: {
+  defaults: NotificationDefaults;
+  onChange: (defaults: NotificationDefaults) => void;
+}) {
+  const set = (patch: Partial<NotificationDefaults>) => onChange({ ...defaults, ...patch });
+
+  return (
+    <div className="space-y-3">
+      <RadioGroup
+        label="Notify about"
+        value={defaults.notify_level}
+        onChange={(value) => set({ notify_level: value as NotifyLevel })}
+      >
+        <Radio value="all">All messages</Radio>
+        <Radio value="mentions">Mentions and keywords</Radio>
+        <Radio value="none">Nothing</Radio>
+      </RadioGroup>
+      <SettingToggle
+        label="Email when away"
+        isSelected={defaults.email_enabled}
+        onChange={(email_enabled) => set({ email_enabled })}
+      />
+      <SettingToggle
+        label="Push notifications"
+        isSelected={defaults.push_enabled}
+        onChange={(push_enabled) => set({ push_enabled })}
+      />
+      <SettingToggle
+        label="Only push to mobile when inactive elsewhere"
+        isSelected={defaults.mobile_only_when_idle}
+        isDisabled={!defaults.push_enabled}
+        onChange={(mobile_only_when_idle) => set({ mobile_only_when_idle })}
+      />
+    </div>
+  );
+}
+
+function SettingToggle({
+  label,
+  isSelected,
+  isDisabled,
+  onChange,
+}: {
+  label: string;
+  isSelected: boolean;
+  isDisabled?: boolean;
+  onChange: (isSelected: boolean) => void;
+}) {
+  return (
+    <div className="flex items-center justify-between gap-4">
+      <span className="text-sm text-gray-700 dark:text-gray-300">{label}</span>
+      <ToggleButton
+        aria-label={label}
+        isSelected={isSelected}
+        isDisabled={isDisabled}
+        onChange={onChange}
+        className={({ isSelected }) =>
+          cn(
+            'w-12 rounded-md border px-2 py-1 text-xs font-medium disabled:opacity-50',
+            isSelected
+              ? 'border-blue-500 bg-blue-50 text-blue-700 dark:bg-blue-900/30 dark:text-blue-300'
+              : 'border-gray-300 text-gray-600 dark:border-gray-600 dark:text-gray-300',
+          )
+        }
+      >
+        {isSelected ? 'On' : 'Off'}
+      </ToggleButton>
+    </div>
+  );
+}
@@ -12,6 +12,7 @@ import {
   XMarkIcon,
   NoSymbolIcon,
   KeyIcon,
+  BellIcon,
 } from '@heroicons/react/24/outline';
 import {
   useWorkspace,
@@ -43,6 +44,7 @@ import {
 import { useBlocks, useBlockUser, useUnblockUser } from '../../hooks/useModeration';
 import { CustomEmojiManager } from './CustomEmojiManager';
 import { ModerationPanel } from './ModerationPanel';
+import { NotificationDefaultsPanel } from './NotificationDefaultsPanel';
 import { cn } from '../../lib/utils';
 import { getAvatarColor, hasPermission } from '@enzyme/shared';
 import type { WorkspaceRole, PermissionLevel } from '@enzyme/api-client';
@@ -51,6 +53,7 @@ export type WorkspaceSettingsTab =
   | 'general'
   | 'members'
   | 'emoji'
+  | 'notifications'
   | 'invite'
   | 'permissions'
   | 'moderation';
@@ -66,6 +69,7 @@ const navItems: NavItem[] = [
   { id: 'general', label: 'Workspace Settings', icon: Cog6ToothIcon },
   { id: 'members', label: 'Manage Members', icon: UsersIcon },
   { id: 'emoji', label: 'Custom Emoji', icon: FaceSmileIcon },
+  { id: 'notifications', label: 'Notifications', icon: BellIcon },
   { id: 'invite', label: 'Invite People', icon: UserPlusIcon, adminOnly: true },
   { id: 'permissions', label: 'Permissions', icon: KeyIcon, adminOnly: true },
   { id: 'moderation', label: 'Moderation', icon: ShieldExclamationIcon, adminOnly: true },
@@ -692,6 +696,10 @@ export function WorkspaceSettingsModal({
 
               {selectedTab === 'emoji' && <CustomEmojiManager workspaceId={workspaceId} />}
 
+              {selectedTab === 'notifications' && (
+                <NotificationDefaultsPanel workspaceId={workspaceId} canManage={canManage} />
+              )}
+
               {selectedTab === 'invite' && canInvite && (
                 <div className="space-y-6 rounded-lg bg-gray-50 p-6 dark:bg-gray-800">
                   <p className="text-gray-600 dark:text-gray-300">
@@ -804,6 +812,49 @@ export function WorkspaceSettingsModal({
                       )
                     }
                   />
+
+                  <PermissionSelect
+                    label="Who can use @here"
+                    value={parsedSettings?.who_can_mention_here ?? 'members'}
+                    onChange={(value) =>
+                      updateWorkspace.mutate(
+                        { settings: { who_can_mention_here: value } },
+                        { onError: () => toast('Failed to update permission', 'error') },
+                      )
+                    }
+                  />
+
+                  <PermissionSelect
+                    label="Who can use @channel"
+                    value={parsedSettings?.who_can_mention_channel ?? 'members'}
+                    onChange={(value) =>
+                      updateWorkspace.mutate(
+                        { settings: { who_can_mention_channel: value } },
+                        { onError: () => toast('Failed to update permission', 'error') },
+                      )
+                    }
+                  />
+
+                  <PermissionSelect
+                    label="Who can use @everyone"
+                    value={parsedSettings?.who_can_mention_everyone ?? 'admins'}
+                    onChange={(value) =>
+                      updateWorkspace.mutate(
+                        { settings: { who_can_mention_everyone: value } },
+                        { onError: () => toast('Failed to update permission', 'error') },
+                      )
+                    }
+                  />
+
+                  <MentionThresholdSelect
+                    value={parsedSettings?.mention_confirm_threshold ?? 50}
+                    onChange={(value) =>
+                      updateWorkspace.mutate(
+                        { settings: { mention_confirm_threshold: value } },
+                        { onError: () => toast('Failed to update setting', 'error') },
+                      )
+                    }
+                  />
                 </div>
               )}
 
@@ -928,3 +979,50 @@ function PermissionSelect({
     </Select>
   );
 }
+
+const mentionThresholds = [0, 10, 25, 50, 100, 250, 500];
+
+function MentionThresholdSelect({
+  value,
+  onChange,
+}: {
+  value: number;
+  onChange: (value: number) => void;
+}) {
+  // Keep a threshold set through the API selectable
+  const items = mentionThresholds.includes(value)
+    ? mentionThresholds
+    : [...mentionThresholds, value].sort((a, b) => a - b);
+
+  return (
+    <Select
+      selectedKey={String(value)}
+      onSelectionChange={(key: Key | null) => key !== null && onChange(Number(key))}
+      className="max-w-xs"
+    >
+      <Label className="mb-1 block text-sm font-medium text-gray-700 dark:text-gray-300">
+        Confirm @here, @channel and @everyone when they notify
+      </Label>
+      <UnstyledButton className="flex w-full items-center justify-between rounded-lg border border-gray-300 bg-white px-3 py-2 text-sm text-gray-900 dark:border-gray-600 dark:bg-gray-700 dark:text-white">
+        <SelectValue />
+        <ChevronDownIcon className="h-4 w-4 text-gray-500" />
+      </UnstyledButton>
+      <Popover
+        offset={4}
+        className="w-(--trigger-width) rounded-lg border border-gray-200 bg-white shadow-lg dark:border-gray-700 dark:bg-gray-800"
+      >
+        <ListBox className="py-1 outline-none">
+          {items.map((threshold) => (
+            <ListBoxItem
+              key={threshold}
+              id={String(threshold)}
+              className="cursor-pointer px-3 py-1.5 text-sm text-gray-700 outline-none focus:bg-gray-100 dark:text-gray-200 dark:focus:bg-gray-700"
+            >
+              {threshold === 0 ? 'Never' : `More than ${threshold} people`}
+            </ListBoxItem>
+          ))}
+        </ListBox>
+      </Popover>
+    </Select>
+  );
+}
@@ -51,6 +51,7 @@ import {
 import { useDarkMode } from '../../hooks/useDarkMode';
 import { useProfilePanel } from '../../hooks/usePanel';
 import { cn } from '../../lib/utils';
+import { unsubscribeFromWebPush } from '../../lib/webPush';
 import { getAvatarColor } from '@enzyme/shared';
 import type { WorkspaceSummary, WorkspaceNotificationSummary } from '@enzyme/api-client';
 import { WorkspaceContextMenu } from './WorkspaceContextMenu';
@@ -342,6 +343,8 @@ function UserMenu() {
 
   const handleLogout = async () => {
     try {
+      // Must run while still signed in to unregister on the server
+      await unsubscribeFromWebPush().catch(() => {});
       await logout();
       navigate('/login');
     } catch {
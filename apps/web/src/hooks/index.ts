@@ -26,7 +26,14 @@ export {
   useAddReaction,
   useRemoveReaction,
 } from './useMessages';
-export { useUserProfile, useUpdateProfile, useUploadAvatar, useDeleteAvatar } from './useProfile';
+export {
+  useUserProfile,
+  useUpdateProfile,
+  useUploadAvatar,
+  useDeleteAvatar,
+  useNotificationSettings,
+  useUpdateNotificationSettings,
+} from './useProfile';
 export { useSSE } from './useSSE';
 export { useTyping } from './useTyping';
 export { useUploadFile } from './useFiles';
@@ -41,7 +48,17 @@ export {
   useUnsubscribeFromThread,
 } from './useThreadSubscription';
 export { useMentions } from './useMentions';
-export { useChannelNotifications, useUpdateChannelNotifications } from './useChannelNotifications';
+export {
+  useChannelNotifications,
+  useUpdateChannelNotifications,
+  useResetChannelNotifications,
+  useChannelNotificationDefaults,
+  useUpdateChannelNotificationDefaults,
+  useWorkspaceNotificationPreferences,
+  useUpdateNotificationDefaults,
+  useWorkspaceNotificationDefaults,
+  useUpdateWorkspaceNotificationDefaults,
+} from './useChannelNotifications';
 export { useAutoFocusComposer } from './useAutoFocusComposer';
 export { useUserThreads, useMarkThreadRead } from './useThreads';
 export {
@@ -8,6 +8,7 @@ import type { NotificationPreferences } from '@enzyme/api-client';
 const mockChannelsApi = vi.hoisted(() => ({
   getNotifications: vi.fn(),
   updateNotifications: vi.fn(),
+  resetNotifications: vi.fn(),
 }));
 
 vi.mock('@enzyme/api-client', async (importOriginal) => {
@@ -15,7 +16,11 @@ vi.mock('@enzyme/api-client', async (importOriginal) => {
   return { ...original, channelsApi: mockChannelsApi };
 });
 
-import { useChannelNotifications, useUpdateChannelNotifications } from './useChannelNotifications';
+import {
+  useChannelNotifications,
+  useResetChannelNotifications,
+  useUpdateChannelNotifications,
+} from './useChannelNotifications';
 
 function createTestQueryClient() {
   return new QueryClient({
@@ -163,3 +168,38 @@ describe('useUpdateChannelNotifications', () => {
     );
   });
 });
+
+describe('useResetChannelNotifications', () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  it('replaces cached preferences with the inherited ones', async () => {
+    // Keep unobserved queries cached so the result can be read back
+    const queryClient = new QueryClient({ defaultOptions: { queries: { gcTime: Infinity } } });
+    const inherited: NotificationPreferences = {
+      notify_level: 'mentions',
+      email_enabled: true,
+      push_enabled: true,
+      inherited: true,
+    };
+    mockChannelsApi.resetNotifications.mockResolvedValue({ preferences: inherited });
+
+    queryClient.setQueryData(['channel-notifications', 'ch-1'], {
+      preferences: { notify_level: 'none', email_enabled: false, inherited: false },
+    });
+
+    const { result } = renderHook(() => useResetChannelNotifications('ch-1'), {
+      wrapper: createWrapper(queryClient),
+    });
+
+    await act(async () => {
+      await result.current.mutateAsync();
+    });
+
+    expect(mockChannelsApi.resetNotifications).toHaveBeenCalledWith('ch-1');
+    expect(queryClient.getQueryData(['channel-notifications', 'ch-1'])).toEqual({
+      preferences: inherited,
+    });
+  });
+});
@@ -1 +1,11 @@
-export { useChannelNotifications, useUpdateChannelNotifications } from '@enzyme/shared';
+export {
+  useChannelNotifications,
+  useUpdateChannelNotifications,
+  useResetChannelNotifications,
+  useChannelNotificationDefaults,
+  useUpdateChannelNotificationDefaults,
+  useWorkspaceNotificationPreferences,
+  useUpdateNotificationDefaults,
+  useWorkspaceNotificationDefaults,
+  useUpdateWorkspaceNotificationDefaults,
+} from '@enzyme/shared';
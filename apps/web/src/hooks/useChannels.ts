@@ -13,4 +13,10 @@ export {
   useStarChannel,
   useUnstarChannel,
   useConvertGroupDMToChannel,
+  useChannelMentionPermissions,
+  useUpdateChannelMentionPermissions,
+  useChannelEmailAddress,
+  useUpdateChannelEmailAddress,
+  useRotateChannelEmailAddress,
+  useDeleteChannelEmailAddress,
 } from '@enzyme/shared';
@@ -1 +1,8 @@
-export { useUserProfile, useUpdateProfile, useUploadAvatar, useDeleteAvatar } from '@enzyme/shared';
+export {
+  useUserProfile,
+  useUpdateProfile,
+  useUploadAvatar,
+  useDeleteAvatar,
+  useNotificationSettings,
+  useUpdateNotificationSettings,
+} from '@enzyme/shared';
@@ -8,6 +8,8 @@ import {
   unlockAudio,
   requestNotificationPermission,
 } from '../lib/notificationSound';
+import { subscribeToWebPush } from '../lib/webPush';
+import { useServerInfo } from './useServerInfo';
 import { toast } from '../components/ui';
 import type { NotificationData } from '@enzyme/api-client';
 import {
@@ -49,10 +51,21 @@ export function useSSE(workspaceId: string | undefined) {
   const queryClient = useQueryClient();
   const navigate = useNavigate();
   const navigateRef = useRef(navigate);
+  const { webPushPublicKey } = useServerInfo();
   useEffect(() => {
     navigateRef.current = navigate;
   }, [navigate]);
 
+  // Subscribe to Web Push so notifications arrive with no tab open
+  useEffect(() => {
+    if (!webPushPublicKey || !('Notification' in window) || Notification.permission === 'denied') {
+      return;
+    }
+    requestNotificationPermission()
+      .then((granted) => (granted ? subscribeToWebPush(webPushPublicKey) : undefined))
+      .catch((err) => console.warn('Web Push subscription failed', err));
+  }, [webPushPublicKey]);
+
   useEffect(() => {
     if (!workspaceId) {
       return;
@@ -270,6 +283,8 @@ function getNotificationTitle(notification: NotificationData): string {
       return `${sender} in ${prefix} (@everyone)`;
     case 'thread_reply':
       return `${sender} replied to a thread in ${prefix}`;
+    case 'keyword':
+      return `${sender} mentioned a keyword in ${prefix}`;
     default:
       return `New message from ${sender}`;
   }
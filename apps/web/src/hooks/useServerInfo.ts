@@ -13,5 +13,7 @@ export function useServerInfo() {
   return {
     emailEnabled: data?.email_enabled ?? true,
     filesEnabled: data?.files_enabled ?? true,
+    webPushPublicKey: data?.web_push_public_key,
+    inboundEmailEnabled: data?.inbound_email_enabled ?? false,
   };
 }
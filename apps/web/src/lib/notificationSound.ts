@@ -79,6 +79,9 @@ function playTone(
   oscillator.stop(startTime + duration);
 }
 
+// Shared so concurrent callers wait on a single permission prompt
+let pendingPermission: Promise<boolean> | null = null;
+
 // Request browser notification permission
 export async function requestNotificationPermission(): Promise<boolean> {
   if (!('Notification' in window)) {
@@ -93,8 +96,11 @@ export async function requestNotificationPermission(): Promise<boolean> {
     return false;
   }
 
-  const permission = await Notification.requestPermission();
-  return permission === 'granted';
+  pendingPermission ??= Notification.requestPermission().then((permission) => {
+    pendingPermission = null;
+    return permission === 'granted';
+  });
+  return pendingPermission;
 }
 
 // Show browser notification
@@ -0,0 +1,77 @@
+import { authApi } from '@enzyme/api-client';
+
+const SUBSCRIPTION_ID_KEY = 'enzyme:web-push-subscription-id';
+
+export function isWebPushSupported(): boolean {
+  return 'serviceWorker' in navigator && 'PushManager' in window && 'Notification' in window;
+}
+
+// Decode the server's base64url VAPID public key for PushManager.subscribe
+function decodeBase64Url(value: string): Uint8Array<ArrayBuffer> {
+  const base64 = value.replace(/-/g, '+').replace(/_/g, '/');
+  const raw = atob(base64.padEnd(Math.ceil(base64.length / 4) * 4, '='));
+  const bytes = new Uint8Array(raw.length);
+  for (let i = 0; i < raw.length; i++) {
+    bytes[i] = raw.charCodeAt(i);
+  }
+  return bytes;
+}
+
+function sameKey(a: ArrayBuffer | null, b: Uint8Array): boolean {
+  if (!a || a.byteLength !== b.byteLength) {
+    return false;
+  }
+  const view = new Uint8Array(a);
+  return view.every((byte, i) => byte === b[i]);
+}
+
+// Subscribe this browser to Web Push and register the subscription with the
+// server. Requires notification permission; does nothing without it.
+export async function subscribeToWebPush(publicKey: string): Promise<void> {
+  if (!isWebPushSupported() || Notification.permission !== 'granted') {
+    return;
+  }
+
+  const registration = await navigator.serviceWorker.register('/sw.js');
+  const applicationServerKey = decodeBase64Url(publicKey);
+
+  let subscription = await registration.pushManager.getSubscription();
+  if (subscription && !sameKey(subscription.options.applicationServerKey, applicationServerKey)) {
+    // The server's keys changed, so the old subscription can't be used
+    await subscription.unsubscribe();
+    subscription = null;
+  }
+  if (!subscription) {
+    subscription = await registration.pushManager.subscribe({
+      userVisibleOnly: true,
+      applicationServerKey,
+    });
+  }
+
+  const { endpoint, keys } = subscription.toJSON();
+  if (!endpoint || !keys?.p256dh || !keys?.auth) {
+    return;
+  }
+
+  const { id } = await authApi.registerWebPushSubscription({
+    endpoint,
+    keys: { p256dh: keys.p256dh, auth: keys.auth },
+  });
+  localStorage.setItem(SUBSCRIPTION_ID_KEY, id);
+}
+
+// Remove this browser's subscription, e.g. on logout, so notifications stop
+// arriving for the signed-out user.
+export async function unsubscribeFromWebPush(): Promise<void> {
+  const id = localStorage.getItem(SUBSCRIPTION_ID_KEY);
+  localStorage.removeItem(SUBSCRIPTION_ID_KEY);
+
+  if (isWebPushSupported()) {
+    const registration = await navigator.serviceWorker.getRegistration();
+    const subscription = await registration?.pushManager.getSubscription();
+    await subscription?.unsubscribe();
+  }
+  if (id) {
+    await authApi.unregisterWebPushSubscription(id);
+  }
+}
@@ -239,6 +239,11 @@ export function ChannelPage() {
     channel.type !== 'group_dm' &&
     channel.channel_role !== undefined;
   const isChannel = channel?.type === 'public' || channel?.type === 'private';
+  const canManageEmail =
+    isChannel &&
+    (workspaceMembership?.role === 'admin' ||
+      workspaceMembership?.role === 'owner' ||
+      channel?.channel_role === 'admin');
   const isMuted = notifData?.preferences?.notify_level === 'none';
 
   const handleToggleMute = () => {
@@ -581,6 +586,7 @@ export function ChannelPage() {
         channel={channel}
         canAddMembers={canAddMembers}
         canEditChannel={!!canEditChannel}
+        canManageEmail={canManageEmail}
         defaultTab={detailsModalTab}
       />
     </div>
@@ -0,0 +1,91 @@
+import { Link, useSearchParams } from 'react-router-dom';
+import { useMutation } from '@tanstack/react-query';
+import { usePageTitle } from '../hooks';
+import { Button } from '../components/ui';
+import { usersApi, ApiError } from '@enzyme/api-client';
+
+function CenteredLayout({ children }: { children: React.ReactNode }) {
+  return (
+    <div className="flex min-h-screen items-center justify-center bg-gray-50 px-4 dark:bg-gray-900">
+      <div className="w-full max-w-md">{children}</div>
+    </div>
+  );
+}
+
+export function UnsubscribePage() {
+  usePageTitle('Unsubscribe');
+
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token');
+
+  // Unsubscribing waits for a click, since mail scanners open links in emails
+  const unsubscribe = useMutation({
+    mutationFn: (t: string) => usersApi.unsubscribeFromEmail(t),
+  });
+
+  if (!token) {
+    return (
+      <CenteredLayout>
+        <div className="mb-8 text-center">
+          <h1 className="text-3xl font-bold text-gray-900 dark:text-white">
+            Invalid unsubscribe link
+          </h1>
+          <p className="mt-2 text-gray-600 dark:text-gray-400">This unsubscribe link is invalid.</p>
+        </div>
+        <p className="text-center text-sm text-gray-600 dark:text-gray-400">
+          <Link to="/login" className="font-medium text-blue-600 hover:text-blue-700">
+            Go to login
+          </Link>
+        </p>
+      </CenteredLayout>
+    );
+  }
+
+  if (unsubscribe.isSuccess) {
+    return (
+      <CenteredLayout>
+        <div className="mb-8 text-center">
+          <h1 className="text-3xl font-bold text-gray-900 dark:text-white">Unsubscribed</h1>
+          <p className="mt-2 text-gray-600 dark:text-gray-400">
+            You won't get notification emails anymore. You can turn them back on in your
+            notification settings.
+          </p>
+        </div>
+        <p className="text-center text-sm text-gray-600 dark:text-gray-400">
+          <Link to="/login" className="font-medium text-blue-600 hover:text-blue-700">
+            Go to login
+          </Link>
+        </p>
+      </CenteredLayout>
+    );
+  }
+
+  const errorMessage = unsubscribe.isError
+    ? unsubscribe.error instanceof ApiError
+      ? unsubscribe.error.message
+      : 'An error occurred. Please try again.'
+    : null;
+
+  return (
+    <CenteredLayout>
+      <div className="mb-8 text-center">
+        <h1 className="text-3xl font-bold text-gray-900 dark:text-white">
+          Unsubscribe from emails
+        </h1>
+        <p className="mt-2 text-gray-600 dark:text-gray-400">
+          Stop all notification emails from Enzyme. You'll still be notified in the app.
+        </p>
+      </div>
+      {errorMessage && (
+        <p className="mb-4 text-center text-sm text-red-600 dark:text-red-400">{errorMessage}</p>
+      )}
+      <Button
+        className="w-full"
+        isLoading={unsubscribe.isPending}
+        onPress={() => unsubscribe.mutate(token)}
+      >
+        Unsubscribe
+      </Button>
+    </CenteredLayout>
+  );
+}
@@ -195,7 +195,7 @@ export interface paths {
         put?: never;
         /**
          * Register a device token for push notifications
-         * @description Register a device token for push notifications (FCM for Android, APNs for iOS). Each token is associated with the current user and session. Re-registering an existing token updates its association.
+         * @description Register a device token for push notifications (FCM for Android, APNs for iOS, or a UnifiedPush distributor endpoint). Each token is associated with the current user and session. Re-registering an existing token updates its association. Returns PLATFORM_NOT_ENABLED if the server doesn't deliver to the platform.
          */
         post: operations["registerDeviceToken"];
         delete?: never;
@@ -224,6 +224,46 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
+    "/auth/web-push-subscriptions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Register a browser for Web Push notifications
+         * @description Register a browser's push subscription, as returned by `PushManager.subscribe` with the server's `web_push_public_key` from `/server-info`. The server then delivers notifications for the current user to the browser's push service directly, without the push relay. Re-registering an endpoint updates it and moves it to the current user.
+         */
+        post: operations["registerWebPushSubscription"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/auth/web-push-subscriptions/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * Unregister a browser from Web Push notifications
+         * @description Remove a previously registered push subscription to stop receiving notifications in that browser.
+         */
+        delete: operations["unregisterWebPushSubscription"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/workspaces/create": {
         parameters: {
             query?: never;
@@ -706,6 +746,178 @@ export interface paths {
          * @description Set notification preferences for a specific channel. Overrides the workspace-level notification defaults for this channel only.
          */
         post: operations["updateChannelNotifications"];
+        /**
+         * Reset channel notification preferences
+         * @description Remove the current user's notification preferences for a channel, so it inherits the channel's default notify level and the user's workspace defaults again. Returns the preferences that now apply.
+         */
+        delete: operations["resetChannelNotifications"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/channels/{id}/notification-defaults": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get channel default notify level
+         * @description Get the notify level members of a channel inherit unless they've set their own preferences for it. `notify_level` is omitted when the channel follows each member's workspace defaults.
+         */
+        get: operations["getChannelNotificationDefaults"];
+        put?: never;
+        /**
+         * Set channel default notify level
+         * @description Set the notify level members of a channel inherit, for example `all` for #announcements. Members' own preferences for the channel still take precedence. Not available for DMs. Requires admin or owner role.
+         */
+        post: operations["updateChannelNotificationDefaults"];
+        /**
+         * Remove channel default notify level
+         * @description Remove a channel's default notify level, so its members follow their workspace defaults. Requires admin or owner role.
+         */
+        delete: operations["deleteChannelNotificationDefaults"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/channels/{id}/mention-permissions": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get channel mention permissions
+         * @description Get a channel's overrides of who may use @here, @channel and @everyone. Omitted fields follow the workspace settings.
+         */
+        get: operations["getChannelMentionPermissions"];
+        put?: never;
+        /**
+         * Set channel mention permissions
+         * @description Replace a channel's overrides of who may use @here, @channel and @everyone. Omitted fields follow the workspace settings, so an empty object removes all overrides. Requires admin or owner role.
+         */
+        post: operations["updateChannelMentionPermissions"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/channels/{id}/email-address": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get channel email address
+         * @description Get the secret address that posts mail sent to it into the channel. Requires a workspace admin or a channel admin.
+         */
+        get: operations["getChannelEmailAddress"];
+        put?: never;
+        /**
+         * Set channel email address
+         * @description Give the channel an email address, or update the senders its existing address accepts mail from. Requires a workspace admin or a channel admin, and inbound email to be enabled on the server.
+         */
+        post: operations["updateChannelEmailAddress"];
+        /**
+         * Remove channel email address
+         * @description Remove the channel's email address. Mail sent to it is rejected afterwards. Requires a workspace admin or a channel admin.
+         */
+        delete: operations["deleteChannelEmailAddress"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/channels/{id}/email-address/rotate": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Rotate channel email address
+         * @description Replace the channel's email address with a new one, for when the address has leaked. Mail sent to the previous address is rejected. Requires a workspace admin or a channel admin.
+         */
+        post: operations["rotateChannelEmailAddress"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/workspaces/{wid}/notification-preferences": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get notification preferences for all channels
+         * @description Get the current user's notification defaults for a workspace and the effective preferences for every channel they belong to in it, with inheritance already resolved.
+         */
+        get: operations["getWorkspaceNotificationPreferences"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/workspaces/{wid}/notification-preferences/defaults": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Set notification defaults
+         * @description Set the current user's notification defaults for a workspace. Channels without their own preferences inherit them.
+         */
+        post: operations["updateNotificationDefaults"];
+        /**
+         * Reset notification defaults
+         * @description Remove the current user's notification defaults for a workspace, so they follow the workspace's defaults again. Returns the defaults that now apply.
+         */
+        delete: operations["resetNotificationDefaults"];
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/workspaces/{wid}/notification-defaults": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get workspace notification defaults
+         * @description Get the notification defaults for members of the workspace who haven't set their own.
+         */
+        get: operations["getWorkspaceNotificationDefaults"];
+        put?: never;
+        /**
+         * Set workspace notification defaults
+         * @description Set the notification defaults for members of the workspace who haven't set their own, including new members. Requires admin or owner role.
+         */
+        post: operations["updateWorkspaceNotificationDefaults"];
         delete?: never;
         options?: never;
         head?: never;
@@ -824,6 +1036,8 @@ export interface paths {
         /**
          * Send a message
          * @description Send a new message to a channel. Supports plain text content, file attachments (by referencing previously uploaded file IDs), and threading (by setting a parent message ID). The sender must be a member of the channel.
+         *
+         *     Using @here, @channel or @everyone requires the permission set by the workspace or channel. In public channels @everyone notifies every workspace member; elsewhere it notifies the channel's members.
          */
         post: operations["sendMessage"];
         delete?: never;
@@ -1492,6 +1706,50 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
+    "/users/me/notification-settings": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get notification settings
+         * @description Get the current user's notification settings that apply across all channels: highlight keywords, the working-hours schedule and how often notification emails are sent.
+         */
+        get: operations["getNotificationSettings"];
+        put?: never;
+        /**
+         * Update notification settings
+         * @description Replace the current user's notification settings. Highlight keywords notify you when they appear as whole words in a channel you belong to, as if you were mentioned. Outside the schedule's windows, push and email notifications are held until the next window opens; in-app notifications are still shown. Omit `schedule` to be notified at any time. Omit `email_digest` to keep the current frequency.
+         */
+        post: operations["updateNotificationSettings"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/email/unsubscribe": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Unsubscribe from notification emails
+         * @description Stop all notification emails for the user identified by a signed token from the unsubscribe link of a notification email. Sets their `email_digest` to `never`. Does not require authentication, and supports one-click unsubscribe (RFC 8058): any request body is ignored.
+         */
+        post: operations["unsubscribeFromEmail"];
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/workspaces/{wid}/icon": {
         parameters: {
             query?: never;
@@ -1709,6 +1967,28 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
+    "/workspaces/{wid}/ws": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * WebSocket event stream
+         * @description Open a WebSocket carrying the same events as the SSE stream, one JSON event per text frame. The client's first frame must be `{"type": "hello"}`, with an optional `last_event_id` to replay missed events. The server then sends `connected` and `presence.initial`, followed by live events and heartbeats.
+         *
+         *     Over the same socket the client can send `typing.start` and `typing.stop` (with `channel_id`), `channel.read` (with `channel_id` and an optional `message_id`), and `presence.ping`. Frames that can't be handled are answered with an `error` frame whose data has a `code` and `message`.
+         */
+        get: operations["websocket"];
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
     "/workspaces/{wid}/typing/start": {
         parameters: {
             query?: never;
@@ -1821,6 +2101,17 @@ export interface components {
             who_can_pin_messages: components["schemas"]["PermissionLevel"];
             /** @default members */
             who_can_manage_custom_emoji: components["schemas"]["PermissionLevel"];
+            /** @default members */
+            who_can_mention_here: components["schemas"]["PermissionLevel"];
+            /** @default members */
+            who_can_mention_channel: components["schemas"]["PermissionLevel"];
+            /** @default admins */
+            who_can_mention_everyone: components["schemas"]["PermissionLevel"];
+            /**
+             * @description Number of people above which @here, @channel and @everyone must be confirmed before sending. 0 never asks.
+             * @default 50
+             */
+            mention_confirm_threshold: number;
         };
         Workspace: {
             /** @example 01JQ3KMN7XFGY4P6WBR2SZTA9V */
@@ -1975,8 +2266,11 @@ export interface components {
             gravatar_url?: string;
             channel_role?: components["schemas"]["ChannelRole"];
         };
-        /** @enum {string} */
-        MessageType: "user" | "system";
+        /**
+         * @description `bot` messages are posted by an integration, such as mail sent to a channel's email address. They have no user; `user_display_name` holds the name of the integration.
+         * @enum {string}
+         */
+        MessageType: "user" | "system" | "bot";
         /** @enum {string} */
         SystemEventType: "user_joined" | "user_left" | "user_added" | "user_converted_channel" | "channel_renamed" | "channel_visibility_changed" | "channel_description_updated" | "message_pinned" | "message_unpinned";
         SystemEventData: {
@@ -2254,6 +2548,10 @@ export interface components {
             version: string;
             email_enabled?: boolean;
             files_enabled?: boolean;
+            /** @description The server's VAPID public key, base64url encoded, for subscribing browsers to Web Push. Absent when Web Push is disabled. */
+            web_push_public_key?: string;
+            /** @description Whether the server receives email, so channels can be given email addresses */
+            inbound_email_enabled?: boolean;
         };
         SuccessResponse: {
             success: boolean;
@@ -2671,7 +2969,7 @@ export interface components {
         };
         NotificationData: {
             /** @enum {string} */
-            type: "mention" | "dm" | "channel" | "here" | "everyone" | "thread_reply";
+            type: "mention" | "dm" | "channel" | "here" | "everyone" | "thread_reply" | "keyword";
             /** @example 01JQ3KMQ8YNBC3DFHM6RWVS7AG */
             channel_id: string;
             /** @example 01JQ3KMR5KVDW2TG9NHP0XEJBL */
@@ -2690,6 +2988,107 @@ export interface components {
         NotificationPreferences: {
             notify_level: components["schemas"]["NotifyLevel"];
             email_enabled: boolean;
+            /** @description Omit when updating to inherit from the defaults */
+            push_enabled?: boolean;
+            /** @description True when the user has no preferences of their own for the channel */
+            inherited?: boolean;
+        };
+        ChannelNotificationPreferences: {
+            channel_id: string;
+            notify_level: components["schemas"]["NotifyLevel"];
+            email_enabled: boolean;
+            push_enabled: boolean;
+            /** @description True when the user has no preferences of their own for the channel */
+            inherited: boolean;
+        };
+        NotificationDefaults: {
+            notify_level: components["schemas"]["NotifyLevel"];
+            email_enabled: boolean;
+            push_enabled: boolean;
+            /** @description Only push to mobile devices while the user isn't connected from another client */
+            mobile_only_when_idle: boolean;
+        };
+        ChannelNotificationDefaults: {
+            notify_level?: components["schemas"]["NotifyLevel"];
+        };
+        /** @description Overrides of the workspace's settings for who may use @here, @channel and @everyone in a channel. Omitted fields inherit the workspace setting. */
+        ChannelMentionPermissions: {
+            who_can_mention_here?: components["schemas"]["PermissionLevel"];
+            who_can_mention_channel?: components["schemas"]["PermissionLevel"];
+            who_can_mention_everyone?: components["schemas"]["PermissionLevel"];
+        };
+        ChannelEmailAddress: {
+            /**
+             * Format: email
+             * @description Secret address that posts mail sent to it into the channel
+             */
+            address: string;
+            /** @description Addresses and domains mail is accepted from. Empty accepts mail from anyone who knows the address. */
+            allowed_senders: string[];
+            created_by?: string;
+            /** Format: date-time */
+            created_at: string;
+            /** Format: date-time */
+            updated_at: string;
+        };
+        UpdateChannelEmailAddressInput: {
+            /** @description Addresses such as `alerts@example.com` and domains such as `example.com` to accept mail from. Empty accepts mail from anyone. */
+            allowed_senders: string[];
+        };
+        MentionConfirmationRequired: {
+            error: components["schemas"]["ApiError"];
+            /**
+             * @description The special mention that reaches the most people
+             * @enum {string}
+             */
+            mention: "@here" | "@channel" | "@everyone";
+            /**
+             * @description Number of people the message would notify
+             * @example 240
+             */
+            recipient_count: number;
+        };
+        NotificationSettings: {
+            /**
+             * @description Words or phrases matched case-insensitively on word boundaries
+             * @example [
+             *       "outage",
+             *       "Enzyme"
+             *     ]
+             */
+            highlight_keywords: string[];
+            schedule?: components["schemas"]["NotificationSchedule"];
+            email_digest?: components["schemas"]["EmailDigest"];
+        };
+        /**
+         * @description How often notification emails are sent. `immediate` batches notifications a few minutes after the first one, `hourly` sends a digest at the top of each hour, `daily` at 09:00 in the schedule's time zone (UTC without a schedule), and `never` sends none. Always set in responses; defaults to `immediate`.
+         * @enum {string}
+         */
+        EmailDigest: "immediate" | "hourly" | "daily" | "never";
+        NotificationSchedule: {
+            /**
+             * @description IANA time zone the windows are in
+             * @example Europe/Berlin
+             */
+            time_zone: string;
+            windows: components["schemas"]["NotificationScheduleWindow"][];
+        };
+        NotificationScheduleWindow: {
+            /**
+             * @description Day of the week, from 0 (Sunday) to 6 (Saturday)
+             * @example 1
+             */
+            day: number;
+            /**
+             * @description Local start time as HH:MM
+             * @example 09:00
+             */
+            start: string;
+            /**
+             * @description Local end time as HH:MM, after start; 24:00 is the end of the day
+             * @example 17:30
+             */
+            end: string;
         };
         TypingEventData: {
             /** @example 01JQ3KMN7XFGY4P6WBR2SZTA9V */
@@ -2716,7 +3115,12 @@ export interface components {
             password: string;
             /** @example Alice Chen */
             display_name: string;
-        };
+            /**
+             * @description Workspace invite code, required when registration is invite-only
+             * @example abc123def456
+             */
+            invite_code?: string;
+        };
         LoginInput: {
             /**
              * Format: email
@@ -2737,15 +3141,15 @@ export interface components {
         };
         RegisterDeviceTokenRequest: {
             /**
-             * @description The push notification token (FCM or APNs)
+             * @description The push notification token (FCM or APNs), or for UnifiedPush the distributor's endpoint URL
              * @example enz_v1_01JQ3KMWX8FVN4CPRD6BHTYGSZ
              */
             token: string;
             /**
-             * @description The push platform
+             * @description The push platform. `fcm` and `apns` are delivered through the push relay; `unifiedpush` is posted directly to the endpoint.
              * @enum {string}
              */
-            platform: "fcm" | "apns";
+            platform: "fcm" | "apns" | "unifiedpush";
             /** @description A unique identifier for the device */
             device_id: string;
         };
@@ -2756,6 +3160,26 @@ export interface components {
              */
             id: string;
         };
+        RegisterWebPushSubscriptionRequest: {
+            /**
+             * @description The push service URL for this browser. Must use HTTPS.
+             * @example https://fcm.googleapis.com/fcm/send/dXN1cl9pZA
+             */
+            endpoint: string;
+            keys: {
+                /** @description The browser's P-256 public key, base64url encoded */
+                p256dh: string;
+                /** @description The browser's 16-byte authentication secret, base64url encoded */
+                auth: string;
+            };
+        };
+        RegisterWebPushSubscriptionResponse: {
+            /**
+             * @description The subscription record ID
+             * @example 01JQ3KMN7XFGY4P6WBR2SZTA9V
+             */
+            id: string;
+        };
         CreateWorkspaceInput: {
             /** @example general */
             name: string;
@@ -2770,6 +3194,10 @@ export interface components {
                 who_can_create_invites?: components["schemas"]["PermissionLevel"];
                 who_can_pin_messages?: components["schemas"]["PermissionLevel"];
                 who_can_manage_custom_emoji?: components["schemas"]["PermissionLevel"];
+                who_can_mention_here?: components["schemas"]["PermissionLevel"];
+                who_can_mention_channel?: components["schemas"]["PermissionLevel"];
+                who_can_mention_everyone?: components["schemas"]["PermissionLevel"];
+                mention_confirm_threshold?: number;
             };
         };
         CreateInviteInput: {
@@ -2817,6 +3245,8 @@ export interface components {
             attachment_ids?: string[];
             /** @description When replying in a thread, also show the reply in the channel */
             also_send_to_channel?: boolean;
+            /** @description Send even though @here, @channel or @everyone would notify more people than the workspace's confirmation threshold */
+            confirm_mentions?: boolean;
         };
         ListMessagesInput: {
             /** @example eyJpZCI6IjAxSkVYQU1QTEUifQ */
@@ -3099,7 +3529,27 @@ export interface operations {
                     "application/json": components["schemas"]["AuthResponse"];
                 };
             };
+            /** @description Account created and awaiting approval by a site admin */
+            202: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        user: components["schemas"]["User"];
+                    };
+                };
+            };
             400: components["responses"]["BadRequest"];
+            /** @description Registration is not allowed. The error code is REGISTRATION_CLOSED, INVITE_REQUIRED, INVALID_INVITE or EMAIL_DOMAIN_NOT_ALLOWED. */
+            403: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["ApiErrorResponse"];
+                };
+            };
         };
     };
     login: {
@@ -3331,6 +3781,55 @@ export interface operations {
             404: components["responses"]["NotFound"];
         };
     };
+    registerWebPushSubscription: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["RegisterWebPushSubscriptionRequest"];
+            };
+        };
+        responses: {
+            /** @description Subscription registered */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["RegisterWebPushSubscriptionResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    unregisterWebPushSubscription: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description The subscription record ID */
+                id: string;
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Subscription removed */
+            204: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
     createWorkspace: {
         parameters: {
             query?: never;
@@ -3670,73 +4169,476 @@ export interface operations {
                 };
                 content: {
                     "application/json": {
-                        channel: components["schemas"]["Channel"];
+                        channel: components["schemas"]["Channel"];
+                    };
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+        };
+    };
+    listChannels: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Workspace ID */
+                wid: components["parameters"]["workspaceId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description List of channels */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        channels: components["schemas"]["ChannelWithMembership"][];
+                    };
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    createDM: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Workspace ID */
+                wid: components["parameters"]["workspaceId"];
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["CreateDMInput"];
+            };
+        };
+        responses: {
+            /** @description DM channel */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        channel: components["schemas"]["Channel"];
+                    };
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    updateChannel: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateChannelInput"];
+            };
+        };
+        responses: {
+            /** @description Channel updated */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        channel: components["schemas"]["Channel"];
+                    };
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    convertGroupDMToChannel: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["ConvertGroupDMInput"];
+            };
+        };
+        responses: {
+            /** @description Channel converted */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        channel: components["schemas"]["Channel"];
+                    };
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+        };
+    };
+    archiveChannel: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Channel archived */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SuccessResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    addChannelMember: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": {
+                    /** @example 01JQ3KMN7XFGY4P6WBR2SZTA9V */
+                    user_id: string;
+                    role?: components["schemas"]["ChannelRole"];
+                };
+            };
+        };
+        responses: {
+            /** @description Member added */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SuccessResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    listChannelMembers: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description List of members */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        members: components["schemas"]["ChannelMember"][];
+                    };
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    joinChannel: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Joined channel */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SuccessResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    leaveChannel: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Left channel */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SuccessResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    starChannel: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Channel starred */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SuccessResponse"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    unstarChannel: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Channel unstarred */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SuccessResponse"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    markChannelRead: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: {
+            content: {
+                "application/json": {
+                    /**
+                     * @description Message ID to mark as last read (defaults to latest message)
+                     * @example 01JQ3KMR5KVDW2TG9NHP0XEJBL
+                     */
+                    message_id?: string;
+                };
+            };
+        };
+        responses: {
+            /** @description Channel marked as read */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["MarkReadResponse"];
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    getChannelNotifications: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Notification preferences */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        preferences: components["schemas"]["NotificationPreferences"];
+                    };
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
+        };
+    };
+    updateChannelNotifications: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
+            };
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["NotificationPreferences"];
+            };
+        };
+        responses: {
+            /** @description Preferences updated */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        preferences: components["schemas"]["NotificationPreferences"];
                     };
                 };
             };
             400: components["responses"]["BadRequest"];
             401: components["responses"]["Unauthorized"];
-            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
     };
-    listChannels: {
+    resetChannelNotifications: {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Workspace ID */
-                wid: components["parameters"]["workspaceId"];
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description List of channels */
+            /** @description Preferences reset */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
                     "application/json": {
-                        channels: components["schemas"]["ChannelWithMembership"][];
+                        preferences: components["schemas"]["NotificationPreferences"];
                     };
                 };
             };
             401: components["responses"]["Unauthorized"];
+            404: components["responses"]["NotFound"];
         };
     };
-    createDM: {
+    getChannelNotificationDefaults: {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Workspace ID */
-                wid: components["parameters"]["workspaceId"];
+                /** @description Channel ID */
+                id: components["parameters"]["channelId"];
             };
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["CreateDMInput"];
-            };
-        };
+        requestBody?: never;
         responses: {
-            /** @description DM channel */
+            /** @description Channel default */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": {
-                        channel: components["schemas"]["Channel"];
-                    };
+                    "application/json": components["schemas"]["ChannelNotificationDefaults"];
                 };
             };
-            400: components["responses"]["BadRequest"];
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
     };
-    updateChannel: {
+    updateChannelNotificationDefaults: {
         parameters: {
             query?: never;
             header?: never;
@@ -3748,19 +4650,19 @@ export interface operations {
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["UpdateChannelInput"];
+                "application/json": {
+                    notify_level: components["schemas"]["NotifyLevel"];
+                };
             };
         };
         responses: {
-            /** @description Channel updated */
+            /** @description Channel default updated */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": {
-                        channel: components["schemas"]["Channel"];
-                    };
+                    "application/json": components["schemas"]["ChannelNotificationDefaults"];
                 };
             };
             400: components["responses"]["BadRequest"];
@@ -3769,7 +4671,7 @@ export interface operations {
             404: components["responses"]["NotFound"];
         };
     };
-    convertGroupDMToChannel: {
+    deleteChannelNotificationDefaults: {
         parameters: {
             query?: never;
             header?: never;
@@ -3779,29 +4681,23 @@ export interface operations {
             };
             cookie?: never;
         };
-        requestBody: {
-            content: {
-                "application/json": components["schemas"]["ConvertGroupDMInput"];
-            };
-        };
+        requestBody?: never;
         responses: {
-            /** @description Channel converted */
+            /** @description Channel default removed */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": {
-                        channel: components["schemas"]["Channel"];
-                    };
+                    "application/json": components["schemas"]["SuccessResponse"];
                 };
             };
-            400: components["responses"]["BadRequest"];
             401: components["responses"]["Unauthorized"];
             403: components["responses"]["Forbidden"];
+            404: components["responses"]["NotFound"];
         };
     };
-    archiveChannel: {
+    getChannelMentionPermissions: {
         parameters: {
             query?: never;
             header?: never;
@@ -3813,22 +4709,21 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Channel archived */
+            /** @description Channel mention permissions */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SuccessResponse"];
+                    "application/json": components["schemas"]["ChannelMentionPermissions"];
                 };
             };
-            400: components["responses"]["BadRequest"];
             401: components["responses"]["Unauthorized"];
             403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
         };
     };
-    addChannelMember: {
+    updateChannelMentionPermissions: {
         parameters: {
             query?: never;
             header?: never;
@@ -3840,21 +4735,17 @@ export interface operations {
         };
         requestBody: {
             content: {
-                "application/json": {
-                    /** @example 01JQ3KMN7XFGY4P6WBR2SZTA9V */
-                    user_id: string;
-                    role?: components["schemas"]["ChannelRole"];
-                };
+                "application/json": components["schemas"]["ChannelMentionPermissions"];
             };
         };
         responses: {
-            /** @description Member added */
+            /** @description Channel mention permissions updated */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SuccessResponse"];
+                    "application/json": components["schemas"]["ChannelMentionPermissions"];
                 };
             };
             400: components["responses"]["BadRequest"];
@@ -3863,7 +4754,7 @@ export interface operations {
             404: components["responses"]["NotFound"];
         };
     };
-    listChannelMembers: {
+    getChannelEmailAddress: {
         parameters: {
             query?: never;
             header?: never;
@@ -3875,22 +4766,21 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description List of members */
+            /** @description Channel email address */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": {
-                        members: components["schemas"]["ChannelMember"][];
-                    };
+                    "application/json": components["schemas"]["ChannelEmailAddress"];
                 };
             };
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
         };
     };
-    joinChannel: {
+    updateChannelEmailAddress: {
         parameters: {
             query?: never;
             header?: never;
@@ -3900,23 +4790,28 @@ export interface operations {
             };
             cookie?: never;
         };
-        requestBody?: never;
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["UpdateChannelEmailAddressInput"];
+            };
+        };
         responses: {
-            /** @description Joined channel */
+            /** @description Channel email address updated */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SuccessResponse"];
+                    "application/json": components["schemas"]["ChannelEmailAddress"];
                 };
             };
             400: components["responses"]["BadRequest"];
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
         };
     };
-    leaveChannel: {
+    deleteChannelEmailAddress: {
         parameters: {
             query?: never;
             header?: never;
@@ -3928,7 +4823,7 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Left channel */
+            /** @description Channel email address removed */
             200: {
                 headers: {
                     [name: string]: unknown;
@@ -3937,12 +4832,12 @@ export interface operations {
                     "application/json": components["schemas"]["SuccessResponse"];
                 };
             };
-            400: components["responses"]["BadRequest"];
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
         };
     };
-    starChannel: {
+    rotateChannelEmailAddress: {
         parameters: {
             query?: never;
             header?: never;
@@ -3954,136 +4849,167 @@ export interface operations {
         };
         requestBody?: never;
         responses: {
-            /** @description Channel starred */
+            /** @description Channel email address rotated */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SuccessResponse"];
+                    "application/json": components["schemas"]["ChannelEmailAddress"];
                 };
             };
+            400: components["responses"]["BadRequest"];
             401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
         };
     };
-    unstarChannel: {
+    getWorkspaceNotificationPreferences: {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Channel ID */
-                id: components["parameters"]["channelId"];
+                /** @description Workspace ID */
+                wid: components["parameters"]["workspaceId"];
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Channel unstarred */
+            /** @description Notification preferences */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["SuccessResponse"];
+                    "application/json": {
+                        defaults: components["schemas"]["NotificationDefaults"];
+                        /** @description Whether the defaults are the user's own rather than the workspace's */
+                        defaults_customized: boolean;
+                        channels: components["schemas"]["ChannelNotificationPreferences"][];
+                    };
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
+            403: components["responses"]["Forbidden"];
         };
     };
-    markChannelRead: {
+    updateNotificationDefaults: {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Channel ID */
-                id: components["parameters"]["channelId"];
+                /** @description Workspace ID */
+                wid: components["parameters"]["workspaceId"];
             };
             cookie?: never;
         };
-        requestBody?: {
+        requestBody: {
             content: {
-                "application/json": {
-                    /**
-                     * @description Message ID to mark as last read (defaults to latest message)
-                     * @example 01JQ3KMR5KVDW2TG9NHP0XEJBL
-                     */
-                    message_id?: string;
+                "application/json": components["schemas"]["NotificationDefaults"];
+            };
+        };
+        responses: {
+            /** @description Defaults updated */
+            200: {
+                headers: {
+                    [name: string]: unknown;
                 };
+                content: {
+                    "application/json": {
+                        defaults: components["schemas"]["NotificationDefaults"];
+                    };
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+            403: components["responses"]["Forbidden"];
+        };
+    };
+    resetNotificationDefaults: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Workspace ID */
+                wid: components["parameters"]["workspaceId"];
             };
+            cookie?: never;
         };
+        requestBody?: never;
         responses: {
-            /** @description Channel marked as read */
+            /** @description Defaults reset */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
-                    "application/json": components["schemas"]["MarkReadResponse"];
+                    "application/json": {
+                        defaults: components["schemas"]["NotificationDefaults"];
+                    };
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
+            403: components["responses"]["Forbidden"];
         };
     };
-    getChannelNotifications: {
+    getWorkspaceNotificationDefaults: {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Channel ID */
-                id: components["parameters"]["channelId"];
+                /** @description Workspace ID */
+                wid: components["parameters"]["workspaceId"];
             };
             cookie?: never;
         };
         requestBody?: never;
         responses: {
-            /** @description Notification preferences */
+            /** @description Workspace defaults */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
                     "application/json": {
-                        preferences: components["schemas"]["NotificationPreferences"];
+                        defaults: components["schemas"]["NotificationDefaults"];
                     };
                 };
             };
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
+            403: components["responses"]["Forbidden"];
         };
     };
-    updateChannelNotifications: {
+    updateWorkspaceNotificationDefaults: {
         parameters: {
             query?: never;
             header?: never;
             path: {
-                /** @description Channel ID */
-                id: components["parameters"]["channelId"];
+                /** @description Workspace ID */
+                wid: components["parameters"]["workspaceId"];
             };
             cookie?: never;
         };
         requestBody: {
             content: {
-                "application/json": components["schemas"]["NotificationPreferences"];
+                "application/json": components["schemas"]["NotificationDefaults"];
             };
         };
         responses: {
-            /** @description Preferences updated */
+            /** @description Workspace defaults updated */
             200: {
                 headers: {
                     [name: string]: unknown;
                 };
                 content: {
                     "application/json": {
-                        preferences: components["schemas"]["NotificationPreferences"];
+                        defaults: components["schemas"]["NotificationDefaults"];
                     };
                 };
             };
             400: components["responses"]["BadRequest"];
             401: components["responses"]["Unauthorized"];
-            404: components["responses"]["NotFound"];
+            403: components["responses"]["Forbidden"];
         };
     };
     markAllChannelsRead: {
@@ -4264,6 +5190,15 @@ export interface operations {
             401: components["responses"]["Unauthorized"];
             403: components["responses"]["Forbidden"];
             404: components["responses"]["NotFound"];
+            /** @description The message uses @here, @channel or @everyone and would notify more people than the workspace's confirmation threshold. Resend with `confirm_mentions` to send it. */
+            409: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["MentionConfirmationRequired"];
+                };
+            };
         };
     };
     listMessages: {
@@ -5245,6 +6180,81 @@ export interface operations {
             401: components["responses"]["Unauthorized"];
         };
     };
+    getNotificationSettings: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Notification settings */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        settings: components["schemas"]["NotificationSettings"];
+                    };
+                };
+            };
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    updateNotificationSettings: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody: {
+            content: {
+                "application/json": components["schemas"]["NotificationSettings"];
+            };
+        };
+        responses: {
+            /** @description Settings updated */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": {
+                        settings: components["schemas"]["NotificationSettings"];
+                    };
+                };
+            };
+            400: components["responses"]["BadRequest"];
+            401: components["responses"]["Unauthorized"];
+        };
+    };
+    unsubscribeFromEmail: {
+        parameters: {
+            query: {
+                /** @description Signed unsubscribe token from the email */
+                token: string;
+            };
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Unsubscribed */
+            200: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content: {
+                    "application/json": components["schemas"]["SuccessResponse"];
+                };
+            };
+            400: components["responses"]["BadRequest"];
+        };
+    };
     uploadWorkspaceIcon: {
         parameters: {
             query?: never;
@@ -5572,6 +6582,34 @@ export interface operations {
             };
         };
     };
+    websocket: {
+        parameters: {
+            query?: never;
+            header?: never;
+            path: {
+                /** @description Workspace ID */
+                wid: components["parameters"]["workspaceId"];
+            };
+            cookie?: never;
+        };
+        requestBody?: never;
+        responses: {
+            /** @description Switching to the WebSocket protocol */
+            101: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+            /** @description Not a member of this workspace */
+            403: {
+                headers: {
+                    [name: string]: unknown;
+                };
+                content?: never;
+            };
+        };
+    };
     startTyping: {
         parameters: {
             query?: never;
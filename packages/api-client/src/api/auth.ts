@@ -1,5 +1,10 @@
 import { apiClient, throwIfError } from '../client';
-import type { LoginInput, RegisterDeviceTokenInput, RegisterInput } from '../types';
+import type {
+  LoginInput,
+  RegisterDeviceTokenInput,
+  RegisterInput,
+  RegisterWebPushSubscriptionInput,
+} from '../types';
 
 export const authApi = {
   login: (input: LoginInput) => throwIfError(apiClient.POST('/auth/login', { body: input })),
@@ -27,4 +32,12 @@ export const authApi = {
 
   unregisterDeviceToken: (id: string) =>
     throwIfError(apiClient.DELETE('/auth/device-tokens/{id}', { params: { path: { id } } })),
+
+  registerWebPushSubscription: (input: RegisterWebPushSubscriptionInput) =>
+    throwIfError(apiClient.POST('/auth/web-push-subscriptions', { body: input })),
+
+  unregisterWebPushSubscription: (id: string) =>
+    throwIfError(
+      apiClient.DELETE('/auth/web-push-subscriptions/{id}', { params: { path: { id } } }),
+    ),
 };
@@ -1,11 +1,13 @@
 import { apiClient, throwIfError } from '../client';
 import type {
+  ChannelMentionPermissions,
   ChannelRole,
   CreateChannelInput,
   CreateDMInput,
   ConvertGroupDMInput,
   UpdateChannelInput,
   NotificationPreferences,
+  NotifyLevel,
 } from '../types';
 
 export const channelsApi = {
@@ -90,6 +92,77 @@ export const channelsApi = {
       }),
     ),
 
+  resetNotifications: (channelId: string) =>
+    throwIfError(
+      apiClient.DELETE('/channels/{id}/notifications', { params: { path: { id: channelId } } }),
+    ),
+
+  getNotificationDefaults: (channelId: string) =>
+    throwIfError(
+      apiClient.GET('/channels/{id}/notification-defaults', {
+        params: { path: { id: channelId } },
+      }),
+    ),
+
+  updateNotificationDefaults: (channelId: string, notifyLevel: NotifyLevel) =>
+    throwIfError(
+      apiClient.POST('/channels/{id}/notification-defaults', {
+        params: { path: { id: channelId } },
+        body: { notify_level: notifyLevel },
+      }),
+    ),
+
+  deleteNotificationDefaults: (channelId: string) =>
+    throwIfError(
+      apiClient.DELETE('/channels/{id}/notification-defaults', {
+        params: { path: { id: channelId } },
+      }),
+    ),
+
+  getMentionPermissions: (channelId: string) =>
+    throwIfError(
+      apiClient.GET('/channels/{id}/mention-permissions', {
+        params: { path: { id: channelId } },
+      }),
+    ),
+
+  updateMentionPermissions: (channelId: string, permissions: ChannelMentionPermissions) =>
+    throwIfError(
+      apiClient.POST('/channels/{id}/mention-permissions', {
+        params: { path: { id: channelId } },
+        body: permissions,
+      }),
+    ),
+
+  getEmailAddress: (channelId: string) =>
+    throwIfError(
+      apiClient.GET('/channels/{id}/email-address', {
+        params: { path: { id: channelId } },
+      }),
+    ),
+
+  updateEmailAddress: (channelId: string, allowedSenders: string[]) =>
+    throwIfError(
+      apiClient.POST('/channels/{id}/email-address', {
+        params: { path: { id: channelId } },
+        body: { allowed_senders: allowedSenders },
+      }),
+    ),
+
+  deleteEmailAddress: (channelId: string) =>
+    throwIfError(
+      apiClient.DELETE('/channels/{id}/email-address', {
+        params: { path: { id: channelId } },
+      }),
+    ),
+
+  rotateEmailAddress: (channelId: string) =>
+    throwIfError(
+      apiClient.POST('/channels/{id}/email-address/rotate', {
+        params: { path: { id: channelId } },
+      }),
+    ),
+
   star: (channelId: string) =>
     throwIfError(apiClient.POST('/channels/{id}/star', { params: { path: { id: channelId } } })),
 
@@ -1,5 +1,5 @@
 import { apiClient, throwIfError, multipartRequest } from '../client';
-import type { UpdateProfileInput } from '../types';
+import type { NotificationSettings, UpdateProfileInput } from '../types';
 
 export const usersApi = {
   getUser: (userId: string) =>
@@ -15,4 +15,12 @@ export const usersApi = {
   },
 
   deleteAvatar: () => throwIfError(apiClient.DELETE('/users/me/avatar')),
+
+  getNotificationSettings: () => throwIfError(apiClient.GET('/users/me/notification-settings')),
+
+  updateNotificationSettings: (settings: NotificationSettings) =>
+    throwIfError(apiClient.POST('/users/me/notification-settings', { body: settings })),
+
+  unsubscribeFromEmail: (token: string) =>
+    throwIfError(apiClient.POST('/email/unsubscribe', { params: { query: { token } } })),
 };
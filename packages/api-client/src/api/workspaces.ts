@@ -4,6 +4,7 @@ import type {
   UpdateWorkspaceInput,
   CreateInviteInput,
   WorkspaceRole,
+  NotificationDefaults,
 } from '../types';
 
 export const workspacesApi = {
@@ -96,4 +97,41 @@ export const workspacesApi = {
     ),
 
   getNotifications: () => throwIfError(apiClient.GET('/workspaces/notifications')),
+
+  getNotificationPreferences: (workspaceId: string) =>
+    throwIfError(
+      apiClient.GET('/workspaces/{wid}/notification-preferences', {
+        params: { path: { wid: workspaceId } },
+      }),
+    ),
+
+  updateNotificationDefaults: (workspaceId: string, defaults: NotificationDefaults) =>
+    throwIfError(
+      apiClient.POST('/workspaces/{wid}/notification-preferences/defaults', {
+        params: { path: { wid: workspaceId } },
+        body: defaults,
+      }),
+    ),
+
+  resetNotificationDefaults: (workspaceId: string) =>
+    throwIfError(
+      apiClient.DELETE('/workspaces/{wid}/notification-preferences/defaults', {
+        params: { path: { wid: workspaceId } },
+      }),
+    ),
+
+  getWorkspaceNotificationDefaults: (workspaceId: string) =>
+    throwIfError(
+      apiClient.GET('/workspaces/{wid}/notification-defaults', {
+        params: { path: { wid: workspaceId } },
+      }),
+    ),
+
+  updateWorkspaceNotificationDefaults: (workspaceId: string, defaults: NotificationDefaults) =>
+    throwIfError(
+      apiClient.POST('/workspaces/{wid}/notification-defaults', {
+        params: { path: { wid: workspaceId } },
+        body: defaults,
+      }),
+    ),
 };
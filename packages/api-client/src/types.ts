@@ -12,6 +12,8 @@ export type MeResponse = components['schemas']['MeResponse'];
 export type LoginInput = components['schemas']['LoginInput'];
 export type RegisterInput = components['schemas']['RegisterInput'];
 export type RegisterDeviceTokenInput = components['schemas']['RegisterDeviceTokenRequest'];
+export type RegisterWebPushSubscriptionInput =
+  components['schemas']['RegisterWebPushSubscriptionRequest'];
 
 // Workspace types
 export type Workspace = components['schemas']['Workspace'];
@@ -39,6 +41,10 @@ export type MarkReadResponse = components['schemas']['MarkReadResponse'];
 export type ChannelReadEventData = components['schemas']['ChannelReadEventData'];
 export type CreateChannelInput = components['schemas']['CreateChannelInput'];
 export type UpdateChannelInput = components['schemas']['UpdateChannelInput'];
+export type ChannelMentionPermissions = components['schemas']['ChannelMentionPermissions'];
+export type ChannelEmailAddress = components['schemas']['ChannelEmailAddress'];
+export type UpdateChannelEmailAddressInput =
+  components['schemas']['UpdateChannelEmailAddressInput'];
 
 // Message types
 export type Message = components['schemas']['Message'];
@@ -69,6 +75,12 @@ export type PresenceData = components['schemas']['PresenceData'];
 // Notification types
 export type NotifyLevel = components['schemas']['NotifyLevel'];
 export type NotificationPreferences = components['schemas']['NotificationPreferences'];
+export type ChannelNotificationPreferences =
+  components['schemas']['ChannelNotificationPreferences'];
+export type NotificationDefaults = components['schemas']['NotificationDefaults'];
+export type NotificationSettings = components['schemas']['NotificationSettings'];
+export type NotificationSchedule = components['schemas']['NotificationSchedule'];
+export type EmailDigest = components['schemas']['EmailDigest'];
 export type ThreadSubscriptionStatus = components['schemas']['ThreadSubscriptionStatus'];
 export type NotificationData = components['schemas']['NotificationData'];
 
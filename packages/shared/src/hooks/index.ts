@@ -28,15 +28,38 @@ export {
   useStarChannel,
   useUnstarChannel,
   useConvertGroupDMToChannel,
+  useChannelMentionPermissions,
+  useUpdateChannelMentionPermissions,
+  useChannelEmailAddress,
+  useUpdateChannelEmailAddress,
+  useRotateChannelEmailAddress,
+  useDeleteChannelEmailAddress,
 } from './useChannels';
-export { useUserProfile, useUpdateProfile, useUploadAvatar, useDeleteAvatar } from './useProfile';
+export {
+  useUserProfile,
+  useUpdateProfile,
+  useUploadAvatar,
+  useDeleteAvatar,
+  useNotificationSettings,
+  useUpdateNotificationSettings,
+} from './useProfile';
 export { useTyping } from './useTyping';
 export {
   useThreadSubscription,
   useSubscribeToThread,
   useUnsubscribeFromThread,
 } from './useThreadSubscription';
-export { useChannelNotifications, useUpdateChannelNotifications } from './useChannelNotifications';
+export {
+  useChannelNotifications,
+  useUpdateChannelNotifications,
+  useResetChannelNotifications,
+  useChannelNotificationDefaults,
+  useUpdateChannelNotificationDefaults,
+  useWorkspaceNotificationPreferences,
+  useUpdateNotificationDefaults,
+  useWorkspaceNotificationDefaults,
+  useUpdateWorkspaceNotificationDefaults,
+} from './useChannelNotifications';
 export { useSearch, type UseSearchOptions } from './useSearch';
 export { useMentions } from './useMentions';
 export { useAllUnreads } from './useAllUnreads';
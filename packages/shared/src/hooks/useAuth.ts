@@ -54,8 +54,11 @@ export function useAuth() {
   const registerMutation = useMutation({
     mutationFn: (input: RegisterInput) => authApi.register(input),
     onSuccess: (data) => {
-      setAuthToken(data.token);
-      queryClient.invalidateQueries({ queryKey: authKeys.me() });
+      // Accounts awaiting approval get no session until a site admin approves them
+      if ('token' in data) {
+        setAuthToken(data.token);
+        queryClient.invalidateQueries({ queryKey: authKeys.me() });
+      }
     },
   });
 
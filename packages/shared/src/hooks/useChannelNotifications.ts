@@ -1,6 +1,12 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
-import { channelsApi, type NotificationPreferences } from '@enzyme/api-client';
-import { channelKeys } from '../queryKeys';
+import {
+  channelsApi,
+  workspacesApi,
+  type NotificationDefaults,
+  type NotificationPreferences,
+  type NotifyLevel,
+} from '@enzyme/api-client';
+import { channelKeys, workspaceKeys } from '../queryKeys';
 
 /**
  * Hook to get the current user's notification preferences for a channel
@@ -48,6 +54,123 @@ export function useUpdateChannelNotifications(channelId: string) {
     onSettled: () => {
       // Invalidate to refetch
       queryClient.invalidateQueries({ queryKey: channelKeys.notifications(channelId) });
+      queryClient.invalidateQueries({ queryKey: workspaceKeys.allNotificationPreferences() });
+    },
+  });
+}
+
+/**
+ * Hook to remove the current user's preferences for a channel, so it
+ * inherits their defaults again
+ */
+export function useResetChannelNotifications(channelId: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: () => channelsApi.resetNotifications(channelId),
+    onSuccess: (data) => {
+      queryClient.setQueryData(channelKeys.notifications(channelId), data);
+      queryClient.invalidateQueries({ queryKey: workspaceKeys.allNotificationPreferences() });
+    },
+  });
+}
+
+/**
+ * Hook to get the notify level members of a channel inherit
+ */
+export function useChannelNotificationDefaults(channelId: string | undefined) {
+  return useQuery({
+    queryKey: channelKeys.notificationDefaults(channelId!),
+    queryFn: () => channelsApi.getNotificationDefaults(channelId!),
+    enabled: !!channelId,
+  });
+}
+
+/**
+ * Hook to set a channel's default notify level (admin only). Passing null
+ * removes it, so members follow their workspace defaults.
+ */
+export function useUpdateChannelNotificationDefaults(channelId: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: async (notifyLevel: NotifyLevel | null) => {
+      if (notifyLevel === null) {
+        await channelsApi.deleteNotificationDefaults(channelId);
+        return {};
+      }
+      return channelsApi.updateNotificationDefaults(channelId, notifyLevel);
+    },
+    onSuccess: (data) => {
+      queryClient.setQueryData(channelKeys.notificationDefaults(channelId), data);
+      // Members' inherited preferences may have changed
+      queryClient.invalidateQueries({ queryKey: ['channel-notifications'] });
+      queryClient.invalidateQueries({ queryKey: workspaceKeys.allNotificationPreferences() });
+    },
+  });
+}
+
+/**
+ * Hook to get the current user's notification defaults for a workspace and
+ * the effective preferences for each of their channels
+ */
+export function useWorkspaceNotificationPreferences(workspaceId: string | undefined) {
+  return useQuery({
+    queryKey: workspaceKeys.notificationPreferences(workspaceId!),
+    queryFn: () => workspacesApi.getNotificationPreferences(workspaceId!),
+    enabled: !!workspaceId,
+    staleTime: 30000, // 30 seconds
+  });
+}
+
+/**
+ * Hook to set the current user's notification defaults for a workspace.
+ * Passing null resets them to the workspace's defaults.
+ */
+export function useUpdateNotificationDefaults(workspaceId: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (defaults: NotificationDefaults | null) =>
+      defaults === null
+        ? workspacesApi.resetNotificationDefaults(workspaceId)
+        : workspacesApi.updateNotificationDefaults(workspaceId, defaults),
+    onSettled: () => {
+      queryClient.invalidateQueries({
+        queryKey: workspaceKeys.notificationPreferences(workspaceId),
+      });
+      queryClient.invalidateQueries({ queryKey: ['channel-notifications'] });
+    },
+  });
+}
+
+/**
+ * Hook to get the notification defaults for members of a workspace who
+ * haven't set their own
+ */
+export function useWorkspaceNotificationDefaults(workspaceId: string | undefined) {
+  return useQuery({
+    queryKey: workspaceKeys.notificationDefaults(workspaceId!),
+    queryFn: () => workspacesApi.getWorkspaceNotificationDefaults(workspaceId!),
+    enabled: !!workspaceId,
+  });
+}
+
+/**
+ * Hook to set the notification defaults for members of a workspace (admin only)
+ */
+export function useUpdateWorkspaceNotificationDefaults(workspaceId: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (defaults: NotificationDefaults) =>
+      workspacesApi.updateWorkspaceNotificationDefaults(workspaceId, defaults),
+    onSuccess: (data) => {
+      queryClient.setQueryData(workspaceKeys.notificationDefaults(workspaceId), data);
+      queryClient.invalidateQueries({
+        queryKey: workspaceKeys.notificationPreferences(workspaceId),
+      });
+      queryClient.invalidateQueries({ queryKey: ['channel-notifications'] });
     },
   });
 }
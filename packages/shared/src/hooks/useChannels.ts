@@ -1,12 +1,14 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
 import {
   channelsApi,
+  ApiError,
   type CreateChannelInput,
   type CreateDMInput,
   type UpdateChannelInput,
   type ConvertGroupDMInput,
   type ChannelRole,
   type ChannelWithMembership,
+  type ChannelMentionPermissions,
 } from '@enzyme/api-client';
 import { channelKeys, workspaceKeys } from '../queryKeys';
 
@@ -252,3 +254,75 @@ export function useConvertGroupDMToChannel(workspaceId: string, channelId: strin
     },
   });
 }
+
+export function useChannelMentionPermissions(channelId: string | undefined) {
+  return useQuery({
+    queryKey: channelKeys.mentionPermissions(channelId!),
+    queryFn: () => channelsApi.getMentionPermissions(channelId!),
+    enabled: !!channelId,
+  });
+}
+
+export function useUpdateChannelMentionPermissions(channelId: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (permissions: ChannelMentionPermissions) =>
+      channelsApi.updateMentionPermissions(channelId, permissions),
+    onSuccess: (data) => {
+      queryClient.setQueryData(channelKeys.mentionPermissions(channelId), data);
+    },
+  });
+}
+
+// Resolves to null when the channel has no email address
+export function useChannelEmailAddress(channelId: string | undefined, enabled = true) {
+  return useQuery({
+    queryKey: channelKeys.emailAddress(channelId!),
+    queryFn: async () => {
+      try {
+        return await channelsApi.getEmailAddress(channelId!);
+      } catch (error) {
+        if (error instanceof ApiError && error.status === 404) {
+          return null;
+        }
+        throw error;
+      }
+    },
+    enabled: !!channelId && enabled,
+  });
+}
+
+export function useUpdateChannelEmailAddress(channelId: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (allowedSenders: string[]) =>
+      channelsApi.updateEmailAddress(channelId, allowedSenders),
+    onSuccess: (data) => {
+      queryClient.setQueryData(channelKeys.emailAddress(channelId), data);
+    },
+  });
+}
+
+export function useRotateChannelEmailAddress(channelId: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: () => channelsApi.rotateEmailAddress(channelId),
+    onSuccess: (data) => {
+      queryClient.setQueryData(channelKeys.emailAddress(channelId), data);
+    },
+  });
+}
+
+export function useDeleteChannelEmailAddress(channelId: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: () => channelsApi.deleteEmailAddress(channelId),
+    onSuccess: () => {
+      queryClient.setQueryData(channelKeys.emailAddress(channelId), null);
+    },
+  });
+}
@@ -118,6 +118,7 @@ interface SendThreadReplyInput {
   content?: string;
   attachment_ids?: string[];
   also_send_to_channel?: boolean;
+  confirm_mentions?: boolean;
 }
 
 export function useSendThreadReply(parentMessageId: string, channelId: string) {
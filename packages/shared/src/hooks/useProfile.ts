@@ -1,5 +1,5 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
-import { usersApi, type UpdateProfileInput } from '@enzyme/api-client';
+import { usersApi, type NotificationSettings, type UpdateProfileInput } from '@enzyme/api-client';
 import { authKeys, userKeys, messageKeys, threadKeys } from '../queryKeys';
 
 export function useUserProfile(userId: string | null) {
@@ -65,3 +65,21 @@ export function useDeleteAvatar() {
     },
   });
 }
+
+export function useNotificationSettings() {
+  return useQuery({
+    queryKey: userKeys.notificationSettings(),
+    queryFn: () => usersApi.getNotificationSettings(),
+  });
+}
+
+export function useUpdateNotificationSettings() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (settings: NotificationSettings) => usersApi.updateNotificationSettings(settings),
+    onSuccess: (data) => {
+      queryClient.setQueryData(userKeys.notificationSettings(), data);
+    },
+  });
+}
@@ -100,16 +100,31 @@ export {
   useStarChannel,
   useUnstarChannel,
   useConvertGroupDMToChannel,
+  useChannelMentionPermissions,
+  useUpdateChannelMentionPermissions,
+  useChannelEmailAddress,
+  useUpdateChannelEmailAddress,
+  useRotateChannelEmailAddress,
+  useDeleteChannelEmailAddress,
   useUserProfile,
   useUpdateProfile,
   useUploadAvatar,
   useDeleteAvatar,
+  useNotificationSettings,
+  useUpdateNotificationSettings,
   useTyping,
   useThreadSubscription,
   useSubscribeToThread,
   useUnsubscribeFromThread,
   useChannelNotifications,
   useUpdateChannelNotifications,
+  useResetChannelNotifications,
+  useChannelNotificationDefaults,
+  useUpdateChannelNotificationDefaults,
+  useWorkspaceNotificationPreferences,
+  useUpdateNotificationDefaults,
+  useWorkspaceNotificationDefaults,
+  useUpdateWorkspaceNotificationDefaults,
   useSearch,
   type UseSearchOptions,
   useMentions,
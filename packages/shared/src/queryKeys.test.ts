@@ -23,6 +23,7 @@ describe('queryKeys', () => {
   it('userKeys produces correct keys', () => {
     expect(userKeys.all).toEqual(['user']);
     expect(userKeys.detail('u1')).toEqual(['user', 'u1']);
+    expect(userKeys.notificationSettings()).toEqual(['notification-settings']);
   });
 
   it('messageKeys produces correct keys', () => {
@@ -43,6 +44,16 @@ describe('queryKeys', () => {
     expect(channelKeys.list('ws1')).toEqual(['channels', 'ws1']);
     expect(channelKeys.members('ch1')).toEqual(['channel', 'ch1', 'members']);
     expect(channelKeys.notifications('ch1')).toEqual(['channel-notifications', 'ch1']);
+    expect(channelKeys.notificationDefaults('ch1')).toEqual([
+      'channel',
+      'ch1',
+      'notification-defaults',
+    ]);
+    expect(channelKeys.mentionPermissions('ch1')).toEqual([
+      'channel',
+      'ch1',
+      'mention-permissions',
+    ]);
   });
 
   it('workspaceKeys produces correct keys', () => {
@@ -53,6 +64,16 @@ describe('queryKeys', () => {
     expect(workspaceKeys.blocks('ws1')).toEqual(['workspace', 'ws1', 'blocks']);
     expect(workspaceKeys.moderationLog('ws1')).toEqual(['workspace', 'ws1', 'moderation-log']);
     expect(workspaceKeys.notifications()).toEqual(['workspaces', 'notifications']);
+    expect(workspaceKeys.notificationDefaults('ws1')).toEqual([
+      'workspace',
+      'ws1',
+      'notification-defaults',
+    ]);
+    expect(workspaceKeys.notificationPreferences('ws1')).toEqual([
+      'notification-preferences',
+      'ws1',
+    ]);
+    expect(workspaceKeys.allNotificationPreferences()).toEqual(['notification-preferences']);
   });
 
   it('emojiKeys produces correct keys', () => {
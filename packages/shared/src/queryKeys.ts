@@ -13,6 +13,7 @@ export const authKeys = {
 export const userKeys = {
   all: ['user'] as const,
   detail: (userId: string) => ['user', userId] as const,
+  notificationSettings: () => ['notification-settings'] as const,
 };
 
 export const messageKeys = {
@@ -35,6 +36,10 @@ export const channelKeys = {
   list: (workspaceId: string) => ['channels', workspaceId] as const,
   members: (channelId: string) => ['channel', channelId, 'members'] as const,
   notifications: (channelId: string) => ['channel-notifications', channelId] as const,
+  notificationDefaults: (channelId: string) =>
+    ['channel', channelId, 'notification-defaults'] as const,
+  mentionPermissions: (channelId: string) => ['channel', channelId, 'mention-permissions'] as const,
+  emailAddress: (channelId: string) => ['channel', channelId, 'email-address'] as const,
 };
 
 export const workspaceKeys = {
@@ -47,6 +52,13 @@ export const workspaceKeys = {
   // Intentionally outside 'workspace' prefix — this is a cross-workspace aggregate
   // that should not be invalidated by single-workspace operations.
   notifications: () => ['workspaces', 'notifications'] as const,
+  notificationDefaults: (workspaceId: string) =>
+    ['workspace', workspaceId, 'notification-defaults'] as const,
+  // Outside the 'workspace' prefix so channel preference changes can
+  // invalidate it without knowing the workspace.
+  notificationPreferences: (workspaceId: string) =>
+    ['notification-preferences', workspaceId] as const,
+  allNotificationPreferences: () => ['notification-preferences'] as const,
 };
 
 export const emojiKeys = {
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+
+	"github.com/enzyme/server/internal/admin"
+	"github.com/enzyme/server/internal/config"
+	"github.com/enzyme/server/internal/database"
+	"github.com/enzyme/server/internal/email"
+	"github.com/enzyme/server/internal/logging"
+	"github.com/enzyme/server/internal/workspace"
+	"github.com/spf13/pflag"
+)
+
+const adminUsage = `Usage: enzyme admin <command> [flags] [args]
+
+Manage users and workspaces directly in the database, e.g. to recover a
+locked-out account on a server without email. Changes are recorded in the
+moderation audit log of the affected workspaces as made by the system.
+
+Users:
+  create-user EMAIL --name NAME [--password PW] [--verified]
+  reset-password EMAIL [--password PW]   Also signs the user out everywhere
+  verify-email EMAIL
+  disable-user EMAIL                     Deactivate and sign out everywhere
+  enable-user EMAIL
+  revoke-sessions EMAIL                  Sign the user out everywhere
+  grant-site-admin EMAIL                 Allow the user to manage the instance
+  revoke-site-admin EMAIL
+  approve-user EMAIL                     Let an account awaiting approval sign in
+  reject-user EMAIL                      Delete an account awaiting approval
+
+Workspaces:
+  list-workspaces
+  add-member EMAIL WORKSPACE_ID [--role ROLE]   owner, admin, member (default) or guest
+  promote-owner EMAIL WORKSPACE_ID
+
+Email:
+  test-email ADDRESS     Send a test email now, bypassing the queue
+  failed-emails          List emails that could not be delivered
+  retry-email ID         Queue a failed email for delivery again
+
+Database:
+  migrate-status   Show the applied and latest schema versions
+  migrate-down     Roll back the most recent migration
+
+When --password is omitted a random password is generated and printed.
+All commands accept the usual --config and --database.* flags.
+`
+
+func runAdmin(args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, adminUsage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "create-user":
+		runAdminCreateUser(args[1:])
+	case "reset-password":
+		runAdminResetPassword(args[1:])
+	case "verify-email":
+		runAdminUserCommand(args[1:], func(ctx context.Context, svc *admin.Service, email string) error {
+			_, err := svc.VerifyEmail(ctx, email)
+			return err
+		}, "email verified")
+	case "disable-user":
+		runAdminUserCommand(args[1:], func(ctx context.Context, svc *admin.Service, email string) error {
+			_, err := svc.SetDisabled(ctx, email, true)
+			return err
+		}, "user disabled")
+	case "enable-user":
+		runAdminUserCommand(args[1:], func(ctx context.Context, svc *admin.Service, email string) error {
+			_, err := svc.SetDisabled(ctx, email, false)
+			return err
+		}, "user enabled")
+	case "revoke-sessions":
+		runAdminUserCommand(args[1:], func(ctx context.Context, svc *admin.Service, email string) error {
+			n, err := svc.RevokeSessions(ctx, email)
+			if err == nil {
+				slog.Info("revoked sessions", "count", n)
+			}
+			return err
+		}, "sessions revoked")
+	case "grant-site-admin":
+		runAdminUserCommand(args[1:], func(ctx context.Context, svc *admin.Service, email string) error {
+			_, err := svc.SetSiteAdmin(ctx, email, true)
+			return err
+		}, "site admin granted")
+	case "revoke-site-admin":
+		runAdminUserCommand(args[1:], func(ctx context.Context, svc *admin.Service, email string) error {
+			_, err := svc.SetSiteAdmin(ctx, email, false)
+			return err
+		}, "site admin revoked")
+	case "approve-user":
+		runAdminUserCommand(args[1:], func(ctx context.Context, svc *admin.Service, email string) error {
+			_, err := svc.Approve(ctx, email)
+			return err
+		}, "user approved")
+	case "reject-user":
+		runAdminUserCommand(args[1:], func(ctx context.Context, svc *admin.Service, email string) error {
+			return svc.Reject(ctx, email)
+		}, "user rejected")
+	case "list-workspaces":
+		runAdminListWorkspaces(args[1:])
+	case "add-member":
+		runAdminAddMember(args[1:])
+	case "promote-owner":
+		runAdminPromoteOwner(args[1:])
+	case "test-email":
+		runAdminTestEmail(args[1:])
+	case "failed-emails":
+		runAdminFailedEmails(args[1:])
+	case "retry-email":
+		runAdminRetryEmail(args[1:])
+	case "migrate-status":
+		runAdminMigrateStatus(args[1:])
+	case "migrate-down":
+		runAdminMigrateDown(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(adminUsage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown admin command %q\n\n%s", args[0], adminUsage)
+		os.Exit(2)
+	}
+}
+
+// adminFlags returns the config flag set with usage pointing at the admin help.
+func adminFlags() *pflag.FlagSet {
+	flags := config.SetupFlags()
+	flags.Usage = func() { fmt.Fprint(os.Stderr, adminUsage) }
+	return flags
+}
+
+// loadAdminConfig parses flags, checks the positional argument count and
+// loads the config, exiting on failure.
+func loadAdminConfig(flags *pflag.FlagSet, args []string, nargs int) *config.Config {
+	if err := flags.Parse(args); err != nil {
+		slog.Error("error parsing flags", "error", err)
+		os.Exit(2)
+	}
+	if flags.NArg() != nargs {
+		fmt.Fprint(os.Stderr, adminUsage)
+		os.Exit(2)
+	}
+
+	configPath, _ := flags.GetString("config")
+
+	cfg, err := config.Load(configPath, flags)
+	if err != nil {
+		slog.Error("error loading config", "error", err)
+		os.Exit(1)
+	}
+
+	logging.Setup(cfg.Log, false, cfg.Telemetry.ServiceName)
+	return cfg
+}
+
+func exitOnAdminError(msg string, err error) {
+	if err != nil {
+		slog.Error(msg, "error", err)
+		os.Exit(1)
+	}
+}
+
+func runAdminCreateUser(args []string) {
+	flags := adminFlags()
+	name := flags.String("name", "", "Display name")
+	password := flags.String("password", "", "Password (generated if omitted)")
+	verified := flags.Bool("verified", false, "Mark the email address as verified")
+	cfg := loadAdminConfig(flags, args, 1)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	svc := admin.NewService(db.DB, cfg.Auth.BcryptCost)
+	u, pw, err := svc.CreateUser(context.Background(), flags.Arg(0), *name, *password, *verified)
+	exitOnAdminError("error creating user", err)
+
+	slog.Info("user created", "id", u.ID, "email", u.Email)
+	if *password == "" {
+		fmt.Println(pw)
+	}
+}
+
+func runAdminResetPassword(args []string) {
+	flags := adminFlags()
+	password := flags.String("password", "", "New password (generated if omitted)")
+	cfg := loadAdminConfig(flags, args, 1)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	svc := admin.NewService(db.DB, cfg.Auth.BcryptCost)
+	pw, err := svc.ResetPassword(context.Background(), flags.Arg(0), *password)
+	exitOnAdminError("error resetting password", err)
+
+	slog.Info("password reset", "email", flags.Arg(0))
+	if *password == "" {
+		fmt.Println(pw)
+	}
+}
+
+// runAdminUserCommand runs a command that takes just an email address.
+func runAdminUserCommand(args []string, fn func(ctx context.Context, svc *admin.Service, email string) error, done string) {
+	flags := adminFlags()
+	cfg := loadAdminConfig(flags, args, 1)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	svc := admin.NewService(db.DB, cfg.Auth.BcryptCost)
+	exitOnAdminError("admin command failed", fn(context.Background(), svc, flags.Arg(0)))
+	slog.Info(done, "email", flags.Arg(0))
+}
+
+func runAdminListWorkspaces(args []string) {
+	flags := adminFlags()
+	cfg := loadAdminConfig(flags, args, 0)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	workspaces, err := admin.NewService(db.DB, cfg.Auth.BcryptCost).ListWorkspaces(context.Background())
+	exitOnAdminError("error listing workspaces", err)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tMEMBERS\tOWNERS\tCREATED")
+	for _, ws := range workspaces {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", ws.ID, ws.Name, ws.MemberCount, ws.OwnerCount, ws.CreatedAt.Format("2006-01-02"))
+	}
+	_ = w.Flush()
+}
+
+func runAdminAddMember(args []string) {
+	flags := adminFlags()
+	role := flags.String("role", workspace.RoleMember, "Workspace role: owner, admin, member or guest")
+	cfg := loadAdminConfig(flags, args, 2)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	svc := admin.NewService(db.DB, cfg.Auth.BcryptCost)
+	_, err := svc.AddMember(context.Background(), flags.Arg(0), flags.Arg(1), *role)
+	if errors.Is(err, workspace.ErrMembershipExists) {
+		slog.Error("user is already a member; use promote-owner to change their role", "email", flags.Arg(0))
+		os.Exit(1)
+	}
+	exitOnAdminError("error adding member", err)
+
+	slog.Info("member added", "email", flags.Arg(0), "workspace_id", flags.Arg(1), "role", *role)
+}
+
+func runAdminPromoteOwner(args []string) {
+	flags := adminFlags()
+	cfg := loadAdminConfig(flags, args, 2)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	svc := admin.NewService(db.DB, cfg.Auth.BcryptCost)
+	exitOnAdminError("error promoting member", svc.PromoteToOwner(context.Background(), flags.Arg(0), flags.Arg(1)))
+
+	slog.Info("member promoted to owner", "email", flags.Arg(0), "workspace_id", flags.Arg(1))
+}
+
+// runAdminTestEmail sends synchronously through the configured transport so
+// that delivery errors are reported instead of queued for a retry.
+func runAdminTestEmail(args []string) {
+	flags := adminFlags()
+	cfg := loadAdminConfig(flags, args, 1)
+	if !cfg.Email.Enabled {
+		slog.Error("email is disabled; set email.enabled")
+		os.Exit(1)
+	}
+
+	sender, err := email.NewSender(cfg.Email)
+	exitOnAdminError("error configuring email", err)
+
+	to := flags.Arg(0)
+	transport := cfg.Email.Transport
+	if transport == "" {
+		transport = "smtp"
+	}
+	host, _ := os.Hostname()
+	err = sender.Send(context.Background(), &email.Message{
+		To:      to,
+		Subject: "Enzyme test email",
+		TextBody: "This is a test email from Enzyme.\n\n" +
+			"Sent by `enzyme admin test-email` on " + host + " through the " + transport + " transport.\n",
+	})
+	exitOnAdminError("error sending test email", err)
+
+	slog.Info("test email sent", "to", to, "transport", transport)
+}
+
+func runAdminFailedEmails(args []string) {
+	flags := adminFlags()
+	cfg := loadAdminConfig(flags, args, 0)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	outbox := email.NewOutbox(db.DB)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTO\tSUBJECT\tATTEMPTS\tFAILED\tERROR")
+	cursor := ""
+	for {
+		emails, hasMore, next, err := outbox.List(context.Background(), email.OutboxStatusFailed, cursor, 100)
+		exitOnAdminError("error listing failed emails", err)
+		for _, q := range emails {
+			lastError := ""
+			if q.LastError != nil {
+				lastError = *q.LastError
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", q.ID, q.Message.To, q.Message.Subject, q.Attempts, q.UpdatedAt.Format("2006-01-02 15:04"), orDash(lastError))
+		}
+		if !hasMore {
+			break
+		}
+		cursor = next
+	}
+	_ = w.Flush()
+}
+
+func runAdminRetryEmail(args []string) {
+	flags := adminFlags()
+	cfg := loadAdminConfig(flags, args, 1)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	exitOnAdminError("error retrying email", email.NewOutbox(db.DB).Retry(context.Background(), flags.Arg(0)))
+	slog.Info("email queued for retry; a running server delivers it shortly", "id", flags.Arg(0))
+}
+
+func runAdminMigrateStatus(args []string) {
+	flags := adminFlags()
+	cfg := loadAdminConfig(flags, args, 0)
+
+	// Don't migrate: report the database as it is
+	db, err := database.Open(databaseDSN(cfg), databaseOptions(cfg))
+	exitOnAdminError("error opening database", err)
+	defer db.Close()
+
+	latest, err := database.LatestVersion(db.Dialect)
+	exitOnAdminError("error reading migrations", err)
+
+	current, err := database.SchemaVersion(db.DB)
+	if errors.Is(err, database.ErrNoSchema) {
+		current, err = 0, nil
+	}
+	exitOnAdminError("error reading schema version", err)
+
+	pending, err := db.PendingMigrations(context.Background())
+	exitOnAdminError("error reading migration status", err)
+
+	fmt.Printf("database: %s\napplied:  %d\nlatest:   %d\n", db.Dialect, current, latest)
+	switch {
+	case pending > 0:
+		fmt.Printf("%d pending migration(s); they run when the server starts\n", pending)
+	case current > latest:
+		fmt.Println("database is newer than this binary; upgrade enzyme")
+	default:
+		fmt.Println("up to date")
+	}
+}
+
+func runAdminMigrateDown(args []string) {
+	flags := adminFlags()
+	cfg := loadAdminConfig(flags, args, 0)
+
+	db, err := database.Open(databaseDSN(cfg), databaseOptions(cfg))
+	exitOnAdminError("error opening database", err)
+	defer db.Close()
+
+	exitOnAdminError("error rolling back migration", db.MigrateDown())
+
+	version, err := database.SchemaVersion(db.DB)
+	exitOnAdminError("error reading schema version", err)
+	slog.Info("rolled back one migration", "schema_version", version)
+}
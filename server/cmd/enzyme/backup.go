@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/enzyme/server/internal/backup"
+	"github.com/enzyme/server/internal/config"
+	"github.com/enzyme/server/internal/database"
+	"github.com/enzyme/server/internal/logging"
+)
+
+const backupUsage = `Usage: enzyme backup [flags]
+
+Write a consistent snapshot of the database to backup.dir. Safe to run while
+the server is running.
+
+Flags:
+  --backup.dir DIR            Where to write the backup (default ./data/backups)
+  --backup.include_uploads    Bundle storage.local.path into a .tar.gz archive
+  --no-rotate                 Keep all existing backups instead of applying
+                              backup.keep and backup.max_age
+`
+
+const restoreUsage = `Usage: enzyme restore [flags] <backup-file>
+
+Replace the database with a backup made by "enzyme backup". Stop the server
+first. The current database is kept next to it with a .pre-restore suffix.
+
+Flags:
+  --skip-uploads   Restore only the database from an archive with uploads
+  --force          Restore even if the database appears to be in use
+`
+
+func runBackup(args []string) {
+	flags := config.SetupFlags()
+	noRotate := flags.Bool("no-rotate", false, "Do not delete old backups")
+	flags.Usage = func() { fmt.Fprint(os.Stderr, backupUsage) }
+	if err := flags.Parse(args); err != nil {
+		slog.Error("error parsing flags", "error", err)
+		os.Exit(2)
+	}
+
+	configPath, _ := flags.GetString("config")
+
+	cfg, err := config.Load(configPath, flags)
+	if err != nil {
+		slog.Error("error loading config", "error", err)
+		os.Exit(1)
+	}
+
+	logging.Setup(cfg.Log, false, cfg.Telemetry.ServiceName)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	// Don't migrate: a backup should capture the database exactly as it is
+	db, err := database.Open(databaseDSN(cfg), databaseOptions(cfg))
+	if err != nil {
+		slog.Error("error opening database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	opts := backup.OptionsFromConfig(cfg)
+	b, err := backup.Create(ctx, db.DB, opts)
+	if err != nil {
+		slog.Error("backup failed", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("backup created", "path", b.Path, "size", b.Size, "schema_version", b.Version, "uploads", opts.UploadsDir != "")
+
+	if !*noRotate {
+		removed, err := backup.Rotate(opts.Dir, opts.Keep, opts.MaxAge, b.CreatedAt)
+		for _, p := range removed {
+			slog.Info("removed old backup", "path", p)
+		}
+		if err != nil {
+			slog.Error("error rotating backups", "error", err)
+			os.Exit(1)
+		}
+	}
+}
+
+func runRestore(args []string) {
+	flags := config.SetupFlags()
+	skipUploads := flags.Bool("skip-uploads", false, "Restore only the database")
+	force := flags.Bool("force", false, "Restore even if the database appears to be in use")
+	flags.Usage = func() { fmt.Fprint(os.Stderr, restoreUsage) }
+	if err := flags.Parse(args); err != nil {
+		slog.Error("error parsing flags", "error", err)
+		os.Exit(2)
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, restoreUsage)
+		os.Exit(2)
+	}
+	src := flags.Arg(0)
+
+	configPath, _ := flags.GetString("config")
+
+	cfg, err := config.Load(configPath, flags)
+	if err != nil {
+		slog.Error("error loading config", "error", err)
+		os.Exit(1)
+	}
+
+	logging.Setup(cfg.Log, false, cfg.Telemetry.ServiceName)
+
+	if cfg.Database.Driver != database.DriverSQLite {
+		slog.Error(backup.ErrUnsupported.Error())
+		os.Exit(1)
+	}
+
+	// SQLite removes the shared-memory file when the last connection closes,
+	// so its presence means the server is running (or did not shut down cleanly)
+	if _, err := os.Stat(cfg.Database.Path + "-shm"); err == nil && !*force {
+		slog.Error("database appears to be in use; stop the server first or pass --force", "path", cfg.Database.Path)
+		os.Exit(1)
+	}
+
+	opts := backup.RestoreOptions{DBPath: cfg.Database.Path}
+	if !*skipUploads && cfg.Storage.Type == "local" {
+		opts.UploadsDir = cfg.Storage.Local.Path
+	}
+
+	res, err := backup.Restore(context.Background(), src, opts)
+	if errors.Is(err, backup.ErrNewerSchema) {
+		slog.Error("backup is from a newer version of enzyme; upgrade before restoring it", "error", err)
+		os.Exit(1)
+	}
+	if err != nil {
+		slog.Error("restore failed", "error", err)
+		os.Exit(1)
+	}
+
+	if res.PreviousDB != "" {
+		slog.Info("previous database kept", "path", res.PreviousDB)
+	}
+	if res.PreviousUploads != "" {
+		slog.Info("previous uploads kept", "path", res.PreviousUploads)
+	}
+	slog.Info("restore complete; pending migrations run when the server starts",
+		"database", cfg.Database.Path, "schema_version", res.Version, "uploads", res.Uploads)
+}
@@ -22,6 +22,26 @@ func main() {
 		runSeed(os.Args[2:])
 		return
 	}
+	if len(os.Args) > 1 && os.Args[1] == "storage" {
+		runStorage(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "backup" {
+		runBackup(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "restore" {
+		runRestore(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "tasks" {
+		runTasks(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "admin" {
+		runAdmin(os.Args[2:])
+		return
+	}
 
 	// Setup CLI flags
 	flags := config.SetupFlags()
@@ -72,6 +92,23 @@ func main() {
 		}
 	}()
 
+	// Reload the config file and environment on SIGHUP. CLI flags given at
+	// startup still take precedence.
+	hupCh := make(chan os.Signal, 1)
+	signal.Notify(hupCh, syscall.SIGHUP)
+
+	go func() {
+		for range hupCh {
+			slog.Info("received SIGHUP, reloading config")
+			next, err := config.Load(configPath, flags)
+			if err != nil {
+				slog.Error("config reload failed, keeping the running config", "error", err)
+				continue
+			}
+			application.Reload(next)
+		}
+	}()
+
 	// Start application
 	if err := application.Start(ctx); err != nil && err != http.ErrServerClosed {
 		slog.Error("server error", "error", err)
@@ -100,27 +137,48 @@ func runSeed(args []string) {
 	logging.Setup(cfg.Log, cfg.Telemetry.Enabled && cfg.Telemetry.Logs, cfg.Telemetry.ServiceName)
 
 	// Open database and run migrations (no full app startup)
-	db, err := database.Open(cfg.Database.Path, database.Options{
-		MaxOpenConns:     cfg.Database.MaxOpenConns,
-		BusyTimeout:      cfg.Database.BusyTimeout,
-		CacheSize:        cfg.Database.CacheSize,
-		MmapSize:         cfg.Database.MmapSize,
-		JournalSizeLimit: cfg.Database.JournalSizeLimit,
-	})
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	ctx := context.Background()
+	if err := seed.Run(ctx, db.DB); err != nil {
+		slog.Error("error seeding database", "error", err)
+		os.Exit(1)
+	}
+}
+
+// openDatabase opens the configured database and applies pending migrations,
+// exiting on failure. Used by subcommands that don't start the full app.
+func openDatabase(cfg *config.Config) *database.DB {
+	db, err := database.Open(databaseDSN(cfg), databaseOptions(cfg))
 	if err != nil {
 		slog.Error("error opening database", "error", err)
 		os.Exit(1)
 	}
-	defer db.Close()
 
 	if err := db.Migrate(); err != nil {
+		_ = db.Close()
 		slog.Error("error running migrations", "error", err)
 		os.Exit(1)
 	}
+	return db
+}
 
-	ctx := context.Background()
-	if err := seed.Run(ctx, db.DB); err != nil {
-		slog.Error("error seeding database", "error", err)
-		os.Exit(1)
+func databaseOptions(cfg *config.Config) database.Options {
+	return database.Options{
+		Driver:           cfg.Database.Driver,
+		MaxOpenConns:     cfg.Database.MaxOpenConns,
+		BusyTimeout:      cfg.Database.BusyTimeout,
+		CacheSize:        cfg.Database.CacheSize,
+		MmapSize:         cfg.Database.MmapSize,
+		JournalSizeLimit: cfg.Database.JournalSizeLimit,
+	}
+}
+
+// databaseDSN returns the file path or connection URL for the configured driver.
+func databaseDSN(cfg *config.Config) string {
+	if cfg.Database.Driver == database.DriverPostgres {
+		return cfg.Database.URL
 	}
+	return cfg.Database.Path
 }
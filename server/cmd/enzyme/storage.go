@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/enzyme/server/internal/config"
+	"github.com/enzyme/server/internal/logging"
+	"github.com/enzyme/server/internal/storage"
+	"github.com/enzyme/server/internal/storagemigrate"
+)
+
+const storageUsage = `Usage: enzyme storage <command> [flags]
+
+Commands:
+  generate-key   Print a new random master key for storage.encryption.key
+  rotate-key     Re-wrap all object data keys with the current master key
+  encrypt-existing
+                 Encrypt objects stored before encryption was enabled
+  migrate        Copy all referenced objects between backends, e.g.
+                 enzyme storage migrate --from local --to s3
+
+Migrate flags:
+  --from, --to          Backends to copy between: local or s3 (settings are
+                        read from storage.local.* and storage.s3.*)
+  --concurrency N       Objects copied in parallel (default 4)
+  --dry-run             Report what would be copied without writing
+  --delete-source       Delete source objects after every copy is verified
+  --state PATH          Resume journal (default: next to the SQLite
+                        database; required with PostgreSQL)
+  --orphans             List source objects that no database row references
+  --gc-orphans          Delete those orphaned objects
+
+Objects modified in the last hour are never treated as orphans, since an
+upload may not have been recorded in the database yet.
+
+Rotating the master key:
+  1. Move the current key to storage.encryption.previous_keys and set
+     storage.encryption.key to a new key from "enzyme storage generate-key".
+  2. Restart the server, then run "enzyme storage rotate-key".
+  3. Remove the old key from storage.encryption.previous_keys.
+`
+
+func runStorage(args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, storageUsage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "generate-key":
+		fmt.Println(storage.GenerateMasterKey())
+	case "rotate-key":
+		runStorageRotateKey(args[1:])
+	case "encrypt-existing":
+		runStorageEncryptExisting(args[1:])
+	case "migrate":
+		runStorageMigrate(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(storageUsage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown storage command %q\n\n%s", args[0], storageUsage)
+		os.Exit(2)
+	}
+}
+
+func runStorageRotateKey(args []string) {
+	ctx := context.Background()
+	store := openEncryptedStorage(ctx, args)
+
+	rewrapped, unchanged, failed, err := store.RotateAll(ctx)
+	if err != nil {
+		slog.Error("error rotating storage key", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("storage key rotation complete", "rewrapped", rewrapped, "unchanged", unchanged, "failed", failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func runStorageEncryptExisting(args []string) {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	store := openEncryptedStorage(ctx, args)
+
+	encrypted, skipped, failed, err := store.EncryptExisting(ctx)
+	if err != nil {
+		slog.Error("error encrypting existing objects", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("existing objects encrypted", "encrypted", encrypted, "already_encrypted", skipped, "failed", failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// openEncryptedStorage loads the config and opens the configured storage,
+// exiting unless encryption is enabled.
+func openEncryptedStorage(ctx context.Context, args []string) *storage.Encrypted {
+	flags := config.SetupFlags()
+	if err := flags.Parse(args); err != nil {
+		slog.Error("error parsing flags", "error", err)
+		os.Exit(1)
+	}
+
+	configPath, _ := flags.GetString("config")
+
+	cfg, err := config.Load(configPath, flags)
+	if err != nil {
+		slog.Error("error loading config", "error", err)
+		os.Exit(1)
+	}
+
+	logging.Setup(cfg.Log, false, cfg.Telemetry.ServiceName)
+
+	if cfg.Storage.Type == "off" || !cfg.Storage.Encryption.Enabled {
+		slog.Error("storage encryption is not enabled")
+		os.Exit(1)
+	}
+
+	store, err := storage.New(ctx, cfg.Storage)
+	if err != nil {
+		slog.Error("error opening storage", "error", err)
+		os.Exit(1)
+	}
+	return store.(*storage.Encrypted)
+}
+
+func runStorageMigrate(args []string) {
+	flags := config.SetupFlags()
+	from := flags.String("from", "", "Source backend: local or s3")
+	to := flags.String("to", "", "Destination backend: local or s3")
+	concurrency := flags.Int("concurrency", storagemigrate.DefaultConcurrency, "Objects copied in parallel")
+	dryRun := flags.Bool("dry-run", false, "Report what would be copied without writing")
+	deleteSource := flags.Bool("delete-source", false, "Delete source objects after a verified copy")
+	statePath := flags.String("state", "", "Resume journal path")
+	orphans := flags.Bool("orphans", false, "List unreferenced objects in the source backend")
+	gcOrphans := flags.Bool("gc-orphans", false, "Delete unreferenced objects in the source backend")
+	if err := flags.Parse(args); err != nil {
+		slog.Error("error parsing flags", "error", err)
+		os.Exit(1)
+	}
+
+	if *from == "" || *to == "" || *from == *to {
+		slog.Error("--from and --to must name two different backends (local, s3)")
+		os.Exit(2)
+	}
+
+	configPath, _ := flags.GetString("config")
+
+	cfg, err := config.Load(configPath, flags)
+	if err != nil {
+		slog.Error("error loading config", "error", err)
+		os.Exit(1)
+	}
+
+	logging.Setup(cfg.Log, false, cfg.Telemetry.ServiceName)
+
+	if *statePath == "" {
+		if cfg.Database.Driver != "sqlite" {
+			slog.Error("--state is required with a PostgreSQL database")
+			os.Exit(2)
+		}
+		*statePath = filepath.Join(filepath.Dir(cfg.Database.Path), ".storage-migrate-"+*from+"-"+*to+".state")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	src, err := openRawBackend(ctx, cfg.Storage, *from)
+	if err != nil {
+		slog.Error("error opening source storage", "error", err)
+		os.Exit(1)
+	}
+	dst, err := openRawBackend(ctx, cfg.Storage, *to)
+	if err != nil {
+		slog.Error("error opening destination storage", "error", err)
+		os.Exit(1)
+	}
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	m := storagemigrate.New(db.DB, src, dst)
+	report, err := m.Run(ctx, storagemigrate.Options{
+		Concurrency:  *concurrency,
+		DryRun:       *dryRun,
+		DeleteSource: *deleteSource,
+		StatePath:    *statePath,
+	})
+	if report != nil {
+		for _, key := range report.Missing {
+			slog.Warn("referenced object missing from source", "key", key)
+		}
+		for _, f := range report.Failed {
+			slog.Error("object failed to migrate", "key", f.Key, "error", f.Err)
+		}
+		slog.Info("storage migration finished",
+			"dry_run", *dryRun,
+			"referenced", report.Referenced,
+			"copied", report.Copied,
+			"resumed", report.Resumed,
+			"bytes", report.Bytes,
+			"missing", len(report.Missing),
+			"failed", len(report.Failed),
+			"deleted", report.Deleted,
+		)
+	}
+	if err != nil {
+		slog.Error("storage migration failed", "error", err)
+		os.Exit(1)
+	}
+
+	if *orphans || *gcOrphans {
+		keys, err := m.Orphans(ctx, storagemigrate.OrphanGracePeriod)
+		if err != nil {
+			slog.Error("error listing orphaned objects", "error", err)
+			os.Exit(1)
+		}
+		for _, key := range keys {
+			fmt.Println(key)
+		}
+		slog.Info("orphaned objects in source", "count", len(keys))
+		if *gcOrphans && !*dryRun {
+			n, err := m.DeleteOrphans(ctx, keys)
+			if err != nil {
+				slog.Error("error deleting orphaned objects", "deleted", n, "error", err)
+				os.Exit(1)
+			}
+			slog.Info("deleted orphaned objects", "count", n)
+		}
+	}
+
+	if len(report.Failed) > 0 {
+		os.Exit(1)
+	}
+	if !*dryRun {
+		slog.Info("set storage.type to the new backend and restart the server", "storage.type", *to)
+	}
+}
+
+// openRawBackend opens a backend without the encryption wrapper, so objects
+// are copied byte for byte.
+func openRawBackend(ctx context.Context, cfg config.StorageConfig, typ string) (storage.Storage, error) {
+	switch typ {
+	case "local":
+		if cfg.Local.Path == "" {
+			return nil, fmt.Errorf("storage.local.path is not configured")
+		}
+		return storage.NewLocal(cfg.Local.Path), nil
+	case "s3":
+		if cfg.S3.Endpoint == "" || cfg.S3.Bucket == "" {
+			return nil, fmt.Errorf("storage.s3.endpoint and storage.s3.bucket must be configured")
+		}
+		s3Store, err := storage.NewS3(cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+		if err := s3Store.CheckConnectivity(ctx); err != nil {
+			return nil, err
+		}
+		return s3Store, nil
+	default:
+		return nil, fmt.Errorf("unknown backend %q (want local or s3)", typ)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/enzyme/server/internal/config"
+	"github.com/enzyme/server/internal/logging"
+	"github.com/enzyme/server/internal/scheduler"
+)
+
+const tasksUsage = `Usage: enzyme tasks [flags]
+
+Show background task status across all instances sharing the database: which
+instance holds each task, and when and how it last ran.
+`
+
+func runTasks(args []string) {
+	flags := config.SetupFlags()
+	flags.Usage = func() { fmt.Fprint(os.Stderr, tasksUsage) }
+	if err := flags.Parse(args); err != nil {
+		slog.Error("error parsing flags", "error", err)
+		os.Exit(2)
+	}
+
+	configPath, _ := flags.GetString("config")
+
+	cfg, err := config.Load(configPath, flags)
+	if err != nil {
+		slog.Error("error loading config", "error", err)
+		os.Exit(1)
+	}
+
+	logging.Setup(cfg.Log, false, cfg.Telemetry.ServiceName)
+
+	db := openDatabase(cfg)
+	defer db.Close()
+
+	statuses, err := scheduler.NewLeases(db.DB, "").List(context.Background())
+	if err != nil {
+		slog.Error("error listing tasks", "error", err)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TASK\tOWNER\tLAST RUN\tDURATION\tRUN BY\tERROR")
+	for _, st := range statuses {
+		owner := "-"
+		if st.Owner != "" && st.LeaseExpiresAt != nil && st.LeaseExpiresAt.After(now) {
+			owner = st.Owner
+		}
+		lastRun, duration := "never", "-"
+		if st.LastRunAt != nil {
+			lastRun = now.Sub(*st.LastRunAt).Truncate(time.Second).String() + " ago"
+			duration = st.LastDuration.String()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", st.Name, owner, lastRun, duration, orDash(st.LastRunBy), orDash(st.LastError))
+	}
+	_ = w.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
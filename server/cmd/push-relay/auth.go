@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/enzyme/server/internal/relayauth"
+	"golang.org/x/time/rate"
+)
+
+// serverCacheTTL bounds how long a revoked key or changed quota takes to
+// apply, since servers are cached rather than read on every request.
+// Unknown keys are cached for missingCacheTTL, up to maxMissingKeys of them,
+// so requests with made-up key IDs don't each reach the store.
+const (
+	serverCacheTTL  = time.Minute
+	missingCacheTTL = 10 * time.Second
+	maxMissingKeys  = 10_000
+)
+
+type serverKeyIDKey struct{}
+
+type serverState struct {
+	server   *Server
+	loadedAt time.Time
+	limiter  *rate.Limiter
+	day      string // UTC day that used counts towards
+	used     int64  // requests admitted against the daily quota
+}
+
+// Authenticator verifies signed requests from registered servers and enforces
+// each server's rate limit and daily quota. Unsigned requests are refused or,
+// unless authentication is required, limited per IP as before. Failed
+// authentications count against the same per-IP limit, so guessing keys is
+// no faster than sending unsigned requests.
+type Authenticator struct {
+	store       *Store
+	anonymous   *RateLimiter
+	requireAuth bool
+	now         func() time.Time
+
+	mu      sync.Mutex
+	servers map[string]*serverState
+	missing map[string]time.Time // unknown key IDs and when they were looked up
+}
+
+// NewAuthenticator creates an Authenticator. anonymous limits unsigned
+// requests when requireAuth is false.
+func NewAuthenticator(store *Store, anonymous *RateLimiter, requireAuth bool) *Authenticator {
+	return &Authenticator{
+		store:       store,
+		anonymous:   anonymous,
+		requireAuth: requireAuth,
+		now:         time.Now,
+		servers:     make(map[string]*serverState),
+		missing:     make(map[string]time.Time),
+	}
+}
+
+// Middleware returns an HTTP middleware that authenticates the request and
+// applies the server's quota.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID := r.Header.Get(relayauth.HeaderKeyID)
+		if keyID == "" {
+			if a.requireAuth {
+				writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "authentication required"})
+				return
+			}
+			a.anonymous.Middleware(next).ServeHTTP(w, r)
+			return
+		}
+
+		// An IP that has used up its allowance on failed attempts is turned
+		// away before the key is looked up
+		ip := clientIP(r)
+		if a.anonymous.Exhausted(ip) {
+			a.anonymous.refuse(w)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+		if err != nil {
+			writeJSON(w, http.StatusRequestEntityTooLarge, map[string]string{"error": "request body too large"})
+			return
+		}
+
+		state, err := a.lookup(r.Context(), keyID)
+		if errors.Is(err, ErrServerNotFound) {
+			a.anonymous.Spend(ip)
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid credentials"})
+			return
+		}
+		if err != nil {
+			slog.Error("failed to look up server", "key_id", keyID, "error", err)
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+			return
+		}
+		if err := relayauth.Verify(state.server.Secret, r.Header, body, a.now()); err != nil {
+			a.anonymous.Spend(ip)
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid credentials"})
+			return
+		}
+
+		if retryAfter, ok := a.admit(state); !ok {
+			a.record(r.Context(), keyID, Usage{Throttled: 1})
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			writeJSON(w, http.StatusTooManyRequests, map[string]string{"error": "quota exceeded"})
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), serverKeyIDKey{}, keyID)))
+	})
+}
+
+// lookup returns the cached state for a server, reloading it from the store
+// when stale. Revoked and unknown keys return ErrServerNotFound.
+func (a *Authenticator) lookup(ctx context.Context, keyID string) (*serverState, error) {
+	now := a.now()
+
+	a.mu.Lock()
+	state, ok := a.servers[keyID]
+	fresh := ok && now.Sub(state.loadedAt) < serverCacheTTL
+	missingAt, missing := a.missing[keyID]
+	a.mu.Unlock()
+
+	if missing && now.Sub(missingAt) < missingCacheTTL {
+		return nil, ErrServerNotFound
+	}
+
+	if !fresh {
+		srv, err := a.store.GetServer(ctx, keyID)
+		if errors.Is(err, ErrServerNotFound) {
+			a.mu.Lock()
+			delete(a.servers, keyID)
+			if len(a.missing) >= maxMissingKeys {
+				clear(a.missing)
+			}
+			a.missing[keyID] = now
+			a.mu.Unlock()
+			return nil, err
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var used int64
+		if !ok {
+			// Pick up today's count so a restart doesn't reset the quota
+			usage, err := a.store.GetUsage(ctx, keyID, usageDay(now))
+			if err != nil {
+				return nil, err
+			}
+			used = usage.Total()
+		}
+
+		a.mu.Lock()
+		delete(a.missing, keyID)
+		if current, exists := a.servers[keyID]; exists {
+			state = current
+		} else {
+			state = &serverState{day: usageDay(now), used: used}
+			a.servers[keyID] = state
+		}
+		if state.limiter == nil || state.server.RateLimit != srv.RateLimit {
+			state.limiter = newServerLimiter(srv.RateLimit)
+		}
+		state.server = srv
+		state.loadedAt = now
+		a.mu.Unlock()
+	}
+
+	if state.server.RevokedAt != nil {
+		return nil, ErrServerNotFound
+	}
+	return state, nil
+}
+
+// newServerLimiter allows bursts of a tenth of a minute's allowance, so a
+// message to a busy channel isn't throttled when it fans out to many devices.
+func newServerLimiter(perMinute int) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(float64(perMinute)/60.0), max(1, perMinute/10))
+}
+
+// admit counts a request against the server's rate limit and daily quota.
+// When refused, it returns the seconds until the server may retry.
+func (a *Authenticator) admit(state *serverState) (int, bool) {
+	now := a.now()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if day := usageDay(now); state.day != day {
+		state.day = day
+		state.used = 0
+	}
+	if quota := state.server.DailyQuota; quota > 0 && state.used >= int64(quota) {
+		midnight := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+		return max(1, int(midnight.Sub(now).Seconds())), false
+	}
+	if !state.limiter.AllowN(now, 1) {
+		return max(1, int(60/max(1, state.server.RateLimit))), false
+	}
+	state.used++
+	return 0, true
+}
+
+// RecordOutcome counts the outcome of an authenticated request towards the
+// server's usage. It does nothing for unsigned requests or a nil receiver.
+func (a *Authenticator) RecordOutcome(ctx context.Context, status string) {
+	if a == nil {
+		return
+	}
+	keyID, ok := ctx.Value(serverKeyIDKey{}).(string)
+	if !ok {
+		return
+	}
+	var u Usage
+	switch status {
+	case "sent":
+		u.Sent = 1
+	case "invalid_token":
+		u.InvalidToken = 1
+	default:
+		u.Failed = 1
+	}
+	a.record(ctx, keyID, u)
+}
+
+func (a *Authenticator) record(ctx context.Context, keyID string, u Usage) {
+	// Count the request even if the client has gone away
+	ctx = context.WithoutCancel(ctx)
+	if err := a.store.RecordUsage(ctx, keyID, usageDay(a.now()), u); err != nil {
+		slog.Error("failed to record usage", "key_id", keyID, "error", err)
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/relayauth"
+)
+
+const authTestBody = `{"device_token":"tok123","platform":"fcm","title":"Hello","body":"World"}`
+
+// setupAuthRouter creates a router that authenticates servers against a
+// temporary store.
+func setupAuthRouter(t *testing.T, requireAuth bool) (http.Handler, *Store) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	store := testStore(t)
+	auth := NewAuthenticator(store, NewRateLimiter(ctx, 100000, 100000), requireAuth)
+	return newRouter(&mockDispatcher{status: "sent"}, nil, nil, auth, false), store
+}
+
+func signedNotify(cred relayauth.Credential, body string, at time.Time) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	cred.SignRequest(req, []byte(body), at)
+	return req
+}
+
+func TestAuth_SignedRequest(t *testing.T) {
+	handler, store := setupAuthRouter(t, true)
+	cred, err := store.CreateServer(context.Background(), "chat.example.com", 600, 0)
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, signedNotify(cred, authTestBody, time.Now()))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp NotifyResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "sent" {
+		t.Errorf("expected status \"sent\", got %q", resp.Status)
+	}
+
+	usage, err := store.GetUsage(context.Background(), cred.KeyID, usageDay(time.Now()))
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if usage.Sent != 1 {
+		t.Errorf("expected 1 sent notification recorded, got %+v", usage)
+	}
+}
+
+func TestAuth_RejectsBadCredentials(t *testing.T) {
+	handler, store := setupAuthRouter(t, true)
+	cred, err := store.CreateServer(context.Background(), "chat.example.com", 600, 0)
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *http.Request
+	}{
+		{"unsigned", httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(authTestBody))},
+		{"unknown key", signedNotify(relayauth.Credential{KeyID: "rk_unknown", Secret: cred.Secret}, authTestBody, time.Now())},
+		{"wrong secret", signedNotify(relayauth.Credential{KeyID: cred.KeyID, Secret: "wrong"}, authTestBody, time.Now())},
+		{"stale timestamp", signedNotify(cred, authTestBody, time.Now().Add(-time.Hour))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, tt.req)
+			if w.Code != http.StatusUnauthorized {
+				t.Errorf("expected 401, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestAuth_FailuresCountAgainstIPLimit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	store := testStore(t)
+	handler := newRouter(&mockDispatcher{status: "sent"}, nil, nil, NewAuthenticator(store, NewRateLimiter(ctx, 1, 2), true), false)
+	cred, err := store.CreateServer(context.Background(), "chat.example.com", 600, 0)
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+
+	// Valid requests don't use up the IP's allowance
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, signedNotify(cred, authTestBody, time.Now()))
+		if w.Code != http.StatusOK {
+			t.Fatalf("signed request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+
+	guess := relayauth.Credential{KeyID: "rk_unknown", Secret: cred.Secret}
+	for i, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, signedNotify(guess, authTestBody, time.Now()))
+		if w.Code != want {
+			t.Fatalf("guess %d: expected %d, got %d", i+1, want, w.Code)
+		}
+	}
+
+	// The IP stays blocked even with a valid key until its allowance refills
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, signedNotify(cred, authTestBody, time.Now()))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 after failed attempts, got %d", w.Code)
+	}
+}
+
+func TestAuth_CachesUnknownKeys(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	auth := NewAuthenticator(testStore(t), NewRateLimiter(ctx, 100000, 100000), true)
+	now := time.Now()
+	auth.now = func() time.Time { return now }
+
+	if _, err := auth.lookup(ctx, "rk_unknown"); !errors.Is(err, ErrServerNotFound) {
+		t.Fatalf("lookup: expected ErrServerNotFound, got %v", err)
+	}
+	if _, ok := auth.missing["rk_unknown"]; !ok {
+		t.Fatal("unknown key was not cached")
+	}
+
+	// Within the TTL the store isn't consulted, so a closed store isn't noticed
+	_ = auth.store.Close()
+	if _, err := auth.lookup(ctx, "rk_unknown"); !errors.Is(err, ErrServerNotFound) {
+		t.Fatalf("cached lookup: expected ErrServerNotFound, got %v", err)
+	}
+	now = now.Add(missingCacheTTL)
+	if _, err := auth.lookup(ctx, "rk_unknown"); err == nil || errors.Is(err, ErrServerNotFound) {
+		t.Fatalf("lookup after the TTL: expected a store error, got %v", err)
+	}
+}
+
+func TestAuth_RevokedServer(t *testing.T) {
+	handler, store := setupAuthRouter(t, true)
+	cred, err := store.CreateServer(context.Background(), "chat.example.com", 600, 0)
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+	if err := store.RevokeServer(context.Background(), cred.KeyID); err != nil {
+		t.Fatalf("RevokeServer: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, signedNotify(cred, authTestBody, time.Now()))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a revoked key, got %d", w.Code)
+	}
+}
+
+func TestAuth_DailyQuota(t *testing.T) {
+	handler, store := setupAuthRouter(t, true)
+	cred, err := store.CreateServer(context.Background(), "chat.example.com", 600, 2)
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+
+	for i := range 2 {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, signedNotify(cred, authTestBody, time.Now()))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, signedNotify(cred, authTestBody, time.Now()))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 over the daily quota, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+
+	usage, _ := store.GetUsage(context.Background(), cred.KeyID, usageDay(time.Now()))
+	if usage.Sent != 2 || usage.Throttled != 1 {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestAuth_QuotaIsPerServer(t *testing.T) {
+	handler, store := setupAuthRouter(t, true)
+	ctx := context.Background()
+	busy, err := store.CreateServer(ctx, "busy.example.com", 10, 0) // burst of 1
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+	quiet, err := store.CreateServer(ctx, "quiet.example.com", 10, 0)
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+
+	// Both requests come from the same IP
+	codes := make([]int, 0, 3)
+	for _, cred := range []relayauth.Credential{busy, busy, quiet} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, signedNotify(cred, authTestBody, time.Now()))
+		codes = append(codes, w.Code)
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests || codes[2] != http.StatusOK {
+		t.Errorf("expected [200 429 200], got %v", codes)
+	}
+}
+
+func TestAuth_OptionalAllowsUnsigned(t *testing.T) {
+	handler, _ := setupAuthRouter(t, false)
+
+	req := httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(authTestBody))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected unsigned request to be allowed, got %d", w.Code)
+	}
+}
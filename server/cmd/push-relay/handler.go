@@ -16,6 +16,7 @@ type Dispatcher interface {
 type notifyHandler struct {
 	fcm  Dispatcher
 	apns Dispatcher
+	auth *Authenticator // nil when servers aren't registered
 }
 
 func (h *notifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -41,6 +42,7 @@ func (h *notifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch req.Platform {
 	case "fcm":
 		if h.fcm == nil {
+			h.auth.RecordOutcome(r.Context(), "error")
 			writeJSON(w, http.StatusServiceUnavailable, NotifyResponse{
 				Status: "error",
 				Error:  "FCM client not configured",
@@ -50,6 +52,7 @@ func (h *notifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		status, sendErr = h.fcm.Send(r.Context(), &req)
 	case "apns":
 		if h.apns == nil {
+			h.auth.RecordOutcome(r.Context(), "error")
 			writeJSON(w, http.StatusServiceUnavailable, NotifyResponse{
 				Status: "error",
 				Error:  "APNs client not configured",
@@ -60,6 +63,7 @@ func (h *notifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if sendErr != nil {
+		h.auth.RecordOutcome(r.Context(), "error")
 		slog.Error("push dispatch failed",
 			"platform", req.Platform,
 			"error", sendErr.Error(),
@@ -69,6 +73,7 @@ func (h *notifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.auth.RecordOutcome(r.Context(), status)
 	slog.Info("push notification dispatched",
 		"platform", req.Platform,
 		"status", status,
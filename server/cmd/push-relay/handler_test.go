@@ -25,7 +25,7 @@ func setupTestRouter(t *testing.T, fcm, apns Dispatcher) http.Handler {
 	ctx, cancel := context.WithCancel(context.Background())
 	t.Cleanup(cancel)
 	rl := NewRateLimiter(ctx, 100000, 100000)
-	return newRouter(fcm, apns, rl, false)
+	return newRouter(fcm, apns, rl, nil, false)
 }
 
 func TestHandler_ValidFCMRequest(t *testing.T) {
@@ -12,6 +12,12 @@ import (
 )
 
 func main() {
+	// Check for subcommands before reading the environment.
+	if len(os.Args) > 1 && os.Args[1] == "servers" {
+		runServers(os.Args[2:])
+		return
+	}
+
 	// Configure logging.
 	var level slog.Level
 	_ = level.UnmarshalText([]byte(envOr("RELAY_LOG_LEVEL", "info")))
@@ -79,9 +85,28 @@ func main() {
 	}
 	rateLimiter := NewRateLimiter(ctx, rateLimit, burst)
 
+	// Server registry (optional — without it the relay accepts anyone's requests).
+	requireAuth := envOr("RELAY_REQUIRE_AUTH", "false") == "true"
+	var auth *Authenticator
+	if dbPath := os.Getenv("RELAY_DB_PATH"); dbPath != "" {
+		store, err := OpenStore(dbPath)
+		if err != nil {
+			slog.Error("failed to open server store", "path", dbPath, "error", err)
+			os.Exit(1)
+		}
+		defer store.Close()
+		auth = NewAuthenticator(store, rateLimiter, requireAuth)
+		slog.Info("server authentication enabled", "path", dbPath, "require_auth", requireAuth)
+	} else if requireAuth {
+		slog.Error("RELAY_DB_PATH is required when RELAY_REQUIRE_AUTH is true")
+		os.Exit(1)
+	} else {
+		slog.Warn("RELAY_DB_PATH not set, accepting unsigned requests from anyone")
+	}
+
 	// Router.
 	trustProxy := envOr("RELAY_TRUST_PROXY", "false") == "true"
-	router := newRouter(fcm, apns, rateLimiter, trustProxy)
+	router := newRouter(fcm, apns, rateLimiter, auth, trustProxy)
 
 	// HTTP server.
 	port := envOr("RELAY_PORT", "8090")
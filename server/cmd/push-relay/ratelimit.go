@@ -44,16 +44,8 @@ func NewRateLimiter(ctx context.Context, requestsPerMinute int, burst int) *Rate
 // Middleware returns an HTTP middleware that enforces the rate limit.
 func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ip := r.RemoteAddr
-		if host, _, err := net.SplitHostPort(ip); err == nil {
-			ip = host
-		}
-
-		limiter := rl.getLimiter(ip)
-		if !limiter.Allow() {
-			retryAfter := max(1, int(1.0/float64(rl.limit)))
-			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
-			http.Error(w, `{"error":"rate limit exceeded"}`, http.StatusTooManyRequests)
+		if !rl.getLimiter(clientIP(r)).Allow() {
+			rl.refuse(w)
 			return
 		}
 
@@ -61,6 +53,31 @@ func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// Exhausted reports whether the IP has no allowance left, without spending
+// any of it.
+func (rl *RateLimiter) Exhausted(ip string) bool {
+	return rl.getLimiter(ip).Tokens() < 1
+}
+
+// Spend counts a request against the IP's allowance.
+func (rl *RateLimiter) Spend(ip string) {
+	rl.getLimiter(ip).Allow()
+}
+
+func (rl *RateLimiter) refuse(w http.ResponseWriter) {
+	retryAfter := max(1, int(1.0/float64(rl.limit)))
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	http.Error(w, `{"error":"rate limit exceeded"}`, http.StatusTooManyRequests)
+}
+
+func clientIP(r *http.Request) string {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return ip
+}
+
 func (rl *RateLimiter) getLimiter(ip string) *rate.Limiter {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
@@ -8,7 +8,9 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 )
 
-func newRouter(fcm, apns Dispatcher, rateLimiter *RateLimiter, trustProxy bool) http.Handler {
+// newRouter builds the relay's routes. If auth is nil, /notify is open to
+// anyone and limited per IP.
+func newRouter(fcm, apns Dispatcher, rateLimiter *RateLimiter, auth *Authenticator, trustProxy bool) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(middleware.Recoverer)
@@ -26,7 +28,12 @@ func newRouter(fcm, apns Dispatcher, rateLimiter *RateLimiter, trustProxy bool)
 		json.NewEncoder(w).Encode(resp) //nolint:errcheck
 	})
 
-	r.With(rateLimiter.Middleware).Post("/notify", (&notifyHandler{fcm: fcm, apns: apns}).ServeHTTP)
+	notify := &notifyHandler{fcm: fcm, apns: apns, auth: auth}
+	if auth != nil {
+		r.With(auth.Middleware).Post("/notify", notify.ServeHTTP)
+	} else {
+		r.With(rateLimiter.Middleware).Post("/notify", notify.ServeHTTP)
+	}
 
 	return r
 }
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const serversUsage = `Usage: push-relay servers <command> [flags] [args]
+
+Manage the Enzyme servers allowed to send through this relay. Servers sign
+requests with the key issued here, and quotas apply per server.
+
+Commands:
+  add NAME [--rate-limit N] [--daily-quota N]   Register a server and print its key
+  list                                          Show servers and their usage
+  set-quota KEY_ID [--rate-limit N] [--daily-quota N]
+  revoke KEY_ID                                 Refuse the server's requests
+
+--rate-limit is in requests per minute (default 600). --daily-quota caps the
+notifications sent per UTC day; 0 (the default) means unlimited. Revocations
+and quota changes reach a running relay within a minute.
+
+All commands accept --db, which defaults to $RELAY_DB_PATH.
+`
+
+const (
+	defaultServerRateLimit = 600
+	usageWindowDays        = 30
+)
+
+func runServers(args []string) {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, serversUsage)
+		os.Exit(2)
+	}
+
+	switch args[0] {
+	case "add":
+		runServersAdd(args[1:])
+	case "list":
+		runServersList(args[1:])
+	case "set-quota":
+		runServersSetQuota(args[1:])
+	case "revoke":
+		runServersRevoke(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(serversUsage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown servers command %q\n\n%s", args[0], serversUsage)
+		os.Exit(2)
+	}
+}
+
+// serversFlags returns a flag set with --db, plus the quota flags if asked.
+func serversFlags(name string, quotas bool) (*pflag.FlagSet, *string, *int, *int) {
+	flags := pflag.NewFlagSet(name, pflag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, serversUsage) }
+	db := flags.String("db", os.Getenv("RELAY_DB_PATH"), "path to the relay's server database")
+	var rateLimit, dailyQuota *int
+	if quotas {
+		rateLimit = flags.Int("rate-limit", defaultServerRateLimit, "requests per minute")
+		dailyQuota = flags.Int("daily-quota", 0, "notifications per UTC day, 0 for unlimited")
+	}
+	return flags, db, rateLimit, dailyQuota
+}
+
+func openServersStore(path string) *Store {
+	if path == "" {
+		fmt.Fprintln(os.Stderr, "error: --db or RELAY_DB_PATH is required")
+		os.Exit(2)
+	}
+	store, err := OpenStore(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	return store
+}
+
+func validateQuotas(rateLimit, dailyQuota int) {
+	if rateLimit <= 0 {
+		fmt.Fprintln(os.Stderr, "error: --rate-limit must be positive")
+		os.Exit(2)
+	}
+	if dailyQuota < 0 {
+		fmt.Fprintln(os.Stderr, "error: --daily-quota must not be negative")
+		os.Exit(2)
+	}
+}
+
+func runServersAdd(args []string) {
+	flags, db, rateLimit, dailyQuota := serversFlags("add", true)
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, serversUsage)
+		os.Exit(2)
+	}
+	validateQuotas(*rateLimit, *dailyQuota)
+
+	store := openServersStore(*db)
+	defer store.Close()
+
+	cred, err := store.CreateServer(context.Background(), flags.Arg(0), *rateLimit, *dailyQuota)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Registered %q as %s.\n\n", flags.Arg(0), cred.KeyID)
+	fmt.Println("Set this key on the Enzyme server as push_notifications.relay_key. It won't be")
+	fmt.Println("shown again:")
+	fmt.Printf("\n  %s\n", cred)
+}
+
+func runServersList(args []string) {
+	flags, db, _, _ := serversFlags("list", false)
+	_ = flags.Parse(args)
+
+	store := openServersStore(*db)
+	defer store.Close()
+
+	ctx := context.Background()
+	servers, err := store.ListServers(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	now := time.Now()
+	today := usageDay(now)
+	since := usageDay(now.AddDate(0, 0, -(usageWindowDays - 1)))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "KEY ID\tNAME\tSTATUS\tRATE LIMIT\tDAILY QUOTA\tTODAY\tLAST %d DAYS\tINVALID\tFAILED\tTHROTTLED\n", usageWindowDays)
+	for _, srv := range servers {
+		status := "active"
+		if srv.RevokedAt != nil {
+			status = "revoked"
+		}
+		quota := "unlimited"
+		if srv.DailyQuota > 0 {
+			quota = fmt.Sprint(srv.DailyQuota)
+		}
+		day, err := store.GetUsage(ctx, srv.KeyID, today)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		window, err := store.SumUsage(ctx, srv.KeyID, since)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d/min\t%s\t%d\t%d\t%d\t%d\t%d\n",
+			srv.KeyID, srv.Name, status, srv.RateLimit, quota,
+			day.Total(), window.Total(), window.InvalidToken, window.Failed, window.Throttled)
+	}
+	w.Flush()
+}
+
+func runServersSetQuota(args []string) {
+	flags, db, rateLimit, dailyQuota := serversFlags("set-quota", true)
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 || (!flags.Changed("rate-limit") && !flags.Changed("daily-quota")) {
+		fmt.Fprint(os.Stderr, serversUsage)
+		os.Exit(2)
+	}
+
+	store := openServersStore(*db)
+	defer store.Close()
+
+	ctx := context.Background()
+	srv, err := store.GetServer(ctx, flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if flags.Changed("rate-limit") {
+		srv.RateLimit = *rateLimit
+	}
+	if flags.Changed("daily-quota") {
+		srv.DailyQuota = *dailyQuota
+	}
+	validateQuotas(srv.RateLimit, srv.DailyQuota)
+
+	if err := store.SetQuota(ctx, srv.KeyID, srv.RateLimit, srv.DailyQuota); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Quota updated.")
+}
+
+func runServersRevoke(args []string) {
+	flags, db, _, _ := serversFlags("revoke", false)
+	_ = flags.Parse(args)
+	if flags.NArg() != 1 {
+		fmt.Fprint(os.Stderr, serversUsage)
+		os.Exit(2)
+	}
+
+	store := openServersStore(*db)
+	defer store.Close()
+
+	if err := store.RevokeServer(context.Background(), flags.Arg(0)); err != nil {
+		if errors.Is(err, ErrServerNotFound) {
+			fmt.Fprintln(os.Stderr, "error: no active server with that key ID")
+		} else {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	fmt.Println("Server revoked.")
+}
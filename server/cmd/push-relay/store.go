@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/enzyme/server/internal/relayauth"
+
+	_ "modernc.org/sqlite"
+)
+
+var ErrServerNotFound = errors.New("server not found")
+
+const storeSchema = `
+CREATE TABLE IF NOT EXISTS servers (
+    key_id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    rate_limit INTEGER NOT NULL,
+    daily_quota INTEGER NOT NULL,
+    created_at TEXT NOT NULL,
+    revoked_at TEXT
+);
+
+CREATE TABLE IF NOT EXISTS usage (
+    key_id TEXT NOT NULL REFERENCES servers(key_id) ON DELETE CASCADE,
+    day TEXT NOT NULL,
+    sent INTEGER NOT NULL DEFAULT 0,
+    invalid_token INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    throttled INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (key_id, day)
+);
+`
+
+// Server is an Enzyme server registered with the relay.
+type Server struct {
+	KeyID      string
+	Name       string
+	Secret     string
+	RateLimit  int // requests per minute
+	DailyQuota int // notifications per UTC day, 0 for unlimited
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// Usage counts a server's requests on one UTC day by outcome.
+type Usage struct {
+	Sent         int64
+	InvalidToken int64
+	Failed       int64
+	Throttled    int64 // refused by the rate limit or daily quota
+}
+
+// Total is the number of requests counted against the daily quota.
+func (u Usage) Total() int64 {
+	return u.Sent + u.InvalidToken + u.Failed
+}
+
+// Store persists registered servers and their usage in a SQLite file.
+type Store struct {
+	db *sql.DB
+}
+
+// OpenStore opens the store, creating the file and schema if needed.
+func OpenStore(path string) (*Store, error) {
+	dsn := path + "?_txlock=immediate&_pragma=journal_mode%28WAL%29&_pragma=busy_timeout%285000%29&_pragma=foreign_keys%28ON%29"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("opening store: %w", err)
+	}
+	if _, err := db.Exec(storeSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("creating schema: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// CreateServer registers a server and returns its newly issued credential.
+func (s *Store) CreateServer(ctx context.Context, name string, rateLimit, dailyQuota int) (relayauth.Credential, error) {
+	cred, err := relayauth.Generate()
+	if err != nil {
+		return relayauth.Credential{}, err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO servers (key_id, name, secret, rate_limit, daily_quota, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, cred.KeyID, name, cred.Secret, rateLimit, dailyQuota, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return relayauth.Credential{}, err
+	}
+	return cred, nil
+}
+
+// RevokeServer revokes a server's key. Requests signed with it are refused
+// once the relay's cache of the server expires.
+func (s *Store) RevokeServer(ctx context.Context, keyID string) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE servers SET revoked_at = ? WHERE key_id = ? AND revoked_at IS NULL
+	`, time.Now().UTC().Format(time.RFC3339), keyID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrServerNotFound
+	}
+	return nil
+}
+
+// SetQuota changes a server's rate limit and daily quota.
+func (s *Store) SetQuota(ctx context.Context, keyID string, rateLimit, dailyQuota int) error {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE servers SET rate_limit = ?, daily_quota = ? WHERE key_id = ?
+	`, rateLimit, dailyQuota, keyID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrServerNotFound
+	}
+	return nil
+}
+
+// GetServer returns a server by key ID, including revoked ones.
+func (s *Store) GetServer(ctx context.Context, keyID string) (*Server, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT key_id, name, secret, rate_limit, daily_quota, created_at, revoked_at
+		FROM servers WHERE key_id = ?
+	`, keyID)
+	srv, err := scanServer(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrServerNotFound
+	}
+	return srv, err
+}
+
+// ListServers returns all servers, oldest first.
+func (s *Store) ListServers(ctx context.Context) ([]*Server, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT key_id, name, secret, rate_limit, daily_quota, created_at, revoked_at
+		FROM servers ORDER BY created_at, key_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var servers []*Server
+	for rows.Next() {
+		srv, err := scanServer(rows)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, srv)
+	}
+	return servers, rows.Err()
+}
+
+func scanServer(row interface{ Scan(...any) error }) (*Server, error) {
+	var srv Server
+	var createdAt string
+	var revokedAt sql.NullString
+	if err := row.Scan(&srv.KeyID, &srv.Name, &srv.Secret, &srv.RateLimit, &srv.DailyQuota, &createdAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	var err error
+	srv.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("parsing created_at: %w", err)
+	}
+	if revokedAt.Valid {
+		t, err := time.Parse(time.RFC3339, revokedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("parsing revoked_at: %w", err)
+		}
+		srv.RevokedAt = &t
+	}
+	return &srv, nil
+}
+
+// RecordUsage adds to a server's counters for the given day (YYYY-MM-DD).
+func (s *Store) RecordUsage(ctx context.Context, keyID, day string, u Usage) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO usage (key_id, day, sent, invalid_token, failed, throttled)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(key_id, day) DO UPDATE SET
+			sent = sent + excluded.sent,
+			invalid_token = invalid_token + excluded.invalid_token,
+			failed = failed + excluded.failed,
+			throttled = throttled + excluded.throttled
+	`, keyID, day, u.Sent, u.InvalidToken, u.Failed, u.Throttled)
+	return err
+}
+
+// GetUsage returns a server's counters for one day.
+func (s *Store) GetUsage(ctx context.Context, keyID, day string) (Usage, error) {
+	var u Usage
+	err := s.db.QueryRowContext(ctx, `
+		SELECT sent, invalid_token, failed, throttled FROM usage WHERE key_id = ? AND day = ?
+	`, keyID, day).Scan(&u.Sent, &u.InvalidToken, &u.Failed, &u.Throttled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Usage{}, nil
+	}
+	return u, err
+}
+
+// SumUsage returns a server's counters summed over the days since the given
+// day, inclusive.
+func (s *Store) SumUsage(ctx context.Context, keyID, sinceDay string) (Usage, error) {
+	var u Usage
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(sent), 0), COALESCE(SUM(invalid_token), 0), COALESCE(SUM(failed), 0), COALESCE(SUM(throttled), 0)
+		FROM usage WHERE key_id = ? AND day >= ?
+	`, keyID, sinceDay).Scan(&u.Sent, &u.InvalidToken, &u.Failed, &u.Throttled)
+	return u, err
+}
+
+// usageDay returns the UTC day a time falls on, as used for usage counters.
+func usageDay(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func testStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := OpenStore(filepath.Join(t.TempDir(), "relay.db"))
+	if err != nil {
+		t.Fatalf("opening store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStore_CreateAndRevokeServer(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+
+	cred, err := store.CreateServer(ctx, "chat.example.com", 120, 1000)
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+
+	srv, err := store.GetServer(ctx, cred.KeyID)
+	if err != nil {
+		t.Fatalf("GetServer: %v", err)
+	}
+	if srv.Name != "chat.example.com" || srv.Secret != cred.Secret || srv.RateLimit != 120 || srv.DailyQuota != 1000 {
+		t.Errorf("unexpected server: %+v", srv)
+	}
+	if srv.RevokedAt != nil {
+		t.Error("expected a new server to be active")
+	}
+
+	if err := store.RevokeServer(ctx, cred.KeyID); err != nil {
+		t.Fatalf("RevokeServer: %v", err)
+	}
+	srv, _ = store.GetServer(ctx, cred.KeyID)
+	if srv.RevokedAt == nil {
+		t.Error("expected the server to be revoked")
+	}
+	if err := store.RevokeServer(ctx, cred.KeyID); !errors.Is(err, ErrServerNotFound) {
+		t.Errorf("revoking twice: expected ErrServerNotFound, got %v", err)
+	}
+	if _, err := store.GetServer(ctx, "rk_missing"); !errors.Is(err, ErrServerNotFound) {
+		t.Errorf("expected ErrServerNotFound, got %v", err)
+	}
+}
+
+func TestStore_Usage(t *testing.T) {
+	store := testStore(t)
+	ctx := context.Background()
+
+	cred, err := store.CreateServer(ctx, "chat.example.com", 120, 0)
+	if err != nil {
+		t.Fatalf("CreateServer: %v", err)
+	}
+
+	for _, u := range []Usage{{Sent: 1}, {Sent: 1}, {InvalidToken: 1}, {Throttled: 1}} {
+		if err := store.RecordUsage(ctx, cred.KeyID, "2026-01-02", u); err != nil {
+			t.Fatalf("RecordUsage: %v", err)
+		}
+	}
+	if err := store.RecordUsage(ctx, cred.KeyID, "2026-01-01", Usage{Failed: 1}); err != nil {
+		t.Fatalf("RecordUsage: %v", err)
+	}
+
+	day, err := store.GetUsage(ctx, cred.KeyID, "2026-01-02")
+	if err != nil {
+		t.Fatalf("GetUsage: %v", err)
+	}
+	if day != (Usage{Sent: 2, InvalidToken: 1, Throttled: 1}) {
+		t.Errorf("unexpected usage: %+v", day)
+	}
+	if day.Total() != 3 {
+		t.Errorf("expected throttled requests not to count towards the total, got %d", day.Total())
+	}
+
+	sum, err := store.SumUsage(ctx, cred.KeyID, "2026-01-01")
+	if err != nil {
+		t.Fatalf("SumUsage: %v", err)
+	}
+	if sum.Total() != 4 {
+		t.Errorf("expected 4 requests across both days, got %d", sum.Total())
+	}
+}
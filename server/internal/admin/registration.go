@@ -0,0 +1,156 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/enzyme/server/internal/auth"
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/user"
+	"github.com/enzyme/server/internal/workspace"
+)
+
+var (
+	ErrRegistrationClosed    = errors.New("registration is closed")
+	ErrInviteRequired        = errors.New("an invite is required to register")
+	ErrInvalidInvite         = errors.New("invite is invalid or has expired")
+	ErrEmailDomainNotAllowed = errors.New("email domain is not allowed to register")
+	ErrNotPending            = errors.New("user is not awaiting approval")
+)
+
+// CheckRegistration decides whether email may create an account under the
+// current registration mode. A usable workspace invite addressed to email (or
+// to anyone) admits the user in every mode except closed, without approval.
+// If pending is true the account must be created awaiting approval.
+func (s *Service) CheckRegistration(ctx context.Context, email, inviteCode string) (pending bool, err error) {
+	settings, err := s.GetSettings(ctx)
+	if err != nil {
+		return false, err
+	}
+	if settings.RegistrationMode == RegistrationClosed {
+		return false, ErrRegistrationClosed
+	}
+
+	if inviteCode != "" {
+		invite, err := s.workspaces.GetInviteByCode(ctx, inviteCode)
+		if err != nil || invite.Usable(time.Now()) != nil ||
+			(invite.InvitedEmail != nil && !strings.EqualFold(*invite.InvitedEmail, email)) {
+			return false, ErrInvalidInvite
+		}
+		return false, nil
+	}
+
+	switch settings.RegistrationMode {
+	case RegistrationInviteOnly:
+		return false, ErrInviteRequired
+	case RegistrationDomainAllowlist:
+		if !settings.EmailDomainAllowed(email) {
+			return false, ErrEmailDomainNotAllowed
+		}
+	case RegistrationApproval:
+		return true, nil
+	}
+	return false, nil
+}
+
+// Register creates an account if CheckRegistration admits it. An invite
+// code is redeemed in the same transaction that creates the user: its use
+// count is incremented, max_uses and expiry are enforced, and the user joins
+// the invite's workspace. The ID of that workspace, if any, is returned.
+func (s *Service) Register(ctx context.Context, input auth.RegisterInput, inviteCode string) (*user.User, string, error) {
+	pending, err := s.CheckRegistration(ctx, input.Email, inviteCode)
+	if err != nil {
+		return nil, "", err
+	}
+	input.PendingApproval = pending
+
+	var workspaceID string
+	if inviteCode != "" {
+		input.InTx = func(ctx context.Context, tx *sql.Tx, u *user.User) error {
+			invite, err := s.workspaces.RedeemInviteTx(ctx, tx, inviteCode, u.ID)
+			if err != nil {
+				if errors.Is(err, workspace.ErrInviteNotFound) || errors.Is(err, workspace.ErrInviteExpired) ||
+					errors.Is(err, workspace.ErrInviteMaxUsed) {
+					return ErrInvalidInvite
+				}
+				return err
+			}
+			workspaceID = invite.WorkspaceID
+			return nil
+		}
+	}
+	u, err := s.auth.Register(ctx, input)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if workspaceID != "" {
+		if defaultChannel, err := s.channels.GetDefaultChannel(ctx, workspaceID); err == nil {
+			memberRole := channel.ChannelRolePoster
+			if _, err := s.channels.AddMember(ctx, u.ID, defaultChannel.ID, &memberRole); err != nil {
+				slog.Warn("failed to add member to default channel", "workspace_id", workspaceID, "user_id", u.ID, "error", err)
+			}
+		}
+	}
+	return u, workspaceID, nil
+}
+
+// Approvers returns the site admins to notify about accounts awaiting
+// approval.
+func (s *Service) Approvers(ctx context.Context) ([]user.User, error) {
+	return s.users.ListSiteAdmins(ctx)
+}
+
+// Approve lets a pending account sign in.
+func (s *Service) Approve(ctx context.Context, email string) (*user.User, error) {
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return u, s.approve(ctx, u)
+}
+
+// ApproveUser is Approve by user ID.
+func (s *Service) ApproveUser(ctx context.Context, userID string) (*user.User, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u, s.approve(ctx, u)
+}
+
+func (s *Service) approve(ctx context.Context, u *user.User) error {
+	if !u.PendingApproval {
+		return ErrNotPending
+	}
+	if err := s.users.Approve(ctx, u.ID); err != nil {
+		return err
+	}
+	u.PendingApproval = false
+	return nil
+}
+
+// Reject deletes a pending account so the email address can register again.
+func (s *Service) Reject(ctx context.Context, email string) error {
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	return s.RejectUser(ctx, u.ID)
+}
+
+// RejectUser is Reject by user ID.
+func (s *Service) RejectUser(ctx context.Context, userID string) error {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !u.PendingApproval {
+		return ErrNotPending
+	}
+	return s.users.DeletePending(ctx, u.ID)
+}
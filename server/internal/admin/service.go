@@ -0,0 +1,289 @@
+// Package admin implements server-operator actions on users and workspaces
+// that bypass the usual workspace permission checks, such as recovering a
+// locked-out account on a server without email. They are reachable from the
+// "enzyme admin" command and, for site admins, from the /api/admin endpoints.
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"log/slog"
+
+	"github.com/enzyme/server/internal/auth"
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/moderation"
+	"github.com/enzyme/server/internal/user"
+	"github.com/enzyme/server/internal/workspace"
+)
+
+var ErrInvalidRole = errors.New("invalid workspace role")
+
+// Service performs administrative actions. Every change is recorded in the
+// moderation audit log of the affected workspaces, attributed to the acting
+// site admin or, from the command line, to moderation.SystemActorID.
+type Service struct {
+	db         *sql.DB
+	users      *user.Repository
+	workspaces *workspace.Repository
+	channels   *channel.Repository
+	moderation *moderation.Repository
+	sessions   *auth.SessionStore
+	auth       *auth.Service
+	bcryptCost int
+}
+
+// NewService creates an admin service backed by db.
+func NewService(db *sql.DB, bcryptCost int) *Service {
+	users := user.NewRepository(db)
+	return &Service{
+		db:         db,
+		users:      users,
+		workspaces: workspace.NewRepository(db),
+		channels:   channel.NewRepository(db),
+		moderation: moderation.NewRepository(db),
+		// Only DeleteAllForUser is used, which doesn't depend on the lifetime
+		sessions:   auth.NewSessionStore(db, 0),
+		auth:       auth.NewService(users, auth.NewPasswordResetRepo(db), auth.NewEmailVerificationRepo(db), bcryptCost),
+		bcryptCost: bcryptCost,
+	}
+}
+
+// CreateUser creates an account. If password is empty a random one is
+// generated and returned; the password actually set is always returned.
+func (s *Service) CreateUser(ctx context.Context, email, displayName, password string, verified bool) (*user.User, string, error) {
+	if password == "" {
+		password = GeneratePassword()
+	}
+	u, err := s.auth.Register(ctx, auth.RegisterInput{Email: email, Password: password, DisplayName: displayName})
+	if err != nil {
+		return nil, "", err
+	}
+	if verified {
+		if err := s.users.VerifyEmail(ctx, u.ID); err != nil {
+			return nil, "", err
+		}
+	}
+	return u, password, nil
+}
+
+// ResetPassword sets a new password for the user with the given email and
+// signs them out everywhere. If password is empty a random one is generated;
+// the password actually set is returned.
+func (s *Service) ResetPassword(ctx context.Context, email, password string) (string, error) {
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		password = GeneratePassword()
+	}
+	if len(password) < 8 {
+		return "", auth.ErrPasswordTooShort
+	}
+
+	hash, err := auth.HashPassword(password, s.bcryptCost)
+	if err != nil {
+		return "", err
+	}
+	if err := s.users.UpdatePassword(ctx, u.ID, hash); err != nil {
+		return "", err
+	}
+	if _, err := s.sessions.DeleteAllForUser(u.ID); err != nil {
+		return "", err
+	}
+
+	s.auditUser(ctx, moderation.SystemActorID, u.ID, moderation.ActionUserPasswordReset, nil)
+	return password, nil
+}
+
+// VerifyEmail marks the user's email address as verified.
+func (s *Service) VerifyEmail(ctx context.Context, email string) (*user.User, error) {
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.users.VerifyEmail(ctx, u.ID); err != nil {
+		return nil, err
+	}
+
+	s.auditUser(ctx, moderation.SystemActorID, u.ID, moderation.ActionUserEmailVerified, nil)
+	return u, nil
+}
+
+// SetDisabled deactivates or reactivates an account. Deactivating also
+// revokes all of the user's sessions.
+func (s *Service) SetDisabled(ctx context.Context, email string, disabled bool) (*user.User, error) {
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return u, s.setDisabled(ctx, moderation.SystemActorID, u, disabled)
+}
+
+// SetUserDisabled is SetDisabled for a site admin acting through the API.
+func (s *Service) SetUserDisabled(ctx context.Context, actorID, userID string, disabled bool) (*user.User, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u, s.setDisabled(ctx, actorID, u, disabled)
+}
+
+func (s *Service) setDisabled(ctx context.Context, actorID string, u *user.User, disabled bool) error {
+	action := moderation.ActionUserEnabled
+	u.Status = user.StatusActive
+	if disabled {
+		action = moderation.ActionUserDisabled
+		u.Status = user.StatusDeactivated
+	}
+	if err := s.users.Update(ctx, u); err != nil {
+		return err
+	}
+	if disabled {
+		if _, err := s.sessions.DeleteAllForUser(u.ID); err != nil {
+			return err
+		}
+	}
+
+	s.auditUser(ctx, actorID, u.ID, action, nil)
+	return nil
+}
+
+// SetSiteAdmin grants or revokes instance administration rights.
+func (s *Service) SetSiteAdmin(ctx context.Context, email string, isSiteAdmin bool) (*user.User, error) {
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return u, s.setSiteAdmin(ctx, moderation.SystemActorID, u, isSiteAdmin)
+}
+
+// SetUserSiteAdmin is SetSiteAdmin for a site admin acting through the API.
+func (s *Service) SetUserSiteAdmin(ctx context.Context, actorID, userID string, isSiteAdmin bool) (*user.User, error) {
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u, s.setSiteAdmin(ctx, actorID, u, isSiteAdmin)
+}
+
+func (s *Service) setSiteAdmin(ctx context.Context, actorID string, u *user.User, isSiteAdmin bool) error {
+	if err := s.users.SetSiteAdmin(ctx, u.ID, isSiteAdmin); err != nil {
+		return err
+	}
+	u.IsSiteAdmin = isSiteAdmin
+
+	action := moderation.ActionUserSiteAdminRevoked
+	if isSiteAdmin {
+		action = moderation.ActionUserSiteAdminGranted
+	}
+	s.auditUser(ctx, actorID, u.ID, action, nil)
+	return nil
+}
+
+// RevokeSessions signs the user out of every device and returns how many
+// sessions were removed.
+func (s *Service) RevokeSessions(ctx context.Context, email string) (int64, error) {
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return 0, err
+	}
+	n, err := s.sessions.DeleteAllForUser(u.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	s.auditUser(ctx, moderation.SystemActorID, u.ID, moderation.ActionUserSessionsRevoked, map[string]interface{}{"sessions": n})
+	return n, nil
+}
+
+// ListWorkspaces returns every workspace on the server.
+func (s *Service) ListWorkspaces(ctx context.Context) ([]workspace.WorkspaceWithCounts, error) {
+	return s.workspaces.ListAll(ctx)
+}
+
+// AddMember adds the user to a workspace with the given role and to its
+// default channel, as accepting an invite would.
+func (s *Service) AddMember(ctx context.Context, email, workspaceID, role string) (*workspace.Membership, error) {
+	if role != workspace.RoleOwner && role != workspace.RoleAdmin && role != workspace.RoleMember && role != workspace.RoleGuest {
+		return nil, ErrInvalidRole
+	}
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.workspaces.GetByID(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+
+	m, err := s.workspaces.AddMember(ctx, u.ID, workspaceID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if defaultChannel, err := s.channels.GetDefaultChannel(ctx, workspaceID); err == nil {
+		memberRole := channel.ChannelRolePoster
+		if _, err := s.channels.AddMember(ctx, u.ID, defaultChannel.ID, &memberRole); err != nil {
+			slog.Warn("failed to add member to default channel", "workspace_id", workspaceID, "user_id", u.ID, "error", err)
+		}
+	}
+
+	s.audit(ctx, moderation.SystemActorID, workspaceID, moderation.ActionMemberAdded, u.ID, map[string]interface{}{"role": role})
+	return m, nil
+}
+
+// PromoteToOwner makes an existing member an owner of the workspace.
+func (s *Service) PromoteToOwner(ctx context.Context, email, workspaceID string) error {
+	u, err := s.users.GetByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	m, err := s.workspaces.GetMembership(ctx, u.ID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if m.Role == workspace.RoleOwner {
+		return nil
+	}
+	if err := s.workspaces.UpdateMemberRole(ctx, u.ID, workspaceID, workspace.RoleOwner); err != nil {
+		return err
+	}
+
+	s.audit(ctx, moderation.SystemActorID, workspaceID, moderation.ActionMemberRoleChanged, u.ID, map[string]interface{}{
+		"old_role": m.Role,
+		"new_role": workspace.RoleOwner,
+	})
+	return nil
+}
+
+// auditUser records an action on a user in every workspace they belong to,
+// since the audit log is kept per workspace.
+func (s *Service) auditUser(ctx context.Context, actorID, userID, action string, metadata map[string]interface{}) {
+	ids, err := s.workspaces.ListWorkspaceIDsForUser(ctx, userID)
+	if err != nil {
+		slog.Error("failed to list workspaces for audit log", "user_id", userID, "error", err)
+		return
+	}
+	for _, wsID := range ids {
+		s.audit(ctx, actorID, wsID, action, userID, metadata)
+	}
+}
+
+// audit is best-effort: the action has already been applied.
+func (s *Service) audit(ctx context.Context, actorID, workspaceID, action, userID string, metadata map[string]interface{}) {
+	if err := s.moderation.CreateAuditLogEntryWithMetadata(ctx, workspaceID, actorID,
+		action, moderation.TargetTypeUser, userID, metadata); err != nil {
+		slog.Error("failed to write audit log entry", "workspace_id", workspaceID, "action", action, "error", err)
+	}
+}
+
+// GeneratePassword returns a random password suitable for handing to a user
+// who is expected to change it.
+func GeneratePassword() string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
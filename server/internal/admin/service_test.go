@@ -0,0 +1,343 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/auth"
+	"github.com/enzyme/server/internal/moderation"
+	"github.com/enzyme/server/internal/testutil"
+	"github.com/enzyme/server/internal/user"
+	"github.com/enzyme/server/internal/workspace"
+)
+
+func TestCreateUserAndResetPassword(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	svc := NewService(db, 4)
+
+	u, password, err := svc.CreateUser(ctx, "ops@example.com", "Ops", "", true)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if password == "" {
+		t.Fatal("expected a generated password")
+	}
+	got, _ := user.NewRepository(db).GetByID(ctx, u.ID)
+	if got.EmailVerifiedAt == nil {
+		t.Error("expected email to be verified")
+	}
+
+	sessions := auth.NewSessionStore(db, 0)
+	if _, err := sessions.Create(u.ID); err != nil {
+		t.Fatalf("session Create: %v", err)
+	}
+
+	newPassword, err := svc.ResetPassword(ctx, "ops@example.com", "correct horse")
+	if err != nil {
+		t.Fatalf("ResetPassword: %v", err)
+	}
+	if newPassword != "correct horse" {
+		t.Errorf("password = %q, want the one given", newPassword)
+	}
+	got, _ = user.NewRepository(db).GetByID(ctx, u.ID)
+	if !auth.CheckPassword("correct horse", got.PasswordHash) {
+		t.Error("password was not changed")
+	}
+	if n, _ := sessions.DeleteAllForUser(u.ID); n != 0 {
+		t.Errorf("%d sessions survived a password reset", n)
+	}
+
+	if _, err := svc.ResetPassword(ctx, "ops@example.com", "short"); !errors.Is(err, auth.ErrPasswordTooShort) {
+		t.Errorf("short password err = %v, want ErrPasswordTooShort", err)
+	}
+	if _, err := svc.ResetPassword(ctx, "nobody@example.com", ""); !errors.Is(err, user.ErrUserNotFound) {
+		t.Errorf("unknown user err = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestSetDisabled(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	svc := NewService(db, 4)
+
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	target := testutil.CreateTestUser(t, db, "spam@example.com", "Spam")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Acme")
+	if _, err := svc.AddMember(ctx, "spam@example.com", ws.ID, workspace.RoleMember); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+
+	sessions := auth.NewSessionStore(db, 0)
+	if _, err := sessions.Create(target.ID); err != nil {
+		t.Fatalf("session Create: %v", err)
+	}
+
+	if _, err := svc.SetDisabled(ctx, "spam@example.com", true); err != nil {
+		t.Fatalf("SetDisabled: %v", err)
+	}
+	got, _ := user.NewRepository(db).GetByID(ctx, target.ID)
+	if got.Status != user.StatusDeactivated {
+		t.Errorf("status = %q, want %q", got.Status, user.StatusDeactivated)
+	}
+	if n, _ := sessions.DeleteAllForUser(target.ID); n != 0 {
+		t.Errorf("%d sessions survived deactivation", n)
+	}
+
+	entries, _, _, err := moderation.NewRepository(db).ListAuditLog(ctx, ws.ID, "", 10)
+	if err != nil {
+		t.Fatalf("ListAuditLog: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != moderation.ActionUserDisabled || entries[0].ActorID != moderation.SystemActorID {
+		t.Fatalf("audit log = %+v, want user.disabled by the system first", entries)
+	}
+
+	if _, err := svc.SetDisabled(ctx, "spam@example.com", false); err != nil {
+		t.Fatalf("SetDisabled(false): %v", err)
+	}
+	got, _ = user.NewRepository(db).GetByID(ctx, target.ID)
+	if got.Status != user.StatusActive {
+		t.Errorf("status = %q, want %q", got.Status, user.StatusActive)
+	}
+}
+
+func TestSetSiteAdmin(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	svc := NewService(db, 4)
+
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Acme")
+
+	if _, err := svc.SetSiteAdmin(ctx, "owner@example.com", true); err != nil {
+		t.Fatalf("SetSiteAdmin: %v", err)
+	}
+	got, _ := user.NewRepository(db).GetByID(ctx, owner.ID)
+	if !got.IsSiteAdmin {
+		t.Error("expected owner to be a site admin")
+	}
+	if _, err := svc.SetUserSiteAdmin(ctx, owner.ID, owner.ID, false); err != nil {
+		t.Fatalf("SetUserSiteAdmin: %v", err)
+	}
+
+	entries, _, _, err := moderation.NewRepository(db).ListAuditLog(ctx, ws.ID, "", 10)
+	if err != nil {
+		t.Fatalf("ListAuditLog: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("audit log = %+v, want a grant and a revoke", entries)
+	}
+	if entries[0].Action != moderation.ActionUserSiteAdminRevoked || entries[0].ActorID != owner.ID {
+		t.Errorf("latest entry = %+v, want user.site_admin_revoked by the owner", entries[0])
+	}
+	if entries[1].Action != moderation.ActionUserSiteAdminGranted || entries[1].ActorID != moderation.SystemActorID {
+		t.Errorf("first entry = %+v, want user.site_admin_granted by the system", entries[1])
+	}
+}
+
+func TestAddMemberAndPromote(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	svc := NewService(db, 4)
+
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	bob := testutil.CreateTestUser(t, db, "bob@example.com", "Bob")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Acme")
+
+	if _, err := svc.AddMember(ctx, "bob@example.com", ws.ID, "superuser"); !errors.Is(err, ErrInvalidRole) {
+		t.Errorf("invalid role err = %v, want ErrInvalidRole", err)
+	}
+	if _, err := svc.AddMember(ctx, "bob@example.com", ws.ID, workspace.RoleGuest); err != nil {
+		t.Fatalf("AddMember: %v", err)
+	}
+	if _, err := svc.AddMember(ctx, "bob@example.com", ws.ID, workspace.RoleGuest); !errors.Is(err, workspace.ErrMembershipExists) {
+		t.Errorf("duplicate AddMember err = %v, want ErrMembershipExists", err)
+	}
+
+	if err := svc.PromoteToOwner(ctx, "bob@example.com", ws.ID); err != nil {
+		t.Fatalf("PromoteToOwner: %v", err)
+	}
+	m, err := workspace.NewRepository(db).GetMembership(ctx, bob.ID, ws.ID)
+	if err != nil {
+		t.Fatalf("GetMembership: %v", err)
+	}
+	if m.Role != workspace.RoleOwner {
+		t.Errorf("role = %q, want owner", m.Role)
+	}
+
+	list, err := svc.ListWorkspaces(ctx)
+	if err != nil {
+		t.Fatalf("ListWorkspaces: %v", err)
+	}
+	if len(list) != 1 || list[0].MemberCount != 2 || list[0].OwnerCount != 2 {
+		t.Errorf("workspaces = %+v, want one with 2 members and 2 owners", list)
+	}
+
+	entries, _, _, _ := moderation.NewRepository(db).ListAuditLog(ctx, ws.ID, "", 10)
+	if len(entries) != 2 || entries[0].Action != moderation.ActionMemberRoleChanged || entries[1].Action != moderation.ActionMemberAdded {
+		t.Errorf("audit log = %+v, want member.added then member.role_changed", entries)
+	}
+}
+
+func TestSettings(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	svc := NewService(db, 4)
+
+	settings, err := svc.GetSettings(ctx)
+	if err != nil {
+		t.Fatalf("GetSettings: %v", err)
+	}
+	if !reflect.DeepEqual(settings, DefaultSettings()) {
+		t.Errorf("settings = %+v, want the defaults", settings)
+	}
+
+	want := Settings{
+		RegistrationMode:    RegistrationDomainAllowlist,
+		AllowedEmailDomains: []string{"example.com", "corp.example.org"},
+		WorkspaceCreation:   WorkspaceCreationSiteAdmins,
+	}
+	input := want
+	input.AllowedEmailDomains = []string{" Example.com", "@corp.example.org", "example.com", ""}
+	if err := svc.UpdateSettings(ctx, input); err != nil {
+		t.Fatalf("UpdateSettings: %v", err)
+	}
+	// Updating twice exercises the upsert
+	if err := svc.UpdateSettings(ctx, want); err != nil {
+		t.Fatalf("UpdateSettings again: %v", err)
+	}
+	if settings, _ = svc.GetSettings(ctx); !reflect.DeepEqual(settings, want) {
+		t.Errorf("settings = %+v, want %+v", settings, want)
+	}
+
+	if err := svc.UpdateSettings(ctx, Settings{RegistrationMode: RegistrationOpen, WorkspaceCreation: "nobody"}); !errors.Is(err, ErrInvalidSetting) {
+		t.Errorf("invalid policy err = %v, want ErrInvalidSetting", err)
+	}
+	if err := svc.UpdateSettings(ctx, Settings{RegistrationMode: "sometimes", WorkspaceCreation: WorkspaceCreationEveryone}); !errors.Is(err, ErrInvalidSetting) {
+		t.Errorf("invalid mode err = %v, want ErrInvalidSetting", err)
+	}
+	if err := svc.UpdateSettings(ctx, Settings{RegistrationMode: RegistrationDomainAllowlist, WorkspaceCreation: WorkspaceCreationEveryone}); !errors.Is(err, ErrInvalidSetting) {
+		t.Errorf("empty allowlist err = %v, want ErrInvalidSetting", err)
+	}
+
+	if !settings.EmailDomainAllowed("Bob@EXAMPLE.com") || settings.EmailDomainAllowed("bob@sub.example.com") {
+		t.Error("expected only exact allowed domains to match")
+	}
+
+	regular := &user.User{}
+	if settings.CanCreateWorkspace(regular) {
+		t.Error("regular users should not create workspaces when restricted to site admins")
+	}
+	if !settings.CanCreateWorkspace(&user.User{IsSiteAdmin: true}) {
+		t.Error("site admins should always create workspaces")
+	}
+}
+
+func TestCheckRegistration(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	svc := NewService(db, 4)
+
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Acme")
+	workspaces := workspace.NewRepository(db)
+	open := &workspace.Invite{WorkspaceID: ws.ID, Role: workspace.RoleMember}
+	if err := workspaces.CreateInvite(ctx, open); err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+	addressed := "carol@example.com"
+	targeted := &workspace.Invite{WorkspaceID: ws.ID, Role: workspace.RoleMember, InvitedEmail: &addressed}
+	if err := workspaces.CreateInvite(ctx, targeted); err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+	expiredAt := time.Now().Add(-time.Hour)
+	expired := &workspace.Invite{WorkspaceID: ws.ID, Role: workspace.RoleMember, ExpiresAt: &expiredAt}
+	if err := workspaces.CreateInvite(ctx, expired); err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+
+	tests := []struct {
+		mode        string
+		email       string
+		invite      string
+		wantPending bool
+		wantErr     error
+	}{
+		{RegistrationOpen, "a@example.com", "", false, nil},
+		{RegistrationInviteOnly, "a@example.com", "", false, ErrInviteRequired},
+		{RegistrationInviteOnly, "a@example.com", open.Code, false, nil},
+		{RegistrationInviteOnly, "a@example.com", "bogus", false, ErrInvalidInvite},
+		{RegistrationInviteOnly, "a@example.com", expired.Code, false, ErrInvalidInvite},
+		{RegistrationInviteOnly, "a@example.com", targeted.Code, false, ErrInvalidInvite},
+		{RegistrationInviteOnly, "Carol@Example.com", targeted.Code, false, nil},
+		{RegistrationDomainAllowlist, "a@example.com", "", false, nil},
+		{RegistrationDomainAllowlist, "a@elsewhere.com", "", false, ErrEmailDomainNotAllowed},
+		{RegistrationDomainAllowlist, "a@elsewhere.com", open.Code, false, nil},
+		{RegistrationApproval, "a@elsewhere.com", "", true, nil},
+		{RegistrationApproval, "a@elsewhere.com", open.Code, false, nil},
+		{RegistrationClosed, "a@example.com", open.Code, false, ErrRegistrationClosed},
+	}
+	for _, tt := range tests {
+		settings := Settings{RegistrationMode: tt.mode, AllowedEmailDomains: []string{"example.com"}, WorkspaceCreation: WorkspaceCreationEveryone}
+		if err := svc.UpdateSettings(ctx, settings); err != nil {
+			t.Fatalf("UpdateSettings: %v", err)
+		}
+		pending, err := svc.CheckRegistration(ctx, tt.email, tt.invite)
+		if !errors.Is(err, tt.wantErr) || pending != tt.wantPending {
+			t.Errorf("%s %s invite=%q: pending=%v err=%v, want pending=%v err=%v",
+				tt.mode, tt.email, tt.invite, pending, err, tt.wantPending, tt.wantErr)
+		}
+	}
+}
+
+func TestApproveAndReject(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	svc := NewService(db, 4)
+	authService := auth.NewService(user.NewRepository(db), auth.NewPasswordResetRepo(db), auth.NewEmailVerificationRepo(db), 4)
+
+	register := func(email string) {
+		t.Helper()
+		if _, err := authService.Register(ctx, auth.RegisterInput{Email: email, Password: "password123", DisplayName: "New", PendingApproval: true}); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+	register("pending@example.com")
+	register("spam@example.com")
+
+	login := auth.LoginInput{Email: "pending@example.com", Password: "password123"}
+	if _, err := authService.Login(ctx, login); !errors.Is(err, auth.ErrUserPendingApproval) {
+		t.Fatalf("Login err = %v, want ErrUserPendingApproval", err)
+	}
+
+	if st, _ := svc.Stats(ctx); st.PendingUsers != 2 {
+		t.Errorf("pending users = %d, want 2", st.PendingUsers)
+	}
+
+	u, err := svc.Approve(ctx, "pending@example.com")
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if u.PendingApproval {
+		t.Error("user still pending after approval")
+	}
+	if _, err := authService.Login(ctx, login); err != nil {
+		t.Errorf("Login after approval: %v", err)
+	}
+	if _, err := svc.Approve(ctx, "pending@example.com"); !errors.Is(err, ErrNotPending) {
+		t.Errorf("second Approve err = %v, want ErrNotPending", err)
+	}
+	if err := svc.Reject(ctx, "pending@example.com"); !errors.Is(err, ErrNotPending) {
+		t.Errorf("Reject of approved user err = %v, want ErrNotPending", err)
+	}
+
+	if err := svc.Reject(ctx, "spam@example.com"); err != nil {
+		t.Fatalf("Reject: %v", err)
+	}
+	if _, err := user.NewRepository(db).GetByEmail(ctx, "spam@example.com"); !errors.Is(err, user.ErrUserNotFound) {
+		t.Errorf("rejected user lookup err = %v, want ErrUserNotFound", err)
+	}
+}
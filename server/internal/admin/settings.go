@@ -0,0 +1,159 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/enzyme/server/internal/user"
+)
+
+// Who may create workspaces.
+const (
+	WorkspaceCreationEveryone   = "everyone"
+	WorkspaceCreationSiteAdmins = "site_admins"
+)
+
+// Who may create an account. A valid workspace invite admits anyone except
+// when registration is closed.
+const (
+	RegistrationOpen            = "open"
+	RegistrationInviteOnly      = "invite_only"
+	RegistrationDomainAllowlist = "domain_allowlist"
+	RegistrationApproval        = "approval"
+	RegistrationClosed          = "closed"
+)
+
+var ErrInvalidSetting = errors.New("invalid server setting")
+
+// Settings are instance-wide switches changed at runtime by site admins.
+// They live in the database so that every instance sees the same values.
+type Settings struct {
+	RegistrationMode string
+	// AllowedEmailDomains are lower-case domains that may register in
+	// RegistrationDomainAllowlist mode.
+	AllowedEmailDomains []string
+	WorkspaceCreation   string
+}
+
+// DefaultSettings are used for keys that have never been set.
+func DefaultSettings() Settings {
+	return Settings{
+		RegistrationMode:  RegistrationOpen,
+		WorkspaceCreation: WorkspaceCreationEveryone,
+	}
+}
+
+const (
+	settingRegistrationMode    = "registration_mode"
+	settingAllowedEmailDomains = "allowed_email_domains"
+	settingWorkspaceCreation   = "workspace_creation"
+)
+
+// GetSettings returns the current settings. Settings are read on every call
+// rather than cached, so a change made on one instance applies everywhere.
+func (s *Service) GetSettings(ctx context.Context) (Settings, error) {
+	settings := DefaultSettings()
+
+	rows, err := s.db.QueryContext(ctx, `SELECT key, value FROM server_settings`)
+	if err != nil {
+		return settings, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return settings, err
+		}
+		switch key {
+		case settingRegistrationMode:
+			settings.RegistrationMode = value
+		case settingAllowedEmailDomains:
+			settings.AllowedEmailDomains = splitDomains(value)
+		case settingWorkspaceCreation:
+			settings.WorkspaceCreation = value
+		}
+	}
+
+	return settings, rows.Err()
+}
+
+// UpdateSettings validates and stores all settings. Allowed domains are
+// normalized to lower case without a leading "@".
+func (s *Service) UpdateSettings(ctx context.Context, settings Settings) error {
+	switch settings.RegistrationMode {
+	case RegistrationOpen, RegistrationInviteOnly, RegistrationDomainAllowlist, RegistrationApproval, RegistrationClosed:
+	default:
+		return fmt.Errorf("%w: unknown registration mode %q", ErrInvalidSetting, settings.RegistrationMode)
+	}
+	domains := splitDomains(strings.Join(settings.AllowedEmailDomains, ","))
+	if settings.RegistrationMode == RegistrationDomainAllowlist && len(domains) == 0 {
+		return fmt.Errorf("%w: the domain allowlist mode needs at least one allowed email domain", ErrInvalidSetting)
+	}
+	if settings.WorkspaceCreation != WorkspaceCreationEveryone && settings.WorkspaceCreation != WorkspaceCreationSiteAdmins {
+		return fmt.Errorf("%w: unknown workspace creation policy %q", ErrInvalidSetting, settings.WorkspaceCreation)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for key, value := range map[string]string{
+		settingRegistrationMode:    settings.RegistrationMode,
+		settingAllowedEmailDomains: strings.Join(domains, ","),
+		settingWorkspaceCreation:   settings.WorkspaceCreation,
+	} {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO server_settings (key, value, updated_at) VALUES (?, ?, ?)
+			ON CONFLICT (key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at
+		`, key, value, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// CanCreateWorkspace reports whether the settings allow u to create a
+// workspace.
+func (s Settings) CanCreateWorkspace(u *user.User) bool {
+	return s.WorkspaceCreation == WorkspaceCreationEveryone || u.IsSiteAdmin
+}
+
+// EmailDomainAllowed reports whether email belongs to one of the allowed
+// domains. Subdomains must be listed separately.
+func (s Settings) EmailDomainAllowed(email string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, d := range s.AllowedEmailDomains {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
+
+// splitDomains parses a comma-separated domain list, dropping blanks and
+// duplicates.
+func splitDomains(value string) []string {
+	var domains []string
+	seen := make(map[string]bool)
+	for _, d := range strings.Split(value, ",") {
+		d = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if d == "" || seen[d] {
+			continue
+		}
+		seen[d] = true
+		domains = append(domains, d)
+	}
+	return domains
+}
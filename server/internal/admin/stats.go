@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/enzyme/server/internal/user"
+)
+
+// Stats are instance-wide totals for the site admin dashboard.
+type Stats struct {
+	Users            int
+	DeactivatedUsers int
+	PendingUsers     int
+	SiteAdmins       int
+	Workspaces       int
+	Channels         int
+	Messages         int
+	Files            int
+	FileBytes        int64
+}
+
+// Stats counts users, workspaces and content across the whole instance.
+// Deleted messages are not counted.
+func (s *Service) Stats(ctx context.Context) (*Stats, error) {
+	var st Stats
+	err := s.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM users WHERE status = ?),
+			(SELECT COUNT(*) FROM users WHERE pending_approval = 1),
+			(SELECT COUNT(*) FROM users WHERE is_site_admin = 1),
+			(SELECT COUNT(*) FROM workspaces),
+			(SELECT COUNT(*) FROM channels),
+			(SELECT COUNT(*) FROM messages WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM attachments),
+			(SELECT COALESCE(SUM(size_bytes), 0) FROM attachments)
+	`, user.StatusDeactivated).Scan(
+		&st.Users, &st.DeactivatedUsers, &st.PendingUsers, &st.SiteAdmins, &st.Workspaces,
+		&st.Channels, &st.Messages, &st.Files, &st.FileBytes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
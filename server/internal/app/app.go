@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"crypto/rand"
+	"crypto/tls"
 	"encoding/hex"
 	"fmt"
 	"log/slog"
@@ -12,7 +13,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/enzyme/server/internal/admin"
 	"github.com/enzyme/server/internal/auth"
+	"github.com/enzyme/server/internal/backup"
 	"github.com/enzyme/server/internal/channel"
 	"github.com/enzyme/server/internal/config"
 	"github.com/enzyme/server/internal/database"
@@ -20,6 +23,8 @@ import (
 	"github.com/enzyme/server/internal/emoji"
 	"github.com/enzyme/server/internal/file"
 	"github.com/enzyme/server/internal/handler"
+	"github.com/enzyme/server/internal/imageproxy"
+	"github.com/enzyme/server/internal/inbound"
 	"github.com/enzyme/server/internal/linkpreview"
 	"github.com/enzyme/server/internal/message"
 	"github.com/enzyme/server/internal/moderation"
@@ -27,6 +32,8 @@ import (
 	"github.com/enzyme/server/internal/presence"
 	"github.com/enzyme/server/internal/pushnotification"
 	"github.com/enzyme/server/internal/ratelimit"
+	"github.com/enzyme/server/internal/relayauth"
+	"github.com/enzyme/server/internal/scanner"
 	"github.com/enzyme/server/internal/scheduled"
 	"github.com/enzyme/server/internal/scheduler"
 	"github.com/enzyme/server/internal/server"
@@ -46,25 +53,38 @@ type App struct {
 	DB                    *database.DB
 	Server                *server.Server
 	Hub                   *sse.Hub
+	broker                sse.Broker
 	PresenceManager       *presence.Manager
 	EmailService          *email.Service
 	NotificationService   *notification.Service
 	EmailWorker           *notification.EmailWorker
 	RateLimiter           *ratelimit.Limiter
+	cors                  *server.CORS
+	sseHandler            *sse.Handler
 	SessionStore          *auth.SessionStore
 	emailVerificationRepo *auth.EmailVerificationRepo
 	LinkPreviewRepo       *linkpreview.Repository
 	ScheduledWorker       *scheduled.Worker
 	passwordResetRepo     *auth.PasswordResetRepo
 	pushTokenRepo         *pushnotification.Repository
+	webPushRepo           *pushnotification.SubscriptionRepository
 	moderationRepo        *moderation.Repository
+	scanService           *scanner.Service
+	imageProxy            *imageproxy.Proxy
 	scheduler             *scheduler.Scheduler
 	Telemetry             *telemetry.Telemetry
+	metricsServer         *http.Server
+	inboundServer         *inbound.Server
 }
 
 func New(cfg *config.Config) (*App, error) {
 	// Open database
-	db, err := database.Open(cfg.Database.Path, database.Options{
+	dsn := cfg.Database.Path
+	if cfg.Database.Driver == database.DriverPostgres {
+		dsn = cfg.Database.URL
+	}
+	db, err := database.Open(dsn, database.Options{
+		Driver:           cfg.Database.Driver,
 		MaxOpenConns:     cfg.Database.MaxOpenConns,
 		BusyTimeout:      cfg.Database.BusyTimeout,
 		CacheSize:        cfg.Database.CacheSize,
@@ -83,16 +103,20 @@ func New(cfg *config.Config) (*App, error) {
 
 	// Initialize telemetry (before other components so they can use global providers)
 	var tel *telemetry.Telemetry
-	if cfg.Telemetry.Enabled {
-		tel, err = telemetry.Init(cfg.Telemetry, version.Version)
+	if cfg.Telemetry.Enabled || cfg.Metrics.Enabled {
+		tel, err = telemetry.Init(cfg.Telemetry, cfg.Metrics, version.Version)
 		if err != nil {
 			_ = db.Close()
 			return nil, fmt.Errorf("initializing telemetry: %w", err)
 		}
-		slog.Info("telemetry enabled", "endpoint", cfg.Telemetry.Endpoint, "protocol", cfg.Telemetry.Protocol)
+		if cfg.Telemetry.Enabled {
+			slog.Info("telemetry enabled", "endpoint", cfg.Telemetry.Endpoint, "protocol", cfg.Telemetry.Protocol)
+		}
 	} else {
 		tel = telemetry.Noop()
 	}
+	telemetry.SetDBSystem(db.Dialect.String())
+	telemetry.RegisterDBStats(db.DB)
 
 	// Initialize SSE hub
 	hub := sse.NewHub(db.DB, cfg.SSE.EventRetention)
@@ -106,6 +130,8 @@ func New(cfg *config.Config) (*App, error) {
 		_ = db.Close()
 		return nil, err
 	}
+	emailOutbox := email.NewOutbox(db.DB)
+	emailService.SetOutbox(emailOutbox)
 
 	// Initialize repositories
 	userRepo := user.NewRepository(db.DB)
@@ -117,6 +143,16 @@ func New(cfg *config.Config) (*App, error) {
 	fileRepo := file.NewRepository(db.DB)
 	linkPreviewRepo := linkpreview.NewRepository(db.DB)
 	linkPreviewFetcher := linkpreview.NewFetcher(linkPreviewRepo)
+	oembedProviders := make([]linkpreview.OEmbedProvider, 0, len(cfg.LinkPreview.OEmbedProviders)+len(linkpreview.DefaultOEmbedProviders))
+	for _, p := range cfg.LinkPreview.OEmbedProviders {
+		oembedProviders = append(oembedProviders, linkpreview.OEmbedProvider{Name: p.Name, Endpoint: p.Endpoint, Schemes: p.Schemes})
+	}
+	oembedRegistry, err := linkpreview.NewProviderRegistry(append(oembedProviders, linkpreview.DefaultOEmbedProviders...))
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("configuring oembed providers: %w", err)
+	}
+	linkPreviewFetcher.SetOEmbed(oembedRegistry, cfg.LinkPreview.OEmbedDiscovery)
 	emojiRepo := emoji.NewRepository(db.DB)
 	threadRepo := thread.NewRepository(db.DB)
 	scheduledRepo := scheduled.NewRepository(db.DB)
@@ -124,50 +160,94 @@ func New(cfg *config.Config) (*App, error) {
 
 	// Initialize services
 	authService := auth.NewService(userRepo, passwordResetRepo, emailVerificationRepo, cfg.Auth.BcryptCost)
+	adminService := admin.NewService(db.DB, cfg.Auth.BcryptCost)
 
 	// Initialize notification service
 	notificationPrefsRepo := notification.NewPreferencesRepository(db.DB)
 	notificationPendingRepo := notification.NewPendingRepository(db.DB)
-	notificationService := notification.NewService(notificationPrefsRepo, notificationPendingRepo, channelRepo, hub)
+	notificationSettingsRepo := notification.NewSettingsRepository(db.DB)
+	notificationHeldRepo := notification.NewHeldRepository(db.DB)
+	notificationService := notification.NewService(notificationPrefsRepo, notificationPendingRepo, notificationSettingsRepo, notificationHeldRepo, channelRepo, hub)
 	notificationService.SetThreadSubscriptionProvider(threadRepo)
+	notificationService.SetWorkspaceMemberProvider(workspaceRepo)
 
 	// Initialize push notification service
 	var pushTokenRepo *pushnotification.Repository
-	if cfg.PushNotifications.Enabled {
+	var pushService *pushnotification.Service
+	pushCfg := cfg.PushNotifications
+	if pushCfg.Enabled || pushCfg.WebPush.Enabled || pushCfg.UnifiedPush.Enabled {
+		notificationService.SetPushOptions(cfg.Server.PublicURL, pushCfg.IncludePreview)
+	}
+	if pushCfg.Enabled || pushCfg.UnifiedPush.Enabled {
+		relayURL := ""
+		if pushCfg.Enabled {
+			relayURL = pushCfg.RelayURL
+			slog.Info("push notifications enabled", "relay_url", relayURL)
+		}
 		pushTokenRepo = pushnotification.NewRepository(db.DB)
-		pushService := pushnotification.NewService(pushTokenRepo, cfg.PushNotifications.RelayURL)
-		notificationService.SetPushService(pushService, cfg.Server.PublicURL, cfg.PushNotifications.IncludePreview)
-		slog.Info("push notifications enabled", "relay_url", cfg.PushNotifications.RelayURL)
+		pushService = pushnotification.NewService(pushTokenRepo, relayURL)
+		if pushCfg.Enabled && pushCfg.RelayKey != "" {
+			relayKey, err := relayauth.ParseCredential(pushCfg.RelayKey)
+			if err != nil {
+				_ = db.Close()
+				return nil, fmt.Errorf("parsing push_notifications.relay_key: %w", err)
+			}
+			pushService.SetRelayKey(relayKey)
+		}
+		if pushCfg.UnifiedPush.Enabled {
+			var client *http.Client
+			if pushCfg.UnifiedPush.AllowPrivateEndpoints {
+				client = &http.Client{Timeout: 5 * time.Second}
+			}
+			pushService.EnableUnifiedPush(client)
+			slog.Info("unifiedpush notifications enabled")
+		}
+		notificationService.AddMobilePushSender(pushService)
 	}
 
-	// Initialize email worker
-	emailWorker := notification.NewEmailWorker(notificationPendingRepo, userRepo, emailService, hub)
+	// Initialize Web Push, delivered directly to browser push services
+	var webPushRepo *pushnotification.SubscriptionRepository
+	var vapidPublicKey string
+	if cfg.PushNotifications.WebPush.Enabled {
+		vapidKeys, err := pushnotification.LoadVAPIDKeys(context.Background(), db.DB)
+		if err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("loading VAPID keys: %w", err)
+		}
+		subject := cfg.PushNotifications.WebPush.Subject
+		if subject == "" {
+			subject = cfg.Server.PublicURL
+		}
+		var client *http.Client
+		if cfg.PushNotifications.WebPush.AllowPrivateEndpoints {
+			client = &http.Client{Timeout: 5 * time.Second}
+		}
+		webPushRepo = pushnotification.NewSubscriptionRepository(db.DB)
+		notificationService.AddPushSender(pushnotification.NewWebPushSender(webPushRepo, vapidKeys, subject, client))
+		vapidPublicKey = vapidKeys.PublicKey
+		slog.Info("web push notifications enabled")
+	}
 
 	// Initialize session store
 	sessionStore := auth.NewSessionStore(db.DB, cfg.Auth.SessionDuration)
 
 	// Initialize storage backend
-	var store storage.Storage
-	switch cfg.Storage.Type {
-	case "local":
-		store = storage.NewLocal(cfg.Storage.Local.Path)
-	case "s3":
-		s3Store, err := storage.NewS3(cfg.Storage.S3)
-		if err != nil {
-			_ = db.Close()
-			return nil, fmt.Errorf("initializing S3 storage: %w", err)
-		}
-		if err := s3Store.CheckConnectivity(context.Background()); err != nil {
-			_ = db.Close()
-			return nil, fmt.Errorf("S3 connectivity check: %w", err)
-		}
-		store = s3Store
-	case "off":
-		// store remains nil — upload endpoints return 403
+	// (nil when storage is off — upload endpoints return 403)
+	store, err := storage.New(context.Background(), cfg.Storage)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	if cfg.Storage.Type != "off" && cfg.Storage.Encryption.Enabled {
+		slog.Info("storage encryption at rest enabled")
 	}
 
-	// Initialize file URL signer (only needed for local storage)
-	if cfg.Storage.Type == "local" && cfg.Storage.Local.SigningSecret == "" {
+	// Initialize file URL signer (needed whenever downloads are proxied
+	// through the server: local storage, or encrypted S3, and to sign
+	// image proxy URLs and email unsubscribe links)
+	needsSigner := cfg.Storage.Type == "local" || (cfg.Storage.Type != "off" && cfg.Storage.Encryption.Enabled) ||
+		cfg.LinkPreview.ImageProxy.Enabled || cfg.Email.Enabled
+	if needsSigner && cfg.Storage.Local.SigningSecret == "" {
 		secretPath := filepath.Join(filepath.Dir(cfg.Database.Path), ".signing_secret")
 		if data, err := os.ReadFile(secretPath); err == nil && len(data) > 0 {
 			cfg.Storage.Local.SigningSecret = strings.TrimSpace(string(data))
@@ -192,11 +272,49 @@ func New(cfg *config.Config) (*App, error) {
 	signingSecret := cfg.Storage.Local.SigningSecret
 	signer := signing.NewSigner(signingSecret)
 
+	// Initialize email worker
+	emailWorker := notification.NewEmailWorker(notificationPendingRepo, userRepo, emailService, hub, signer)
+
+	// Initialize image proxy for link preview images (cached in storage
+	// when it is configured)
+	var imageProxy *imageproxy.Proxy
+	if cfg.LinkPreview.ImageProxy.Enabled {
+		imageProxy = imageproxy.New(imageproxy.NewRepository(db.DB), store, signer, nil,
+			cfg.LinkPreview.ImageProxy.MaxSize, cfg.LinkPreview.ImageProxy.CacheTTL)
+		slog.Info("image proxy enabled", "cache", store != nil)
+	}
+
+	// Initialize upload scanner (nil when scanning or storage is off)
+	var scanService *scanner.Service
+	if store != nil {
+		var sc scanner.Scanner
+		switch cfg.Storage.Scan.Type {
+		case "clamav":
+			clam := scanner.NewClamAV(cfg.Storage.Scan.ClamAV.Address, cfg.Storage.Scan.Timeout)
+			if err := clam.Ping(context.Background()); err != nil {
+				// Not fatal: uploads are marked as scan errors until clamd comes back
+				slog.Warn("clamd is not reachable", "address", cfg.Storage.Scan.ClamAV.Address, "error", err)
+			}
+			sc = clam
+		case "http":
+			sc = scanner.NewHTTP(cfg.Storage.Scan.HTTP.URL, cfg.Storage.Scan.HTTP.Token, cfg.Storage.Scan.Timeout)
+		}
+		if sc != nil {
+			scanService = scanner.NewService(sc, store, fileRepo, channelRepo, moderationRepo, cfg.Storage.Scan.Timeout)
+			slog.Info("upload scanning enabled", "type", cfg.Storage.Scan.Type)
+		}
+	}
+	scanPolicy := scanner.Policy{
+		// Pending files can never complete once scanning is turned off
+		BlockPending: scanService != nil && cfg.Storage.Scan.BlockPending,
+		BlockOnError: cfg.Storage.Scan.BlockOnError,
+	}
+
 	// Normalize publicURL to avoid double slashes in constructed URLs
 	cfg.Server.PublicURL = strings.TrimRight(cfg.Server.PublicURL, "/")
 
 	// Initialize SSE handler (kept separate as it requires streaming)
-	sseHandler := sse.NewHandler(hub, workspaceRepo, channelRepo, cfg.SSE.HeartbeatInterval, cfg.SSE.ClientBufferSize)
+	sseHandler := sse.NewHandler(hub, workspaceRepo, channelRepo, moderationRepo, sessionStore, presenceManager, cfg.SSE.HeartbeatInterval, cfg.Server.AllowedOrigins, cfg.SSE.ClientBufferSize)
 
 	// Initialize main handler implementing StrictServerInterface
 	h := handler.New(handler.Dependencies{
@@ -209,16 +327,24 @@ func New(cfg *config.Config) (*App, error) {
 		FileRepo:            fileRepo,
 		LinkPreviewRepo:     linkPreviewRepo,
 		LinkPreviewFetcher:  linkPreviewFetcher,
+		ImageProxy:          imageProxy,
 		ThreadRepo:          threadRepo,
 		EmojiRepo:           emojiRepo,
 		ScheduledRepo:       scheduledRepo,
 		EmailService:        emailService,
+		EmailOutbox:         emailOutbox,
 		NotificationService: notificationService,
 		PushTokenRepo:       pushTokenRepo,
+		PushService:         pushService,
+		WebPushRepo:         webPushRepo,
+		VAPIDPublicKey:      vapidPublicKey,
 		ModerationRepo:      moderationRepo,
+		AdminService:        adminService,
 		Hub:                 hub,
 		Signer:              signer,
 		Storage:             store,
+		ScanService:         scanService,
+		ScanPolicy:          scanPolicy,
 		MaxUploadSize:       cfg.Storage.MaxUploadSize,
 		PublicURL:           cfg.Server.PublicURL,
 	})
@@ -226,20 +352,9 @@ func New(cfg *config.Config) (*App, error) {
 	// Initialize scheduled message worker
 	scheduledWorker := scheduled.NewWorker(scheduledRepo, h)
 
-	// Build rate limiter (nil if disabled)
-	var limiter *ratelimit.Limiter
-	if cfg.RateLimit.Enabled {
-		rules := []ratelimit.Rule{
-			{Method: "POST", Path: "/api/auth/login", Limit: cfg.RateLimit.Login.Limit, Window: cfg.RateLimit.Login.Window},
-			{Method: "POST", Path: "/api/auth/register", Limit: cfg.RateLimit.Register.Limit, Window: cfg.RateLimit.Register.Window},
-			{Method: "POST", Path: "/api/auth/forgot-password", Limit: cfg.RateLimit.ForgotPassword.Limit, Window: cfg.RateLimit.ForgotPassword.Window},
-			{Method: "POST", Path: "/api/auth/reset-password", Limit: cfg.RateLimit.ResetPassword.Limit, Window: cfg.RateLimit.ResetPassword.Window},
-			{Method: "POST", Path: "/api/auth/verify-email", Limit: cfg.RateLimit.VerifyEmail.Limit, Window: cfg.RateLimit.VerifyEmail.Window},
-			{Method: "POST", Path: "/api/auth/resend-verification", Limit: cfg.RateLimit.ResendVerification.Limit, Window: cfg.RateLimit.ResendVerification.Window},
-			{Method: "POST", Path: "/api/auth/device-tokens", Limit: cfg.RateLimit.DeviceTokenRegister.Limit, Window: cfg.RateLimit.DeviceTokenRegister.Window},
-		}
-		limiter = ratelimit.NewLimiter(rules)
-	}
+	// Build rate limiter (without rules if disabled, so that a reload can
+	// enable it)
+	limiter := ratelimit.NewLimiter(rateLimitRules(cfg.RateLimit))
 
 	// Create embedded SPA handler if web client is bundled
 	var spaHandler http.Handler
@@ -256,8 +371,49 @@ func New(cfg *config.Config) (*App, error) {
 		otlpProxy = telemetry.NewOTLPProxy(cfg.Telemetry)
 	}
 
+	// Serve Prometheus metrics on the main router behind a token, or on a
+	// separate listener that is only reachable by the scraper
+	var metricsHandler http.Handler
+	var metricsServer *http.Server
+	if mh := tel.MetricsHandler(); mh != nil {
+		mh = telemetry.RequireToken(cfg.Metrics.Token, mh)
+		if cfg.Metrics.Listen != "" {
+			mux := http.NewServeMux()
+			mux.Handle("GET /metrics", mh)
+			metricsServer = &http.Server{Addr: cfg.Metrics.Listen, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		} else {
+			metricsHandler = mh
+		}
+	}
+
+	// Receive replies to notification emails and mail to channels' addresses
+	// on the SMTP listener, from an MTA piping them to the API, or both
+	var inboundServer *inbound.Server
+	var inboundHandler http.Handler
+	if in := cfg.Email.Inbound; cfg.Email.Enabled && in.Enabled {
+		mux := inbound.NewMux(in.Domain)
+		mux.Handle(email.ReplyAddressPrefix, h.EmailReplies())
+		mux.Handle(email.ChannelAddressPrefix, h.ChannelEmails(in.TrustedAuthservID))
+		if in.Listen != "" {
+			inboundServer = inbound.NewServer(in.Listen, in.Domain, in.MaxSize, mux)
+			inboundServer.SetConnectionLimits(in.MaxConnections, in.MaxConnectionsPerIP)
+			if in.CertFile != "" {
+				cert, err := tls.LoadX509KeyPair(in.CertFile, in.KeyFile)
+				if err != nil {
+					return nil, fmt.Errorf("loading inbound email certificate: %w", err)
+				}
+				inboundServer.SetTLSConfig(&tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
+			}
+		}
+		if in.Token != "" {
+			inboundHandler = inbound.NewHTTPHandler(mux, in.Token, in.MaxSize)
+		}
+		slog.Info("inbound email enabled", "domain", in.Domain, "listen", in.Listen, "http", inboundHandler != nil)
+	}
+
 	// Create router with generated handlers
-	router := server.NewRouter(h, sseHandler, sessionStore, moderationRepo, limiter, cfg.Server.AllowedOrigins, cfg.Telemetry.Enabled, spaHandler, otlpProxy)
+	corsPolicy := server.NewCORS(cfg.Server.AllowedOrigins, cfg.Telemetry.Enabled)
+	router := server.NewRouter(h, sseHandler, sessionStore, moderationRepo, limiter, corsPolicy, cfg.Telemetry.Enabled, spaHandler, otlpProxy, metricsHandler, inboundHandler)
 
 	// Build TLS options
 	tlsOpts := server.TLSOptions{
@@ -275,6 +431,21 @@ func New(cfg *config.Config) (*App, error) {
 		}
 	}
 
+	// Fan SSE events out to other instances (last, so nothing below can fail and leak the connection)
+	var broker sse.Broker
+	if cfg.SSE.Broker == "redis" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		broker, err = sse.NewRedisBroker(ctx, cfg.SSE.Redis.URL, cfg.SSE.Redis.Channel)
+		cancel()
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+		hub.SetBroker(broker)
+		presenceManager.SetClustered(true)
+		slog.Info("sse redis broker enabled", "channel", cfg.SSE.Redis.Channel, "node_id", hub.NodeID())
+	}
+
 	// Create server
 	srv := server.New(cfg.Server.Host, cfg.Server.Port, router, tlsOpts,
 		cfg.Server.ReadTimeout, cfg.Server.WriteTimeout, cfg.Server.IdleTimeout)
@@ -284,20 +455,28 @@ func New(cfg *config.Config) (*App, error) {
 		DB:                    db,
 		Server:                srv,
 		Hub:                   hub,
+		broker:                broker,
 		PresenceManager:       presenceManager,
 		EmailService:          emailService,
 		NotificationService:   notificationService,
 		EmailWorker:           emailWorker,
 		RateLimiter:           limiter,
+		cors:                  corsPolicy,
+		sseHandler:            sseHandler,
 		SessionStore:          sessionStore,
 		emailVerificationRepo: emailVerificationRepo,
 		LinkPreviewRepo:       linkPreviewRepo,
 		ScheduledWorker:       scheduledWorker,
 		passwordResetRepo:     passwordResetRepo,
 		pushTokenRepo:         pushTokenRepo,
+		webPushRepo:           webPushRepo,
 		moderationRepo:        moderationRepo,
+		scanService:           scanService,
+		imageProxy:            imageProxy,
 		scheduler:             scheduler.New(),
 		Telemetry:             tel,
+		metricsServer:         metricsServer,
+		inboundServer:         inboundServer,
 	}, nil
 }
 
@@ -308,12 +487,12 @@ func (a *App) Start(ctx context.Context) error {
 	// Start SSE hub (needs its own goroutine for client register/unregister channels)
 	go a.Hub.Run(ctx)
 
-	// Register periodic tasks
+	// Register periodic tasks. Tasks that touch shared state run on one
+	// instance at a time; Local ones maintain per-instance state.
 	s := a.scheduler
+	s.SetLeases(scheduler.NewLeases(a.DB.DB, a.Hub.NodeID()))
 
-	if a.RateLimiter != nil {
-		s.Register(scheduler.Task{Name: "rate-limiter-cleanup", Interval: 10 * time.Minute, Fn: func(ctx context.Context) error { a.RateLimiter.Cleanup(); return nil }})
-	}
+	s.Register(scheduler.Task{Name: "rate-limiter-cleanup", Interval: 10 * time.Minute, Local: true, Fn: func(ctx context.Context) error { a.RateLimiter.Cleanup(); return nil }})
 	s.Register(scheduler.Task{Name: "session-cleanup", Interval: time.Hour, Fn: func(ctx context.Context) error { return a.SessionStore.DeleteExpired() }})
 	s.Register(scheduler.Task{Name: "link-preview-cleanup", Interval: 24 * time.Hour, Fn: func(ctx context.Context) error { return a.LinkPreviewRepo.CleanExpiredCache(ctx) }})
 
@@ -321,17 +500,35 @@ func (a *App) Start(ctx context.Context) error {
 		s.Register(scheduler.Task{Name: "sse-event-cleanup", Interval: a.Config.SSE.CleanupInterval, Fn: a.Hub.CleanupOldEvents, RunOnStart: true})
 	}
 
-	s.Register(scheduler.Task{Name: "presence-check", Interval: 10 * time.Second, Fn: a.PresenceManager.CheckPresence})
+	s.Register(scheduler.Task{Name: "presence-check", Interval: 10 * time.Second, Local: true, Fn: a.PresenceManager.CheckPresence})
+	s.Register(scheduler.Task{Name: "held-notifications", Interval: time.Minute, Fn: a.NotificationService.ReleaseHeld})
 	s.Register(scheduler.Task{Name: "scheduled-messages", Interval: 30 * time.Second, Fn: a.ScheduledWorker.ProcessDue})
 	s.Register(scheduler.Task{Name: "expired-ban-cleanup", Interval: time.Hour, Fn: a.moderationRepo.CleanupExpiredBans})
-	s.Register(scheduler.Task{Name: "sqlite-optimize", Interval: 24 * time.Hour, Fn: func(ctx context.Context) error { _, err := a.DB.Exec("PRAGMA optimize(0x10002)"); return err }})
+	if a.DB.Dialect == database.SQLite {
+		s.Register(scheduler.Task{Name: "sqlite-optimize", Interval: 24 * time.Hour, Fn: func(ctx context.Context) error { _, err := a.DB.Exec("PRAGMA optimize(0x10002)"); return err }})
+	}
 
 	if a.EmailService.IsEnabled() {
 		s.Register(scheduler.Task{Name: "email-notifications", Interval: time.Minute, Fn: a.EmailWorker.ProcessPending})
+		s.Register(scheduler.Task{Name: "email-outbox", Interval: 30 * time.Second, Fn: a.EmailService.ProcessOutbox, RunOnStart: true})
+		s.Register(scheduler.Task{Name: "email-outbox-cleanup", Interval: 24 * time.Hour, Fn: a.EmailService.CleanupOutbox})
 		s.Register(scheduler.Task{Name: "password-reset-cleanup", Interval: 24 * time.Hour, Fn: a.passwordResetRepo.DeleteExpired})
 		s.Register(scheduler.Task{Name: "email-verification-cleanup", Interval: 24 * time.Hour, Fn: a.emailVerificationRepo.DeleteExpired})
 	}
 
+	if a.Config.Backup.Enabled {
+		opts := backup.OptionsFromConfig(a.Config)
+		s.Register(scheduler.Task{Name: "database-backup", Interval: a.Config.Backup.Interval, Fn: func(ctx context.Context) error { return backup.Run(ctx, a.DB.DB, opts) }})
+	}
+
+	if a.imageProxy != nil {
+		s.Register(scheduler.Task{Name: "image-proxy-eviction", Interval: time.Hour, Fn: a.imageProxy.EvictExpired})
+	}
+
+	if a.scanService != nil {
+		s.Register(scheduler.Task{Name: "attachment-scan", Interval: time.Minute, Fn: a.scanService.ProcessPending})
+	}
+
 	if a.pushTokenRepo != nil {
 		s.Register(scheduler.Task{Name: "push-token-cleanup", Interval: 24 * time.Hour, Fn: func(ctx context.Context) error {
 			n, err := a.pushTokenRepo.CleanupStale(ctx, time.Now().Add(-90*24*time.Hour))
@@ -341,9 +538,36 @@ func (a *App) Start(ctx context.Context) error {
 			return err
 		}})
 	}
+	if a.webPushRepo != nil {
+		s.Register(scheduler.Task{Name: "web-push-subscription-cleanup", Interval: 24 * time.Hour, Fn: func(ctx context.Context) error {
+			n, err := a.webPushRepo.CleanupStale(ctx, time.Now().Add(-90*24*time.Hour))
+			if err == nil && n > 0 {
+				slog.Info("cleaned up stale web push subscriptions", "count", n)
+			}
+			return err
+		}})
+	}
 
 	s.Start(ctx)
 
+	if a.metricsServer != nil {
+		go func() {
+			slog.Info("serving metrics", "addr", a.metricsServer.Addr)
+			if err := a.metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				slog.Error("metrics server error", "error", err)
+			}
+		}()
+	}
+
+	if a.inboundServer != nil {
+		go func() {
+			slog.Info("receiving email over SMTP", "addr", a.inboundServer.Addr())
+			if err := a.inboundServer.ListenAndServe(); err != nil && err != inbound.ErrServerClosed {
+				slog.Error("inbound email server error", "error", err)
+			}
+		}()
+	}
+
 	var storageInfo string
 	switch a.Config.Storage.Type {
 	case "local":
@@ -371,9 +595,24 @@ func (a *App) Shutdown(ctx context.Context) error {
 	if err := a.Server.Shutdown(ctx); err != nil {
 		return err
 	}
+	if a.metricsServer != nil {
+		if err := a.metricsServer.Shutdown(ctx); err != nil {
+			slog.Error("metrics server shutdown error", "error", err)
+		}
+	}
+	if a.inboundServer != nil {
+		if err := a.inboundServer.Shutdown(ctx); err != nil {
+			slog.Error("inbound email server shutdown error", "error", err)
+		}
+	}
 	// Flush telemetry before closing database
 	if err := a.Telemetry.Shutdown(ctx); err != nil {
 		slog.Error("telemetry shutdown error", "error", err)
 	}
+	if a.broker != nil {
+		if err := a.broker.Close(); err != nil {
+			slog.Error("sse broker shutdown error", "error", err)
+		}
+	}
 	return a.DB.Close()
 }
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/enzyme/server/internal/config"
+	"github.com/enzyme/server/internal/logging"
+	"github.com/enzyme/server/internal/ratelimit"
+)
+
+// liveConfigKeys are the config keys, or prefixes of them, that Reload
+// applies to the running server. Everything else needs a restart.
+var liveConfigKeys = []string{
+	"log.level",
+	"log.format",
+	"rate_limit",
+	"server.allowed_origins",
+	"email.transport",
+	"email.host",
+	"email.port",
+	"email.username",
+	"email.password",
+	"email.tls",
+	"email.from",
+	"email.http",
+	"email.sendmail",
+	"email.dkim",
+	"push_notifications.include_preview",
+	"sse.heartbeat_interval",
+}
+
+func isLiveConfigKey(key string) bool {
+	for _, live := range liveConfigKeys {
+		if key == live || strings.HasPrefix(key, live+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Reload applies a new, already validated config to the running server.
+// Settings that can't change without a restart are logged and otherwise
+// ignored until the next restart.
+func (a *App) Reload(next *config.Config) {
+	// Derived the same way as in New, so they don't show up as changes
+	next.Server.PublicURL = strings.TrimRight(next.Server.PublicURL, "/")
+	if next.Storage.Local.SigningSecret == "" {
+		next.Storage.Local.SigningSecret = a.Config.Storage.Local.SigningSecret
+	}
+
+	var applied, restart []string
+	for _, key := range config.Diff(a.Config, next) {
+		if isLiveConfigKey(key) {
+			applied = append(applied, key)
+		} else {
+			restart = append(restart, key)
+		}
+	}
+
+	cfg := a.Config
+	if len(applied) > 0 {
+		cfg.Log = next.Log
+		logging.Setup(cfg.Log, cfg.Telemetry.Enabled && cfg.Telemetry.Logs, cfg.Telemetry.ServiceName)
+
+		cfg.RateLimit = next.RateLimit
+		a.RateLimiter.SetRules(rateLimitRules(cfg.RateLimit))
+
+		cfg.Server.AllowedOrigins = next.Server.AllowedOrigins
+		a.cors.SetAllowedOrigins(cfg.Server.AllowedOrigins)
+		a.sseHandler.SetAllowedOrigins(cfg.Server.AllowedOrigins)
+
+		cfg.Email.Transport = next.Email.Transport
+		cfg.Email.Host = next.Email.Host
+		cfg.Email.Port = next.Email.Port
+		cfg.Email.Username = next.Email.Username
+		cfg.Email.Password = next.Email.Password
+		cfg.Email.TLS = next.Email.TLS
+		cfg.Email.From = next.Email.From
+		cfg.Email.HTTP = next.Email.HTTP
+		cfg.Email.Sendmail = next.Email.Sendmail
+		cfg.Email.DKIM = next.Email.DKIM
+		a.EmailService.Reconfigure(cfg.Email)
+
+		cfg.PushNotifications.IncludePreview = next.PushNotifications.IncludePreview
+		a.NotificationService.SetIncludePreview(cfg.PushNotifications.IncludePreview)
+
+		cfg.SSE.HeartbeatInterval = next.SSE.HeartbeatInterval
+		a.sseHandler.SetHeartbeatInterval(cfg.SSE.HeartbeatInterval)
+	}
+
+	if len(restart) > 0 {
+		slog.Warn("config changes need a restart to take effect", "keys", restart)
+	}
+	slog.Info("config reloaded", "applied", applied)
+}
+
+// rateLimitRules returns the rules for the rate limited auth endpoints, or
+// none when rate limiting is disabled.
+func rateLimitRules(cfg config.RateLimitConfig) []ratelimit.Rule {
+	if !cfg.Enabled {
+		return nil
+	}
+	return []ratelimit.Rule{
+		{Method: "POST", Path: "/api/auth/login", Limit: cfg.Login.Limit, Window: cfg.Login.Window},
+		{Method: "POST", Path: "/api/auth/register", Limit: cfg.Register.Limit, Window: cfg.Register.Window},
+		{Method: "POST", Path: "/api/auth/forgot-password", Limit: cfg.ForgotPassword.Limit, Window: cfg.ForgotPassword.Window},
+		{Method: "POST", Path: "/api/auth/reset-password", Limit: cfg.ResetPassword.Limit, Window: cfg.ResetPassword.Window},
+		{Method: "POST", Path: "/api/auth/verify-email", Limit: cfg.VerifyEmail.Limit, Window: cfg.VerifyEmail.Window},
+		{Method: "POST", Path: "/api/auth/resend-verification", Limit: cfg.ResendVerification.Limit, Window: cfg.ResendVerification.Window},
+		{Method: "POST", Path: "/api/auth/device-tokens", Limit: cfg.DeviceTokenRegister.Limit, Window: cfg.DeviceTokenRegister.Window},
+		{Method: "POST", Path: "/api/auth/web-push-subscriptions", Limit: cfg.DeviceTokenRegister.Limit, Window: cfg.DeviceTokenRegister.Window},
+	}
+}
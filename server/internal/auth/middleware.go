@@ -46,7 +46,8 @@ func RequireAuth() func(http.Handler) http.Handler {
 	}
 }
 
-// WithUserID returns a context with the given user ID set (for testing).
+// WithUserID returns a context with the given user ID set (for testing, and
+// for acting as a user outside of an HTTP request).
 func WithUserID(ctx context.Context, id string) context.Context {
 	return context.WithValue(ctx, userIDKey, id)
 }
@@ -15,6 +15,7 @@ import (
 var (
 	ErrInvalidCredentials       = errors.New("invalid email or password")
 	ErrUserDeactivated          = errors.New("user account is deactivated")
+	ErrUserPendingApproval      = errors.New("user account is awaiting approval")
 	ErrInvalidResetToken        = errors.New("invalid or expired reset token")
 	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
 	ErrPasswordTooShort         = errors.New("password must be at least 8 characters")
@@ -56,6 +57,12 @@ type RegisterInput struct {
 	Email       string `json:"email"`
 	Password    string `json:"password"`
 	DisplayName string `json:"display_name"`
+	// PendingApproval creates an account that cannot log in until a site
+	// admin approves it.
+	PendingApproval bool `json:"-"`
+	// InTx runs in the transaction that creates the account, for changes
+	// that must succeed or fail together with it.
+	InTx func(ctx context.Context, tx *sql.Tx, u *user.User) error `json:"-"`
 }
 
 func (s *Service) Register(ctx context.Context, input RegisterInput) (*user.User, error) {
@@ -75,9 +82,11 @@ func (s *Service) Register(ctx context.Context, input RegisterInput) (*user.User
 	}
 
 	return s.userRepo.Create(ctx, user.CreateUserInput{
-		Email:        input.Email,
-		DisplayName:  input.DisplayName,
-		PasswordHash: hash,
+		Email:           input.Email,
+		DisplayName:     input.DisplayName,
+		PasswordHash:    hash,
+		PendingApproval: input.PendingApproval,
+		InTx:            input.InTx,
 	})
 }
 
@@ -95,7 +104,7 @@ func (s *Service) Login(ctx context.Context, input LoginInput) (*user.User, erro
 		return nil, err
 	}
 
-	if u.Status == "deactivated" {
+	if u.Status == user.StatusDeactivated {
 		return nil, ErrUserDeactivated
 	}
 
@@ -103,6 +112,12 @@ func (s *Service) Login(ctx context.Context, input LoginInput) (*user.User, erro
 		return nil, ErrInvalidCredentials
 	}
 
+	// Checked after the password so that only the account holder learns
+	// the account exists
+	if u.PendingApproval {
+		return nil, ErrUserPendingApproval
+	}
+
 	return u, nil
 }
 
@@ -69,6 +69,16 @@ func (s *SessionStore) Delete(token string) error {
 	return err
 }
 
+// DeleteAllForUser removes every session belonging to a user, signing them
+// out everywhere. It returns the number of sessions removed.
+func (s *SessionStore) DeleteAllForUser(userID string) (int64, error) {
+	result, err := s.db.Exec("DELETE FROM sessions WHERE user_id = ?", userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 // DeleteExpired removes all expired sessions.
 func (s *SessionStore) DeleteExpired() error {
 	_, err := s.db.Exec("DELETE FROM sessions WHERE expiry < ?", time.Now().UTC().Format(time.RFC3339))
@@ -0,0 +1,180 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Archive layout: the database snapshot at archiveDBName, and the uploads
+// directory under archiveUploads.
+const (
+	archiveDBName  = "enzyme.db"
+	archiveUploads = "uploads/"
+)
+
+// writeArchive bundles the database snapshot and the uploads directory into
+// a gzipped tarball at dst.
+func writeArchive(ctx context.Context, dst, snapshot, uploadsDir string) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("creating archive: %w", err)
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	if err := addFile(tw, snapshot, archiveDBName); err != nil {
+		return err
+	}
+
+	err = filepath.WalkDir(uploadsDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(uploadsDir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		name := archiveUploads + filepath.ToSlash(rel)
+		switch {
+		case d.IsDir():
+			return tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: 0755})
+		case !d.Type().IsRegular(), strings.HasPrefix(d.Name(), tmpPrefix):
+			// Skip symlinks and in-progress local storage writes
+			return nil
+		default:
+			return addFile(tw, p, name)
+		}
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("archiving uploads: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	return f.Close()
+}
+
+func addFile(tw *tar.Writer, src, name string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0644,
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+	}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("archiving %s: %w", name, err)
+	}
+	return nil
+}
+
+// verifyArchive reads the whole archive back, which checks the gzip CRC and
+// every entry length, and confirms the database entry matches snapshot.
+func verifyArchive(p, snapshot string) error {
+	var dbSum []byte
+	err := walkArchive(p, func(hdr *tar.Header, r io.Reader) error {
+		h := sha256.New()
+		if _, err := io.Copy(h, r); err != nil {
+			return err
+		}
+		if hdr.Name == archiveDBName {
+			dbSum = h.Sum(nil)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("verifying archive: %w", err)
+	}
+	if dbSum == nil {
+		return fmt.Errorf("verifying archive: %s is missing", archiveDBName)
+	}
+
+	want, err := fileSum(snapshot)
+	if err != nil {
+		return fmt.Errorf("verifying archive: %w", err)
+	}
+	if !bytes.Equal(dbSum, want) {
+		return fmt.Errorf("verifying archive: database entry does not match snapshot")
+	}
+	return nil
+}
+
+func fileSum(p string) ([]byte, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// walkArchive calls fn for every entry in a gzipped tarball, rejecting
+// entries that would escape the extraction directory.
+func walkArchive(p string, fn func(hdr *tar.Header, r io.Reader) error) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !filepath.IsLocal(hdr.Name) || path.Clean(hdr.Name) != strings.TrimSuffix(hdr.Name, "/") {
+			return fmt.Errorf("unsafe path in archive: %q", hdr.Name)
+		}
+		if err := fn(hdr, tr); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,242 @@
+// Package backup takes consistent snapshots of the database, optionally
+// bundled with local uploads, and restores them.
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/enzyme/server/internal/config"
+	"github.com/enzyme/server/internal/database"
+)
+
+const (
+	filePrefix = "enzyme-"
+	tmpPrefix  = ".tmp-"
+	dbExt      = ".db"
+	archiveExt = ".tar.gz"
+	timeFormat = "20060102T150405Z"
+)
+
+var (
+	ErrIntegrity   = errors.New("database failed integrity check")
+	ErrNewerSchema = errors.New("backup was made by a newer version of enzyme")
+	ErrUnsupported = errors.New("backups are only supported for sqlite; use pg_dump for postgres")
+)
+
+// Options controls where backups are written and how many are kept.
+type Options struct {
+	Dir        string        // directory backups are written to
+	UploadsDir string        // local uploads directory to bundle; empty backs up the database only
+	Keep       int           // number of backups to keep; 0 keeps all
+	MaxAge     time.Duration // delete backups older than this; 0 disables
+}
+
+// OptionsFromConfig returns the backup options for cfg. Uploads are only
+// bundled for local storage; S3 buckets should be backed up separately.
+func OptionsFromConfig(cfg *config.Config) Options {
+	opts := Options{Dir: cfg.Backup.Dir, Keep: cfg.Backup.Keep, MaxAge: cfg.Backup.MaxAge}
+	if cfg.Backup.IncludeUploads && cfg.Storage.Type == "local" {
+		opts.UploadsDir = cfg.Storage.Local.Path
+	}
+	return opts
+}
+
+// Backup is a backup file on disk.
+type Backup struct {
+	Path      string
+	CreatedAt time.Time
+	Size      int64
+	Version   int64 // schema version; only set for backups returned by Create
+}
+
+// Create writes a new backup to opts.Dir. The database snapshot is taken
+// with VACUUM INTO, which is consistent even while the server is writing,
+// and is integrity-checked before it is kept. Without uploads the backup is
+// a plain SQLite file; with uploads it is a .tar.gz archive.
+func Create(ctx context.Context, db *sql.DB, opts Options) (*Backup, error) {
+	if database.DialectOf(db) != database.SQLite {
+		return nil, ErrUnsupported
+	}
+	if err := os.MkdirAll(opts.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("creating backup directory: %w", err)
+	}
+
+	now := time.Now().UTC()
+	name := filePrefix + now.Format(timeFormat)
+	snapshot := filepath.Join(opts.Dir, tmpPrefix+name+dbExt)
+	_ = os.Remove(snapshot)
+	defer os.Remove(snapshot)
+
+	if _, err := db.ExecContext(ctx, `VACUUM INTO ?`, snapshot); err != nil {
+		return nil, fmt.Errorf("snapshotting database: %w", err)
+	}
+	version, err := checkDatabase(ctx, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var final string
+	if opts.UploadsDir == "" {
+		final = filepath.Join(opts.Dir, name+dbExt)
+		if err := os.Chmod(snapshot, 0600); err != nil {
+			return nil, err
+		}
+		if err := os.Rename(snapshot, final); err != nil {
+			return nil, fmt.Errorf("writing backup: %w", err)
+		}
+	} else {
+		final = filepath.Join(opts.Dir, name+archiveExt)
+		tmp := filepath.Join(opts.Dir, tmpPrefix+name+archiveExt)
+		if err := writeArchive(ctx, tmp, snapshot, opts.UploadsDir); err != nil {
+			_ = os.Remove(tmp)
+			return nil, err
+		}
+		if err := verifyArchive(tmp, snapshot); err != nil {
+			_ = os.Remove(tmp)
+			return nil, err
+		}
+		if err := os.Rename(tmp, final); err != nil {
+			_ = os.Remove(tmp)
+			return nil, fmt.Errorf("writing backup: %w", err)
+		}
+	}
+
+	info, err := os.Stat(final)
+	if err != nil {
+		return nil, err
+	}
+	return &Backup{Path: final, CreatedAt: now, Size: info.Size(), Version: version}, nil
+}
+
+// Run creates a backup and then rotates old ones. It is the scheduled task.
+func Run(ctx context.Context, db *sql.DB, opts Options) error {
+	start := time.Now()
+	b, err := Create(ctx, db, opts)
+	if err != nil {
+		return err
+	}
+	slog.Info("backup created", "path", b.Path, "size", b.Size, "duration", time.Since(start))
+
+	removed, err := Rotate(opts.Dir, opts.Keep, opts.MaxAge, time.Now())
+	for _, p := range removed {
+		slog.Info("removed old backup", "path", p)
+	}
+	return err
+}
+
+// List returns the backups in dir, newest first.
+func List(dir string) ([]Backup, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		createdAt, ok := parseName(e.Name())
+		if !ok {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, Backup{Path: filepath.Join(dir, e.Name()), CreatedAt: createdAt, Size: info.Size()})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// Rotate deletes backups beyond the newest keep, and any older than maxAge.
+// The newest backup is never deleted. It returns the paths it removed.
+func Rotate(dir string, keep int, maxAge time.Duration, now time.Time) ([]string, error) {
+	backups, err := List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for i, b := range backups {
+		if i == 0 {
+			continue
+		}
+		tooMany := keep > 0 && i >= keep
+		tooOld := maxAge > 0 && now.Sub(b.CreatedAt) > maxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(b.Path); err != nil {
+			return removed, fmt.Errorf("removing old backup: %w", err)
+		}
+		removed = append(removed, b.Path)
+	}
+	return removed, nil
+}
+
+// parseName extracts the creation time from a backup file name.
+func parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, filePrefix) {
+		return time.Time{}, false
+	}
+	stamp := strings.TrimPrefix(name, filePrefix)
+	switch {
+	case strings.HasSuffix(stamp, archiveExt):
+		stamp = strings.TrimSuffix(stamp, archiveExt)
+	case strings.HasSuffix(stamp, dbExt):
+		stamp = strings.TrimSuffix(stamp, dbExt)
+	default:
+		return time.Time{}, false
+	}
+	t, err := time.Parse(timeFormat, stamp)
+	return t, err == nil
+}
+
+// checkDatabase runs PRAGMA integrity_check on a database file and returns
+// its schema version. The file is opened read-only.
+func checkDatabase(ctx context.Context, path string) (int64, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	if err != nil {
+		return 0, fmt.Errorf("opening snapshot: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, `PRAGMA integrity_check`)
+	if err != nil {
+		return 0, fmt.Errorf("checking integrity: %w", err)
+	}
+	var problems []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("checking integrity: %w", err)
+	}
+	if len(problems) > 0 {
+		return 0, fmt.Errorf("%w: %s", ErrIntegrity, strings.Join(problems, "; "))
+	}
+
+	return database.SchemaVersion(db)
+}
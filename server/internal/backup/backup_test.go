@@ -0,0 +1,216 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/database"
+	"github.com/enzyme/server/internal/testutil"
+)
+
+// openFileDB opens a migrated on-disk database, since restores replace files.
+func openFileDB(t *testing.T, path string) *sql.DB {
+	t.Helper()
+	db, err := database.Open(path, database.Options{MaxOpenConns: 1, BusyTimeout: 5000, CacheSize: -2000})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db.DB
+}
+
+func countUsers(t *testing.T, path string) int {
+	t.Helper()
+	db := openFileDB(t, path)
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM users`).Scan(&n); err != nil {
+		t.Fatalf("count users: %v", err)
+	}
+	_ = db.Close()
+	return n
+}
+
+func writeTestFile(t *testing.T, p, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreate_DatabaseOnly(t *testing.T) {
+	db := testutil.TestDB(t)
+	testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	dir := t.TempDir()
+
+	b, err := Create(context.Background(), db, Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if filepath.Ext(b.Path) != dbExt {
+		t.Fatalf("backup path = %q, want a .db file", b.Path)
+	}
+	latest, _ := database.LatestVersion(database.SQLite)
+	if b.Version != latest {
+		t.Errorf("Version = %d, want %d", b.Version, latest)
+	}
+	if info, _ := os.Stat(b.Path); info.Mode().Perm() != 0600 {
+		t.Errorf("backup mode = %v, want 0600", info.Mode().Perm())
+	}
+	if n := countUsers(t, b.Path); n != 1 {
+		t.Errorf("users in backup = %d, want 1", n)
+	}
+
+	backups, err := List(dir)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("List = (%v, %v), want one backup", backups, err)
+	}
+}
+
+func TestCreateAndRestore_WithUploads(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.TestDB(t)
+	testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	testutil.CreateTestUser(t, db, "bob@example.com", "Bob")
+
+	uploads := t.TempDir()
+	writeTestFile(t, filepath.Join(uploads, "avatars", "a.png"), "avatar")
+	writeTestFile(t, filepath.Join(uploads, "ws", "ch", "doc.txt"), "document")
+	writeTestFile(t, filepath.Join(uploads, "ws", ".tmp-doc.txt-123"), "partial")
+
+	b, err := Create(ctx, db, Options{Dir: t.TempDir(), UploadsDir: uploads})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if !strings.HasSuffix(b.Path, archiveExt) {
+		t.Fatalf("backup path = %q, want a %s archive", b.Path, archiveExt)
+	}
+
+	// Restore over an existing installation
+	target := t.TempDir()
+	dbPath := filepath.Join(target, "enzyme.db")
+	uploadsDir := filepath.Join(target, "uploads")
+	existing := openFileDB(t, dbPath)
+	_ = existing.Close()
+	writeTestFile(t, filepath.Join(uploadsDir, "old.txt"), "old")
+
+	res, err := Restore(ctx, b.Path, RestoreOptions{DBPath: dbPath, UploadsDir: uploadsDir})
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if !res.Uploads || res.PreviousDB == "" || res.PreviousUploads == "" {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	if n := countUsers(t, dbPath); n != 2 {
+		t.Errorf("users after restore = %d, want 2", n)
+	}
+	for rel, want := range map[string]string{"avatars/a.png": "avatar", "ws/ch/doc.txt": "document"} {
+		got, err := os.ReadFile(filepath.Join(uploadsDir, rel))
+		if err != nil || string(got) != want {
+			t.Errorf("%s = (%q, %v), want %q", rel, got, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(uploadsDir, "ws", ".tmp-doc.txt-123")); !os.IsNotExist(err) {
+		t.Error("in-progress upload should not be archived")
+	}
+	if _, err := os.Stat(filepath.Join(res.PreviousUploads, "old.txt")); err != nil {
+		t.Errorf("previous uploads should be kept: %v", err)
+	}
+	if n := countUsers(t, res.PreviousDB); n != 0 {
+		t.Errorf("previous database users = %d, want 0", n)
+	}
+}
+
+func TestRestore_RejectsNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	b, err := Create(ctx, testutil.TestDB(t), Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// Simulate a backup taken by a newer release
+	latest, _ := database.LatestVersion(database.SQLite)
+	newer, err := sql.Open("sqlite", b.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newer.Exec(`INSERT INTO goose_db_version (version_id, is_applied) VALUES (?, 1)`, latest+1); err != nil {
+		t.Fatalf("bump version: %v", err)
+	}
+	_ = newer.Close()
+
+	dbPath := filepath.Join(t.TempDir(), "enzyme.db")
+	writeTestFile(t, dbPath, "current")
+	_, err = Restore(ctx, b.Path, RestoreOptions{DBPath: dbPath})
+	if !errors.Is(err, ErrNewerSchema) {
+		t.Fatalf("expected ErrNewerSchema, got %v", err)
+	}
+	if got, _ := os.ReadFile(dbPath); string(got) != "current" {
+		t.Fatal("current database should be left in place")
+	}
+}
+
+func TestRestore_RejectsInvalidBackup(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "enzyme-20260101T000000Z.db")
+	writeTestFile(t, src, "this is not a database")
+
+	dbPath := filepath.Join(t.TempDir(), "enzyme.db")
+	if _, err := Restore(context.Background(), src, RestoreOptions{DBPath: dbPath}); err == nil {
+		t.Fatal("expected error restoring an invalid backup")
+	}
+	if _, err := os.Stat(dbPath); !os.IsNotExist(err) {
+		t.Fatal("nothing should be installed for an invalid backup")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	var names []string
+	for days := 0; days < 5; days++ {
+		name := filePrefix + now.Add(-time.Duration(days)*24*time.Hour).Format(timeFormat) + dbExt
+		writeTestFile(t, filepath.Join(dir, name), "x")
+		names = append(names, name)
+	}
+	writeTestFile(t, filepath.Join(dir, "unrelated.db"), "x")
+	writeTestFile(t, filepath.Join(dir, tmpPrefix+names[0]), "x")
+
+	removed, err := Rotate(dir, 3, 0, now)
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Fatalf("removed %v, want the two oldest", removed)
+	}
+
+	removed, err = Rotate(dir, 0, 36*time.Hour, now)
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	if len(removed) != 1 || filepath.Base(removed[0]) != names[2] {
+		t.Fatalf("removed %v, want %s", removed, names[2])
+	}
+
+	// The newest backup survives even when it is too old
+	removed, _ = Rotate(dir, 0, time.Hour, now.Add(30*24*time.Hour))
+	backups, _ := List(dir)
+	if len(backups) != 1 || filepath.Base(backups[0].Path) != names[0] {
+		t.Fatalf("remaining backups = %v (removed %v), want only %s", backups, removed, names[0])
+	}
+	for _, keep := range []string{"unrelated.db", tmpPrefix + names[0]} {
+		if _, err := os.Stat(filepath.Join(dir, keep)); err != nil {
+			t.Errorf("%s should not be touched: %v", keep, err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package backup
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/enzyme/server/internal/database"
+)
+
+// RestoreOptions says where a backup is restored to.
+type RestoreOptions struct {
+	DBPath     string
+	UploadsDir string // where bundled uploads are restored; empty leaves uploads alone
+}
+
+// RestoreResult describes what a restore replaced.
+type RestoreResult struct {
+	Version         int64
+	Uploads         bool   // whether uploads were restored from the archive
+	PreviousDB      string // where the replaced database was moved; empty if there was none
+	PreviousUploads string // where the replaced uploads directory was moved
+}
+
+// Restore replaces the database (and, for archives, the uploads directory)
+// with the contents of a backup. The backup is staged next to its target
+// and validated first: it must pass an integrity check and must not have a
+// newer schema than this binary can migrate. Existing files are moved aside
+// with a .pre-restore suffix rather than deleted. The server must be
+// stopped while this runs.
+func Restore(ctx context.Context, src string, opts RestoreOptions) (*RestoreResult, error) {
+	if err := os.MkdirAll(filepath.Dir(opts.DBPath), 0755); err != nil {
+		return nil, fmt.Errorf("creating database directory: %w", err)
+	}
+
+	stagedDB := opts.DBPath + ".restore-tmp"
+	_ = os.Remove(stagedDB)
+	defer os.Remove(stagedDB)
+
+	var stagedUploads string
+	hasUploads := false
+	if strings.HasSuffix(src, archiveExt) {
+		if opts.UploadsDir != "" {
+			stagedUploads = opts.UploadsDir + ".restore-tmp"
+			_ = os.RemoveAll(stagedUploads)
+			defer os.RemoveAll(stagedUploads)
+		}
+		var err error
+		if hasUploads, err = extractArchive(src, stagedDB, stagedUploads); err != nil {
+			return nil, err
+		}
+	} else if err := copyFile(src, stagedDB); err != nil {
+		return nil, fmt.Errorf("staging backup: %w", err)
+	}
+
+	version, err := checkDatabase(ctx, stagedDB)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := database.LatestVersion(database.SQLite)
+	if err != nil {
+		return nil, err
+	}
+	if version > latest {
+		return nil, fmt.Errorf("%w: schema version %d, this binary supports up to %d", ErrNewerSchema, version, latest)
+	}
+
+	res := &RestoreResult{Version: version, Uploads: hasUploads}
+	suffix := ".pre-restore-" + time.Now().UTC().Format(timeFormat)
+
+	if exists(opts.DBPath) {
+		res.PreviousDB = opts.DBPath + suffix
+		if err := os.Rename(opts.DBPath, res.PreviousDB); err != nil {
+			return nil, fmt.Errorf("moving current database aside: %w", err)
+		}
+		// Leftover WAL files belong to the old database, not the restored one
+		for _, ext := range []string{"-wal", "-shm"} {
+			if exists(opts.DBPath + ext) {
+				if err := os.Rename(opts.DBPath+ext, res.PreviousDB+ext); err != nil {
+					return nil, fmt.Errorf("moving current database aside: %w", err)
+				}
+			}
+		}
+	}
+	if err := os.Rename(stagedDB, opts.DBPath); err != nil {
+		return nil, fmt.Errorf("installing restored database: %w", err)
+	}
+
+	if hasUploads {
+		if exists(opts.UploadsDir) {
+			res.PreviousUploads = opts.UploadsDir + suffix
+			if err := os.Rename(opts.UploadsDir, res.PreviousUploads); err != nil {
+				return res, fmt.Errorf("moving current uploads aside: %w", err)
+			}
+		}
+		if err := os.Rename(stagedUploads, opts.UploadsDir); err != nil {
+			return res, fmt.Errorf("installing restored uploads: %w", err)
+		}
+	}
+	return res, nil
+}
+
+// extractArchive writes the archived database to dbPath and, when
+// uploadsDir is set, the archived uploads under it. It reports whether any
+// uploads were extracted.
+func extractArchive(src, dbPath, uploadsDir string) (bool, error) {
+	foundDB, foundUploads := false, false
+	err := walkArchive(src, func(hdr *tar.Header, r io.Reader) error {
+		switch {
+		case hdr.Name == archiveDBName && hdr.Typeflag == tar.TypeReg:
+			foundDB = true
+			return writeFile(dbPath, r, 0600)
+		case uploadsDir == "" || !strings.HasPrefix(hdr.Name, archiveUploads):
+			return nil
+		}
+
+		dst := filepath.Join(uploadsDir, filepath.FromSlash(strings.TrimPrefix(hdr.Name, archiveUploads)))
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			return os.MkdirAll(dst, 0755)
+		case tar.TypeReg:
+			foundUploads = true
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				return err
+			}
+			return writeFile(dst, r, 0644)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("extracting backup: %w", err)
+	}
+	if !foundDB {
+		return false, fmt.Errorf("extracting backup: %s is missing", archiveDBName)
+	}
+	return foundUploads, nil
+}
+
+func copyFile(src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return writeFile(dst, f, 0600)
+}
+
+func writeFile(p string, r io.Reader, perm os.FileMode) error {
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func exists(p string) bool {
+	_, err := os.Stat(p)
+	return !errors.Is(err, os.ErrNotExist)
+}
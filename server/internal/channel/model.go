@@ -1,6 +1,7 @@
 package channel
 
 import (
+	"strings"
 	"time"
 )
 
@@ -64,6 +65,47 @@ const (
 	ChannelRoleViewer = "viewer"
 )
 
+// MentionPermissions overrides the workspace's settings for which roles may
+// use @here, @channel and @everyone in a channel. Nil fields inherit.
+type MentionPermissions struct {
+	Here     *string `json:"who_can_mention_here,omitempty"`
+	Channel  *string `json:"who_can_mention_channel,omitempty"`
+	Everyone *string `json:"who_can_mention_everyone,omitempty"`
+}
+
+// EmailAddress is a channel's secret inbound email address. Mail sent to it
+// is posted into the channel as a bot message.
+type EmailAddress struct {
+	ChannelID      string    `json:"channel_id"`
+	Token          string    `json:"-"`
+	AllowedSenders []string  `json:"allowed_senders"`
+	CreatedBy      *string   `json:"created_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// AllowsSender reports whether mail from the given address may be posted.
+// Entries of the allowlist are full addresses or domains, written either as
+// "example.com" or "@example.com". An empty allowlist allows every sender.
+func (a *EmailAddress) AllowsSender(address string) bool {
+	if len(a.AllowedSenders) == 0 {
+		return true
+	}
+	address = strings.ToLower(strings.TrimSpace(address))
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return false
+	}
+	domain := address[at+1:]
+	for _, entry := range a.AllowedSenders {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == address || strings.TrimPrefix(entry, "@") == domain {
+			return true
+		}
+	}
+	return false
+}
+
 // WorkspaceNotificationSummary holds aggregated unread/notification counts per workspace
 type WorkspaceNotificationSummary struct {
 	WorkspaceID       string
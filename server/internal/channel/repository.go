@@ -2,14 +2,17 @@ package channel
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/enzyme/server/internal/database"
 	"github.com/enzyme/server/internal/telemetry"
 	"github.com/oklog/ulid/v2"
 )
@@ -24,14 +27,41 @@ var (
 	ErrCannotLeaveDefault   = errors.New("cannot leave the default channel")
 	ErrCannotArchiveDefault = errors.New("cannot archive the default channel")
 	ErrChannelNameTaken     = errors.New("channel name already taken")
+	ErrEmailAddressNotFound = errors.New("channel has no email address")
 )
 
 type Repository struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect database.Dialect
 }
 
 func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: db}
+	return &Repository{db: db, dialect: database.DialectOf(db)}
+}
+
+// notifyLevelJoins joins the user's channel preferences and the defaults they
+// inherit, as resolved by the notification package. Binds the user ID twice.
+const notifyLevelJoins = `
+		LEFT JOIN notification_preferences np ON np.channel_id = c.id AND np.user_id = ?
+		LEFT JOIN channel_notification_defaults cnd ON cnd.channel_id = c.id
+		LEFT JOIN user_notification_defaults und ON und.workspace_id = c.workspace_id AND und.user_id = ?
+		LEFT JOIN workspace_notification_defaults wnd ON wnd.workspace_id = c.workspace_id`
+
+// notifyLevelSQL is the user's effective notify level for channel c
+const notifyLevelSQL = `COALESCE(np.notify_level, cnd.notify_level, und.notify_level, wnd.notify_level, 'mentions')`
+
+// notifiableSQL returns a condition on message m that is true when it counts
+// towards the user's notification badge for channel c: every message in DMs
+// and "all" channels, otherwise only messages mentioning the user (bound as
+// the single placeholder) or the whole channel. The query must include
+// notifyLevelJoins.
+func (r *Repository) notifiableSQL() string {
+	return `(c.type IN ('dm', 'group_dm')
+		OR ` + notifyLevelSQL + ` = 'all'
+		OR (` + notifyLevelSQL + ` = 'mentions' AND EXISTS (
+			SELECT 1 FROM ` + r.dialect.JSONEach("m.mentions", "je") + `
+			WHERE je.value = ? OR je.value IN ('@channel', '@everyone')
+		)))`
 }
 
 func (r *Repository) Create(ctx context.Context, channel *Channel, creatorID string) error {
@@ -224,25 +254,14 @@ func (r *Repository) ListForWorkspace(ctx context.Context, workspaceID, userID s
 		             AND m.thread_parent_id IS NULL
 		             AND m.deleted_at IS NULL
 		             AND (cm.last_read_message_id IS NULL OR m.id > cm.last_read_message_id)
-		             AND CASE
-		               WHEN c.type IN ('dm', 'group_dm') THEN 1
-		               WHEN np.notify_level = 'none' THEN 0
-		               WHEN np.notify_level = 'all' THEN 1
-		               WHEN np.notify_level = 'mentions' OR np.notify_level IS NULL THEN
-		                 EXISTS (
-		                   SELECT 1 FROM json_each(m.mentions) je
-		                   WHERE je.value = ? OR je.value IN ('@channel', '@everyone')
-		                 )
-		               ELSE 0
-		             END = 1
+		             AND `+r.notifiableSQL()+`
 		       ), 0) as notification_count
 		FROM channels c
-		LEFT JOIN channel_memberships cm ON cm.channel_id = c.id AND cm.user_id = ?
-		LEFT JOIN notification_preferences np ON np.channel_id = c.id AND np.user_id = ?
+		LEFT JOIN channel_memberships cm ON cm.channel_id = c.id AND cm.user_id = ?`+notifyLevelJoins+`
 		WHERE c.workspace_id = ? AND c.archived_at IS NULL
 		  AND (c.type = 'public' OR cm.id IS NOT NULL)
 		ORDER BY c.name
-	`, userID, userID, userID, workspaceID)
+	`, userID, userID, userID, userID, workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -388,24 +407,13 @@ func (r *Repository) GetWorkspaceNotificationSummaries(ctx context.Context, user
 		              AND m.thread_parent_id IS NULL
 		              AND m.deleted_at IS NULL
 		              AND (cm.last_read_message_id IS NULL OR m.id > cm.last_read_message_id)
-		              AND CASE
-		                WHEN c.type IN ('dm', 'group_dm') THEN 1
-		                WHEN np.notify_level = 'none' THEN 0
-		                WHEN np.notify_level = 'all' THEN 1
-		                WHEN np.notify_level = 'mentions' OR np.notify_level IS NULL THEN
-		                  EXISTS (
-		                    SELECT 1 FROM json_each(m.mentions) je
-		                    WHERE je.value = ? OR je.value IN ('@channel', '@everyone')
-		                  )
-		                ELSE 0
-		              END = 1)
+		              AND `+r.notifiableSQL()+`)
 		       ), 0) as notification_count
 		FROM channels c
-		JOIN channel_memberships cm ON cm.channel_id = c.id AND cm.user_id = ?
-		LEFT JOIN notification_preferences np ON np.channel_id = c.id AND np.user_id = ?
+		JOIN channel_memberships cm ON cm.channel_id = c.id AND cm.user_id = ?`+notifyLevelJoins+`
 		WHERE c.archived_at IS NULL
 		GROUP BY c.workspace_id
-	`, userID, userID, userID)
+	`, userID, userID, userID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -827,6 +835,158 @@ func (r *Repository) GetMemberUserIDs(ctx context.Context, channelID string) ([]
 	return userIDs, rows.Err()
 }
 
+// GetMentionPermissions returns a channel's overrides of who may use @here,
+// @channel and @everyone. Fields are nil when the channel has none.
+func (r *Repository) GetMentionPermissions(ctx context.Context, channelID string) (*MentionPermissions, error) {
+	var here, channel, everyone sql.NullString
+	err := r.db.QueryRowContext(ctx, `
+		SELECT who_can_mention_here, who_can_mention_channel, who_can_mention_everyone
+		FROM channel_mention_permissions WHERE channel_id = ?
+	`, channelID).Scan(&here, &channel, &everyone)
+	if err == sql.ErrNoRows {
+		return &MentionPermissions{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var perms MentionPermissions
+	if here.Valid {
+		perms.Here = &here.String
+	}
+	if channel.Valid {
+		perms.Channel = &channel.String
+	}
+	if everyone.Valid {
+		perms.Everyone = &everyone.String
+	}
+	return &perms, nil
+}
+
+// SetMentionPermissions replaces a channel's overrides of who may use @here,
+// @channel and @everyone
+func (r *Repository) SetMentionPermissions(ctx context.Context, channelID string, perms *MentionPermissions) error {
+	if perms.Here == nil && perms.Channel == nil && perms.Everyone == nil {
+		_, err := r.db.ExecContext(ctx, `
+			DELETE FROM channel_mention_permissions WHERE channel_id = ?
+		`, channelID)
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO channel_mention_permissions (channel_id, who_can_mention_here, who_can_mention_channel, who_can_mention_everyone, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(channel_id) DO UPDATE SET
+			who_can_mention_here = excluded.who_can_mention_here,
+			who_can_mention_channel = excluded.who_can_mention_channel,
+			who_can_mention_everyone = excluded.who_can_mention_everyone,
+			updated_at = excluded.updated_at
+	`, channelID, perms.Here, perms.Channel, perms.Everyone, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// GetEmailAddress returns a channel's inbound email address
+func (r *Repository) GetEmailAddress(ctx context.Context, channelID string) (*EmailAddress, error) {
+	return r.scanEmailAddress(r.db.QueryRowContext(ctx, `
+		SELECT channel_id, token, allowed_senders, created_by, created_at, updated_at
+		FROM channel_email_addresses WHERE channel_id = ?
+	`, channelID))
+}
+
+// GetEmailAddressByToken returns the inbound email address with the given
+// secret token
+func (r *Repository) GetEmailAddressByToken(ctx context.Context, token string) (*EmailAddress, error) {
+	return r.scanEmailAddress(r.db.QueryRowContext(ctx, `
+		SELECT channel_id, token, allowed_senders, created_by, created_at, updated_at
+		FROM channel_email_addresses WHERE token = ?
+	`, token))
+}
+
+// SetEmailAddress gives a channel an inbound email address with the given
+// sender allowlist. A channel that already has one keeps its token.
+func (r *Repository) SetEmailAddress(ctx context.Context, channelID, createdBy string, allowedSenders []string) (*EmailAddress, error) {
+	if allowedSenders == nil {
+		allowedSenders = []string{}
+	}
+	sendersJSON, err := json.Marshal(allowedSenders)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO channel_email_addresses (channel_id, token, allowed_senders, created_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(channel_id) DO UPDATE SET
+			allowed_senders = excluded.allowed_senders,
+			updated_at = excluded.updated_at
+	`, channelID, generateEmailToken(), string(sendersJSON), createdBy, now, now)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetEmailAddress(ctx, channelID)
+}
+
+// RotateEmailAddress replaces the token of a channel's inbound email
+// address, so mail to the previous address is no longer accepted
+func (r *Repository) RotateEmailAddress(ctx context.Context, channelID string) (*EmailAddress, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE channel_email_addresses SET token = ?, updated_at = ? WHERE channel_id = ?
+	`, generateEmailToken(), time.Now().UTC().Format(time.RFC3339), channelID)
+	if err != nil {
+		return nil, err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return nil, ErrEmailAddressNotFound
+	}
+	return r.GetEmailAddress(ctx, channelID)
+}
+
+// DeleteEmailAddress removes a channel's inbound email address
+func (r *Repository) DeleteEmailAddress(ctx context.Context, channelID string) error {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM channel_email_addresses WHERE channel_id = ?
+	`, channelID)
+	if err != nil {
+		return err
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrEmailAddressNotFound
+	}
+	return nil
+}
+
+func (r *Repository) scanEmailAddress(row *sql.Row) (*EmailAddress, error) {
+	var a EmailAddress
+	var sendersJSON, createdAt, updatedAt string
+	var createdBy sql.NullString
+	err := row.Scan(&a.ChannelID, &a.Token, &sendersJSON, &createdBy, &createdAt, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrEmailAddressNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(sendersJSON), &a.AllowedSenders); err != nil {
+		return nil, err
+	}
+	if createdBy.Valid {
+		a.CreatedBy = &createdBy.String
+	}
+	a.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	a.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return &a, nil
+}
+
+// generateEmailToken returns a new secret for the local part of a channel's
+// email address. It is lowercase, since mail systems may fold its case.
+func generateEmailToken() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
 // GetDefaultChannel returns the default channel for a workspace
 func (r *Repository) GetDefaultChannel(ctx context.Context, workspaceID string) (*Channel, error) {
 	return r.scanChannel(r.db.QueryRowContext(ctx, `
@@ -955,6 +955,54 @@ func TestRepository_ListForWorkspace_NotificationCount_NotifyNone(t *testing.T)
 	}
 }
 
+func TestRepository_ListForWorkspace_NotificationCount_InheritsDefaults(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	user1 := testutil.CreateTestUser(t, db, "user1@example.com", "User 1")
+	user2 := testutil.CreateTestUser(t, db, "user2@example.com", "User 2")
+	ws := testutil.CreateTestWorkspace(t, db, user1.ID, "Test WS")
+
+	general := testutil.CreateTestChannel(t, db, ws.ID, user1.ID, "general", "public")
+	announcements := testutil.CreateTestChannel(t, db, ws.ID, user1.ID, "announcements", "public")
+
+	// Workspace mutes channels by default, except #announcements
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := db.Exec(`
+		INSERT INTO workspace_notification_defaults (workspace_id, notify_level, email_enabled, push_enabled, mobile_only_when_idle, updated_at)
+		VALUES (?, 'none', 1, 1, 1, ?)
+	`, ws.ID, now); err != nil {
+		t.Fatalf("setting workspace defaults: %v", err)
+	}
+	if _, err := db.Exec(`
+		INSERT INTO channel_notification_defaults (channel_id, notify_level, updated_at) VALUES (?, 'all', ?)
+	`, announcements.ID, now); err != nil {
+		t.Fatalf("setting channel default: %v", err)
+	}
+
+	for _, chID := range []string{general.ID, announcements.ID} {
+		createMessageWithMentions(t, db, chID, user2.ID, "Hey @User 1", []string{user1.ID})
+		testutil.CreateTestMessage(t, db, chID, user2.ID, "Hello")
+	}
+
+	channels, err := repo.ListForWorkspace(ctx, ws.ID, user1.ID)
+	if err != nil {
+		t.Fatalf("ListForWorkspace() error = %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, c := range channels {
+		counts[c.ID] = c.NotificationCount
+	}
+	if counts[general.ID] != 0 {
+		t.Errorf("general NotificationCount = %d, want 0 (workspace default 'none')", counts[general.ID])
+	}
+	if counts[announcements.ID] != 2 {
+		t.Errorf("announcements NotificationCount = %d, want 2 (channel default 'all')", counts[announcements.ID])
+	}
+}
+
 func TestRepository_ListForWorkspace_NotificationCount_MentionsExplicit(t *testing.T) {
 	db := testutil.TestDB(t)
 	repo := NewRepository(db)
@@ -1101,3 +1149,82 @@ func TestRepository_GetWorkspaceNotificationSummaries_NoUnreads(t *testing.T) {
 		t.Error("expected workspace to appear in summaries")
 	}
 }
+
+func TestRepository_EmailAddress(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "alerts", TypePublic)
+
+	if _, err := repo.GetEmailAddress(ctx, ch.ID); !errors.Is(err, ErrEmailAddressNotFound) {
+		t.Fatalf("GetEmailAddress() error = %v, want ErrEmailAddressNotFound", err)
+	}
+
+	created, err := repo.SetEmailAddress(ctx, ch.ID, owner.ID, []string{"example.com"})
+	if err != nil {
+		t.Fatalf("SetEmailAddress() error = %v", err)
+	}
+	updated, err := repo.SetEmailAddress(ctx, ch.ID, owner.ID, nil)
+	if err != nil {
+		t.Fatalf("SetEmailAddress() error = %v", err)
+	}
+	if updated.Token != created.Token {
+		t.Error("updating the allowlist changed the token")
+	}
+	if updated.AllowedSenders == nil || len(updated.AllowedSenders) != 0 {
+		t.Errorf("AllowedSenders = %#v, want empty", updated.AllowedSenders)
+	}
+
+	rotated, err := repo.RotateEmailAddress(ctx, ch.ID)
+	if err != nil {
+		t.Fatalf("RotateEmailAddress() error = %v", err)
+	}
+	if rotated.Token == created.Token {
+		t.Error("rotating kept the token")
+	}
+	if _, err := repo.GetEmailAddressByToken(ctx, created.Token); !errors.Is(err, ErrEmailAddressNotFound) {
+		t.Errorf("GetEmailAddressByToken(old) error = %v, want ErrEmailAddressNotFound", err)
+	}
+	byToken, err := repo.GetEmailAddressByToken(ctx, rotated.Token)
+	if err != nil || byToken.ChannelID != ch.ID {
+		t.Fatalf("GetEmailAddressByToken() = %v, %v", byToken, err)
+	}
+
+	if err := repo.DeleteEmailAddress(ctx, ch.ID); err != nil {
+		t.Fatalf("DeleteEmailAddress() error = %v", err)
+	}
+	if err := repo.DeleteEmailAddress(ctx, ch.ID); !errors.Is(err, ErrEmailAddressNotFound) {
+		t.Errorf("DeleteEmailAddress() again error = %v, want ErrEmailAddressNotFound", err)
+	}
+	if _, err := repo.RotateEmailAddress(ctx, ch.ID); !errors.Is(err, ErrEmailAddressNotFound) {
+		t.Errorf("RotateEmailAddress() error = %v, want ErrEmailAddressNotFound", err)
+	}
+}
+
+func TestEmailAddress_AllowsSender(t *testing.T) {
+	addr := &EmailAddress{AllowedSenders: []string{"alerts@vendor.com", "@monitoring.example.com", "example.org"}}
+	tests := []struct {
+		sender string
+		want   bool
+	}{
+		{"alerts@vendor.com", true},
+		{"Alerts@Vendor.COM", true},
+		{"billing@vendor.com", false},
+		{"pager@monitoring.example.com", true},
+		{"pager@sub.monitoring.example.com", false},
+		{"anyone@example.org", true},
+		{"example.org", false},
+	}
+	for _, tt := range tests {
+		if got := addr.AllowsSender(tt.sender); got != tt.want {
+			t.Errorf("AllowsSender(%q) = %v, want %v", tt.sender, got, tt.want)
+		}
+	}
+
+	if !(&EmailAddress{}).AllowsSender("anyone@anywhere.com") {
+		t.Error("an empty allowlist should allow every sender")
+	}
+}
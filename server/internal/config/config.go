@@ -6,13 +6,16 @@ type Config struct {
 	Log               LogConfig              `koanf:"log"`
 	Server            ServerConfig           `koanf:"server"`
 	Database          DatabaseConfig         `koanf:"database"`
+	Backup            BackupConfig           `koanf:"backup"`
 	Auth              AuthConfig             `koanf:"auth"`
 	Storage           StorageConfig          `koanf:"storage"`
 	Email             EmailConfig            `koanf:"email"`
 	RateLimit         RateLimitConfig        `koanf:"rate_limit"`
 	SSE               SSEConfig              `koanf:"sse"`
+	LinkPreview       LinkPreviewConfig      `koanf:"link_preview"`
 	PushNotifications PushNotificationConfig `koanf:"push_notifications"`
 	Telemetry         TelemetryConfig        `koanf:"telemetry"`
+	Metrics           MetricsConfig          `koanf:"metrics"`
 }
 
 type LogConfig struct {
@@ -45,7 +48,9 @@ type AutoTLSConfig struct {
 }
 
 type DatabaseConfig struct {
-	Path             string `koanf:"path"`
+	Driver           string `koanf:"driver"` // "sqlite" or "postgres"
+	Path             string `koanf:"path"`   // SQLite database file
+	URL              string `koanf:"url"`    // PostgreSQL connection URL
 	MaxOpenConns     int    `koanf:"max_open_conns"`
 	BusyTimeout      int    `koanf:"busy_timeout"`
 	CacheSize        int    `koanf:"cache_size"`
@@ -53,16 +58,27 @@ type DatabaseConfig struct {
 	JournalSizeLimit int64  `koanf:"journal_size_limit"`
 }
 
+type BackupConfig struct {
+	Enabled        bool          `koanf:"enabled"`         // run scheduled backups
+	Dir            string        `koanf:"dir"`             // where backups are written
+	Interval       time.Duration `koanf:"interval"`        // time between scheduled backups
+	IncludeUploads bool          `koanf:"include_uploads"` // bundle storage.local.path into each backup
+	Keep           int           `koanf:"keep"`            // number of backups to keep; 0 keeps all
+	MaxAge         time.Duration `koanf:"max_age"`         // delete backups older than this; 0 disables
+}
+
 type AuthConfig struct {
 	SessionDuration time.Duration `koanf:"session_duration"`
 	BcryptCost      int           `koanf:"bcrypt_cost"`
 }
 
 type StorageConfig struct {
-	Type          string      `koanf:"type"` // "off", "local", or "s3"
-	MaxUploadSize int64       `koanf:"max_upload_size"`
-	Local         LocalConfig `koanf:"local"`
-	S3            S3Config    `koanf:"s3"`
+	Type          string           `koanf:"type"` // "off", "local", or "s3"
+	MaxUploadSize int64            `koanf:"max_upload_size"`
+	Local         LocalConfig      `koanf:"local"`
+	S3            S3Config         `koanf:"s3"`
+	Scan          ScanConfig       `koanf:"scan"`
+	Encryption    EncryptionConfig `koanf:"encryption"`
 }
 
 type LocalConfig struct {
@@ -80,13 +96,88 @@ type S3Config struct {
 	UseSSL    bool   `koanf:"use_ssl"`
 }
 
+type ScanConfig struct {
+	Type         string         `koanf:"type"`           // "off", "clamav", or "http"
+	Timeout      time.Duration  `koanf:"timeout"`        // per-file scan timeout
+	BlockPending bool           `koanf:"block_pending"`  // refuse downloads until the scan has finished
+	BlockOnError bool           `koanf:"block_on_error"` // refuse downloads when the scanner failed
+	ClamAV       ClamAVConfig   `koanf:"clamav"`
+	HTTP         HTTPScanConfig `koanf:"http"`
+}
+
+type ClamAVConfig struct {
+	Address string `koanf:"address"` // clamd "host:port", or "unix:/path/to/clamd.sock"
+}
+
+type HTTPScanConfig struct {
+	URL   string `koanf:"url"`   // endpoint receiving the raw file body via POST
+	Token string `koanf:"token"` // optional bearer token
+}
+
+type EncryptionConfig struct {
+	Enabled      bool     `koanf:"enabled"`
+	Key          string   `koanf:"key"`           // base64-encoded 32-byte master key
+	KeyFile      string   `koanf:"key_file"`      // file containing the base64 master key; used when key is empty
+	PreviousKeys []string `koanf:"previous_keys"` // retired master keys, still accepted for decryption
+}
+
 type EmailConfig struct {
-	Enabled  bool   `koanf:"enabled"`
-	Host     string `koanf:"host"`
-	Port     int    `koanf:"port"`
-	Username string `koanf:"username"`
-	Password string `koanf:"password"`
-	From     string `koanf:"from"`
+	Enabled   bool               `koanf:"enabled"`
+	Transport string             `koanf:"transport"` // "smtp", "http", or "sendmail"
+	Host      string             `koanf:"host"`
+	Port      int                `koanf:"port"`
+	Username  string             `koanf:"username"`
+	Password  string             `koanf:"password"`
+	TLS       string             `koanf:"tls"` // "auto", "starttls", "implicit", or "none"
+	From      string             `koanf:"from"`
+	HTTP      HTTPEmailConfig    `koanf:"http"`
+	Sendmail  SendmailConfig     `koanf:"sendmail"`
+	DKIM      DKIMConfig         `koanf:"dkim"`
+	Inbound   InboundEmailConfig `koanf:"inbound"`
+}
+
+// HTTPEmailConfig configures delivering mail by POSTing it to a mail relay's
+// HTTP API.
+type HTTPEmailConfig struct {
+	URL   string `koanf:"url"`   // endpoint receiving each message as JSON via POST
+	Token string `koanf:"token"` // optional bearer token
+}
+
+// SendmailConfig configures delivering mail by piping it to a local
+// sendmail-compatible program.
+type SendmailConfig struct {
+	Path string   `koanf:"path"` // program reading the message on stdin
+	Args []string `koanf:"args"` // arguments before the envelope sender and recipient
+}
+
+// DKIMConfig configures signing outgoing mail. Signing is off when domain is
+// empty.
+type DKIMConfig struct {
+	Domain         string `koanf:"domain"`           // signing domain, usually that of email.from
+	Selector       string `koanf:"selector"`         // DNS selector, the public key is at <selector>._domainkey.<domain>
+	PrivateKey     string `koanf:"private_key"`      // PEM-encoded RSA or Ed25519 private key
+	PrivateKeyFile string `koanf:"private_key_file"` // file containing the PEM key; used when private_key is empty
+}
+
+// InboundEmailConfig configures receiving replies to notification emails and
+// mail to channels' addresses, either on a built-in SMTP listener or piped in
+// by a mail server over HTTP.
+type InboundEmailConfig struct {
+	Enabled bool   `koanf:"enabled"`
+	Domain  string `koanf:"domain"`   // domain of the reply and channel addresses, whose MX points at this server or the piping MTA
+	Listen  string `koanf:"listen"`   // address of the SMTP listener, e.g. ":25"; empty to not listen
+	Token   string `koanf:"token"`    // bearer token for POST /api/email/inbound; empty to disable the endpoint
+	MaxSize int64  `koanf:"max_size"` // largest message accepted, in bytes
+
+	MaxConnections      int    `koanf:"max_connections"`        // SMTP connections open at once
+	MaxConnectionsPerIP int    `koanf:"max_connections_per_ip"` // SMTP connections open at once from one IP address
+	CertFile            string `koanf:"cert_file"`              // certificate offered with STARTTLS; empty to not offer it
+	KeyFile             string `koanf:"key_file"`               // private key of cert_file
+
+	// TrustedAuthservID names the mail server whose Authentication-Results
+	// headers are trusted to check senders in channels' allowlists; empty
+	// to match allowlists against the unverified From header
+	TrustedAuthservID string `koanf:"trusted_authserv_id"`
 }
 
 type RateLimitConfig struct {
@@ -106,16 +197,59 @@ type RateLimitEndpoint struct {
 }
 
 type SSEConfig struct {
-	EventRetention    time.Duration `koanf:"event_retention"`
-	CleanupInterval   time.Duration `koanf:"cleanup_interval"`
-	HeartbeatInterval time.Duration `koanf:"heartbeat_interval"`
-	ClientBufferSize  int           `koanf:"client_buffer_size"`
+	EventRetention    time.Duration  `koanf:"event_retention"`
+	CleanupInterval   time.Duration  `koanf:"cleanup_interval"`
+	HeartbeatInterval time.Duration  `koanf:"heartbeat_interval"`
+	ClientBufferSize  int            `koanf:"client_buffer_size"`
+	Broker            string         `koanf:"broker"` // "local" or "redis"
+	Redis             SSERedisConfig `koanf:"redis"`
+}
+
+type SSERedisConfig struct {
+	URL     string `koanf:"url"`     // redis://[:password@]host:port[/db]
+	Channel string `koanf:"channel"` // pub/sub channel shared by all instances
+}
+
+type LinkPreviewConfig struct {
+	OEmbedDiscovery bool                   `koanf:"oembed_discovery"` // follow oEmbed links advertised by pages
+	OEmbedProviders []OEmbedProviderConfig `koanf:"oembed_providers"` // checked before the built-in providers
+	ImageProxy      ImageProxyConfig       `koanf:"image_proxy"`
+}
+
+type ImageProxyConfig struct {
+	Enabled  bool          `koanf:"enabled"`   // rewrite preview images to /api/image-proxy
+	MaxSize  int64         `koanf:"max_size"`  // largest image fetched, in bytes
+	CacheTTL time.Duration `koanf:"cache_ttl"` // how long fetched images stay in storage
+}
+
+type OEmbedProviderConfig struct {
+	Name     string   `koanf:"name"`
+	Endpoint string   `koanf:"endpoint"` // JSON oEmbed endpoint
+	Schemes  []string `koanf:"schemes"`  // URL patterns with * wildcards, e.g. "https://*.example.com/video/*"
 }
 
 type PushNotificationConfig struct {
-	Enabled        bool   `koanf:"enabled"`
-	RelayURL       string `koanf:"relay_url"`
-	IncludePreview bool   `koanf:"include_preview"`
+	Enabled        bool              `koanf:"enabled"`
+	RelayURL       string            `koanf:"relay_url"`
+	RelayKey       string            `koanf:"relay_key"` // "<key id>.<secret>" issued by the relay; empty to send unsigned
+	IncludePreview bool              `koanf:"include_preview"`
+	WebPush        WebPushConfig     `koanf:"web_push"`
+	UnifiedPush    UnifiedPushConfig `koanf:"unified_push"`
+}
+
+// WebPushConfig configures browser push notifications, which the server
+// delivers itself rather than through the relay.
+type WebPushConfig struct {
+	Enabled               bool   `koanf:"enabled"`
+	Subject               string `koanf:"subject"`                 // VAPID contact, a mailto: or https: URL; defaults to server.public_url
+	AllowPrivateEndpoints bool   `koanf:"allow_private_endpoints"` // allow push services on private networks
+}
+
+// UnifiedPushConfig configures delivery to UnifiedPush distributors (e.g.
+// ntfy), which the server posts to directly rather than through the relay.
+type UnifiedPushConfig struct {
+	Enabled               bool `koanf:"enabled"`
+	AllowPrivateEndpoints bool `koanf:"allow_private_endpoints"` // allow distributors on private networks
 }
 
 type TelemetryConfig struct {
@@ -132,6 +266,12 @@ type TelemetryConfig struct {
 	FrontendEndpoint string            `koanf:"frontend_endpoint"` // OTLP/HTTP endpoint for browser trace proxy (auto-derived if empty)
 }
 
+type MetricsConfig struct {
+	Enabled bool   `koanf:"enabled"` // serve Prometheus metrics at /metrics
+	Token   string `koanf:"token"`   // bearer token scrapers must send
+	Listen  string `koanf:"listen"`  // separate address to serve /metrics on, e.g. "127.0.0.1:9090"
+}
+
 func Defaults() *Config {
 	return &Config{
 		Log: LogConfig{
@@ -154,6 +294,7 @@ func Defaults() *Config {
 			IdleTimeout:  120 * time.Second,
 		},
 		Database: DatabaseConfig{
+			Driver:           "sqlite",
 			Path:             "./data/enzyme.db",
 			MaxOpenConns:     10,
 			BusyTimeout:      5000,
@@ -161,6 +302,12 @@ func Defaults() *Config {
 			MmapSize:         268435456, // 256MB
 			JournalSizeLimit: 67108864,  // 64MB
 		},
+		Backup: BackupConfig{
+			Enabled:  false,
+			Dir:      "./data/backups",
+			Interval: 24 * time.Hour,
+			Keep:     7,
+		},
 		Auth: AuthConfig{
 			SessionDuration: 720 * time.Hour, // 30 days
 			BcryptCost:      12,
@@ -174,10 +321,29 @@ func Defaults() *Config {
 			S3: S3Config{
 				UseSSL: true,
 			},
+			Scan: ScanConfig{
+				Type:         "off",
+				Timeout:      time.Minute,
+				BlockPending: true,
+				ClamAV: ClamAVConfig{
+					Address: "localhost:3310",
+				},
+			},
 		},
 		Email: EmailConfig{
-			Enabled: false,
-			Port:    587,
+			Enabled:   false,
+			Transport: "smtp",
+			Port:      587,
+			TLS:       "auto",
+			Sendmail: SendmailConfig{
+				Path: "/usr/sbin/sendmail",
+				Args: []string{"-i"},
+			},
+			Inbound: InboundEmailConfig{
+				MaxSize:             25 * 1024 * 1024, // 25MB
+				MaxConnections:      100,
+				MaxConnectionsPerIP: 10,
+			},
 		},
 		RateLimit: RateLimitConfig{
 			Enabled:             true,
@@ -194,6 +360,18 @@ func Defaults() *Config {
 			CleanupInterval:   time.Hour,
 			HeartbeatInterval: 30 * time.Second,
 			ClientBufferSize:  256,
+			Broker:            "local",
+			Redis: SSERedisConfig{
+				Channel: "enzyme:sse",
+			},
+		},
+		LinkPreview: LinkPreviewConfig{
+			OEmbedDiscovery: true,
+			ImageProxy: ImageProxyConfig{
+				Enabled:  false,
+				MaxSize:  5 * 1024 * 1024, // 5MB
+				CacheTTL: 7 * 24 * time.Hour,
+			},
 		},
 		PushNotifications: PushNotificationConfig{
 			Enabled:        false,
@@ -211,5 +389,8 @@ func Defaults() *Config {
 			Metrics:     true,
 			Logs:        true,
 		},
+		Metrics: MetricsConfig{
+			Enabled: false,
+		},
 	}
 }
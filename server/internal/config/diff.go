@@ -0,0 +1,28 @@
+package config
+
+import "reflect"
+
+// Diff returns the dotted keys (as used in the config file, e.g.
+// "rate_limit.login.limit") whose values differ between a and b. Only keys
+// are returned so that callers can log them without leaking secrets.
+func Diff(a, b *Config) []string {
+	var keys []string
+	diffValue("", reflect.ValueOf(*a), reflect.ValueOf(*b), &keys)
+	return keys
+}
+
+func diffValue(prefix string, a, b reflect.Value, keys *[]string) {
+	if a.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*keys = append(*keys, prefix)
+		}
+		return
+	}
+	for i := 0; i < a.NumField(); i++ {
+		key := a.Type().Field(i).Tag.Get("koanf")
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		diffValue(key, a.Field(i), b.Field(i), keys)
+	}
+}
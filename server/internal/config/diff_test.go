@@ -0,0 +1,25 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	a := Defaults()
+	b := Defaults()
+	if keys := Diff(a, b); len(keys) != 0 {
+		t.Fatalf("Diff of identical configs = %v, want none", keys)
+	}
+
+	b.Log.Level = "debug"
+	b.RateLimit.Login.Window = 2 * time.Minute
+	b.Server.AllowedOrigins = []string{"https://chat.example.com"}
+	b.Telemetry.Headers = map[string]string{"x-api-key": "secret"}
+
+	want := []string{"log.level", "server.allowed_origins", "rate_limit.login.window", "telemetry.headers"}
+	if keys := Diff(a, b); !reflect.DeepEqual(keys, want) {
+		t.Errorf("Diff = %v, want %v", keys, want)
+	}
+}
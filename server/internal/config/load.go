@@ -132,12 +132,22 @@ func (d *defaultsProviderStruct) Read() (map[string]interface{}, error) {
 			"idle_timeout":  d.defaults.Server.IdleTimeout.String(),
 		},
 		"database": map[string]interface{}{
+			"driver":         d.defaults.Database.Driver,
 			"path":           d.defaults.Database.Path,
+			"url":            d.defaults.Database.URL,
 			"max_open_conns": d.defaults.Database.MaxOpenConns,
 			"busy_timeout":   d.defaults.Database.BusyTimeout,
 			"cache_size":     d.defaults.Database.CacheSize,
 			"mmap_size":      d.defaults.Database.MmapSize,
 		},
+		"backup": map[string]interface{}{
+			"enabled":         d.defaults.Backup.Enabled,
+			"dir":             d.defaults.Backup.Dir,
+			"interval":        d.defaults.Backup.Interval.String(),
+			"include_uploads": d.defaults.Backup.IncludeUploads,
+			"keep":            d.defaults.Backup.Keep,
+			"max_age":         d.defaults.Backup.MaxAge.String(),
+		},
 		"auth": map[string]interface{}{
 			"session_duration": d.defaults.Auth.SessionDuration.String(),
 			"bcrypt_cost":      d.defaults.Auth.BcryptCost,
@@ -158,14 +168,62 @@ func (d *defaultsProviderStruct) Read() (map[string]interface{}, error) {
 				"path_style": d.defaults.Storage.S3.PathStyle,
 				"use_ssl":    d.defaults.Storage.S3.UseSSL,
 			},
+			"scan": map[string]interface{}{
+				"type":           d.defaults.Storage.Scan.Type,
+				"timeout":        d.defaults.Storage.Scan.Timeout.String(),
+				"block_pending":  d.defaults.Storage.Scan.BlockPending,
+				"block_on_error": d.defaults.Storage.Scan.BlockOnError,
+				"clamav": map[string]interface{}{
+					"address": d.defaults.Storage.Scan.ClamAV.Address,
+				},
+				"http": map[string]interface{}{
+					"url":   d.defaults.Storage.Scan.HTTP.URL,
+					"token": d.defaults.Storage.Scan.HTTP.Token,
+				},
+			},
+			"encryption": map[string]interface{}{
+				"enabled":       d.defaults.Storage.Encryption.Enabled,
+				"key":           d.defaults.Storage.Encryption.Key,
+				"key_file":      d.defaults.Storage.Encryption.KeyFile,
+				"previous_keys": d.defaults.Storage.Encryption.PreviousKeys,
+			},
 		},
 		"email": map[string]interface{}{
-			"enabled":  d.defaults.Email.Enabled,
-			"host":     d.defaults.Email.Host,
-			"port":     d.defaults.Email.Port,
-			"username": d.defaults.Email.Username,
-			"password": d.defaults.Email.Password,
-			"from":     d.defaults.Email.From,
+			"enabled":   d.defaults.Email.Enabled,
+			"transport": d.defaults.Email.Transport,
+			"host":      d.defaults.Email.Host,
+			"port":      d.defaults.Email.Port,
+			"username":  d.defaults.Email.Username,
+			"password":  d.defaults.Email.Password,
+			"tls":       d.defaults.Email.TLS,
+			"from":      d.defaults.Email.From,
+			"http": map[string]interface{}{
+				"url":   d.defaults.Email.HTTP.URL,
+				"token": d.defaults.Email.HTTP.Token,
+			},
+			"sendmail": map[string]interface{}{
+				"path": d.defaults.Email.Sendmail.Path,
+				"args": d.defaults.Email.Sendmail.Args,
+			},
+			"dkim": map[string]interface{}{
+				"domain":           d.defaults.Email.DKIM.Domain,
+				"selector":         d.defaults.Email.DKIM.Selector,
+				"private_key":      d.defaults.Email.DKIM.PrivateKey,
+				"private_key_file": d.defaults.Email.DKIM.PrivateKeyFile,
+			},
+			"inbound": map[string]interface{}{
+				"enabled":  d.defaults.Email.Inbound.Enabled,
+				"domain":   d.defaults.Email.Inbound.Domain,
+				"listen":   d.defaults.Email.Inbound.Listen,
+				"token":    d.defaults.Email.Inbound.Token,
+				"max_size": d.defaults.Email.Inbound.MaxSize,
+
+				"max_connections":        d.defaults.Email.Inbound.MaxConnections,
+				"max_connections_per_ip": d.defaults.Email.Inbound.MaxConnectionsPerIP,
+				"cert_file":              d.defaults.Email.Inbound.CertFile,
+				"key_file":               d.defaults.Email.Inbound.KeyFile,
+				"trusted_authserv_id":    d.defaults.Email.Inbound.TrustedAuthservID,
+			},
 		},
 		"rate_limit": map[string]interface{}{
 			"enabled": d.defaults.RateLimit.Enabled,
@@ -201,13 +259,37 @@ func (d *defaultsProviderStruct) Read() (map[string]interface{}, error) {
 		"push_notifications": map[string]interface{}{
 			"enabled":         d.defaults.PushNotifications.Enabled,
 			"relay_url":       d.defaults.PushNotifications.RelayURL,
+			"relay_key":       d.defaults.PushNotifications.RelayKey,
 			"include_preview": d.defaults.PushNotifications.IncludePreview,
+			"web_push": map[string]interface{}{
+				"enabled":                 d.defaults.PushNotifications.WebPush.Enabled,
+				"subject":                 d.defaults.PushNotifications.WebPush.Subject,
+				"allow_private_endpoints": d.defaults.PushNotifications.WebPush.AllowPrivateEndpoints,
+			},
+			"unified_push": map[string]interface{}{
+				"enabled":                 d.defaults.PushNotifications.UnifiedPush.Enabled,
+				"allow_private_endpoints": d.defaults.PushNotifications.UnifiedPush.AllowPrivateEndpoints,
+			},
 		},
 		"sse": map[string]interface{}{
 			"event_retention":    d.defaults.SSE.EventRetention.String(),
 			"cleanup_interval":   d.defaults.SSE.CleanupInterval.String(),
 			"heartbeat_interval": d.defaults.SSE.HeartbeatInterval.String(),
 			"client_buffer_size": d.defaults.SSE.ClientBufferSize,
+			"broker":             d.defaults.SSE.Broker,
+			"redis": map[string]interface{}{
+				"url":     d.defaults.SSE.Redis.URL,
+				"channel": d.defaults.SSE.Redis.Channel,
+			},
+		},
+		"link_preview": map[string]interface{}{
+			"oembed_discovery": d.defaults.LinkPreview.OEmbedDiscovery,
+			"oembed_providers": []interface{}{},
+			"image_proxy": map[string]interface{}{
+				"enabled":   d.defaults.LinkPreview.ImageProxy.Enabled,
+				"max_size":  d.defaults.LinkPreview.ImageProxy.MaxSize,
+				"cache_ttl": d.defaults.LinkPreview.ImageProxy.CacheTTL.String(),
+			},
 		},
 		"telemetry": map[string]interface{}{
 			"enabled":           d.defaults.Telemetry.Enabled,
@@ -222,6 +304,11 @@ func (d *defaultsProviderStruct) Read() (map[string]interface{}, error) {
 			"logs":              d.defaults.Telemetry.Logs,
 			"frontend_endpoint": d.defaults.Telemetry.FrontendEndpoint,
 		},
+		"metrics": map[string]interface{}{
+			"enabled": d.defaults.Metrics.Enabled,
+			"token":   d.defaults.Metrics.Token,
+			"listen":  d.defaults.Metrics.Listen,
+		},
 	}, nil
 }
 
@@ -234,11 +321,15 @@ func SetupFlags() *pflag.FlagSet {
 	flags.Int("server.port", 0, "Server port")
 	flags.String("server.public_url", "", "Public URL")
 	flags.String("database.path", "", "Database path")
+	flags.String("backup.dir", "", "Backup directory")
+	flags.Bool("backup.include_uploads", false, "Bundle local uploads into backups")
 	flags.Duration("auth.session_duration", 0, "Session duration")
 	flags.String("storage.type", "", "Storage type: off, local, or s3")
 	flags.String("storage.local.path", "", "Local storage path")
 	flags.Int64("storage.max_upload_size", 0, "Max upload size in bytes")
+	flags.String("storage.scan.type", "", "Upload scanner: off, clamav, or http")
 	flags.Bool("email.enabled", false, "Enable email sending")
+	flags.String("email.transport", "", "Email transport: smtp, http, or sendmail")
 	flags.StringSlice("server.allowed_origins", nil, "Allowed CORS origins")
 	flags.String("server.tls.mode", "", "TLS mode: off, auto, or manual")
 	flags.String("server.tls.cert_file", "", "TLS certificate file (manual mode)")
@@ -252,6 +343,8 @@ func SetupFlags() *pflag.FlagSet {
 	flags.Bool("telemetry.insecure", false, "Use plaintext (no TLS) for OTLP export")
 	flags.Float64("telemetry.sample_rate", 0, "Trace sample rate (0.0 to 1.0)")
 	flags.String("telemetry.service_name", "", "Service name for telemetry")
+	flags.Bool("metrics.enabled", false, "Serve Prometheus metrics at /metrics")
+	flags.String("metrics.listen", "", "Separate listen address for /metrics")
 	return flags
 }
 
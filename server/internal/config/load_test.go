@@ -225,3 +225,37 @@ server:
 		t.Fatalf("expected default cache_dir './data/certs', got %q", cfg.Server.TLS.Auto.CacheDir)
 	}
 }
+
+func TestLoad_OEmbedProvidersFromYAML(t *testing.T) {
+	dir := t.TempDir()
+	cfgPath := filepath.Join(dir, "config.yaml")
+
+	yaml := `
+link_preview:
+  oembed_discovery: false
+  oembed_providers:
+    - name: Internal Wiki
+      endpoint: https://wiki.example.com/oembed
+      schemes:
+        - https://wiki.example.com/page/*
+`
+	if err := os.WriteFile(cfgPath, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(cfgPath, nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if cfg.LinkPreview.OEmbedDiscovery {
+		t.Fatal("expected oembed_discovery to be false")
+	}
+	if len(cfg.LinkPreview.OEmbedProviders) != 1 {
+		t.Fatalf("expected 1 provider, got %d", len(cfg.LinkPreview.OEmbedProviders))
+	}
+	p := cfg.LinkPreview.OEmbedProviders[0]
+	if p.Name != "Internal Wiki" || p.Endpoint != "https://wiki.example.com/oembed" || len(p.Schemes) != 1 {
+		t.Fatalf("unexpected provider: %+v", p)
+	}
+}
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
+
+	"github.com/enzyme/server/internal/relayauth"
 )
 
 func Validate(cfg *Config) error {
@@ -76,8 +80,20 @@ func Validate(cfg *Config) error {
 	}
 
 	// Database validation
-	if cfg.Database.Path == "" {
-		errs = append(errs, fmt.Errorf("database.path is required"))
+	switch cfg.Database.Driver {
+	case "sqlite":
+		if cfg.Database.Path == "" {
+			errs = append(errs, fmt.Errorf("database.path is required"))
+		}
+	case "postgres":
+		if cfg.Database.URL == "" {
+			errs = append(errs, fmt.Errorf("database.url is required when database.driver is postgres"))
+		}
+		if cfg.Backup.Enabled {
+			errs = append(errs, fmt.Errorf("backup.enabled is only supported with the sqlite driver; back up postgres with pg_dump"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("database.driver must be one of: sqlite, postgres"))
 	}
 	if cfg.Database.MaxOpenConns < 1 {
 		errs = append(errs, fmt.Errorf("database.max_open_conns must be at least 1"))
@@ -89,6 +105,23 @@ func Validate(cfg *Config) error {
 		errs = append(errs, fmt.Errorf("database.mmap_size must be at least 0"))
 	}
 
+	// Backup validation
+	if cfg.Backup.Dir == "" {
+		errs = append(errs, fmt.Errorf("backup.dir is required"))
+	}
+	if cfg.Backup.Enabled && cfg.Backup.Interval < time.Minute {
+		errs = append(errs, fmt.Errorf("backup.interval must be at least 1m"))
+	}
+	if cfg.Backup.Keep < 0 {
+		errs = append(errs, fmt.Errorf("backup.keep must be at least 0"))
+	}
+	if cfg.Backup.MaxAge < 0 {
+		errs = append(errs, fmt.Errorf("backup.max_age must be at least 0"))
+	}
+	if cfg.Backup.IncludeUploads && cfg.Storage.Type != "local" {
+		errs = append(errs, fmt.Errorf("backup.include_uploads requires storage.type local"))
+	}
+
 	// Auth validation
 	if cfg.Auth.SessionDuration < time.Hour {
 		errs = append(errs, fmt.Errorf("auth.session_duration must be at least 1 hour"))
@@ -125,16 +158,120 @@ func Validate(cfg *Config) error {
 		errs = append(errs, fmt.Errorf("storage.max_upload_size must be at least 1KB"))
 	}
 
+	// Upload scanning validation
+	switch cfg.Storage.Scan.Type {
+	case "", "off":
+		// no validation needed
+	case "clamav":
+		if cfg.Storage.Scan.ClamAV.Address == "" {
+			errs = append(errs, fmt.Errorf("storage.scan.clamav.address is required when scan type is clamav"))
+		}
+	case "http":
+		u, err := url.Parse(cfg.Storage.Scan.HTTP.URL)
+		if cfg.Storage.Scan.HTTP.URL == "" || err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("storage.scan.http.url must be a valid URL when scan type is http"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("storage.scan.type must be one of: off, clamav, http"))
+	}
+	if cfg.Storage.Scan.Type != "" && cfg.Storage.Scan.Type != "off" && cfg.Storage.Scan.Timeout < time.Second {
+		errs = append(errs, fmt.Errorf("storage.scan.timeout must be at least 1s"))
+	}
+
+	// Encryption at rest validation
+	if cfg.Storage.Encryption.Enabled {
+		enc := cfg.Storage.Encryption
+		switch {
+		case enc.Key == "" && enc.KeyFile == "":
+			errs = append(errs, fmt.Errorf("storage.encryption.key or storage.encryption.key_file is required when encryption is enabled"))
+		case enc.Key != "" && !validMasterKey(enc.Key):
+			errs = append(errs, fmt.Errorf("storage.encryption.key must be a base64-encoded 32-byte key"))
+		}
+		for i, k := range enc.PreviousKeys {
+			if !validMasterKey(k) {
+				errs = append(errs, fmt.Errorf("storage.encryption.previous_keys[%d] must be a base64-encoded 32-byte key", i))
+			}
+		}
+	}
+
+	// Link preview validation
+	for i, p := range cfg.LinkPreview.OEmbedProviders {
+		u, err := url.Parse(p.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("link_preview.oembed_providers[%d].endpoint must be a valid http(s) URL", i))
+		}
+		if len(p.Schemes) == 0 {
+			errs = append(errs, fmt.Errorf("link_preview.oembed_providers[%d].schemes must not be empty", i))
+		}
+	}
+	if proxy := cfg.LinkPreview.ImageProxy; proxy.Enabled {
+		if proxy.MaxSize < 1024 {
+			errs = append(errs, fmt.Errorf("link_preview.image_proxy.max_size must be at least 1KB"))
+		}
+		if proxy.CacheTTL < time.Minute {
+			errs = append(errs, fmt.Errorf("link_preview.image_proxy.cache_ttl must be at least 1m"))
+		}
+	}
+
 	// Email validation (only if enabled)
 	if cfg.Email.Enabled {
-		if cfg.Email.Host == "" {
-			errs = append(errs, fmt.Errorf("email.host is required when email is enabled"))
-		}
 		if cfg.Email.From == "" {
 			errs = append(errs, fmt.Errorf("email.from is required when email is enabled"))
 		}
-		if cfg.Email.Port < 1 || cfg.Email.Port > 65535 {
-			errs = append(errs, fmt.Errorf("email.port must be between 1 and 65535"))
+		switch cfg.Email.Transport {
+		case "", "smtp":
+			if cfg.Email.Host == "" {
+				errs = append(errs, fmt.Errorf("email.host is required when email is enabled"))
+			}
+			if cfg.Email.Port < 1 || cfg.Email.Port > 65535 {
+				errs = append(errs, fmt.Errorf("email.port must be between 1 and 65535"))
+			}
+			switch cfg.Email.TLS {
+			case "", "auto", "starttls", "implicit", "none":
+			default:
+				errs = append(errs, fmt.Errorf("email.tls must be one of: auto, starttls, implicit, none"))
+			}
+		case "http":
+			u, err := url.Parse(cfg.Email.HTTP.URL)
+			if cfg.Email.HTTP.URL == "" || err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Errorf("email.http.url must be a valid http(s) URL when email transport is http"))
+			}
+		case "sendmail":
+			if cfg.Email.Sendmail.Path == "" {
+				errs = append(errs, fmt.Errorf("email.sendmail.path is required when email transport is sendmail"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("email.transport must be one of: smtp, http, sendmail"))
+		}
+		if dkim := cfg.Email.DKIM; dkim.Domain != "" {
+			if dkim.Selector == "" {
+				errs = append(errs, fmt.Errorf("email.dkim.selector is required when email.dkim.domain is set"))
+			}
+			if dkim.PrivateKey == "" && dkim.PrivateKeyFile == "" {
+				errs = append(errs, fmt.Errorf("email.dkim.private_key or email.dkim.private_key_file is required when email.dkim.domain is set"))
+			}
+		}
+	}
+	if in := cfg.Email.Inbound; in.Enabled {
+		if !cfg.Email.Enabled {
+			errs = append(errs, fmt.Errorf("email.enabled is required when email.inbound is enabled"))
+		}
+		if in.Domain == "" {
+			errs = append(errs, fmt.Errorf("email.inbound.domain is required when email.inbound is enabled"))
+		}
+		if in.Listen == "" && in.Token == "" {
+			errs = append(errs, fmt.Errorf("email.inbound.listen or email.inbound.token is required when email.inbound is enabled"))
+		}
+		if in.MaxSize < 64*1024 {
+			errs = append(errs, fmt.Errorf("email.inbound.max_size must be at least 64KB"))
+		}
+		if in.Listen != "" {
+			if in.MaxConnections < 1 || in.MaxConnectionsPerIP < 1 {
+				errs = append(errs, fmt.Errorf("email.inbound.max_connections and email.inbound.max_connections_per_ip must be at least 1"))
+			}
+			if (in.CertFile == "") != (in.KeyFile == "") {
+				errs = append(errs, fmt.Errorf("email.inbound.cert_file and email.inbound.key_file must be set together"))
+			}
 		}
 	}
 
@@ -171,6 +308,18 @@ func Validate(cfg *Config) error {
 				errs = append(errs, fmt.Errorf("push_notifications.relay_url must use HTTPS (except for localhost)"))
 			}
 		}
+		if cfg.PushNotifications.RelayKey != "" {
+			if _, err := relayauth.ParseCredential(cfg.PushNotifications.RelayKey); err != nil {
+				errs = append(errs, fmt.Errorf("push_notifications.relay_key: %w", err))
+			}
+		}
+	}
+
+	if cfg.PushNotifications.WebPush.Enabled && cfg.PushNotifications.WebPush.Subject != "" {
+		u, err := url.Parse(cfg.PushNotifications.WebPush.Subject)
+		if err != nil || (u.Scheme != "mailto" && u.Scheme != "https") {
+			errs = append(errs, fmt.Errorf("push_notifications.web_push.subject must be a mailto: or https: URL"))
+		}
 	}
 
 	// SSE validation
@@ -180,6 +329,18 @@ func Validate(cfg *Config) error {
 	if cfg.SSE.ClientBufferSize < 16 {
 		errs = append(errs, fmt.Errorf("sse.client_buffer_size must be at least 16"))
 	}
+	switch cfg.SSE.Broker {
+	case "local":
+	case "redis":
+		if cfg.SSE.Redis.URL == "" {
+			errs = append(errs, fmt.Errorf("sse.redis.url is required when sse.broker is redis"))
+		}
+		if cfg.SSE.Redis.Channel == "" {
+			errs = append(errs, fmt.Errorf("sse.redis.channel is required when sse.broker is redis"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("sse.broker must be one of: local, redis"))
+	}
 
 	// Telemetry validation (only when enabled)
 	if cfg.Telemetry.Enabled {
@@ -197,8 +358,19 @@ func Validate(cfg *Config) error {
 		}
 	}
 
+	// The metrics endpoint exposes internals, so it must not be reachable
+	// anonymously on the public listener
+	if cfg.Metrics.Enabled && cfg.Metrics.Token == "" && cfg.Metrics.Listen == "" {
+		errs = append(errs, fmt.Errorf("metrics.token or metrics.listen is required when metrics are enabled"))
+	}
+
 	if len(errs) > 0 {
 		return errors.Join(errs...)
 	}
 	return nil
 }
+
+func validMasterKey(s string) bool {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	return err == nil && len(key) == 32
+}
@@ -335,3 +335,290 @@ func TestValidate_RateLimitMultipleErrors(t *testing.T) {
 		t.Fatalf("expected forgot_password window error, got: %v", err)
 	}
 }
+
+func TestValidate_ScanDefaults(t *testing.T) {
+	cfg := validConfig()
+	if cfg.Storage.Scan.Type != "off" {
+		t.Fatalf("expected default scan type 'off', got %q", cfg.Storage.Scan.Type)
+	}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("default scan config should be valid: %v", err)
+	}
+}
+
+func TestValidate_ScanClamAVMissingAddress(t *testing.T) {
+	cfg := validConfig()
+	cfg.Storage.Scan.Type = "clamav"
+	cfg.Storage.Scan.ClamAV.Address = ""
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error for missing clamav address")
+	}
+	if !strings.Contains(err.Error(), "storage.scan.clamav.address") {
+		t.Fatalf("expected clamav address error, got: %v", err)
+	}
+}
+
+func TestValidate_ScanHTTPInvalidURL(t *testing.T) {
+	cfg := validConfig()
+	cfg.Storage.Scan.Type = "http"
+	cfg.Storage.Scan.HTTP.URL = "not-a-url"
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error for invalid scanner URL")
+	}
+	if !strings.Contains(err.Error(), "storage.scan.http.url") {
+		t.Fatalf("expected scanner URL error, got: %v", err)
+	}
+}
+
+func TestValidate_ScanInvalidType(t *testing.T) {
+	cfg := validConfig()
+	cfg.Storage.Scan.Type = "virustotal"
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error for invalid scan type")
+	}
+	if !strings.Contains(err.Error(), "storage.scan.type") {
+		t.Fatalf("expected scan type error, got: %v", err)
+	}
+}
+
+func TestValidate_EncryptionRequiresKey(t *testing.T) {
+	cfg := validConfig()
+	cfg.Storage.Encryption.Enabled = true
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error for missing encryption key")
+	}
+	if !strings.Contains(err.Error(), "storage.encryption.key") {
+		t.Fatalf("expected encryption key error, got: %v", err)
+	}
+}
+
+func TestValidate_EncryptionKeys(t *testing.T) {
+	validKey := "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=" // 32 bytes
+
+	cfg := validConfig()
+	cfg.Storage.Encryption.Enabled = true
+	cfg.Storage.Encryption.Key = validKey
+	cfg.Storage.Encryption.PreviousKeys = []string{validKey}
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("expected valid config, got: %v", err)
+	}
+
+	cfg.Storage.Encryption.Key = "c2hvcnQ="
+	cfg.Storage.Encryption.PreviousKeys = []string{"not base64!"}
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error for invalid keys")
+	}
+	if !strings.Contains(err.Error(), "storage.encryption.key must be") {
+		t.Errorf("expected key length error, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "storage.encryption.previous_keys[0]") {
+		t.Errorf("expected previous key error, got: %v", err)
+	}
+}
+
+func TestValidate_ImageProxyLimits(t *testing.T) {
+	cfg := validConfig()
+	cfg.LinkPreview.ImageProxy.Enabled = true
+	cfg.LinkPreview.ImageProxy.MaxSize = 10
+	cfg.LinkPreview.ImageProxy.CacheTTL = 0
+	err := Validate(cfg)
+	if err == nil {
+		t.Fatal("expected error for image proxy limits")
+	}
+	if !strings.Contains(err.Error(), "link_preview.image_proxy.max_size") ||
+		!strings.Contains(err.Error(), "link_preview.image_proxy.cache_ttl") {
+		t.Fatalf("expected image proxy errors, got: %v", err)
+	}
+}
+
+func TestValidate_BackupIncludeUploadsRequiresLocal(t *testing.T) {
+	cfg := validConfig()
+	cfg.Backup.IncludeUploads = true
+	cfg.Storage.Type = "s3"
+	cfg.Storage.S3.Endpoint = "s3.example.com"
+	cfg.Storage.S3.Bucket = "bucket"
+	cfg.Storage.S3.AccessKey = "key"
+	cfg.Storage.S3.SecretKey = "secret"
+	err := Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "backup.include_uploads") {
+		t.Fatalf("expected backup.include_uploads error, got: %v", err)
+	}
+}
+
+func TestValidate_SSERedisBrokerRequiresURL(t *testing.T) {
+	cfg := validConfig()
+	cfg.SSE.Broker = "redis"
+	err := Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "sse.redis.url") {
+		t.Fatalf("expected sse.redis.url error, got: %v", err)
+	}
+
+	cfg.SSE.Redis.URL = "redis://localhost:6379/0"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidate_PostgresRequiresURL(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.Driver = "postgres"
+	err := Validate(cfg)
+	if err == nil || !strings.Contains(err.Error(), "database.url") {
+		t.Fatalf("expected database.url error, got: %v", err)
+	}
+
+	cfg.Database.URL = "postgres://enzyme@localhost/enzyme"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg.Backup.Enabled = true
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "backup.enabled") {
+		t.Fatalf("expected backup.enabled error, got: %v", err)
+	}
+}
+
+func TestValidate_UnknownDatabaseDriver(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.Driver = "mysql"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "database.driver") {
+		t.Fatalf("expected database.driver error, got: %v", err)
+	}
+}
+
+func TestValidate_MetricsRequiresTokenOrListen(t *testing.T) {
+	cfg := validConfig()
+	cfg.Metrics.Enabled = true
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "metrics.token") {
+		t.Fatalf("expected metrics.token error, got: %v", err)
+	}
+
+	cfg.Metrics.Token = "secret"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error with token: %v", err)
+	}
+
+	cfg.Metrics.Token = ""
+	cfg.Metrics.Listen = "127.0.0.1:9090"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error with listen address: %v", err)
+	}
+}
+
+func TestValidate_RelayKey(t *testing.T) {
+	cfg := validConfig()
+	cfg.PushNotifications.Enabled = true
+	cfg.PushNotifications.RelayKey = "not-a-key"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "push_notifications.relay_key") {
+		t.Fatalf("expected relay_key error, got: %v", err)
+	}
+
+	cfg.PushNotifications.RelayKey = "rk_01jq3kmn7xfgy4p6wbr2szta9v.c2VjcmV0"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error with a valid relay key: %v", err)
+	}
+}
+
+func TestValidate_WebPushSubject(t *testing.T) {
+	cfg := validConfig()
+	cfg.PushNotifications.WebPush.Enabled = true
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error without subject: %v", err)
+	}
+
+	cfg.PushNotifications.WebPush.Subject = "ops@example.com"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "push_notifications.web_push.subject") {
+		t.Fatalf("expected web_push.subject error, got: %v", err)
+	}
+
+	cfg.PushNotifications.WebPush.Subject = "mailto:ops@example.com"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error with mailto subject: %v", err)
+	}
+}
+
+func TestValidate_InboundEmail(t *testing.T) {
+	cfg := validConfig()
+	cfg.Email.Inbound.Enabled = true
+	cfg.Email.Inbound.Domain = "reply.example.com"
+	cfg.Email.Inbound.Token = "secret"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.enabled") {
+		t.Fatalf("expected email.enabled error, got: %v", err)
+	}
+
+	cfg.Email.Enabled = true
+	cfg.Email.Host = "smtp.example.com"
+	cfg.Email.From = "enzyme@example.com"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error with token: %v", err)
+	}
+
+	cfg.Email.Inbound.Token = ""
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.inbound.listen") {
+		t.Fatalf("expected email.inbound.listen error, got: %v", err)
+	}
+
+	cfg.Email.Inbound.Listen = ":2525"
+	cfg.Email.Inbound.Domain = ""
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.inbound.domain") {
+		t.Fatalf("expected email.inbound.domain error, got: %v", err)
+	}
+
+	cfg.Email.Inbound.Domain = "reply.example.com"
+	cfg.Email.Inbound.CertFile = "/etc/ssl/mx.pem"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.inbound.key_file") {
+		t.Fatalf("expected email.inbound.key_file error, got: %v", err)
+	}
+
+	cfg.Email.Inbound.KeyFile = "/etc/ssl/mx.key"
+	cfg.Email.Inbound.MaxConnectionsPerIP = 0
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.inbound.max_connections") {
+		t.Fatalf("expected email.inbound.max_connections error, got: %v", err)
+	}
+}
+
+func TestValidate_EmailTransport(t *testing.T) {
+	cfg := validConfig()
+	cfg.Email.Enabled = true
+	cfg.Email.From = "enzyme@example.com"
+	cfg.Email.Transport = "carrier-pigeon"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.transport") {
+		t.Fatalf("expected email.transport error, got: %v", err)
+	}
+
+	cfg.Email.Transport = "http"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.http.url") {
+		t.Fatalf("expected email.http.url error, got: %v", err)
+	}
+	cfg.Email.HTTP.URL = "https://relay.example.com/send"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error with http transport: %v", err)
+	}
+
+	cfg.Email.Transport = "smtp"
+	cfg.Email.Host = "smtp.example.com"
+	cfg.Email.TLS = "sometimes"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.tls") {
+		t.Fatalf("expected email.tls error, got: %v", err)
+	}
+	cfg.Email.TLS = "implicit"
+	cfg.Email.Port = 465
+
+	cfg.Email.DKIM.Domain = "example.com"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.dkim.selector") {
+		t.Fatalf("expected email.dkim.selector error, got: %v", err)
+	}
+	cfg.Email.DKIM.Selector = "enzyme"
+	if err := Validate(cfg); err == nil || !strings.Contains(err.Error(), "email.dkim.private_key") {
+		t.Fatalf("expected email.dkim.private_key error, got: %v", err)
+	}
+	cfg.Email.DKIM.PrivateKeyFile = "/etc/enzyme/dkim.pem"
+	if err := Validate(cfg); err != nil {
+		t.Fatalf("unexpected error with dkim: %v", err)
+	}
+}
@@ -12,18 +12,33 @@ import (
 
 type DB struct {
 	*sql.DB
+	Dialect Dialect
 }
 
-// Options controls SQLite connection pool and pragma settings.
+// Options controls the connection pool and, for SQLite, pragma settings.
 type Options struct {
-	MaxOpenConns     int   // max open connections (default: 10)
-	BusyTimeout      int   // milliseconds to wait on lock (default: 5000)
-	CacheSize        int   // negative = KB, positive = pages (default: -2000)
-	MmapSize         int64 // bytes, 0 = disabled (default: 0)
-	JournalSizeLimit int64 // bytes, caps WAL file size (default: 67108864 = 64MB)
+	Driver           string // DriverSQLite (default) or DriverPostgres
+	MaxOpenConns     int    // max open connections (default: 10)
+	BusyTimeout      int    // milliseconds to wait on lock (default: 5000)
+	CacheSize        int    // negative = KB, positive = pages (default: -2000)
+	MmapSize         int64  // bytes, 0 = disabled (default: 0)
+	JournalSizeLimit int64  // bytes, caps WAL file size (default: 67108864 = 64MB)
 }
 
-func Open(path string, opts Options) (*DB, error) {
+// Open opens the database. dsn is a file path for SQLite and a connection
+// URL for Postgres.
+func Open(dsn string, opts Options) (*DB, error) {
+	switch opts.Driver {
+	case "", DriverSQLite:
+		return openSQLite(dsn, opts)
+	case DriverPostgres:
+		return openPostgres(dsn, opts)
+	default:
+		return nil, fmt.Errorf("unsupported database driver %q", opts.Driver)
+	}
+}
+
+func openSQLite(path string, opts Options) (*DB, error) {
 	// Ensure the directory exists (skip for in-memory databases)
 	if path != ":memory:" {
 		dir := filepath.Dir(path)
@@ -67,7 +82,7 @@ func Open(path string, opts Options) (*DB, error) {
 		}
 	}
 
-	return &DB{db}, nil
+	return &DB{DB: db, Dialect: SQLite}, nil
 }
 
 func (db *DB) Close() error {
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Supported values for the database.driver setting.
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// Dialect identifies the SQL engine behind a connection pool. Most queries
+// are written in SQL both engines accept; repositories use the dialect for
+// the few that differ (full-text search, JSON functions).
+type Dialect int
+
+const (
+	SQLite Dialect = iota
+	Postgres
+)
+
+func (d Dialect) String() string {
+	if d == Postgres {
+		return DriverPostgres
+	}
+	return DriverSQLite
+}
+
+// DialectOf reports which engine db was opened with.
+func DialectOf(db *sql.DB) Dialect {
+	if _, ok := db.Driver().(*pgDriver); ok {
+		return Postgres
+	}
+	return SQLite
+}
+
+// JSONEach returns a table expression that yields one row per element of
+// the JSON array stored in the text column, exposed as alias.value.
+func (d Dialect) JSONEach(column, alias string) string {
+	if d == Postgres {
+		return fmt.Sprintf("jsonb_array_elements_text(%s::jsonb) AS %s(value)", column, alias)
+	}
+	return fmt.Sprintf("json_each(%s) %s", column, alias)
+}
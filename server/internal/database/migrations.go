@@ -1,23 +1,46 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 
 	"github.com/pressly/goose/v3"
 )
 
-//go:embed migrations/*.sql
+// Each engine has its own migration set. Schema changes are added to both
+// with the same version number so the two stay in step.
+//
+//go:embed migrations/sqlite/*.sql migrations/postgres/*.sql
 var embedMigrations embed.FS
 
+// ErrNoSchema is returned for databases that have never been migrated.
+var ErrNoSchema = errors.New("database has no migration history")
+
+// gooseDialect and migrationsDir select the goose dialect and embedded
+// migration set for d.
+func (d Dialect) gooseDialect() string {
+	if d == Postgres {
+		return "postgres"
+	}
+	return "sqlite3"
+}
+
+func (d Dialect) migrationsDir() string {
+	return "migrations/" + d.String()
+}
+
 func (db *DB) Migrate() error {
 	goose.SetBaseFS(embedMigrations)
 
-	if err := goose.SetDialect("sqlite3"); err != nil {
+	if err := goose.SetDialect(db.Dialect.gooseDialect()); err != nil {
 		return fmt.Errorf("setting dialect: %w", err)
 	}
 
-	if err := goose.Up(db.DB, "migrations"); err != nil {
+	if err := goose.Up(db.DB, db.Dialect.migrationsDir()); err != nil {
 		return fmt.Errorf("running migrations: %w", err)
 	}
 
@@ -27,13 +50,94 @@ func (db *DB) Migrate() error {
 func (db *DB) MigrateDown() error {
 	goose.SetBaseFS(embedMigrations)
 
-	if err := goose.SetDialect("sqlite3"); err != nil {
+	if err := goose.SetDialect(db.Dialect.gooseDialect()); err != nil {
 		return fmt.Errorf("setting dialect: %w", err)
 	}
 
-	if err := goose.Down(db.DB, "migrations"); err != nil {
+	if err := goose.Down(db.DB, db.Dialect.migrationsDir()); err != nil {
 		return fmt.Errorf("running down migration: %w", err)
 	}
 
 	return nil
 }
+
+// LatestVersion returns the newest migration version built into this binary
+// for the given engine.
+func LatestVersion(d Dialect) (int64, error) {
+	goose.SetBaseFS(embedMigrations)
+
+	migrations, err := goose.CollectMigrations(d.migrationsDir(), 0, goose.MaxVersion)
+	if err != nil {
+		return 0, fmt.Errorf("collecting migrations: %w", err)
+	}
+	last, err := migrations.Last()
+	if err != nil {
+		return 0, fmt.Errorf("collecting migrations: %w", err)
+	}
+	return last.Version, nil
+}
+
+// PendingMigrations returns how many of the migrations built into this binary
+// have not been applied to db. Versions are not contiguous (the Postgres set
+// starts at 049), so this counts migrations rather than subtracting versions.
+// Like SchemaVersion it doesn't create the version table.
+func (db *DB) PendingMigrations(ctx context.Context) (int, error) {
+	fsys, err := fs.Sub(embedMigrations, db.Dialect.migrationsDir())
+	if err != nil {
+		return 0, fmt.Errorf("collecting migrations: %w", err)
+	}
+	provider, err := goose.NewProvider(goose.Dialect(db.Dialect.gooseDialect()), db.DB, fsys)
+	if err != nil {
+		return 0, fmt.Errorf("collecting migrations: %w", err)
+	}
+
+	// The provider creates the version table, so only consult it once the
+	// table exists
+	if _, err := SchemaVersion(db.DB); errors.Is(err, ErrNoSchema) {
+		return len(provider.ListSources()), nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("reading migration status: %w", err)
+	}
+	pending := 0
+	for _, st := range statuses {
+		if st.State == goose.StatePending {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// SchemaVersion returns the migration version applied to db. Unlike goose's
+// own lookup it never creates the version table, so it is safe to call on
+// read-only connections and on files that may not be Enzyme databases.
+func SchemaVersion(db *sql.DB) (int64, error) {
+	dialect := DialectOf(db)
+
+	var exists bool
+	var err error
+	if dialect == Postgres {
+		err = db.QueryRow(`SELECT to_regclass(?) IS NOT NULL`, goose.TableName()).Scan(&exists)
+	} else {
+		err = db.QueryRow(`SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?`, goose.TableName()).Scan(&exists)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("reading schema: %w", err)
+	}
+	if !exists {
+		return 0, ErrNoSchema
+	}
+
+	if err := goose.SetDialect(dialect.gooseDialect()); err != nil {
+		return 0, fmt.Errorf("setting dialect: %w", err)
+	}
+	version, err := goose.GetDBVersion(db)
+	if err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	return version, nil
+}
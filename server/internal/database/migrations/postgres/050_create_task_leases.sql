@@ -0,0 +1,16 @@
+-- +goose Up
+-- One row per scheduler task. The instance named in owner runs the task until
+-- expires_at; the last_* columns record its most recent run on any instance.
+CREATE TABLE task_leases (
+    name TEXT PRIMARY KEY,
+    owner TEXT,
+    heartbeat_at TEXT,
+    expires_at TEXT,
+    last_run_at TEXT,
+    last_run_by TEXT,
+    last_duration_ms BIGINT,
+    last_error TEXT
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS task_leases;
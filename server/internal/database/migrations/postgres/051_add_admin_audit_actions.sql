@@ -0,0 +1,27 @@
+-- +goose Up
+-- Add the actions recorded by server operators through "enzyme admin".
+ALTER TABLE moderation_log DROP CONSTRAINT moderation_log_action_check;
+ALTER TABLE moderation_log ADD CONSTRAINT moderation_log_action_check CHECK (action IN (
+    'user.banned', 'user.unbanned',
+    'user.blocked', 'user.unblocked',
+    'message.deleted', 'member.removed',
+    'member.role_changed', 'channel.archived',
+    'file.quarantined', 'member.added',
+    'user.password_reset', 'user.email_verified',
+    'user.disabled', 'user.enabled',
+    'user.sessions_revoked'
+));
+
+-- +goose Down
+DELETE FROM moderation_log WHERE action IN (
+    'member.added', 'user.password_reset', 'user.email_verified',
+    'user.disabled', 'user.enabled', 'user.sessions_revoked'
+);
+ALTER TABLE moderation_log DROP CONSTRAINT moderation_log_action_check;
+ALTER TABLE moderation_log ADD CONSTRAINT moderation_log_action_check CHECK (action IN (
+    'user.banned', 'user.unbanned',
+    'user.blocked', 'user.unblocked',
+    'message.deleted', 'member.removed',
+    'member.role_changed', 'channel.archived',
+    'file.quarantined'
+));
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Site admins manage the whole instance through /api/admin, independent of
+-- their workspace roles.
+ALTER TABLE users ADD COLUMN is_site_admin INTEGER NOT NULL DEFAULT 0;
+
+-- Instance-wide settings changed at runtime by site admins. Missing keys
+-- fall back to the defaults in admin.DefaultSettings.
+CREATE TABLE server_settings (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS server_settings;
+ALTER TABLE users DROP COLUMN is_site_admin;
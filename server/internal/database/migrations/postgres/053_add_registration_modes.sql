@@ -0,0 +1,20 @@
+-- +goose Up
+-- Accounts created under the approval registration mode cannot log in until
+-- a site admin approves them.
+ALTER TABLE users ADD COLUMN pending_approval INTEGER NOT NULL DEFAULT 0;
+
+-- The registration_open switch became the closed registration mode
+INSERT INTO server_settings (key, value, updated_at)
+SELECT 'registration_mode', CASE value WHEN 'false' THEN 'closed' ELSE 'open' END, updated_at
+FROM server_settings WHERE key = 'registration_open';
+DELETE FROM server_settings WHERE key = 'registration_open';
+
+-- +goose Down
+-- Pending accounts would become usable without the column
+DELETE FROM users WHERE pending_approval = 1;
+ALTER TABLE users DROP COLUMN pending_approval;
+
+INSERT INTO server_settings (key, value, updated_at)
+SELECT 'registration_open', CASE value WHEN 'open' THEN 'true' ELSE 'false' END, updated_at
+FROM server_settings WHERE key = 'registration_mode';
+DELETE FROM server_settings WHERE key IN ('registration_mode', 'allowed_email_domains');
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Browser Web Push subscriptions, delivered to directly by the server rather
+-- than through the push relay. An endpoint belongs to a single browser
+-- profile, so it moves to whoever registered it last.
+CREATE TABLE web_push_subscriptions (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    endpoint TEXT NOT NULL UNIQUE,
+    p256dh TEXT NOT NULL,
+    auth TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_web_push_subscriptions_user ON web_push_subscriptions(user_id);
+
+-- The VAPID key pair identifying this server to push services. Generated on
+-- first use and shared by every instance, since subscriptions are bound to
+-- the public key.
+CREATE TABLE vapid_keys (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    public_key TEXT NOT NULL,
+    private_key TEXT NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS vapid_keys;
+DROP TABLE IF EXISTS web_push_subscriptions;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- UnifiedPush devices register their distributor endpoint URL as the token.
+ALTER TABLE device_tokens DROP CONSTRAINT device_tokens_platform_check;
+ALTER TABLE device_tokens ADD CONSTRAINT device_tokens_platform_check CHECK (platform IN ('fcm', 'apns', 'unifiedpush'));
+
+-- +goose Down
+DELETE FROM device_tokens WHERE platform = 'unifiedpush';
+ALTER TABLE device_tokens DROP CONSTRAINT device_tokens_platform_check;
+ALTER TABLE device_tokens ADD CONSTRAINT device_tokens_platform_check CHECK (platform IN ('fcm', 'apns'));
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Outgoing mail awaiting delivery or given up on. Delivered messages are
+-- deleted. While an attempt is in flight the status is 'sending' and
+-- next_attempt_at is when the attempt's claim expires.
+CREATE TABLE email_outbox (
+    id TEXT PRIMARY KEY,
+    recipient TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    text_body TEXT NOT NULL,
+    html_body TEXT NOT NULL DEFAULT '',
+    headers TEXT NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_email_outbox_due ON email_outbox(status, next_attempt_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS email_outbox;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Counts scans of an attachment, so errored scans are retried a limited
+-- number of times.
+ALTER TABLE attachments ADD COLUMN scan_attempts INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX idx_attachments_scan_error ON attachments(scanned_at) WHERE scan_status = 'error';
+
+-- +goose Down
+DROP INDEX idx_attachments_scan_error;
+ALTER TABLE attachments DROP COLUMN scan_attempts;
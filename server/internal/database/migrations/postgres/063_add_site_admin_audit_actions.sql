@@ -0,0 +1,28 @@
+-- +goose Up
+-- Record site admin grants and revocations.
+ALTER TABLE moderation_log DROP CONSTRAINT moderation_log_action_check;
+ALTER TABLE moderation_log ADD CONSTRAINT moderation_log_action_check CHECK (action IN (
+    'user.banned', 'user.unbanned',
+    'user.blocked', 'user.unblocked',
+    'message.deleted', 'member.removed',
+    'member.role_changed', 'channel.archived',
+    'file.quarantined', 'member.added',
+    'user.password_reset', 'user.email_verified',
+    'user.disabled', 'user.enabled',
+    'user.sessions_revoked', 'user.site_admin_granted',
+    'user.site_admin_revoked'
+));
+
+-- +goose Down
+DELETE FROM moderation_log WHERE action IN ('user.site_admin_granted', 'user.site_admin_revoked');
+ALTER TABLE moderation_log DROP CONSTRAINT moderation_log_action_check;
+ALTER TABLE moderation_log ADD CONSTRAINT moderation_log_action_check CHECK (action IN (
+    'user.banned', 'user.unbanned',
+    'user.blocked', 'user.unblocked',
+    'message.deleted', 'member.removed',
+    'member.role_changed', 'channel.archived',
+    'file.quarantined', 'member.added',
+    'user.password_reset', 'user.email_verified',
+    'user.disabled', 'user.enabled',
+    'user.sessions_revoked'
+));
@@ -0,0 +1,73 @@
+-- +goose Up
+-- Track antivirus scan results per attachment. NULL scan_status means the file
+-- was uploaded while scanning was disabled.
+ALTER TABLE attachments ADD COLUMN scan_status TEXT CHECK (scan_status IN ('pending', 'clean', 'infected', 'error'));
+ALTER TABLE attachments ADD COLUMN scan_signature TEXT;
+ALTER TABLE attachments ADD COLUMN scanned_at TEXT;
+CREATE INDEX idx_attachments_scan_pending ON attachments(created_at) WHERE scan_status = 'pending';
+
+-- Allow system-initiated audit log entries (NULL actor_id) and add the
+-- 'file.quarantined' action with its 'file' target type.
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE moderation_log RENAME TO moderation_log_old;
+
+CREATE TABLE moderation_log (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    actor_id TEXT REFERENCES users(id) ON DELETE CASCADE,
+    action TEXT NOT NULL CHECK (action IN (
+        'user.banned', 'user.unbanned',
+        'user.blocked', 'user.unblocked',
+        'message.deleted', 'member.removed',
+        'member.role_changed', 'channel.archived',
+        'file.quarantined'
+    )),
+    target_type TEXT NOT NULL CHECK (target_type IN ('user', 'message', 'channel', 'file')),
+    target_id TEXT NOT NULL,
+    metadata TEXT,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+INSERT INTO moderation_log SELECT * FROM moderation_log_old;
+
+DROP TABLE moderation_log_old;
+
+CREATE INDEX idx_moderation_log_workspace ON moderation_log(workspace_id, created_at);
+
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE moderation_log RENAME TO moderation_log_old;
+
+CREATE TABLE moderation_log (
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    actor_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action TEXT NOT NULL CHECK (action IN (
+        'user.banned', 'user.unbanned',
+        'user.blocked', 'user.unblocked',
+        'message.deleted', 'member.removed',
+        'member.role_changed', 'channel.archived'
+    )),
+    target_type TEXT NOT NULL CHECK (target_type IN ('user', 'message', 'channel')),
+    target_id TEXT NOT NULL,
+    metadata TEXT,
+    created_at TEXT NOT NULL DEFAULT (strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+);
+
+INSERT INTO moderation_log SELECT * FROM moderation_log_old
+WHERE actor_id IS NOT NULL AND action != 'file.quarantined';
+
+DROP TABLE moderation_log_old;
+
+CREATE INDEX idx_moderation_log_workspace ON moderation_log(workspace_id, created_at);
+
+PRAGMA foreign_keys = ON;
+
+DROP INDEX IF EXISTS idx_attachments_scan_pending;
+ALTER TABLE attachments DROP COLUMN scanned_at;
+ALTER TABLE attachments DROP COLUMN scan_signature;
+ALTER TABLE attachments DROP COLUMN scan_status;
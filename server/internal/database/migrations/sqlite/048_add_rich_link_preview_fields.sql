@@ -0,0 +1,44 @@
+-- +goose Up
+ALTER TABLE link_preview_cache ADD COLUMN media_type TEXT;
+ALTER TABLE link_preview_cache ADD COLUMN image_width INTEGER;
+ALTER TABLE link_preview_cache ADD COLUMN image_height INTEGER;
+ALTER TABLE link_preview_cache ADD COLUMN video_url TEXT;
+ALTER TABLE link_preview_cache ADD COLUMN video_width INTEGER;
+ALTER TABLE link_preview_cache ADD COLUMN video_height INTEGER;
+ALTER TABLE link_preview_cache ADD COLUMN author_name TEXT;
+ALTER TABLE link_preview_cache ADD COLUMN author_url TEXT;
+ALTER TABLE link_preview_cache ADD COLUMN published_at TEXT;
+ALTER TABLE link_preview_cache ADD COLUMN favicon_url TEXT;
+
+ALTER TABLE link_previews ADD COLUMN media_type TEXT;
+ALTER TABLE link_previews ADD COLUMN image_width INTEGER;
+ALTER TABLE link_previews ADD COLUMN image_height INTEGER;
+ALTER TABLE link_previews ADD COLUMN video_url TEXT;
+ALTER TABLE link_previews ADD COLUMN video_width INTEGER;
+ALTER TABLE link_previews ADD COLUMN video_height INTEGER;
+ALTER TABLE link_previews ADD COLUMN author_name TEXT;
+ALTER TABLE link_previews ADD COLUMN author_url TEXT;
+ALTER TABLE link_previews ADD COLUMN published_at TEXT;
+ALTER TABLE link_previews ADD COLUMN favicon_url TEXT;
+
+-- +goose Down
+ALTER TABLE link_previews DROP COLUMN favicon_url;
+ALTER TABLE link_previews DROP COLUMN published_at;
+ALTER TABLE link_previews DROP COLUMN author_url;
+ALTER TABLE link_previews DROP COLUMN author_name;
+ALTER TABLE link_previews DROP COLUMN video_height;
+ALTER TABLE link_previews DROP COLUMN video_width;
+ALTER TABLE link_previews DROP COLUMN video_url;
+ALTER TABLE link_previews DROP COLUMN image_height;
+ALTER TABLE link_previews DROP COLUMN image_width;
+ALTER TABLE link_previews DROP COLUMN media_type;
+ALTER TABLE link_preview_cache DROP COLUMN favicon_url;
+ALTER TABLE link_preview_cache DROP COLUMN published_at;
+ALTER TABLE link_preview_cache DROP COLUMN author_url;
+ALTER TABLE link_preview_cache DROP COLUMN author_name;
+ALTER TABLE link_preview_cache DROP COLUMN video_height;
+ALTER TABLE link_preview_cache DROP COLUMN video_width;
+ALTER TABLE link_preview_cache DROP COLUMN video_url;
+ALTER TABLE link_preview_cache DROP COLUMN image_height;
+ALTER TABLE link_preview_cache DROP COLUMN image_width;
+ALTER TABLE link_preview_cache DROP COLUMN media_type;
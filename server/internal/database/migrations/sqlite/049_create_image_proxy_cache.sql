@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE image_proxy_cache (
+    url_hash TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes INTEGER NOT NULL,
+    storage_key TEXT NOT NULL,
+    fetched_at TEXT NOT NULL,
+    expires_at TEXT NOT NULL
+);
+
+CREATE INDEX idx_image_proxy_cache_expires ON image_proxy_cache(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS image_proxy_cache;
@@ -0,0 +1,44 @@
+-- +goose Up
+-- UnifiedPush devices register their distributor endpoint URL as the token.
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE device_tokens RENAME TO device_tokens_old;
+
+CREATE TABLE device_tokens (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token TEXT NOT NULL,
+    platform TEXT NOT NULL CHECK (platform IN ('fcm', 'apns', 'unifiedpush')),
+    device_id TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    UNIQUE(user_id, token)
+);
+
+INSERT INTO device_tokens SELECT * FROM device_tokens_old;
+
+DROP TABLE device_tokens_old;
+
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE device_tokens RENAME TO device_tokens_old;
+
+CREATE TABLE device_tokens (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token TEXT NOT NULL,
+    platform TEXT NOT NULL CHECK (platform IN ('fcm', 'apns')),
+    device_id TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL,
+    UNIQUE(user_id, token)
+);
+
+INSERT INTO device_tokens SELECT * FROM device_tokens_old WHERE platform IN ('fcm', 'apns');
+
+DROP TABLE device_tokens_old;
+
+PRAGMA foreign_keys = ON;
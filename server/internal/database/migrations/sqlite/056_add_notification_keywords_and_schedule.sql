@@ -0,0 +1,90 @@
+-- +goose Up
+-- Per-user notification settings that apply across channels: highlight
+-- keywords (a JSON array of strings) and a working-hours schedule (JSON, NULL
+-- when push and email are always allowed).
+CREATE TABLE notification_settings (
+    user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    highlight_keywords TEXT NOT NULL DEFAULT '[]',
+    schedule TEXT,
+    updated_at TEXT NOT NULL
+);
+
+-- Push notifications held back because they arrived outside the recipient's
+-- schedule, delivered once their window opens.
+CREATE TABLE held_notifications (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    channel_type TEXT NOT NULL,
+    channel_name TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    thread_parent_id TEXT,
+    notification_type TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    release_at TEXT NOT NULL,
+    UNIQUE(user_id, message_id)
+);
+
+CREATE INDEX idx_held_notifications_release_at ON held_notifications(release_at);
+
+-- Allow keyword notifications in the email queue. thread_reply was missing
+-- from the original constraint.
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE pending_notifications RENAME TO pending_notifications_old;
+
+CREATE TABLE pending_notifications (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    notification_type TEXT NOT NULL
+        CHECK (notification_type IN ('mention', 'dm', 'channel', 'here', 'everyone', 'thread_reply', 'keyword')),
+    created_at TEXT NOT NULL,
+    send_after TEXT NOT NULL,
+    UNIQUE(user_id, message_id)
+);
+
+INSERT INTO pending_notifications SELECT * FROM pending_notifications_old;
+
+DROP TABLE pending_notifications_old;
+
+CREATE INDEX idx_pending_notifications_user_id ON pending_notifications(user_id);
+CREATE INDEX idx_pending_notifications_send_after ON pending_notifications(send_after);
+
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+PRAGMA foreign_keys = OFF;
+
+ALTER TABLE pending_notifications RENAME TO pending_notifications_old;
+
+CREATE TABLE pending_notifications (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id TEXT NOT NULL,
+    channel_id TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    notification_type TEXT NOT NULL
+        CHECK (notification_type IN ('mention', 'dm', 'channel', 'here', 'everyone')),
+    created_at TEXT NOT NULL,
+    send_after TEXT NOT NULL,
+    UNIQUE(user_id, message_id)
+);
+
+INSERT INTO pending_notifications SELECT * FROM pending_notifications_old
+    WHERE notification_type IN ('mention', 'dm', 'channel', 'here', 'everyone');
+
+DROP TABLE pending_notifications_old;
+
+CREATE INDEX idx_pending_notifications_user_id ON pending_notifications(user_id);
+CREATE INDEX idx_pending_notifications_send_after ON pending_notifications(send_after);
+
+PRAGMA foreign_keys = ON;
+
+DROP TABLE IF EXISTS held_notifications;
+DROP TABLE IF EXISTS notification_settings;
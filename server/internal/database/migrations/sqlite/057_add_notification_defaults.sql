@@ -0,0 +1,39 @@
+-- +goose Up
+-- Notification defaults that channels inherit unless a member overrides them.
+-- The workspace's defaults apply to members who haven't set their own.
+CREATE TABLE workspace_notification_defaults (
+    workspace_id TEXT PRIMARY KEY REFERENCES workspaces(id) ON DELETE CASCADE,
+    notify_level TEXT NOT NULL CHECK (notify_level IN ('all', 'mentions', 'none')),
+    email_enabled INTEGER NOT NULL,
+    push_enabled INTEGER NOT NULL,
+    mobile_only_when_idle INTEGER NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+CREATE TABLE user_notification_defaults (
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id TEXT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    notify_level TEXT NOT NULL CHECK (notify_level IN ('all', 'mentions', 'none')),
+    email_enabled INTEGER NOT NULL,
+    push_enabled INTEGER NOT NULL,
+    mobile_only_when_idle INTEGER NOT NULL,
+    updated_at TEXT NOT NULL,
+    PRIMARY KEY (user_id, workspace_id)
+);
+
+-- Set by admins for channels like #announcements; takes precedence over
+-- members' workspace defaults but not over their own channel preferences.
+CREATE TABLE channel_notification_defaults (
+    channel_id TEXT PRIMARY KEY REFERENCES channels(id) ON DELETE CASCADE,
+    notify_level TEXT NOT NULL CHECK (notify_level IN ('all', 'mentions', 'none')),
+    updated_at TEXT NOT NULL
+);
+
+-- NULL inherits from the defaults
+ALTER TABLE notification_preferences ADD COLUMN push_enabled INTEGER;
+
+-- +goose Down
+ALTER TABLE notification_preferences DROP COLUMN push_enabled;
+DROP TABLE IF EXISTS channel_notification_defaults;
+DROP TABLE IF EXISTS user_notification_defaults;
+DROP TABLE IF EXISTS workspace_notification_defaults;
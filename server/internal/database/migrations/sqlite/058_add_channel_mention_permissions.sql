@@ -0,0 +1,13 @@
+-- +goose Up
+-- Per-channel overrides of the workspace's settings for who may use @here,
+-- @channel and @everyone. NULL inherits the workspace setting.
+CREATE TABLE channel_mention_permissions (
+    channel_id TEXT PRIMARY KEY REFERENCES channels(id) ON DELETE CASCADE,
+    who_can_mention_here TEXT CHECK (who_can_mention_here IN ('everyone', 'members', 'admins')),
+    who_can_mention_channel TEXT CHECK (who_can_mention_channel IN ('everyone', 'members', 'admins')),
+    who_can_mention_everyone TEXT CHECK (who_can_mention_everyone IN ('everyone', 'members', 'admins')),
+    updated_at TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS channel_mention_permissions;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- How often a user's notification emails are batched into a digest. 'never'
+-- is also set by the one-click unsubscribe link in the emails.
+ALTER TABLE notification_settings ADD COLUMN email_digest TEXT NOT NULL DEFAULT 'immediate'
+    CHECK (email_digest IN ('immediate', 'hourly', 'daily', 'never'));
+
+-- +goose Down
+ALTER TABLE notification_settings DROP COLUMN email_digest;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Name shown for messages posted by an integration rather than a user, such
+-- as mail sent to a channel's email address.
+ALTER TABLE messages ADD COLUMN bot_name TEXT;
+
+-- Secret inbound email addresses of channels. Mail sent to the address is
+-- posted into the channel; a non-empty allowed_senders list of addresses and
+-- domains restricts who may send to it.
+CREATE TABLE channel_email_addresses (
+    channel_id TEXT PRIMARY KEY REFERENCES channels(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    allowed_senders TEXT NOT NULL DEFAULT '[]',
+    created_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TEXT NOT NULL,
+    updated_at TEXT NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS channel_email_addresses;
+ALTER TABLE messages DROP COLUMN bot_name;
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPendingMigrations(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(":memory:", Options{MaxOpenConns: 1})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+
+	total, err := db.PendingMigrations(ctx)
+	if err != nil {
+		t.Fatalf("PendingMigrations: %v", err)
+	}
+	if total == 0 {
+		t.Fatal("expected pending migrations on an empty database")
+	}
+	if _, err := SchemaVersion(db.DB); !errors.Is(err, ErrNoSchema) {
+		t.Fatalf("PendingMigrations created the version table: %v", err)
+	}
+
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if err := db.MigrateDown(); err != nil {
+		t.Fatalf("MigrateDown: %v", err)
+	}
+	if n, err := db.PendingMigrations(ctx); err != nil || n != 1 {
+		t.Fatalf("PendingMigrations after rolling back one = %d, %v; want 1", n, err)
+	}
+
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if n, err := db.PendingMigrations(ctx); err != nil || n != 0 {
+		t.Fatalf("PendingMigrations when up to date = %d, %v; want 0", n, err)
+	}
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// openPostgres opens a PostgreSQL pool through pgx. Repositories are written
+// with SQLite-style ? placeholders, integer booleans and text timestamps, so
+// connections are wrapped to translate those on the way in.
+func openPostgres(url string, opts Options) (*DB, error) {
+	cfg, err := pgx.ParseConfig(url)
+	if err != nil {
+		return nil, fmt.Errorf("parsing database url: %w", err)
+	}
+
+	db := sql.OpenDB(&pgConnector{base: stdlib.GetConnector(*cfg)})
+	db.SetMaxOpenConns(opts.MaxOpenConns)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("connecting to database: %w", err)
+	}
+
+	// Text columns hold ULIDs and RFC 3339 timestamps that are compared and
+	// sorted as strings. Only byte-order collation sorts them the way SQLite does.
+	var collation string
+	if err := db.QueryRowContext(ctx, `SELECT datcollate FROM pg_database WHERE datname = current_database()`).Scan(&collation); err == nil &&
+		collation != "C" && collation != "POSIX" && !strings.HasPrefix(collation, "C.") {
+		slog.Warn("database collation is not C; create the database with LC_COLLATE 'C' for correct ordering", "collation", collation)
+	}
+
+	return &DB{DB: db, Dialect: Postgres}, nil
+}
+
+type pgConnector struct {
+	base driver.Connector
+}
+
+func (c *pgConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pgConn{Conn: conn.(*stdlib.Conn)}, nil
+}
+
+func (c *pgConnector) Driver() driver.Driver {
+	return &pgDriver{}
+}
+
+// pgDriver only exists so DialectOf can recognise Postgres pools; connections
+// are always made through pgConnector.
+type pgDriver struct{}
+
+func (*pgDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("database: open postgres connections with database.Open")
+}
+
+type pgConn struct {
+	*stdlib.Conn
+}
+
+func (c *pgConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(rebind(query))
+}
+
+func (c *pgConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.Conn.PrepareContext(ctx, rebind(query))
+}
+
+func (c *pgConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.ExecContext(ctx, rebind(query), args)
+}
+
+func (c *pgConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.Conn.QueryContext(ctx, rebind(query), args)
+}
+
+// CheckNamedValue stores Go values the way the SQLite driver does: booleans
+// as 0/1 and times as text, since the schema uses integer and text columns.
+func (c *pgConn) CheckNamedValue(nv *driver.NamedValue) error {
+	switch v := nv.Value.(type) {
+	case bool:
+		if v {
+			nv.Value = int64(1)
+		} else {
+			nv.Value = int64(0)
+		}
+	case time.Time:
+		nv.Value = v.UTC().Format(time.RFC3339Nano)
+	}
+	return c.Conn.CheckNamedValue(nv)
+}
+
+// rebind rewrites ? placeholders as $1, $2, ... skipping string literals,
+// quoted identifiers and comments.
+func rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+	n := 0
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case ch == '\'' || ch == '"':
+			end := strings.IndexByte(query[i+1:], ch)
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			// A doubled quote inside a literal is an escape; the scan
+			// simply resumes after it as the start of a new literal
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case ch == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end])
+			i += end - 1
+		case ch == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+4])
+			i += end + 3
+		case ch == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package database
+
+import "testing"
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"no placeholders", `SELECT 1`, `SELECT 1`},
+		{"positional", `SELECT * FROM t WHERE a = ? AND b IN (?, ?)`, `SELECT * FROM t WHERE a = $1 AND b IN ($2, $3)`},
+		{"string literal", `SELECT '?' || ? FROM t`, `SELECT '?' || $1 FROM t`},
+		{"escaped quote", `SELECT 'it''s ?' WHERE a = ?`, `SELECT 'it''s ?' WHERE a = $1`},
+		{"quoted identifier", `SELECT "a?b" FROM t WHERE c = ?`, `SELECT "a?b" FROM t WHERE c = $1`},
+		{"line comment", "SELECT ? -- why?\nFROM t WHERE a = ?", "SELECT $1 -- why?\nFROM t WHERE a = $2"},
+		{"block comment", `SELECT /* ? */ ? FROM t`, `SELECT /* ? */ $1 FROM t`},
+		{"unterminated literal", `SELECT ? WHERE a = 'x?`, `SELECT $1 WHERE a = 'x?`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rebind(tt.query); got != tt.want {
+				t.Errorf("rebind(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialectJSONEach(t *testing.T) {
+	if got := SQLite.JSONEach("m.mentions", "je"); got != "json_each(m.mentions) je" {
+		t.Errorf("sqlite: %q", got)
+	}
+	if got := Postgres.JSONEach("m.mentions", "je"); got != "jsonb_array_elements_text(m.mentions::jsonb) AS je(value)" {
+		t.Errorf("postgres: %q", got)
+	}
+}
@@ -0,0 +1,174 @@
+package email
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/enzyme/server/internal/config"
+)
+
+func TestRelaxedCanonicalization(t *testing.T) {
+	// Example from RFC 6376 section 3.4.5
+	fields := splitHeaderFields("A: X\r\nB : Y\t\r\n\tZ  \r\n")
+	var got []string
+	for _, f := range fields {
+		got = append(got, relaxedHeader(f))
+	}
+	if strings.Join(got, "\r\n") != "a:X\r\nb:Y Z" {
+		t.Errorf("headers = %q", got)
+	}
+	if body := relaxedBody([]byte(" C \r\nD \t E\r\n\r\n\r\n")); string(body) != " C\r\nD E\r\n" {
+		t.Errorf("body = %q", body)
+	}
+	if body := relaxedBody([]byte("\r\n\r\n")); len(body) != 0 {
+		t.Errorf("empty body = %q", body)
+	}
+}
+
+func TestDKIMSign(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name      string
+		pem       []byte
+		algorithm string
+		public    crypto.PublicKey
+	}{
+		{"rsa", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}), "rsa-sha256", &rsaKey.PublicKey},
+		{"ed25519", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: edDER}), "ed25519-sha256", edKey.Public()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := newDKIMSigner(config.DKIMConfig{Domain: "example.org", Selector: "enzyme", PrivateKey: string(tt.pem)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			raw, err := buildMessage("Enzyme <enzyme@example.org>", &Message{
+				To:       "alice@example.com",
+				Subject:  "Hello",
+				TextBody: "Hi Alice,\n\nsee you  there.\n",
+				HTMLBody: "<p>Hi Alice</p>",
+				Headers:  map[string]string{"List-Unsubscribe": "<https://example.org/unsubscribe>"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			signed, err := signer.sign(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.HasSuffix(signed, raw) {
+				t.Fatal("signing changed the message")
+			}
+
+			tags := verifyDKIM(t, signed, tt.public)
+			if tags["a"] != tt.algorithm || tags["d"] != "example.org" || tags["s"] != "enzyme" {
+				t.Errorf("tags = %v", tags)
+			}
+			if tags["h"] != "from:to:subject:date:message-id:list-unsubscribe:mime-version:content-type" {
+				t.Errorf("signed headers = %q", tags["h"])
+			}
+
+			// Rewrapping whitespace survives relaxed canonicalization, changing
+			// content doesn't
+			rewrapped := bytes.Replace(signed, []byte("Subject: Hello"), []byte("Subject:   Hello "), 1)
+			verifyDKIM(t, rewrapped, tt.public)
+			tampered := bytes.Replace(signed, []byte("Subject: Hello"), []byte("Subject: Goodbye"), 1)
+			if verifyDKIMErr(tampered, tt.public) == "" {
+				t.Error("tampered header verified")
+			}
+		})
+	}
+}
+
+func TestNewDKIMSignerDisabled(t *testing.T) {
+	signer, err := newDKIMSigner(config.DKIMConfig{})
+	if err != nil || signer != nil {
+		t.Fatalf("signer, err = %v, %v, want nil when no domain is set", signer, err)
+	}
+	if _, err := newDKIMSigner(config.DKIMConfig{Domain: "example.org", Selector: "s", PrivateKey: "not a key"}); err == nil {
+		t.Fatal("expected error for an invalid key")
+	}
+}
+
+// verifyDKIM checks the message's DKIM signature, returning its tags.
+func verifyDKIM(t *testing.T, msg []byte, public crypto.PublicKey) map[string]string {
+	t.Helper()
+	if reason := verifyDKIMErr(msg, public); reason != "" {
+		t.Fatalf("verification failed: %s", reason)
+	}
+	tags, _ := dkimTags(msg)
+	return tags
+}
+
+// verifyDKIMErr verifies the first header, which must be the DKIM signature,
+// returning why verification failed or "" on success.
+func verifyDKIMErr(msg []byte, public crypto.PublicKey) string {
+	tags, sigField := dkimTags(msg)
+	header, body, _ := bytes.Cut(msg, []byte("\r\n\r\n"))
+	fields := splitHeaderFields(string(header) + "\r\n")
+
+	bodyHash := sha256.Sum256(relaxedBody(body))
+	if tags["bh"] != base64.StdEncoding.EncodeToString(bodyHash[:]) {
+		return "body hash mismatch"
+	}
+
+	h := sha256.New()
+	for _, name := range strings.Split(tags["h"], ":") {
+		field, ok := lastHeaderField(fields[1:], name)
+		if !ok {
+			return "missing signed header " + name
+		}
+		h.Write([]byte(relaxedHeader(field) + "\r\n"))
+	}
+	unsigned := sigField[:strings.LastIndex(sigField, "b=")+2]
+	h.Write([]byte(relaxedHeader(unsigned)))
+	digest := h.Sum(nil)
+
+	sig, err := base64.StdEncoding.DecodeString(tags["b"])
+	if err != nil {
+		return err.Error()
+	}
+	switch key := public.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, sig); err != nil {
+			return err.Error()
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, digest, sig) {
+			return "bad ed25519 signature"
+		}
+	}
+	return ""
+}
+
+func dkimTags(msg []byte) (map[string]string, string) {
+	header, _, _ := bytes.Cut(msg, []byte("\r\n\r\n"))
+	field := splitHeaderFields(string(header) + "\r\n")[0]
+	_, value, _ := strings.Cut(field, ":")
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(value, ";") {
+		name, v, _ := strings.Cut(strings.TrimSpace(tag), "=")
+		tags[name] = strings.Join(strings.Fields(v), "")
+	}
+	return tags, field
+}
@@ -0,0 +1,99 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/enzyme/server/internal/config"
+)
+
+// HTTPSender delivers mail by POSTing it to a mail relay's HTTP API as JSON
+// of the form
+//
+//	{"from": "...", "to": ["..."], "subject": "...", "text": "...", "html": "...",
+//	 "headers": {...}, "raw": "<base64 RFC 5322 message>"}
+//
+// where from is the bare sender address and raw is the complete message,
+// DKIM signed when configured. Any 2xx response means the relay accepted
+// the message; 4xx responses other than 408 and 429 are permanent failures.
+type HTTPSender struct {
+	url      string
+	token    string
+	composer composer
+	client   *http.Client
+}
+
+// httpSendRequest is the JSON body posted to the relay.
+type httpSendRequest struct {
+	From    string            `json:"from"`
+	To      []string          `json:"to"`
+	Subject string            `json:"subject"`
+	Text    string            `json:"text"`
+	HTML    string            `json:"html,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Raw     []byte            `json:"raw"`
+}
+
+// NewHTTPSender creates an HTTP sender. If cfg.HTTP.Token is non-empty it
+// is sent as a bearer token.
+func NewHTTPSender(cfg config.EmailConfig) (*HTTPSender, error) {
+	c, err := newComposer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPSender{
+		url:      cfg.HTTP.URL,
+		token:    cfg.HTTP.Token,
+		composer: c,
+		client:   &http.Client{Timeout: sendTimeout},
+	}, nil
+}
+
+func (h *HTTPSender) Send(ctx context.Context, m *Message) error {
+	raw, err := h.composer.compose(m)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(httpSendRequest{
+		From:    h.composer.envelopeFrom(),
+		To:      []string{m.To},
+		Subject: m.Subject,
+		Text:    m.TextBody,
+		HTML:    m.HTMLBody,
+		Headers: m.Headers,
+		Raw:     raw,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("mail relay request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("mail relay returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return &PermanentError{Err: err}
+	}
+	return err
+}
@@ -0,0 +1,274 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// Outbox statuses. A message is "sending" while an attempt is in flight;
+// if that attempt never finishes, e.g. because the server stopped, the
+// message is picked up again once its claim expires.
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSending = "sending"
+	OutboxStatusFailed  = "failed"
+)
+
+// outboxClaimTimeout is how long a delivery attempt holds a message before
+// another may retry it. It comfortably exceeds sendTimeout.
+const outboxClaimTimeout = 5 * time.Minute
+
+// outboxRetryDelays are the waits after each failed attempt. A message that
+// fails once more after the last one is marked failed, about a day after it
+// was queued.
+var outboxRetryDelays = []time.Duration{
+	time.Minute, 5 * time.Minute, 15 * time.Minute, time.Hour, 3 * time.Hour, 6 * time.Hour, 12 * time.Hour,
+}
+
+var ErrQueuedEmailNotFound = errors.New("queued email not found")
+
+// QueuedEmail is a message in the outbox. Delivered messages are removed,
+// so it is either awaiting delivery or has failed.
+type QueuedEmail struct {
+	ID            string
+	Message       Message
+	Status        string
+	Attempts      int
+	LastError     *string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Outbox is the persistent queue of outgoing mail, so that messages survive
+// restarts and an unreachable mail server.
+type Outbox struct {
+	db *sql.DB
+}
+
+func NewOutbox(db *sql.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+// Enqueue stores a message, claimed for an immediate delivery attempt by
+// the caller.
+func (o *Outbox) Enqueue(ctx context.Context, msg *Message) (*QueuedEmail, error) {
+	now := time.Now().UTC()
+	headersJSON := "{}"
+	if len(msg.Headers) > 0 {
+		data, err := json.Marshal(msg.Headers)
+		if err != nil {
+			return nil, err
+		}
+		headersJSON = string(data)
+	}
+
+	q := &QueuedEmail{
+		ID:            ulid.Make().String(),
+		Message:       *msg,
+		Status:        OutboxStatusSending,
+		NextAttemptAt: now.Add(outboxClaimTimeout),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	_, err := o.db.ExecContext(ctx, `
+		INSERT INTO email_outbox (id, recipient, subject, text_body, html_body, headers, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 0, ?, ?, ?)
+	`, q.ID, msg.To, msg.Subject, msg.TextBody, msg.HTMLBody, headersJSON, q.Status,
+		q.NextAttemptAt.Format(time.RFC3339), now.Format(time.RFC3339), now.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// ListDue returns messages awaiting delivery whose next attempt is due,
+// oldest first.
+func (o *Outbox) ListDue(ctx context.Context, now time.Time, limit int) ([]QueuedEmail, error) {
+	rows, err := o.db.QueryContext(ctx, `
+		SELECT `+queuedEmailColumns+`
+		FROM email_outbox
+		WHERE status IN (?, ?) AND next_attempt_at <= ?
+		ORDER BY next_attempt_at
+		LIMIT ?
+	`, OutboxStatusPending, OutboxStatusSending, now.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []QueuedEmail
+	for rows.Next() {
+		q, err := scanQueuedEmail(rows)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, *q)
+	}
+	return emails, rows.Err()
+}
+
+// Claim marks a due message as being delivered, reporting false if another
+// attempt claimed it first.
+func (o *Outbox) Claim(ctx context.Context, id string, now time.Time) (bool, error) {
+	now = now.UTC()
+	result, err := o.db.ExecContext(ctx, `
+		UPDATE email_outbox SET status = ?, next_attempt_at = ?, updated_at = ?
+		WHERE id = ? AND status IN (?, ?) AND next_attempt_at <= ?
+	`, OutboxStatusSending, now.Add(outboxClaimTimeout).Format(time.RFC3339), now.Format(time.RFC3339),
+		id, OutboxStatusPending, OutboxStatusSending, now.Format(time.RFC3339))
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// MarkSent removes a delivered message.
+func (o *Outbox) MarkSent(ctx context.Context, id string) error {
+	_, err := o.db.ExecContext(ctx, `DELETE FROM email_outbox WHERE id = ?`, id)
+	return err
+}
+
+// RecordFailure records a failed attempt, scheduling a retry with backoff
+// unless the error is permanent or the attempts are used up. It reports
+// whether the message is now marked failed.
+func (o *Outbox) RecordFailure(ctx context.Context, q *QueuedEmail, sendErr error) (bool, error) {
+	now := time.Now().UTC()
+	q.Attempts++
+	lastError := sendErr.Error()
+	q.LastError = &lastError
+	q.UpdatedAt = now
+	if IsPermanent(sendErr) || q.Attempts > len(outboxRetryDelays) {
+		q.Status = OutboxStatusFailed
+		q.NextAttemptAt = now
+	} else {
+		q.Status = OutboxStatusPending
+		q.NextAttemptAt = now.Add(outboxRetryDelays[q.Attempts-1])
+	}
+
+	_, err := o.db.ExecContext(ctx, `
+		UPDATE email_outbox SET status = ?, attempts = ?, last_error = ?, next_attempt_at = ?, updated_at = ?
+		WHERE id = ?
+	`, q.Status, q.Attempts, lastError, q.NextAttemptAt.Format(time.RFC3339), now.Format(time.RFC3339), q.ID)
+	return q.Status == OutboxStatusFailed, err
+}
+
+func (o *Outbox) Get(ctx context.Context, id string) (*QueuedEmail, error) {
+	row := o.db.QueryRowContext(ctx, `SELECT `+queuedEmailColumns+` FROM email_outbox WHERE id = ?`, id)
+	q, err := scanQueuedEmail(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrQueuedEmailNotFound
+	}
+	return q, err
+}
+
+// List returns messages with the given status, newest first, with
+// cursor-based pagination.
+func (o *Outbox) List(ctx context.Context, status, cursor string, limit int) ([]QueuedEmail, bool, string, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	where := "WHERE status = ?"
+	args := []interface{}{status}
+	if cursor != "" {
+		where += " AND id < ?"
+		args = append(args, cursor)
+	}
+	args = append(args, limit+1)
+
+	rows, err := o.db.QueryContext(ctx, `
+		SELECT `+queuedEmailColumns+`
+		FROM email_outbox `+where+`
+		ORDER BY id DESC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, false, "", err
+	}
+	defer rows.Close()
+
+	var emails []QueuedEmail
+	for rows.Next() {
+		q, err := scanQueuedEmail(rows)
+		if err != nil {
+			return nil, false, "", err
+		}
+		emails = append(emails, *q)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(emails) > limit
+	nextCursor := ""
+	if hasMore {
+		emails = emails[:limit]
+		nextCursor = emails[len(emails)-1].ID
+	}
+	return emails, hasMore, nextCursor, nil
+}
+
+// Retry queues a failed message for another round of delivery attempts,
+// starting now.
+func (o *Outbox) Retry(ctx context.Context, id string) error {
+	now := time.Now().UTC().Format(time.RFC3339)
+	result, err := o.db.ExecContext(ctx, `
+		UPDATE email_outbox SET status = ?, attempts = 0, next_attempt_at = ?, updated_at = ?
+		WHERE id = ? AND status = ?
+	`, OutboxStatusPending, now, now, id, OutboxStatusFailed)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrQueuedEmailNotFound
+	}
+	return nil
+}
+
+// DeleteFailedBefore removes messages that failed before cutoff.
+func (o *Outbox) DeleteFailedBefore(ctx context.Context, cutoff time.Time) error {
+	_, err := o.db.ExecContext(ctx, `
+		DELETE FROM email_outbox WHERE status = ? AND updated_at < ?
+	`, OutboxStatusFailed, cutoff.UTC().Format(time.RFC3339))
+	return err
+}
+
+const queuedEmailColumns = `id, recipient, subject, text_body, html_body, headers, status, attempts, last_error, next_attempt_at, created_at, updated_at`
+
+func scanQueuedEmail(row interface{ Scan(dest ...any) error }) (*QueuedEmail, error) {
+	var q QueuedEmail
+	var headersJSON, nextAttemptAt, createdAt, updatedAt string
+	var lastError sql.NullString
+	err := row.Scan(&q.ID, &q.Message.To, &q.Message.Subject, &q.Message.TextBody, &q.Message.HTMLBody, &headersJSON,
+		&q.Status, &q.Attempts, &lastError, &nextAttemptAt, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(headersJSON), &q.Message.Headers); err != nil {
+		return nil, err
+	}
+	if len(q.Message.Headers) == 0 {
+		q.Message.Headers = nil
+	}
+	if lastError.Valid {
+		q.LastError = &lastError.String
+	}
+	q.NextAttemptAt, _ = time.Parse(time.RFC3339, nextAttemptAt)
+	q.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	q.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return &q, nil
+}
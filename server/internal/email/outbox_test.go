@@ -0,0 +1,233 @@
+package email
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/testutil"
+)
+
+// flakySender fails with err, if set, and records delivered messages.
+type flakySender struct {
+	mu   sync.Mutex
+	err  error
+	sent []*Message
+}
+
+func (f *flakySender) Send(ctx context.Context, msg *Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func testQueueingService(t *testing.T) (*Service, *flakySender, *Outbox, *sql.DB) {
+	t.Helper()
+	db := testutil.TestDB(t)
+	sender := &flakySender{}
+	outbox := NewOutbox(db)
+	s := NewTestServiceWithSender(true, "http://localhost:8080", sender)
+	s.SetOutbox(outbox)
+	return s, sender, outbox, db
+}
+
+// makeDue moves every queued message's next attempt into the past.
+func makeDue(t *testing.T, db *sql.DB) {
+	t.Helper()
+	past := time.Now().Add(-time.Second).UTC().Format(time.RFC3339)
+	if _, err := db.Exec(`UPDATE email_outbox SET next_attempt_at = ?`, past); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func queued(t *testing.T, outbox *Outbox, status string) []QueuedEmail {
+	t.Helper()
+	emails, _, _, err := outbox.List(context.Background(), status, "", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return emails
+}
+
+func TestOutboxDeliversImmediately(t *testing.T) {
+	s, sender, outbox, _ := testQueueingService(t)
+	ctx := context.Background()
+
+	if err := s.SendPasswordReset(ctx, "alice@example.com", "token"); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sender.sent))
+	}
+	for _, status := range []string{OutboxStatusPending, OutboxStatusSending, OutboxStatusFailed} {
+		if n := len(queued(t, outbox, status)); n != 0 {
+			t.Errorf("%d %s messages left in the outbox", n, status)
+		}
+	}
+}
+
+func TestOutboxRetriesWithBackoff(t *testing.T) {
+	s, sender, outbox, db := testQueueingService(t)
+	ctx := context.Background()
+	sender.err = errors.New("connection refused")
+
+	if err := s.SendPasswordReset(ctx, "alice@example.com", "token"); err != nil {
+		t.Fatalf("queued send should succeed, got %v", err)
+	}
+	pending := queued(t, outbox, OutboxStatusPending)
+	if len(pending) != 1 {
+		t.Fatalf("pending = %d, want 1", len(pending))
+	}
+	q := pending[0]
+	if q.Attempts != 1 || q.LastError == nil || *q.LastError != "connection refused" {
+		t.Errorf("attempts, last error = %d, %v", q.Attempts, q.LastError)
+	}
+	if wait := time.Until(q.NextAttemptAt); wait < 50*time.Second || wait > outboxRetryDelays[0] {
+		t.Errorf("next attempt in %v, want about %v", wait, outboxRetryDelays[0])
+	}
+
+	// Not due yet
+	sender.err = nil
+	if err := s.ProcessOutbox(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatal("retried before the backoff elapsed")
+	}
+
+	makeDue(t, db)
+	if err := s.ProcessOutbox(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].To != "alice@example.com" {
+		t.Fatalf("sent = %v, want the retried message", sender.sent)
+	}
+	if _, err := outbox.Get(ctx, q.ID); !errors.Is(err, ErrQueuedEmailNotFound) {
+		t.Errorf("sent message still queued: %v", err)
+	}
+}
+
+func TestOutboxGivesUp(t *testing.T) {
+	s, sender, outbox, db := testQueueingService(t)
+	ctx := context.Background()
+	sender.err = errors.New("timeout")
+
+	if err := s.SendPasswordReset(ctx, "alice@example.com", "token"); err != nil {
+		t.Fatal(err)
+	}
+	for range outboxRetryDelays {
+		makeDue(t, db)
+		if err := s.ProcessOutbox(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	failed := queued(t, outbox, OutboxStatusFailed)
+	if len(failed) != 1 || failed[0].Attempts != len(outboxRetryDelays)+1 {
+		t.Fatalf("failed = %+v, want one message failed after every attempt", failed)
+	}
+
+	// Failed messages aren't retried on their own
+	sender.err = nil
+	makeDue(t, db)
+	if err := s.ProcessOutbox(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 0 {
+		t.Fatal("failed message was retried")
+	}
+}
+
+func TestOutboxPermanentFailureAndRetry(t *testing.T) {
+	s, sender, outbox, _ := testQueueingService(t)
+	ctx := context.Background()
+	sender.err = &PermanentError{Err: errors.New("550 no such user")}
+
+	if err := s.SendPasswordReset(ctx, "nobody@example.com", "token"); err != nil {
+		t.Fatal(err)
+	}
+	failed := queued(t, outbox, OutboxStatusFailed)
+	if len(failed) != 1 || failed[0].Attempts != 1 {
+		t.Fatalf("failed = %+v, want one message failed on the first attempt", failed)
+	}
+
+	if err := outbox.Retry(ctx, failed[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := outbox.Retry(ctx, failed[0].ID); !errors.Is(err, ErrQueuedEmailNotFound) {
+		t.Errorf("retrying a message that hasn't failed: err = %v", err)
+	}
+
+	sender.err = nil
+	if err := s.ProcessOutbox(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(sender.sent) != 1 {
+		t.Fatalf("sent %d messages after retry, want 1", len(sender.sent))
+	}
+}
+
+func TestOutboxClaim(t *testing.T) {
+	db := testutil.TestDB(t)
+	outbox := NewOutbox(db)
+	ctx := context.Background()
+
+	q, err := outbox.Enqueue(ctx, &Message{To: "alice@example.com", Subject: "Hi", TextBody: "Hi", Headers: map[string]string{"X-Test": "1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Enqueued messages are claimed by the sender
+	if ok, err := outbox.Claim(ctx, q.ID, time.Now()); err != nil || ok {
+		t.Fatalf("claimed a message with an attempt in flight: %v, %v", ok, err)
+	}
+
+	// Once the claim expires another attempt may take over, but only one
+	later := time.Now().Add(outboxClaimTimeout + time.Second)
+	due, err := outbox.ListDue(ctx, later, 10)
+	if err != nil || len(due) != 1 {
+		t.Fatalf("due = %v, %v", due, err)
+	}
+	if due[0].Message.Headers["X-Test"] != "1" {
+		t.Errorf("headers = %v", due[0].Message.Headers)
+	}
+	if ok, err := outbox.Claim(ctx, q.ID, later); err != nil || !ok {
+		t.Fatalf("claim after expiry: %v, %v", ok, err)
+	}
+	if ok, err := outbox.Claim(ctx, q.ID, later); err != nil || ok {
+		t.Fatalf("second claim: %v, %v", ok, err)
+	}
+}
+
+func TestOutboxCleanup(t *testing.T) {
+	s, sender, outbox, db := testQueueingService(t)
+	ctx := context.Background()
+	sender.err = &PermanentError{Err: errors.New("rejected")}
+
+	if err := s.SendPasswordReset(ctx, "alice@example.com", "token"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CleanupOutbox(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(queued(t, outbox, OutboxStatusFailed)); n != 1 {
+		t.Fatalf("recent failure removed, %d left", n)
+	}
+
+	old := time.Now().Add(-outboxFailedRetention - time.Hour).UTC().Format(time.RFC3339)
+	if _, err := db.Exec(`UPDATE email_outbox SET updated_at = ?`, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.CleanupOutbox(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(queued(t, outbox, OutboxStatusFailed)); n != 0 {
+		t.Fatalf("%d expired failures left", n)
+	}
+}
@@ -1,88 +1,313 @@
 package email
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
+	"mime"
+	"mime/quotedprintable"
+	"net"
 	"net/mail"
 	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/enzyme/server/internal/config"
 )
 
+// sendTimeout bounds a single delivery attempt when the context has no
+// earlier deadline.
+const sendTimeout = time.Minute
+
+// Message is an email to send. HTMLBody is optional.
+type Message struct {
+	To       string
+	Subject  string
+	TextBody string
+	HTMLBody string
+	// Headers are added to the standard ones, e.g. List-Unsubscribe
+	Headers map[string]string
+}
+
+// Sender delivers a message, returning a *PermanentError when retrying
+// can't help.
 type Sender interface {
-	Send(ctx context.Context, to, subject, textBody, htmlBody string) error
+	Send(ctx context.Context, msg *Message) error
+}
+
+// PermanentError is a delivery failure that retrying won't fix, such as the
+// mail server rejecting the recipient.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// IsPermanent reports whether err is a delivery failure not worth retrying.
+func IsPermanent(err error) bool {
+	var perr *PermanentError
+	return errors.As(err, &perr)
+}
+
+// NewSender returns the sender for the configured transport.
+func NewSender(cfg config.EmailConfig) (Sender, error) {
+	switch cfg.Transport {
+	case "", "smtp":
+		return NewSMTPSender(cfg)
+	case "http":
+		return NewHTTPSender(cfg)
+	case "sendmail":
+		return NewSendmailSender(cfg)
+	default:
+		return nil, fmt.Errorf("unknown email transport %q", cfg.Transport)
+	}
+}
+
+// composer formats messages from the configured sender address, DKIM
+// signing them when a key is configured.
+type composer struct {
+	from string
+	dkim *dkimSigner // nil when not signing
+}
+
+func newComposer(cfg config.EmailConfig) (composer, error) {
+	dkim, err := newDKIMSigner(cfg.DKIM)
+	if err != nil {
+		return composer{}, err
+	}
+	return composer{from: cfg.From, dkim: dkim}, nil
+}
+
+// compose returns the message as sent, with CRLF line endings.
+func (c *composer) compose(m *Message) ([]byte, error) {
+	msg, err := buildMessage(c.from, m)
+	if err != nil {
+		return nil, err
+	}
+	if c.dkim != nil {
+		return c.dkim.sign(msg)
+	}
+	return msg, nil
+}
+
+// envelopeFrom returns the bare address of the sender, since from may
+// contain a display name.
+func (c *composer) envelopeFrom() string {
+	if parsed, err := mail.ParseAddress(c.from); err == nil {
+		return parsed.Address
+	}
+	return c.from
 }
 
+// SMTPSender delivers mail to an SMTP server. Depending on the TLS mode it
+// connects over TLS (implicit, the default on port 465), upgrades with
+// STARTTLS (starttls requires it, auto uses it when offered) or sends in the
+// clear (none).
 type SMTPSender struct {
 	host     string
 	port     int
 	username string
 	password string
-	from     string
+	tls      string
+	composer composer
 }
 
-func NewSMTPSender(cfg config.EmailConfig) *SMTPSender {
+func NewSMTPSender(cfg config.EmailConfig) (*SMTPSender, error) {
+	c, err := newComposer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	mode := cfg.TLS
+	if mode == "" || mode == "auto" {
+		mode = "auto"
+		if cfg.Port == 465 {
+			mode = "implicit"
+		}
+	}
 	return &SMTPSender{
 		host:     cfg.Host,
 		port:     cfg.Port,
 		username: cfg.Username,
 		password: cfg.Password,
-		from:     cfg.From,
+		tls:      mode,
+		composer: c,
+	}, nil
+}
+
+func (s *SMTPSender) Send(ctx context.Context, m *Message) error {
+	msg, err := s.composer.compose(m)
+	if err != nil {
+		return err
 	}
+	if err := s.send(ctx, m.To, msg); err != nil {
+		return smtpError(err)
+	}
+	return nil
 }
 
-func (s *SMTPSender) Send(ctx context.Context, to, subject, textBody, htmlBody string) error {
-	addr := fmt.Sprintf("%s:%d", s.host, s.port)
+func (s *SMTPSender) send(ctx context.Context, to string, msg []byte) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, sendTimeout)
+		defer cancel()
+	}
+	deadline, _ := ctx.Deadline()
 
-	var auth smtp.Auth
-	if s.username != "" {
-		auth = smtp.PlainAuth("", s.username, s.password, s.host)
-	}
-
-	// Build message
-	msg := fmt.Sprintf("From: %s\r\n", s.from)
-	msg += fmt.Sprintf("To: %s\r\n", to)
-	msg += fmt.Sprintf("Subject: %s\r\n", subject)
-
-	if htmlBody != "" {
-		msg += "MIME-Version: 1.0\r\n"
-		msg += "Content-Type: multipart/alternative; boundary=\"boundary\"\r\n"
-		msg += "\r\n"
-		msg += "--boundary\r\n"
-		msg += "Content-Type: text/plain; charset=\"utf-8\"\r\n"
-		msg += "\r\n"
-		msg += textBody + "\r\n"
-		msg += "--boundary\r\n"
-		msg += "Content-Type: text/html; charset=\"utf-8\"\r\n"
-		msg += "\r\n"
-		msg += htmlBody + "\r\n"
-		msg += "--boundary--\r\n"
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	tlsConfig := &tls.Config{ServerName: s.host}
+	var conn net.Conn
+	var err error
+	if s.tls == "implicit" {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
 	} else {
-		msg += "Content-Type: text/plain; charset=\"utf-8\"\r\n"
-		msg += "\r\n"
-		msg += textBody + "\r\n"
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return err
 	}
+	_ = conn.SetDeadline(deadline)
 
-	// Extract bare email for SMTP envelope sender (s.from may contain display name)
-	envelopeFrom := s.from
-	if parsed, err := mail.ParseAddress(s.from); err == nil {
-		envelopeFrom = parsed.Address
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		_ = conn.Close()
+		return err
 	}
+	defer c.Close()
 
-	err := smtp.SendMail(addr, auth, envelopeFrom, []string{to}, []byte(msg))
+	if s.tls == "auto" || s.tls == "starttls" {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return err
+			}
+		} else if s.tls == "starttls" {
+			return errors.New("smtp server does not support STARTTLS")
+		}
+	}
+	if s.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(s.composer.envelopeFrom()); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
 	if err != nil {
-		slog.Error("failed to send email", "component", "email", "to", to, "error", err)
 		return err
 	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// smtpError marks rejections with a 5xx reply code as permanent.
+func smtpError(err error) error {
+	var perr *textproto.Error
+	if errors.As(err, &perr) && perr.Code >= 500 {
+		return &PermanentError{Err: err}
+	}
+	return err
+}
+
+// buildMessage formats a message with its headers, as a multipart message
+// when it has an HTML body. Bodies are quoted-printable so that relays don't
+// re-encode them and break a DKIM signature.
+func buildMessage(from string, m *Message) ([]byte, error) {
+	// Random IDs for the Message-ID and boundary, since message content may
+	// appear in the bodies
+	rnd := make([]byte, 24)
+	if _, err := rand.Read(rnd); err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@%s>\r\n", hex.EncodeToString(rnd[:12]), messageIDDomain(from))
+
+	names := make([]string, 0, len(m.Headers))
+	for name := range m.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, m.Headers[name])
+	}
+	b.WriteString("MIME-Version: 1.0\r\n")
 
-	slog.Info("sent email", "component", "email", "to", to, "subject", subject)
+	if m.HTMLBody == "" {
+		if err := writePart(&b, "text/plain", m.TextBody); err != nil {
+			return nil, err
+		}
+		return b.Bytes(), nil
+	}
+
+	boundary := "enzyme-" + hex.EncodeToString(rnd[12:])
+	fmt.Fprintf(&b, "Content-Type: multipart/alternative; boundary=\"%s\"\r\n", boundary)
+	b.WriteString("\r\n")
+	b.WriteString("--" + boundary + "\r\n")
+	if err := writePart(&b, "text/plain", m.TextBody); err != nil {
+		return nil, err
+	}
+	b.WriteString("--" + boundary + "\r\n")
+	if err := writePart(&b, "text/html", m.HTMLBody); err != nil {
+		return nil, err
+	}
+	b.WriteString("--" + boundary + "--\r\n")
+	return b.Bytes(), nil
+}
+
+// writePart writes the content headers and quoted-printable body of a text
+// part, ending with a line break.
+func writePart(b *bytes.Buffer, contentType, body string) error {
+	fmt.Fprintf(b, "Content-Type: %s; charset=\"utf-8\"\r\n", contentType)
+	b.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	b.WriteString("\r\n")
+	qp := quotedprintable.NewWriter(b)
+	if _, err := qp.Write([]byte(strings.ReplaceAll(body, "\r\n", "\n"))); err != nil {
+		return err
+	}
+	if err := qp.Close(); err != nil {
+		return err
+	}
+	b.WriteString("\r\n")
 	return nil
 }
 
+// messageIDDomain returns the domain of the sender address, for generating
+// Message-IDs.
+func messageIDDomain(from string) string {
+	addr := from
+	if parsed, err := mail.ParseAddress(from); err == nil {
+		addr = parsed.Address
+	}
+	if i := strings.LastIndexByte(addr, '@'); i >= 0 && i < len(addr)-1 {
+		return addr[i+1:]
+	}
+	return "enzyme.localhost"
+}
+
 type NoOpSender struct{}
 
-func (s *NoOpSender) Send(ctx context.Context, to, subject, textBody, htmlBody string) error {
-	slog.Debug("would send email", "component", "email", "to", to, "subject", subject)
+func (s *NoOpSender) Send(ctx context.Context, msg *Message) error {
+	slog.Debug("would send email", "component", "email", "to", msg.To, "subject", msg.Subject)
 	return nil
 }
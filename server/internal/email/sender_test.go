@@ -0,0 +1,207 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/config"
+	"github.com/enzyme/server/internal/inbound"
+)
+
+// recordingInbox accepts mail for addresses at example.com and records it.
+type recordingInbox struct {
+	mu        sync.Mutex
+	delivered map[string]*inbound.Message
+}
+
+func (r *recordingInbox) Accepts(rcpt string) bool {
+	return strings.HasSuffix(rcpt, "@example.com")
+}
+
+func (r *recordingInbox) Deliver(ctx context.Context, rcpt string, msg *inbound.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.delivered == nil {
+		r.delivered = make(map[string]*inbound.Message)
+	}
+	r.delivered[rcpt] = msg
+	return nil
+}
+
+// startSMTPServer runs an SMTP server without TLS, returning its port.
+func startSMTPServer(t *testing.T, inbox *recordingInbox) int {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := inbound.NewServer(l.Addr().String(), "example.com", 1<<20, inbox)
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	})
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestSMTPSender(t *testing.T) {
+	inbox := &recordingInbox{}
+	cfg := config.EmailConfig{Host: "127.0.0.1", Port: startSMTPServer(t, inbox), TLS: "none", From: "Enzyme <enzyme@example.org>"}
+	sender, err := NewSMTPSender(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = sender.Send(context.Background(), &Message{
+		To:       "alice@example.com",
+		Subject:  "Grüße",
+		TextBody: "Hello Alice,\n\n" + strings.Repeat("a long line ", 20) + "\n",
+		HTMLBody: "<p>Hello Alice</p>",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	msg := inbox.delivered["alice@example.com"]
+	if msg == nil {
+		t.Fatal("message not delivered")
+	}
+	if msg.From != "enzyme@example.org" || msg.Subject != "Grüße" {
+		t.Errorf("from, subject = %q, %q", msg.From, msg.Subject)
+	}
+	if !strings.Contains(msg.Text, strings.Repeat("a long line ", 20)) {
+		t.Errorf("text = %q, want the long line intact", msg.Text)
+	}
+
+	err = sender.Send(context.Background(), &Message{To: "bob@example.net", Subject: "Hi", TextBody: "Hi"})
+	if err == nil || !IsPermanent(err) {
+		t.Fatalf("rejected recipient: err = %v, want a permanent error", err)
+	}
+}
+
+func TestSMTPSenderStartTLSRequired(t *testing.T) {
+	cfg := config.EmailConfig{Host: "127.0.0.1", Port: startSMTPServer(t, &recordingInbox{}), TLS: "starttls", From: "enzyme@example.org"}
+	sender, err := NewSMTPSender(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sender.Send(context.Background(), &Message{To: "alice@example.com", Subject: "Hi", TextBody: "Hi"})
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("err = %v, want STARTTLS error", err)
+	}
+	if IsPermanent(err) {
+		t.Error("missing STARTTLS should be retried")
+	}
+}
+
+func TestSMTPSenderTLSMode(t *testing.T) {
+	for _, tt := range []struct {
+		tls  string
+		port int
+		want string
+	}{
+		{"", 587, "auto"},
+		{"auto", 465, "implicit"},
+		{"starttls", 465, "starttls"},
+		{"none", 25, "none"},
+	} {
+		s, err := NewSMTPSender(config.EmailConfig{Host: "smtp.example.com", Port: tt.port, TLS: tt.tls})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.tls != tt.want {
+			t.Errorf("tls %q on port %d = %q, want %q", tt.tls, tt.port, s.tls, tt.want)
+		}
+	}
+}
+
+func TestHTTPSender(t *testing.T) {
+	var got httpSendRequest
+	status := http.StatusAccepted
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sender, err := NewHTTPSender(config.EmailConfig{
+		From: "Enzyme <enzyme@example.org>",
+		HTTP: config.HTTPEmailConfig{URL: srv.URL, Token: "secret"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := &Message{To: "alice@example.com", Subject: "Hi", TextBody: "Hello"}
+	if err := sender.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if got.From != "enzyme@example.org" || len(got.To) != 1 || got.To[0] != "alice@example.com" || got.Subject != "Hi" {
+		t.Errorf("request = %+v", got)
+	}
+	if !strings.Contains(string(got.Raw), "From: Enzyme <enzyme@example.org>\r\n") {
+		t.Errorf("raw message missing From header:\n%s", got.Raw)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := sender.Send(context.Background(), msg); err == nil || IsPermanent(err) {
+		t.Errorf("503: err = %v, want a temporary error", err)
+	}
+	status = http.StatusUnprocessableEntity
+	if err := sender.Send(context.Background(), msg); err == nil || !IsPermanent(err) {
+		t.Errorf("422: err = %v, want a permanent error", err)
+	}
+}
+
+func TestSendmailSender(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "sendmail")
+	body := "#!/bin/sh\necho \"$@\" > " + out + ".args\ncat > " + out + "\n" +
+		"case \"$5\" in nobody@*) exit 67;; busy@*) exit 75;; esac\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	sender, err := NewSendmailSender(config.EmailConfig{
+		From:     "Enzyme <enzyme@example.org>",
+		Sendmail: config.SendmailConfig{Path: script, Args: []string{"-i"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sender.Send(context.Background(), &Message{To: "alice@example.com", Subject: "Hi", TextBody: "Hello"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	args, _ := os.ReadFile(out + ".args")
+	if got := strings.TrimSpace(string(args)); got != "-i -f enzyme@example.org -- alice@example.com" {
+		t.Errorf("args = %q", got)
+	}
+	msg, _ := os.ReadFile(out)
+	if !strings.Contains(string(msg), "Subject: Hi\n") || strings.Contains(string(msg), "\r") {
+		t.Errorf("message should use LF line endings:\n%q", msg)
+	}
+
+	err = sender.Send(context.Background(), &Message{To: "nobody@example.com", Subject: "Hi", TextBody: "Hello"})
+	if err == nil || !IsPermanent(err) {
+		t.Errorf("EX_NOUSER: err = %v, want a permanent error", err)
+	}
+	err = sender.Send(context.Background(), &Message{To: "busy@example.com", Subject: "Hi", TextBody: "Hello"})
+	if err == nil || IsPermanent(err) {
+		t.Errorf("EX_TEMPFAIL: err = %v, want a temporary error", err)
+	}
+}
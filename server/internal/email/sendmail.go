@@ -0,0 +1,69 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/enzyme/server/internal/config"
+)
+
+// Exit statuses from sysexits.h that sendmail uses for undeliverable mail.
+// Other failures, such as EX_TEMPFAIL, are retried.
+const (
+	exDataErr = 65
+	exNoUser  = 67
+	exNoHost  = 68
+)
+
+// SendmailSender delivers mail by piping it to a local sendmail-compatible
+// program, which is run as
+//
+//	<path> <args...> -f <sender> -- <recipient>
+//
+// with the message on stdin.
+type SendmailSender struct {
+	path     string
+	args     []string
+	composer composer
+}
+
+func NewSendmailSender(cfg config.EmailConfig) (*SendmailSender, error) {
+	c, err := newComposer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &SendmailSender{path: cfg.Sendmail.Path, args: cfg.Sendmail.Args, composer: c}, nil
+}
+
+func (s *SendmailSender) Send(ctx context.Context, m *Message) error {
+	msg, err := s.composer.compose(m)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	args := append(append([]string{}, s.args...), "-f", s.composer.envelopeFrom(), "--", m.To)
+	cmd := exec.CommandContext(ctx, s.path, args...)
+	// Local mail programs expect native line endings
+	cmd.Stdin = bytes.NewReader(bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n")))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		err = fmt.Errorf("%s: %w: %s", s.path, err, bytes.TrimSpace(stderr.Bytes()))
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			switch exitErr.ExitCode() {
+			case exDataErr, exNoUser, exNoHost:
+				return &PermanentError{Err: err}
+			}
+		}
+		return err
+	}
+	return nil
+}
@@ -7,58 +7,292 @@ import (
 	"html/template"
 	"log/slog"
 	"net/url"
+	"strings"
+	"sync"
+	texttemplate "text/template"
+	"time"
 
 	"github.com/enzyme/server/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 //go:embed templates/*.html templates/*.txt
 var templateFS embed.FS
 
+// Pre-computed metric attribute sets for send results.
+var (
+	sendAttrsSent   = metric.WithAttributes(attribute.String("result", "sent"))
+	sendAttrsFailed = metric.WithAttributes(attribute.String("result", "failed"))
+)
+
+// outboxFailedRetention is how long messages that couldn't be delivered are
+// kept for admins to inspect and retry.
+const outboxFailedRetention = 30 * 24 * time.Hour
+
 type Service struct {
-	sender    Sender
-	templates *template.Template
-	publicURL string
-	enabled   bool
+	mu     sync.RWMutex
+	sender Sender
+	// outbox queues messages for delivery with retries, or is nil to send
+	// synchronously
+	outbox        *Outbox
+	templates     *template.Template
+	textTemplates *texttemplate.Template
+	publicURL     string
+	enabled       bool
+	// inboundDomain is the domain mail is received on, or empty when the
+	// server doesn't receive mail
+	inboundDomain string
+
+	// OTel metrics (no-op when telemetry is disabled)
+	sends metric.Int64Counter
 }
 
+const (
+	// ReplyAddressPrefix starts the local part of the addresses replies to
+	// a notification email are sent to, reply+<token>@domain.
+	ReplyAddressPrefix = "reply+"
+	// ChannelAddressPrefix starts the local part of channels' email
+	// addresses, channel+<token>@domain.
+	ChannelAddressPrefix = "channel+"
+)
+
 func NewService(cfg config.EmailConfig, publicURL string) (*Service, error) {
-	var sender Sender
+	var sender Sender = &NoOpSender{}
 	if cfg.Enabled {
-		sender = NewSMTPSender(cfg)
-	} else {
-		sender = &NoOpSender{}
+		var err error
+		sender, err = NewSender(cfg)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	templates, err := template.ParseFS(templateFS, "templates/*.html", "templates/*.txt")
+	templates, textTemplates, err := parseTemplates()
 	if err != nil {
-		// Templates might not exist yet, create empty template
-		templates = template.New("empty")
+		return nil, err
 	}
 
-	return &Service{
-		sender:    sender,
-		templates: templates,
-		publicURL: publicURL,
-		enabled:   cfg.Enabled,
-	}, nil
+	s := &Service{
+		sender:        sender,
+		templates:     templates,
+		textTemplates: textTemplates,
+		publicURL:     publicURL,
+		enabled:       cfg.Enabled,
+		sends:         newSendsCounter(),
+	}
+	if cfg.Enabled && cfg.Inbound.Enabled {
+		s.inboundDomain = cfg.Inbound.Domain
+	}
+	return s, nil
+}
+
+func newSendsCounter() metric.Int64Counter {
+	sends, err := otel.Meter("enzyme.email").Int64Counter("email.sends",
+		metric.WithDescription("Email delivery attempts by result"),
+	)
+	if err != nil {
+		slog.Error("failed to create email.sends metric", "error", err)
+	}
+	return sends
+}
+
+// SetOutbox makes the service queue messages in the outbox rather than
+// sending them synchronously. Call before sending anything.
+func (s *Service) SetOutbox(o *Outbox) {
+	s.outbox = o
+}
+
+// parseTemplates parses the HTML templates, and the plain text ones without
+// HTML escaping
+func parseTemplates() (*template.Template, *texttemplate.Template, error) {
+	templates, err := template.New("").Funcs(template.FuncMap(templateFuncs)).ParseFS(templateFS, "templates/*.html")
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing email templates: %w", err)
+	}
+	textTemplates, err := texttemplate.New("").Funcs(templateFuncs).ParseFS(templateFS, "templates/*.txt")
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing email templates: %w", err)
+	}
+	return templates, textTemplates, nil
 }
 
 func (s *Service) IsEnabled() bool {
 	return s.enabled
 }
 
+// InboundEnabled reports whether the server receives mail
+func (s *Service) InboundEnabled() bool {
+	return s.inboundDomain != ""
+}
+
+// ReplyAddress returns the address for replying by email with a signed reply
+// token, or "" when replies aren't received.
+func (s *Service) ReplyAddress(token string) string {
+	if s.inboundDomain == "" {
+		return ""
+	}
+	return ReplyAddressPrefix + token + "@" + s.inboundDomain
+}
+
+// ChannelAddress returns the email address of a channel with the given
+// token, or "" when mail isn't received.
+func (s *Service) ChannelAddress(token string) string {
+	if s.inboundDomain == "" {
+		return ""
+	}
+	return ChannelAddressPrefix + token + "@" + s.inboundDomain
+}
+
+// Reconfigure replaces the transport settings used for subsequent emails,
+// keeping the current ones if the new settings can't be loaded. Enabling or
+// disabling email requires a restart, so it does nothing when email is
+// disabled.
+func (s *Service) Reconfigure(cfg config.EmailConfig) {
+	if !s.enabled {
+		return
+	}
+	sender, err := NewSender(cfg)
+	if err != nil {
+		slog.Error("failed to reconfigure email, keeping the previous settings", "component", "email", "error", err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sender = sender
+}
+
+// send queues a message and makes a first delivery attempt right away.
+// Failed attempts are retried by ProcessOutbox, so only failing to queue
+// the message is returned.
+func (s *Service) send(ctx context.Context, msg *Message) error {
+	if s.outbox == nil {
+		return s.deliver(ctx, msg)
+	}
+	q, err := s.outbox.Enqueue(ctx, msg)
+	if err != nil {
+		return fmt.Errorf("queueing email: %w", err)
+	}
+	s.attempt(ctx, q)
+	return nil
+}
+
+// deliver sends a message through the current transport.
+func (s *Service) deliver(ctx context.Context, msg *Message) error {
+	s.mu.RLock()
+	sender := s.sender
+	s.mu.RUnlock()
+
+	if err := sender.Send(ctx, msg); err != nil {
+		slog.Error("failed to send email", "component", "email", "to", msg.To, "error", err)
+		s.recordSend(ctx, sendAttrsFailed)
+		return err
+	}
+	s.recordSend(ctx, sendAttrsSent)
+	slog.Info("sent email", "component", "email", "to", msg.To, "subject", msg.Subject)
+	return nil
+}
+
+// attempt delivers a claimed outbox message, removing it once sent or
+// recording the failure so it is retried later.
+func (s *Service) attempt(ctx context.Context, q *QueuedEmail) {
+	sendErr := s.deliver(ctx, &q.Message)
+	// Record the outcome even if the caller's deadline ran out mid-send
+	ctx = context.WithoutCancel(ctx)
+	if sendErr == nil {
+		if err := s.outbox.MarkSent(ctx, q.ID); err != nil {
+			slog.Error("failed to remove sent email from outbox", "component", "email", "id", q.ID, "error", err)
+		}
+		return
+	}
+
+	failed, err := s.outbox.RecordFailure(ctx, q, sendErr)
+	switch {
+	case err != nil:
+		slog.Error("failed to record email delivery failure", "component", "email", "id", q.ID, "error", err)
+	case failed:
+		slog.Error("giving up on email", "component", "email", "id", q.ID, "to", q.Message.To, "attempts", q.Attempts)
+	default:
+		slog.Warn("email will be retried", "component", "email", "id", q.ID, "to", q.Message.To, "retry_at", q.NextAttemptAt)
+	}
+}
+
+// ProcessOutbox retries queued messages whose next attempt is due.
+func (s *Service) ProcessOutbox(ctx context.Context) error {
+	if s.outbox == nil {
+		return nil
+	}
+	due, err := s.outbox.ListDue(ctx, time.Now(), 50)
+	if err != nil {
+		return err
+	}
+	for i := range due {
+		claimed, err := s.outbox.Claim(ctx, due[i].ID, time.Now())
+		if err != nil {
+			return err
+		}
+		if claimed {
+			s.attempt(ctx, &due[i])
+		}
+	}
+	return nil
+}
+
+// CleanupOutbox removes failed messages once they are past retention.
+func (s *Service) CleanupOutbox(ctx context.Context) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.DeleteFailedBefore(ctx, time.Now().Add(-outboxFailedRetention))
+}
+
+func (s *Service) recordSend(ctx context.Context, attrs metric.MeasurementOption) {
+	if s.sends != nil {
+		s.sends.Add(ctx, 1, attrs)
+	}
+}
+
+// render executes the text and HTML templates with the given base name
+func (s *Service) render(name string, data any) (textBody, htmlBody string, err error) {
+	var text, html strings.Builder
+	if err := s.textTemplates.ExecuteTemplate(&text, name+".txt", data); err != nil {
+		return "", "", fmt.Errorf("rendering %s.txt: %w", name, err)
+	}
+	if err := s.templates.ExecuteTemplate(&html, name+".html", data); err != nil {
+		return "", "", fmt.Errorf("rendering %s.html: %w", name, err)
+	}
+	return text.String(), html.String(), nil
+}
+
 // NewTestService creates an email service for testing with a NoOpSender.
 // Use enabled=true to test email-enabled code paths without real SMTP.
 func NewTestService(enabled bool, publicURL string) *Service {
-	templates, _ := template.ParseFS(templateFS, "templates/*.html", "templates/*.txt")
+	return NewTestServiceWithSender(enabled, publicURL, &NoOpSender{})
+}
+
+// NewTestServiceWithSender creates an email service for testing that sends
+// through the given sender.
+func NewTestServiceWithSender(enabled bool, publicURL string, sender Sender) *Service {
+	templates, textTemplates, err := parseTemplates()
+	if err != nil {
+		panic(err)
+	}
 	return &Service{
-		sender:    &NoOpSender{},
-		templates: templates,
-		publicURL: publicURL,
-		enabled:   enabled,
+		sender:        sender,
+		templates:     templates,
+		textTemplates: textTemplates,
+		publicURL:     publicURL,
+		enabled:       enabled,
+		sends:         newSendsCounter(),
 	}
 }
 
+// WithInboundDomain makes a test service receive mail on domain.
+func (s *Service) WithInboundDomain(domain string) *Service {
+	s.inboundDomain = domain
+	return s
+}
+
 type InviteEmailData struct {
 	WorkspaceName string
 	InviterName   string
@@ -75,7 +309,7 @@ func (s *Service) SendWorkspaceInvite(ctx context.Context, to string, data Invit
 	body := "You've been invited to join " + data.WorkspaceName + " on Enzyme.\n\n"
 	body += "Click here to accept: " + data.InviteURL + "\n"
 
-	return s.sender.Send(ctx, to, subject, body, "")
+	return s.send(ctx, &Message{To: to, Subject: subject, TextBody: body})
 }
 
 func (s *Service) SendPasswordReset(ctx context.Context, to string, token string) error {
@@ -91,7 +325,7 @@ func (s *Service) SendPasswordReset(ctx context.Context, to string, token string
 	body += "Click here to reset: " + resetURL + "\n\n"
 	body += "If you didn't request this, you can ignore this email.\n"
 
-	return s.sender.Send(ctx, to, subject, body, "")
+	return s.send(ctx, &Message{To: to, Subject: subject, TextBody: body})
 }
 
 func (s *Service) SendEmailVerification(ctx context.Context, to string, token string) error {
@@ -106,59 +340,199 @@ func (s *Service) SendEmailVerification(ctx context.Context, to string, token st
 	body := "Please verify your email address by clicking the link below:\n\n"
 	body += verifyURL + "\n"
 
-	return s.sender.Send(ctx, to, subject, body, "")
+	return s.send(ctx, &Message{To: to, Subject: subject, TextBody: body})
 }
 
-// NotificationDigestItem represents a single notification in a digest
-type NotificationDigestItem struct {
-	ChannelName string
-	SenderName  string
-	Preview     string
-	Type        string
+// RegistrationPendingData describes an account awaiting a site admin's
+// approval.
+type RegistrationPendingData struct {
+	DisplayName string
+	Email       string
+}
+
+func (s *Service) SendRegistrationPending(ctx context.Context, to string, data RegistrationPendingData) error {
+	if !s.enabled {
+		slog.Debug("would send registration pending", "component", "email", "to", to, "email", data.Email)
+		return nil
+	}
+
+	subject := data.DisplayName + " is waiting for approval on Enzyme"
+	body := data.DisplayName + " (" + data.Email + ") has signed up and is waiting for a site admin to approve their account.\n\n"
+	body += "Review pending accounts: " + s.publicURL + "\n"
+
+	return s.send(ctx, &Message{To: to, Subject: subject, TextBody: body})
+}
+
+func (s *Service) SendAccountApproved(ctx context.Context, to string) error {
+	if !s.enabled {
+		slog.Debug("would send account approved", "component", "email", "to", to)
+		return nil
+	}
+
+	subject := "Your Enzyme account has been approved"
+	body := "A site admin has approved your account. You can now sign in:\n\n"
+	body += s.publicURL + "/login\n"
+
+	return s.send(ctx, &Message{To: to, Subject: subject, TextBody: body})
+}
+
+// DigestMessage is a message a digest notifies about
+type DigestMessage struct {
+	SenderName string
+	AvatarURL  string // absolute, or empty if the sender has none
+	Text       string // with mentions rendered as display names
+	URL        string // deep link to the message
+	Type       string // notification type, e.g. "mention"
+	ReplyTo    string // address to reply to the message by email, or empty
+}
+
+// DigestThread is a thread's replies in a digest
+type DigestThread struct {
+	URL      string
+	Messages []DigestMessage
+}
+
+// DigestChannel is a channel's messages in a digest, with thread replies
+// grouped by thread
+type DigestChannel struct {
+	Name     string // e.g. "#general" or "Direct message"
+	URL      string
+	Messages []DigestMessage
+	Threads  []DigestThread
+}
+
+// DigestWorkspace is a workspace's channels in a digest
+type DigestWorkspace struct {
+	Name     string
+	URL      string
+	Channels []DigestChannel
 }
 
 // NotificationDigestData contains data for notification digest emails
 type NotificationDigestData struct {
-	WorkspaceName string
-	Items         []NotificationDigestItem
-	WorkspaceURL  string
+	Workspaces []DigestWorkspace
+	// UnsubscribeToken is a signed token identifying the recipient for the
+	// one-click unsubscribe links
+	UnsubscribeToken string
+}
+
+// Count returns the number of messages in the digest
+func (d NotificationDigestData) Count() int {
+	count := 0
+	for _, ws := range d.Workspaces {
+		for _, ch := range ws.Channels {
+			count += len(ch.Messages)
+			for _, th := range ch.Threads {
+				count += len(th.Messages)
+			}
+		}
+	}
+	return count
+}
+
+// first returns the first message in the digest
+func (d NotificationDigestData) first() DigestMessage {
+	for _, ws := range d.Workspaces {
+		for _, ch := range ws.Channels {
+			if len(ch.Messages) > 0 {
+				return ch.Messages[0]
+			}
+			for _, th := range ch.Threads {
+				if len(th.Messages) > 0 {
+					return th.Messages[0]
+				}
+			}
+		}
+	}
+	return DigestMessage{}
+}
+
+// notificationDigestView is what the digest templates render
+type notificationDigestView struct {
+	NotificationDigestData
+	Count          int
+	SettingsURL    string
+	UnsubscribeURL string
 }
 
 func (s *Service) SendNotificationDigest(ctx context.Context, to string, data NotificationDigestData) error {
+	count := data.Count()
 	if !s.enabled {
-		slog.Debug("would send notification digest", "component", "email", "to", to, "count", len(data.Items), "workspace", data.WorkspaceName)
+		slog.Debug("would send notification digest", "component", "email", "to", to, "count", count, "workspaces", len(data.Workspaces))
 		return nil
 	}
 
-	count := len(data.Items)
 	subject := ""
+	switch {
+	case len(data.Workspaces) > 1:
+		subject = fmt.Sprintf("%d new notifications in %d workspaces", count, len(data.Workspaces))
+	case count == 1:
+		subject = "1 new notification in " + data.Workspaces[0].Name
+	default:
+		subject = fmt.Sprintf("%d new notifications in %s", count, data.Workspaces[0].Name)
+	}
+
+	query := url.Values{"token": {data.UnsubscribeToken}}.Encode()
+	textBody, htmlBody, err := s.render("notification_digest", notificationDigestView{
+		NotificationDigestData: data,
+		Count:                  count,
+		SettingsURL:            s.publicURL,
+		UnsubscribeURL:         s.publicURL + "/unsubscribe?" + query,
+	})
+	if err != nil {
+		return err
+	}
+
+	// One-click unsubscribe (RFC 8058): mail clients POST to the API
+	// directly, while the link in the body opens a confirmation page
+	headers := map[string]string{
+		"List-Unsubscribe":      "<" + s.publicURL + "/api/email/unsubscribe?" + query + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	}
+	// Replying to a digest of one message answers that message; longer
+	// digests link a reply address per message instead
 	if count == 1 {
-		subject = "1 new notification in " + data.WorkspaceName
-	} else {
-		subject = fmt.Sprintf("%d new notifications in %s", count, data.WorkspaceName)
+		if replyTo := data.first().ReplyTo; replyTo != "" {
+			headers["Reply-To"] = replyTo
+		}
 	}
 
-	// Build plain text body
-	body := "You have new notifications in " + data.WorkspaceName + ":\n\n"
-	for _, item := range data.Items {
-		prefix := ""
-		switch item.Type {
+	return s.send(ctx, &Message{
+		To:       to,
+		Subject:  subject,
+		TextBody: textBody,
+		HTMLBody: htmlBody,
+		Headers:  headers,
+	})
+}
+
+// templateFuncs are available to every email template
+var templateFuncs = map[string]any{
+	"typeLabel": func(notificationType string) string {
+		switch notificationType {
 		case "mention":
-			prefix = "[Mentioned] "
+			return "Mentioned"
 		case "dm":
-			prefix = "[DM] "
+			return "DM"
 		case "channel":
-			prefix = "[@channel] "
+			return "@channel"
 		case "here":
-			prefix = "[@here] "
+			return "@here"
 		case "everyone":
-			prefix = "[@everyone] "
+			return "@everyone"
+		case "thread_reply":
+			return "Thread"
+		case "keyword":
+			return "Keyword"
 		}
-		body += prefix + item.SenderName + " in #" + item.ChannelName + ": " + item.Preview + "\n"
-	}
-	body += "\nOpen Enzyme: " + data.WorkspaceURL + "\n"
-
-	return s.sender.Send(ctx, to, subject, body, "")
+		return ""
+	},
+	"initial": func(name string) string {
+		for _, r := range name {
+			return strings.ToUpper(string(r))
+		}
+		return "?"
+	},
 }
 
 // GetPublicURL returns the public URL for the service
@@ -34,7 +34,7 @@ func (r *Repository) Create(ctx context.Context, e *CustomEmoji) error {
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`, e.ID, e.WorkspaceID, e.Name, e.CreatedBy, e.ContentType, e.SizeBytes, e.StoragePath, e.CreatedAt.Format(time.RFC3339))
 	if err != nil {
-		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") || strings.Contains(err.Error(), "duplicate key") {
 			return ErrEmojiNameTaken
 		}
 		return err
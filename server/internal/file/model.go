@@ -14,4 +14,17 @@ type Attachment struct {
 	SizeBytes   int64     `json:"size_bytes"`
 	StoragePath string    `json:"-"`
 	CreatedAt   time.Time `json:"created_at"`
+
+	// ScanStatus is empty when the file was uploaded with scanning disabled.
+	ScanStatus    string     `json:"scan_status,omitempty"`
+	ScanSignature *string    `json:"scan_signature,omitempty"`
+	ScannedAt     *time.Time `json:"scanned_at,omitempty"`
 }
+
+// Antivirus scan statuses
+const (
+	ScanStatusPending  = "pending"
+	ScanStatusClean    = "clean"
+	ScanStatusInfected = "infected"
+	ScanStatusError    = "error"
+)
@@ -14,6 +14,9 @@ var (
 	ErrAttachmentNotFound = errors.New("attachment not found")
 )
 
+const attachmentColumns = `id, message_id, channel_id, user_id, filename, content_type, size_bytes, storage_path, created_at,
+		scan_status, scan_signature, scanned_at`
+
 type Repository struct {
 	db *sql.DB
 }
@@ -26,38 +29,30 @@ func (r *Repository) Create(ctx context.Context, attachment *Attachment) error {
 	attachment.ID = ulid.Make().String()
 	attachment.CreatedAt = time.Now().UTC()
 
+	var scanStatus *string
+	if attachment.ScanStatus != "" {
+		scanStatus = &attachment.ScanStatus
+	}
+
 	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO attachments (id, message_id, channel_id, user_id, filename, content_type, size_bytes, storage_path, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, attachment.ID, attachment.MessageID, attachment.ChannelID, attachment.UserID, attachment.Filename, attachment.ContentType, attachment.SizeBytes, attachment.StoragePath, attachment.CreatedAt.Format(time.RFC3339))
+		INSERT INTO attachments (id, message_id, channel_id, user_id, filename, content_type, size_bytes, storage_path, created_at, scan_status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, attachment.ID, attachment.MessageID, attachment.ChannelID, attachment.UserID, attachment.Filename, attachment.ContentType, attachment.SizeBytes, attachment.StoragePath, attachment.CreatedAt.Format(time.RFC3339), scanStatus)
 	return err
 }
 
 func (r *Repository) GetByID(ctx context.Context, id string) (*Attachment, error) {
-	var a Attachment
-	var messageID, userID sql.NullString
-	var createdAt string
-
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, message_id, channel_id, user_id, filename, content_type, size_bytes, storage_path, created_at
+	a, err := scanAttachment(r.db.QueryRowContext(ctx, `
+		SELECT `+attachmentColumns+`
 		FROM attachments WHERE id = ?
-	`, id).Scan(&a.ID, &messageID, &a.ChannelID, &userID, &a.Filename, &a.ContentType, &a.SizeBytes, &a.StoragePath, &createdAt)
+	`, id))
 	if err == sql.ErrNoRows {
 		return nil, ErrAttachmentNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
-
-	if messageID.Valid {
-		a.MessageID = &messageID.String
-	}
-	if userID.Valid {
-		a.UserID = &userID.String
-	}
-	a.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-
-	return &a, nil
+	return a, nil
 }
 
 func (r *Repository) Delete(ctx context.Context, id string) error {
@@ -74,7 +69,7 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 
 func (r *Repository) ListForMessage(ctx context.Context, messageID string) ([]Attachment, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, message_id, channel_id, user_id, filename, content_type, size_bytes, storage_path, created_at
+		SELECT `+attachmentColumns+`
 		FROM attachments WHERE message_id = ?
 	`, messageID)
 	if err != nil {
@@ -84,24 +79,11 @@ func (r *Repository) ListForMessage(ctx context.Context, messageID string) ([]At
 
 	var attachments []Attachment
 	for rows.Next() {
-		var a Attachment
-		var msgID, userID sql.NullString
-		var createdAt string
-
-		err := rows.Scan(&a.ID, &msgID, &a.ChannelID, &userID, &a.Filename, &a.ContentType, &a.SizeBytes, &a.StoragePath, &createdAt)
+		a, err := scanAttachment(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		if msgID.Valid {
-			a.MessageID = &msgID.String
-		}
-		if userID.Valid {
-			a.UserID = &userID.String
-		}
-		a.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-
-		attachments = append(attachments, a)
+		attachments = append(attachments, *a)
 	}
 
 	return attachments, rows.Err()
@@ -128,7 +110,7 @@ func (r *Repository) ListForMessages(ctx context.Context, messageIDs []string) (
 	}
 
 	query := `
-		SELECT id, message_id, channel_id, user_id, filename, content_type, size_bytes, storage_path, created_at
+		SELECT ` + attachmentColumns + `
 		FROM attachments
 		WHERE message_id IN (` + strings.Join(placeholders, ",") + `)
 		ORDER BY created_at
@@ -142,27 +124,99 @@ func (r *Repository) ListForMessages(ctx context.Context, messageIDs []string) (
 
 	attachments := make(map[string][]Attachment)
 	for rows.Next() {
-		var a Attachment
-		var messageID, userID sql.NullString
-		var createdAt string
-
-		err := rows.Scan(&a.ID, &messageID, &a.ChannelID, &userID, &a.Filename, &a.ContentType, &a.SizeBytes, &a.StoragePath, &createdAt)
+		a, err := scanAttachment(rows)
 		if err != nil {
 			return nil, err
 		}
-
-		if messageID.Valid {
-			a.MessageID = &messageID.String
-		}
-		if userID.Valid {
-			a.UserID = &userID.String
+		if a.MessageID != nil {
+			attachments[*a.MessageID] = append(attachments[*a.MessageID], *a)
 		}
-		a.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	}
+
+	return attachments, rows.Err()
+}
 
-		if messageID.Valid {
-			attachments[messageID.String] = append(attachments[messageID.String], a)
+// ListPendingScan returns attachments to scan again, oldest first: those still
+// awaiting a scan that were uploaded before pendingBefore, and those whose last
+// scan errored before errorBefore and that have been scanned fewer than
+// maxAttempts times.
+func (r *Repository) ListPendingScan(ctx context.Context, pendingBefore, errorBefore time.Time, maxAttempts, limit int) ([]Attachment, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM attachments
+		WHERE (scan_status = 'pending' AND created_at < ?)
+		   OR (scan_status = 'error' AND scanned_at < ? AND scan_attempts < ?)
+		ORDER BY created_at
+		LIMIT ?
+	`, pendingBefore.UTC().Format(time.RFC3339), errorBefore.UTC().Format(time.RFC3339), maxAttempts, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []Attachment
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
 		}
+		attachments = append(attachments, *a)
 	}
 
 	return attachments, rows.Err()
 }
+
+// UpdateScanResult records the outcome of an antivirus scan and counts the
+// attempt.
+func (r *Repository) UpdateScanResult(ctx context.Context, id, status string, signature *string) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE attachments SET scan_status = ?, scan_signature = ?, scanned_at = ?, scan_attempts = scan_attempts + 1
+		WHERE id = ?
+	`, status, signature, time.Now().UTC().Format(time.RFC3339), id)
+	if err != nil {
+		return err
+	}
+	rows, _ := result.RowsAffected()
+	if rows == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// UpdateStoragePath points the attachment at a new storage key (e.g. after quarantine).
+func (r *Repository) UpdateStoragePath(ctx context.Context, id, storagePath string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE attachments SET storage_path = ? WHERE id = ?
+	`, storagePath, id)
+	return err
+}
+
+func scanAttachment(scanner interface{ Scan(dest ...any) error }) (*Attachment, error) {
+	var a Attachment
+	var messageID, userID, scanStatus, scanSignature, scannedAt sql.NullString
+	var createdAt string
+
+	err := scanner.Scan(&a.ID, &messageID, &a.ChannelID, &userID, &a.Filename, &a.ContentType, &a.SizeBytes, &a.StoragePath, &createdAt,
+		&scanStatus, &scanSignature, &scannedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if messageID.Valid {
+		a.MessageID = &messageID.String
+	}
+	if userID.Valid {
+		a.UserID = &userID.String
+	}
+	a.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	a.ScanStatus = scanStatus.String
+	if scanSignature.Valid {
+		a.ScanSignature = &scanSignature.String
+	}
+	if scannedAt.Valid {
+		t, _ := time.Parse(time.RFC3339, scannedAt.String)
+		a.ScannedAt = &t
+	}
+
+	return &a, nil
+}
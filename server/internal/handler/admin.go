@@ -0,0 +1,457 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/enzyme/server/internal/admin"
+	"github.com/enzyme/server/internal/email"
+	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/user"
+)
+
+// isSiteAdmin reports whether the user may use the /admin endpoints. The flag
+// is read from the database on every request so that revoking it takes effect
+// immediately.
+func (h *Handler) isSiteAdmin(ctx context.Context, userID string) (bool, error) {
+	u, err := h.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return u.IsSiteAdmin, nil
+}
+
+func siteAdminRequiredResponse() openapi.ForbiddenJSONResponse {
+	return forbiddenResponse("Site admin access required")
+}
+
+// GetAdminStats returns instance-wide totals
+func (h *Handler) GetAdminStats(ctx context.Context, request openapi.GetAdminStatsRequestObject) (openapi.GetAdminStatsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.GetAdminStats401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.GetAdminStats403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	stats, err := h.adminService.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.GetAdminStats200JSONResponse{
+		Users:            stats.Users,
+		DeactivatedUsers: stats.DeactivatedUsers,
+		PendingUsers:     stats.PendingUsers,
+		SiteAdmins:       stats.SiteAdmins,
+		Workspaces:       stats.Workspaces,
+		Channels:         stats.Channels,
+		Messages:         stats.Messages,
+		Files:            stats.Files,
+		FileBytes:        stats.FileBytes,
+	}, nil
+}
+
+// ListAdminUsers lists or searches every user on the instance
+func (h *Handler) ListAdminUsers(ctx context.Context, request openapi.ListAdminUsersRequestObject) (openapi.ListAdminUsersResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.ListAdminUsers401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.ListAdminUsers403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	var listInput *openapi.AdminListInput
+	pendingOnly := false
+	if request.Body != nil {
+		listInput = &openapi.AdminListInput{Query: request.Body.Query, Cursor: request.Body.Cursor, Limit: request.Body.Limit}
+		pendingOnly = request.Body.PendingApproval != nil && *request.Body.PendingApproval
+	}
+	query, cursor, limit := adminListParams(listInput)
+	users, hasMore, nextCursor, err := h.userRepo.List(ctx, query, pendingOnly, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	apiUsers := make([]openapi.User, len(users))
+	for i := range users {
+		apiUsers[i] = userToAPI(&users[i])
+	}
+
+	resp := openapi.ListAdminUsers200JSONResponse{
+		Users:   apiUsers,
+		HasMore: hasMore,
+	}
+	if nextCursor != "" {
+		resp.NextCursor = &nextCursor
+	}
+	return resp, nil
+}
+
+// DisableUser deactivates an account instance-wide
+func (h *Handler) DisableUser(ctx context.Context, request openapi.DisableUserRequestObject) (openapi.DisableUserResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.DisableUser401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.DisableUser403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	if request.Id == userID {
+		return openapi.DisableUser400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Cannot disable your own account")}, nil
+	}
+
+	u, err := h.adminService.SetUserDisabled(ctx, userID, request.Id, true)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return openapi.DisableUser404JSONResponse{NotFoundJSONResponse: notFoundResponse("User not found")}, nil
+		}
+		return nil, err
+	}
+
+	// Sessions are gone, but open event streams were authenticated when they
+	// connected, so close them too
+	if h.hub != nil {
+		workspaceIDs, err := h.workspaceRepo.ListWorkspaceIDsForUser(ctx, u.ID)
+		if err != nil {
+			slog.Error("failed to list workspaces to disconnect disabled user", "user_id", u.ID, "error", err)
+		}
+		for _, wsID := range workspaceIDs {
+			h.hub.DisconnectUserClients(wsID, u.ID)
+		}
+	}
+
+	slog.Info("user disabled by site admin", "user_id", u.ID, "actor_id", userID)
+	return openapi.DisableUser200JSONResponse{User: userToAPI(u)}, nil
+}
+
+// EnableUser reactivates a disabled account
+func (h *Handler) EnableUser(ctx context.Context, request openapi.EnableUserRequestObject) (openapi.EnableUserResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.EnableUser401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.EnableUser403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	u, err := h.adminService.SetUserDisabled(ctx, userID, request.Id, false)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return openapi.EnableUser404JSONResponse{NotFoundJSONResponse: notFoundResponse("User not found")}, nil
+		}
+		return nil, err
+	}
+
+	slog.Info("user enabled by site admin", "user_id", u.ID, "actor_id", userID)
+	return openapi.EnableUser200JSONResponse{User: userToAPI(u)}, nil
+}
+
+// SetSiteAdmin grants or revokes the site admin role
+func (h *Handler) SetSiteAdmin(ctx context.Context, request openapi.SetSiteAdminRequestObject) (openapi.SetSiteAdminResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.SetSiteAdmin401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.SetSiteAdmin403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	if request.Id == userID && !request.Body.IsSiteAdmin {
+		return openapi.SetSiteAdmin400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Cannot remove your own site admin role")}, nil
+	}
+
+	u, err := h.adminService.SetUserSiteAdmin(ctx, userID, request.Id, request.Body.IsSiteAdmin)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return openapi.SetSiteAdmin404JSONResponse{NotFoundJSONResponse: notFoundResponse("User not found")}, nil
+		}
+		return nil, err
+	}
+
+	slog.Info("site admin role changed", "user_id", u.ID, "is_site_admin", u.IsSiteAdmin, "actor_id", userID)
+	return openapi.SetSiteAdmin200JSONResponse{User: userToAPI(u)}, nil
+}
+
+// ApproveUser lets an account awaiting approval sign in
+func (h *Handler) ApproveUser(ctx context.Context, request openapi.ApproveUserRequestObject) (openapi.ApproveUserResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.ApproveUser401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.ApproveUser403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	u, err := h.adminService.ApproveUser(ctx, request.Id)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			return openapi.ApproveUser404JSONResponse{NotFoundJSONResponse: notFoundResponse("User not found")}, nil
+		case errors.Is(err, admin.ErrNotPending):
+			return openapi.ApproveUser400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "User is not awaiting approval")}, nil
+		}
+		return nil, err
+	}
+
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := h.emailService.SendAccountApproved(sendCtx, u.Email); err != nil {
+			slog.Error("failed to send account approved email", "user_id", u.ID, "error", err)
+		}
+	}()
+
+	slog.Info("user approved by site admin", "user_id", u.ID, "actor_id", userID)
+	return openapi.ApproveUser200JSONResponse{User: userToAPI(u)}, nil
+}
+
+// RejectUser deletes an account awaiting approval
+func (h *Handler) RejectUser(ctx context.Context, request openapi.RejectUserRequestObject) (openapi.RejectUserResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.RejectUser401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.RejectUser403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	if err := h.adminService.RejectUser(ctx, request.Id); err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserNotFound):
+			return openapi.RejectUser404JSONResponse{NotFoundJSONResponse: notFoundResponse("User not found")}, nil
+		case errors.Is(err, admin.ErrNotPending):
+			return openapi.RejectUser400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "User is not awaiting approval")}, nil
+		}
+		return nil, err
+	}
+
+	slog.Info("pending user rejected by site admin", "user_id", request.Id, "actor_id", userID)
+	return openapi.RejectUser200JSONResponse{Success: true}, nil
+}
+
+// ListAdminWorkspaces lists or searches every workspace on the instance
+func (h *Handler) ListAdminWorkspaces(ctx context.Context, request openapi.ListAdminWorkspacesRequestObject) (openapi.ListAdminWorkspacesResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.ListAdminWorkspaces401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.ListAdminWorkspaces403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	query, cursor, limit := adminListParams(request.Body)
+	workspaces, hasMore, nextCursor, err := h.workspaceRepo.Search(ctx, query, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	apiWorkspaces := make([]openapi.AdminWorkspace, len(workspaces))
+	for i, ws := range workspaces {
+		apiWorkspaces[i] = openapi.AdminWorkspace{
+			Id:          ws.ID,
+			Name:        ws.Name,
+			IconUrl:     ws.IconURL,
+			MemberCount: ws.MemberCount,
+			OwnerCount:  ws.OwnerCount,
+			CreatedAt:   ws.CreatedAt,
+		}
+	}
+
+	resp := openapi.ListAdminWorkspaces200JSONResponse{
+		Workspaces: apiWorkspaces,
+		HasMore:    hasMore,
+	}
+	if nextCursor != "" {
+		resp.NextCursor = &nextCursor
+	}
+	return resp, nil
+}
+
+// GetServerSettings returns the instance-wide settings
+func (h *Handler) GetServerSettings(ctx context.Context, request openapi.GetServerSettingsRequestObject) (openapi.GetServerSettingsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.GetServerSettings401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.GetServerSettings403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	settings, err := h.adminService.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return openapi.GetServerSettings200JSONResponse(serverSettingsToAPI(settings)), nil
+}
+
+// UpdateServerSettings changes the instance-wide settings
+func (h *Handler) UpdateServerSettings(ctx context.Context, request openapi.UpdateServerSettingsRequestObject) (openapi.UpdateServerSettingsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.UpdateServerSettings401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.UpdateServerSettings403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	settings, err := h.adminService.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if request.Body.RegistrationMode != nil {
+		settings.RegistrationMode = string(*request.Body.RegistrationMode)
+	}
+	if request.Body.AllowedEmailDomains != nil {
+		settings.AllowedEmailDomains = *request.Body.AllowedEmailDomains
+	}
+	if request.Body.WorkspaceCreation != nil {
+		settings.WorkspaceCreation = string(*request.Body.WorkspaceCreation)
+	}
+
+	if err := h.adminService.UpdateSettings(ctx, settings); err != nil {
+		if errors.Is(err, admin.ErrInvalidSetting) {
+			return openapi.UpdateServerSettings400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, err.Error())}, nil
+		}
+		return nil, err
+	}
+	// Read back the normalized domains
+	if settings, err = h.adminService.GetSettings(ctx); err != nil {
+		return nil, err
+	}
+
+	slog.Info("server settings updated", "registration_mode", settings.RegistrationMode, "workspace_creation", settings.WorkspaceCreation, "actor_id", userID)
+	return openapi.UpdateServerSettings200JSONResponse(serverSettingsToAPI(settings)), nil
+}
+
+// ListQueuedEmails lists outgoing emails that failed or are awaiting a retry
+func (h *Handler) ListQueuedEmails(ctx context.Context, request openapi.ListQueuedEmailsRequestObject) (openapi.ListQueuedEmailsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.ListQueuedEmails401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.ListQueuedEmails403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	status := email.OutboxStatusFailed
+	var listInput *openapi.AdminListInput
+	if request.Body != nil {
+		listInput = &openapi.AdminListInput{Cursor: request.Body.Cursor, Limit: request.Body.Limit}
+		if request.Body.Status != nil {
+			status = string(*request.Body.Status)
+		}
+	}
+	_, cursor, limit := adminListParams(listInput)
+	emails, hasMore, nextCursor, err := h.emailOutbox.List(ctx, status, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	apiEmails := make([]openapi.QueuedEmail, len(emails))
+	for i, q := range emails {
+		apiEmails[i] = openapi.QueuedEmail{
+			Id:            q.ID,
+			To:            q.Message.To,
+			Subject:       q.Message.Subject,
+			Status:        openapi.QueuedEmailStatus(q.Status),
+			Attempts:      q.Attempts,
+			LastError:     q.LastError,
+			NextAttemptAt: q.NextAttemptAt,
+			CreatedAt:     q.CreatedAt,
+			UpdatedAt:     q.UpdatedAt,
+		}
+	}
+
+	resp := openapi.ListQueuedEmails200JSONResponse{
+		Emails:  apiEmails,
+		HasMore: hasMore,
+	}
+	if nextCursor != "" {
+		resp.NextCursor = &nextCursor
+	}
+	return resp, nil
+}
+
+// RetryQueuedEmail queues a failed email for another round of delivery
+// attempts
+func (h *Handler) RetryQueuedEmail(ctx context.Context, request openapi.RetryQueuedEmailRequestObject) (openapi.RetryQueuedEmailResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.RetryQueuedEmail401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+	if ok, err := h.isSiteAdmin(ctx, userID); err != nil {
+		return nil, err
+	} else if !ok {
+		return openapi.RetryQueuedEmail403JSONResponse{ForbiddenJSONResponse: siteAdminRequiredResponse()}, nil
+	}
+
+	if err := h.emailOutbox.Retry(ctx, request.Id); err != nil {
+		if errors.Is(err, email.ErrQueuedEmailNotFound) {
+			return openapi.RetryQueuedEmail404JSONResponse{NotFoundJSONResponse: notFoundResponse("Failed email not found")}, nil
+		}
+		return nil, err
+	}
+
+	slog.Info("failed email queued for retry by site admin", "email_id", request.Id, "actor_id", userID)
+	return openapi.RetryQueuedEmail200JSONResponse{Success: true}, nil
+}
+
+func adminListParams(body *openapi.AdminListInput) (query, cursor string, limit int) {
+	limit = 50
+	if body == nil {
+		return query, cursor, limit
+	}
+	if body.Query != nil {
+		query = *body.Query
+	}
+	if body.Cursor != nil {
+		cursor = *body.Cursor
+	}
+	if body.Limit != nil {
+		limit = *body.Limit
+	}
+	return query, cursor, limit
+}
+
+func serverSettingsToAPI(s admin.Settings) openapi.ServerSettings {
+	out := openapi.ServerSettings{
+		RegistrationMode:    openapi.RegistrationMode(s.RegistrationMode),
+		AllowedEmailDomains: s.AllowedEmailDomains,
+		WorkspaceCreation:   openapi.WorkspaceCreationPolicy(s.WorkspaceCreation),
+	}
+	if out.AllowedEmailDomains == nil {
+		out.AllowedEmailDomains = []string{}
+	}
+	return out
+}
@@ -0,0 +1,516 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/enzyme/server/internal/admin"
+	"github.com/enzyme/server/internal/email"
+	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/testutil"
+	"github.com/enzyme/server/internal/user"
+	"github.com/enzyme/server/internal/workspace"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func makeSiteAdmin(t *testing.T, h *Handler, userID string) {
+	t.Helper()
+	if err := h.userRepo.SetSiteAdmin(context.Background(), userID, true); err != nil {
+		t.Fatalf("SetSiteAdmin: %v", err)
+	}
+}
+
+func TestAdminEndpoints_RequireSiteAdmin(t *testing.T) {
+	h, db := testHandler(t)
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	testutil.CreateTestWorkspace(t, db, owner.ID, "WS")
+
+	// Owning a workspace grants nothing instance-wide
+	ctx := ctxWithUser(t, h, owner.ID)
+	resp, err := h.GetAdminStats(ctx, openapi.GetAdminStatsRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.GetAdminStats403JSONResponse); !ok {
+		t.Fatalf("expected 403, got %T", resp)
+	}
+
+	settingsResp, err := h.UpdateServerSettings(ctx, openapi.UpdateServerSettingsRequestObject{
+		Body: &openapi.UpdateServerSettingsInput{WorkspaceCreation: new(openapi.WorkspaceCreationPolicy)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := settingsResp.(openapi.UpdateServerSettings403JSONResponse); !ok {
+		t.Fatalf("expected 403, got %T", settingsResp)
+	}
+
+	unauth, err := h.ListAdminUsers(context.Background(), openapi.ListAdminUsersRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := unauth.(openapi.ListAdminUsers401JSONResponse); !ok {
+		t.Fatalf("expected 401, got %T", unauth)
+	}
+}
+
+func TestGetAdminStats(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	other := testutil.CreateTestUser(t, db, "other@test.com", "Other")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+	ws := testutil.CreateTestWorkspace(t, db, other.ID, "WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, other.ID, "general", "public")
+	testutil.CreateTestMessage(t, db, ch.ID, other.ID, "hello")
+
+	resp, err := h.GetAdminStats(ctxWithUser(t, h, siteAdmin.ID), openapi.GetAdminStatsRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stats, ok := resp.(openapi.GetAdminStats200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	if stats.Users != 2 || stats.SiteAdmins != 1 || stats.Workspaces != 1 || stats.Channels != 1 || stats.Messages != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestListAdminUsers_Search(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+	testutil.CreateTestUser(t, db, "alice@test.com", "Alice")
+	testutil.CreateTestUser(t, db, "bob@test.com", "Bob")
+	ctx := ctxWithUser(t, h, siteAdmin.ID)
+
+	query := "ALI"
+	resp, err := h.ListAdminUsers(ctx, openapi.ListAdminUsersRequestObject{
+		Body: &openapi.ListAdminUsersInput{Query: &query},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list := resp.(openapi.ListAdminUsers200JSONResponse)
+	if len(list.Users) != 1 || list.Users[0].DisplayName != "Alice" {
+		t.Fatalf("users = %+v, want only Alice", list.Users)
+	}
+
+	// Page through everyone
+	limit := 2
+	resp, _ = h.ListAdminUsers(ctx, openapi.ListAdminUsersRequestObject{
+		Body: &openapi.ListAdminUsersInput{Limit: &limit},
+	})
+	page := resp.(openapi.ListAdminUsers200JSONResponse)
+	if len(page.Users) != 2 || !page.HasMore || page.NextCursor == nil {
+		t.Fatalf("first page = %+v, want 2 users and more", page)
+	}
+	resp, _ = h.ListAdminUsers(ctx, openapi.ListAdminUsersRequestObject{
+		Body: &openapi.ListAdminUsersInput{Limit: &limit, Cursor: page.NextCursor},
+	})
+	page = resp.(openapi.ListAdminUsers200JSONResponse)
+	if len(page.Users) != 1 || page.HasMore {
+		t.Fatalf("second page = %+v, want the last user", page)
+	}
+}
+
+func TestDisableUser(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+	spammer := testutil.CreateTestUser(t, db, "spam@test.com", "Spam")
+	testutil.CreateTestWorkspace(t, db, spammer.ID, "Spam WS")
+	spammerToken, err := h.sessionStore.Create(spammer.ID)
+	if err != nil {
+		t.Fatalf("creating session: %v", err)
+	}
+	ctx := ctxWithUser(t, h, siteAdmin.ID)
+
+	self, err := h.DisableUser(ctx, openapi.DisableUserRequestObject{Id: siteAdmin.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := self.(openapi.DisableUser400JSONResponse); !ok {
+		t.Fatalf("expected 400 disabling yourself, got %T", self)
+	}
+
+	resp, err := h.DisableUser(ctx, openapi.DisableUserRequestObject{Id: spammer.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	disabled, ok := resp.(openapi.DisableUser200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	if disabled.User.Status != user.StatusDeactivated {
+		t.Errorf("status = %q, want deactivated", disabled.User.Status)
+	}
+	if _, err := h.sessionStore.Validate(spammerToken); err == nil {
+		t.Error("session survived disabling the account")
+	}
+
+	missing, _ := h.EnableUser(ctx, openapi.EnableUserRequestObject{Id: "nope"})
+	if _, ok := missing.(openapi.EnableUser404JSONResponse); !ok {
+		t.Fatalf("expected 404, got %T", missing)
+	}
+}
+
+func TestSetSiteAdmin_CannotDemoteSelf(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+	other := testutil.CreateTestUser(t, db, "other@test.com", "Other")
+	ctx := ctxWithUser(t, h, siteAdmin.ID)
+
+	resp, err := h.SetSiteAdmin(ctx, openapi.SetSiteAdminRequestObject{
+		Id:   siteAdmin.ID,
+		Body: &openapi.SetSiteAdminJSONRequestBody{IsSiteAdmin: false},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.SetSiteAdmin400JSONResponse); !ok {
+		t.Fatalf("expected 400, got %T", resp)
+	}
+
+	resp, _ = h.SetSiteAdmin(ctx, openapi.SetSiteAdminRequestObject{
+		Id:   other.ID,
+		Body: &openapi.SetSiteAdminJSONRequestBody{IsSiteAdmin: true},
+	})
+	granted, ok := resp.(openapi.SetSiteAdmin200JSONResponse)
+	if !ok || granted.User.IsSiteAdmin == nil || !*granted.User.IsSiteAdmin {
+		t.Fatalf("expected other to become a site admin, got %+v", resp)
+	}
+}
+
+func TestServerSettings_ClosedRegistration(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+
+	closed := openapi.RegistrationMode(admin.RegistrationClosed)
+	resp, err := h.UpdateServerSettings(ctxWithUser(t, h, siteAdmin.ID), openapi.UpdateServerSettingsRequestObject{
+		Body: &openapi.UpdateServerSettingsInput{RegistrationMode: &closed},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated := resp.(openapi.UpdateServerSettings200JSONResponse)
+	if updated.RegistrationMode != closed || updated.WorkspaceCreation != admin.WorkspaceCreationEveryone {
+		t.Fatalf("settings = %+v, want registration closed and workspace creation unchanged", updated)
+	}
+
+	info, _ := h.GetServerInfo(context.Background(), openapi.GetServerInfoRequestObject{})
+	if mode := info.(openapi.GetServerInfo200JSONResponse).RegistrationMode; mode == nil || *mode != closed {
+		t.Error("expected server info to report registration closed")
+	}
+
+	reg, err := h.Register(context.Background(), openapi.RegisterRequestObject{
+		Body: &openapi.RegisterJSONRequestBody{Email: "new@test.com", Password: "password123", DisplayName: "New"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := reg.(openapi.Register403JSONResponse); !ok {
+		t.Fatalf("expected 403, got %T", reg)
+	}
+}
+
+func TestServerSettings_RestrictWorkspaceCreation(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+	member := testutil.CreateTestUser(t, db, "member@test.com", "Member")
+	adminCtx := ctxWithUser(t, h, siteAdmin.ID)
+
+	policy := openapi.WorkspaceCreationPolicy(admin.WorkspaceCreationSiteAdmins)
+	if _, err := h.UpdateServerSettings(adminCtx, openapi.UpdateServerSettingsRequestObject{
+		Body: &openapi.UpdateServerSettingsInput{WorkspaceCreation: &policy},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := h.CreateWorkspace(ctxWithUser(t, h, member.ID), openapi.CreateWorkspaceRequestObject{
+		Body: &openapi.CreateWorkspaceJSONRequestBody{Name: "Mine"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.CreateWorkspace403JSONResponse); !ok {
+		t.Fatalf("expected 403 for a regular user, got %T", resp)
+	}
+
+	resp, _ = h.CreateWorkspace(adminCtx, openapi.CreateWorkspaceRequestObject{
+		Body: &openapi.CreateWorkspaceJSONRequestBody{Name: "Official"},
+	})
+	if _, ok := resp.(openapi.CreateWorkspace200JSONResponse); !ok {
+		t.Fatalf("expected a site admin to create a workspace, got %T", resp)
+	}
+
+	invalid := openapi.WorkspaceCreationPolicy("nobody")
+	bad, _ := h.UpdateServerSettings(adminCtx, openapi.UpdateServerSettingsRequestObject{
+		Body: &openapi.UpdateServerSettingsInput{WorkspaceCreation: &invalid},
+	})
+	if _, ok := bad.(openapi.UpdateServerSettings400JSONResponse); !ok {
+		t.Fatalf("expected 400 for an unknown policy, got %T", bad)
+	}
+}
+
+func TestRegister_ApprovalQueue(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+	adminCtx := ctxWithUser(t, h, siteAdmin.ID)
+
+	approval := openapi.RegistrationMode(admin.RegistrationApproval)
+	if _, err := h.UpdateServerSettings(adminCtx, openapi.UpdateServerSettingsRequestObject{
+		Body: &openapi.UpdateServerSettingsInput{RegistrationMode: &approval},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reg, err := h.Register(context.Background(), openapi.RegisterRequestObject{
+		Body: &openapi.RegisterJSONRequestBody{Email: "new@test.com", Password: "password123", DisplayName: "New"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created, ok := reg.(openapi.Register202JSONResponse)
+	if !ok {
+		t.Fatalf("expected 202, got %T", reg)
+	}
+
+	login := func() openapi.LoginResponseObject {
+		resp, err := h.Login(context.Background(), openapi.LoginRequestObject{
+			Body: &openapi.LoginJSONRequestBody{Email: "new@test.com", Password: "password123"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+	if denied, ok := login().(openapi.Login401JSONResponse); !ok || denied.Error.Code != "USER_PENDING" {
+		t.Fatalf("expected USER_PENDING, got %+v", denied)
+	}
+
+	pendingOnly := true
+	resp, _ := h.ListAdminUsers(adminCtx, openapi.ListAdminUsersRequestObject{
+		Body: &openapi.ListAdminUsersInput{PendingApproval: &pendingOnly},
+	})
+	list := resp.(openapi.ListAdminUsers200JSONResponse)
+	if len(list.Users) != 1 || list.Users[0].Id != created.User.Id {
+		t.Fatalf("pending users = %+v, want only the new user", list.Users)
+	}
+
+	approved, err := h.ApproveUser(adminCtx, openapi.ApproveUserRequestObject{Id: created.User.Id})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := approved.(openapi.ApproveUser200JSONResponse); !ok {
+		t.Fatalf("expected 200, got %T", approved)
+	}
+	if _, ok := login().(openapi.Login200JSONResponse); !ok {
+		t.Fatal("expected login to succeed after approval")
+	}
+
+	rejected, _ := h.RejectUser(adminCtx, openapi.RejectUserRequestObject{Id: created.User.Id})
+	if _, ok := rejected.(openapi.RejectUser400JSONResponse); !ok {
+		t.Fatalf("expected 400 rejecting an approved user, got %T", rejected)
+	}
+}
+
+func TestRegister_InviteOnly(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+	ws := testutil.CreateTestWorkspace(t, db, siteAdmin.ID, "WS")
+	invite := &workspace.Invite{WorkspaceID: ws.ID, Role: workspace.RoleMember}
+	if err := h.workspaceRepo.CreateInvite(context.Background(), invite); err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+
+	inviteOnly := openapi.RegistrationMode(admin.RegistrationInviteOnly)
+	if _, err := h.UpdateServerSettings(ctxWithUser(t, h, siteAdmin.ID), openapi.UpdateServerSettingsRequestObject{
+		Body: &openapi.UpdateServerSettingsInput{RegistrationMode: &inviteOnly},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	register := func(email string, code *string) openapi.RegisterResponseObject {
+		resp, err := h.Register(context.Background(), openapi.RegisterRequestObject{
+			Body: &openapi.RegisterJSONRequestBody{Email: openapi_types.Email(email), Password: "password123", DisplayName: "New", InviteCode: code},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	if denied, ok := register("a@test.com", nil).(openapi.Register403JSONResponse); !ok || denied.Error.Code != "INVITE_REQUIRED" {
+		t.Fatalf("expected INVITE_REQUIRED, got %+v", denied)
+	}
+	bogus := "bogus"
+	if denied, ok := register("a@test.com", &bogus).(openapi.Register403JSONResponse); !ok || denied.Error.Code != "INVALID_INVITE" {
+		t.Fatalf("expected INVALID_INVITE, got %+v", denied)
+	}
+	if _, ok := register("a@test.com", &invite.Code).(openapi.Register200JSONResponse); !ok {
+		t.Fatal("expected registration with an invite to succeed")
+	}
+}
+
+func TestRegister_RedeemsInvite(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "WS")
+	maxUses := 1
+	invite := &workspace.Invite{WorkspaceID: ws.ID, Role: workspace.RoleMember, MaxUses: &maxUses}
+	if err := h.workspaceRepo.CreateInvite(ctx, invite); err != nil {
+		t.Fatalf("CreateInvite: %v", err)
+	}
+
+	register := func(email string) openapi.RegisterResponseObject {
+		resp, err := h.Register(ctx, openapi.RegisterRequestObject{
+			Body: &openapi.RegisterJSONRequestBody{Email: openapi_types.Email(email), Password: "password123", DisplayName: "New", InviteCode: &invite.Code},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	created, ok := register("a@test.com").(openapi.Register200JSONResponse)
+	if !ok {
+		t.Fatal("expected registration with an invite to succeed")
+	}
+	if _, err := h.workspaceRepo.GetMembership(ctx, created.User.Id, ws.ID); err != nil {
+		t.Errorf("registered user didn't join the invite's workspace: %v", err)
+	}
+	redeemed, err := h.workspaceRepo.GetInviteByCode(ctx, invite.Code)
+	if err != nil || redeemed.UseCount != 1 {
+		t.Fatalf("invite = %+v, %v, want one use", redeemed, err)
+	}
+
+	if denied, ok := register("b@test.com").(openapi.Register403JSONResponse); !ok || denied.Error.Code != "INVALID_INVITE" {
+		t.Fatalf("reusing a single-use invite: expected INVALID_INVITE, got %+v", denied)
+	}
+	if _, err := h.userRepo.GetByEmail(ctx, "b@test.com"); err == nil {
+		t.Error("account created with a used-up invite")
+	}
+}
+
+func TestUpdateServerSettings_DomainAllowlist(t *testing.T) {
+	h, db := testHandler(t)
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+	adminCtx := ctxWithUser(t, h, siteAdmin.ID)
+
+	allowlist := openapi.RegistrationMode(admin.RegistrationDomainAllowlist)
+	bad, _ := h.UpdateServerSettings(adminCtx, openapi.UpdateServerSettingsRequestObject{
+		Body: &openapi.UpdateServerSettingsInput{RegistrationMode: &allowlist},
+	})
+	if _, ok := bad.(openapi.UpdateServerSettings400JSONResponse); !ok {
+		t.Fatalf("expected 400 without any allowed domains, got %T", bad)
+	}
+
+	domains := []string{"@Example.com"}
+	resp, _ := h.UpdateServerSettings(adminCtx, openapi.UpdateServerSettingsRequestObject{
+		Body: &openapi.UpdateServerSettingsInput{RegistrationMode: &allowlist, AllowedEmailDomains: &domains},
+	})
+	updated, ok := resp.(openapi.UpdateServerSettings200JSONResponse)
+	if !ok || len(updated.AllowedEmailDomains) != 1 || updated.AllowedEmailDomains[0] != "example.com" {
+		t.Fatalf("expected the normalized domain, got %+v", resp)
+	}
+
+	reg, _ := h.Register(context.Background(), openapi.RegisterRequestObject{
+		Body: &openapi.RegisterJSONRequestBody{Email: "a@other.com", Password: "password123", DisplayName: "A"},
+	})
+	if denied, ok := reg.(openapi.Register403JSONResponse); !ok || denied.Error.Code != "EMAIL_DOMAIN_NOT_ALLOWED" {
+		t.Fatalf("expected EMAIL_DOMAIN_NOT_ALLOWED, got %+v", reg)
+	}
+	reg, _ = h.Register(context.Background(), openapi.RegisterRequestObject{
+		Body: &openapi.RegisterJSONRequestBody{Email: "a@example.com", Password: "password123", DisplayName: "A"},
+	})
+	if _, ok := reg.(openapi.Register200JSONResponse); !ok {
+		t.Fatalf("expected 200 for an allowed domain, got %T", reg)
+	}
+}
+
+func TestQueuedEmails_ListAndRetry(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	siteAdmin := testutil.CreateTestUser(t, db, "admin@test.com", "Admin")
+	other := testutil.CreateTestUser(t, db, "other@test.com", "Other")
+	makeSiteAdmin(t, h, siteAdmin.ID)
+
+	q, err := h.emailOutbox.Enqueue(ctx, &email.Message{To: "bounce@test.com", Subject: "Reset your Enzyme password", TextBody: "..."})
+	if err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	if _, err := h.emailOutbox.RecordFailure(ctx, q, &email.PermanentError{Err: errors.New("550 no such user")}); err != nil {
+		t.Fatalf("RecordFailure: %v", err)
+	}
+
+	resp, err := h.ListQueuedEmails(ctxWithUser(t, h, other.ID), openapi.ListQueuedEmailsRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.ListQueuedEmails403JSONResponse); !ok {
+		t.Fatalf("expected 403, got %T", resp)
+	}
+
+	adminCtx := ctxWithUser(t, h, siteAdmin.ID)
+	resp, err = h.ListQueuedEmails(adminCtx, openapi.ListQueuedEmailsRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list, ok := resp.(openapi.ListQueuedEmails200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", resp)
+	}
+	if len(list.Emails) != 1 || list.Emails[0].To != "bounce@test.com" || list.Emails[0].Status != openapi.QueuedEmailStatus(email.OutboxStatusFailed) {
+		t.Fatalf("emails = %+v", list.Emails)
+	}
+	if list.Emails[0].LastError == nil || *list.Emails[0].LastError != "550 no such user" {
+		t.Errorf("last_error = %v", list.Emails[0].LastError)
+	}
+
+	retryResp, err := h.RetryQueuedEmail(adminCtx, openapi.RetryQueuedEmailRequestObject{Id: q.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := retryResp.(openapi.RetryQueuedEmail200JSONResponse); !ok {
+		t.Fatalf("expected 200, got %T", retryResp)
+	}
+
+	pending := openapi.QueuedEmailStatus(email.OutboxStatusPending)
+	resp, err = h.ListQueuedEmails(adminCtx, openapi.ListQueuedEmailsRequestObject{Body: &openapi.ListQueuedEmailsInput{Status: &pending}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if list := resp.(openapi.ListQueuedEmails200JSONResponse); len(list.Emails) != 1 || list.Emails[0].Attempts != 0 {
+		t.Fatalf("pending = %+v, want the retried email with its attempts reset", list.Emails)
+	}
+
+	// Only failed emails can be retried
+	retryResp, err = h.RetryQueuedEmail(adminCtx, openapi.RetryQueuedEmailRequestObject{Id: q.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := retryResp.(openapi.RetryQueuedEmail404JSONResponse); !ok {
+		t.Fatalf("expected 404, got %T", retryResp)
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/enzyme/server/internal/admin"
 	"github.com/enzyme/server/internal/auth"
+	"github.com/enzyme/server/internal/email"
 	"github.com/enzyme/server/internal/gravatar"
 	"github.com/enzyme/server/internal/openapi"
 	"github.com/enzyme/server/internal/pushnotification"
@@ -17,15 +19,34 @@ import (
 
 // Register handles user registration
 func (h *Handler) Register(ctx context.Context, request openapi.RegisterRequestObject) (openapi.RegisterResponseObject, error) {
+	inviteCode := ""
+	if request.Body.InviteCode != nil {
+		inviteCode = *request.Body.InviteCode
+	}
+
 	input := auth.RegisterInput{
 		Email:       string(request.Body.Email),
 		Password:    request.Body.Password,
 		DisplayName: request.Body.DisplayName,
 	}
 
-	u, err := h.authService.Register(ctx, input)
+	u, joinedWorkspaceID, err := h.adminService.Register(ctx, input, inviteCode)
 	if err != nil {
 		var code, msg string
+		switch {
+		case errors.Is(err, admin.ErrRegistrationClosed):
+			code, msg = "REGISTRATION_CLOSED", "Registration is closed on this server"
+		case errors.Is(err, admin.ErrInviteRequired):
+			code, msg = "INVITE_REQUIRED", "An invite is required to register on this server"
+		case errors.Is(err, admin.ErrInvalidInvite):
+			code, msg = "INVALID_INVITE", "Invite is invalid or has expired"
+		case errors.Is(err, admin.ErrEmailDomainNotAllowed):
+			code, msg = "EMAIL_DOMAIN_NOT_ALLOWED", "Registration is limited to approved email domains"
+		}
+		if code != "" {
+			return openapi.Register403JSONResponse(newErrorResponse(code, msg)), nil
+		}
+
 		switch {
 		case errors.Is(err, user.ErrEmailAlreadyInUse):
 			code, msg = "EMAIL_IN_USE", "Email is already registered"
@@ -42,11 +63,8 @@ func (h *Handler) Register(ctx context.Context, request openapi.RegisterRequestO
 			BadRequestJSONResponse: openapi.BadRequestJSONResponse(newErrorResponse(code, msg)),
 		}, nil
 	}
-
-	// Create session token
-	token, err := h.sessionStore.Create(u.ID)
-	if err != nil {
-		return nil, err
+	if joinedWorkspaceID != "" {
+		h.autoCreateDMs(ctx, joinedWorkspaceID, u.ID)
 	}
 
 	// Verification email is best-effort; registration succeeds regardless of email config.
@@ -66,12 +84,43 @@ func (h *Handler) Register(ctx context.Context, request openapi.RegisterRequestO
 		}
 	}
 
+	if u.PendingApproval {
+		h.notifyApprovers(u)
+		return openapi.Register202JSONResponse{User: userToAPI(u)}, nil
+	}
+
+	// Create session token
+	token, err := h.sessionStore.Create(u.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return openapi.Register200JSONResponse{
 		User:  userToAPI(u),
 		Token: token,
 	}, nil
 }
 
+// notifyApprovers emails every site admin about an account awaiting approval.
+// Like the verification email it is best-effort and sent in the background.
+func (h *Handler) notifyApprovers(u *user.User) {
+	go func() {
+		sendCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		approvers, err := h.adminService.Approvers(sendCtx)
+		if err != nil {
+			slog.Error("failed to list approvers", "user_id", u.ID, "error", err)
+			return
+		}
+		data := email.RegistrationPendingData{DisplayName: u.DisplayName, Email: u.Email}
+		for _, approver := range approvers {
+			if err := h.emailService.SendRegistrationPending(sendCtx, approver.Email, data); err != nil {
+				slog.Error("failed to send registration pending email", "user_id", u.ID, "approver_id", approver.ID, "error", err)
+			}
+		}
+	}()
+}
+
 // Login handles user login
 func (h *Handler) Login(ctx context.Context, request openapi.LoginRequestObject) (openapi.LoginResponseObject, error) {
 	input := auth.LoginInput{
@@ -87,6 +136,8 @@ func (h *Handler) Login(ctx context.Context, request openapi.LoginRequestObject)
 			code, msg = "INVALID_CREDENTIALS", "Invalid email or password"
 		case errors.Is(err, auth.ErrUserDeactivated):
 			code, msg = "USER_DEACTIVATED", "Account is deactivated"
+		case errors.Is(err, auth.ErrUserPendingApproval):
+			code, msg = "USER_PENDING", "Account is awaiting approval"
 		default:
 			code, msg = ErrCodeInternalError, "An error occurred"
 		}
@@ -308,6 +359,18 @@ func (h *Handler) RegisterDeviceToken(ctx context.Context, request openapi.Regis
 			BadRequestJSONResponse: openapi.BadRequestJSONResponse(newErrorResponse("INVALID_DEVICE_ID", "Device ID must be between 1 and 256 characters")),
 		}, nil
 	}
+	if h.pushService != nil && !h.pushService.SupportsPlatform(string(request.Body.Platform)) {
+		return openapi.RegisterDeviceToken400JSONResponse{
+			BadRequestJSONResponse: openapi.BadRequestJSONResponse(newErrorResponse("PLATFORM_NOT_ENABLED", "Push notifications for this platform are not enabled on this server")),
+		}, nil
+	}
+	if request.Body.Platform == openapi.Unifiedpush {
+		if err := pushnotification.ValidateUnifiedPushEndpoint(request.Body.Token); err != nil {
+			return openapi.RegisterDeviceToken400JSONResponse{
+				BadRequestJSONResponse: openapi.BadRequestJSONResponse(newErrorResponse("INVALID_TOKEN", "UnifiedPush token must be the distributor's http or https endpoint URL")),
+			}, nil
+		}
+	}
 
 	token := &pushnotification.DeviceToken{
 		UserID:   userID,
@@ -353,15 +416,87 @@ func (h *Handler) UnregisterDeviceToken(ctx context.Context, request openapi.Unr
 	return openapi.UnregisterDeviceToken204Response{}, nil
 }
 
+// RegisterWebPushSubscription registers a browser for Web Push notifications
+func (h *Handler) RegisterWebPushSubscription(ctx context.Context, request openapi.RegisterWebPushSubscriptionRequestObject) (openapi.RegisterWebPushSubscriptionResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.RegisterWebPushSubscription401JSONResponse{
+			UnauthorizedJSONResponse: openapi.UnauthorizedJSONResponse(newErrorResponse(ErrCodeNotAuthenticated, "Not authenticated")),
+		}, nil
+	}
+
+	if h.webPushRepo == nil {
+		return openapi.RegisterWebPushSubscription400JSONResponse{
+			BadRequestJSONResponse: openapi.BadRequestJSONResponse(newErrorResponse("PUSH_NOT_ENABLED", "Web Push is not enabled on this server")),
+		}, nil
+	}
+
+	if len(request.Body.Endpoint) > 2048 {
+		return openapi.RegisterWebPushSubscription400JSONResponse{
+			BadRequestJSONResponse: openapi.BadRequestJSONResponse(newErrorResponse("INVALID_SUBSCRIPTION", "Endpoint must be at most 2048 characters")),
+		}, nil
+	}
+	if err := pushnotification.ValidateSubscription(request.Body.Endpoint, request.Body.Keys.P256dh, request.Body.Keys.Auth); err != nil {
+		return openapi.RegisterWebPushSubscription400JSONResponse{
+			BadRequestJSONResponse: openapi.BadRequestJSONResponse(newErrorResponse("INVALID_SUBSCRIPTION", err.Error())),
+		}, nil
+	}
+
+	sub := &pushnotification.WebPushSubscription{
+		UserID:   userID,
+		Endpoint: request.Body.Endpoint,
+		P256DH:   request.Body.Keys.P256dh,
+		Auth:     request.Body.Keys.Auth,
+	}
+
+	if err := h.webPushRepo.Upsert(ctx, sub); err != nil {
+		return nil, fmt.Errorf("upserting web push subscription: %w", err)
+	}
+
+	return openapi.RegisterWebPushSubscription200JSONResponse{
+		Id: sub.ID,
+	}, nil
+}
+
+// UnregisterWebPushSubscription removes a browser's Web Push subscription
+func (h *Handler) UnregisterWebPushSubscription(ctx context.Context, request openapi.UnregisterWebPushSubscriptionRequestObject) (openapi.UnregisterWebPushSubscriptionResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.UnregisterWebPushSubscription401JSONResponse{
+			UnauthorizedJSONResponse: openapi.UnauthorizedJSONResponse(newErrorResponse(ErrCodeNotAuthenticated, "Not authenticated")),
+		}, nil
+	}
+
+	if h.webPushRepo == nil {
+		return openapi.UnregisterWebPushSubscription404JSONResponse{
+			NotFoundJSONResponse: openapi.NotFoundJSONResponse(newErrorResponse("PUSH_NOT_ENABLED", "Web Push is not enabled on this server")),
+		}, nil
+	}
+
+	err := h.webPushRepo.DeleteByID(ctx, userID, request.Id)
+	if err != nil {
+		if errors.Is(err, pushnotification.ErrSubscriptionNotFound) {
+			return openapi.UnregisterWebPushSubscription404JSONResponse{
+				NotFoundJSONResponse: openapi.NotFoundJSONResponse(newErrorResponse("SUBSCRIPTION_NOT_FOUND", "Web Push subscription not found")),
+			}, nil
+		}
+		return nil, err
+	}
+
+	return openapi.UnregisterWebPushSubscription204Response{}, nil
+}
+
 // userToAPI converts a user.User to openapi.User
 func userToAPI(u *user.User) openapi.User {
 	apiUser := openapi.User{
-		Id:          u.ID,
-		Email:       openapi_types.Email(u.Email),
-		DisplayName: u.DisplayName,
-		Status:      u.Status,
-		CreatedAt:   u.CreatedAt,
-		UpdatedAt:   u.UpdatedAt,
+		Id:              u.ID,
+		Email:           openapi_types.Email(u.Email),
+		DisplayName:     u.DisplayName,
+		Status:          u.Status,
+		IsSiteAdmin:     &u.IsSiteAdmin,
+		PendingApproval: &u.PendingApproval,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       u.UpdatedAt,
 	}
 	if u.EmailVerifiedAt != nil {
 		apiUser.EmailVerifiedAt = u.EmailVerifiedAt
@@ -2,10 +2,14 @@ package handler
 
 import (
 	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
 	"testing"
 	"time"
 
 	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/pushnotification"
 	"github.com/enzyme/server/internal/testutil"
 	"github.com/enzyme/server/internal/user"
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -182,3 +186,143 @@ func TestErrorCodeConstants(t *testing.T) {
 		})
 	}
 }
+
+func webPushSubscriptionBody(t *testing.T, endpoint string) *openapi.RegisterWebPushSubscriptionJSONRequestBody {
+	t.Helper()
+
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	body := &openapi.RegisterWebPushSubscriptionJSONRequestBody{Endpoint: endpoint}
+	body.Keys.P256dh = base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+	body.Keys.Auth = base64.RawURLEncoding.EncodeToString(make([]byte, 16))
+	return body
+}
+
+func TestRegisterWebPushSubscription(t *testing.T) {
+	h, db := testHandler(t)
+	h.webPushRepo = pushnotification.NewSubscriptionRepository(db)
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ctx := ctxWithUser(t, h, u.ID)
+
+	resp, err := h.RegisterWebPushSubscription(ctx, openapi.RegisterWebPushSubscriptionRequestObject{
+		Body: webPushSubscriptionBody(t, "https://push.example.com/send/abc"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	registered, ok := resp.(openapi.RegisterWebPushSubscription200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+
+	subs, err := h.webPushRepo.ListByUserID(context.Background(), u.ID)
+	if err != nil {
+		t.Fatalf("listing subscriptions: %v", err)
+	}
+	if len(subs) != 1 || subs[0].ID != registered.Id {
+		t.Fatalf("expected the registered subscription to be stored, got %+v", subs)
+	}
+
+	delResp, err := h.UnregisterWebPushSubscription(ctx, openapi.UnregisterWebPushSubscriptionRequestObject{Id: registered.Id})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := delResp.(openapi.UnregisterWebPushSubscription204Response); !ok {
+		t.Fatalf("expected 204 response, got %T", delResp)
+	}
+
+	delResp, err = h.UnregisterWebPushSubscription(ctx, openapi.UnregisterWebPushSubscriptionRequestObject{Id: registered.Id})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := delResp.(openapi.UnregisterWebPushSubscription404JSONResponse); !ok {
+		t.Fatalf("expected 404 for a removed subscription, got %T", delResp)
+	}
+}
+
+func TestRegisterWebPushSubscription_InvalidEndpoint(t *testing.T) {
+	h, db := testHandler(t)
+	h.webPushRepo = pushnotification.NewSubscriptionRepository(db)
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+
+	resp, err := h.RegisterWebPushSubscription(ctxWithUser(t, h, u.ID), openapi.RegisterWebPushSubscriptionRequestObject{
+		Body: webPushSubscriptionBody(t, "http://push.example.com/send/abc"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	badReq, ok := resp.(openapi.RegisterWebPushSubscription400JSONResponse)
+	if !ok {
+		t.Fatalf("expected 400 response, got %T", resp)
+	}
+	if badReq.Error.Code != "INVALID_SUBSCRIPTION" {
+		t.Errorf("expected INVALID_SUBSCRIPTION, got %q", badReq.Error.Code)
+	}
+}
+
+func TestRegisterWebPushSubscription_Disabled(t *testing.T) {
+	h, db := testHandler(t)
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+
+	resp, err := h.RegisterWebPushSubscription(ctxWithUser(t, h, u.ID), openapi.RegisterWebPushSubscriptionRequestObject{
+		Body: webPushSubscriptionBody(t, "https://push.example.com/send/abc"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	badReq, ok := resp.(openapi.RegisterWebPushSubscription400JSONResponse)
+	if !ok {
+		t.Fatalf("expected 400 response, got %T", resp)
+	}
+	if badReq.Error.Code != "PUSH_NOT_ENABLED" {
+		t.Errorf("expected PUSH_NOT_ENABLED, got %q", badReq.Error.Code)
+	}
+}
+
+func TestRegisterDeviceToken_UnifiedPush(t *testing.T) {
+	h, db := testHandler(t)
+	h.pushTokenRepo = pushnotification.NewRepository(db)
+	h.pushService = pushnotification.NewService(h.pushTokenRepo, "")
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ctx := ctxWithUser(t, h, u.ID)
+
+	register := func(token string) openapi.RegisterDeviceTokenResponseObject {
+		t.Helper()
+		resp, err := h.RegisterDeviceToken(ctx, openapi.RegisterDeviceTokenRequestObject{
+			Body: &openapi.RegisterDeviceTokenJSONRequestBody{Token: token, Platform: openapi.Unifiedpush, DeviceId: "device-1"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	// UnifiedPush not enabled yet
+	resp := register("https://ntfy.example.com/upAbC123?up=1")
+	badReq, ok := resp.(openapi.RegisterDeviceToken400JSONResponse)
+	if !ok || badReq.Error.Code != "PLATFORM_NOT_ENABLED" {
+		t.Fatalf("expected PLATFORM_NOT_ENABLED, got %+v", resp)
+	}
+
+	h.pushService.EnableUnifiedPush(nil)
+
+	resp = register("not-a-url")
+	badReq, ok = resp.(openapi.RegisterDeviceToken400JSONResponse)
+	if !ok || badReq.Error.Code != "INVALID_TOKEN" {
+		t.Fatalf("expected INVALID_TOKEN, got %+v", resp)
+	}
+
+	resp = register("https://ntfy.example.com/upAbC123?up=1")
+	if _, ok := resp.(openapi.RegisterDeviceToken200JSONResponse); !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	tokens, err := h.pushTokenRepo.ListByUserID(context.Background(), u.ID)
+	if err != nil {
+		t.Fatalf("listing tokens: %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].Platform != "unifiedpush" {
+		t.Fatalf("expected one unifiedpush token, got %+v", tokens)
+	}
+}
@@ -791,7 +791,7 @@ func (h *Handler) createConvertSystemMessage(ctx context.Context, ch *channel.Ch
 	if h.hub != nil {
 		msgWithUser, _ := h.messageRepo.GetByIDWithUser(ctx, msg.ID)
 		if msgWithUser != nil {
-			apiMsg := messageWithUserToAPI(msgWithUser)
+			apiMsg := h.messageWithUserToAPI(msgWithUser)
 			h.hub.BroadcastToChannel(ch.WorkspaceID, ch.ID, sse.NewMessageNewEvent(apiMsg))
 		}
 	}
@@ -911,8 +911,8 @@ func (h *Handler) GetChannelNotifications(ctx context.Context, request openapi.G
 		return nil, err
 	}
 
-	// Get preferences (will return defaults if not set)
-	pref, err := h.notificationService.GetPreferences(ctx, userID, string(request.Id), ch.Type)
+	// Get preferences (inherited from the defaults if not set)
+	pref, err := h.notificationService.GetPreferences(ctx, userID, string(request.Id))
 	if err != nil {
 		return nil, err
 	}
@@ -943,7 +943,7 @@ func (h *Handler) UpdateChannelNotifications(ctx context.Context, request openap
 
 	// Validate notify level
 	notifyLevel := string(request.Body.NotifyLevel)
-	if notifyLevel != notification.NotifyAll && notifyLevel != notification.NotifyMentions && notifyLevel != notification.NotifyNone {
+	if !notification.IsValidNotifyLevel(notifyLevel) {
 		return openapi.UpdateChannelNotifications400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid notify_level")}, nil
 	}
 
@@ -952,23 +952,262 @@ func (h *Handler) UpdateChannelNotifications(ctx context.Context, request openap
 		ChannelID:    string(request.Id),
 		NotifyLevel:  notifyLevel,
 		EmailEnabled: request.Body.EmailEnabled,
+		PushEnabled:  request.Body.PushEnabled,
 	}
 
 	if err := h.notificationService.SetPreferences(ctx, pref); err != nil {
 		return nil, err
 	}
 
-	apiPrefs := notificationPreferencesToAPI(pref)
+	// Return the result of inheritance when push_enabled was omitted
+	effective, err := h.notificationService.GetPreferences(ctx, userID, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	apiPrefs := notificationPreferencesToAPI(effective)
 	return openapi.UpdateChannelNotifications200JSONResponse{
 		Preferences: apiPrefs,
 	}, nil
 }
 
+// ResetChannelNotifications removes the current user's notification
+// preferences for a channel, so it inherits the defaults again
+func (h *Handler) ResetChannelNotifications(ctx context.Context, request openapi.ResetChannelNotificationsRequestObject) (openapi.ResetChannelNotificationsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.ResetChannelNotifications401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check workspace membership
+	_, err = h.workspaceRepo.GetMembership(ctx, userID, ch.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.notificationService.ResetPreferences(ctx, userID, ch.ID); err != nil {
+		return nil, err
+	}
+
+	pref, err := h.notificationService.GetPreferences(ctx, userID, ch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.ResetChannelNotifications200JSONResponse{
+		Preferences: notificationPreferencesToAPI(pref),
+	}, nil
+}
+
 // notificationPreferencesToAPI converts notification preferences to API type
-func notificationPreferencesToAPI(pref *notification.NotificationPreference) openapi.NotificationPreferences {
+func notificationPreferencesToAPI(pref *notification.EffectivePreference) openapi.NotificationPreferences {
+	inherited := !pref.Overridden
 	return openapi.NotificationPreferences{
 		NotifyLevel:  openapi.NotifyLevel(pref.NotifyLevel),
 		EmailEnabled: pref.EmailEnabled,
+		PushEnabled:  &pref.PushEnabled,
+		Inherited:    &inherited,
+	}
+}
+
+// GetChannelNotificationDefaults returns the notify level members of a
+// channel inherit
+func (h *Handler) GetChannelNotificationDefaults(ctx context.Context, request openapi.GetChannelNotificationDefaultsRequestObject) (openapi.GetChannelNotificationDefaultsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.GetChannelNotificationDefaults401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check workspace membership
+	if _, err := h.workspaceRepo.GetMembership(ctx, userID, ch.WorkspaceID); err != nil {
+		return openapi.GetChannelNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+
+	level, err := h.notificationService.GetChannelDefault(ctx, ch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.GetChannelNotificationDefaults200JSONResponse(channelNotificationDefaultsToAPI(level)), nil
+}
+
+// UpdateChannelNotificationDefaults sets the notify level members of a
+// channel inherit (admin only)
+func (h *Handler) UpdateChannelNotificationDefaults(ctx context.Context, request openapi.UpdateChannelNotificationDefaultsRequestObject) (openapi.UpdateChannelNotificationDefaultsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.UpdateChannelNotificationDefaults401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	// DMs always notify on every message unless a member overrides it
+	if ch.Type == channel.TypeDM || ch.Type == channel.TypeGroupDM {
+		return openapi.UpdateChannelNotificationDefaults400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Cannot set notification defaults for DM channels")}, nil
+	}
+
+	membership, err := h.workspaceRepo.GetMembership(ctx, userID, ch.WorkspaceID)
+	if err != nil {
+		return openapi.UpdateChannelNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+	if !workspace.CanManageMembers(membership.Role) {
+		return openapi.UpdateChannelNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
+	}
+
+	level := string(request.Body.NotifyLevel)
+	if !notification.IsValidNotifyLevel(level) {
+		return openapi.UpdateChannelNotificationDefaults400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid notify_level")}, nil
+	}
+
+	if err := h.notificationService.SetChannelDefault(ctx, ch.ID, level); err != nil {
+		return nil, err
+	}
+
+	return openapi.UpdateChannelNotificationDefaults200JSONResponse(channelNotificationDefaultsToAPI(level)), nil
+}
+
+// DeleteChannelNotificationDefaults removes a channel's default notify
+// level (admin only)
+func (h *Handler) DeleteChannelNotificationDefaults(ctx context.Context, request openapi.DeleteChannelNotificationDefaultsRequestObject) (openapi.DeleteChannelNotificationDefaultsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.DeleteChannelNotificationDefaults401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := h.workspaceRepo.GetMembership(ctx, userID, ch.WorkspaceID)
+	if err != nil {
+		return openapi.DeleteChannelNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+	if !workspace.CanManageMembers(membership.Role) {
+		return openapi.DeleteChannelNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
+	}
+
+	if err := h.notificationService.ResetChannelDefault(ctx, ch.ID); err != nil {
+		return nil, err
+	}
+
+	return openapi.DeleteChannelNotificationDefaults200JSONResponse{Success: true}, nil
+}
+
+// channelNotificationDefaultsToAPI converts a channel's default notify
+// level, which is "" when it has none, to API type
+func channelNotificationDefaultsToAPI(level string) openapi.ChannelNotificationDefaults {
+	if level == "" {
+		return openapi.ChannelNotificationDefaults{}
+	}
+	apiLevel := openapi.NotifyLevel(level)
+	return openapi.ChannelNotificationDefaults{NotifyLevel: &apiLevel}
+}
+
+// GetChannelMentionPermissions returns a channel's overrides of who may use
+// @here, @channel and @everyone
+func (h *Handler) GetChannelMentionPermissions(ctx context.Context, request openapi.GetChannelMentionPermissionsRequestObject) (openapi.GetChannelMentionPermissionsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.GetChannelMentionPermissions401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check workspace membership
+	if _, err := h.workspaceRepo.GetMembership(ctx, userID, ch.WorkspaceID); err != nil {
+		return openapi.GetChannelMentionPermissions403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+
+	perms, err := h.channelRepo.GetMentionPermissions(ctx, ch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.GetChannelMentionPermissions200JSONResponse(mentionPermissionsToAPI(perms)), nil
+}
+
+// UpdateChannelMentionPermissions replaces a channel's overrides of who may
+// use @here, @channel and @everyone (admin only)
+func (h *Handler) UpdateChannelMentionPermissions(ctx context.Context, request openapi.UpdateChannelMentionPermissionsRequestObject) (openapi.UpdateChannelMentionPermissionsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.UpdateChannelMentionPermissions401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	if ch.Type == channel.TypeDM || ch.Type == channel.TypeGroupDM {
+		return openapi.UpdateChannelMentionPermissions400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Cannot set mention permissions for DM channels")}, nil
+	}
+
+	membership, err := h.workspaceRepo.GetMembership(ctx, userID, ch.WorkspaceID)
+	if err != nil {
+		return openapi.UpdateChannelMentionPermissions403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+	if !workspace.CanManageMembers(membership.Role) {
+		return openapi.UpdateChannelMentionPermissions403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
+	}
+
+	var perms channel.MentionPermissions
+	for _, field := range []struct {
+		name  string
+		value *openapi.PermissionLevel
+		dest  **string
+	}{
+		{"who_can_mention_here", request.Body.WhoCanMentionHere, &perms.Here},
+		{"who_can_mention_channel", request.Body.WhoCanMentionChannel, &perms.Channel},
+		{"who_can_mention_everyone", request.Body.WhoCanMentionEveryone, &perms.Everyone},
+	} {
+		if field.value == nil {
+			continue
+		}
+		v := string(*field.value)
+		if !workspace.IsValidPermissionLevel(workspace.PermissionLevel(v)) {
+			return openapi.UpdateChannelMentionPermissions400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid value for "+field.name)}, nil
+		}
+		*field.dest = &v
+	}
+
+	if err := h.channelRepo.SetMentionPermissions(ctx, ch.ID, &perms); err != nil {
+		return nil, err
+	}
+
+	return openapi.UpdateChannelMentionPermissions200JSONResponse(mentionPermissionsToAPI(&perms)), nil
+}
+
+// mentionPermissionsToAPI converts channel.MentionPermissions to API type
+func mentionPermissionsToAPI(perms *channel.MentionPermissions) openapi.ChannelMentionPermissions {
+	level := func(v *string) *openapi.PermissionLevel {
+		if v == nil {
+			return nil
+		}
+		l := openapi.PermissionLevel(*v)
+		return &l
+	}
+	return openapi.ChannelMentionPermissions{
+		WhoCanMentionHere:     level(perms.Here),
+		WhoCanMentionChannel:  level(perms.Channel),
+		WhoCanMentionEveryone: level(perms.Everyone),
 	}
 }
 
@@ -1058,7 +1297,7 @@ func (h *Handler) createChannelSystemMessage(ctx context.Context, ch *channel.Ch
 	if h.hub != nil {
 		msgWithUser, _ := h.messageRepo.GetByIDWithUser(ctx, msg.ID)
 		if msgWithUser != nil {
-			apiMsg := messageWithUserToAPI(msgWithUser)
+			apiMsg := h.messageWithUserToAPI(msgWithUser)
 			h.hub.BroadcastToChannel(ch.WorkspaceID, ch.ID, sse.NewMessageNewEvent(apiMsg))
 		}
 	}
@@ -1151,7 +1390,7 @@ func (h *Handler) createAddedSystemMessage(ctx context.Context, ch *channel.Chan
 	if h.hub != nil {
 		msgWithUser, _ := h.messageRepo.GetByIDWithUser(ctx, msg.ID)
 		if msgWithUser != nil {
-			apiMsg := messageWithUserToAPI(msgWithUser)
+			apiMsg := h.messageWithUserToAPI(msgWithUser)
 			h.hub.BroadcastToChannel(ch.WorkspaceID, ch.ID, sse.NewMessageNewEvent(apiMsg))
 		}
 	}
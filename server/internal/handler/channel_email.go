@@ -0,0 +1,450 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/mail"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/file"
+	"github.com/enzyme/server/internal/inbound"
+	"github.com/enzyme/server/internal/message"
+	"github.com/enzyme/server/internal/notification"
+	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/sse"
+	"github.com/enzyme/server/internal/workspace"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"github.com/oklog/ulid/v2"
+)
+
+// maxAllowedSenders bounds the sender allowlist of a channel email address
+const maxAllowedSenders = 100
+
+// GetChannelEmailAddress returns a channel's inbound email address
+// (workspace or channel admin only)
+func (h *Handler) GetChannelEmailAddress(ctx context.Context, request openapi.GetChannelEmailAddressRequestObject) (openapi.GetChannelEmailAddressResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.GetChannelEmailAddress401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+	canManage, err := h.canManageChannelEmail(ctx, userID, ch)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return openapi.GetChannelEmailAddress403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
+	}
+
+	addr, err := h.channelRepo.GetEmailAddress(ctx, ch.ID)
+	if err != nil {
+		if errors.Is(err, channel.ErrEmailAddressNotFound) {
+			return openapi.GetChannelEmailAddress404JSONResponse{NotFoundJSONResponse: notFoundResponse("Channel has no email address")}, nil
+		}
+		return nil, err
+	}
+
+	return openapi.GetChannelEmailAddress200JSONResponse(h.channelEmailAddressToAPI(addr)), nil
+}
+
+// UpdateChannelEmailAddress gives a channel an inbound email address, or
+// replaces the sender allowlist of its existing one (workspace or channel
+// admin only)
+func (h *Handler) UpdateChannelEmailAddress(ctx context.Context, request openapi.UpdateChannelEmailAddressRequestObject) (openapi.UpdateChannelEmailAddressResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.UpdateChannelEmailAddress401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	if !h.emailService.InboundEnabled() {
+		return openapi.UpdateChannelEmailAddress400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Inbound email is not enabled on this server")}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+	if ch.Type == channel.TypeDM || ch.Type == channel.TypeGroupDM {
+		return openapi.UpdateChannelEmailAddress400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "DM channels cannot have an email address")}, nil
+	}
+	canManage, err := h.canManageChannelEmail(ctx, userID, ch)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return openapi.UpdateChannelEmailAddress403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
+	}
+
+	if len(request.Body.AllowedSenders) > maxAllowedSenders {
+		return openapi.UpdateChannelEmailAddress400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, fmt.Sprintf("At most %d allowed senders can be set", maxAllowedSenders))}, nil
+	}
+	allowedSenders, invalid := normalizeAllowedSenders(request.Body.AllowedSenders)
+	if invalid != "" {
+		return openapi.UpdateChannelEmailAddress400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, fmt.Sprintf("%q is not an email address or domain", invalid))}, nil
+	}
+
+	addr, err := h.channelRepo.SetEmailAddress(ctx, ch.ID, userID, allowedSenders)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.UpdateChannelEmailAddress200JSONResponse(h.channelEmailAddressToAPI(addr)), nil
+}
+
+// DeleteChannelEmailAddress removes a channel's inbound email address
+// (workspace or channel admin only)
+func (h *Handler) DeleteChannelEmailAddress(ctx context.Context, request openapi.DeleteChannelEmailAddressRequestObject) (openapi.DeleteChannelEmailAddressResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.DeleteChannelEmailAddress401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+	canManage, err := h.canManageChannelEmail(ctx, userID, ch)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return openapi.DeleteChannelEmailAddress403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
+	}
+
+	if err := h.channelRepo.DeleteEmailAddress(ctx, ch.ID); err != nil {
+		if errors.Is(err, channel.ErrEmailAddressNotFound) {
+			return openapi.DeleteChannelEmailAddress404JSONResponse{NotFoundJSONResponse: notFoundResponse("Channel has no email address")}, nil
+		}
+		return nil, err
+	}
+
+	return openapi.DeleteChannelEmailAddress200JSONResponse{Success: true}, nil
+}
+
+// RotateChannelEmailAddress replaces a channel's inbound email address with
+// a new one (workspace or channel admin only)
+func (h *Handler) RotateChannelEmailAddress(ctx context.Context, request openapi.RotateChannelEmailAddressRequestObject) (openapi.RotateChannelEmailAddressResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.RotateChannelEmailAddress401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	if !h.emailService.InboundEnabled() {
+		return openapi.RotateChannelEmailAddress400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Inbound email is not enabled on this server")}, nil
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, string(request.Id))
+	if err != nil {
+		return nil, err
+	}
+	canManage, err := h.canManageChannelEmail(ctx, userID, ch)
+	if err != nil {
+		return nil, err
+	}
+	if !canManage {
+		return openapi.RotateChannelEmailAddress403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
+	}
+
+	addr, err := h.channelRepo.RotateEmailAddress(ctx, ch.ID)
+	if err != nil {
+		if errors.Is(err, channel.ErrEmailAddressNotFound) {
+			return openapi.RotateChannelEmailAddress404JSONResponse{NotFoundJSONResponse: notFoundResponse("Channel has no email address")}, nil
+		}
+		return nil, err
+	}
+
+	return openapi.RotateChannelEmailAddress200JSONResponse(h.channelEmailAddressToAPI(addr)), nil
+}
+
+// canManageChannelEmail reports whether a user may see and change a
+// channel's email address: workspace admins and channel admins may
+func (h *Handler) canManageChannelEmail(ctx context.Context, userID string, ch *channel.Channel) (bool, error) {
+	membership, err := h.workspaceRepo.GetMembership(ctx, userID, ch.WorkspaceID)
+	if err != nil {
+		if errors.Is(err, workspace.ErrNotAMember) {
+			return false, nil
+		}
+		return false, err
+	}
+	channelMembership, err := h.channelRepo.GetMembership(ctx, userID, ch.ID)
+	if err != nil && !errors.Is(err, channel.ErrNotChannelMember) {
+		return false, err
+	}
+	return workspace.CanManageMembers(membership.Role) || (channelMembership != nil && channel.CanManageChannel(channelMembership.ChannelRole)), nil
+}
+
+// normalizeAllowedSenders lower-cases and deduplicates a sender allowlist of
+// addresses and domains. It returns the first invalid entry, if any.
+func normalizeAllowedSenders(entries []string) (normalized []string, invalid string) {
+	normalized = make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		domain := strings.TrimPrefix(entry, "@")
+		if strings.Contains(domain, "@") {
+			if a, err := mail.ParseAddress(entry); err != nil || a.Address != entry {
+				return nil, entry
+			}
+		} else if !strings.Contains(domain, ".") || strings.ContainsFunc(domain, unicode.IsSpace) {
+			return nil, entry
+		}
+		if !slices.Contains(normalized, entry) {
+			normalized = append(normalized, entry)
+		}
+	}
+	return normalized, ""
+}
+
+// channelEmailAddressToAPI converts a channel.EmailAddress to API type
+func (h *Handler) channelEmailAddressToAPI(a *channel.EmailAddress) openapi.ChannelEmailAddress {
+	return openapi.ChannelEmailAddress{
+		Address:        openapi_types.Email(h.emailService.ChannelAddress(a.Token)),
+		AllowedSenders: a.AllowedSenders,
+		CreatedBy:      a.CreatedBy,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+}
+
+// channelEmails delivers mail sent to channels' email addresses, which are
+// channel+<token>@domain with the channel's secret token.
+type channelEmails struct {
+	h          *Handler
+	authservID string
+}
+
+// ChannelEmails returns the inbound mail handler for channels' email
+// addresses. Each email is posted as a bot message named after its sender,
+// with the subject as a bold header and the attachments as files.
+//
+// A channel's sender allowlist is matched against the From header, which
+// anyone can forge. With authservID set, mail to a channel with an
+// allowlist must also carry that mail server's Authentication-Results
+// verifying the sender; otherwise only the secret address protects the
+// channel.
+func (h *Handler) ChannelEmails(authservID string) inbound.Handler {
+	return channelEmails{h: h, authservID: authservID}
+}
+
+// Accepts checks a channel token's shape; whether a channel has it is only
+// checked on delivery.
+func (e channelEmails) Accepts(token string) bool {
+	_, err := hex.DecodeString(token)
+	return len(token) == 32 && err == nil
+}
+
+func (e channelEmails) Deliver(ctx context.Context, token string, msg *inbound.Message) error {
+	h := e.h
+	addr, err := h.channelRepo.GetEmailAddressByToken(ctx, strings.ToLower(token))
+	if err != nil {
+		if errors.Is(err, channel.ErrEmailAddressNotFound) {
+			return inbound.Reject("No channel has this address")
+		}
+		return err
+	}
+	if !addr.AllowsSender(msg.From) {
+		return inbound.Reject("%s may not send to this channel", msg.From)
+	}
+	if len(addr.AllowedSenders) > 0 && e.authservID != "" && !msg.SenderVerified(e.authservID) {
+		return inbound.Reject("%s could not be verified as the sender", msg.From)
+	}
+
+	ch, err := h.channelRepo.GetByID(ctx, addr.ChannelID)
+	if err != nil {
+		return err
+	}
+	if ch.ArchivedAt != nil {
+		return inbound.Reject("The channel is archived")
+	}
+
+	content := channelEmailContent(msg.Subject, msg.Text)
+	var attachments []inbound.Attachment
+	var tooLarge []string
+	if h.storage != nil {
+		for _, a := range msg.Attachments {
+			if int64(len(a.Data)) > h.maxUploadSize {
+				tooLarge = append(tooLarge, a.Filename)
+				continue
+			}
+			attachments = append(attachments, a)
+		}
+	}
+	if len(tooLarge) > 0 {
+		note := tooLargeNote(tooLarge, h.maxUploadSize)
+		if room := maxMessageLength - utf8.RuneCountInString(note) - 2; utf8.RuneCountInString(content) > room {
+			content = string([]rune(content)[:max(0, room-1)]) + "…"
+		}
+		content = strings.TrimSpace(content + "\n\n" + note)
+	}
+	if content == "" && len(attachments) == 0 {
+		return inbound.Reject("The email is empty")
+	}
+
+	botName := msg.FromName
+	if botName == "" {
+		botName = msg.From
+	}
+	m := &message.Message{
+		ChannelID: ch.ID,
+		BotName:   &botName,
+		Content:   content,
+		Type:      message.MessageTypeBot,
+	}
+	if err := h.messageRepo.Create(ctx, m); err != nil {
+		return err
+	}
+
+	for _, a := range attachments {
+		if err := h.storeEmailAttachment(ctx, ch, m.ID, a); err != nil {
+			slog.Error("failed to store email attachment", "channel_id", ch.ID, "message_id", m.ID, "error", err)
+		}
+	}
+
+	msgWithUser, err := h.messageRepo.GetByIDWithUser(ctx, m.ID)
+	if err != nil {
+		msgWithUser = &message.MessageWithUser{Message: *m, UserDisplayName: botName}
+	}
+	if len(attachments) > 0 {
+		msgWithUser.Attachments, _ = h.fileRepo.ListForMessage(ctx, m.ID)
+	}
+	if h.hub != nil {
+		h.hub.BroadcastToChannel(ch.WorkspaceID, ch.ID, sse.NewMessageNewEvent(h.messageWithUserToAPI(msgWithUser)))
+	}
+
+	if h.notificationService != nil {
+		channelInfo := &notification.ChannelInfo{
+			ID:          ch.ID,
+			WorkspaceID: ch.WorkspaceID,
+			Name:        ch.Name,
+			Type:        ch.Type,
+		}
+		msgInfo := &notification.MessageInfo{
+			ID:         m.ID,
+			ChannelID:  ch.ID,
+			SenderName: botName,
+			Content:    m.Content,
+		}
+		go func() {
+			_ = h.notificationService.Notify(context.Background(), channelInfo, msgInfo)
+		}()
+	}
+	return nil
+}
+
+// storeEmailAttachment stores a file attached to an email posted into a
+// channel, linked to its message
+func (h *Handler) storeEmailAttachment(ctx context.Context, ch *channel.Channel, messageID string, a inbound.Attachment) error {
+	size := int64(len(a.Data))
+	if size > h.maxUploadSize {
+		return errors.New("attachment too large")
+	}
+	filename := sanitizeFilename(a.Filename)
+	if filename == "" || filename == "." {
+		filename = "attachment"
+		if exts, _ := mime.ExtensionsByType(a.ContentType); len(exts) > 0 {
+			filename += exts[0]
+		}
+	}
+	contentType := a.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	storageKey := ch.WorkspaceID + "/" + ch.ID + "/" + ulid.Make().String() + filepath.Ext(filename)
+	if err := h.storage.Put(ctx, storageKey, bytes.NewReader(a.Data), size, contentType); err != nil {
+		return err
+	}
+
+	attachment := &file.Attachment{
+		ChannelID:   ch.ID,
+		MessageID:   &messageID,
+		Filename:    filename,
+		ContentType: contentType,
+		SizeBytes:   size,
+		StoragePath: storageKey,
+	}
+	if h.scanService != nil {
+		attachment.ScanStatus = file.ScanStatusPending
+	}
+	if err := h.fileRepo.Create(ctx, attachment); err != nil {
+		_ = h.storage.Delete(ctx, storageKey)
+		return err
+	}
+	if h.scanService != nil {
+		h.scanService.ScanAsync(attachment)
+	}
+	return nil
+}
+
+// tooLargeNote tells the channel which attachments of an email were left out
+// for being larger than the upload limit
+func tooLargeNote(filenames []string, limit int64) string {
+	names := make([]string, len(filenames))
+	for i, name := range filenames {
+		name = sanitizeFilename(name)
+		if name == "" || name == "." {
+			name = "attachment"
+		}
+		names[i] = emailMentionMarkup.ReplaceAllString(name, "<\u200b$1")
+	}
+	return fmt.Sprintf("_Not attached, larger than the %d MB upload limit: %s_", limit/(1024*1024), strings.Join(names, ", "))
+}
+
+// emailMentionMarkup matches the mrkdwn of mentions, which mail must not be
+// able to trigger
+var emailMentionMarkup = regexp.MustCompile(`<([@!#])`)
+
+// blankLines matches runs of more than one blank line
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// channelEmailContent builds the content of a message posted by email: the
+// subject as a bold header, then the body as plain text
+func channelEmailContent(subject, body string) string {
+	sanitize := func(s string) string {
+		s = strings.ReplaceAll(s, "\r\n", "\n")
+		s = strings.Map(func(r rune) rune {
+			if r == '\n' || r == '\t' {
+				return r
+			}
+			if unicode.IsControl(r) || r == utf8.RuneError {
+				return -1
+			}
+			return r
+		}, s)
+		// A zero-width space keeps mention markup from being parsed
+		return emailMentionMarkup.ReplaceAllString(s, "<\u200b$1")
+	}
+
+	subject = strings.Join(strings.Fields(sanitize(subject)), " ")
+	lines := strings.Split(sanitize(body), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	body = strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+
+	content := body
+	if subject != "" {
+		content = strings.TrimSpace("*" + subject + "*\n\n" + body)
+	}
+	if utf8.RuneCountInString(content) > maxMessageLength {
+		content = string([]rune(content)[:maxMessageLength-1]) + "…"
+	}
+	return content
+}
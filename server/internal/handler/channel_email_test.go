@@ -0,0 +1,332 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/email"
+	"github.com/enzyme/server/internal/inbound"
+	"github.com/enzyme/server/internal/message"
+	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/testutil"
+)
+
+func TestChannelEmailAddress_Lifecycle(t *testing.T) {
+	h, db := testHandler(t)
+	h.emailService = email.NewTestService(true, "http://localhost:8080").WithInboundDomain("in.test.com")
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	channelAdmin := testutil.CreateTestUser(t, db, "chadmin@test.com", "Channel Admin")
+	member := testutil.CreateTestUser(t, db, "member@test.com", "Member")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+	addWorkspaceMember(t, db, channelAdmin.ID, ws.ID, "member")
+	addWorkspaceMember(t, db, member.ID, ws.ID, "member")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "alerts", channel.TypePublic)
+	adminRole := channel.ChannelRoleAdmin
+	addChannelMember(t, db, channelAdmin.ID, ch.ID, &adminRole)
+	addChannelMember(t, db, member.ID, ch.ID, nil)
+
+	resp, err := h.UpdateChannelEmailAddress(ctxWithUser(t, h, member.ID), openapi.UpdateChannelEmailAddressRequestObject{
+		Id:   ch.ID,
+		Body: &openapi.UpdateChannelEmailAddressJSONRequestBody{AllowedSenders: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChannelEmailAddress() error = %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateChannelEmailAddress403JSONResponse); !ok {
+		t.Fatalf("expected 403 for a channel member, got %T", resp)
+	}
+
+	resp, err = h.UpdateChannelEmailAddress(ctxWithUser(t, h, channelAdmin.ID), openapi.UpdateChannelEmailAddressRequestObject{
+		Id:   ch.ID,
+		Body: &openapi.UpdateChannelEmailAddressJSONRequestBody{AllowedSenders: []string{" Alerts@Vendor.com", "@monitoring.example.com", "alerts@vendor.com"}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChannelEmailAddress() error = %v", err)
+	}
+	created, ok := resp.(openapi.UpdateChannelEmailAddress200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 for a channel admin, got %T", resp)
+	}
+	address := string(created.Address)
+	if !strings.HasPrefix(address, "channel+") || !strings.HasSuffix(address, "@in.test.com") {
+		t.Errorf("address = %q", address)
+	}
+	if want := []string{"alerts@vendor.com", "@monitoring.example.com"}; strings.Join(created.AllowedSenders, ",") != strings.Join(want, ",") {
+		t.Errorf("allowed_senders = %v, want %v", created.AllowedSenders, want)
+	}
+
+	// Updating the allowlist keeps the address
+	resp, err = h.UpdateChannelEmailAddress(ctxWithUser(t, h, owner.ID), openapi.UpdateChannelEmailAddressRequestObject{
+		Id:   ch.ID,
+		Body: &openapi.UpdateChannelEmailAddressJSONRequestBody{AllowedSenders: []string{}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateChannelEmailAddress() error = %v", err)
+	}
+	if updated := resp.(openapi.UpdateChannelEmailAddress200JSONResponse); string(updated.Address) != address || len(updated.AllowedSenders) != 0 {
+		t.Errorf("after update: address = %q, allowed_senders = %v", updated.Address, updated.AllowedSenders)
+	}
+
+	rotateResp, err := h.RotateChannelEmailAddress(ctxWithUser(t, h, owner.ID), openapi.RotateChannelEmailAddressRequestObject{Id: ch.ID})
+	if err != nil {
+		t.Fatalf("RotateChannelEmailAddress() error = %v", err)
+	}
+	rotated, ok := rotateResp.(openapi.RotateChannelEmailAddress200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200, got %T", rotateResp)
+	}
+	if string(rotated.Address) == address {
+		t.Error("rotating kept the same address")
+	}
+
+	getResp, err := h.GetChannelEmailAddress(ctxWithUser(t, h, member.ID), openapi.GetChannelEmailAddressRequestObject{Id: ch.ID})
+	if err != nil {
+		t.Fatalf("GetChannelEmailAddress() error = %v", err)
+	}
+	if _, ok := getResp.(openapi.GetChannelEmailAddress403JSONResponse); !ok {
+		t.Fatalf("expected 403 for a channel member, got %T", getResp)
+	}
+
+	deleteResp, err := h.DeleteChannelEmailAddress(ctxWithUser(t, h, owner.ID), openapi.DeleteChannelEmailAddressRequestObject{Id: ch.ID})
+	if err != nil {
+		t.Fatalf("DeleteChannelEmailAddress() error = %v", err)
+	}
+	if _, ok := deleteResp.(openapi.DeleteChannelEmailAddress200JSONResponse); !ok {
+		t.Fatalf("expected 200, got %T", deleteResp)
+	}
+	getResp, err = h.GetChannelEmailAddress(ctxWithUser(t, h, owner.ID), openapi.GetChannelEmailAddressRequestObject{Id: ch.ID})
+	if err != nil {
+		t.Fatalf("GetChannelEmailAddress() error = %v", err)
+	}
+	if _, ok := getResp.(openapi.GetChannelEmailAddress404JSONResponse); !ok {
+		t.Fatalf("expected 404 after delete, got %T", getResp)
+	}
+}
+
+func TestUpdateChannelEmailAddress_Validation(t *testing.T) {
+	h, db := testHandler(t)
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "alerts", channel.TypePublic)
+	ctx := ctxWithUser(t, h, owner.ID)
+
+	request := openapi.UpdateChannelEmailAddressRequestObject{
+		Id:   ch.ID,
+		Body: &openapi.UpdateChannelEmailAddressJSONRequestBody{AllowedSenders: []string{}},
+	}
+	resp, err := h.UpdateChannelEmailAddress(ctx, request)
+	if err != nil {
+		t.Fatalf("UpdateChannelEmailAddress() error = %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateChannelEmailAddress400JSONResponse); !ok {
+		t.Fatalf("expected 400 while inbound email is disabled, got %T", resp)
+	}
+
+	h.emailService = email.NewTestService(true, "http://localhost:8080").WithInboundDomain("in.test.com")
+	for _, senders := range [][]string{{"not an address"}, {"localhost"}, {"a@b@example.com"}} {
+		request.Body.AllowedSenders = senders
+		resp, err := h.UpdateChannelEmailAddress(ctx, request)
+		if err != nil {
+			t.Fatalf("UpdateChannelEmailAddress() error = %v", err)
+		}
+		if _, ok := resp.(openapi.UpdateChannelEmailAddress400JSONResponse); !ok {
+			t.Errorf("expected 400 for allowed senders %v, got %T", senders, resp)
+		}
+	}
+}
+
+func TestChannelEmails_PostsBotMessage(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "alerts", channel.TypePublic)
+	addr, err := h.channelRepo.SetEmailAddress(ctx, ch.ID, owner.ID, []string{"vendor.com"})
+	if err != nil {
+		t.Fatalf("SetEmailAddress() error = %v", err)
+	}
+
+	err = h.ChannelEmails("").Deliver(ctx, strings.ToUpper(addr.Token), &inbound.Message{
+		From:     "alerts@vendor.com",
+		FromName: "Vendor Alerts",
+		Subject:  "Disk  almost full",
+		Text:     "Host db-1 is at 95%.\r\n\r\n\r\n\r\nPing <!everyone> and <@U123>   \r\n",
+		Attachments: []inbound.Attachment{
+			{Filename: "../graph.png", ContentType: "image/png", Data: []byte("png")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	var id string
+	if err := db.QueryRow(`SELECT id FROM messages WHERE channel_id = ? AND type = ?`, ch.ID, message.MessageTypeBot).Scan(&id); err != nil {
+		t.Fatalf("reading posted message: %v", err)
+	}
+	msg, err := h.messageRepo.GetByIDWithUser(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByIDWithUser() error = %v", err)
+	}
+	if want := "*Disk almost full*\n\nHost db-1 is at 95%.\n\nPing <\u200b!everyone> and <\u200b@U123>"; msg.Content != want {
+		t.Errorf("content = %q, want %q", msg.Content, want)
+	}
+	if msg.UserID != nil {
+		t.Errorf("user_id = %v, want none", *msg.UserID)
+	}
+	if msg.UserDisplayName != "Vendor Alerts" {
+		t.Errorf("user_display_name = %q", msg.UserDisplayName)
+	}
+
+	attachments, err := h.fileRepo.ListForMessage(ctx, id)
+	if err != nil {
+		t.Fatalf("ListForMessage() error = %v", err)
+	}
+	if len(attachments) != 1 || attachments[0].Filename != "graph.png" || attachments[0].ContentType != "image/png" {
+		t.Fatalf("attachments = %+v", attachments)
+	}
+	rc, err := h.storage.Get(ctx, attachments[0].StoragePath)
+	if err != nil {
+		t.Fatalf("reading stored attachment: %v", err)
+	}
+	rc.Close()
+}
+
+func TestChannelEmails_NotesOversizeAttachments(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "alerts", channel.TypePublic)
+	addr, err := h.channelRepo.SetEmailAddress(ctx, ch.ID, owner.ID, nil)
+	if err != nil {
+		t.Fatalf("SetEmailAddress() error = %v", err)
+	}
+	h.maxUploadSize = 1024 * 1024
+
+	err = h.ChannelEmails("").Deliver(ctx, addr.Token, &inbound.Message{
+		From:    "alerts@vendor.com",
+		Subject: "Dump",
+		Attachments: []inbound.Attachment{
+			{Filename: "core.dump", ContentType: "application/octet-stream", Data: make([]byte, 2*1024*1024)},
+			{Filename: "notes.txt", ContentType: "text/plain", Data: []byte("notes")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	var id, content string
+	if err := db.QueryRow(`SELECT id, content FROM messages WHERE channel_id = ?`, ch.ID).Scan(&id, &content); err != nil {
+		t.Fatalf("reading posted message: %v", err)
+	}
+	if want := "*Dump*\n\n_Not attached, larger than the 1 MB upload limit: core.dump_"; content != want {
+		t.Errorf("content = %q, want %q", content, want)
+	}
+	attachments, err := h.fileRepo.ListForMessage(ctx, id)
+	if err != nil {
+		t.Fatalf("ListForMessage() error = %v", err)
+	}
+	if len(attachments) != 1 || attachments[0].Filename != "notes.txt" {
+		t.Errorf("attachments = %+v", attachments)
+	}
+}
+
+func TestChannelEmails_VerifiesAllowedSenders(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "alerts", channel.TypePublic)
+	addr, err := h.channelRepo.SetEmailAddress(ctx, ch.ID, owner.ID, []string{"vendor.com"})
+	if err != nil {
+		t.Fatalf("SetEmailAddress() error = %v", err)
+	}
+	open := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "inbox", channel.TypePublic)
+	openAddr, err := h.channelRepo.SetEmailAddress(ctx, open.ID, owner.ID, nil)
+	if err != nil {
+		t.Fatalf("SetEmailAddress() error = %v", err)
+	}
+	emails := h.ChannelEmails("mx.example.org")
+
+	tests := []struct {
+		name    string
+		token   string
+		results []string
+		posted  bool
+	}{
+		{"forged From", addr.Token, nil, false},
+		{"failed DMARC", addr.Token, []string{"mx.example.org; dmarc=fail header.from=vendor.com"}, false},
+		{"verified", addr.Token, []string{"mx.example.org; dmarc=pass header.from=vendor.com"}, true},
+		{"no allowlist", openAddr.Token, nil, true},
+	}
+	for _, tt := range tests {
+		err := emails.Deliver(ctx, tt.token, &inbound.Message{From: "alerts@vendor.com", Text: tt.name, AuthResults: tt.results})
+		var reject *inbound.RejectError
+		if tt.posted && err != nil {
+			t.Errorf("%s: Deliver() error = %v", tt.name, err)
+		}
+		if !tt.posted && !errors.As(err, &reject) {
+			t.Errorf("%s: Deliver() error = %v, want a rejection", tt.name, err)
+		}
+	}
+}
+
+func TestChannelEmails_Rejects(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "alerts", channel.TypePublic)
+	addr, err := h.channelRepo.SetEmailAddress(ctx, ch.ID, owner.ID, []string{"alerts@vendor.com"})
+	if err != nil {
+		t.Fatalf("SetEmailAddress() error = %v", err)
+	}
+	archived := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "old-alerts", channel.TypePublic)
+	archivedAddr, err := h.channelRepo.SetEmailAddress(ctx, archived.ID, owner.ID, nil)
+	if err != nil {
+		t.Fatalf("SetEmailAddress() error = %v", err)
+	}
+	if _, err := db.Exec(`UPDATE channels SET archived_at = ? WHERE id = ?`, time.Now().UTC().Format(time.RFC3339), archived.ID); err != nil {
+		t.Fatalf("archiving channel: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		msg   *inbound.Message
+	}{
+		{"unknown address", strings.Repeat("0", 32), &inbound.Message{From: "alerts@vendor.com", Text: "hi"}},
+		{"sender not allowed", addr.Token, &inbound.Message{From: "someone@vendor.com", Text: "hi"}},
+		{"archived channel", archivedAddr.Token, &inbound.Message{From: "alerts@vendor.com", Text: "hi"}},
+		{"empty email", addr.Token, &inbound.Message{From: "alerts@vendor.com", Text: " \n"}},
+	}
+	for _, tt := range tests {
+		err := h.ChannelEmails("").Deliver(ctx, tt.token, tt.msg)
+		var reject *inbound.RejectError
+		if !errors.As(err, &reject) {
+			t.Errorf("%s: Deliver() error = %v, want a rejection", tt.name, err)
+		}
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM messages WHERE type = ?`, message.MessageTypeBot).Scan(&count); err != nil {
+		t.Fatalf("counting messages: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("%d messages posted, want 0", count)
+	}
+
+	if h.ChannelEmails("").Accepts("not-a-token") {
+		t.Error("Accepts() accepted a malformed token")
+	}
+}
@@ -463,3 +463,60 @@ func TestListChannelMembers_PrivateNonMember(t *testing.T) {
 		t.Fatalf("expected 404 response, got %T", resp)
 	}
 }
+
+func TestResetChannelNotifications_InheritsDefaults(t *testing.T) {
+	h, db := testHandler(t)
+
+	user := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", "public")
+	ctx := ctxWithUser(t, h, user.ID)
+
+	pushOff := false
+	resp, err := h.UpdateChannelNotifications(ctx, openapi.UpdateChannelNotificationsRequestObject{
+		Id:   ch.ID,
+		Body: &openapi.UpdateChannelNotificationsJSONRequestBody{NotifyLevel: "all", EmailEnabled: false, PushEnabled: &pushOff},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated, ok := resp.(openapi.UpdateChannelNotifications200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	if *updated.Preferences.Inherited || *updated.Preferences.PushEnabled {
+		t.Errorf("preferences = %+v, want overridden with push disabled", updated.Preferences)
+	}
+
+	resetResp, err := h.ResetChannelNotifications(ctx, openapi.ResetChannelNotificationsRequestObject{Id: ch.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reset, ok := resetResp.(openapi.ResetChannelNotifications200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", resetResp)
+	}
+	prefs := reset.Preferences
+	if !*prefs.Inherited || prefs.NotifyLevel != "mentions" || !prefs.EmailEnabled || !*prefs.PushEnabled {
+		t.Errorf("preferences = %+v, want the built-in defaults", prefs)
+	}
+}
+
+func TestUpdateChannelNotificationDefaults_CannotSetForDM(t *testing.T) {
+	h, db := testHandler(t)
+
+	user := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "WS")
+	dm := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "dm", "dm")
+
+	resp, err := h.UpdateChannelNotificationDefaults(ctxWithUser(t, h, user.ID), openapi.UpdateChannelNotificationDefaultsRequestObject{
+		Id:   dm.ID,
+		Body: &openapi.UpdateChannelNotificationDefaultsJSONRequestBody{NotifyLevel: "none"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateChannelNotificationDefaults400JSONResponse); !ok {
+		t.Fatalf("expected 400 response, got %T", resp)
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/enzyme/server/internal/auth"
+	"github.com/enzyme/server/internal/inbound"
+	"github.com/enzyme/server/internal/message"
+	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/user"
+)
+
+// emailReplies delivers replies to notification emails, which are sent to
+// reply+<token>@domain with a token signed for the recipient and message.
+type emailReplies struct {
+	h *Handler
+}
+
+// EmailReplies returns the inbound mail handler for replies to notification
+// emails. Each reply is posted as its sender through SendMessage, into the
+// thread of the message it answers or else its channel.
+func (h *Handler) EmailReplies() inbound.Handler {
+	return emailReplies{h: h}
+}
+
+// Accepts checks a reply token's shape; its signature can only be checked
+// once the sender is known.
+func (e emailReplies) Accepts(token string) bool {
+	messageID, sig, ok := strings.Cut(token, "-")
+	return ok && messageID != "" && sig != ""
+}
+
+func (e emailReplies) Deliver(ctx context.Context, token string, msg *inbound.Message) error {
+	h := e.h
+	if h.signer == nil {
+		return inbound.Reject("Replies by email are not enabled")
+	}
+	// Out-of-office and other automatic replies to notifications are
+	// accepted and dropped: posting them would be noise, and bouncing them
+	// could start a loop with the responder
+	if msg.Automatic {
+		slog.Info("dropping automatic reply", "from", msg.From)
+		return nil
+	}
+
+	// The token is signed for the user the notification was sent to, so
+	// replies forwarded to or spoofed by anyone else don't verify
+	sender, err := h.userRepo.GetByEmail(ctx, msg.From)
+	if err != nil && !errors.Is(err, user.ErrUserNotFound) {
+		return err
+	}
+	if sender == nil || sender.Status == user.StatusDeactivated || sender.PendingApproval {
+		return inbound.Reject("Replies are only accepted from the address the notification was sent to")
+	}
+	messageID, err := h.signer.VerifyReplyToken(token, sender.ID)
+	if err != nil {
+		return inbound.Reject("Replies are only accepted from the address the notification was sent to")
+	}
+
+	content := inbound.StripReply(msg.Text)
+	if content == "" {
+		return inbound.Reject("The reply is empty")
+	}
+
+	original, err := h.messageRepo.GetByID(ctx, messageID)
+	if err != nil {
+		if errors.Is(err, message.ErrMessageNotFound) {
+			return inbound.Reject("The message you replied to no longer exists")
+		}
+		return err
+	}
+
+	resp, err := h.SendMessage(auth.WithUserID(ctx, sender.ID), openapi.SendMessageRequestObject{
+		Id: original.ChannelID,
+		Body: &openapi.SendMessageJSONRequestBody{
+			Content:        &content,
+			ThreadParentId: original.ThreadParentID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	switch r := resp.(type) {
+	case openapi.SendMessage200JSONResponse:
+		return nil
+	case openapi.SendMessage400JSONResponse:
+		return inbound.Reject("%s", r.Error.Message)
+	case openapi.SendMessage403JSONResponse:
+		return inbound.Reject("%s", r.Error.Message)
+	case openapi.SendMessage404JSONResponse:
+		return inbound.Reject("%s", r.Error.Message)
+	case openapi.SendMessage409JSONResponse:
+		// There's no way to confirm by email
+		return inbound.Reject("%s; post it in Enzyme instead", r.Error.Message)
+	default:
+		return fmt.Errorf("unexpected response sending email reply: %T", resp)
+	}
+}
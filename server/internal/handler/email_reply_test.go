@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/inbound"
+	"github.com/enzyme/server/internal/testutil"
+)
+
+func TestEmailReplies_PostsIntoThread(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	author := testutil.CreateTestUser(t, db, "author@test.com", "Author")
+	replier := testutil.CreateTestUser(t, db, "replier@test.com", "Replier")
+	ws := testutil.CreateTestWorkspace(t, db, author.ID, "Test Workspace")
+	addWorkspaceMember(t, db, replier.ID, ws.ID, "member")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, author.ID, "general", channel.TypePublic)
+	addChannelMember(t, db, replier.ID, ch.ID, nil)
+
+	parent := testutil.CreateTestMessage(t, db, ch.ID, author.ID, "deploy today?")
+	reply := testutil.CreateTestMessage(t, db, ch.ID, author.ID, "<@"+replier.ID+"> can you check?")
+	if _, err := db.Exec(`UPDATE messages SET thread_parent_id = ? WHERE id = ?`, parent.ID, reply.ID); err != nil {
+		t.Fatalf("making reply: %v", err)
+	}
+
+	token := h.signer.ReplyToken(replier.ID, reply.ID)
+	err := h.EmailReplies().Deliver(ctx, token, &inbound.Message{
+		From: "replier@test.com",
+		Text: "Looks good to me\n\nOn Tue, Mar 10, 2026 Enzyme <reply+" + token + "@reply.test.com> wrote:\n> can you check?\n",
+	})
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	var content, userID string
+	var threadParentID *string
+	if err := db.QueryRow(`SELECT content, user_id, thread_parent_id FROM messages WHERE user_id = ?`, replier.ID).
+		Scan(&content, &userID, &threadParentID); err != nil {
+		t.Fatalf("reading posted message: %v", err)
+	}
+	if content != "Looks good to me" {
+		t.Errorf("content = %q", content)
+	}
+	if threadParentID == nil || *threadParentID != parent.ID {
+		t.Errorf("thread_parent_id = %v, want %s", threadParentID, parent.ID)
+	}
+}
+
+func TestEmailReplies_PostsIntoChannel(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	u := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, u.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, u.ID, "general", channel.TypePublic)
+	original := testutil.CreateTestMessage(t, db, ch.ID, u.ID, "standup in 5")
+
+	err := h.EmailReplies().Deliver(ctx, h.signer.ReplyToken(u.ID, original.ID), &inbound.Message{From: "user@test.com", Text: "omw"})
+	if err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+
+	var threadParentID *string
+	if err := db.QueryRow(`SELECT thread_parent_id FROM messages WHERE content = 'omw'`).Scan(&threadParentID); err != nil {
+		t.Fatalf("reading posted message: %v", err)
+	}
+	if threadParentID != nil {
+		t.Errorf("thread_parent_id = %v, want the reply in the channel", *threadParentID)
+	}
+}
+
+func TestEmailReplies_DropsAutomaticReplies(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	u := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, u.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, u.ID, "general", channel.TypePublic)
+	original := testutil.CreateTestMessage(t, db, ch.ID, u.ID, "standup in 5")
+
+	err := h.EmailReplies().Deliver(ctx, h.signer.ReplyToken(u.ID, original.ID), &inbound.Message{
+		From:      "user@test.com",
+		Subject:   "Out of office",
+		Text:      "I'm away until Monday",
+		Automatic: true,
+	})
+	if err != nil {
+		t.Fatalf("Deliver() error = %v, want the reply dropped without a bounce", err)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM messages WHERE channel_id = ?`, ch.ID).Scan(&count); err != nil {
+		t.Fatalf("counting messages: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("channel has %d messages, want the automatic reply dropped", count)
+	}
+}
+
+func TestEmailReplies_Rejects(t *testing.T) {
+	h, db := testHandler(t)
+	ctx := context.Background()
+
+	u := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	other := testutil.CreateTestUser(t, db, "other@test.com", "Other")
+	ws := testutil.CreateTestWorkspace(t, db, u.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, u.ID, "general", channel.TypePrivate)
+	original := testutil.CreateTestMessage(t, db, ch.ID, u.ID, "secret plans")
+
+	token := h.signer.ReplyToken(u.ID, original.ID)
+	tests := []struct {
+		name  string
+		token string
+		msg   *inbound.Message
+	}{
+		{"unknown sender", token, &inbound.Message{From: "stranger@test.com", Text: "hi"}},
+		{"token of another user", token, &inbound.Message{From: "other@test.com", Text: "hi"}},
+		{"empty reply", token, &inbound.Message{From: "user@test.com", Text: "> quoted only"}},
+		{"not a member", h.signer.ReplyToken(other.ID, original.ID), &inbound.Message{From: "other@test.com", Text: "hi"}},
+	}
+	for _, tt := range tests {
+		err := h.EmailReplies().Deliver(ctx, tt.token, tt.msg)
+		var reject *inbound.RejectError
+		if !errors.As(err, &reject) {
+			t.Errorf("%s: Deliver() error = %v, want a RejectError", tt.name, err)
+		}
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM messages`).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("%d messages, want only the original", count)
+	}
+}
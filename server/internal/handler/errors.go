@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/scanner"
 )
 
 // newError creates an ApiError with the given code and message
@@ -21,15 +24,19 @@ func newErrorResponse(code, message string) openapi.ApiErrorResponse {
 
 // Common error codes
 const (
-	ErrCodeInvalidJSON      = "INVALID_JSON"
-	ErrCodeInternalError    = "INTERNAL_ERROR"
-	ErrCodeNotAuthenticated = "NOT_AUTHENTICATED"
-	ErrCodeNotAMember       = "NOT_A_MEMBER"
-	ErrCodeNotFound         = "NOT_FOUND"
-	ErrCodePermissionDenied = "PERMISSION_DENIED"
-	ErrCodeValidationError  = "VALIDATION_ERROR"
-	ErrCodeConflict         = "CONFLICT"
-	ErrCodeFilesDisabled    = "FILES_DISABLED"
+	ErrCodeInvalidJSON          = "INVALID_JSON"
+	ErrCodeInternalError        = "INTERNAL_ERROR"
+	ErrCodeNotAuthenticated     = "NOT_AUTHENTICATED"
+	ErrCodeNotAMember           = "NOT_A_MEMBER"
+	ErrCodeNotFound             = "NOT_FOUND"
+	ErrCodePermissionDenied     = "PERMISSION_DENIED"
+	ErrCodeValidationError      = "VALIDATION_ERROR"
+	ErrCodeConflict             = "CONFLICT"
+	ErrCodeConfirmationRequired = "CONFIRMATION_REQUIRED"
+	ErrCodeFilesDisabled        = "FILES_DISABLED"
+	ErrCodeFileInfected         = "FILE_INFECTED"
+	ErrCodeFileScanPending      = "FILE_SCAN_PENDING"
+	ErrCodeFileScanFailed       = "FILE_SCAN_FAILED"
 )
 
 // Error response helpers that return typed shared response components.
@@ -62,3 +69,15 @@ func conflictResponse(msg string) openapi.ConflictJSONResponse {
 func filesDisabledResponse() openapi.ForbiddenJSONResponse {
 	return openapi.ForbiddenJSONResponse(newErrorResponse(ErrCodeFilesDisabled, "File uploads are disabled"))
 }
+
+// scanBlockedResponse maps a scanner.Policy rejection to a 403 response.
+func scanBlockedResponse(err error) openapi.ForbiddenJSONResponse {
+	switch {
+	case errors.Is(err, scanner.ErrInfected):
+		return openapi.ForbiddenJSONResponse(newErrorResponse(ErrCodeFileInfected, "File has been quarantined by the virus scanner"))
+	case errors.Is(err, scanner.ErrScanPending):
+		return openapi.ForbiddenJSONResponse(newErrorResponse(ErrCodeFileScanPending, "File is still being scanned"))
+	default:
+		return openapi.ForbiddenJSONResponse(newErrorResponse(ErrCodeFileScanFailed, "File could not be scanned"))
+	}
+}
@@ -104,23 +104,32 @@ func (h *Handler) UploadFile(ctx context.Context, request openapi.UploadFileRequ
 		SizeBytes:   size,
 		StoragePath: storageKey,
 	}
+	if h.scanService != nil {
+		attachment.ScanStatus = file.ScanStatusPending
+	}
 
 	if err := h.fileRepo.Create(ctx, attachment); err != nil {
 		_ = h.storage.Delete(ctx, storageKey)
 		return nil, err
 	}
 
+	if h.scanService != nil {
+		h.scanService.ScanAsync(attachment)
+	}
+
 	return openapi.UploadFile200JSONResponse{
 		File: struct {
-			ContentType string `json:"content_type"`
-			Filename    string `json:"filename"`
-			Id          string `json:"id"`
-			Size        int    `json:"size"`
+			ContentType string                  `json:"content_type"`
+			Filename    string                  `json:"filename"`
+			Id          string                  `json:"id"`
+			ScanStatus  *openapi.FileScanStatus `json:"scan_status,omitempty"`
+			Size        int                     `json:"size"`
 		}{
 			Id:          attachment.ID,
 			Filename:    attachment.Filename,
 			Size:        int(size),
 			ContentType: attachment.ContentType,
+			ScanStatus:  scanStatusToAPI(attachment.ScanStatus),
 		},
 	}, nil
 }
@@ -164,6 +173,10 @@ func (h *Handler) DownloadFile(ctx context.Context, request openapi.DownloadFile
 		return openapi.DownloadFile403JSONResponse{ForbiddenJSONResponse: notAMemberResponse("Not a member of this channel")}, nil
 	}
 
+	if err := h.scanPolicy.Check(attachment.ScanStatus); err != nil {
+		return openapi.DownloadFile403JSONResponse{ForbiddenJSONResponse: scanBlockedResponse(err)}, nil
+	}
+
 	if h.storage == nil {
 		return openapi.DownloadFile404JSONResponse{NotFoundJSONResponse: notFoundResponse("File not found")}, nil
 	}
@@ -266,6 +279,10 @@ func (h *Handler) SignFileUrl(ctx context.Context, request openapi.SignFileUrlRe
 		return openapi.SignFileUrl403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
 	}
 
+	if err := h.scanPolicy.Check(attachment.ScanStatus); err != nil {
+		return openapi.SignFileUrl403JSONResponse{ForbiddenJSONResponse: scanBlockedResponse(err)}, nil
+	}
+
 	url, expiresAt, err := h.signFileURL(ctx, attachment, userID)
 	if err != nil {
 		return nil, err
@@ -291,11 +308,14 @@ func (h *Handler) SignFileUrls(ctx context.Context, request openapi.SignFileUrls
 
 	urls := make([]openapi.SignedUrl, 0, len(request.Body.FileIds))
 	for _, fileID := range request.Body.FileIds {
-		// Skip files the user doesn't have access to
+		// Skip files the user doesn't have access to or that the scan policy blocks
 		attachment, err := h.checkFileAccess(ctx, fileID, userID)
 		if err != nil {
 			continue
 		}
+		if h.scanPolicy.Check(attachment.ScanStatus) != nil {
+			continue
+		}
 		url, expiresAt, err := h.signFileURL(ctx, attachment, userID)
 		if err != nil {
 			return nil, err
@@ -361,6 +381,16 @@ func (h *Handler) signFileURL(ctx context.Context, attachment *file.Attachment,
 	return h.signer.SignedURL(baseURL, attachment.ID, userID, signedURLTTL)
 }
 
+// scanStatusToAPI converts an attachment scan status to its API form, or nil
+// when the file was not scanned.
+func scanStatusToAPI(status string) *openapi.FileScanStatus {
+	if status == "" {
+		return nil
+	}
+	s := openapi.FileScanStatus(status)
+	return &s
+}
+
 // sanitizePathSegment strips directory traversal from a single path segment.
 func sanitizePathSegment(s string) string {
 	// Remove slashes and path separators
@@ -2,10 +2,13 @@ package handler
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/file"
 	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/scanner"
 	"github.com/enzyme/server/internal/testutil"
 )
 
@@ -200,3 +203,95 @@ func TestDeleteFile_StorageDisabled(t *testing.T) {
 		t.Fatalf("expected 200 response (DB record deleted even if storage off), got %T", resp)
 	}
 }
+
+// setScanStatus overrides an attachment's scan status directly in the database.
+func setScanStatus(t *testing.T, db *sql.DB, fileID, status string) {
+	t.Helper()
+	if _, err := db.ExecContext(context.Background(), `UPDATE attachments SET scan_status = ? WHERE id = ?`, status, fileID); err != nil {
+		t.Fatalf("setting scan status: %v", err)
+	}
+}
+
+func TestDownloadFile_ScanPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		policy   scanner.Policy
+		wantCode string // empty means the download is allowed
+	}{
+		{"infected always refused", file.ScanStatusInfected, scanner.Policy{}, ErrCodeFileInfected},
+		{"pending allowed by default policy", file.ScanStatusPending, scanner.Policy{}, ""},
+		{"pending blocked", file.ScanStatusPending, scanner.Policy{BlockPending: true}, ErrCodeFileScanPending},
+		{"error blocked", file.ScanStatusError, scanner.Policy{BlockOnError: true}, ErrCodeFileScanFailed},
+		{"clean allowed", file.ScanStatusClean, scanner.Policy{BlockPending: true, BlockOnError: true}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, db := testHandler(t)
+			h.scanPolicy = tt.policy
+
+			user := testutil.CreateTestUser(t, db, "user@test.com", "User")
+			ws := testutil.CreateTestWorkspace(t, db, user.ID, "WS")
+			ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", channel.TypePublic)
+			fileID := createFileAttachment(t, db, ch.ID, user.ID)
+			setScanStatus(t, db, fileID, tt.status)
+
+			ctx := ctxWithUser(t, h, user.ID)
+			resp, err := h.DownloadFile(ctx, openapi.DownloadFileRequestObject{Id: fileID})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			forbidden, isForbidden := resp.(openapi.DownloadFile403JSONResponse)
+			if tt.wantCode == "" {
+				if isForbidden {
+					t.Fatalf("expected download to be allowed, got 403 %s", forbidden.Error.Code)
+				}
+				return
+			}
+			if !isForbidden {
+				t.Fatalf("expected 403 response, got %T", resp)
+			}
+			if forbidden.Error.Code != tt.wantCode {
+				t.Fatalf("error code = %q, want %q", forbidden.Error.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestSignFileUrl_InfectedRefused(t *testing.T) {
+	h, db := testHandler(t)
+
+	user := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", channel.TypePublic)
+	fileID := createFileAttachment(t, db, ch.ID, user.ID)
+	setScanStatus(t, db, fileID, file.ScanStatusInfected)
+
+	ctx := ctxWithUser(t, h, user.ID)
+	resp, err := h.SignFileUrl(ctx, openapi.SignFileUrlRequestObject{Id: fileID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	forbidden, ok := resp.(openapi.SignFileUrl403JSONResponse)
+	if !ok {
+		t.Fatalf("expected 403 response, got %T", resp)
+	}
+	if forbidden.Error.Code != ErrCodeFileInfected {
+		t.Fatalf("error code = %q, want %q", forbidden.Error.Code, ErrCodeFileInfected)
+	}
+
+	// Batch signing silently skips the blocked file
+	batch, err := h.SignFileUrls(ctx, openapi.SignFileUrlsRequestObject{
+		Body: &openapi.SignFileUrlsJSONRequestBody{FileIds: []string{fileID}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	urls, ok := batch.(openapi.SignFileUrls200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", batch)
+	}
+	if len(urls.Urls) != 0 {
+		t.Fatalf("expected infected file to be skipped, got %d URLs", len(urls.Urls))
+	}
+}
@@ -4,17 +4,20 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/enzyme/server/internal/admin"
 	"github.com/enzyme/server/internal/auth"
 	"github.com/enzyme/server/internal/channel"
 	"github.com/enzyme/server/internal/email"
 	"github.com/enzyme/server/internal/emoji"
 	"github.com/enzyme/server/internal/file"
+	"github.com/enzyme/server/internal/imageproxy"
 	"github.com/enzyme/server/internal/linkpreview"
 	"github.com/enzyme/server/internal/message"
 	"github.com/enzyme/server/internal/moderation"
 	"github.com/enzyme/server/internal/notification"
 	"github.com/enzyme/server/internal/openapi"
 	"github.com/enzyme/server/internal/pushnotification"
+	"github.com/enzyme/server/internal/scanner"
 	"github.com/enzyme/server/internal/scheduled"
 	"github.com/enzyme/server/internal/signing"
 	"github.com/enzyme/server/internal/sse"
@@ -38,16 +41,24 @@ type Handler struct {
 	fileRepo            *file.Repository
 	linkPreviewRepo     *linkpreview.Repository
 	linkPreviewFetcher  *linkpreview.Fetcher
+	imageProxy          *imageproxy.Proxy
 	threadRepo          *thread.Repository
 	emojiRepo           *emoji.Repository
 	scheduledRepo       *scheduled.Repository
 	emailService        *email.Service
+	emailOutbox         *email.Outbox
 	notificationService *notification.Service
 	pushTokenRepo       *pushnotification.Repository
+	pushService         *pushnotification.Service
+	webPushRepo         *pushnotification.SubscriptionRepository
+	vapidPublicKey      string
 	moderationRepo      *moderation.Repository
+	adminService        *admin.Service
 	hub                 *sse.Hub
 	signer              *signing.Signer
 	storage             storage.Storage
+	scanService         *scanner.Service
+	scanPolicy          scanner.Policy
 	maxUploadSize       int64
 	publicURL           string
 }
@@ -63,16 +74,24 @@ type Dependencies struct {
 	FileRepo            *file.Repository
 	LinkPreviewRepo     *linkpreview.Repository
 	LinkPreviewFetcher  *linkpreview.Fetcher
+	ImageProxy          *imageproxy.Proxy // nil when the image proxy is disabled
 	ThreadRepo          *thread.Repository
 	EmojiRepo           *emoji.Repository
 	ScheduledRepo       *scheduled.Repository
 	EmailService        *email.Service
+	EmailOutbox         *email.Outbox
 	NotificationService *notification.Service
 	PushTokenRepo       *pushnotification.Repository
+	PushService         *pushnotification.Service
+	WebPushRepo         *pushnotification.SubscriptionRepository // nil when Web Push is disabled
+	VAPIDPublicKey      string
 	ModerationRepo      *moderation.Repository
+	AdminService        *admin.Service
 	Hub                 *sse.Hub
 	Signer              *signing.Signer
 	Storage             storage.Storage
+	ScanService         *scanner.Service // nil when upload scanning is disabled
+	ScanPolicy          scanner.Policy
 	MaxUploadSize       int64
 	PublicURL           string
 }
@@ -89,16 +108,24 @@ func New(deps Dependencies) *Handler {
 		fileRepo:            deps.FileRepo,
 		linkPreviewRepo:     deps.LinkPreviewRepo,
 		linkPreviewFetcher:  deps.LinkPreviewFetcher,
+		imageProxy:          deps.ImageProxy,
 		threadRepo:          deps.ThreadRepo,
 		emojiRepo:           deps.EmojiRepo,
 		scheduledRepo:       deps.ScheduledRepo,
 		emailService:        deps.EmailService,
+		emailOutbox:         deps.EmailOutbox,
 		notificationService: deps.NotificationService,
 		pushTokenRepo:       deps.PushTokenRepo,
+		pushService:         deps.PushService,
+		webPushRepo:         deps.WebPushRepo,
+		vapidPublicKey:      deps.VAPIDPublicKey,
 		moderationRepo:      deps.ModerationRepo,
+		adminService:        deps.AdminService,
 		hub:                 deps.Hub,
 		signer:              deps.Signer,
 		storage:             deps.Storage,
+		scanService:         deps.ScanService,
+		scanPolicy:          deps.ScanPolicy,
 		maxUploadSize:       deps.MaxUploadSize,
 		publicURL:           deps.PublicURL,
 	}
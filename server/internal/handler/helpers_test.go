@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/enzyme/server/internal/admin"
 	"github.com/enzyme/server/internal/auth"
 	"github.com/enzyme/server/internal/channel"
 	"github.com/enzyme/server/internal/email"
@@ -52,7 +53,7 @@ func testHandler(t *testing.T) (*Handler, *sql.DB) {
 
 	notifPrefsRepo := notification.NewPreferencesRepository(db)
 	notifPendingRepo := notification.NewPendingRepository(db)
-	notifService := notification.NewService(notifPrefsRepo, notifPendingRepo, channelRepo, hub)
+	notifService := notification.NewService(notifPrefsRepo, notifPendingRepo, notification.NewSettingsRepository(db), notification.NewHeldRepository(db), channelRepo, hub)
 
 	moderationRepo := moderation.NewRepository(db)
 
@@ -69,8 +70,10 @@ func testHandler(t *testing.T) (*Handler, *sql.DB) {
 		ThreadRepo:          threadRepo,
 		EmojiRepo:           emojiRepo,
 		ModerationRepo:      moderationRepo,
+		AdminService:        admin.NewService(db, 4),
 		NotificationService: notifService,
 		EmailService:        emailService,
+		EmailOutbox:         email.NewOutbox(db),
 		Hub:                 hub,
 		Signer:              signing.NewSigner("test-signing-secret"),
 		Storage:             storage.NewLocal(t.TempDir()),
@@ -187,7 +190,7 @@ func testHandlerWithLinkPreviews(t *testing.T, httpClient *http.Client) (*Handle
 
 	notifPrefsRepo := notification.NewPreferencesRepository(db)
 	notifPendingRepo := notification.NewPendingRepository(db)
-	notifService := notification.NewService(notifPrefsRepo, notifPendingRepo, channelRepo, hub)
+	notifService := notification.NewService(notifPrefsRepo, notifPendingRepo, notification.NewSettingsRepository(db), notification.NewHeldRepository(db), channelRepo, hub)
 
 	lpRepo := linkpreview.NewRepository(db)
 	lpFetcher := linkpreview.NewFetcherWithClient(lpRepo, httpClient)
@@ -208,8 +211,10 @@ func testHandlerWithLinkPreviews(t *testing.T, httpClient *http.Client) (*Handle
 		ThreadRepo:          threadRepo,
 		EmojiRepo:           emojiRepo,
 		ModerationRepo:      moderationRepo,
+		AdminService:        admin.NewService(db, 4),
 		NotificationService: notifService,
 		EmailService:        emailService,
+		EmailOutbox:         email.NewOutbox(db),
 		Hub:                 hub,
 		Signer:              signing.NewSigner("test-signing-secret"),
 		Storage:             storage.NewLocal(t.TempDir()),
@@ -220,6 +225,13 @@ func testHandlerWithLinkPreviews(t *testing.T, httpClient *http.Client) (*Handle
 	return h, db
 }
 
+// testAdminService creates an admin service backed by a fresh database, for
+// tests that build a bare Handler.
+func testAdminService(t *testing.T) *admin.Service {
+	t.Helper()
+	return admin.NewService(testutil.TestDB(t), 4)
+}
+
 // seedLinkPreviewCache populates the link preview cache for a URL so that
 // fetchLinkPreview gets a synchronous cache hit (no async goroutine).
 func seedLinkPreviewCache(t *testing.T, db *sql.DB, url, title string) {
@@ -227,8 +239,9 @@ func seedLinkPreviewCache(t *testing.T, db *sql.DB, url, title string) {
 
 	now := time.Now().UTC()
 	_, err := db.ExecContext(context.Background(), `
-		INSERT OR REPLACE INTO link_preview_cache (url, title, description, image_url, site_name, fetched_at, expires_at, fetch_error)
+		INSERT INTO link_preview_cache (url, title, description, image_url, site_name, fetched_at, expires_at, fetch_error)
 		VALUES (?, ?, NULL, NULL, NULL, ?, ?, NULL)
+		ON CONFLICT(url) DO UPDATE SET title = excluded.title, fetched_at = excluded.fetched_at, expires_at = excluded.expires_at
 	`, url, title, now.Format(time.RFC3339), now.Add(24*time.Hour).Format(time.RFC3339))
 	if err != nil {
 		t.Fatalf("seeding link preview cache: %v", err)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"slices"
 	"strings"
 	"time"
@@ -169,6 +170,26 @@ func (h *Handler) SendMessage(ctx context.Context, request openapi.SendMessageRe
 			mentions = filtered
 		}
 
+		// Check who may use @here, @channel and @everyone, and confirm
+		// before notifying a large audience
+		if ch.Type != channel.TypeDM && ch.Type != channel.TypeGroupDM && slices.ContainsFunc(mentions, notification.IsSpecialMention) {
+			check, err := h.checkSpecialMentions(ctx, ch, userID, mentions)
+			if err != nil {
+				return nil, err
+			}
+			if check.denied != "" {
+				return openapi.SendMessage403JSONResponse{ForbiddenJSONResponse: forbiddenResponse(fmt.Sprintf("You don't have permission to use %s in this channel", check.denied))}, nil
+			}
+			confirmed := request.Body.ConfirmMentions != nil && *request.Body.ConfirmMentions
+			if check.needsConfirmation && !confirmed {
+				return openapi.SendMessage409JSONResponse{
+					Error:          newError(ErrCodeConfirmationRequired, fmt.Sprintf("%s will notify %d people", check.mention, check.recipientCount)),
+					Mention:        openapi.MentionConfirmationRequiredMention(check.mention),
+					RecipientCount: check.recipientCount,
+				}, nil
+			}
+		}
+
 		originalMentions = mentions
 
 		// Resolve @here to online user IDs for storage (badge count accuracy)
@@ -242,7 +263,7 @@ func (h *Handler) SendMessage(ctx context.Context, request openapi.SendMessageRe
 		}
 	}
 
-	apiMsg := messageWithUserToAPI(msgWithUser)
+	apiMsg := h.messageWithUserToAPI(msgWithUser)
 
 	// Broadcast message via SSE (use API type to include attachment URLs)
 	if h.hub != nil {
@@ -299,6 +320,99 @@ func (h *Handler) SendMessage(ctx context.Context, request openapi.SendMessageRe
 	}, nil
 }
 
+// specialMentionCheck is the result of checking a message's @here, @channel
+// and @everyone mentions
+type specialMentionCheck struct {
+	// denied is the first special mention the sender may not use
+	denied string
+	// mention is the special mention that reaches the most people, and
+	// recipientCount how many it reaches
+	mention           string
+	recipientCount    int
+	needsConfirmation bool
+}
+
+// checkSpecialMentions checks the sender's permission to use each special
+// mention in a channel and whether the message must be confirmed before it
+// notifies everyone they reach.
+func (h *Handler) checkSpecialMentions(ctx context.Context, ch *channel.Channel, userID string, mentions []string) (*specialMentionCheck, error) {
+	wsMembership, err := h.workspaceRepo.GetMembership(ctx, userID, ch.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := h.workspaceRepo.GetByID(ctx, ch.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	settings := ws.ParsedSettings()
+	overrides, err := h.channelRepo.GetMentionPermissions(ctx, ch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &specialMentionCheck{}
+	var channelMemberIDs []string
+	for _, mention := range mentions {
+		if !notification.IsSpecialMention(mention) {
+			continue
+		}
+		if !workspace.HasPermission(wsMembership.Role, mentionPermission(settings, overrides, mention)) {
+			check.denied = mention
+			return check, nil
+		}
+
+		if channelMemberIDs == nil {
+			if channelMemberIDs, err = h.channelRepo.GetMemberUserIDs(ctx, ch.ID); err != nil {
+				return nil, err
+			}
+		}
+		recipientIDs := channelMemberIDs
+		switch mention {
+		case notification.MentionHere:
+			if h.hub != nil {
+				recipientIDs = notification.ResolveHereMentions([]string{notification.MentionHere}, channelMemberIDs, userID, h.hub, ch.WorkspaceID)
+			}
+		case notification.MentionEveryone:
+			if notification.EveryoneReachesWorkspace(ch.Type) {
+				if recipientIDs, err = h.workspaceRepo.GetMemberUserIDs(ctx, ch.WorkspaceID); err != nil {
+					return nil, err
+				}
+			}
+		}
+		count := len(recipientIDs)
+		if mention != notification.MentionHere && slices.Contains(recipientIDs, userID) {
+			count-- // The sender isn't notified
+		}
+		if check.mention == "" || count > check.recipientCount {
+			check.mention = mention
+			check.recipientCount = count
+		}
+	}
+
+	threshold := settings.MentionConfirmThreshold
+	check.needsConfirmation = threshold > 0 && check.recipientCount > threshold
+	return check, nil
+}
+
+// mentionPermission returns the permission level required to use a special
+// mention, from the channel's overrides or else the workspace settings
+func mentionPermission(settings workspace.WorkspaceSettings, overrides *channel.MentionPermissions, mention string) workspace.PermissionLevel {
+	var level workspace.PermissionLevel
+	var override *string
+	switch mention {
+	case notification.MentionHere:
+		level, override = settings.WhoCanMentionHere, overrides.Here
+	case notification.MentionChannel:
+		level, override = settings.WhoCanMentionChannel, overrides.Channel
+	case notification.MentionEveryone:
+		level, override = settings.WhoCanMentionEveryone, overrides.Everyone
+	}
+	if override != nil {
+		return workspace.PermissionLevel(*override)
+	}
+	return level
+}
+
 // ListMessages lists messages in a channel
 func (h *Handler) ListMessages(ctx context.Context, request openapi.ListMessagesRequestObject) (openapi.ListMessagesResponseObject, error) {
 	userID := h.getUserID(ctx)
@@ -353,7 +467,7 @@ func (h *Handler) ListMessages(ctx context.Context, request openapi.ListMessages
 	// Load link previews for all messages
 	h.loadLinkPreviewsForMessages(ctx, result.Messages)
 
-	return openapi.ListMessages200JSONResponse(messageListResultToAPI(result)), nil
+	return openapi.ListMessages200JSONResponse(h.messageListResultToAPI(result)), nil
 }
 
 // UpdateMessage updates a message
@@ -459,7 +573,7 @@ func (h *Handler) UpdateMessage(ctx context.Context, request openapi.UpdateMessa
 		}
 	}
 
-	apiMsg := messageWithUserToAPI(msgWithUser)
+	apiMsg := h.messageWithUserToAPI(msgWithUser)
 
 	// Broadcast update via SSE (use API type to include attachment URLs)
 	if h.hub != nil && ch != nil && msgWithUser != nil {
@@ -563,7 +677,7 @@ func (h *Handler) DeleteLinkPreview(ctx context.Context, request openapi.DeleteL
 		if loadErr == nil && h.hub != nil {
 			attachments, _ := h.fileRepo.ListForMessage(ctx, msg.ID)
 			msgWithUser.Attachments = attachments
-			apiMsg := messageWithUserToAPI(msgWithUser)
+			apiMsg := h.messageWithUserToAPI(msgWithUser)
 			h.hub.BroadcastToChannel(ch.WorkspaceID, msg.ChannelID, sse.NewMessageUpdatedEvent(apiMsg))
 		}
 	}
@@ -719,7 +833,7 @@ func (h *Handler) ListThread(ctx context.Context, request openapi.ListThreadRequ
 	// Load link previews for all messages
 	h.loadLinkPreviewsForMessages(ctx, result.Messages)
 
-	return openapi.ListThread200JSONResponse(messageListResultToAPI(result)), nil
+	return openapi.ListThread200JSONResponse(h.messageListResultToAPI(result)), nil
 }
 
 // SearchMessages searches messages in a workspace
@@ -818,7 +932,7 @@ func searchResultToAPI(result *message.SearchResult) openapi.SearchMessagesResul
 }
 
 // messageWithUserToAPI converts a message.MessageWithUser to openapi.MessageWithUser
-func messageWithUserToAPI(m *message.MessageWithUser) openapi.MessageWithUser {
+func (h *Handler) messageWithUserToAPI(m *message.MessageWithUser) openapi.MessageWithUser {
 	apiMsg := openapi.MessageWithUser{
 		Id:             m.ID,
 		ChannelId:      m.ChannelID,
@@ -898,7 +1012,7 @@ func messageWithUserToAPI(m *message.MessageWithUser) openapi.MessageWithUser {
 		apiMsg.Attachments = &attachments
 	}
 	if m.LinkPreview != nil {
-		lp := linkPreviewToAPI(m.LinkPreview)
+		lp := h.linkPreviewToAPI(m.LinkPreview)
 		apiMsg.LinkPreview = &lp
 	}
 	return apiMsg
@@ -914,6 +1028,7 @@ func attachmentToAPI(a *file.Attachment) openapi.Attachment {
 		SizeBytes:   a.SizeBytes,
 		Url:         url,
 		CreatedAt:   a.CreatedAt,
+		ScanStatus:  scanStatusToAPI(a.ScanStatus),
 	}
 }
 
@@ -1038,14 +1153,8 @@ func (h *Handler) fetchLinkPreview(ctx context.Context, url, msgID, channelID, w
 	}
 	if cached != nil && cached.FetchError == "" {
 		// Cache hit — attach synchronously
-		preview := &linkpreview.Preview{
-			MessageID:   msgID,
-			URL:         cached.URL,
-			Title:       cached.Title,
-			Description: cached.Description,
-			ImageURL:    cached.ImageURL,
-			SiteName:    cached.SiteName,
-		}
+		preview := cached.Preview()
+		preview.MessageID = msgID
 		if err := h.linkPreviewRepo.CreatePreview(ctx, preview); err != nil {
 			slog.Error("link preview create failed", "url", url, "error", err)
 		}
@@ -1078,7 +1187,7 @@ func (h *Handler) fetchLinkPreview(ctx context.Context, url, msgID, channelID, w
 				updated.Attachments = attch
 			}
 			updated.LinkPreview = p
-			apiUpdated := messageWithUserToAPI(updated)
+			apiUpdated := h.messageWithUserToAPI(updated)
 			if h.hub != nil && workspaceID != "" {
 				h.hub.BroadcastToChannel(workspaceID, channelID, sse.NewMessageUpdatedEvent(apiUpdated))
 			}
@@ -1089,7 +1198,7 @@ func (h *Handler) fetchLinkPreview(ctx context.Context, url, msgID, channelID, w
 }
 
 // linkPreviewToAPI converts a linkpreview.Preview to openapi.LinkPreview
-func linkPreviewToAPI(p *linkpreview.Preview) openapi.LinkPreview {
+func (h *Handler) linkPreviewToAPI(p *linkpreview.Preview) openapi.LinkPreview {
 	previewType := openapi.LinkPreviewType(p.Type)
 	if previewType == "" {
 		previewType = openapi.LinkPreviewTypeExternal
@@ -1102,11 +1211,42 @@ func linkPreviewToAPI(p *linkpreview.Preview) openapi.LinkPreview {
 		lp.Description = &p.Description
 	}
 	if p.ImageURL != "" {
-		lp.ImageUrl = &p.ImageURL
+		imageURL := h.proxyImageURL(p.ImageURL)
+		lp.ImageUrl = &imageURL
 	}
 	if p.SiteName != "" {
 		lp.SiteName = &p.SiteName
 	}
+	if p.MediaType != "" {
+		mediaType := openapi.LinkPreviewMediaType(p.MediaType)
+		lp.MediaType = &mediaType
+	}
+	if p.ImageWidth > 0 && p.ImageHeight > 0 {
+		lp.ImageWidth = &p.ImageWidth
+		lp.ImageHeight = &p.ImageHeight
+	}
+	if p.VideoURL != "" {
+		lp.VideoUrl = &p.VideoURL
+	}
+	if p.VideoWidth > 0 && p.VideoHeight > 0 {
+		lp.VideoWidth = &p.VideoWidth
+		lp.VideoHeight = &p.VideoHeight
+	}
+	if p.AuthorName != "" {
+		lp.AuthorName = &p.AuthorName
+	}
+	if p.AuthorURL != "" {
+		lp.AuthorUrl = &p.AuthorURL
+	}
+	if p.PublishedAt != "" {
+		if t, err := time.Parse(time.RFC3339, p.PublishedAt); err == nil {
+			lp.PublishedAt = &t
+		}
+	}
+	if p.FaviconURL != "" {
+		faviconURL := h.proxyImageURL(p.FaviconURL)
+		lp.FaviconUrl = &faviconURL
+	}
 	// Internal message preview fields
 	if p.LinkedMessageID != "" {
 		lp.LinkedMessageId = &p.LinkedMessageID
@@ -1266,6 +1406,26 @@ func (h *Handler) loadLinkPreviewsForMessages(ctx context.Context, messages []me
 	}
 }
 
+// ServeImageProxy serves external images through the signed image proxy.
+// The signature in the URL authorizes the request, since <img> tags cannot
+// send bearer tokens.
+func (h *Handler) ServeImageProxy(w http.ResponseWriter, r *http.Request) {
+	if h.imageProxy == nil {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	h.imageProxy.ServeHTTP(w, r)
+}
+
+// proxyImageURL rewrites an external image URL to go through the image
+// proxy, so clients never contact third-party hosts directly.
+func (h *Handler) proxyImageURL(rawURL string) string {
+	if h.imageProxy == nil {
+		return rawURL
+	}
+	return h.imageProxy.URL(rawURL)
+}
+
 // threadParticipantToAPI converts a message.ThreadParticipant to openapi.ThreadParticipant
 func threadParticipantToAPI(p *message.ThreadParticipant) openapi.ThreadParticipant {
 	participant := openapi.ThreadParticipant{
@@ -1295,10 +1455,10 @@ func reactionToAPI(r *message.Reaction) openapi.Reaction {
 }
 
 // messageListResultToAPI converts a message.ListResult to openapi.MessageListResult
-func messageListResultToAPI(result *message.ListResult) openapi.MessageListResult {
+func (h *Handler) messageListResultToAPI(result *message.ListResult) openapi.MessageListResult {
 	messages := make([]openapi.MessageWithUser, len(result.Messages))
 	for i, m := range result.Messages {
-		messages[i] = messageWithUserToAPI(&m)
+		messages[i] = h.messageWithUserToAPI(&m)
 	}
 
 	apiResult := openapi.MessageListResult{
@@ -1379,7 +1539,7 @@ func (h *Handler) GetMessage(ctx context.Context, request openapi.GetMessageRequ
 		}
 	}
 
-	apiMsg := messageWithUserToAPI(msgWithUser)
+	apiMsg := h.messageWithUserToAPI(msgWithUser)
 	return openapi.GetMessage200JSONResponse{
 		Message: apiMsg,
 	}, nil
@@ -1541,7 +1701,7 @@ func (h *Handler) PinMessage(ctx context.Context, request openapi.PinMessageRequ
 	if err != nil {
 		return nil, err
 	}
-	apiMsg := messageWithUserToAPI(updatedMsg)
+	apiMsg := h.messageWithUserToAPI(updatedMsg)
 
 	// Broadcast SSE events
 	if h.hub != nil {
@@ -1551,7 +1711,7 @@ func (h *Handler) PinMessage(ctx context.Context, request openapi.PinMessageRequ
 		if sysMsg != nil {
 			sysMsgWithUser, _ := h.messageRepo.GetByIDWithUser(ctx, sysMsg.ID)
 			if sysMsgWithUser != nil {
-				h.hub.BroadcastToChannel(ch.WorkspaceID, msg.ChannelID, sse.NewMessageNewEvent(messageWithUserToAPI(sysMsgWithUser)))
+				h.hub.BroadcastToChannel(ch.WorkspaceID, msg.ChannelID, sse.NewMessageNewEvent(h.messageWithUserToAPI(sysMsgWithUser)))
 			}
 		}
 	}
@@ -1615,7 +1775,7 @@ func (h *Handler) UnpinMessage(ctx context.Context, request openapi.UnpinMessage
 	if err != nil {
 		return nil, err
 	}
-	apiMsg := messageWithUserToAPI(updatedMsg)
+	apiMsg := h.messageWithUserToAPI(updatedMsg)
 
 	// Broadcast SSE event
 	if h.hub != nil {
@@ -1625,7 +1785,7 @@ func (h *Handler) UnpinMessage(ctx context.Context, request openapi.UnpinMessage
 		if sysMsg != nil {
 			sysMsgWithUser, _ := h.messageRepo.GetByIDWithUser(ctx, sysMsg.ID)
 			if sysMsgWithUser != nil {
-				h.hub.BroadcastToChannel(ch.WorkspaceID, msg.ChannelID, sse.NewMessageNewEvent(messageWithUserToAPI(sysMsgWithUser)))
+				h.hub.BroadcastToChannel(ch.WorkspaceID, msg.ChannelID, sse.NewMessageNewEvent(h.messageWithUserToAPI(sysMsgWithUser)))
 			}
 		}
 	}
@@ -1678,7 +1838,7 @@ func (h *Handler) ListPinnedMessages(ctx context.Context, request openapi.ListPi
 
 	apiMessages := make([]openapi.MessageWithUser, len(messages))
 	for i, m := range messages {
-		apiMessages[i] = messageWithUserToAPI(&m)
+		apiMessages[i] = h.messageWithUserToAPI(&m)
 	}
 
 	return openapi.ListPinnedMessages200JSONResponse{
@@ -4,10 +4,13 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/imageproxy"
 	"github.com/enzyme/server/internal/linkpreview"
 	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/signing"
 	"github.com/enzyme/server/internal/testutil"
 	"github.com/oklog/ulid/v2"
 )
@@ -70,7 +73,7 @@ func TestSendMessage_ArchivedChannel(t *testing.T) {
 
 	// Archive the channel
 	_, err := db.ExecContext(context.Background(),
-		`UPDATE channels SET archived_at = datetime('now') WHERE id = ?`, ch.ID)
+		`UPDATE channels SET archived_at = ? WHERE id = ?`, time.Now().UTC().Format(time.RFC3339), ch.ID)
 	if err != nil {
 		t.Fatalf("archiving channel: %v", err)
 	}
@@ -203,10 +206,11 @@ func TestSendMessage_CannotReplyToReply(t *testing.T) {
 
 	// Create a reply (a message with thread_parent_id set)
 	replyID := ulid.Make().String()
+	now := time.Now().UTC().Format(time.RFC3339)
 	_, err := db.ExecContext(context.Background(), `
 		INSERT INTO messages (id, channel_id, user_id, content, thread_parent_id, reply_count, created_at, updated_at)
-		VALUES (?, ?, ?, 'Reply', ?, 0, datetime('now'), datetime('now'))
-	`, replyID, ch.ID, user.ID, parent.ID)
+		VALUES (?, ?, ?, 'Reply', ?, 0, ?, ?)
+	`, replyID, ch.ID, user.ID, parent.ID, now, now)
 	if err != nil {
 		t.Fatalf("creating reply: %v", err)
 	}
@@ -228,6 +232,119 @@ func TestSendMessage_CannotReplyToReply(t *testing.T) {
 	}
 }
 
+func TestSendMessage_SpecialMentionPermissions(t *testing.T) {
+	h, db := testHandler(t)
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	member := testutil.CreateTestUser(t, db, "member@test.com", "Member")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "WS")
+	addWorkspaceMember(t, db, member.ID, ws.ID, "member")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "general", channel.TypePublic)
+	addChannelMember(t, db, member.ID, ch.ID, nil)
+
+	send := func(userID, content string) openapi.SendMessageResponseObject {
+		t.Helper()
+		resp, err := h.SendMessage(ctxWithUser(t, h, userID), openapi.SendMessageRequestObject{
+			Id:   ch.ID,
+			Body: &openapi.SendMessageJSONRequestBody{Content: &content},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp
+	}
+
+	// @everyone is limited to admins by default
+	if resp := send(member.ID, "<!everyone> hello"); !isForbidden(resp) {
+		t.Fatalf("member @everyone: expected 403 response, got %T", resp)
+	}
+	if _, ok := send(member.ID, "<!channel> hello").(openapi.SendMessage200JSONResponse); !ok {
+		t.Fatal("member @channel: expected 200 response")
+	}
+	if _, ok := send(owner.ID, "<!everyone> hello").(openapi.SendMessage200JSONResponse); !ok {
+		t.Fatal("owner @everyone: expected 200 response")
+	}
+
+	// A channel override takes precedence over the workspace setting
+	members := openapi.PermissionLevel("members")
+	admins := openapi.PermissionLevel("admins")
+	resp, err := h.UpdateChannelMentionPermissions(ctxWithUser(t, h, owner.ID), openapi.UpdateChannelMentionPermissionsRequestObject{
+		Id:   ch.ID,
+		Body: &openapi.UpdateChannelMentionPermissionsJSONRequestBody{WhoCanMentionEveryone: &members, WhoCanMentionChannel: &admins},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateChannelMentionPermissions200JSONResponse); !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	if _, ok := send(member.ID, "<!everyone> hello").(openapi.SendMessage200JSONResponse); !ok {
+		t.Fatal("member @everyone with override: expected 200 response")
+	}
+	if resp := send(member.ID, "<!channel> hello"); !isForbidden(resp) {
+		t.Fatalf("member @channel with override: expected 403 response, got %T", resp)
+	}
+}
+
+func isForbidden(resp openapi.SendMessageResponseObject) bool {
+	_, ok := resp.(openapi.SendMessage403JSONResponse)
+	return ok
+}
+
+func TestSendMessage_LargeMentionRequiresConfirmation(t *testing.T) {
+	h, db := testHandler(t)
+
+	user := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", channel.TypePublic)
+	for _, email := range []string{"a@test.com", "b@test.com", "c@test.com"} {
+		other := testutil.CreateTestUser(t, db, email, email)
+		addWorkspaceMember(t, db, other.ID, ws.ID, "member")
+		addChannelMember(t, db, other.ID, ch.ID, nil)
+	}
+	if _, err := db.ExecContext(context.Background(),
+		`UPDATE workspaces SET settings = ? WHERE id = ?`, `{"mention_confirm_threshold":2}`, ws.ID); err != nil {
+		t.Fatalf("updating settings: %v", err)
+	}
+
+	ctx := ctxWithUser(t, h, user.ID)
+	content := "<!channel> heads up"
+	resp, err := h.SendMessage(ctx, openapi.SendMessageRequestObject{
+		Id:   ch.ID,
+		Body: &openapi.SendMessageJSONRequestBody{Content: &content},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, ok := resp.(openapi.SendMessage409JSONResponse)
+	if !ok {
+		t.Fatalf("expected 409 response, got %T", resp)
+	}
+	if r.Error.Code != ErrCodeConfirmationRequired || r.Mention != "@channel" || r.RecipientCount != 3 {
+		t.Errorf("response = %+v, want @channel confirmation for 3 people", r)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM messages WHERE channel_id = ?`, ch.ID).Scan(&count); err != nil {
+		t.Fatalf("counting messages: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("message count = %d, want 0 before confirming", count)
+	}
+
+	confirm := true
+	resp, err = h.SendMessage(ctx, openapi.SendMessageRequestObject{
+		Id:   ch.ID,
+		Body: &openapi.SendMessageJSONRequestBody{Content: &content, ConfirmMentions: &confirm},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.SendMessage200JSONResponse); !ok {
+		t.Fatalf("expected 200 response after confirming, got %T", resp)
+	}
+}
+
 func TestDeleteMessage_Success(t *testing.T) {
 	h, db := testHandler(t)
 
@@ -307,10 +424,11 @@ func TestDeleteMessage_SystemMessage(t *testing.T) {
 
 	// Create a system message
 	sysMsgID := ulid.Make().String()
+	now := time.Now().UTC().Format(time.RFC3339)
 	_, err := db.ExecContext(context.Background(), `
 		INSERT INTO messages (id, channel_id, content, type, reply_count, created_at, updated_at)
-		VALUES (?, ?, 'User joined', 'system', 0, datetime('now'), datetime('now'))
-	`, sysMsgID, ch.ID)
+		VALUES (?, ?, 'User joined', 'system', 0, ?, ?)
+	`, sysMsgID, ch.ID, now, now)
 	if err != nil {
 		t.Fatalf("creating system message: %v", err)
 	}
@@ -785,3 +903,114 @@ func TestUpdateMessage_LinkPreview_URLAdded(t *testing.T) {
 		t.Errorf("title = %q, want %q", *r.Message.LinkPreview.Title, "Added")
 	}
 }
+
+func TestUpdateMessage_LinkPreview_RichFields(t *testing.T) {
+	h, db := testHandlerWithLinkPreviews(t, &http.Client{})
+
+	user := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", channel.TypePublic)
+	msg := testutil.CreateTestMessage(t, db, ch.ID, user.ID, "No link here")
+
+	now := time.Now().UTC()
+	err := linkpreview.NewRepository(db).SetCachedURL(context.Background(), &linkpreview.CacheEntry{
+		URL:         "https://video.example.com/watch",
+		Title:       "A video",
+		MediaType:   linkpreview.MediaTypeVideo,
+		VideoURL:    "https://video.example.com/embed/1",
+		VideoWidth:  640,
+		VideoHeight: 360,
+		AuthorName:  "Jane",
+		PublishedAt: "2024-05-01T12:00:00Z",
+		FaviconURL:  "https://video.example.com/favicon.ico",
+		FetchedAt:   now,
+		ExpiresAt:   now.Add(linkpreview.CacheTTL),
+	})
+	if err != nil {
+		t.Fatalf("SetCachedURL: %v", err)
+	}
+
+	ctx := ctxWithUser(t, h, user.ID)
+	resp, err := h.UpdateMessage(ctx, openapi.UpdateMessageRequestObject{
+		Id:   msg.ID,
+		Body: &openapi.UpdateMessageJSONRequestBody{Content: "Watch https://video.example.com/watch"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, ok := resp.(openapi.UpdateMessage200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	lp := r.Message.LinkPreview
+	if lp == nil {
+		t.Fatal("expected link preview")
+	}
+	if lp.MediaType == nil || *lp.MediaType != openapi.Video {
+		t.Errorf("media_type = %v, want video", lp.MediaType)
+	}
+	if lp.VideoUrl == nil || *lp.VideoUrl != "https://video.example.com/embed/1" {
+		t.Errorf("video_url = %v", lp.VideoUrl)
+	}
+	if lp.VideoWidth == nil || *lp.VideoWidth != 640 || lp.VideoHeight == nil || *lp.VideoHeight != 360 {
+		t.Errorf("video size = %v x %v, want 640x360", lp.VideoWidth, lp.VideoHeight)
+	}
+	if lp.AuthorName == nil || *lp.AuthorName != "Jane" {
+		t.Errorf("author_name = %v", lp.AuthorName)
+	}
+	if lp.PublishedAt == nil || !lp.PublishedAt.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("published_at = %v", lp.PublishedAt)
+	}
+	if lp.FaviconUrl == nil {
+		t.Error("expected favicon_url")
+	}
+}
+
+func TestUpdateMessage_LinkPreview_ImageProxy(t *testing.T) {
+	h, db := testHandlerWithLinkPreviews(t, &http.Client{})
+	h.imageProxy = imageproxy.New(imageproxy.NewRepository(db), nil, signing.NewSigner("test-secret"), nil, 1024*1024, time.Hour)
+
+	user := testutil.CreateTestUser(t, db, "user@test.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", channel.TypePublic)
+	msg := testutil.CreateTestMessage(t, db, ch.ID, user.ID, "No link here")
+
+	now := time.Now().UTC()
+	err := linkpreview.NewRepository(db).SetCachedURL(context.Background(), &linkpreview.CacheEntry{
+		URL:        "https://example.com/article",
+		Title:      "An article",
+		ImageURL:   "https://cdn.example.com/cover.jpg",
+		FaviconURL: "https://example.com/favicon.ico",
+		FetchedAt:  now,
+		ExpiresAt:  now.Add(linkpreview.CacheTTL),
+	})
+	if err != nil {
+		t.Fatalf("SetCachedURL: %v", err)
+	}
+
+	ctx := ctxWithUser(t, h, user.ID)
+	resp, err := h.UpdateMessage(ctx, openapi.UpdateMessageRequestObject{
+		Id:   msg.ID,
+		Body: &openapi.UpdateMessageJSONRequestBody{Content: "Read https://example.com/article"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, ok := resp.(openapi.UpdateMessage200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	lp := r.Message.LinkPreview
+	if lp == nil {
+		t.Fatal("expected link preview")
+	}
+	if lp.ImageUrl == nil || *lp.ImageUrl != h.imageProxy.URL("https://cdn.example.com/cover.jpg") {
+		t.Errorf("image_url = %v, want proxied URL", lp.ImageUrl)
+	}
+	if lp.FaviconUrl == nil || *lp.FaviconUrl != h.imageProxy.URL("https://example.com/favicon.ico") {
+		t.Errorf("favicon_url = %v, want proxied URL", lp.FaviconUrl)
+	}
+	if lp.Url != "https://example.com/article" {
+		t.Errorf("url = %q, link itself should not be proxied", lp.Url)
+	}
+}
@@ -371,6 +371,18 @@ func (h *Handler) executeScheduledSend(ctx context.Context, smsg *scheduled.Sche
 	var originalMentions []string
 	if h.notificationService != nil && smsg.Content != "" {
 		mentions, _ = notification.ParseMentions(ctx, h.userRepo, ch.WorkspaceID, smsg.Content)
+
+		// Permissions may have changed since the message was scheduled. The
+		// sender chose to schedule it, so it isn't held for confirmation.
+		if ch.Type != channel.TypeDM && ch.Type != channel.TypeGroupDM && slices.ContainsFunc(mentions, notification.IsSpecialMention) {
+			check, err := h.checkSpecialMentions(ctx, ch, smsg.UserID, mentions)
+			if err != nil {
+				return nil, fmt.Errorf("checking mention permissions: %w", err)
+			}
+			if check.denied != "" {
+				return nil, &scheduled.PermanentError{Err: fmt.Errorf("no permission to use %s in this channel", check.denied)}
+			}
+		}
 		originalMentions = mentions
 
 		if h.hub != nil && slices.Contains(mentions, notification.MentionHere) {
@@ -439,7 +451,7 @@ func (h *Handler) executeScheduledSend(ctx context.Context, smsg *scheduled.Sche
 		}
 	}
 
-	apiMsg := messageWithUserToAPI(msgWithUser)
+	apiMsg := h.messageWithUserToAPI(msgWithUser)
 
 	// Broadcast the new message
 	if h.hub != nil {
@@ -7,12 +7,27 @@ import (
 	"github.com/enzyme/server/internal/version"
 )
 
-func (h *Handler) GetServerInfo(_ context.Context, _ openapi.GetServerInfoRequestObject) (openapi.GetServerInfoResponseObject, error) {
+func (h *Handler) GetServerInfo(ctx context.Context, _ openapi.GetServerInfoRequestObject) (openapi.GetServerInfoResponseObject, error) {
+	settings, err := h.adminService.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	emailEnabled := h.emailService.IsEnabled()
+	inboundEmailEnabled := h.emailService.InboundEnabled()
 	filesEnabled := h.storage != nil
-	return openapi.GetServerInfo200JSONResponse{
-		Version:      version.Version,
-		EmailEnabled: &emailEnabled,
-		FilesEnabled: &filesEnabled,
-	}, nil
+	registrationMode := openapi.RegistrationMode(settings.RegistrationMode)
+	workspaceCreation := openapi.WorkspaceCreationPolicy(settings.WorkspaceCreation)
+	info := openapi.GetServerInfo200JSONResponse{
+		Version:             version.Version,
+		EmailEnabled:        &emailEnabled,
+		InboundEmailEnabled: &inboundEmailEnabled,
+		FilesEnabled:        &filesEnabled,
+		RegistrationMode:    &registrationMode,
+		WorkspaceCreation:   &workspaceCreation,
+	}
+	if h.vapidPublicKey != "" {
+		info.WebPushPublicKey = &h.vapidPublicKey
+	}
+	return info, nil
 }
@@ -11,7 +11,7 @@ import (
 )
 
 func TestGetServerInfo(t *testing.T) {
-	h := &Handler{emailService: email.NewTestService(false, ""), storage: storage.NewLocal(t.TempDir())}
+	h := &Handler{emailService: email.NewTestService(false, ""), storage: storage.NewLocal(t.TempDir()), adminService: testAdminService(t)}
 
 	resp, err := h.GetServerInfo(context.Background(), openapi.GetServerInfoRequestObject{})
 	if err != nil {
@@ -37,7 +37,7 @@ func TestGetServerInfo(t *testing.T) {
 }
 
 func TestGetServerInfo_EmailEnabled(t *testing.T) {
-	h := &Handler{emailService: email.NewTestService(true, ""), storage: storage.NewLocal(t.TempDir())}
+	h := &Handler{emailService: email.NewTestService(true, ""), storage: storage.NewLocal(t.TempDir()), adminService: testAdminService(t)}
 
 	resp, err := h.GetServerInfo(context.Background(), openapi.GetServerInfoRequestObject{})
 	if err != nil {
@@ -48,10 +48,22 @@ func TestGetServerInfo_EmailEnabled(t *testing.T) {
 	if jsonResp.EmailEnabled == nil || *jsonResp.EmailEnabled != true {
 		t.Error("expected email_enabled to be true")
 	}
+	if jsonResp.InboundEmailEnabled == nil || *jsonResp.InboundEmailEnabled != false {
+		t.Error("expected inbound_email_enabled to be false")
+	}
+
+	h.emailService = h.emailService.WithInboundDomain("in.example.com")
+	resp, err = h.GetServerInfo(context.Background(), openapi.GetServerInfoRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if jsonResp := resp.(openapi.GetServerInfo200JSONResponse); jsonResp.InboundEmailEnabled == nil || *jsonResp.InboundEmailEnabled != true {
+		t.Error("expected inbound_email_enabled to be true")
+	}
 }
 
 func TestGetServerInfo_FilesDisabled(t *testing.T) {
-	h := &Handler{emailService: email.NewTestService(false, "")} // storage is nil
+	h := &Handler{emailService: email.NewTestService(false, ""), adminService: testAdminService(t)} // storage is nil
 
 	resp, err := h.GetServerInfo(context.Background(), openapi.GetServerInfoRequestObject{})
 	if err != nil {
@@ -7,8 +7,10 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/enzyme/server/internal/gravatar"
+	"github.com/enzyme/server/internal/notification"
 	"github.com/enzyme/server/internal/openapi"
 	"github.com/enzyme/server/internal/user"
 	"github.com/go-chi/chi/v5"
@@ -234,3 +236,109 @@ func (h *Handler) ServeAvatar(w http.ResponseWriter, r *http.Request) {
 	}
 	h.storage.Serve(w, r, "avatars/"+filename)
 }
+
+// GetNotificationSettings returns the current user's notification settings
+func (h *Handler) GetNotificationSettings(ctx context.Context, request openapi.GetNotificationSettingsRequestObject) (openapi.GetNotificationSettingsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.GetNotificationSettings401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	settings, err := h.notificationService.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.GetNotificationSettings200JSONResponse{
+		Settings: notificationSettingsToAPI(settings),
+	}, nil
+}
+
+// UpdateNotificationSettings replaces the current user's notification settings
+func (h *Handler) UpdateNotificationSettings(ctx context.Context, request openapi.UpdateNotificationSettingsRequestObject) (openapi.UpdateNotificationSettingsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.UpdateNotificationSettings401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	keywords, err := notification.NormalizeKeywords(request.Body.HighlightKeywords)
+	if err != nil {
+		return openapi.UpdateNotificationSettings400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, err.Error())}, nil
+	}
+
+	settings := &notification.Settings{
+		UserID:            userID,
+		HighlightKeywords: keywords,
+	}
+	if request.Body.EmailDigest != nil {
+		settings.EmailDigest = string(*request.Body.EmailDigest)
+		if !notification.IsValidDigest(settings.EmailDigest) {
+			return openapi.UpdateNotificationSettings400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid email digest frequency")}, nil
+		}
+	} else {
+		current, err := h.notificationService.GetSettings(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		settings.EmailDigest = current.EmailDigest
+	}
+	if s := request.Body.Schedule; s != nil {
+		settings.Schedule = &notification.Schedule{
+			TimeZone: s.TimeZone,
+			Windows:  make([]notification.ScheduleWindow, len(s.Windows)),
+		}
+		for i, w := range s.Windows {
+			settings.Schedule.Windows[i] = notification.ScheduleWindow{Day: time.Weekday(w.Day), Start: w.Start, End: w.End}
+		}
+		if err := settings.Schedule.Validate(); err != nil {
+			return openapi.UpdateNotificationSettings400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, err.Error())}, nil
+		}
+	}
+
+	if err := h.notificationService.UpdateSettings(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	return openapi.UpdateNotificationSettings200JSONResponse{
+		Settings: notificationSettingsToAPI(settings),
+	}, nil
+}
+
+// UnsubscribeFromEmail stops notification emails for the user an unsubscribe
+// link was sent to. It's unauthenticated, so mail clients can call it.
+func (h *Handler) UnsubscribeFromEmail(ctx context.Context, request openapi.UnsubscribeFromEmailRequestObject) (openapi.UnsubscribeFromEmailResponseObject, error) {
+	userID, err := h.signer.VerifyUnsubscribeToken(request.Params.Token)
+	if err != nil {
+		return openapi.UnsubscribeFromEmail400JSONResponse{BadRequestJSONResponse: badRequestResponse("INVALID_TOKEN", "Invalid unsubscribe link")}, nil
+	}
+	if _, err := h.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return openapi.UnsubscribeFromEmail400JSONResponse{BadRequestJSONResponse: badRequestResponse("INVALID_TOKEN", "Invalid unsubscribe link")}, nil
+		}
+		return nil, err
+	}
+
+	if err := h.notificationService.UnsubscribeFromEmail(ctx, userID); err != nil {
+		return nil, err
+	}
+	return openapi.UnsubscribeFromEmail200JSONResponse{Success: true}, nil
+}
+
+func notificationSettingsToAPI(settings *notification.Settings) openapi.NotificationSettings {
+	digest := openapi.EmailDigest(settings.EmailDigest)
+	result := openapi.NotificationSettings{
+		HighlightKeywords: settings.HighlightKeywords,
+		EmailDigest:       &digest,
+	}
+	if settings.Schedule != nil {
+		schedule := openapi.NotificationSchedule{
+			TimeZone: settings.Schedule.TimeZone,
+			Windows:  make([]openapi.NotificationScheduleWindow, len(settings.Schedule.Windows)),
+		}
+		for i, w := range settings.Schedule.Windows {
+			schedule.Windows[i] = openapi.NotificationScheduleWindow{Day: int(w.Day), Start: w.Start, End: w.End}
+		}
+		result.Schedule = &schedule
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/testutil"
+)
+
+func TestUpdateNotificationSettings(t *testing.T) {
+	h, db := testHandler(t)
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ctx := ctxWithUser(t, h, u.ID)
+
+	resp, err := h.UpdateNotificationSettings(ctx, openapi.UpdateNotificationSettingsRequestObject{
+		Body: &openapi.NotificationSettings{
+			HighlightKeywords: []string{" outage ", "Outage", "Enzyme"},
+			Schedule: &openapi.NotificationSchedule{
+				TimeZone: "America/New_York",
+				Windows:  []openapi.NotificationScheduleWindow{{Day: 1, Start: "09:00", End: "17:00"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateNotificationSettings200JSONResponse); !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+
+	getResp, err := h.GetNotificationSettings(ctx, openapi.GetNotificationSettingsRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	settings := getResp.(openapi.GetNotificationSettings200JSONResponse).Settings
+	if len(settings.HighlightKeywords) != 2 || settings.HighlightKeywords[0] != "outage" {
+		t.Errorf("expected deduplicated keywords [outage Enzyme], got %q", settings.HighlightKeywords)
+	}
+	if settings.Schedule == nil || settings.Schedule.TimeZone != "America/New_York" || len(settings.Schedule.Windows) != 1 {
+		t.Errorf("expected the schedule to be stored, got %+v", settings.Schedule)
+	}
+}
+
+func TestGetNotificationSettings_Defaults(t *testing.T) {
+	h, db := testHandler(t)
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+
+	resp, err := h.GetNotificationSettings(ctxWithUser(t, h, u.ID), openapi.GetNotificationSettingsRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	settings := resp.(openapi.GetNotificationSettings200JSONResponse).Settings
+	if settings.HighlightKeywords == nil || len(settings.HighlightKeywords) != 0 {
+		t.Errorf("expected an empty keyword list, got %#v", settings.HighlightKeywords)
+	}
+	if settings.Schedule != nil {
+		t.Errorf("expected no schedule, got %+v", settings.Schedule)
+	}
+}
+
+func TestUpdateNotificationSettings_InvalidSchedule(t *testing.T) {
+	h, db := testHandler(t)
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+
+	resp, err := h.UpdateNotificationSettings(ctxWithUser(t, h, u.ID), openapi.UpdateNotificationSettingsRequestObject{
+		Body: &openapi.NotificationSettings{
+			HighlightKeywords: []string{},
+			Schedule: &openapi.NotificationSchedule{
+				TimeZone: "Nowhere/Special",
+				Windows:  []openapi.NotificationScheduleWindow{{Day: 1, Start: "09:00", End: "17:00"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	badReq, ok := resp.(openapi.UpdateNotificationSettings400JSONResponse)
+	if !ok {
+		t.Fatalf("expected 400 response, got %T", resp)
+	}
+	if badReq.Error.Code != ErrCodeValidationError {
+		t.Errorf("expected %s, got %q", ErrCodeValidationError, badReq.Error.Code)
+	}
+}
+
+func TestUpdateNotificationSettings_EmailDigest(t *testing.T) {
+	h, db := testHandler(t)
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ctx := ctxWithUser(t, h, u.ID)
+
+	daily := openapi.Daily
+	resp, err := h.UpdateNotificationSettings(ctx, openapi.UpdateNotificationSettingsRequestObject{
+		Body: &openapi.NotificationSettings{HighlightKeywords: []string{}, EmailDigest: &daily},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateNotificationSettings200JSONResponse); !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+
+	// Omitting the frequency keeps it
+	resp, err = h.UpdateNotificationSettings(ctx, openapi.UpdateNotificationSettingsRequestObject{
+		Body: &openapi.NotificationSettings{HighlightKeywords: []string{"outage"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	settings := resp.(openapi.UpdateNotificationSettings200JSONResponse).Settings
+	if settings.EmailDigest == nil || *settings.EmailDigest != openapi.Daily {
+		t.Errorf("expected the daily digest to be kept, got %v", settings.EmailDigest)
+	}
+
+	invalid := openapi.EmailDigest("weekly")
+	resp, err = h.UpdateNotificationSettings(ctx, openapi.UpdateNotificationSettingsRequestObject{
+		Body: &openapi.NotificationSettings{HighlightKeywords: []string{}, EmailDigest: &invalid},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateNotificationSettings400JSONResponse); !ok {
+		t.Fatalf("expected 400 response for an unknown frequency, got %T", resp)
+	}
+}
+
+func TestUnsubscribeFromEmail(t *testing.T) {
+	h, db := testHandler(t)
+	u := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+
+	// Unauthenticated, as sent by a mail client
+	ctx := context.Background()
+	resp, err := h.UnsubscribeFromEmail(ctx, openapi.UnsubscribeFromEmailRequestObject{
+		Params: openapi.UnsubscribeFromEmailParams{Token: h.signer.UnsubscribeToken(u.ID)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.UnsubscribeFromEmail200JSONResponse); !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+
+	getResp, err := h.GetNotificationSettings(ctxWithUser(t, h, u.ID), openapi.GetNotificationSettingsRequestObject{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	settings := getResp.(openapi.GetNotificationSettings200JSONResponse).Settings
+	if settings.EmailDigest == nil || *settings.EmailDigest != openapi.Never {
+		t.Errorf("expected email digest never after unsubscribing, got %v", settings.EmailDigest)
+	}
+
+	for _, token := range []string{"", u.ID, u.ID + ".forged", h.signer.UnsubscribeToken("no-such-user")} {
+		resp, err := h.UnsubscribeFromEmail(ctx, openapi.UnsubscribeFromEmailRequestObject{
+			Params: openapi.UnsubscribeFromEmailParams{Token: token},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := resp.(openapi.UnsubscribeFromEmail400JSONResponse); !ok {
+			t.Errorf("token %q: expected 400 response, got %T", token, resp)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/enzyme/server/internal/gravatar"
 	"github.com/enzyme/server/internal/message"
 	"github.com/enzyme/server/internal/moderation"
+	"github.com/enzyme/server/internal/notification"
 	"github.com/enzyme/server/internal/openapi"
 	"github.com/enzyme/server/internal/sse"
 	"github.com/enzyme/server/internal/workspace"
@@ -32,6 +33,18 @@ func (h *Handler) CreateWorkspace(ctx context.Context, request openapi.CreateWor
 		return openapi.CreateWorkspace400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Name is required")}, nil
 	}
 
+	u, err := h.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := h.adminService.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !settings.CanCreateWorkspace(u) {
+		return openapi.CreateWorkspace403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Only site admins can create workspaces")}, nil
+	}
+
 	ws := &workspace.Workspace{
 		Name:     request.Body.Name,
 		Settings: "{}",
@@ -150,6 +163,33 @@ func (h *Handler) UpdateWorkspace(ctx context.Context, request openapi.UpdateWor
 			}
 			settings.WhoCanManageCustomEmoji = v
 		}
+		if request.Body.Settings.WhoCanMentionHere != nil {
+			v := workspace.PermissionLevel(*request.Body.Settings.WhoCanMentionHere)
+			if !workspace.IsValidPermissionLevel(v) {
+				return openapi.UpdateWorkspace400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid value for who_can_mention_here")}, nil
+			}
+			settings.WhoCanMentionHere = v
+		}
+		if request.Body.Settings.WhoCanMentionChannel != nil {
+			v := workspace.PermissionLevel(*request.Body.Settings.WhoCanMentionChannel)
+			if !workspace.IsValidPermissionLevel(v) {
+				return openapi.UpdateWorkspace400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid value for who_can_mention_channel")}, nil
+			}
+			settings.WhoCanMentionChannel = v
+		}
+		if request.Body.Settings.WhoCanMentionEveryone != nil {
+			v := workspace.PermissionLevel(*request.Body.Settings.WhoCanMentionEveryone)
+			if !workspace.IsValidPermissionLevel(v) {
+				return openapi.UpdateWorkspace400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid value for who_can_mention_everyone")}, nil
+			}
+			settings.WhoCanMentionEveryone = v
+		}
+		if request.Body.Settings.MentionConfirmThreshold != nil {
+			if *request.Body.Settings.MentionConfirmThreshold < 0 {
+				return openapi.UpdateWorkspace400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "mention_confirm_threshold cannot be negative")}, nil
+			}
+			settings.MentionConfirmThreshold = *request.Body.Settings.MentionConfirmThreshold
+		}
 
 		// Serialize back to JSON string
 		ws.Settings = settings.ToJSON()
@@ -594,12 +634,19 @@ func workspaceToAPI(ws *workspace.Workspace) openapi.Workspace {
 	whoCanCreateInvites := openapi.PermissionLevel(settings.WhoCanCreateInvites)
 	whoCanPinMessages := openapi.PermissionLevel(settings.WhoCanPinMessages)
 	whoCanManageCustomEmoji := openapi.PermissionLevel(settings.WhoCanManageCustomEmoji)
+	whoCanMentionHere := openapi.PermissionLevel(settings.WhoCanMentionHere)
+	whoCanMentionChannel := openapi.PermissionLevel(settings.WhoCanMentionChannel)
+	whoCanMentionEveryone := openapi.PermissionLevel(settings.WhoCanMentionEveryone)
 	apiWs.ParsedSettings = &openapi.WorkspaceSettings{
 		ShowJoinLeaveMessages:   &settings.ShowJoinLeaveMessages,
 		WhoCanCreateChannels:    &whoCanCreateChannels,
 		WhoCanCreateInvites:     &whoCanCreateInvites,
 		WhoCanPinMessages:       &whoCanPinMessages,
 		WhoCanManageCustomEmoji: &whoCanManageCustomEmoji,
+		WhoCanMentionHere:       &whoCanMentionHere,
+		WhoCanMentionChannel:    &whoCanMentionChannel,
+		WhoCanMentionEveryone:   &whoCanMentionEveryone,
+		MentionConfirmThreshold: &settings.MentionConfirmThreshold,
 	}
 
 	return apiWs
@@ -843,6 +890,165 @@ func (h *Handler) GetWorkspaceNotifications(ctx context.Context, request openapi
 	}, nil
 }
 
+// GetWorkspaceNotificationPreferences returns the current user's notification
+// defaults and effective preferences for each of their channels in a workspace
+func (h *Handler) GetWorkspaceNotificationPreferences(ctx context.Context, request openapi.GetWorkspaceNotificationPreferencesRequestObject) (openapi.GetWorkspaceNotificationPreferencesResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.GetWorkspaceNotificationPreferences401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	workspaceID := string(request.Wid)
+	if _, err := h.workspaceRepo.GetMembership(ctx, userID, workspaceID); err != nil {
+		return openapi.GetWorkspaceNotificationPreferences403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+
+	defaults, customized, err := h.notificationService.GetDefaults(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	prefs, err := h.notificationService.ListPreferences(ctx, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := make([]openapi.ChannelNotificationPreferences, len(prefs))
+	for i, pref := range prefs {
+		channels[i] = openapi.ChannelNotificationPreferences{
+			ChannelId:    pref.ChannelID,
+			NotifyLevel:  openapi.NotifyLevel(pref.NotifyLevel),
+			EmailEnabled: pref.EmailEnabled,
+			PushEnabled:  pref.PushEnabled,
+			Inherited:    !pref.Overridden,
+		}
+	}
+
+	return openapi.GetWorkspaceNotificationPreferences200JSONResponse{
+		Defaults:           notificationDefaultsToAPI(defaults),
+		DefaultsCustomized: customized,
+		Channels:           channels,
+	}, nil
+}
+
+// UpdateNotificationDefaults sets the current user's notification defaults
+// for a workspace
+func (h *Handler) UpdateNotificationDefaults(ctx context.Context, request openapi.UpdateNotificationDefaultsRequestObject) (openapi.UpdateNotificationDefaultsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.UpdateNotificationDefaults401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	workspaceID := string(request.Wid)
+	if _, err := h.workspaceRepo.GetMembership(ctx, userID, workspaceID); err != nil {
+		return openapi.UpdateNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+
+	defaults := notificationDefaultsFromAPI(*request.Body)
+	if !notification.IsValidNotifyLevel(defaults.NotifyLevel) {
+		return openapi.UpdateNotificationDefaults400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid notify_level")}, nil
+	}
+
+	if err := h.notificationService.SetDefaults(ctx, userID, workspaceID, defaults); err != nil {
+		return nil, err
+	}
+
+	return openapi.UpdateNotificationDefaults200JSONResponse{Defaults: notificationDefaultsToAPI(defaults)}, nil
+}
+
+// ResetNotificationDefaults removes the current user's notification defaults
+// for a workspace, so they follow the workspace's
+func (h *Handler) ResetNotificationDefaults(ctx context.Context, request openapi.ResetNotificationDefaultsRequestObject) (openapi.ResetNotificationDefaultsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.ResetNotificationDefaults401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	workspaceID := string(request.Wid)
+	if _, err := h.workspaceRepo.GetMembership(ctx, userID, workspaceID); err != nil {
+		return openapi.ResetNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+
+	if err := h.notificationService.ResetDefaults(ctx, userID, workspaceID); err != nil {
+		return nil, err
+	}
+	defaults, err := h.notificationService.GetWorkspaceDefaults(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.ResetNotificationDefaults200JSONResponse{Defaults: notificationDefaultsToAPI(defaults)}, nil
+}
+
+// GetWorkspaceNotificationDefaults returns the notification defaults for
+// members of a workspace who haven't set their own
+func (h *Handler) GetWorkspaceNotificationDefaults(ctx context.Context, request openapi.GetWorkspaceNotificationDefaultsRequestObject) (openapi.GetWorkspaceNotificationDefaultsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.GetWorkspaceNotificationDefaults401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	workspaceID := string(request.Wid)
+	if _, err := h.workspaceRepo.GetMembership(ctx, userID, workspaceID); err != nil {
+		return openapi.GetWorkspaceNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+
+	defaults, err := h.notificationService.GetWorkspaceDefaults(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.GetWorkspaceNotificationDefaults200JSONResponse{Defaults: notificationDefaultsToAPI(defaults)}, nil
+}
+
+// UpdateWorkspaceNotificationDefaults sets the notification defaults for
+// members of a workspace (admin only)
+func (h *Handler) UpdateWorkspaceNotificationDefaults(ctx context.Context, request openapi.UpdateWorkspaceNotificationDefaultsRequestObject) (openapi.UpdateWorkspaceNotificationDefaultsResponseObject, error) {
+	userID := h.getUserID(ctx)
+	if userID == "" {
+		return openapi.UpdateWorkspaceNotificationDefaults401JSONResponse{UnauthorizedJSONResponse: unauthorizedResponse()}, nil
+	}
+
+	workspaceID := string(request.Wid)
+	membership, err := h.workspaceRepo.GetMembership(ctx, userID, workspaceID)
+	if err != nil {
+		return openapi.UpdateWorkspaceNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Not a workspace member")}, nil
+	}
+	if !workspace.CanManageMembers(membership.Role) {
+		return openapi.UpdateWorkspaceNotificationDefaults403JSONResponse{ForbiddenJSONResponse: forbiddenResponse("Permission denied")}, nil
+	}
+
+	defaults := notificationDefaultsFromAPI(*request.Body)
+	if !notification.IsValidNotifyLevel(defaults.NotifyLevel) {
+		return openapi.UpdateWorkspaceNotificationDefaults400JSONResponse{BadRequestJSONResponse: badRequestResponse(ErrCodeValidationError, "Invalid notify_level")}, nil
+	}
+
+	if err := h.notificationService.SetWorkspaceDefaults(ctx, workspaceID, defaults); err != nil {
+		return nil, err
+	}
+
+	return openapi.UpdateWorkspaceNotificationDefaults200JSONResponse{Defaults: notificationDefaultsToAPI(defaults)}, nil
+}
+
+// notificationDefaultsToAPI converts notification defaults to API type
+func notificationDefaultsToAPI(d notification.Defaults) openapi.NotificationDefaults {
+	return openapi.NotificationDefaults{
+		NotifyLevel:        openapi.NotifyLevel(d.NotifyLevel),
+		EmailEnabled:       d.EmailEnabled,
+		PushEnabled:        d.PushEnabled,
+		MobileOnlyWhenIdle: d.MobileOnlyWhenIdle,
+	}
+}
+
+// notificationDefaultsFromAPI converts API notification defaults
+func notificationDefaultsFromAPI(d openapi.NotificationDefaults) notification.Defaults {
+	return notification.Defaults{
+		NotifyLevel:        string(d.NotifyLevel),
+		EmailEnabled:       d.EmailEnabled,
+		PushEnabled:        d.PushEnabled,
+		MobileOnlyWhenIdle: d.MobileOnlyWhenIdle,
+	}
+}
+
 // ListAllUnreads lists all unread messages across channels in a workspace
 func (h *Handler) ListAllUnreads(ctx context.Context, request openapi.ListAllUnreadsRequestObject) (openapi.ListAllUnreadsResponseObject, error) {
 	userID := h.getUserID(ctx)
@@ -635,3 +635,113 @@ func TestCreateWorkspaceInvite_NotAdmin(t *testing.T) {
 		t.Fatalf("expected 403 response, got %T", resp)
 	}
 }
+
+func TestUpdateWorkspaceNotificationDefaults_RequiresAdmin(t *testing.T) {
+	h, db := testHandler(t)
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	member := testutil.CreateTestUser(t, db, "member@test.com", "Member")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "WS")
+	addWorkspaceMember(t, db, member.ID, ws.ID, "member")
+
+	body := &openapi.UpdateWorkspaceNotificationDefaultsJSONRequestBody{
+		NotifyLevel:  openapi.NotifyLevel("all"),
+		EmailEnabled: true,
+		PushEnabled:  true,
+	}
+
+	resp, err := h.UpdateWorkspaceNotificationDefaults(ctxWithUser(t, h, member.ID), openapi.UpdateWorkspaceNotificationDefaultsRequestObject{Wid: ws.ID, Body: body})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateWorkspaceNotificationDefaults403JSONResponse); !ok {
+		t.Fatalf("expected 403 response, got %T", resp)
+	}
+
+	body.NotifyLevel = "sometimes"
+	resp, err = h.UpdateWorkspaceNotificationDefaults(ctxWithUser(t, h, owner.ID), openapi.UpdateWorkspaceNotificationDefaultsRequestObject{Wid: ws.ID, Body: body})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := resp.(openapi.UpdateWorkspaceNotificationDefaults400JSONResponse); !ok {
+		t.Fatalf("expected 400 response, got %T", resp)
+	}
+}
+
+func TestGetWorkspaceNotificationPreferences_Inheritance(t *testing.T) {
+	h, db := testHandler(t)
+
+	owner := testutil.CreateTestUser(t, db, "owner@test.com", "Owner")
+	member := testutil.CreateTestUser(t, db, "member@test.com", "Member")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "WS")
+	addWorkspaceMember(t, db, member.ID, ws.ID, "member")
+	general := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "general", "public")
+	announcements := testutil.CreateTestChannel(t, db, ws.ID, owner.ID, "announcements", "public")
+	addChannelMember(t, db, member.ID, general.ID, nil)
+	addChannelMember(t, db, member.ID, announcements.ID, nil)
+
+	ownerCtx := ctxWithUser(t, h, owner.ID)
+	_, err := h.UpdateWorkspaceNotificationDefaults(ownerCtx, openapi.UpdateWorkspaceNotificationDefaultsRequestObject{
+		Wid:  ws.ID,
+		Body: &openapi.UpdateWorkspaceNotificationDefaultsJSONRequestBody{NotifyLevel: "none", EmailEnabled: false, PushEnabled: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = h.UpdateChannelNotificationDefaults(ownerCtx, openapi.UpdateChannelNotificationDefaultsRequestObject{
+		Id:   announcements.ID,
+		Body: &openapi.UpdateChannelNotificationDefaultsJSONRequestBody{NotifyLevel: "all"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	memberCtx := ctxWithUser(t, h, member.ID)
+	resp, err := h.GetWorkspaceNotificationPreferences(memberCtx, openapi.GetWorkspaceNotificationPreferencesRequestObject{Wid: ws.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, ok := resp.(openapi.GetWorkspaceNotificationPreferences200JSONResponse)
+	if !ok {
+		t.Fatalf("expected 200 response, got %T", resp)
+	}
+	if r.DefaultsCustomized || r.Defaults.NotifyLevel != "none" {
+		t.Errorf("defaults = %+v (customized %v), want the workspace's", r.Defaults, r.DefaultsCustomized)
+	}
+	levels := make(map[string]openapi.NotifyLevel)
+	for _, c := range r.Channels {
+		if !c.Inherited {
+			t.Errorf("channel %s inherited = false, want true", c.ChannelId)
+		}
+		levels[c.ChannelId] = c.NotifyLevel
+	}
+	if len(levels) != 2 || levels[general.ID] != "none" || levels[announcements.ID] != "all" {
+		t.Errorf("channel levels = %v, want general none and announcements all", levels)
+	}
+
+	// The member's own defaults replace the workspace's, but not the channel default
+	_, err = h.UpdateNotificationDefaults(memberCtx, openapi.UpdateNotificationDefaultsRequestObject{
+		Wid:  ws.ID,
+		Body: &openapi.UpdateNotificationDefaultsJSONRequestBody{NotifyLevel: "mentions", EmailEnabled: true, PushEnabled: true, MobileOnlyWhenIdle: true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err = h.GetWorkspaceNotificationPreferences(memberCtx, openapi.GetWorkspaceNotificationPreferencesRequestObject{Wid: ws.ID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r = resp.(openapi.GetWorkspaceNotificationPreferences200JSONResponse)
+	if !r.DefaultsCustomized || r.Defaults.NotifyLevel != "mentions" {
+		t.Errorf("defaults = %+v (customized %v), want the member's", r.Defaults, r.DefaultsCustomized)
+	}
+	for _, c := range r.Channels {
+		want := openapi.NotifyLevel("mentions")
+		if c.ChannelId == announcements.ID {
+			want = "all"
+		}
+		if c.NotifyLevel != want {
+			t.Errorf("channel %s notify_level = %q, want %q", c.ChannelId, c.NotifyLevel, want)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Package imageproxy fetches external images on behalf of clients, so that
+// link preview images are served from this server instead of third-party
+// hosts that would otherwise see every viewer's IP address.
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/enzyme/server/internal/linkpreview"
+	"github.com/enzyme/server/internal/signing"
+	"github.com/enzyme/server/internal/storage"
+)
+
+const (
+	// Path is the route the proxy is mounted on.
+	Path = "/api/image-proxy"
+
+	fetchTimeout   = 10 * time.Second
+	storagePrefix  = "image-proxy/"
+	evictBatchSize = 500
+)
+
+var (
+	ErrTooLarge        = errors.New("image exceeds the maximum proxied size")
+	ErrUnsupportedType = errors.New("content is not a supported image type")
+)
+
+// allowedTypes are the sniffed content types the proxy will serve. SVG is
+// deliberately excluded since it can carry script.
+var allowedTypes = map[string]bool{
+	"image/png":    true,
+	"image/jpeg":   true,
+	"image/gif":    true,
+	"image/webp":   true,
+	"image/avif":   true,
+	"image/bmp":    true,
+	"image/x-icon": true,
+}
+
+// Proxy serves signed external image URLs, caching the bytes in storage.
+type Proxy struct {
+	repo    *Repository
+	store   storage.Storage // nil disables caching; images are fetched on every request
+	signer  *signing.Signer
+	client  *http.Client
+	maxSize int64
+	ttl     time.Duration
+	group   singleflight.Group
+}
+
+// New creates a Proxy. If client is nil, the SSRF-safe link preview client is used.
+func New(repo *Repository, store storage.Storage, signer *signing.Signer, client *http.Client, maxSize int64, ttl time.Duration) *Proxy {
+	if client == nil {
+		client = linkpreview.NewSafeClient(fetchTimeout)
+	}
+	return &Proxy{repo: repo, store: store, signer: signer, client: client, maxSize: maxSize, ttl: ttl}
+}
+
+// URL returns the signed proxy URL for an external image. Anything that is
+// not an absolute http(s) URL is returned unchanged.
+func (p *Proxy) URL(rawURL string) string {
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		return rawURL
+	}
+	q := url.Values{}
+	q.Set("url", rawURL)
+	q.Set("sig", p.signer.SignURL(rawURL))
+	return Path + "?" + q.Encode()
+}
+
+// image is a fetched or cached image held in memory.
+type image struct {
+	contentType string
+	data        []byte
+}
+
+// ServeHTTP serves the image named by the url query parameter. The sig
+// parameter must be the server's signature of that URL, so the endpoint
+// cannot be used as an open proxy.
+func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rawURL := r.URL.Query().Get("url")
+	if rawURL == "" {
+		http.Error(w, "Missing url", http.StatusBadRequest)
+		return
+	}
+	if err := p.signer.VerifyURL(rawURL, r.URL.Query().Get("sig")); err != nil {
+		http.Error(w, "Invalid signature", http.StatusForbidden)
+		return
+	}
+	if u, err := url.Parse(rawURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		http.Error(w, "Invalid url", http.StatusBadRequest)
+		return
+	}
+
+	img, err := p.load(r.Context(), rawURL)
+	if err != nil {
+		slog.Debug("image proxy fetch failed", "url", rawURL, "error", err)
+		http.Error(w, "Image unavailable", http.StatusBadGateway)
+		return
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", img.contentType)
+	h.Set("Content-Length", strconv.Itoa(len(img.data)))
+	h.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(p.ttl.Seconds())))
+	h.Set("X-Content-Type-Options", "nosniff")
+	h.Set("Content-Security-Policy", "default-src 'none'; sandbox")
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = w.Write(img.data)
+}
+
+// load returns the image from the storage cache, fetching it on a miss.
+// Concurrent requests for the same URL share a single upstream fetch.
+func (p *Proxy) load(ctx context.Context, rawURL string) (*image, error) {
+	hash := hashURL(rawURL)
+	if img := p.cached(ctx, hash); img != nil {
+		return img, nil
+	}
+
+	v, err, _ := p.group.Do(hash, func() (any, error) {
+		img, err := p.fetch(context.WithoutCancel(ctx), rawURL)
+		if err != nil {
+			return nil, err
+		}
+		p.save(context.WithoutCancel(ctx), hash, rawURL, img)
+		return img, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*image), nil
+}
+
+// cached reads an image from storage, or returns nil on any miss.
+func (p *Proxy) cached(ctx context.Context, hash string) *image {
+	if p.store == nil {
+		return nil
+	}
+	entry, err := p.repo.Get(ctx, hash)
+	if err != nil {
+		slog.Error("failed to read image proxy cache", "error", err)
+		return nil
+	}
+	if entry == nil {
+		return nil
+	}
+	rc, err := p.store.Get(ctx, entry.StorageKey)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			slog.Error("failed to read cached image", "key", entry.StorageKey, "error", err)
+		}
+		return nil
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, p.maxSize+1))
+	if err != nil || int64(len(data)) > p.maxSize {
+		return nil
+	}
+	return &image{contentType: entry.ContentType, data: data}
+}
+
+// save stores a fetched image and records it in the cache table. Failures
+// are logged: the image is still served, just not cached.
+func (p *Proxy) save(ctx context.Context, hash, rawURL string, img *image) {
+	if p.store == nil {
+		return
+	}
+	key := storagePrefix + hash
+	if err := p.store.Put(ctx, key, bytes.NewReader(img.data), int64(len(img.data)), img.contentType); err != nil {
+		slog.Error("failed to cache proxied image", "key", key, "error", err)
+		return
+	}
+	now := time.Now().UTC()
+	if err := p.repo.Set(ctx, &Entry{
+		URLHash:     hash,
+		URL:         rawURL,
+		ContentType: img.contentType,
+		SizeBytes:   int64(len(img.data)),
+		StorageKey:  key,
+		FetchedAt:   now,
+		ExpiresAt:   now.Add(p.ttl),
+	}); err != nil {
+		slog.Error("failed to record proxied image", "key", key, "error", err)
+	}
+}
+
+// fetch downloads an image, enforcing the size limit and checking the
+// content type from the bytes rather than trusting the upstream header.
+func (p *Proxy) fetch(ctx context.Context, rawURL string) (*image, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", linkpreview.UserAgent)
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	if resp.ContentLength > p.maxSize {
+		return nil, ErrTooLarge
+	}
+	if declared, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); declared != "" &&
+		!strings.HasPrefix(declared, "image/") && declared != "application/octet-stream" {
+		return nil, ErrUnsupportedType
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, p.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > p.maxSize {
+		return nil, ErrTooLarge
+	}
+
+	contentType := sniff(data)
+	if !allowedTypes[contentType] {
+		return nil, ErrUnsupportedType
+	}
+	return &image{contentType: contentType, data: data}, nil
+}
+
+// sniff detects the content type of data. http.DetectContentType does not
+// know AVIF, so its ISO-BMFF brand is checked first.
+func sniff(data []byte) string {
+	if len(data) >= 12 && string(data[4:8]) == "ftyp" {
+		switch string(data[8:12]) {
+		case "avif", "avis":
+			return "image/avif"
+		}
+	}
+	ct, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	return ct
+}
+
+// EvictExpired deletes cached images whose TTL has passed.
+func (p *Proxy) EvictExpired(ctx context.Context) error {
+	evicted := 0
+	for {
+		entries, err := p.repo.ListExpired(ctx, time.Now(), evictBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if p.store != nil {
+				if err := p.store.Delete(ctx, e.StorageKey); err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return fmt.Errorf("deleting cached image %q: %w", e.StorageKey, err)
+				}
+			}
+			if err := p.repo.Delete(ctx, e.URLHash); err != nil {
+				return err
+			}
+			evicted++
+		}
+		if len(entries) < evictBatchSize {
+			if evicted > 0 {
+				slog.Info("evicted expired proxied images", "count", evicted)
+			}
+			return nil
+		}
+	}
+}
+
+func hashURL(rawURL string) string {
+	sum := sha256.Sum256([]byte(rawURL))
+	return hex.EncodeToString(sum[:])
+}
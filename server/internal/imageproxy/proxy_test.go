@@ -0,0 +1,171 @@
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/signing"
+	"github.com/enzyme/server/internal/storage"
+	"github.com/enzyme/server/internal/testutil"
+)
+
+var pngBytes = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+type fixture struct {
+	proxy    *Proxy
+	repo     *Repository
+	dir      string
+	upstream *httptest.Server
+	hits     atomic.Int32
+}
+
+// newFixture serves body with contentType from an upstream test server.
+func newFixture(t *testing.T, contentType string, body []byte) *fixture {
+	t.Helper()
+	f := &fixture{dir: t.TempDir()}
+	f.upstream = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.hits.Add(1)
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(f.upstream.Close)
+
+	f.repo = NewRepository(testutil.TestDB(t))
+	f.proxy = New(f.repo, storage.NewLocal(f.dir), signing.NewSigner("test-secret"), f.upstream.Client(), 1024, time.Hour)
+	return f
+}
+
+func (f *fixture) get(t *testing.T, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	f.proxy.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+	return w
+}
+
+func TestURL(t *testing.T) {
+	f := newFixture(t, "image/png", pngBytes)
+
+	proxied := f.proxy.URL("https://example.com/a.png?size=large")
+	if !strings.HasPrefix(proxied, Path+"?") {
+		t.Fatalf("URL = %q, want %s prefix", proxied, Path)
+	}
+	u, _ := url.Parse(proxied)
+	if got := u.Query().Get("url"); got != "https://example.com/a.png?size=large" {
+		t.Errorf("url param = %q", got)
+	}
+
+	for _, raw := range []string{"", "/api/avatars/x.png", "data:image/png;base64,AAAA"} {
+		if got := f.proxy.URL(raw); got != raw {
+			t.Errorf("URL(%q) = %q, want unchanged", raw, got)
+		}
+	}
+}
+
+func TestServeHTTP_RejectsBadSignature(t *testing.T) {
+	f := newFixture(t, "image/png", pngBytes)
+	target := f.upstream.URL + "/a.png"
+
+	w := f.get(t, Path+"?url="+url.QueryEscape(target)+"&sig=deadbeef")
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+	if f.hits.Load() != 0 {
+		t.Fatal("upstream should not be contacted for an unsigned URL")
+	}
+}
+
+func TestServeHTTP_CachesInStorage(t *testing.T) {
+	f := newFixture(t, "image/png", pngBytes)
+	target := f.proxy.URL(f.upstream.URL + "/a.png")
+
+	for i := 0; i < 2; i++ {
+		w := f.get(t, target)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, body %q", i, w.Code, w.Body.String())
+		}
+		if !bytes.Equal(w.Body.Bytes(), pngBytes) {
+			t.Fatalf("request %d: body mismatch", i)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "image/png" {
+			t.Errorf("Content-Type = %q, want image/png", ct)
+		}
+		if w.Header().Get("X-Content-Type-Options") != "nosniff" {
+			t.Error("missing nosniff header")
+		}
+	}
+	if hits := f.hits.Load(); hits != 1 {
+		t.Fatalf("upstream hits = %d, want 1", hits)
+	}
+
+	key := storagePrefix + hashURL(f.upstream.URL+"/a.png")
+	if _, err := os.Stat(filepath.Join(f.dir, key)); err != nil {
+		t.Fatalf("cached object missing: %v", err)
+	}
+}
+
+func TestServeHTTP_Limits(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+	}{
+		{"html", "text/html", []byte("<html><body>hi</body></html>")},
+		{"svg", "image/svg+xml", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)},
+		{"mislabelled html", "image/png", []byte("<html><script>alert(1)</script></html>")},
+		{"too large", "image/png", append(pngBytes, make([]byte, 2048)...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.contentType, tt.body)
+			w := f.get(t, f.proxy.URL(f.upstream.URL+"/img"))
+			if w.Code != http.StatusBadGateway {
+				t.Fatalf("status = %d, want 502", w.Code)
+			}
+		})
+	}
+}
+
+func TestSniff_AVIF(t *testing.T) {
+	avif := append([]byte("\x00\x00\x00\x1cftypavif"), make([]byte, 16)...)
+	if got := sniff(avif); got != "image/avif" {
+		t.Fatalf("sniff = %q, want image/avif", got)
+	}
+}
+
+func TestEvictExpired(t *testing.T) {
+	f := newFixture(t, "image/png", pngBytes)
+	ctx := context.Background()
+	target := f.upstream.URL + "/a.png"
+	if w := f.get(t, f.proxy.URL(target)); w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+
+	hash := hashURL(target)
+	entry, _ := f.repo.Get(ctx, hash)
+	if entry == nil {
+		t.Fatal("expected cache entry")
+	}
+	entry.ExpiresAt = time.Now().UTC().Add(-time.Minute)
+	if err := f.repo.Set(ctx, entry); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if err := f.proxy.EvictExpired(ctx); err != nil {
+		t.Fatalf("EvictExpired: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(f.dir, entry.StorageKey)); !os.IsNotExist(err) {
+		t.Fatalf("cached object should be deleted, stat err = %v", err)
+	}
+	if expired, _ := f.repo.ListExpired(ctx, time.Now(), 10); len(expired) != 0 {
+		t.Fatalf("expired entries remain: %d", len(expired))
+	}
+}
@@ -0,0 +1,102 @@
+package imageproxy
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Entry is a cached external image.
+type Entry struct {
+	URLHash     string
+	URL         string
+	ContentType string
+	SizeBytes   int64
+	StorageKey  string
+	FetchedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Repository handles image proxy cache persistence.
+type Repository struct {
+	db *sql.DB
+}
+
+// NewRepository creates a new Repository.
+func NewRepository(db *sql.DB) *Repository {
+	return &Repository{db: db}
+}
+
+// Get returns the cache entry for a URL hash, or nil if not found / expired.
+func (r *Repository) Get(ctx context.Context, urlHash string) (*Entry, error) {
+	e, err := scanEntry(r.db.QueryRowContext(ctx, `
+		SELECT url_hash, url, content_type, size_bytes, storage_key, fetched_at, expires_at
+		FROM image_proxy_cache WHERE url_hash = ?
+	`, urlHash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Treat expired entries as a miss; eviction removes them later.
+	if time.Now().After(e.ExpiresAt) {
+		return nil, nil
+	}
+	return e, nil
+}
+
+// Set inserts or replaces a cache entry.
+func (r *Repository) Set(ctx context.Context, e *Entry) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO image_proxy_cache (url_hash, url, content_type, size_bytes, storage_key, fetched_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url_hash) DO UPDATE SET
+			url = excluded.url, content_type = excluded.content_type, size_bytes = excluded.size_bytes,
+			storage_key = excluded.storage_key, fetched_at = excluded.fetched_at, expires_at = excluded.expires_at
+	`, e.URLHash, e.URL, e.ContentType, e.SizeBytes, e.StorageKey,
+		e.FetchedAt.Format(time.RFC3339), e.ExpiresAt.Format(time.RFC3339))
+	return err
+}
+
+// ListExpired returns up to limit entries that expired before now.
+func (r *Repository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*Entry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT url_hash, url, content_type, size_bytes, storage_key, fetched_at, expires_at
+		FROM image_proxy_cache WHERE expires_at < ?
+		ORDER BY expires_at
+		LIMIT ?
+	`, now.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*Entry
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Delete removes the cache entry for a URL hash.
+func (r *Repository) Delete(ctx context.Context, urlHash string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM image_proxy_cache WHERE url_hash = ?`, urlHash)
+	return err
+}
+
+func scanEntry(scanner interface{ Scan(dest ...any) error }) (*Entry, error) {
+	var e Entry
+	var fetchedAt, expiresAt string
+	if err := scanner.Scan(&e.URLHash, &e.URL, &e.ContentType, &e.SizeBytes, &e.StorageKey, &fetchedAt, &expiresAt); err != nil {
+		return nil, err
+	}
+	e.FetchedAt, _ = time.Parse(time.RFC3339, fetchedAt)
+	e.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+	return &e, nil
+}
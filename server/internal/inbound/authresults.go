@@ -0,0 +1,81 @@
+package inbound
+
+import (
+	"strings"
+)
+
+// SenderVerified reports whether the mail server named authservID vouched
+// for the From address in the Authentication-Results header (RFC 8601) it
+// added: with a DMARC pass for the From domain, or a DKIM pass for the From
+// domain or a parent of it. Only the topmost header from authservID counts,
+// since that server is expected to remove copies of its header that came in
+// with the message.
+func (m *Message) SenderVerified(authservID string) bool {
+	at := strings.LastIndex(m.From, "@")
+	if at < 0 || authservID == "" {
+		return false
+	}
+	fromDomain := strings.ToLower(m.From[at+1:])
+
+	for _, value := range m.AuthResults {
+		parts := strings.Split(stripComments(value), ";")
+		id := strings.Fields(parts[0])
+		if len(id) == 0 || !strings.EqualFold(id[0], authservID) {
+			continue
+		}
+		for _, part := range parts[1:] {
+			method, result, props := parseResInfo(part)
+			if result != "pass" {
+				continue
+			}
+			switch method {
+			case "dmarc":
+				if d, ok := props["header.from"]; !ok || d == fromDomain {
+					return true
+				}
+			case "dkim":
+				d := props["header.d"]
+				if d != "" && (fromDomain == d || strings.HasSuffix(fromDomain, "."+d)) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// parseResInfo splits a result like "dkim=pass header.d=example.com" into
+// its method, result and lower-cased properties.
+func parseResInfo(s string) (method, result string, props map[string]string) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return "", "", nil
+	}
+	method, result, _ = strings.Cut(fields[0], "=")
+	method, _, _ = strings.Cut(method, "/") // a method may carry a version
+	props = make(map[string]string)
+	for _, f := range fields[1:] {
+		if k, v, ok := strings.Cut(f, "="); ok {
+			props[k] = strings.Trim(v, `"`)
+		}
+	}
+	return method, result, props
+}
+
+// stripComments removes the parenthesized comments of a header value.
+func stripComments(s string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
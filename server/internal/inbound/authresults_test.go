@@ -0,0 +1,49 @@
+package inbound
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessage_SenderVerified(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		results []string
+		want    bool
+	}{
+		{"no header", "alerts@vendor.com", nil, false},
+		{"dmarc pass", "alerts@vendor.com", []string{"mx.example.org; spf=fail; dmarc=pass (p=reject) header.from=vendor.com"}, true},
+		{"dmarc pass for another domain", "alerts@vendor.com", []string{"mx.example.org; dmarc=pass header.from=evil.example"}, false},
+		{"dkim pass", "alerts@vendor.com", []string{"mx.example.org;\r\n dkim=pass header.d=vendor.com header.s=sel"}, true},
+		{"dkim pass for a parent domain", "alerts@mail.vendor.com", []string{"MX.example.org 1; dkim=pass header.d=\"vendor.com\""}, true},
+		{"dkim pass for another domain", "alerts@vendor.com", []string{"mx.example.org; dkim=pass header.d=notvendor.com"}, false},
+		{"dkim fail", "alerts@vendor.com", []string{"mx.example.org; dkim=fail header.d=vendor.com"}, false},
+		{"spf pass only", "alerts@vendor.com", []string{"mx.example.org; spf=pass smtp.mailfrom=vendor.com"}, false},
+		{"untrusted server", "alerts@vendor.com", []string{"mx.evil.example; dmarc=pass header.from=vendor.com"}, false},
+		{"forged header below the trusted one", "alerts@vendor.com", []string{
+			"mx.example.org; dmarc=fail header.from=vendor.com",
+			"mx.example.org; dmarc=pass header.from=vendor.com",
+		}, false},
+		{"result in a comment", "alerts@vendor.com", []string{"mx.example.org; dkim=none (dkim=pass header.d=vendor.com)"}, false},
+	}
+	for _, tt := range tests {
+		msg := &Message{From: tt.from, AuthResults: tt.results}
+		if got := msg.SenderVerified("mx.example.org"); got != tt.want {
+			t.Errorf("%s: SenderVerified() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParse_AuthResults(t *testing.T) {
+	raw := "Authentication-Results: mx.example.org; dkim=pass header.d=example.com\r\n" +
+		"Authentication-Results: mx.example.org; dkim=fail header.d=example.com\r\n" +
+		"From: grace@example.com\r\n\r\nhi\r\n"
+	msg, err := Parse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(msg.AuthResults) != 2 || !msg.SenderVerified("mx.example.org") {
+		t.Errorf("AuthResults = %q", msg.AuthResults)
+	}
+}
@@ -0,0 +1,91 @@
+package inbound
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/mail"
+)
+
+// NewHTTPHandler returns a handler for raw messages POSTed by a mail server,
+// e.g. from a pipe transport, as an alternative to the SMTP listener.
+// Requests must send token as a bearer token. The recipient is taken from
+// the "recipient" query parameter, falling back to the first accepted address
+// in the message's To and Cc headers.
+//
+// The status tells the mail server what to do with the message: 200 when it
+// was delivered, 4xx when it should bounce with the response body as the
+// reason, and 5xx when delivery should be retried later.
+func NewHTTPHandler(h Handler, token string, maxSize int64) http.Handler {
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if token == "" || subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="inbound email"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSize))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				http.Error(w, "message too big", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "reading message failed", http.StatusBadRequest)
+			return
+		}
+
+		msg, err := Parse(bytes.NewReader(raw))
+		if err != nil {
+			http.Error(w, "malformed message: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rcpt := r.URL.Query().Get("recipient")
+		if rcpt == "" {
+			rcpt = headerRecipient(h, raw)
+		}
+		if rcpt == "" || !h.Accepts(rcpt) {
+			http.Error(w, "no such recipient", http.StatusNotFound)
+			return
+		}
+
+		if err := h.Deliver(r.Context(), rcpt, msg); err != nil {
+			var reject *RejectError
+			switch {
+			case errors.As(err, &reject):
+				http.Error(w, reject.Reason, http.StatusUnprocessableEntity)
+			case errors.Is(err, ErrUnknownRecipient):
+				http.Error(w, "no such recipient", http.StatusNotFound)
+			default:
+				slog.Error("failed to deliver inbound email", "component", "inbound", "error", err)
+				http.Error(w, "temporary failure, try again later", http.StatusServiceUnavailable)
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// headerRecipient returns the first address in a message's To and Cc
+// headers that h accepts.
+func headerRecipient(h Handler, raw []byte) string {
+	m, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return ""
+	}
+	for _, key := range []string{"To", "Cc"} {
+		addrs, _ := m.Header.AddressList(key)
+		for _, addr := range addrs {
+			if h.Accepts(addr.Address) {
+				return addr.Address
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+package inbound
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	const raw = "From: grace@example.com\r\nTo: Enzyme <ok+abc@reply.example.com>\r\n\r\nhello\r\n"
+
+	tests := []struct {
+		name   string
+		auth   string
+		query  string
+		body   string
+		err    error
+		status int
+	}{
+		{"no token", "", "", raw, nil, http.StatusUnauthorized},
+		{"wrong token", "Bearer nope", "", raw, nil, http.StatusUnauthorized},
+		{"recipient from To", "Bearer secret", "", raw, nil, http.StatusOK},
+		{"recipient param", "Bearer secret", "?recipient=ok@reply.example.com", raw, nil, http.StatusOK},
+		{"unknown recipient", "Bearer secret", "?recipient=nobody@reply.example.com", raw, nil, http.StatusNotFound},
+		{"malformed", "Bearer secret", "", "not a message", nil, http.StatusBadRequest},
+		{"too big", "Bearer secret", "", raw + strings.Repeat("x", 1024), nil, http.StatusRequestEntityTooLarge},
+		{"rejected", "Bearer secret", "", raw, Reject("nope"), http.StatusUnprocessableEntity},
+		{"temporary failure", "Bearer secret", "", raw, http.ErrHandlerTimeout, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		h := &fakeHandler{err: tt.err}
+		req := httptest.NewRequest(http.MethodPost, "/api/email/inbound"+tt.query, strings.NewReader(tt.body))
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		rec := httptest.NewRecorder()
+		NewHTTPHandler(h, "secret", 1024).ServeHTTP(rec, req)
+		if rec.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d (%s)", tt.name, rec.Code, tt.status, rec.Body.String())
+		}
+		if tt.status == http.StatusOK && len(h.delivered) != 1 {
+			t.Errorf("%s: delivered %d messages, want 1", tt.name, len(h.delivered))
+		}
+	}
+}
+
+func TestMux(t *testing.T) {
+	replies := &fakeHandler{}
+	mux := NewMux("Reply.Example.com")
+	mux.Handle("reply+", replies)
+
+	for rcpt, want := range map[string]bool{
+		"reply+ok123@reply.example.com":       true,
+		"Reply+OK123@REPLY.EXAMPLE.COM":       true,
+		"Grace <reply+ok1@reply.example.com>": true,
+		"reply+ok123@other.example.com":       false,
+		"reply+@reply.example.com":            false,
+		"other+ok123@reply.example.com":       false,
+		"not an address":                      false,
+	} {
+		if got := mux.Accepts(rcpt); got != want {
+			t.Errorf("Accepts(%q) = %v, want %v", rcpt, got, want)
+		}
+	}
+
+	if err := mux.Deliver(t.Context(), "reply+ok123@reply.example.com", &Message{}); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if replies.delivered["ok123"] == nil {
+		t.Error("handler was not passed the rest of the local part")
+	}
+	if err := mux.Deliver(t.Context(), "other+ok@reply.example.com", &Message{}); err != ErrUnknownRecipient {
+		t.Errorf("Deliver() to unknown recipient = %v, want ErrUnknownRecipient", err)
+	}
+}
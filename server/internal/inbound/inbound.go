@@ -0,0 +1,98 @@
+// Package inbound receives email, either directly over SMTP or piped in by
+// a mail server over HTTP, and hands each message to a Handler chosen by
+// recipient address.
+package inbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// ErrUnknownRecipient is returned for mail to an address no handler accepts.
+var ErrUnknownRecipient = errors.New("unknown recipient")
+
+// RejectError is returned by Handler.Deliver for mail that can never be
+// delivered, as opposed to temporary failures the sender should retry. The
+// reason is sent back to the sender in the bounce.
+type RejectError struct {
+	Reason string
+}
+
+func (e *RejectError) Error() string {
+	return e.Reason
+}
+
+// Reject returns a RejectError with a formatted reason.
+func Reject(format string, args ...any) error {
+	return &RejectError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// Handler delivers inbound mail.
+type Handler interface {
+	// Accepts reports whether mail to the recipient address may be delivered.
+	// It is checked before the message is received, so it can't look at the
+	// sender.
+	Accepts(rcpt string) bool
+	// Deliver delivers a message to one recipient. Errors other than a
+	// RejectError are treated as temporary.
+	Deliver(ctx context.Context, rcpt string, msg *Message) error
+}
+
+// Mux routes mail to handlers by the prefix of the recipient address's
+// local part, e.g. "reply+" for reply+<token>@domain, on a single domain.
+type Mux struct {
+	domain   string
+	handlers []muxEntry
+}
+
+type muxEntry struct {
+	prefix  string
+	handler Handler
+}
+
+// NewMux creates a Mux accepting mail for the given domain.
+func NewMux(domain string) *Mux {
+	return &Mux{domain: strings.ToLower(domain)}
+}
+
+// Handle registers a handler for recipients whose local part starts with
+// prefix. The handler is passed the rest of the local part as rcpt.
+func (m *Mux) Handle(prefix string, h Handler) {
+	m.handlers = append(m.handlers, muxEntry{prefix: strings.ToLower(prefix), handler: h})
+}
+
+func (m *Mux) route(rcpt string) (Handler, string) {
+	addr, err := mail.ParseAddress(rcpt)
+	if err != nil {
+		return nil, ""
+	}
+	local, domain, ok := strings.Cut(addr.Address, "@")
+	if !ok || !strings.EqualFold(domain, m.domain) {
+		return nil, ""
+	}
+	local = strings.ToLower(local)
+	for _, e := range m.handlers {
+		if rest, ok := strings.CutPrefix(local, e.prefix); ok && rest != "" {
+			return e.handler, rest
+		}
+	}
+	return nil, ""
+}
+
+// Accepts implements Handler.
+func (m *Mux) Accepts(rcpt string) bool {
+	h, rest := m.route(rcpt)
+	return h != nil && h.Accepts(rest)
+}
+
+// Deliver implements Handler.
+func (m *Mux) Deliver(ctx context.Context, rcpt string, msg *Message) error {
+	h, rest := m.route(rcpt)
+	if h == nil || !h.Accepts(rest) {
+		return ErrUnknownRecipient
+	}
+	return h.Deliver(ctx, rest, msg)
+}
@@ -0,0 +1,250 @@
+package inbound
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/charset"
+)
+
+// maxPartDepth bounds how deeply multipart messages may nest.
+const maxPartDepth = 10
+
+// Message is a parsed inbound email.
+type Message struct {
+	// From is the sender's address from the From header, and FromName the
+	// display name given with it, if any
+	From     string
+	FromName string
+	Subject  string
+	// Text is the plain text body, or text extracted from the HTML body when
+	// there is no plain text one
+	Text        string
+	Attachments []Attachment
+	// Automatic is set for mail that declares it wasn't written by a
+	// person: auto-replies, bounces and bulk or list mail, per the
+	// Auto-Submitted (RFC 3834) and Precedence headers
+	Automatic bool
+	// AuthResults are the values of the Authentication-Results headers,
+	// topmost first; see SenderVerified
+	AuthResults []string
+}
+
+// Attachment is a file attached to an inbound email.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+var wordDecoder = &mime.WordDecoder{CharsetReader: charset.NewReaderLabel}
+
+// Parse parses a raw RFC 5322 message.
+func Parse(r io.Reader) (*Message, error) {
+	m, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading message: %w", err)
+	}
+
+	from, err := (&mail.AddressParser{WordDecoder: wordDecoder}).ParseList(m.Header.Get("From"))
+	if err != nil || len(from) == 0 {
+		return nil, errors.New("message has no valid From address")
+	}
+	subject, err := wordDecoder.DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		subject = m.Header.Get("Subject")
+	}
+
+	msg := &Message{
+		From:      from[0].Address,
+		FromName:  strings.TrimSpace(from[0].Name),
+		Subject:   strings.TrimSpace(subject),
+		Automatic: isAutomatic(m.Header),
+
+		AuthResults: m.Header["Authentication-Results"],
+	}
+	var htmlBody string
+	if err := msg.walk(textproto.MIMEHeader(m.Header), m.Body, &htmlBody, 0); err != nil {
+		return nil, err
+	}
+	if msg.Text == "" && htmlBody != "" {
+		msg.Text = htmlToText(htmlBody)
+	}
+	return msg, nil
+}
+
+// isAutomatic reports whether a message's headers mark it as automatically
+// generated.
+func isAutomatic(h mail.Header) bool {
+	// Auto-Submitted may carry parameters, e.g. "auto-replied; owner=..."
+	if v, _, _ := strings.Cut(h.Get("Auto-Submitted"), ";"); v != "" && !strings.EqualFold(strings.TrimSpace(v), "no") {
+		return true
+	}
+	switch strings.ToLower(strings.TrimSpace(h.Get("Precedence"))) {
+	case "bulk", "junk", "list", "auto_reply":
+		return true
+	}
+	return false
+}
+
+// walk collects the text bodies and attachments of a message part.
+func (msg *Message) walk(header textproto.MIMEHeader, body io.Reader, htmlBody *string, depth int) error {
+	if depth > maxPartDepth {
+		return errors.New("message parts are nested too deeply")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+	body = decodeTransfer(header.Get("Content-Transfer-Encoding"), body)
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("reading message part: %w", err)
+			}
+			err = msg.walk(part.Header, part, htmlBody, depth+1)
+			part.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if decoded, err := wordDecoder.DecodeHeader(filename); err == nil {
+		filename = decoded
+	}
+
+	isText := mediaType == "text/plain" || mediaType == "text/html"
+	if disposition == "attachment" || filename != "" || !isText {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return fmt.Errorf("reading attachment: %w", err)
+		}
+		if filename == "" {
+			filename = "attachment"
+		}
+		msg.Attachments = append(msg.Attachments, Attachment{Filename: filename, ContentType: mediaType, Data: data})
+		return nil
+	}
+
+	text, err := readText(body, params["charset"])
+	if err != nil {
+		return err
+	}
+	// The first body of each type wins, so alternative versions and later
+	// inline parts don't override the main one
+	if mediaType == "text/plain" && msg.Text == "" {
+		msg.Text = text
+	} else if mediaType == "text/html" && *htmlBody == "" {
+		*htmlBody = text
+	}
+	return nil
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, r)
+	}
+	return r
+}
+
+// readText reads a text body, converting it to UTF-8 from its charset.
+func readText(r io.Reader, cs string) (string, error) {
+	if cs != "" && !strings.EqualFold(cs, "utf-8") && !strings.EqualFold(cs, "us-ascii") {
+		if cr, err := charset.NewReaderLabel(cs, r); err == nil {
+			r = cr
+		}
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("reading message body: %w", err)
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !utf8.ValidString(text) {
+		text = strings.ToValidUTF8(text, "�")
+	}
+	return text, nil
+}
+
+// htmlToText extracts the text of an HTML body, leaving out quoted replies
+// in blockquotes.
+func htmlToText(s string) string {
+	doc, err := html.Parse(strings.NewReader(s))
+	if err != nil {
+		return ""
+	}
+	var b bytes.Buffer
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			words := strings.Fields(n.Data)
+			if len(words) == 0 {
+				return
+			}
+			if b.Len() > 0 && n.Data[0] <= ' ' {
+				b.WriteByte(' ')
+			}
+			b.WriteString(strings.Join(words, " "))
+			if n.Data[len(n.Data)-1] <= ' ' {
+				b.WriteByte(' ')
+			}
+			return
+		}
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "script", "style", "head", "title", "blockquote":
+				return
+			case "br":
+				b.WriteByte('\n')
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			visit(c)
+		}
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "p", "div", "li", "tr", "h1", "h2", "h3", "h4", "h5", "h6", "pre", "table":
+				b.WriteByte('\n')
+			}
+		}
+	}
+	visit(doc)
+
+	// Nested block elements leave runs of empty lines; keep one at most
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" && len(lines) > 0 && lines[len(lines)-1] == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
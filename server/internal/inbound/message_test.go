@@ -0,0 +1,111 @@
+package inbound
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse_Multipart(t *testing.T) {
+	raw := strings.Join([]string{
+		"From: Grace Hopper <Grace@example.com>",
+		"To: reply+abc@reply.example.com",
+		"Subject: =?UTF-8?Q?Re:_Caf=C3=A9?=",
+		"MIME-Version: 1.0",
+		`Content-Type: multipart/mixed; boundary="outer"`,
+		"",
+		"--outer",
+		`Content-Type: multipart/alternative; boundary="inner"`,
+		"",
+		"--inner",
+		"Content-Type: text/plain; charset=iso-8859-1",
+		"Content-Transfer-Encoding: quoted-printable",
+		"",
+		"Sounds good, see you at the caf=E9=",
+		" tomorrow.",
+		"--inner",
+		"Content-Type: text/html; charset=utf-8",
+		"",
+		"<p>Sounds good</p>",
+		"--inner--",
+		"--outer",
+		`Content-Type: text/csv; name="report.csv"`,
+		"Content-Disposition: attachment; filename=report.csv",
+		"Content-Transfer-Encoding: base64",
+		"",
+		"YSxiCjEsMgo=",
+		"--outer--",
+		"",
+	}, "\r\n")
+
+	msg, err := Parse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if msg.From != "Grace@example.com" {
+		t.Errorf("From = %q", msg.From)
+	}
+	if msg.FromName != "Grace Hopper" {
+		t.Errorf("FromName = %q", msg.FromName)
+	}
+	if msg.Subject != "Re: Café" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if msg.Text != "Sounds good, see you at the café tomorrow." {
+		t.Errorf("Text = %q", msg.Text)
+	}
+	if len(msg.Attachments) != 1 {
+		t.Fatalf("got %d attachments, want 1", len(msg.Attachments))
+	}
+	if a := msg.Attachments[0]; a.Filename != "report.csv" || a.ContentType != "text/csv" || string(a.Data) != "a,b\n1,2\n" {
+		t.Errorf("attachment = %q %q %q", a.Filename, a.ContentType, a.Data)
+	}
+}
+
+func TestParse_HTMLOnly(t *testing.T) {
+	raw := "From: grace@example.com\r\n" +
+		"Content-Type: text/html; charset=utf-8\r\n" +
+		"\r\n" +
+		"<html><head><style>p { color: red }</style></head><body>" +
+		"<div>Ship <b>it</b>!</div><div>Thanks,<br>Grace</div>" +
+		"<blockquote>On Monday you wrote: the old message</blockquote></body></html>\r\n"
+
+	msg, err := Parse(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if want := "Ship it!\nThanks,\nGrace"; msg.Text != want {
+		t.Errorf("Text = %q, want %q", msg.Text, want)
+	}
+}
+
+func TestParse_NoFrom(t *testing.T) {
+	if _, err := Parse(strings.NewReader("Subject: hi\r\n\r\nbody\r\n")); err == nil {
+		t.Fatal("expected an error for a message without From")
+	}
+}
+
+func TestParse_Automatic(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{"Auto-Submitted: no\r\n", false},
+		{"Auto-Submitted: auto-replied\r\n", true},
+		{"Auto-Submitted: auto-generated; owner=postmaster@example.com\r\n", true},
+		{"Precedence: bulk\r\n", true},
+		{"Precedence: List\r\n", true},
+		{"Precedence: auto_reply\r\n", true},
+		{"Precedence: first-class\r\n", false},
+	}
+	for _, tt := range tests {
+		raw := "From: grace@example.com\r\n" + tt.header + "\r\nI'm out of office\r\n"
+		msg, err := Parse(strings.NewReader(raw))
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		if msg.Automatic != tt.want {
+			t.Errorf("%q: Automatic = %v, want %v", tt.header, msg.Automatic, tt.want)
+		}
+	}
+}
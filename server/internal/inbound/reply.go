@@ -0,0 +1,47 @@
+package inbound
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// "On Tue, 10 Mar 2026 at 14:20, Grace <grace@example.com> wrote:", or
+	// "Am 10.03.2026 um 14:20 schrieb Grace <grace@example.com>:", which some
+	// clients wrap over two lines
+	replyHeaderStart = regexp.MustCompile(`(?i)^(on|am|le|el|il|op)\s.+`)
+	replyHeaderEnd   = regexp.MustCompile(`(?i)(wrote|schrieb|a écrit|escribió|ha scritto|schreef)(\s.*)?:\s*$`)
+	// Outlook's "-----Original Message-----" and the line of underscores
+	// before its quoted headers
+	originalMessage = regexp.MustCompile(`(?i)^-{2,}\s*original message\s*-{2,}$|^_{10,}$`)
+	// "Sent from my iPhone" and similar mobile signatures
+	mobileSignature = regexp.MustCompile(`(?i)^sent from my\s`)
+	quotedHeader    = regexp.MustCompile(`(?i)^(sent|date|to|subject):\s`)
+)
+
+// StripReply returns the new text of an email reply, without the quoted
+// message it replies to and the sender's signature.
+func StripReply(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var kept []string
+scan:
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case line == "-- " || trimmed == "--":
+			break scan
+		case originalMessage.MatchString(trimmed), mobileSignature.MatchString(trimmed):
+			break scan
+		case replyHeaderStart.MatchString(trimmed) &&
+			(replyHeaderEnd.MatchString(trimmed) || i+1 < len(lines) && replyHeaderEnd.MatchString(strings.TrimSpace(lines[i+1]))):
+			break scan
+		case strings.HasPrefix(trimmed, "From:") && i+1 < len(lines) && quotedHeader.MatchString(strings.TrimSpace(lines[i+1])):
+			break scan
+		case strings.HasPrefix(trimmed, ">"):
+			continue
+		}
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
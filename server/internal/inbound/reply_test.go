@@ -0,0 +1,62 @@
+package inbound
+
+import "testing"
+
+func TestStripReply(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "gmail",
+			in:   "Sounds good!\r\n\r\nOn Tue, Mar 10, 2026 at 2:20 PM Enzyme <reply+abc@example.com> wrote:\r\n> hey, look at this\r\n",
+			want: "Sounds good!",
+		},
+		{
+			name: "wrapped attribution",
+			in:   "Sounds good!\n\nOn Tue, Mar 10, 2026 at 2:20 PM Enzyme <reply+abc@example.com>\nwrote:\n\n> hey\n",
+			want: "Sounds good!",
+		},
+		{
+			name: "outlook",
+			in:   "Will do.\n\n-----Original Message-----\nFrom: Enzyme\nSent: Tuesday\n",
+			want: "Will do.",
+		},
+		{
+			name: "outlook headers",
+			in:   "Will do.\n\n________________________________\nFrom: Enzyme <noreply@example.com>\nSent: Tuesday\n",
+			want: "Will do.",
+		},
+		{
+			name: "signature",
+			in:   "Deploying now.\n\nThanks\n-- \nGrace Hopper\nRear Admiral\n",
+			want: "Deploying now.\n\nThanks",
+		},
+		{
+			name: "mobile signature",
+			in:   "On it\n\nSent from my iPhone\n",
+			want: "On it",
+		},
+		{
+			name: "inline quotes",
+			in:   "> can you check?\nyes\n> and the logs?\nthose too\n",
+			want: "yes\nthose too",
+		},
+		{
+			name: "german",
+			in:   "Passt.\n\nAm 10.03.2026 um 14:20 schrieb Enzyme <reply+abc@example.com>:\n> hey\n",
+			want: "Passt.",
+		},
+		{
+			name: "only quote",
+			in:   "> hey\n",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		if got := StripReply(tt.in); got != tt.want {
+			t.Errorf("%s: StripReply() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
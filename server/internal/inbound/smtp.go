@@ -0,0 +1,514 @@
+package inbound
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxLineLength is the longest command line accepted; RFC 5321 allows
+	// 512 for commands and 1000 for text lines
+	maxLineLength = 1000
+	maxRecipients = 100
+	// commandTimeout and dataTimeout are the minimums RFC 5321 recommends
+	commandTimeout = 5 * time.Minute
+	dataTimeout    = 10 * time.Minute
+	deliverTimeout = time.Minute
+	// tlsTimeout bounds the STARTTLS handshake, and busyTimeout writing the
+	// reply to a connection turned away for being over a limit
+	tlsTimeout  = 30 * time.Second
+	busyTimeout = 5 * time.Second
+
+	// DefaultMaxConnections and DefaultMaxConnectionsPerIP are the connection
+	// limits of a new Server
+	DefaultMaxConnections      = 100
+	DefaultMaxConnectionsPerIP = 10
+)
+
+// ErrServerClosed is returned by Server.Serve after Shutdown.
+var ErrServerClosed = errors.New("inbound: server closed")
+
+var errLineTooLong = errors.New("line too long")
+
+// Server is a minimal SMTP server that receives mail for a Handler. It only
+// accepts mail for recipients the handler accepts, so it doesn't relay.
+// With a TLS config it offers STARTTLS (RFC 3207). Connections over the
+// total or per-IP limit are turned away with a temporary error, so senders
+// retry later.
+type Server struct {
+	addr          string
+	hostname      string
+	maxSize       int64
+	handler       Handler
+	tlsConfig     *tls.Config
+	maxConns      int
+	maxConnsPerIP int
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	perIP    map[string]int
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// NewServer creates an SMTP server listening on addr. hostname is the name
+// it greets clients with, and maxSize the largest message it accepts, in
+// bytes.
+func NewServer(addr, hostname string, maxSize int64, h Handler) *Server {
+	return &Server{
+		addr:     addr,
+		hostname: hostname,
+		maxSize:  maxSize,
+		handler:  h,
+		conns:    make(map[net.Conn]struct{}),
+		perIP:    make(map[string]int),
+
+		maxConns:      DefaultMaxConnections,
+		maxConnsPerIP: DefaultMaxConnectionsPerIP,
+	}
+}
+
+// SetTLSConfig enables STARTTLS with the given config. It must be called
+// before Serve.
+func (s *Server) SetTLSConfig(cfg *tls.Config) {
+	s.tlsConfig = cfg
+}
+
+// SetConnectionLimits sets how many connections may be open at once, in
+// total and from a single IP address. It must be called before Serve.
+func (s *Server) SetConnectionLimits(total, perIP int) {
+	s.maxConns = total
+	s.maxConnsPerIP = perIP
+}
+
+// Addr returns the address the server listens on.
+func (s *Server) Addr() string {
+	return s.addr
+}
+
+// ListenAndServe listens on the server's address and serves connections
+// until Shutdown is called.
+func (s *Server) ListenAndServe() error {
+	l, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// Serve accepts connections on l until Shutdown is called.
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		_ = l.Close()
+		return ErrServerClosed
+	}
+	s.listener = l
+	s.mu.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Timeout() {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+
+		ip := remoteIP(conn)
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return ErrServerClosed
+		}
+		var busy string
+		switch {
+		case len(s.conns) >= s.maxConns:
+			busy = "4.3.2 Too many connections, try again later"
+		case s.perIP[ip] >= s.maxConnsPerIP:
+			busy = "4.7.0 Too many connections from your address, try again later"
+		}
+		s.wg.Add(1)
+		if busy != "" {
+			s.mu.Unlock()
+			go func() {
+				defer s.wg.Done()
+				_ = conn.SetWriteDeadline(time.Now().Add(busyTimeout))
+				_, _ = fmt.Fprintf(conn, "421 %s\r\n", busy)
+				_ = conn.Close()
+			}()
+			continue
+		}
+		s.conns[conn] = struct{}{}
+		s.perIP[ip]++
+		s.mu.Unlock()
+
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				if s.perIP[ip]--; s.perIP[ip] <= 0 {
+					delete(s.perIP, ip)
+				}
+				s.mu.Unlock()
+				_ = conn.Close()
+			}()
+			s.serveConn(conn)
+		}()
+	}
+}
+
+func remoteIP(conn net.Conn) string {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
+
+// Shutdown stops accepting connections and waits for open sessions to end,
+// closing them when ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	if s.listener != nil {
+		_ = s.listener.Close()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		for conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mu.Unlock()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// session is the state of one SMTP connection.
+type session struct {
+	s      *Server
+	conn   net.Conn
+	r      *bufio.Reader
+	w      *bufio.Writer
+	helo   bool
+	from   *string
+	rcpts  []string
+	remote string
+	tls    bool
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	sess := &session{
+		s:      s,
+		conn:   conn,
+		r:      bufio.NewReader(conn),
+		w:      bufio.NewWriter(conn),
+		remote: conn.RemoteAddr().String(),
+	}
+	sess.reply(220, s.hostname+" ESMTP Enzyme")
+
+	for {
+		_ = sess.conn.SetReadDeadline(time.Now().Add(commandTimeout))
+		line, err := sess.readLine()
+		if errors.Is(err, errLineTooLong) {
+			sess.reply(500, "5.5.2 Line too long")
+			continue
+		}
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		if !sess.handle(strings.ToUpper(verb), strings.TrimSpace(arg)) {
+			return
+		}
+	}
+}
+
+// handle runs one command, returning false when the session should end.
+func (sess *session) handle(verb, arg string) bool {
+	switch verb {
+	case "EHLO":
+		if arg == "" {
+			sess.reply(501, "5.5.4 Syntax: EHLO hostname")
+			return true
+		}
+		sess.helo = true
+		sess.reset()
+		extensions := []string{sess.s.hostname, "8BITMIME", "SIZE " + strconv.FormatInt(sess.s.maxSize, 10)}
+		if sess.s.tlsConfig != nil && !sess.tls {
+			extensions = append(extensions, "STARTTLS")
+		}
+		sess.reply(250, extensions...)
+	case "HELO":
+		if arg == "" {
+			sess.reply(501, "5.5.4 Syntax: HELO hostname")
+			return true
+		}
+		sess.helo = true
+		sess.reset()
+		sess.reply(250, sess.s.hostname)
+	case "STARTTLS":
+		return sess.startTLS(arg)
+	case "MAIL":
+		sess.mail(arg)
+	case "RCPT":
+		sess.rcpt(arg)
+	case "DATA":
+		return sess.data()
+	case "RSET":
+		sess.reset()
+		sess.reply(250, "2.0.0 OK")
+	case "NOOP":
+		sess.reply(250, "2.0.0 OK")
+	case "VRFY":
+		sess.reply(252, "2.5.0 Cannot verify users")
+	case "QUIT":
+		sess.reply(221, "2.0.0 Bye")
+		return false
+	default:
+		sess.reply(502, "5.5.2 Command not implemented")
+	}
+	return true
+}
+
+// startTLS upgrades the connection, returning false if the session should
+// end. As RFC 3207 requires, the client starts over with EHLO afterwards.
+func (sess *session) startTLS(arg string) bool {
+	switch {
+	case sess.s.tlsConfig == nil:
+		sess.reply(502, "5.5.1 TLS not available")
+		return true
+	case sess.tls:
+		sess.reply(503, "5.5.1 TLS already active")
+		return true
+	case arg != "":
+		sess.reply(501, "5.5.4 Syntax: STARTTLS")
+		return true
+	case sess.r.Buffered() > 0:
+		// Commands pipelined after STARTTLS would be read as if they had
+		// come over TLS
+		sess.reply(501, "5.5.4 No commands may follow STARTTLS")
+		return false
+	}
+	sess.reply(220, "2.0.0 Ready to start TLS")
+
+	tlsConn := tls.Server(sess.conn, sess.s.tlsConfig)
+	_ = tlsConn.SetDeadline(time.Now().Add(tlsTimeout))
+	if err := tlsConn.Handshake(); err != nil {
+		slog.Debug("inbound TLS handshake failed", "component", "inbound", "remote", sess.remote, "error", err)
+		return false
+	}
+	_ = tlsConn.SetDeadline(time.Time{})
+
+	sess.conn = tlsConn
+	sess.r = bufio.NewReader(tlsConn)
+	sess.w = bufio.NewWriter(tlsConn)
+	sess.tls = true
+	sess.helo = false
+	sess.reset()
+	return true
+}
+
+func (sess *session) reset() {
+	sess.from = nil
+	sess.rcpts = nil
+}
+
+func (sess *session) mail(arg string) {
+	if !sess.helo {
+		sess.reply(503, "5.5.1 Send EHLO first")
+		return
+	}
+	if sess.from != nil {
+		sess.reply(503, "5.5.1 Sender already given")
+		return
+	}
+	path, params, ok := parsePath(arg, "FROM:")
+	if !ok {
+		sess.reply(501, "5.5.4 Syntax: MAIL FROM:<address>")
+		return
+	}
+	for _, p := range params {
+		if k, v, _ := strings.Cut(p, "="); strings.EqualFold(k, "SIZE") {
+			if size, err := strconv.ParseInt(v, 10, 64); err == nil && size > sess.s.maxSize {
+				sess.reply(552, "5.3.4 Message too big")
+				return
+			}
+		}
+	}
+	sess.from = &path
+	sess.reply(250, "2.1.0 OK")
+}
+
+func (sess *session) rcpt(arg string) {
+	if sess.from == nil {
+		sess.reply(503, "5.5.1 Send MAIL first")
+		return
+	}
+	path, _, ok := parsePath(arg, "TO:")
+	if !ok || path == "" {
+		sess.reply(501, "5.5.4 Syntax: RCPT TO:<address>")
+		return
+	}
+	if len(sess.rcpts) >= maxRecipients {
+		sess.reply(452, "4.5.3 Too many recipients")
+		return
+	}
+	if _, err := mail.ParseAddress(path); err != nil || !sess.s.handler.Accepts(path) {
+		sess.reply(550, "5.1.1 No such recipient")
+		return
+	}
+	sess.rcpts = append(sess.rcpts, path)
+	sess.reply(250, "2.1.5 OK")
+}
+
+func (sess *session) data() bool {
+	if len(sess.rcpts) == 0 {
+		sess.reply(503, "5.5.1 Send RCPT first")
+		return true
+	}
+	sess.reply(354, "End data with <CR><LF>.<CR><LF>")
+	_ = sess.conn.SetReadDeadline(time.Now().Add(dataTimeout))
+
+	var buf bytes.Buffer
+	tooBig := false
+	lineStart := true
+	for {
+		line, err := sess.r.ReadSlice('\n')
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			return false
+		}
+		if lineStart {
+			if bytes.Equal(line, []byte(".\r\n")) || bytes.Equal(line, []byte(".\n")) {
+				break
+			}
+			// Undo dot-stuffing
+			if len(line) > 0 && line[0] == '.' {
+				line = line[1:]
+			}
+		}
+		// Lines longer than the read buffer arrive in pieces
+		lineStart = err == nil
+		if int64(buf.Len()+len(line)) > sess.s.maxSize {
+			tooBig = true
+			buf.Reset()
+		}
+		if !tooBig {
+			buf.Write(line)
+		}
+	}
+
+	rcpts := sess.rcpts
+	sess.reset()
+	if tooBig {
+		sess.reply(552, "5.3.4 Message too big")
+		return true
+	}
+
+	msg, err := Parse(&buf)
+	if err != nil {
+		sess.reply(550, "5.6.0 Malformed message: "+err.Error())
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), deliverTimeout)
+	defer cancel()
+	for _, rcpt := range rcpts {
+		if err := sess.s.handler.Deliver(ctx, rcpt, msg); err != nil {
+			var reject *RejectError
+			switch {
+			case errors.As(err, &reject):
+				sess.reply(550, "5.7.1 "+reject.Reason)
+			case errors.Is(err, ErrUnknownRecipient):
+				sess.reply(550, "5.1.1 No such recipient")
+			default:
+				slog.Error("failed to deliver inbound email", "component", "inbound", "remote", sess.remote, "error", err)
+				sess.reply(451, "4.3.0 Temporary failure, try again later")
+			}
+			return true
+		}
+	}
+	sess.reply(250, "2.0.0 OK")
+	return true
+}
+
+// readLine reads a CRLF (or LF) terminated command line.
+func (sess *session) readLine() (string, error) {
+	line, err := sess.r.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) || len(line) > maxLineLength {
+		// Discard the rest of the line
+		for errors.Is(err, bufio.ErrBufferFull) {
+			_, err = sess.r.ReadSlice('\n')
+		}
+		if err != nil {
+			return "", err
+		}
+		return "", errLineTooLong
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
+
+// reply writes a (possibly multiline) response.
+func (sess *session) reply(code int, lines ...string) {
+	for i, line := range lines {
+		sep := "-"
+		if i == len(lines)-1 {
+			sep = " "
+		}
+		fmt.Fprintf(sess.w, "%d%s%s\r\n", code, sep, line)
+	}
+	_ = sess.w.Flush()
+}
+
+// parsePath parses "FROM:<address> PARAMS" or "TO:<address> PARAMS".
+func parsePath(arg, prefix string) (string, []string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+	rest := strings.TrimSpace(arg[len(prefix):])
+	if !strings.HasPrefix(rest, "<") {
+		return "", nil, false
+	}
+	end := strings.IndexByte(rest, '>')
+	if end < 0 {
+		return "", nil, false
+	}
+	return rest[1:end], strings.Fields(rest[end+1:]), true
+}
@@ -0,0 +1,331 @@
+package inbound
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeHandler accepts mail for addresses starting with "ok" and records
+// delivered messages.
+type fakeHandler struct {
+	mu        sync.Mutex
+	delivered map[string]*Message
+	err       error
+}
+
+func (f *fakeHandler) Accepts(rcpt string) bool {
+	return strings.HasPrefix(rcpt, "ok")
+}
+
+func (f *fakeHandler) Deliver(ctx context.Context, rcpt string, msg *Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	if f.delivered == nil {
+		f.delivered = make(map[string]*Message)
+	}
+	f.delivered[rcpt] = msg
+	return nil
+}
+
+type smtpClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// cmd sends a line and returns the (last line of the) reply.
+func (c *smtpClient) cmd(line string) string {
+	c.t.Helper()
+	if _, err := c.conn.Write([]byte(line + "\r\n")); err != nil {
+		c.t.Fatalf("writing %q: %v", line, err)
+	}
+	return c.reply()
+}
+
+func (c *smtpClient) reply() string {
+	c.t.Helper()
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			c.t.Fatalf("reading reply: %v", err)
+		}
+		if len(line) < 4 || line[3] != '-' {
+			return strings.TrimRight(line, "\r\n")
+		}
+	}
+}
+
+func startSMTP(t *testing.T, h Handler) *smtpClient {
+	t.Helper()
+	return dialSMTP(t, startServer(t, h, nil))
+}
+
+// startServer starts a Server, letting configure set it up before it
+// serves, and returns its address.
+func startServer(t *testing.T, h Handler, configure func(*Server)) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := NewServer(l.Addr().String(), "reply.example.com", 1024, h)
+	if configure != nil {
+		configure(srv)
+	}
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	})
+	return l.Addr().String()
+}
+
+func dialSMTP(t *testing.T, addr string) *smtpClient {
+	t.Helper()
+	c := dialRaw(t, addr)
+	if got := c.reply(); !strings.HasPrefix(got, "220 reply.example.com") {
+		t.Fatalf("greeting = %q", got)
+	}
+	return c
+}
+
+func dialRaw(t *testing.T, addr string) *smtpClient {
+	t.Helper()
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+	return &smtpClient{t: t, conn: conn, r: bufio.NewReader(conn)}
+}
+
+func TestServer_Delivers(t *testing.T) {
+	h := &fakeHandler{}
+	c := startSMTP(t, h)
+
+	steps := []struct{ cmd, want string }{
+		{"MAIL FROM:<grace@example.com>", "503"},
+		{"EHLO client.example.com", "250 SIZE 1024"},
+		{"RCPT TO:<ok@reply.example.com>", "503"},
+		{"MAIL FROM:<grace@example.com> SIZE=2048", "552"},
+		{"MAIL FROM:<grace@example.com>", "250"},
+		{"RCPT TO:<unknown@reply.example.com>", "550"},
+		{"RCPT TO:<ok@reply.example.com>", "250"},
+		{"DATA", "354"},
+		{"From: grace@example.com\r\nSubject: hi\r\n\r\n..leading dot\r\nsecond line\r\n.", "250"},
+		{"QUIT", "221"},
+	}
+	for _, s := range steps {
+		if got := c.cmd(s.cmd); !strings.HasPrefix(got, s.want) {
+			t.Fatalf("%q: reply = %q, want %s", s.cmd, got, s.want)
+		}
+	}
+
+	msg := h.delivered["ok@reply.example.com"]
+	if msg == nil {
+		t.Fatal("message was not delivered")
+	}
+	if msg.From != "grace@example.com" || msg.Text != ".leading dot\nsecond line\n" {
+		t.Errorf("delivered From = %q, Text = %q", msg.From, msg.Text)
+	}
+}
+
+func TestServer_DeliveryErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{Reject("not allowed"), "550 5.7.1 not allowed"},
+		{context.DeadlineExceeded, "451"},
+	}
+	for _, tt := range tests {
+		c := startSMTP(t, &fakeHandler{err: tt.err})
+		for _, line := range []string{"HELO client", "MAIL FROM:<>", "RCPT TO:<ok@reply.example.com>", "DATA"} {
+			c.cmd(line)
+		}
+		if got := c.cmd("From: grace@example.com\r\n\r\nhi\r\n."); !strings.HasPrefix(got, tt.want) {
+			t.Errorf("%v: reply = %q, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestServer_MessageTooBig(t *testing.T) {
+	c := startSMTP(t, &fakeHandler{})
+	for _, line := range []string{"HELO client", "MAIL FROM:<>", "RCPT TO:<ok@reply.example.com>", "DATA"} {
+		c.cmd(line)
+	}
+	body := "From: grace@example.com\r\n\r\n" + strings.Repeat("x", 2000) + "\r\n."
+	if got := c.cmd(body); !strings.HasPrefix(got, "552") {
+		t.Errorf("reply = %q, want 552", got)
+	}
+	// The session is still usable
+	if got := c.cmd("NOOP"); !strings.HasPrefix(got, "250") {
+		t.Errorf("NOOP reply = %q", got)
+	}
+}
+
+func TestServer_ConnectionLimits(t *testing.T) {
+	tests := []struct {
+		name         string
+		total, perIP int
+		want         string
+	}{
+		{"total", 2, 10, "421 4.3.2"},
+		{"per IP", 10, 2, "421 4.7.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startServer(t, &fakeHandler{}, func(s *Server) {
+				s.SetConnectionLimits(tt.total, tt.perIP)
+			})
+			first := dialSMTP(t, addr)
+			dialSMTP(t, addr)
+
+			if got := dialRaw(t, addr).reply(); !strings.HasPrefix(got, tt.want) {
+				t.Fatalf("third connection greeting = %q, want %s", got, tt.want)
+			}
+
+			// Closing a connection frees its slot
+			first.cmd("QUIT")
+			deadline := time.Now().Add(2 * time.Second)
+			for {
+				got := dialRaw(t, addr).reply()
+				if strings.HasPrefix(got, "220") {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("greeting after a slot was freed = %q", got)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		})
+	}
+}
+
+func TestServer_StartTLS(t *testing.T) {
+	cert := selfSignedCert(t)
+	h := &fakeHandler{}
+	addr := startServer(t, h, func(s *Server) {
+		s.SetTLSConfig(&tls.Config{Certificates: []tls.Certificate{cert}})
+	})
+
+	c := dialSMTP(t, addr)
+	if got := c.ehlo(); !slices.Contains(got, "STARTTLS") {
+		t.Fatalf("EHLO extensions = %q, want STARTTLS", got)
+	}
+	if got := c.cmd("STARTTLS now"); !strings.HasPrefix(got, "501") {
+		t.Fatalf("STARTTLS with an argument: reply = %q", got)
+	}
+	if got := c.cmd("STARTTLS"); !strings.HasPrefix(got, "220") {
+		t.Fatalf("STARTTLS reply = %q", got)
+	}
+
+	tlsConn := tls.Client(c.conn, &tls.Config{InsecureSkipVerify: true})
+	if err := tlsConn.Handshake(); err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+	c.conn, c.r = tlsConn, bufio.NewReader(tlsConn)
+
+	// The session starts over and STARTTLS isn't offered again
+	if got := c.cmd("MAIL FROM:<grace@example.com>"); !strings.HasPrefix(got, "503") {
+		t.Fatalf("MAIL before EHLO after STARTTLS: reply = %q", got)
+	}
+	if got := c.ehlo(); slices.Contains(got, "STARTTLS") {
+		t.Fatalf("EHLO extensions over TLS = %q", got)
+	}
+	if got := c.cmd("STARTTLS"); !strings.HasPrefix(got, "503") {
+		t.Fatalf("second STARTTLS: reply = %q", got)
+	}
+	for _, line := range []string{"MAIL FROM:<grace@example.com>", "RCPT TO:<ok@reply.example.com>", "DATA"} {
+		c.cmd(line)
+	}
+	if got := c.cmd("From: grace@example.com\r\n\r\nhi\r\n."); !strings.HasPrefix(got, "250") {
+		t.Fatalf("DATA over TLS: reply = %q", got)
+	}
+	if h.delivered["ok@reply.example.com"] == nil {
+		t.Fatal("message was not delivered")
+	}
+}
+
+func TestServer_StartTLSRejectsPipelinedCommands(t *testing.T) {
+	cert := selfSignedCert(t)
+	addr := startServer(t, &fakeHandler{}, func(s *Server) {
+		s.SetTLSConfig(&tls.Config{Certificates: []tls.Certificate{cert}})
+	})
+	c := dialSMTP(t, addr)
+	c.ehlo()
+	if got := c.cmd("STARTTLS\r\nMAIL FROM:<mallory@example.com>"); !strings.HasPrefix(got, "501") {
+		t.Fatalf("pipelined STARTTLS: reply = %q, want 501", got)
+	}
+}
+
+func TestServer_NoStartTLSWithoutConfig(t *testing.T) {
+	c := startSMTP(t, &fakeHandler{})
+	if got := c.ehlo(); slices.Contains(got, "STARTTLS") {
+		t.Fatalf("EHLO extensions = %q", got)
+	}
+	if got := c.cmd("STARTTLS"); !strings.HasPrefix(got, "502") {
+		t.Fatalf("STARTTLS reply = %q, want 502", got)
+	}
+}
+
+// ehlo sends EHLO and returns the extensions in the reply.
+func (c *smtpClient) ehlo() []string {
+	c.t.Helper()
+	if _, err := c.conn.Write([]byte("EHLO client.example.com\r\n")); err != nil {
+		c.t.Fatalf("writing EHLO: %v", err)
+	}
+	var extensions []string
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			c.t.Fatalf("reading EHLO reply: %v", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if !strings.HasPrefix(line, "250") {
+			c.t.Fatalf("EHLO reply = %q", line)
+		}
+		extensions = append(extensions, line[4:])
+		if line[3] != '-' {
+			return extensions[1:]
+		}
+	}
+}
+
+func selfSignedCert(t *testing.T) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "reply.example.com"},
+		DNSNames:     []string{"reply.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
@@ -3,23 +3,36 @@ package linkpreview
 import (
 	"context"
 	"fmt"
+	"image"
 	"io"
+	"log/slog"
+	"mime"
 	"net"
 	"net/http"
+	"net/url"
+	"path"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"golang.org/x/net/html"
+
+	// Decoders for reading the dimensions of direct image links.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
 )
 
 const (
-	maxBodySize    = 1 << 20 // 1 MB
-	fetchTimeout   = 5 * time.Second
-	maxRedirects   = 3
-	userAgentValue = "Enzymebot/1.0 (+https://github.com/nicholasgriffintn/enzyme)"
+	maxBodySize  = 1 << 20 // 1 MB
+	fetchTimeout = 5 * time.Second
+	maxRedirects = 3
 )
 
+// UserAgent identifies the server when it fetches external pages and images.
+const UserAgent = "Enzymebot/1.0 (+https://github.com/nicholasgriffintn/enzyme)"
+
 // bracketLinkPattern matches <url|label> markup from the rich text editor.
 var bracketLinkPattern = regexp.MustCompile(`<(https?://[^|>]+)\|[^>]*>`)
 
@@ -50,18 +63,34 @@ func ExtractFirstURL(content string) string {
 	return ""
 }
 
-// ogData holds parsed Open Graph metadata.
+// ogData holds parsed page metadata from OpenGraph, Twitter card and
+// standard HTML tags, optionally enriched by oEmbed.
 type ogData struct {
 	Title       string
 	Description string
 	ImageURL    string
 	SiteName    string
+	MediaType   string
+	ImageWidth  int
+	ImageHeight int
+	VideoURL    string
+	VideoWidth  int
+	VideoHeight int
+	AuthorName  string
+	AuthorURL   string
+	PublishedAt string
+	FaviconURL  string
+
+	// oembedURL is the JSON oEmbed endpoint advertised by the page, if any.
+	oembedURL string
 }
 
 // Fetcher fetches and caches link previews.
 type Fetcher struct {
-	repo   *Repository
-	client *http.Client
+	repo      *Repository
+	client    *http.Client
+	providers *ProviderRegistry
+	discovery bool
 }
 
 // NewFetcher creates a Fetcher with an SSRF-safe HTTP client.
@@ -73,27 +102,37 @@ func NewFetcher(repo *Repository) *Fetcher {
 // If client is nil, a default SSRF-safe client is used.
 func NewFetcherWithClient(repo *Repository, client *http.Client) *Fetcher {
 	if client == nil {
-		transport := &http.Transport{
-			DialContext: safeDialContext,
-		}
+		client = NewSafeClient(fetchTimeout)
+	}
 
-		client = &http.Client{
-			Timeout:   fetchTimeout,
-			Transport: transport,
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				if len(via) >= maxRedirects {
-					return fmt.Errorf("too many redirects")
-				}
-				return nil
-			},
-		}
+	providers, _ := NewProviderRegistry(DefaultOEmbedProviders)
+	return &Fetcher{repo: repo, client: client, providers: providers, discovery: true}
+}
+
+// NewSafeClient returns an HTTP client that refuses to connect to private,
+// loopback and link-local addresses and follows at most a few redirects.
+func NewSafeClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: safeDialContext},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("too many redirects")
+			}
+			return nil
+		},
 	}
+}
 
-	return &Fetcher{repo: repo, client: client}
+// SetOEmbed replaces the oEmbed provider registry and toggles discovery of
+// endpoints advertised by pages via <link rel="alternate">.
+func (f *Fetcher) SetOEmbed(providers *ProviderRegistry, discovery bool) {
+	f.providers = providers
+	f.discovery = discovery
 }
 
 // FetchPreview returns a Preview for the URL, using the cache when possible.
-// Returns nil if the URL could not be fetched or has no useful OG data.
+// Returns nil if the URL could not be fetched or has no useful metadata.
 func (f *Fetcher) FetchPreview(ctx context.Context, url string) (*Preview, error) {
 	// Check cache.
 	cached, err := f.repo.GetCachedURL(ctx, url)
@@ -104,17 +143,11 @@ func (f *Fetcher) FetchPreview(ctx context.Context, url string) (*Preview, error
 		if cached.FetchError != "" {
 			return nil, nil // cached error — skip
 		}
-		return &Preview{
-			URL:         cached.URL,
-			Title:       cached.Title,
-			Description: cached.Description,
-			ImageURL:    cached.ImageURL,
-			SiteName:    cached.SiteName,
-		}, nil
+		return cached.Preview(), nil
 	}
 
-	// Fetch OG data.
-	og, fetchErr := f.fetchOG(ctx, url)
+	// Fetch page metadata.
+	og, fetchErr := f.fetchMetadata(ctx, url)
 
 	now := time.Now().UTC()
 	entry := &CacheEntry{
@@ -141,28 +174,74 @@ func (f *Fetcher) FetchPreview(ctx context.Context, url string) (*Preview, error
 	entry.Description = og.Description
 	entry.ImageURL = og.ImageURL
 	entry.SiteName = og.SiteName
+	entry.MediaType = og.MediaType
+	entry.ImageWidth = og.ImageWidth
+	entry.ImageHeight = og.ImageHeight
+	entry.VideoURL = og.VideoURL
+	entry.VideoWidth = og.VideoWidth
+	entry.VideoHeight = og.VideoHeight
+	entry.AuthorName = og.AuthorName
+	entry.AuthorURL = og.AuthorURL
+	entry.PublishedAt = og.PublishedAt
+	entry.FaviconURL = og.FaviconURL
 	entry.ExpiresAt = now.Add(CacheTTL)
 	if err := f.repo.SetCachedURL(ctx, entry); err != nil {
 		return nil, err
 	}
 
-	return &Preview{
-		URL:         url,
-		Title:       og.Title,
-		Description: og.Description,
-		ImageURL:    og.ImageURL,
-		SiteName:    og.SiteName,
-	}, nil
+	return entry.Preview(), nil
 }
 
-// fetchOG performs an HTTP GET and parses OG meta tags.
-func (f *Fetcher) fetchOG(ctx context.Context, url string) (*ogData, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// fetchMetadata scrapes the page and enriches it with oEmbed data from a
+// registered provider or, if enabled, an endpoint the page advertises.
+func (f *Fetcher) fetchMetadata(ctx context.Context, rawURL string) (*ogData, error) {
+	provider := f.providers.Match(rawURL)
+
+	og, err := f.fetchOG(ctx, rawURL)
+	if err != nil && provider == nil {
+		return nil, err
+	}
+
+	endpoint := ""
+	switch {
+	case provider != nil:
+		endpoint = provider.endpointURL(rawURL)
+	case og != nil && f.discovery:
+		endpoint = og.oembedURL
+	}
+	if endpoint == "" {
+		return og, nil
+	}
+
+	o, oembedErr := f.fetchOEmbed(ctx, endpoint)
+	if oembedErr != nil {
+		slog.Debug("oembed fetch failed", "url", rawURL, "error", oembedErr)
+		if og == nil {
+			return nil, err
+		}
+		return og, nil
+	}
+	if og == nil {
+		og = &ogData{FaviconURL: defaultFavicon(rawURL)}
+	}
+	applyOEmbed(og, o)
+	// The oEmbed URLs need the same checks as the page's, relative to the
+	// endpoint they came from
+	if base, err := url.Parse(endpoint); err == nil {
+		og.resolve(base)
+	}
+	return og, nil
+}
+
+// fetchOG performs an HTTP GET and parses page metadata. Direct image and
+// video URLs are recognized by content type.
+func (f *Fetcher) fetchOG(ctx context.Context, rawURL string) (*ogData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", userAgentValue)
-	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", "text/html, image/*, video/*;q=0.8")
 
 	resp, err := f.client.Do(req)
 	if err != nil {
@@ -174,32 +253,53 @@ func (f *Fetcher) fetchOG(ctx context.Context, url string) (*ogData, error) {
 		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
 	}
 
-	ct := resp.Header.Get("Content-Type")
-	if !strings.Contains(ct, "text/html") {
-		return nil, nil
-	}
-
+	// Resolve relative URLs against the final location after redirects.
+	base := resp.Request.URL
 	body := io.LimitReader(resp.Body, maxBodySize)
-	return parseOG(body)
+
+	ct, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch {
+	case ct == "text/html":
+		data, err := parseOG(body)
+		if err != nil {
+			return nil, err
+		}
+		data.resolve(base)
+		return data, nil
+	case strings.HasPrefix(ct, "image/"):
+		data := &ogData{
+			Title:      mediaTitle(base),
+			MediaType:  MediaTypeImage,
+			ImageURL:   rawURL,
+			FaviconURL: defaultFavicon(rawURL),
+		}
+		if cfg, _, err := image.DecodeConfig(body); err == nil {
+			data.ImageWidth, data.ImageHeight = cfg.Width, cfg.Height
+		}
+		return data, nil
+	case strings.HasPrefix(ct, "video/"):
+		return &ogData{
+			Title:      mediaTitle(base),
+			MediaType:  MediaTypeVideo,
+			VideoURL:   rawURL,
+			FaviconURL: defaultFavicon(rawURL),
+		}, nil
+	}
+	return nil, nil
 }
 
-// parseOG extracts og:* meta tags and falls back to <title> / <meta name="description">.
+// parseOG extracts og:*, twitter:* and article:* meta tags plus icon and
+// oEmbed <link> tags, falling back to <title> / <meta name="description">.
 func parseOG(r io.Reader) (*ogData, error) {
 	tokenizer := html.NewTokenizer(r)
 	data := &ogData{}
-	var fallbackTitle string
-	var fallbackDesc string
+	fb := fallbacks{}
 
 	for {
 		tt := tokenizer.Next()
 		switch tt {
 		case html.ErrorToken:
-			err := tokenizer.Err()
-			if err == io.EOF {
-				applyFallbacks(data, fallbackTitle, fallbackDesc)
-				return data, nil
-			}
-			applyFallbacks(data, fallbackTitle, fallbackDesc)
+			applyFallbacks(data, fb)
 			return data, nil
 
 		case html.StartTagToken, html.SelfClosingTagToken:
@@ -208,50 +308,213 @@ func parseOG(r io.Reader) (*ogData, error) {
 
 			if tag == "body" {
 				// Stop parsing at <body>.
-				applyFallbacks(data, fallbackTitle, fallbackDesc)
+				applyFallbacks(data, fb)
 				return data, nil
 			}
 
-			if tag == "title" && fallbackTitle == "" {
+			if tag == "title" && fb.title == "" {
 				// Read title text content.
 				if tokenizer.Next() == html.TextToken {
-					fallbackTitle = strings.TrimSpace(string(tokenizer.Text()))
+					fb.title = strings.TrimSpace(string(tokenizer.Text()))
+				}
+				continue
+			}
+
+			if tag == "link" && hasAttr {
+				attrs := readAttrs(tokenizer)
+				rel := strings.ToLower(attrs["rel"])
+				href := attrs["href"]
+				switch {
+				case rel == "alternate" && attrs["type"] == "application/json+oembed":
+					if data.oembedURL == "" {
+						data.oembedURL = href
+					}
+				case rel == "icon" || rel == "shortcut icon":
+					if data.FaviconURL == "" {
+						data.FaviconURL = href
+					}
+				case rel == "apple-touch-icon":
+					if fb.touchIcon == "" {
+						fb.touchIcon = href
+					}
 				}
 				continue
 			}
 
 			if tag == "meta" && hasAttr {
 				attrs := readAttrs(tokenizer)
-				prop := attrs["property"]
-				name := attrs["name"]
 				content := attrs["content"]
+				// Twitter tags are specified with name= but often published with property=.
+				key := attrs["property"]
+				if key == "" {
+					key = attrs["name"]
+				}
 
-				switch prop {
+				switch key {
 				case "og:title":
 					data.Title = content
 				case "og:description":
 					data.Description = content
-				case "og:image":
-					data.ImageURL = content
+				case "og:image", "og:image:url", "og:image:secure_url":
+					if data.ImageURL == "" || key == "og:image:secure_url" {
+						data.ImageURL = content
+					}
+				case "og:image:width":
+					data.ImageWidth = atoi(content)
+				case "og:image:height":
+					data.ImageHeight = atoi(content)
 				case "og:site_name":
 					data.SiteName = content
-				}
-
-				if name == "description" && fallbackDesc == "" {
-					fallbackDesc = content
+				case "og:type":
+					fb.ogType = content
+				case "og:video", "og:video:url", "og:video:secure_url":
+					if data.VideoURL == "" || key == "og:video:secure_url" {
+						data.VideoURL = content
+					}
+				case "og:video:width":
+					data.VideoWidth = atoi(content)
+				case "og:video:height":
+					data.VideoHeight = atoi(content)
+				case "article:published_time", "og:published_time":
+					data.PublishedAt = normalizeTime(content)
+				case "article:author":
+					if isHTTPURL(content) {
+						data.AuthorURL = content
+					} else if data.AuthorName == "" {
+						data.AuthorName = content
+					}
+				case "author":
+					if data.AuthorName == "" {
+						data.AuthorName = content
+					}
+				case "description":
+					if fb.description == "" {
+						fb.description = content
+					}
+				case "twitter:card":
+					fb.twitterCard = content
+				case "twitter:title":
+					fb.twitterTitle = content
+				case "twitter:description":
+					fb.twitterDescription = content
+				case "twitter:image", "twitter:image:src":
+					fb.twitterImage = content
+				case "twitter:player":
+					fb.twitterPlayer = content
+				case "twitter:player:width":
+					fb.twitterPlayerWidth = atoi(content)
+				case "twitter:player:height":
+					fb.twitterPlayerHeight = atoi(content)
 				}
 			}
 		}
 	}
 }
 
-func applyFallbacks(data *ogData, fallbackTitle, fallbackDesc string) {
+// fallbacks holds values used only when the corresponding OpenGraph tag is missing.
+type fallbacks struct {
+	title               string
+	description         string
+	touchIcon           string
+	ogType              string
+	twitterCard         string
+	twitterTitle        string
+	twitterDescription  string
+	twitterImage        string
+	twitterPlayer       string
+	twitterPlayerWidth  int
+	twitterPlayerHeight int
+}
+
+func applyFallbacks(data *ogData, fb fallbacks) {
+	if data.Title == "" {
+		data.Title = fb.twitterTitle
+	}
 	if data.Title == "" {
-		data.Title = fallbackTitle
+		data.Title = fb.title
+	}
+	if data.Description == "" {
+		data.Description = fb.twitterDescription
 	}
 	if data.Description == "" {
-		data.Description = fallbackDesc
+		data.Description = fb.description
 	}
+	if data.ImageURL == "" {
+		data.ImageURL = fb.twitterImage
+	}
+	if data.VideoURL == "" && fb.twitterPlayer != "" {
+		data.VideoURL = fb.twitterPlayer
+		data.VideoWidth, data.VideoHeight = fb.twitterPlayerWidth, fb.twitterPlayerHeight
+	}
+	if data.FaviconURL == "" {
+		data.FaviconURL = fb.touchIcon
+	}
+
+	switch {
+	case strings.HasPrefix(fb.ogType, "video"), fb.twitterCard == "player", data.VideoURL != "":
+		data.MediaType = MediaTypeVideo
+	default:
+		data.MediaType = MediaTypeArticle
+	}
+}
+
+// resolve makes relative URLs absolute against base and drops anything that
+// isn't http(s).
+func (d *ogData) resolve(base *url.URL) {
+	for _, p := range []*string{&d.ImageURL, &d.VideoURL, &d.FaviconURL, &d.AuthorURL, &d.oembedURL} {
+		if *p == "" {
+			continue
+		}
+		u, err := base.Parse(strings.TrimSpace(*p))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			*p = ""
+			continue
+		}
+		*p = u.String()
+	}
+	if d.FaviconURL == "" {
+		d.FaviconURL = defaultFavicon(base.String())
+	}
+}
+
+// defaultFavicon returns the conventional /favicon.ico location for rawURL's host.
+func defaultFavicon(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return u.Scheme + "://" + u.Host + "/favicon.ico"
+}
+
+// mediaTitle derives a title for direct media links from the file name.
+func mediaTitle(u *url.URL) string {
+	if name := path.Base(u.Path); name != "/" && name != "." {
+		return name
+	}
+	return u.Host
+}
+
+// normalizeTime parses common published-time formats and returns RFC3339 in
+// UTC, or "" if the value can't be parsed.
+func normalizeTime(s string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z0700", "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+func atoi(s string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+func isHTTPURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
 }
 
 // readAttrs collects all attributes from the current tag token.
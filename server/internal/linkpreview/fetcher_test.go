@@ -1,8 +1,11 @@
 package linkpreview
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"image"
+	"image/png"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -254,3 +257,218 @@ func TestParseOG_StopsAtBody(t *testing.T) {
 		t.Errorf("title = %q, want %q", data.Title, "Head Title")
 	}
 }
+
+func TestFetchOG_TwitterCardAndLinks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<html><head>
+			<meta name="twitter:card" content="summary_large_image">
+			<meta name="twitter:title" content="Tweet Title">
+			<meta name="twitter:description" content="Tweet Description">
+			<meta name="twitter:image" content="/img/card.png">
+			<meta property="og:image:width" content="1200">
+			<meta property="og:image:height" content="630">
+			<meta property="article:published_time" content="2024-03-05T10:00:00+02:00">
+			<meta name="author" content="Jane Doe">
+			<link rel="icon" href="/static/icon.png">
+		</head><body></body></html>`)
+	}))
+	defer srv.Close()
+
+	f := NewFetcherWithClient(NewRepository(testutil.TestDB(t)), &http.Client{Timeout: fetchTimeout})
+	p, err := f.FetchPreview(context.Background(), srv.URL+"/post")
+	if err != nil || p == nil {
+		t.Fatalf("FetchPreview = %v, %v", p, err)
+	}
+
+	if p.Title != "Tweet Title" || p.Description != "Tweet Description" {
+		t.Errorf("title/description = %q / %q", p.Title, p.Description)
+	}
+	if p.ImageURL != srv.URL+"/img/card.png" {
+		t.Errorf("image_url = %q, want resolved twitter:image", p.ImageURL)
+	}
+	if p.ImageWidth != 1200 || p.ImageHeight != 630 {
+		t.Errorf("image size = %dx%d, want 1200x630", p.ImageWidth, p.ImageHeight)
+	}
+	if p.MediaType != MediaTypeArticle {
+		t.Errorf("media_type = %q, want article", p.MediaType)
+	}
+	if p.PublishedAt != "2024-03-05T08:00:00Z" {
+		t.Errorf("published_at = %q, want 2024-03-05T08:00:00Z", p.PublishedAt)
+	}
+	if p.AuthorName != "Jane Doe" {
+		t.Errorf("author_name = %q, want Jane Doe", p.AuthorName)
+	}
+	if p.FaviconURL != srv.URL+"/static/icon.png" {
+		t.Errorf("favicon_url = %q", p.FaviconURL)
+	}
+}
+
+func TestFetchOG_DirectImage(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	f := NewFetcherWithClient(NewRepository(testutil.TestDB(t)), &http.Client{Timeout: fetchTimeout})
+	url := srv.URL + "/photos/cat.png"
+	p, err := f.FetchPreview(context.Background(), url)
+	if err != nil || p == nil {
+		t.Fatalf("FetchPreview = %v, %v", p, err)
+	}
+	if p.MediaType != MediaTypeImage || p.ImageURL != url || p.Title != "cat.png" {
+		t.Errorf("unexpected preview: %+v", p)
+	}
+	if p.ImageWidth != 40 || p.ImageHeight != 30 {
+		t.Errorf("image size = %dx%d, want 40x30", p.ImageWidth, p.ImageHeight)
+	}
+}
+
+func TestFetchOG_DirectVideo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Write([]byte("not really a video"))
+	}))
+	defer srv.Close()
+
+	f := NewFetcherWithClient(NewRepository(testutil.TestDB(t)), &http.Client{Timeout: fetchTimeout})
+	p, err := f.FetchPreview(context.Background(), srv.URL+"/clip.mp4")
+	if err != nil || p == nil {
+		t.Fatalf("FetchPreview = %v, %v", p, err)
+	}
+	if p.MediaType != MediaTypeVideo || p.VideoURL != srv.URL+"/clip.mp4" {
+		t.Errorf("unexpected preview: %+v", p)
+	}
+}
+
+func TestFetchPreview_OEmbedDiscovery(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/watch", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head>
+			<meta property="og:description" content="Page description">
+			<link rel="alternate" type="application/json+oembed" href="/oembed?url=watch">
+		</head><body></body></html>`)
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"type":"video","version":"1.0","title":"Discovered","author_name":"Carol",
+			"width":320,"height":180,"html":"<iframe src=\"https://player.example/1\"></iframe>"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	f := NewFetcherWithClient(NewRepository(testutil.TestDB(t)), &http.Client{Timeout: fetchTimeout})
+	p, err := f.FetchPreview(context.Background(), srv.URL+"/watch")
+	if err != nil || p == nil {
+		t.Fatalf("FetchPreview = %v, %v", p, err)
+	}
+	if p.Title != "Discovered" || p.Description != "Page description" {
+		t.Errorf("title/description = %q / %q", p.Title, p.Description)
+	}
+	if p.MediaType != MediaTypeVideo || p.VideoURL != "https://player.example/1" || p.VideoWidth != 320 {
+		t.Errorf("unexpected video fields: %+v", p)
+	}
+	if p.AuthorName != "Carol" {
+		t.Errorf("author_name = %q, want Carol", p.AuthorName)
+	}
+
+	// With discovery disabled the oEmbed link is ignored
+	f = NewFetcherWithClient(NewRepository(testutil.TestDB(t)), &http.Client{Timeout: fetchTimeout})
+	f.SetOEmbed(nil, false)
+	p, _ = f.FetchPreview(context.Background(), srv.URL+"/watch")
+	if p == nil || p.Title != "" || p.MediaType != MediaTypeArticle {
+		t.Errorf("expected OpenGraph-only preview, got %+v", p)
+	}
+}
+
+func TestFetchPreview_OEmbedURLsAreChecked(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/post", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head>
+			<meta property="og:title" content="Post">
+			<link rel="alternate" type="application/json+oembed" href="/api/oembed?url=post">
+		</head><body></body></html>`)
+	})
+	mux.HandleFunc("/api/oembed", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"type":"photo","version":"1.0","author_name":"Mallory","author_url":"javascript:alert(1)",
+			"url":"data:image/png;base64,AAAA","thumbnail_url":"thumbs/1.jpg"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	f := NewFetcherWithClient(NewRepository(testutil.TestDB(t)), &http.Client{Timeout: fetchTimeout})
+	p, err := f.FetchPreview(context.Background(), srv.URL+"/post")
+	if err != nil || p == nil {
+		t.Fatalf("FetchPreview = %v, %v", p, err)
+	}
+	if p.AuthorName != "Mallory" || p.AuthorURL != "" {
+		t.Errorf("author = %q <%s>, want the javascript: URL dropped", p.AuthorName, p.AuthorURL)
+	}
+	if p.ImageURL != "" {
+		t.Errorf("image_url = %q, want the data: URL dropped", p.ImageURL)
+	}
+
+	// A relative URL resolves against the oEmbed endpoint
+	mux.HandleFunc("/api/oembed2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"type":"link","version":"1.0","thumbnail_url":"thumbs/1.jpg"}`)
+	})
+	mux.HandleFunc("/link", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head><meta property="og:title" content="Link">
+			<link rel="alternate" type="application/json+oembed" href="/api/oembed2"></head></html>`)
+	})
+	p, err = f.FetchPreview(context.Background(), srv.URL+"/link")
+	if err != nil || p == nil {
+		t.Fatalf("FetchPreview = %v, %v", p, err)
+	}
+	if p.ImageURL != srv.URL+"/api/thumbs/1.jpg" {
+		t.Errorf("image_url = %q, want it resolved against the endpoint", p.ImageURL)
+	}
+}
+
+func TestFetchPreview_RegisteredProvider(t *testing.T) {
+	var oembedQuery string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/photo/1", func(w http.ResponseWriter, r *http.Request) {
+		// Page has no metadata at all
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><head></head><body></body></html>`)
+	})
+	mux.HandleFunc("/api/oembed", func(w http.ResponseWriter, r *http.Request) {
+		oembedQuery = r.URL.RawQuery
+		fmt.Fprint(w, `{"type":"photo","version":"1.0","title":"Sunset","url":"https://cdn.example/sunset.jpg","width":800,"height":600}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	reg, err := NewProviderRegistry([]OEmbedProvider{
+		{Name: "Photos", Endpoint: srv.URL + "/api/oembed", Schemes: []string{srv.URL + "/photo/*"}},
+	})
+	if err != nil {
+		t.Fatalf("NewProviderRegistry: %v", err)
+	}
+	f := NewFetcherWithClient(NewRepository(testutil.TestDB(t)), &http.Client{Timeout: fetchTimeout})
+	f.SetOEmbed(reg, false)
+
+	p, err := f.FetchPreview(context.Background(), srv.URL+"/photo/1")
+	if err != nil || p == nil {
+		t.Fatalf("FetchPreview = %v, %v", p, err)
+	}
+	if !strings.Contains(oembedQuery, "format=json") || !strings.Contains(oembedQuery, "url=") {
+		t.Errorf("oembed query = %q", oembedQuery)
+	}
+	if p.Title != "Sunset" || p.MediaType != MediaTypeImage || p.ImageURL != "https://cdn.example/sunset.jpg" {
+		t.Errorf("unexpected preview: %+v", p)
+	}
+	if p.ImageWidth != 800 || p.ImageHeight != 600 {
+		t.Errorf("image size = %dx%d, want 800x600", p.ImageWidth, p.ImageHeight)
+	}
+}
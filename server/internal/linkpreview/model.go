@@ -15,6 +15,14 @@ const (
 	PreviewTypeMessage  = "message"
 )
 
+// Media types describe what an external preview links to.
+const (
+	MediaTypeArticle = "article"
+	MediaTypeVideo   = "video"
+	MediaTypeImage   = "image"
+	MediaTypeRich    = "rich"
+)
+
 // CacheEntry is a URL-level cache row shared across messages.
 type CacheEntry struct {
 	URL         string
@@ -22,11 +30,43 @@ type CacheEntry struct {
 	Description string
 	ImageURL    string
 	SiteName    string
+	MediaType   string
+	ImageWidth  int
+	ImageHeight int
+	VideoURL    string
+	VideoWidth  int
+	VideoHeight int
+	AuthorName  string
+	AuthorURL   string
+	PublishedAt string // RFC3339
+	FaviconURL  string
 	FetchedAt   time.Time
 	ExpiresAt   time.Time
 	FetchError  string
 }
 
+// Preview returns an external preview populated from the cache entry.
+func (c *CacheEntry) Preview() *Preview {
+	return &Preview{
+		URL:         c.URL,
+		Type:        PreviewTypeExternal,
+		Title:       c.Title,
+		Description: c.Description,
+		ImageURL:    c.ImageURL,
+		SiteName:    c.SiteName,
+		MediaType:   c.MediaType,
+		ImageWidth:  c.ImageWidth,
+		ImageHeight: c.ImageHeight,
+		VideoURL:    c.VideoURL,
+		VideoWidth:  c.VideoWidth,
+		VideoHeight: c.VideoHeight,
+		AuthorName:  c.AuthorName,
+		AuthorURL:   c.AuthorURL,
+		PublishedAt: c.PublishedAt,
+		FaviconURL:  c.FaviconURL,
+	}
+}
+
 // Preview is a per-message link preview row.
 type Preview struct {
 	ID          string
@@ -39,6 +79,18 @@ type Preview struct {
 	SiteName    string
 	CreatedAt   time.Time
 
+	// Rich external preview fields (Type == "external")
+	MediaType   string // "article", "video", "image" or "rich"
+	ImageWidth  int
+	ImageHeight int
+	VideoURL    string // direct video file or embeddable player URL
+	VideoWidth  int
+	VideoHeight int
+	AuthorName  string
+	AuthorURL   string
+	PublishedAt string // RFC3339
+	FaviconURL  string
+
 	// Internal message preview fields (Type == "message")
 	LinkedMessageID        string
 	LinkedChannelID        string
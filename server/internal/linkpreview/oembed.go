@@ -0,0 +1,252 @@
+package linkpreview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+const maxOEmbedSize = 64 << 10 // 64 KB
+
+// OEmbedProvider describes an oEmbed endpoint and the URLs it handles.
+// Schemes use the oEmbed wildcard syntax, e.g. "https://*.example.com/video/*".
+type OEmbedProvider struct {
+	Name     string
+	Endpoint string
+	Schemes  []string
+}
+
+// DefaultOEmbedProviders are well-known providers whose pages either lack
+// useful OpenGraph data or do not advertise their oEmbed endpoint.
+var DefaultOEmbedProviders = []OEmbedProvider{
+	{
+		Name:     "YouTube",
+		Endpoint: "https://www.youtube.com/oembed",
+		Schemes: []string{
+			"https://www.youtube.com/watch*",
+			"https://youtube.com/watch*",
+			"https://m.youtube.com/watch*",
+			"https://youtu.be/*",
+			"https://www.youtube.com/shorts/*",
+		},
+	},
+	{
+		Name:     "Vimeo",
+		Endpoint: "https://vimeo.com/api/oembed.json",
+		Schemes:  []string{"https://vimeo.com/*", "https://player.vimeo.com/video/*"},
+	},
+	{
+		Name:     "X",
+		Endpoint: "https://publish.twitter.com/oembed",
+		Schemes: []string{
+			"https://twitter.com/*/status/*",
+			"https://x.com/*/status/*",
+		},
+	},
+	{
+		Name:     "SoundCloud",
+		Endpoint: "https://soundcloud.com/oembed",
+		Schemes:  []string{"https://soundcloud.com/*"},
+	},
+	{
+		Name:     "Spotify",
+		Endpoint: "https://open.spotify.com/oembed",
+		Schemes:  []string{"https://open.spotify.com/*"},
+	},
+	{
+		Name:     "Flickr",
+		Endpoint: "https://www.flickr.com/services/oembed/",
+		Schemes:  []string{"https://www.flickr.com/photos/*", "https://flic.kr/p/*"},
+	},
+}
+
+// ProviderRegistry matches URLs to oEmbed providers.
+type ProviderRegistry struct {
+	providers []OEmbedProvider
+	patterns  [][]*regexp.Regexp
+}
+
+// NewProviderRegistry builds a registry from providers. Earlier providers
+// win when several match, so configured providers should come first.
+func NewProviderRegistry(providers []OEmbedProvider) (*ProviderRegistry, error) {
+	r := &ProviderRegistry{}
+	for _, p := range providers {
+		if _, err := url.ParseRequestURI(p.Endpoint); err != nil {
+			return nil, fmt.Errorf("oembed provider %q: invalid endpoint: %w", p.Name, err)
+		}
+		var patterns []*regexp.Regexp
+		for _, scheme := range p.Schemes {
+			patterns = append(patterns, compileScheme(scheme))
+		}
+		r.providers = append(r.providers, p)
+		r.patterns = append(r.patterns, patterns)
+	}
+	return r, nil
+}
+
+// compileScheme turns an oEmbed URL scheme into an anchored regexp where *
+// matches any run of characters.
+func compileScheme(scheme string) *regexp.Regexp {
+	parts := strings.Split(scheme, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
+
+// Match returns the provider handling rawURL, or nil.
+func (r *ProviderRegistry) Match(rawURL string) *OEmbedProvider {
+	if r == nil {
+		return nil
+	}
+	for i, patterns := range r.patterns {
+		for _, re := range patterns {
+			if re.MatchString(rawURL) {
+				return &r.providers[i]
+			}
+		}
+	}
+	return nil
+}
+
+// endpointURL builds the JSON oEmbed request URL for rawURL.
+func (p *OEmbedProvider) endpointURL(rawURL string) string {
+	u, _ := url.Parse(p.Endpoint)
+	q := u.Query()
+	q.Set("url", rawURL)
+	q.Set("format", "json")
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// oembedResponse is the subset of the oEmbed 1.0 response we use.
+type oembedResponse struct {
+	Type            string          `json:"type"`
+	Title           string          `json:"title"`
+	AuthorName      string          `json:"author_name"`
+	AuthorURL       string          `json:"author_url"`
+	ProviderName    string          `json:"provider_name"`
+	URL             string          `json:"url"` // photo type only
+	Width           json.RawMessage `json:"width"`
+	Height          json.RawMessage `json:"height"`
+	HTML            string          `json:"html"`
+	ThumbnailURL    string          `json:"thumbnail_url"`
+	ThumbnailWidth  json.RawMessage `json:"thumbnail_width"`
+	ThumbnailHeight json.RawMessage `json:"thumbnail_height"`
+}
+
+// fetchOEmbed requests and decodes an oEmbed JSON document.
+func (f *Fetcher) fetchOEmbed(ctx context.Context, endpoint string) (*oembedResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("oembed HTTP %d", resp.StatusCode)
+	}
+
+	var o oembedResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxOEmbedSize)).Decode(&o); err != nil {
+		return nil, fmt.Errorf("decoding oembed response: %w", err)
+	}
+	return &o, nil
+}
+
+// applyOEmbed merges an oEmbed response into data. oEmbed is authoritative
+// for the media type, dimensions and author; OpenGraph text is kept when present.
+func applyOEmbed(data *ogData, o *oembedResponse) {
+	if data.Title == "" {
+		data.Title = o.Title
+	}
+	if data.SiteName == "" {
+		data.SiteName = o.ProviderName
+	}
+	if o.AuthorName != "" {
+		data.AuthorName = o.AuthorName
+		data.AuthorURL = o.AuthorURL
+	}
+
+	switch o.Type {
+	case "photo":
+		data.MediaType = MediaTypeImage
+		if o.URL != "" {
+			data.ImageURL = o.URL
+			data.ImageWidth, data.ImageHeight = jsonInt(o.Width), jsonInt(o.Height)
+		}
+	case "video":
+		data.MediaType = MediaTypeVideo
+		if src := iframeSrc(o.HTML); src != "" {
+			data.VideoURL = src
+			data.VideoWidth, data.VideoHeight = jsonInt(o.Width), jsonInt(o.Height)
+		}
+	case "rich":
+		data.MediaType = MediaTypeRich
+	case "link":
+		if data.MediaType == "" {
+			data.MediaType = MediaTypeArticle
+		}
+	}
+
+	if data.ImageURL == "" && o.ThumbnailURL != "" {
+		data.ImageURL = o.ThumbnailURL
+		data.ImageWidth, data.ImageHeight = jsonInt(o.ThumbnailWidth), jsonInt(o.ThumbnailHeight)
+	}
+}
+
+// jsonInt decodes an oEmbed dimension, which providers send as either a
+// number or a string.
+func jsonInt(raw json.RawMessage) int {
+	var n float64
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return int(n)
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		var i int
+		if _, err := fmt.Sscanf(s, "%d", &i); err == nil {
+			return i
+		}
+	}
+	return 0
+}
+
+// iframeSrc returns the https src of the first iframe in an oEmbed html
+// snippet. The snippet itself is never stored or rendered.
+func iframeSrc(snippet string) string {
+	z := html.NewTokenizer(strings.NewReader(snippet))
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			return ""
+		case html.StartTagToken, html.SelfClosingTagToken:
+			tn, hasAttr := z.TagName()
+			if string(tn) != "iframe" || !hasAttr {
+				continue
+			}
+			src := readAttrs(z)["src"]
+			if strings.HasPrefix(src, "//") {
+				src = "https:" + src
+			}
+			if strings.HasPrefix(src, "https://") {
+				return src
+			}
+			return ""
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package linkpreview
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProviderRegistry_Match(t *testing.T) {
+	reg, err := NewProviderRegistry(append([]OEmbedProvider{
+		{Name: "Custom", Endpoint: "https://oembed.example.com/", Schemes: []string{"https://*.example.com/v/*"}},
+	}, DefaultOEmbedProviders...))
+	if err != nil {
+		t.Fatalf("NewProviderRegistry: %v", err)
+	}
+
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "YouTube"},
+		{"https://youtu.be/dQw4w9WgXcQ", "YouTube"},
+		{"https://vimeo.com/76979871", "Vimeo"},
+		{"https://x.com/someone/status/123", "X"},
+		{"https://media.example.com/v/42", "Custom"},
+		{"https://example.com/v/42", ""},
+		{"https://www.youtube.com.evil.com/watch?v=1", ""},
+		{"https://example.org/", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if p := reg.Match(tt.url); p != nil {
+			got = p.Name
+		}
+		if got != tt.want {
+			t.Errorf("Match(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestNewProviderRegistry_InvalidEndpoint(t *testing.T) {
+	if _, err := NewProviderRegistry([]OEmbedProvider{{Name: "Bad", Endpoint: "not a url"}}); err == nil {
+		t.Fatal("expected error for invalid endpoint")
+	}
+}
+
+func TestApplyOEmbed(t *testing.T) {
+	var video oembedResponse
+	_ = json.Unmarshal([]byte(`{
+		"type": "video", "title": "Clip", "provider_name": "Tube",
+		"author_name": "Bob", "author_url": "https://tube.example/bob",
+		"width": 640, "height": "360",
+		"html": "<iframe width=\"640\" src=\"//tube.example/embed/1\"></iframe>",
+		"thumbnail_url": "https://tube.example/1.jpg", "thumbnail_width": 480, "thumbnail_height": 360
+	}`), &video)
+
+	data := &ogData{Description: "From the page"}
+	applyOEmbed(data, &video)
+
+	if data.MediaType != MediaTypeVideo {
+		t.Errorf("MediaType = %q, want video", data.MediaType)
+	}
+	if data.Title != "Clip" || data.Description != "From the page" || data.SiteName != "Tube" {
+		t.Errorf("unexpected text fields: %+v", data)
+	}
+	if data.VideoURL != "https://tube.example/embed/1" || data.VideoWidth != 640 || data.VideoHeight != 360 {
+		t.Errorf("unexpected video fields: %q %dx%d", data.VideoURL, data.VideoWidth, data.VideoHeight)
+	}
+	if data.ImageURL != "https://tube.example/1.jpg" || data.ImageWidth != 480 {
+		t.Errorf("unexpected thumbnail: %q %d", data.ImageURL, data.ImageWidth)
+	}
+	if data.AuthorName != "Bob" || data.AuthorURL != "https://tube.example/bob" {
+		t.Errorf("unexpected author: %q %q", data.AuthorName, data.AuthorURL)
+	}
+}
+
+func TestIframeSrc_RejectsInsecure(t *testing.T) {
+	if got := iframeSrc(`<iframe src="http://example.com/embed"></iframe>`); got != "" {
+		t.Errorf("iframeSrc = %q, want empty for http src", got)
+	}
+	if got := iframeSrc(`<blockquote>no iframe</blockquote>`); got != "" {
+		t.Errorf("iframeSrc = %q, want empty", got)
+	}
+}
@@ -19,16 +19,23 @@ func NewRepository(db *sql.DB) *Repository {
 	return &Repository{db: db}
 }
 
+// cacheColumns is the column list used by GetCachedURL.
+const cacheColumns = `url, title, description, image_url, site_name, fetched_at, expires_at, fetch_error,
+	media_type, image_width, image_height, video_url, video_width, video_height,
+	author_name, author_url, published_at, favicon_url`
+
 // GetCachedURL returns the cache entry for a URL, or nil if not found / expired.
 func (r *Repository) GetCachedURL(ctx context.Context, url string) (*CacheEntry, error) {
 	var c CacheEntry
 	var fetchedAt, expiresAt string
 	var title, description, imageURL, siteName, fetchError sql.NullString
+	var rich richColumns
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT url, title, description, image_url, site_name, fetched_at, expires_at, fetch_error
+		SELECT `+cacheColumns+`
 		FROM link_preview_cache WHERE url = ?
-	`, url).Scan(&c.URL, &title, &description, &imageURL, &siteName, &fetchedAt, &expiresAt, &fetchError)
+	`, url).Scan(append([]any{&c.URL, &title, &description, &imageURL, &siteName, &fetchedAt, &expiresAt, &fetchError},
+		rich.dest()...)...)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -43,6 +50,16 @@ func (r *Repository) GetCachedURL(ctx context.Context, url string) (*CacheEntry,
 	c.FetchError = fetchError.String
 	c.FetchedAt, _ = time.Parse(time.RFC3339, fetchedAt)
 	c.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+	c.MediaType = rich.mediaType.String
+	c.ImageWidth = int(rich.imageWidth.Int64)
+	c.ImageHeight = int(rich.imageHeight.Int64)
+	c.VideoURL = rich.videoURL.String
+	c.VideoWidth = int(rich.videoWidth.Int64)
+	c.VideoHeight = int(rich.videoHeight.Int64)
+	c.AuthorName = rich.authorName.String
+	c.AuthorURL = rich.authorURL.String
+	c.PublishedAt = rich.publishedAt.String
+	c.FaviconURL = rich.faviconURL.String
 
 	// Treat expired entries as a miss.
 	if time.Now().After(c.ExpiresAt) {
@@ -55,13 +72,31 @@ func (r *Repository) GetCachedURL(ctx context.Context, url string) (*CacheEntry,
 // SetCachedURL inserts or replaces a cache entry.
 func (r *Repository) SetCachedURL(ctx context.Context, c *CacheEntry) error {
 	_, err := r.db.ExecContext(ctx, `
-		INSERT OR REPLACE INTO link_preview_cache (url, title, description, image_url, site_name, fetched_at, expires_at, fetch_error)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO link_preview_cache (`+cacheColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET `+updateFromExcluded(cacheColumns, "url")+`
 	`, c.URL, nullString(c.Title), nullString(c.Description), nullString(c.ImageURL), nullString(c.SiteName),
-		c.FetchedAt.Format(time.RFC3339), c.ExpiresAt.Format(time.RFC3339), nullString(c.FetchError))
+		c.FetchedAt.Format(time.RFC3339), c.ExpiresAt.Format(time.RFC3339), nullString(c.FetchError),
+		nullString(c.MediaType), nullInt(c.ImageWidth), nullInt(c.ImageHeight),
+		nullString(c.VideoURL), nullInt(c.VideoWidth), nullInt(c.VideoHeight),
+		nullString(c.AuthorName), nullString(c.AuthorURL), nullString(c.PublishedAt), nullString(c.FaviconURL))
 	return err
 }
 
+// richColumns holds the nullable rich preview columns shared by the cache
+// and preview tables.
+type richColumns struct {
+	mediaType, videoURL, authorName, authorURL, publishedAt, faviconURL sql.NullString
+	imageWidth, imageHeight, videoWidth, videoHeight                    sql.NullInt64
+}
+
+func (rc *richColumns) dest() []any {
+	return []any{
+		&rc.mediaType, &rc.imageWidth, &rc.imageHeight, &rc.videoURL, &rc.videoWidth, &rc.videoHeight,
+		&rc.authorName, &rc.authorURL, &rc.publishedAt, &rc.faviconURL,
+	}
+}
+
 // CreatePreview inserts or replaces a per-message preview row.
 func (r *Repository) CreatePreview(ctx context.Context, p *Preview) error {
 	if p.ID == "" {
@@ -75,12 +110,15 @@ func (r *Repository) CreatePreview(ctx context.Context, p *Preview) error {
 	}
 
 	_, err := r.db.ExecContext(ctx, `
-		INSERT OR REPLACE INTO link_previews (
+		INSERT INTO link_previews (
 			id, message_id, url, type, title, description, image_url, site_name, created_at,
 			linked_message_id, linked_channel_id, linked_channel_name, linked_channel_type,
 			message_author_id, message_author_name, message_author_avatar_url, message_author_gravatar_url,
-			message_content, message_created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			message_content, message_created_at,
+			media_type, image_width, image_height, video_url, video_width, video_height,
+			author_name, author_url, published_at, favicon_url
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(message_id) DO UPDATE SET `+updateFromExcluded(previewColumns, "message_id")+`
 	`, p.ID, p.MessageID, p.URL, p.Type,
 		nullString(p.Title), nullString(p.Description), nullString(p.ImageURL), nullString(p.SiteName),
 		p.CreatedAt.Format(time.RFC3339),
@@ -88,7 +126,10 @@ func (r *Repository) CreatePreview(ctx context.Context, p *Preview) error {
 		nullString(p.LinkedChannelName), nullString(p.LinkedChannelType),
 		nullString(p.MessageAuthorID), nullString(p.MessageAuthorName),
 		nullString(p.MessageAuthorAvatarURL), nullString(p.MessageAuthorGravatar),
-		nullString(p.MessageContent), nullString(p.MessageCreatedAt))
+		nullString(p.MessageContent), nullString(p.MessageCreatedAt),
+		nullString(p.MediaType), nullInt(p.ImageWidth), nullInt(p.ImageHeight),
+		nullString(p.VideoURL), nullInt(p.VideoWidth), nullInt(p.VideoHeight),
+		nullString(p.AuthorName), nullString(p.AuthorURL), nullString(p.PublishedAt), nullString(p.FaviconURL))
 	return err
 }
 
@@ -96,7 +137,9 @@ func (r *Repository) CreatePreview(ctx context.Context, p *Preview) error {
 const previewColumns = `id, message_id, url, type, title, description, image_url, site_name, created_at,
 	linked_message_id, linked_channel_id, linked_channel_name, linked_channel_type,
 	message_author_id, message_author_name, message_author_avatar_url, message_author_gravatar_url,
-	message_content, message_created_at`
+	message_content, message_created_at,
+	media_type, image_width, image_height, video_url, video_width, video_height,
+	author_name, author_url, published_at, favicon_url`
 
 // scanPreview scans a row into a Preview.
 func scanPreview(scanner interface{ Scan(dest ...any) error }) (*Preview, error) {
@@ -107,14 +150,15 @@ func scanPreview(scanner interface{ Scan(dest ...any) error }) (*Preview, error)
 	var msgContent, msgCreatedAt sql.NullString
 	var createdAt string
 	var previewType sql.NullString
+	var rich richColumns
 
-	err := scanner.Scan(
+	err := scanner.Scan(append([]any{
 		&p.ID, &p.MessageID, &p.URL, &previewType,
 		&title, &description, &imageURL, &siteName, &createdAt,
 		&linkedMsgID, &linkedChID, &linkedChName, &linkedChType,
 		&authorID, &authorName, &authorAvatar, &authorGravatar,
 		&msgContent, &msgCreatedAt,
-	)
+	}, rich.dest()...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -140,6 +184,17 @@ func scanPreview(scanner interface{ Scan(dest ...any) error }) (*Preview, error)
 	p.MessageContent = msgContent.String
 	p.MessageCreatedAt = msgCreatedAt.String
 
+	p.MediaType = rich.mediaType.String
+	p.ImageWidth = int(rich.imageWidth.Int64)
+	p.ImageHeight = int(rich.imageHeight.Int64)
+	p.VideoURL = rich.videoURL.String
+	p.VideoWidth = int(rich.videoWidth.Int64)
+	p.VideoHeight = int(rich.videoHeight.Int64)
+	p.AuthorName = rich.authorName.String
+	p.AuthorURL = rich.authorURL.String
+	p.PublishedAt = rich.publishedAt.String
+	p.FaviconURL = rich.faviconURL.String
+
 	return &p, nil
 }
 
@@ -215,3 +270,24 @@ func nullString(s string) sql.NullString {
 	}
 	return sql.NullString{String: s, Valid: true}
 }
+
+// nullInt returns sql.NullInt64 for optional integer fields, treating 0 as unset.
+func nullInt(n int) sql.NullInt64 {
+	if n == 0 {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(n), Valid: true}
+}
+
+// updateFromExcluded builds the SET list of an upsert that overwrites every
+// column in columns except the conflict key.
+func updateFromExcluded(columns, key string) string {
+	var set []string
+	for _, col := range strings.Split(columns, ",") {
+		col = strings.TrimSpace(col)
+		if col != key {
+			set = append(set, col+" = excluded."+col)
+		}
+	}
+	return strings.Join(set, ", ")
+}
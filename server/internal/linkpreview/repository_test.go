@@ -191,3 +191,56 @@ func TestCleanExpiredCache(t *testing.T) {
 		t.Errorf("expected 1 remaining cache entry, got %d", count)
 	}
 }
+
+func TestRichFields_RoundTrip(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	entry := &CacheEntry{
+		URL:         "https://video.example.com/watch?v=1",
+		Title:       "A video",
+		MediaType:   MediaTypeVideo,
+		ImageURL:    "https://video.example.com/thumb.jpg",
+		ImageWidth:  480,
+		ImageHeight: 360,
+		VideoURL:    "https://video.example.com/embed/1",
+		VideoWidth:  640,
+		VideoHeight: 480,
+		AuthorName:  "Alice",
+		AuthorURL:   "https://video.example.com/alice",
+		PublishedAt: "2024-05-01T12:00:00Z",
+		FaviconURL:  "https://video.example.com/favicon.ico",
+		FetchedAt:   now,
+		ExpiresAt:   now.Add(CacheTTL),
+	}
+	if err := repo.SetCachedURL(ctx, entry); err != nil {
+		t.Fatalf("SetCachedURL: %v", err)
+	}
+	cached, err := repo.GetCachedURL(ctx, entry.URL)
+	if err != nil || cached == nil {
+		t.Fatalf("GetCachedURL: %v", err)
+	}
+
+	user := testutil.CreateTestUser(t, db, "test@example.com", "Test")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "Test WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", "public")
+	msg := testutil.CreateTestMessage(t, db, ch.ID, user.ID, "Watch "+entry.URL)
+
+	p := cached.Preview()
+	p.MessageID = msg.ID
+	if err := repo.CreatePreview(ctx, p); err != nil {
+		t.Fatalf("CreatePreview: %v", err)
+	}
+	got, err := repo.GetForMessage(ctx, msg.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetForMessage: %v", err)
+	}
+
+	want := entry.Preview()
+	want.ID, want.MessageID, want.CreatedAt = got.ID, got.MessageID, got.CreatedAt
+	if *got != *want {
+		t.Errorf("preview round trip mismatch:\n got %+v\nwant %+v", got, want)
+	}
+}
@@ -11,6 +11,7 @@ import (
 const (
 	MessageTypeUser   = "user"
 	MessageTypeSystem = "system"
+	MessageTypeBot    = "bot"
 )
 
 // System event types
@@ -43,6 +44,7 @@ type Message struct {
 	ID                string           `json:"id"`
 	ChannelID         string           `json:"channel_id"`
 	UserID            *string          `json:"user_id,omitempty"`
+	BotName           *string          `json:"-"`
 	Content           string           `json:"content"`
 	Type              string           `json:"type"`
 	SystemEvent       *SystemEventData `json:"system_event,omitempty"`
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/enzyme/server/internal/database"
 	"github.com/enzyme/server/internal/moderation"
 	"github.com/enzyme/server/internal/telemetry"
 	"github.com/oklog/ulid/v2"
@@ -23,11 +24,12 @@ var (
 )
 
 type Repository struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect database.Dialect
 }
 
 func NewRepository(db *sql.DB) *Repository {
-	return &Repository{db: db}
+	return &Repository{db: db, dialect: database.DialectOf(db)}
 }
 
 func (r *Repository) Create(ctx context.Context, msg *Message) (err error) {
@@ -69,9 +71,9 @@ func (r *Repository) Create(ctx context.Context, msg *Message) (err error) {
 	defer tx.Rollback()
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO messages (id, channel_id, user_id, content, type, system_event, mentions, thread_parent_id, also_send_to_channel, reply_count, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?)
-	`, msg.ID, msg.ChannelID, msg.UserID, msg.Content, msg.Type, systemEventJSON, mentionsJSON, msg.ThreadParentID, msg.AlsoSendToChannel, now.Format(time.RFC3339), now.Format(time.RFC3339))
+		INSERT INTO messages (id, channel_id, user_id, bot_name, content, type, system_event, mentions, thread_parent_id, also_send_to_channel, reply_count, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, ?)
+	`, msg.ID, msg.ChannelID, msg.UserID, msg.BotName, msg.Content, msg.Type, systemEventJSON, mentionsJSON, msg.ThreadParentID, msg.AlsoSendToChannel, now.Format(time.RFC3339), now.Format(time.RFC3339))
 	if err != nil {
 		return err
 	}
@@ -150,7 +152,7 @@ func (r *Repository) GetByID(ctx context.Context, id string) (*Message, error) {
 func (r *Repository) GetByIDWithUser(ctx context.Context, id string) (*MessageWithUser, error) {
 	row := r.db.QueryRowContext(ctx, `
 		SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-		       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+		       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 		FROM messages m
 		LEFT JOIN users u ON u.id = m.user_id
 		WHERE m.id = ?
@@ -213,7 +215,7 @@ func (r *Repository) Delete(ctx context.Context, id string) error {
 	// Decrement parent's reply_count if this is a thread reply
 	if threadParentID.Valid {
 		_, err = tx.ExecContext(ctx, `
-			UPDATE messages SET reply_count = MAX(reply_count - 1, 0), updated_at = ?
+			UPDATE messages SET reply_count = CASE WHEN reply_count > 0 THEN reply_count - 1 ELSE 0 END, updated_at = ?
 			WHERE id = ?
 		`, now.Format(time.RFC3339), threadParentID.String)
 		if err != nil {
@@ -245,10 +247,10 @@ func (r *Repository) List(ctx context.Context, channelID string, opts ListOption
 	if opts.Cursor == "" {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
-			WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = TRUE)` + filterSQL + `
+			WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = 1)` + filterSQL + `
 			ORDER BY m.id DESC
 			LIMIT ?
 		`
@@ -257,10 +259,10 @@ func (r *Repository) List(ctx context.Context, channelID string, opts ListOption
 	} else if opts.Direction == "after" {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
-			WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = TRUE) AND m.id > ?` + filterSQL + `
+			WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = 1) AND m.id > ?` + filterSQL + `
 			ORDER BY m.id ASC
 			LIMIT ?
 		`
@@ -269,10 +271,10 @@ func (r *Repository) List(ctx context.Context, channelID string, opts ListOption
 	} else {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
-			WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = TRUE) AND m.id < ?` + filterSQL + `
+			WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = 1) AND m.id < ?` + filterSQL + `
 			ORDER BY m.id DESC
 			LIMIT ?
 		`
@@ -335,10 +337,10 @@ func (r *Repository) listAround(ctx context.Context, channelID string, opts List
 	// Query messages at or before cursor (DESC order, includes the cursor message)
 	beforeQuery := `
 		SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-		       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+		       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 		FROM messages m
 		LEFT JOIN users u ON u.id = m.user_id
-		WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = TRUE) AND m.id <= ?` + filterSQL + `
+		WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = 1) AND m.id <= ?` + filterSQL + `
 		ORDER BY m.id DESC
 		LIMIT ?
 	`
@@ -370,10 +372,10 @@ func (r *Repository) listAround(ctx context.Context, channelID string, opts List
 	// Query messages after cursor (ASC order)
 	afterQuery := `
 		SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-		       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+		       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 		FROM messages m
 		LEFT JOIN users u ON u.id = m.user_id
-		WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = TRUE) AND m.id > ?` + filterSQL + `
+		WHERE m.channel_id = ? AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = 1) AND m.id > ?` + filterSQL + `
 		ORDER BY m.id ASC
 		LIMIT ?
 	`
@@ -482,7 +484,7 @@ func (r *Repository) ListThread(ctx context.Context, parentID string, opts ListO
 	if opts.Cursor == "" {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
 			WHERE m.thread_parent_id = ?` + filterSQL + `
@@ -494,7 +496,7 @@ func (r *Repository) ListThread(ctx context.Context, parentID string, opts ListO
 	} else {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
 			WHERE m.thread_parent_id = ? AND m.id > ?` + filterSQL + `
@@ -858,14 +860,14 @@ func (r *Repository) ListAllUnreads(ctx context.Context, workspaceID, userID str
 	if opts.Cursor == "" {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
 			       c.name as channel_name, c.type as channel_type
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
 			JOIN channels c ON c.id = m.channel_id
 			JOIN channel_memberships cm ON cm.channel_id = c.id AND cm.user_id = ?
 			WHERE c.workspace_id = ?
-			  AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = TRUE)
+			  AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = 1)
 			  AND m.deleted_at IS NULL
 			  AND (cm.last_read_message_id IS NULL OR m.id > cm.last_read_message_id)` + filterSQL + `
 			ORDER BY m.id DESC
@@ -876,14 +878,14 @@ func (r *Repository) ListAllUnreads(ctx context.Context, workspaceID, userID str
 	} else {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
 			       c.name as channel_name, c.type as channel_type
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
 			JOIN channels c ON c.id = m.channel_id
 			JOIN channel_memberships cm ON cm.channel_id = c.id AND cm.user_id = ?
 			WHERE c.workspace_id = ?
-			  AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = TRUE)
+			  AND (m.thread_parent_id IS NULL OR m.also_send_to_channel = 1)
 			  AND m.deleted_at IS NULL
 			  AND (cm.last_read_message_id IS NULL OR m.id > cm.last_read_message_id)
 			  AND m.id < ?` + filterSQL + `
@@ -1048,7 +1050,8 @@ func sanitizeFTSQuery(query string) string {
 	return strings.Join(quoted, " ")
 }
 
-// Search searches messages across channels in a workspace using FTS5
+// Search searches messages across channels in a workspace using FTS5 on
+// SQLite and the generated search_vector column on Postgres
 func (r *Repository) Search(ctx context.Context, workspaceID, currentUserID string, opts SearchOptions, filter *moderation.FilterOptions) (_ *SearchResult, err error) {
 	ctx, endSpan := telemetry.StartDBSpan(ctx, "message.Search")
 	defer func() { endSpan(err) }()
@@ -1067,12 +1070,28 @@ func (r *Repository) Search(ctx context.Context, workspaceID, currentUserID stri
 		}, nil
 	}
 
+	// The full-text match, its source table and its ranking differ by engine
+	fromSQL := `
+		FROM messages_fts
+		JOIN messages m ON m.rowid = messages_fts.rowid`
+	matchSQL := "messages_fts.content MATCH ?"
+	orderSQL := "messages_fts.rank"
+	var orderArgs []interface{}
+	if r.dialect == database.Postgres {
+		fromSQL = `
+		FROM messages m`
+		matchSQL = "m.search_vector @@ plainto_tsquery('english', ?)"
+		orderSQL = "ts_rank(m.search_vector, plainto_tsquery('english', ?)) DESC, m.id DESC"
+		sanitized = opts.Query
+		orderArgs = []interface{}{sanitized}
+	}
+
 	// Build WHERE clauses and args for both count and data queries
 	whereClauses := []string{
 		"m.deleted_at IS NULL",
 		"m.type != 'system'",
 		"c.workspace_id = ?",
-		"messages_fts.content MATCH ?",
+		matchSQL,
 		// Access control: user must be a channel member OR channel must be public
 		"(cm.user_id IS NOT NULL OR c.type = 'public')",
 	}
@@ -1105,9 +1124,7 @@ func (r *Repository) Search(ctx context.Context, workspaceID, currentUserID stri
 
 	whereSQL := strings.Join(whereClauses, " AND ")
 
-	joinSQL := `
-		FROM messages_fts
-		JOIN messages m ON m.rowid = messages_fts.rowid
+	joinSQL := fromSQL + `
 		JOIN channels c ON c.id = m.channel_id
 		LEFT JOIN users u ON u.id = m.user_id
 		LEFT JOIN channel_memberships cm ON cm.channel_id = c.id AND cm.user_id = ?
@@ -1118,14 +1135,14 @@ func (r *Repository) Search(ctx context.Context, workspaceID, currentUserID stri
 	// Single query with COUNT(*) OVER() to avoid a separate count round-trip
 	dataQuery := `
 		SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-		       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
+		       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
 		       c.name as channel_name, c.type as channel_type,
 		       COUNT(*) OVER() as total_count
 	` + joinSQL + " WHERE " + whereSQL + `
-		ORDER BY messages_fts.rank
+		ORDER BY ` + orderSQL + `
 		LIMIT ? OFFSET ?
 	`
-	dataArgs := append(joinArgs, opts.Limit, opts.Offset)
+	dataArgs := append(append(joinArgs, orderArgs...), opts.Limit, opts.Offset)
 
 	rows, err := r.db.QueryContext(ctx, dataQuery, dataArgs...)
 	if err != nil {
@@ -1182,7 +1199,7 @@ func (r *Repository) ListUserThreads(ctx context.Context, workspaceID, userID st
 	if opts.Cursor == "" {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
 			       c.name as channel_name, c.type as channel_type,
 			       CASE WHEN ts.last_read_reply_id IS NULL THEN 1
 			            WHEN EXISTS (SELECT 1 FROM messages r WHERE r.thread_parent_id = m.id AND r.id > ts.last_read_reply_id AND r.deleted_at IS NULL LIMIT 1) THEN 1
@@ -1204,7 +1221,7 @@ func (r *Repository) ListUserThreads(ctx context.Context, workspaceID, userID st
 	} else {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email,
 			       c.name as channel_name, c.type as channel_type,
 			       CASE WHEN ts.last_read_reply_id IS NULL THEN 1
 			            WHEN EXISTS (SELECT 1 FROM messages r WHERE r.thread_parent_id = m.id AND r.id > ts.last_read_reply_id AND r.deleted_at IS NULL LIMIT 1) THEN 1
@@ -1363,7 +1380,7 @@ func (r *Repository) ListPinnedMessages(ctx context.Context, channelID string, c
 	if cursor == "" {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
 			WHERE m.channel_id = ? AND m.pinned_at IS NOT NULL AND m.deleted_at IS NULL` + filterSQL + `
@@ -1375,7 +1392,7 @@ func (r *Repository) ListPinnedMessages(ctx context.Context, channelID string, c
 	} else {
 		query = `
 			SELECT m.id, m.channel_id, m.user_id, m.content, m.type, m.system_event, m.thread_parent_id, m.also_send_to_channel, m.reply_count, m.last_reply_at, m.edited_at, m.deleted_at, m.pinned_at, m.pinned_by, m.created_at, m.updated_at,
-			       COALESCE(u.display_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
+			       COALESCE(u.display_name, m.bot_name, '') as user_display_name, u.avatar_url, COALESCE(u.email, '') as user_email
 			FROM messages m
 			LEFT JOIN users u ON u.id = m.user_id
 			WHERE m.channel_id = ? AND m.pinned_at IS NOT NULL AND m.deleted_at IS NULL AND m.id < ?` + filterSQL + `
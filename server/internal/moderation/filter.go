@@ -1,5 +1,7 @@
 package moderation
 
+import "time"
+
 // FilterOptions carries context for ban-hide and block filtering in message queries.
 // When non-nil, messages from banned users (with hide_messages=1) and blocked users
 // are excluded from results. Reactions and thread participants from those users are
@@ -23,9 +25,9 @@ func FilterSQL(filter *FilterOptions, userCol string) (string, []interface{}) {
 	sql += ` AND ` + userCol + ` NOT IN (
 		SELECT wb.user_id FROM workspace_bans wb
 		WHERE wb.workspace_id = ? AND wb.hide_messages = 1
-		AND (wb.expires_at IS NULL OR wb.expires_at > strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+		AND (wb.expires_at IS NULL OR wb.expires_at > ?)
 	)`
-	args = append(args, filter.WorkspaceID)
+	args = append(args, filter.WorkspaceID, time.Now().UTC().Format(time.RFC3339))
 
 	// Block filter: exclude messages from users the requester has blocked
 	if filter.RequestingUserID != "" {
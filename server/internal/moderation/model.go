@@ -71,6 +71,17 @@ const (
 	ActionMemberRemoved     = "member.removed"
 	ActionMemberRoleChanged = "member.role_changed"
 	ActionChannelArchived   = "channel.archived"
+	ActionFileQuarantined   = "file.quarantined"
+
+	// Actions taken by server operators, e.g. with the enzyme admin command
+	ActionMemberAdded          = "member.added"
+	ActionUserPasswordReset    = "user.password_reset"
+	ActionUserEmailVerified    = "user.email_verified"
+	ActionUserDisabled         = "user.disabled"
+	ActionUserEnabled          = "user.enabled"
+	ActionUserSessionsRevoked  = "user.sessions_revoked"
+	ActionUserSiteAdminGranted = "user.site_admin_granted"
+	ActionUserSiteAdminRevoked = "user.site_admin_revoked"
 )
 
 // Target type constants
@@ -78,4 +89,9 @@ const (
 	TargetTypeUser    = "user"
 	TargetTypeMessage = "message"
 	TargetTypeChannel = "channel"
+	TargetTypeFile    = "file"
 )
+
+// SystemActorID attributes audit log entries to the server itself rather than
+// a user. It is stored as a NULL actor_id.
+const SystemActorID = "system"
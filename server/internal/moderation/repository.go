@@ -54,8 +54,8 @@ func (r *Repository) CreateBan(ctx context.Context, ban *Ban) error {
 	// Remove any expired ban for this user so the unique constraint doesn't block re-banning
 	_, _ = tx.ExecContext(ctx, `
 		DELETE FROM workspace_bans WHERE workspace_id = ? AND user_id = ?
-		AND expires_at IS NOT NULL AND expires_at <= strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
-	`, ban.WorkspaceID, ban.UserID)
+		AND expires_at IS NOT NULL AND expires_at <= ?
+	`, ban.WorkspaceID, ban.UserID, now.Format(time.RFC3339))
 
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO workspace_bans (id, workspace_id, user_id, banned_by, reason, hide_messages, expires_at, created_at)
@@ -100,8 +100,8 @@ func (r *Repository) GetActiveBan(ctx context.Context, workspaceID, userID strin
 		SELECT id, workspace_id, user_id, banned_by, reason, hide_messages, expires_at, created_at
 		FROM workspace_bans
 		WHERE workspace_id = ? AND user_id = ?
-		AND (expires_at IS NULL OR expires_at > strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
-	`, workspaceID, userID).Scan(
+		AND (expires_at IS NULL OR expires_at > ?)
+	`, workspaceID, userID, time.Now().UTC().Format(time.RFC3339)).Scan(
 		&ban.ID, &ban.WorkspaceID, &ban.UserID, &bannedBy,
 		&ban.Reason, &hideMessages, &expiresAt, &createdAt,
 	)
@@ -136,10 +136,10 @@ func (r *Repository) ListActiveBans(ctx context.Context, workspaceID string, cur
 
 	// Clean up expired bans on read
 	_, _ = r.db.ExecContext(ctx, `
-		DELETE FROM workspace_bans WHERE expires_at IS NOT NULL AND expires_at <= strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
-	`)
+		DELETE FROM workspace_bans WHERE expires_at IS NOT NULL AND expires_at <= ?
+	`, time.Now().UTC().Format(time.RFC3339))
 
-	args := []interface{}{workspaceID}
+	args := []interface{}{workspaceID, time.Now().UTC().Format(time.RFC3339)}
 	cursorClause := ""
 	if cursor != "" {
 		cursorClause = "AND wb.id < ?"
@@ -156,7 +156,7 @@ func (r *Repository) ListActiveBans(ctx context.Context, workspaceID string, cur
 		JOIN users u ON u.id = wb.user_id
 		LEFT JOIN users b ON b.id = wb.banned_by
 		WHERE wb.workspace_id = ?
-		AND (wb.expires_at IS NULL OR wb.expires_at > strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+		AND (wb.expires_at IS NULL OR wb.expires_at > ?)
 		`+cursorClause+`
 		ORDER BY wb.id DESC
 		LIMIT ?
@@ -333,10 +333,15 @@ func (r *Repository) CreateAuditLogEntry(ctx context.Context, entry *AuditLogEnt
 	now := time.Now().UTC()
 	entry.CreatedAt = now
 
+	var actorID *string
+	if entry.ActorID != SystemActorID {
+		actorID = &entry.ActorID
+	}
+
 	_, err := r.db.ExecContext(ctx, `
 		INSERT INTO moderation_log (id, workspace_id, actor_id, action, target_type, target_id, metadata, created_at)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
-	`, entry.ID, entry.WorkspaceID, entry.ActorID, entry.Action, entry.TargetType, entry.TargetID, entry.Metadata, now.Format(time.RFC3339))
+	`, entry.ID, entry.WorkspaceID, actorID, entry.Action, entry.TargetType, entry.TargetID, entry.Metadata, now.Format(time.RFC3339))
 	return err
 }
 
@@ -382,7 +387,7 @@ func (r *Repository) ListAuditLog(ctx context.Context, workspaceID string, curso
 			   u.display_name, u.avatar_url,
 			   tu.display_name
 		FROM moderation_log ml
-		JOIN users u ON u.id = ml.actor_id
+		LEFT JOIN users u ON u.id = ml.actor_id
 		LEFT JOIN users tu ON tu.id = ml.target_id AND ml.target_type = 'user'
 		WHERE ml.workspace_id = ?
 		`+cursorClause+`
@@ -397,16 +402,24 @@ func (r *Repository) ListAuditLog(ctx context.Context, workspaceID string, curso
 	var entries []AuditLogEntryWithActor
 	for rows.Next() {
 		var e AuditLogEntryWithActor
+		var actorID, actorDisplayName sql.NullString
 		var createdAt string
 		err := rows.Scan(
-			&e.ID, &e.WorkspaceID, &e.ActorID, &e.Action,
+			&e.ID, &e.WorkspaceID, &actorID, &e.Action,
 			&e.TargetType, &e.TargetID, &e.Metadata, &createdAt,
-			&e.ActorDisplayName, &e.ActorAvatarURL,
+			&actorDisplayName, &e.ActorAvatarURL,
 			&e.TargetDisplayName,
 		)
 		if err != nil {
 			return nil, false, "", err
 		}
+		if actorID.Valid {
+			e.ActorID = actorID.String
+			e.ActorDisplayName = actorDisplayName.String
+		} else {
+			e.ActorID = SystemActorID
+			e.ActorDisplayName = "System"
+		}
 		e.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 		entries = append(entries, e)
 	}
@@ -424,8 +437,8 @@ func (r *Repository) ListAuditLog(ctx context.Context, workspaceID string, curso
 // CleanupExpiredBans deletes bans that have passed their expiry.
 func (r *Repository) CleanupExpiredBans(ctx context.Context) error {
 	_, err := r.db.ExecContext(ctx, `
-		DELETE FROM workspace_bans WHERE expires_at IS NOT NULL AND expires_at <= strftime('%Y-%m-%dT%H:%M:%SZ', 'now')
-	`)
+		DELETE FROM workspace_bans WHERE expires_at IS NOT NULL AND expires_at <= ?
+	`, time.Now().UTC().Format(time.RFC3339))
 	return err
 }
 
@@ -804,3 +804,36 @@ func TestListAuditLog_Empty(t *testing.T) {
 		t.Error("expected empty cursor")
 	}
 }
+
+func TestListAuditLog_SystemActor(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test WS")
+
+	err := repo.CreateAuditLogEntryWithMetadata(ctx, ws.ID, SystemActorID, ActionFileQuarantined, TargetTypeFile, "file-id", map[string]interface{}{
+		"signature": "Eicar-Test-Signature",
+	})
+	if err != nil {
+		t.Fatalf("CreateAuditLogEntryWithMetadata() error = %v", err)
+	}
+
+	entries, _, _, err := repo.ListAuditLog(ctx, ws.ID, "", 50)
+	if err != nil {
+		t.Fatalf("ListAuditLog() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("len(entries) = %d, want 1", len(entries))
+	}
+	if entries[0].ActorID != SystemActorID {
+		t.Errorf("ActorID = %q, want %q", entries[0].ActorID, SystemActorID)
+	}
+	if entries[0].ActorDisplayName != "System" {
+		t.Errorf("ActorDisplayName = %q, want %q", entries[0].ActorDisplayName, "System")
+	}
+	if entries[0].TargetType != TargetTypeFile {
+		t.Errorf("TargetType = %q, want %q", entries[0].TargetType, TargetTypeFile)
+	}
+}
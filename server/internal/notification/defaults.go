@@ -0,0 +1,282 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Defaults are the preferences channels inherit unless a member overrides
+// them: either a workspace's defaults for its members, or a member's own
+// defaults for a workspace.
+type Defaults struct {
+	NotifyLevel  string `json:"notify_level"`
+	EmailEnabled bool   `json:"email_enabled"`
+	PushEnabled  bool   `json:"push_enabled"`
+	// MobileOnlyWhenIdle holds push notifications back while the user is
+	// connected. When false, mobile devices are notified alongside the app.
+	MobileOnlyWhenIdle bool `json:"mobile_only_when_idle"`
+}
+
+// BuiltInDefaults apply when neither the workspace nor the member has set any.
+var BuiltInDefaults = Defaults{
+	NotifyLevel:        NotifyMentions,
+	EmailEnabled:       true,
+	PushEnabled:        true,
+	MobileOnlyWhenIdle: true,
+}
+
+// EffectivePreference is the preference that applies to a user in a channel
+// once inheritance is resolved.
+type EffectivePreference struct {
+	ChannelID          string
+	NotifyLevel        string
+	EmailEnabled       bool
+	PushEnabled        bool
+	MobileOnlyWhenIdle bool
+	// Overridden is set when the user has preferences for this channel
+	Overridden bool
+}
+
+// resolvePreference applies, from lowest to highest precedence, the
+// defaults, the channel's default notify level and the user's own channel
+// preferences. DMs notify on every message unless overridden.
+func resolvePreference(channelID, channelType string, override *NotificationPreference, channelDefault string, defaults Defaults) EffectivePreference {
+	eff := EffectivePreference{
+		ChannelID:          channelID,
+		NotifyLevel:        defaults.NotifyLevel,
+		EmailEnabled:       defaults.EmailEnabled,
+		PushEnabled:        defaults.PushEnabled,
+		MobileOnlyWhenIdle: defaults.MobileOnlyWhenIdle,
+	}
+	if channelType == "dm" || channelType == "group_dm" {
+		eff.NotifyLevel = NotifyAll
+	} else if channelDefault != "" {
+		eff.NotifyLevel = channelDefault
+	}
+	if override != nil {
+		eff.Overridden = true
+		eff.NotifyLevel = override.NotifyLevel
+		eff.EmailEnabled = override.EmailEnabled
+		if override.PushEnabled != nil {
+			eff.PushEnabled = *override.PushEnabled
+		}
+	}
+	return eff
+}
+
+// nullDefaults scans a LEFT JOINed defaults row
+type nullDefaults struct {
+	notifyLevel        sql.NullString
+	emailEnabled       sql.NullBool
+	pushEnabled        sql.NullBool
+	mobileOnlyWhenIdle sql.NullBool
+}
+
+func (n *nullDefaults) dest() []any {
+	return []any{&n.notifyLevel, &n.emailEnabled, &n.pushEnabled, &n.mobileOnlyWhenIdle}
+}
+
+func (n *nullDefaults) get() (Defaults, bool) {
+	if !n.notifyLevel.Valid {
+		return Defaults{}, false
+	}
+	return Defaults{
+		NotifyLevel:        n.notifyLevel.String,
+		EmailEnabled:       n.emailEnabled.Bool,
+		PushEnabled:        n.pushEnabled.Bool,
+		MobileOnlyWhenIdle: n.mobileOnlyWhenIdle.Bool,
+	}, true
+}
+
+// pickDefaults returns the member's defaults if set, then the workspace's,
+// then the built-in ones. custom reports whether the member's were used.
+func pickDefaults(member, workspace *nullDefaults) (defaults Defaults, custom bool) {
+	if d, ok := member.get(); ok {
+		return d, true
+	}
+	if d, ok := workspace.get(); ok {
+		return d, false
+	}
+	return BuiltInDefaults, false
+}
+
+const effectiveSelect = `
+	SELECT c.id, c.type,
+		np.notify_level, np.email_enabled, np.push_enabled,
+		cnd.notify_level,
+		und.notify_level, und.email_enabled, und.push_enabled, und.mobile_only_when_idle,
+		wnd.notify_level, wnd.email_enabled, wnd.push_enabled, wnd.mobile_only_when_idle
+	FROM channels c
+	LEFT JOIN notification_preferences np ON np.channel_id = c.id AND np.user_id = ?
+	LEFT JOIN channel_notification_defaults cnd ON cnd.channel_id = c.id
+	LEFT JOIN user_notification_defaults und ON und.workspace_id = c.workspace_id AND und.user_id = ?
+	LEFT JOIN workspace_notification_defaults wnd ON wnd.workspace_id = c.workspace_id
+`
+
+func scanEffective(row interface{ Scan(...any) error }) (*EffectivePreference, error) {
+	var channelID, channelType string
+	var level, channelDefault sql.NullString
+	var email, push sql.NullBool
+	var member, workspace nullDefaults
+
+	dest := []any{&channelID, &channelType, &level, &email, &push, &channelDefault}
+	dest = append(dest, member.dest()...)
+	dest = append(dest, workspace.dest()...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	var override *NotificationPreference
+	if level.Valid {
+		override = &NotificationPreference{NotifyLevel: level.String, EmailEnabled: email.Bool}
+		if push.Valid {
+			override.PushEnabled = &push.Bool
+		}
+	}
+	defaults, _ := pickDefaults(&member, &workspace)
+	eff := resolvePreference(channelID, channelType, override, channelDefault.String, defaults)
+	return &eff, nil
+}
+
+// GetEffective returns the preferences that apply to a user in a channel.
+func (r *PreferencesRepository) GetEffective(ctx context.Context, userID, channelID string) (*EffectivePreference, error) {
+	return scanEffective(r.db.QueryRowContext(ctx, effectiveSelect+`WHERE c.id = ?`, userID, userID, channelID))
+}
+
+// ListEffective returns the preferences that apply to a user in each channel
+// they belong to in a workspace.
+func (r *PreferencesRepository) ListEffective(ctx context.Context, userID, workspaceID string) ([]EffectivePreference, error) {
+	rows, err := r.db.QueryContext(ctx, effectiveSelect+`
+		JOIN channel_memberships cm ON cm.channel_id = c.id AND cm.user_id = ?
+		WHERE c.workspace_id = ?
+		ORDER BY c.id
+	`, userID, userID, userID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prefs []EffectivePreference
+	for rows.Next() {
+		eff, err := scanEffective(rows)
+		if err != nil {
+			return nil, err
+		}
+		prefs = append(prefs, *eff)
+	}
+	return prefs, rows.Err()
+}
+
+// GetMemberDefaults returns the defaults a member's channels inherit, and
+// whether they are the member's own rather than the workspace's.
+func (r *PreferencesRepository) GetMemberDefaults(ctx context.Context, userID, workspaceID string) (Defaults, bool, error) {
+	var member, workspace nullDefaults
+	dest := append(member.dest(), workspace.dest()...)
+	err := r.db.QueryRowContext(ctx, `
+		SELECT und.notify_level, und.email_enabled, und.push_enabled, und.mobile_only_when_idle,
+			wnd.notify_level, wnd.email_enabled, wnd.push_enabled, wnd.mobile_only_when_idle
+		FROM workspaces w
+		LEFT JOIN user_notification_defaults und ON und.workspace_id = w.id AND und.user_id = ?
+		LEFT JOIN workspace_notification_defaults wnd ON wnd.workspace_id = w.id
+		WHERE w.id = ?
+	`, userID, workspaceID).Scan(dest...)
+	if err != nil {
+		return Defaults{}, false, err
+	}
+	defaults, custom := pickDefaults(&member, &workspace)
+	return defaults, custom, nil
+}
+
+// SetMemberDefaults sets a member's own defaults for a workspace
+func (r *PreferencesRepository) SetMemberDefaults(ctx context.Context, userID, workspaceID string, d Defaults) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO user_notification_defaults (user_id, workspace_id, notify_level, email_enabled, push_enabled, mobile_only_when_idle, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id, workspace_id) DO UPDATE SET
+			notify_level = excluded.notify_level,
+			email_enabled = excluded.email_enabled,
+			push_enabled = excluded.push_enabled,
+			mobile_only_when_idle = excluded.mobile_only_when_idle,
+			updated_at = excluded.updated_at
+	`, userID, workspaceID, d.NotifyLevel, d.EmailEnabled, d.PushEnabled, d.MobileOnlyWhenIdle,
+		time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// DeleteMemberDefaults removes a member's own defaults, so they follow the
+// workspace's again
+func (r *PreferencesRepository) DeleteMemberDefaults(ctx context.Context, userID, workspaceID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM user_notification_defaults WHERE user_id = ? AND workspace_id = ?
+	`, userID, workspaceID)
+	return err
+}
+
+// GetWorkspaceDefaults returns the defaults for members of a workspace who
+// haven't set their own
+func (r *PreferencesRepository) GetWorkspaceDefaults(ctx context.Context, workspaceID string) (Defaults, error) {
+	var d nullDefaults
+	err := r.db.QueryRowContext(ctx, `
+		SELECT notify_level, email_enabled, push_enabled, mobile_only_when_idle
+		FROM workspace_notification_defaults
+		WHERE workspace_id = ?
+	`, workspaceID).Scan(d.dest()...)
+	if err == sql.ErrNoRows {
+		return BuiltInDefaults, nil
+	}
+	if err != nil {
+		return Defaults{}, err
+	}
+	defaults, _ := d.get()
+	return defaults, nil
+}
+
+// SetWorkspaceDefaults sets the defaults for members of a workspace
+func (r *PreferencesRepository) SetWorkspaceDefaults(ctx context.Context, workspaceID string, d Defaults) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO workspace_notification_defaults (workspace_id, notify_level, email_enabled, push_enabled, mobile_only_when_idle, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(workspace_id) DO UPDATE SET
+			notify_level = excluded.notify_level,
+			email_enabled = excluded.email_enabled,
+			push_enabled = excluded.push_enabled,
+			mobile_only_when_idle = excluded.mobile_only_when_idle,
+			updated_at = excluded.updated_at
+	`, workspaceID, d.NotifyLevel, d.EmailEnabled, d.PushEnabled, d.MobileOnlyWhenIdle,
+		time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// GetChannelDefault returns the notify level a channel's members inherit, or
+// "" if the channel has none
+func (r *PreferencesRepository) GetChannelDefault(ctx context.Context, channelID string) (string, error) {
+	var level string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT notify_level FROM channel_notification_defaults WHERE channel_id = ?
+	`, channelID).Scan(&level)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return level, err
+}
+
+// SetChannelDefault sets the notify level a channel's members inherit
+func (r *PreferencesRepository) SetChannelDefault(ctx context.Context, channelID, notifyLevel string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO channel_notification_defaults (channel_id, notify_level, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(channel_id) DO UPDATE SET
+			notify_level = excluded.notify_level,
+			updated_at = excluded.updated_at
+	`, channelID, notifyLevel, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// DeleteChannelDefault removes a channel's default notify level
+func (r *PreferencesRepository) DeleteChannelDefault(ctx context.Context, channelID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		DELETE FROM channel_notification_defaults WHERE channel_id = ?
+	`, channelID)
+	return err
+}
@@ -0,0 +1,175 @@
+package notification
+
+import (
+	"context"
+	"testing"
+
+	"github.com/enzyme/server/internal/testutil"
+)
+
+func TestResolvePreference(t *testing.T) {
+	off := false
+	defaults := Defaults{NotifyLevel: NotifyNone, EmailEnabled: false, PushEnabled: true, MobileOnlyWhenIdle: false}
+
+	tests := []struct {
+		name           string
+		channelType    string
+		override       *NotificationPreference
+		channelDefault string
+		wantLevel      string
+		wantEmail      bool
+		wantPush       bool
+		wantOverridden bool
+	}{
+		{
+			name:        "inherits defaults",
+			channelType: "public",
+			wantLevel:   NotifyNone,
+			wantPush:    true,
+		},
+		{
+			name:           "channel default beats member defaults",
+			channelType:    "public",
+			channelDefault: NotifyAll,
+			wantLevel:      NotifyAll,
+			wantPush:       true,
+		},
+		{
+			name:           "override beats channel default",
+			channelType:    "public",
+			override:       &NotificationPreference{NotifyLevel: NotifyMentions, EmailEnabled: true},
+			channelDefault: NotifyAll,
+			wantLevel:      NotifyMentions,
+			wantEmail:      true,
+			wantPush:       true,
+			wantOverridden: true,
+		},
+		{
+			name:           "override can disable push",
+			channelType:    "private",
+			override:       &NotificationPreference{NotifyLevel: NotifyAll, PushEnabled: &off},
+			wantLevel:      NotifyAll,
+			wantPush:       false,
+			wantOverridden: true,
+		},
+		{
+			name:        "DMs notify on every message",
+			channelType: "dm",
+			wantLevel:   NotifyAll,
+			wantPush:    true,
+		},
+		{
+			name:           "DMs can be muted",
+			channelType:    "group_dm",
+			override:       &NotificationPreference{NotifyLevel: NotifyNone},
+			wantLevel:      NotifyNone,
+			wantPush:       true,
+			wantOverridden: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolvePreference("ch", tt.channelType, tt.override, tt.channelDefault, defaults)
+			if got.NotifyLevel != tt.wantLevel {
+				t.Errorf("NotifyLevel = %q, want %q", got.NotifyLevel, tt.wantLevel)
+			}
+			if got.EmailEnabled != tt.wantEmail {
+				t.Errorf("EmailEnabled = %v, want %v", got.EmailEnabled, tt.wantEmail)
+			}
+			if got.PushEnabled != tt.wantPush {
+				t.Errorf("PushEnabled = %v, want %v", got.PushEnabled, tt.wantPush)
+			}
+			if got.Overridden != tt.wantOverridden {
+				t.Errorf("Overridden = %v, want %v", got.Overridden, tt.wantOverridden)
+			}
+			if got.MobileOnlyWhenIdle {
+				t.Error("MobileOnlyWhenIdle = true, want it inherited from the defaults")
+			}
+		})
+	}
+}
+
+func TestPreferencesRepository_ListEffective(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewPreferencesRepository(db)
+	ctx := context.Background()
+
+	user := testutil.CreateTestUser(t, db, "user@example.com", "User")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "Test WS")
+	general := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", "public")
+	announcements := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "announcements", "public")
+	random := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "random", "public")
+
+	effective := func() map[string]EffectivePreference {
+		t.Helper()
+		prefs, err := repo.ListEffective(ctx, user.ID, ws.ID)
+		if err != nil {
+			t.Fatalf("ListEffective() error = %v", err)
+		}
+		byChannel := make(map[string]EffectivePreference)
+		for _, p := range prefs {
+			byChannel[p.ChannelID] = p
+		}
+		if len(byChannel) != 3 {
+			t.Fatalf("ListEffective() returned %d channels, want 3", len(byChannel))
+		}
+		return byChannel
+	}
+
+	// Built-in defaults
+	if got := effective()[general.ID]; got.NotifyLevel != NotifyMentions || !got.EmailEnabled || !got.PushEnabled || got.Overridden {
+		t.Errorf("general = %+v, want built-in defaults", got)
+	}
+
+	// Workspace defaults, with an admin default for #announcements
+	if err := repo.SetWorkspaceDefaults(ctx, ws.ID, Defaults{NotifyLevel: NotifyNone, EmailEnabled: false, PushEnabled: true}); err != nil {
+		t.Fatalf("SetWorkspaceDefaults() error = %v", err)
+	}
+	if err := repo.SetChannelDefault(ctx, announcements.ID, NotifyAll); err != nil {
+		t.Fatalf("SetChannelDefault() error = %v", err)
+	}
+	prefs := effective()
+	if got := prefs[general.ID]; got.NotifyLevel != NotifyNone || got.EmailEnabled {
+		t.Errorf("general = %+v, want workspace defaults", got)
+	}
+	if got := prefs[announcements.ID]; got.NotifyLevel != NotifyAll || got.EmailEnabled {
+		t.Errorf("announcements = %+v, want channel default level with workspace defaults", got)
+	}
+
+	// The member's own defaults replace the workspace's
+	mine := Defaults{NotifyLevel: NotifyMentions, EmailEnabled: true, PushEnabled: false, MobileOnlyWhenIdle: true}
+	if err := repo.SetMemberDefaults(ctx, user.ID, ws.ID, mine); err != nil {
+		t.Fatalf("SetMemberDefaults() error = %v", err)
+	}
+	if got, custom, err := repo.GetMemberDefaults(ctx, user.ID, ws.ID); err != nil || !custom || got != mine {
+		t.Errorf("GetMemberDefaults() = %+v, %v, %v; want %+v, true, nil", got, custom, err, mine)
+	}
+
+	// A channel override inherits push when it doesn't set it
+	if err := repo.Upsert(ctx, &NotificationPreference{UserID: user.ID, ChannelID: random.ID, NotifyLevel: NotifyAll, EmailEnabled: false}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	prefs = effective()
+	if got := prefs[general.ID]; got.NotifyLevel != NotifyMentions || !got.EmailEnabled || got.PushEnabled {
+		t.Errorf("general = %+v, want member defaults", got)
+	}
+	if got := prefs[announcements.ID]; got.NotifyLevel != NotifyAll {
+		t.Errorf("announcements NotifyLevel = %q, want channel default %q", got.NotifyLevel, NotifyAll)
+	}
+	if got := prefs[random.ID]; got.NotifyLevel != NotifyAll || got.EmailEnabled || got.PushEnabled || !got.Overridden {
+		t.Errorf("random = %+v, want override with inherited push", got)
+	}
+
+	// Resetting the member's defaults falls back to the workspace's
+	if err := repo.DeleteMemberDefaults(ctx, user.ID, ws.ID); err != nil {
+		t.Fatalf("DeleteMemberDefaults() error = %v", err)
+	}
+	got, err := repo.GetEffective(ctx, user.ID, general.ID)
+	if err != nil {
+		t.Fatalf("GetEffective() error = %v", err)
+	}
+	if got.NotifyLevel != NotifyNone || !got.PushEnabled {
+		t.Errorf("general = %+v, want workspace defaults", got)
+	}
+}
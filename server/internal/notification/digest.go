@@ -0,0 +1,183 @@
+package notification
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/enzyme/server/internal/email"
+)
+
+// DigestEntry is a pending notification with the details a digest email
+// shows of it
+type DigestEntry struct {
+	PendingNotification
+	WorkspaceName   string
+	ChannelName     string
+	ChannelType     string
+	SenderName      string
+	SenderAvatarURL *string
+	Content         string
+	ThreadParentID  *string
+}
+
+// GetDigestEntries returns the details of pending notifications in message
+// order. Notifications of messages deleted since are left out.
+func (r *PendingRepository) GetDigestEntries(ctx context.Context, ids []string) ([]DigestEntry, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT pn.id, pn.user_id, pn.workspace_id, pn.channel_id, pn.message_id, pn.notification_type,
+			w.name, c.name, c.type, COALESCE(u.display_name, m.bot_name, ''), u.avatar_url, m.content, m.thread_parent_id
+		FROM pending_notifications pn
+		JOIN messages m ON m.id = pn.message_id AND m.deleted_at IS NULL
+		JOIN channels c ON c.id = pn.channel_id
+		JOIN workspaces w ON w.id = pn.workspace_id
+		LEFT JOIN users u ON u.id = m.user_id
+		WHERE pn.id IN (?` + strings.Repeat(",?", len(ids)-1) + `)
+		ORDER BY m.id`
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []DigestEntry
+	for rows.Next() {
+		var e DigestEntry
+		if err := rows.Scan(&e.ID, &e.UserID, &e.WorkspaceID, &e.ChannelID, &e.MessageID, &e.NotificationType,
+			&e.WorkspaceName, &e.ChannelName, &e.ChannelType, &e.SenderName, &e.SenderAvatarURL, &e.Content, &e.ThreadParentID); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// GetMentionNames returns the display names of the users and the names of
+// the channels mentioned in the given message contents, keyed by ID.
+func (r *PendingRepository) GetMentionNames(ctx context.Context, contents []string) (users, channels map[string]string, err error) {
+	var userIDs, channelIDs []string
+	for _, content := range contents {
+		for _, m := range mrkdwnUserMention.FindAllStringSubmatch(content, -1) {
+			userIDs = append(userIDs, strings.TrimSpace(m[1]))
+		}
+		for _, m := range mrkdwnChannelMention.FindAllStringSubmatch(content, -1) {
+			channelIDs = append(channelIDs, strings.TrimSpace(m[1]))
+		}
+	}
+
+	if users, err = r.namesByID(ctx, `SELECT id, display_name FROM users WHERE id IN `, userIDs); err != nil {
+		return nil, nil, err
+	}
+	if channels, err = r.namesByID(ctx, `SELECT id, name FROM channels WHERE id IN `, channelIDs); err != nil {
+		return nil, nil, err
+	}
+	return users, channels, nil
+}
+
+// namesByID runs a query selecting (id, name) rows for the given IDs
+func (r *PendingRepository) namesByID(ctx context.Context, query string, ids []string) (map[string]string, error) {
+	names := make(map[string]string)
+	if len(ids) == 0 {
+		return names, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	rows, err := r.db.QueryContext(ctx, query+`(?`+strings.Repeat(",?", len(ids)-1)+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+	return names, rows.Err()
+}
+
+// buildDigest groups digest entries by workspace, channel and thread, in the
+// order each first appears. Links are built from publicURL, and replyTo
+// returns the address for replying to a message by email.
+func buildDigest(entries []DigestEntry, users, channels map[string]string, publicURL string, replyTo func(messageID string) string) []email.DigestWorkspace {
+	var workspaces []email.DigestWorkspace
+	workspaceIdx := make(map[string]int)
+	channelIdx := make(map[string]int)
+	threadIdx := make(map[string]int)
+
+	for _, e := range entries {
+		wi, ok := workspaceIdx[e.WorkspaceID]
+		if !ok {
+			wi = len(workspaces)
+			workspaceIdx[e.WorkspaceID] = wi
+			workspaces = append(workspaces, email.DigestWorkspace{
+				Name: e.WorkspaceName,
+				URL:  publicURL + "/workspaces/" + url.PathEscape(e.WorkspaceID),
+			})
+		}
+		ws := &workspaces[wi]
+
+		channelURL := ws.URL + "/channels/" + url.PathEscape(e.ChannelID)
+		ci, ok := channelIdx[e.ChannelID]
+		if !ok {
+			ci = len(ws.Channels)
+			channelIdx[e.ChannelID] = ci
+			name := "#" + e.ChannelName
+			if e.ChannelType == "dm" || e.ChannelType == "group_dm" {
+				name = "Direct message"
+			}
+			ws.Channels = append(ws.Channels, email.DigestChannel{Name: name, URL: channelURL})
+		}
+		ch := &ws.Channels[ci]
+
+		senderName := e.SenderName
+		if senderName == "" {
+			senderName = "Unknown user"
+		}
+		avatarURL := ""
+		if e.SenderAvatarURL != nil {
+			avatarURL = *e.SenderAvatarURL
+			if strings.HasPrefix(avatarURL, "/") {
+				avatarURL = publicURL + avatarURL
+			}
+		}
+		msg := email.DigestMessage{
+			SenderName: senderName,
+			AvatarURL:  avatarURL,
+			Text:       truncatePreview(RenderPlainText(e.Content, users, channels), 500),
+			Type:       e.NotificationType,
+			ReplyTo:    replyTo(e.MessageID),
+		}
+
+		if e.ThreadParentID == nil {
+			msg.URL = channelURL + "?" + url.Values{"msg": {e.MessageID}}.Encode()
+			ch.Messages = append(ch.Messages, msg)
+			continue
+		}
+
+		threadURL := channelURL + "?" + url.Values{"thread": {*e.ThreadParentID}}.Encode()
+		msg.URL = threadURL + "&" + url.Values{"msg": {e.MessageID}}.Encode()
+		ti, ok := threadIdx[*e.ThreadParentID]
+		if !ok {
+			ti = len(ch.Threads)
+			threadIdx[*e.ThreadParentID] = ti
+			ch.Threads = append(ch.Threads, email.DigestThread{URL: threadURL})
+		}
+		ch.Threads[ti].Messages = append(ch.Threads[ti].Messages, msg)
+	}
+	return workspaces
+}
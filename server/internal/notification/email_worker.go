@@ -5,16 +5,20 @@ import (
 	"log/slog"
 
 	"github.com/enzyme/server/internal/email"
+	"github.com/enzyme/server/internal/signing"
 	"github.com/enzyme/server/internal/sse"
 	"github.com/enzyme/server/internal/user"
 )
 
-// EmailWorker processes pending notifications and sends digest emails
+// EmailWorker processes pending notifications and sends digest emails.
+// How often a user gets one follows their digest frequency, through the
+// send time of their pending notifications.
 type EmailWorker struct {
 	pendingRepo  *PendingRepository
 	userRepo     *user.Repository
 	emailService *email.Service
 	hub          *sse.Hub
+	signer       *signing.Signer
 }
 
 // NewEmailWorker creates a new email notification worker
@@ -23,12 +27,14 @@ func NewEmailWorker(
 	userRepo *user.Repository,
 	emailService *email.Service,
 	hub *sse.Hub,
+	signer *signing.Signer,
 ) *EmailWorker {
 	return &EmailWorker{
 		pendingRepo:  pendingRepo,
 		userRepo:     userRepo,
 		emailService: emailService,
 		hub:          hub,
+		signer:       signer,
 	}
 }
 
@@ -84,55 +90,59 @@ func (w *EmailWorker) ProcessPending(ctx context.Context) error {
 			continue
 		}
 
-		// Group notifications by workspace for better email structure
-		byWorkspace := make(map[string][]PendingNotification)
+		// Skip workspaces the user came online in; they've seen those
+		var ready, seen []string
 		for _, n := range notifications {
-			byWorkspace[n.WorkspaceID] = append(byWorkspace[n.WorkspaceID], n)
+			if w.hub.IsUserOnline(n.WorkspaceID, userID) {
+				seen = append(seen, n.ID)
+			} else {
+				ready = append(ready, n.ID)
+			}
+		}
+		_ = w.pendingRepo.DeleteByIDs(ctx, seen)
+		if len(ready) == 0 {
+			continue
 		}
 
-		// Send digest email for each workspace
-		for workspaceID, wsNotifications := range byWorkspace {
-			// Skip if user came online in this workspace
-			if w.hub.IsUserOnline(workspaceID, userID) {
-				ids := make([]string, len(wsNotifications))
-				for i, n := range wsNotifications {
-					ids[i] = n.ID
-				}
-				_ = w.pendingRepo.DeleteByIDs(ctx, ids)
-				continue
-			}
+		if err := w.sendDigest(ctx, usr.ID, usr.Email, ready); err != nil {
+			slog.Error("error sending notification digest", "component", "notification", "to", usr.Email, "error", err)
+			continue
+		}
 
-			// Build digest data
-			items := make([]email.NotificationDigestItem, len(wsNotifications))
-			for i, n := range wsNotifications {
-				items[i] = email.NotificationDigestItem{
-					ChannelName: "", // Would need channel name from DB
-					SenderName:  "", // Would need sender name from DB
-					Preview:     "", // Would need message content from DB
-					Type:        n.NotificationType,
-				}
-			}
+		// Delete processed notifications
+		_ = w.pendingRepo.DeleteByIDs(ctx, ready)
+	}
+	return nil
+}
 
-			// Send email
-			err := w.emailService.SendNotificationDigest(ctx, usr.Email, email.NotificationDigestData{
-				WorkspaceName: "Enzyme", // Would need workspace name
-				Items:         items,
-				WorkspaceURL:  w.emailService.GetPublicURL(),
-			})
-			if err != nil {
-				slog.Error("error sending notification digest", "component", "notification", "to", usr.Email, "error", err)
-				continue
-			}
+// sendDigest sends one email for a user's pending notifications, grouped by
+// workspace, channel and thread.
+func (w *EmailWorker) sendDigest(ctx context.Context, userID, to string, ids []string) error {
+	entries, err := w.pendingRepo.GetDigestEntries(ctx, ids)
+	if err != nil {
+		return err
+	}
+	// Every message was deleted since
+	if len(entries) == 0 {
+		return nil
+	}
 
-			// Delete processed notifications
-			ids := make([]string, len(wsNotifications))
-			for i, n := range wsNotifications {
-				ids[i] = n.ID
-			}
-			_ = w.pendingRepo.DeleteByIDs(ctx, ids)
-		}
+	contents := make([]string, len(entries))
+	for i, e := range entries {
+		contents[i] = e.Content
 	}
-	return nil
+	users, channels, err := w.pendingRepo.GetMentionNames(ctx, contents)
+	if err != nil {
+		return err
+	}
+
+	replyTo := func(messageID string) string {
+		return w.emailService.ReplyAddress(w.signer.ReplyToken(userID, messageID))
+	}
+	return w.emailService.SendNotificationDigest(ctx, to, email.NotificationDigestData{
+		Workspaces:       buildDigest(entries, users, channels, w.emailService.GetPublicURL(), replyTo),
+		UnsubscribeToken: w.signer.UnsubscribeToken(userID),
+	})
 }
 
 // CancelForUser cancels all pending notifications for a user
@@ -0,0 +1,183 @@
+package notification
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/email"
+	"github.com/enzyme/server/internal/signing"
+	"github.com/enzyme/server/internal/sse"
+	"github.com/enzyme/server/internal/testutil"
+	"github.com/enzyme/server/internal/user"
+)
+
+type recordingEmailSender struct {
+	mu   sync.Mutex
+	sent []*email.Message
+}
+
+func (r *recordingEmailSender) Send(ctx context.Context, msg *email.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, msg)
+	return nil
+}
+
+func TestProcessPending_SendsGroupedDigest(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+
+	recipient := testutil.CreateTestUser(t, db, "recipient@example.com", "Recipient")
+	sender := testutil.CreateTestUser(t, db, "sender@example.com", "Grace Hopper")
+	if _, err := db.Exec(`UPDATE users SET email_verified_at = ? WHERE id = ?`,
+		time.Now().UTC().Format(time.RFC3339), recipient.ID); err != nil {
+		t.Fatalf("verifying recipient: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE users SET avatar_url = ? WHERE id = ?`, "/api/avatars/grace.png", sender.ID); err != nil {
+		t.Fatalf("setting avatar: %v", err)
+	}
+	ws := testutil.CreateTestWorkspace(t, db, sender.ID, "Acme")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, sender.ID, "ops", "public")
+
+	mention := testutil.CreateTestMessage(t, db, ch.ID, sender.ID, "hey <@"+recipient.ID+">, look at <https://example.com|this>")
+	parent := testutil.CreateTestMessage(t, db, ch.ID, sender.ID, "thread start")
+	reply := testutil.CreateTestMessage(t, db, ch.ID, sender.ID, "<!channel> reply")
+	if _, err := db.Exec(`UPDATE messages SET thread_parent_id = ? WHERE id = ?`, parent.ID, reply.ID); err != nil {
+		t.Fatalf("making reply: %v", err)
+	}
+
+	pending := NewPendingRepository(db)
+	due := time.Now().UTC().Add(-time.Minute)
+	for _, n := range []*PendingNotification{
+		{UserID: recipient.ID, WorkspaceID: ws.ID, ChannelID: ch.ID, MessageID: mention.ID, NotificationType: TypeMention, SendAfter: due},
+		{UserID: recipient.ID, WorkspaceID: ws.ID, ChannelID: ch.ID, MessageID: reply.ID, NotificationType: TypeThreadReply, SendAfter: due},
+	} {
+		if err := pending.Create(ctx, n); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	recorder := &recordingEmailSender{}
+	signer := signing.NewSigner("test-secret")
+	worker := NewEmailWorker(pending, user.NewRepository(db), email.NewTestServiceWithSender(true, "https://chat.example.com", recorder),
+		sse.NewHub(db, time.Hour), signer)
+	if err := worker.ProcessPending(ctx); err != nil {
+		t.Fatalf("ProcessPending() error = %v", err)
+	}
+
+	if len(recorder.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(recorder.sent))
+	}
+	msg := recorder.sent[0]
+	if msg.Subject != "2 new notifications in Acme" {
+		t.Errorf("subject = %q", msg.Subject)
+	}
+
+	token := signer.UnsubscribeToken(recipient.ID)
+	if want := "<https://chat.example.com/api/email/unsubscribe?token=" + token + ">"; msg.Headers["List-Unsubscribe"] != want {
+		t.Errorf("List-Unsubscribe = %q, want %q", msg.Headers["List-Unsubscribe"], want)
+	}
+	if msg.Headers["List-Unsubscribe-Post"] != "List-Unsubscribe=One-Click" {
+		t.Errorf("List-Unsubscribe-Post = %q", msg.Headers["List-Unsubscribe-Post"])
+	}
+
+	channelURL := "https://chat.example.com/workspaces/" + ws.ID + "/channels/" + ch.ID
+	for _, want := range []string{
+		"hey @Recipient, look at this",
+		"@channel reply",
+		channelURL + "?msg=" + mention.ID,
+		channelURL + "?thread=" + parent.ID + "&msg=" + reply.ID,
+		"https://chat.example.com/unsubscribe?token=" + token,
+	} {
+		if !strings.Contains(msg.TextBody, want) {
+			t.Errorf("text body is missing %q:\n%s", want, msg.TextBody)
+		}
+	}
+	for _, want := range []string{
+		`src="https://chat.example.com/api/avatars/grace.png"`,
+		"Grace Hopper",
+		"In a thread",
+	} {
+		if !strings.Contains(msg.HTMLBody, want) {
+			t.Errorf("HTML body is missing %q", want)
+		}
+	}
+
+	count, err := pending.CountForUser(ctx, recipient.ID)
+	if err != nil {
+		t.Fatalf("CountForUser() error = %v", err)
+	}
+	if count != 0 {
+		t.Errorf("%d notifications still pending, want 0", count)
+	}
+}
+
+func TestProcessPending_ReplyAddress(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+
+	recipient := testutil.CreateTestUser(t, db, "recipient@example.com", "Recipient")
+	sender := testutil.CreateTestUser(t, db, "sender@example.com", "Sender")
+	if _, err := db.Exec(`UPDATE users SET email_verified_at = ? WHERE id = ?`,
+		time.Now().UTC().Format(time.RFC3339), recipient.ID); err != nil {
+		t.Fatalf("verifying recipient: %v", err)
+	}
+	ws := testutil.CreateTestWorkspace(t, db, sender.ID, "Acme")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, sender.ID, "ops", "public")
+	msg := testutil.CreateTestMessage(t, db, ch.ID, sender.ID, "hey <@"+recipient.ID+">")
+
+	pending := NewPendingRepository(db)
+	if err := pending.Create(ctx, &PendingNotification{UserID: recipient.ID, WorkspaceID: ws.ID, ChannelID: ch.ID, MessageID: msg.ID,
+		NotificationType: TypeMention, SendAfter: time.Now().UTC().Add(-time.Minute)}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	recorder := &recordingEmailSender{}
+	signer := signing.NewSigner("test-secret")
+	emailService := email.NewTestServiceWithSender(true, "https://chat.example.com", recorder).WithInboundDomain("reply.example.com")
+	worker := NewEmailWorker(pending, user.NewRepository(db), emailService, sse.NewHub(db, time.Hour), signer)
+	if err := worker.ProcessPending(ctx); err != nil {
+		t.Fatalf("ProcessPending() error = %v", err)
+	}
+
+	if len(recorder.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(recorder.sent))
+	}
+	want := "reply+" + signer.ReplyToken(recipient.ID, msg.ID) + "@reply.example.com"
+	if got := recorder.sent[0].Headers["Reply-To"]; got != want {
+		t.Errorf("Reply-To = %q, want %q", got, want)
+	}
+	// html/template escapes the "+" of the address as &#43;
+	if !strings.Contains(recorder.sent[0].HTMLBody, `href="mailto:reply&#43;`+signer.ReplyToken(recipient.ID, msg.ID)+`@reply.example.com"`) {
+		t.Error("HTML body is missing the reply by email link")
+	}
+	if !strings.Contains(recorder.sent[0].TextBody, "Reply by email: "+want) {
+		t.Error("text body is missing the reply address")
+	}
+}
+
+func TestSettings_NextDigest(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 20, 0, 0, time.UTC)
+	berlin := &Schedule{TimeZone: "Europe/Berlin", Windows: []ScheduleWindow{{Day: time.Monday, Start: "09:00", End: "17:00"}}}
+
+	tests := []struct {
+		name     string
+		settings Settings
+		want     time.Time
+	}{
+		{"immediate", Settings{EmailDigest: DigestImmediate}, now.Add(5 * time.Minute)},
+		{"unset", Settings{}, now.Add(5 * time.Minute)},
+		{"hourly", Settings{EmailDigest: DigestHourly}, time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)},
+		{"daily in UTC", Settings{EmailDigest: DigestDaily}, time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC)},
+		{"daily in schedule zone", Settings{EmailDigest: DigestDaily, Schedule: berlin}, time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC)},
+		{"never", Settings{EmailDigest: DigestNever}, time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := tt.settings.NextDigest(now, 5*time.Minute); !got.Equal(tt.want) {
+			t.Errorf("%s: NextDigest() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/enzyme/server/internal/pushnotification"
+	"github.com/oklog/ulid/v2"
+)
+
+// HeldNotification is a push notification held back until the recipient's
+// schedule opens. If no device is reached on release, it falls back to email.
+type HeldNotification struct {
+	ID               string
+	UserID           string
+	ChannelType      string
+	NotificationType string
+	Push             pushnotification.NotificationData
+	CreatedAt        time.Time
+	ReleaseAt        time.Time
+}
+
+// HeldRepository handles held notification persistence
+type HeldRepository struct {
+	db *sql.DB
+}
+
+// NewHeldRepository creates a new held notifications repository
+func NewHeldRepository(db *sql.DB) *HeldRepository {
+	return &HeldRepository{db: db}
+}
+
+// Create holds a notification. A message already held for the user is kept.
+func (r *HeldRepository) Create(ctx context.Context, n *HeldNotification) error {
+	n.ID = ulid.Make().String()
+	n.CreatedAt = time.Now().UTC()
+
+	var threadParentID *string
+	if n.Push.ThreadParentID != "" {
+		threadParentID = &n.Push.ThreadParentID
+	}
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO held_notifications (id, user_id, workspace_id, channel_id, channel_type, channel_name,
+			message_id, thread_parent_id, notification_type, title, body, created_at, release_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(user_id, message_id) DO NOTHING
+	`, n.ID, n.UserID, n.Push.WorkspaceID, n.Push.ChannelID, n.ChannelType, n.Push.ChannelName,
+		n.Push.MessageID, threadParentID, n.NotificationType, n.Push.Title, n.Push.Body,
+		n.CreatedAt.Format(time.RFC3339), n.ReleaseAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// ListDue returns the notifications whose release time has passed, oldest first.
+func (r *HeldRepository) ListDue(ctx context.Context, now time.Time) ([]HeldNotification, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, workspace_id, channel_id, channel_type, channel_name, message_id,
+			thread_parent_id, notification_type, title, body, created_at, release_at
+		FROM held_notifications
+		WHERE release_at <= ?
+		ORDER BY created_at
+	`, now.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []HeldNotification
+	for rows.Next() {
+		var n HeldNotification
+		var threadParentID sql.NullString
+		var createdAt, releaseAt string
+
+		err := rows.Scan(&n.ID, &n.UserID, &n.Push.WorkspaceID, &n.Push.ChannelID, &n.ChannelType,
+			&n.Push.ChannelName, &n.Push.MessageID, &threadParentID, &n.NotificationType,
+			&n.Push.Title, &n.Push.Body, &createdAt, &releaseAt)
+		if err != nil {
+			return nil, err
+		}
+
+		n.Push.ThreadParentID = threadParentID.String
+		n.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		n.ReleaseAt, _ = time.Parse(time.RFC3339, releaseAt)
+		notifications = append(notifications, n)
+	}
+
+	return notifications, rows.Err()
+}
+
+// RescheduleForUser moves the release time of all of a user's held
+// notifications, after their schedule changes.
+func (r *HeldRepository) RescheduleForUser(ctx context.Context, userID string, releaseAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE held_notifications SET release_at = ? WHERE user_id = ?
+	`, releaseAt.UTC().Format(time.RFC3339), userID)
+	return err
+}
+
+// DeleteByIDs removes held notifications by their IDs
+func (r *HeldRepository) DeleteByIDs(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := "DELETE FROM held_notifications WHERE id IN (?"
+	args := make([]interface{}, len(ids))
+	args[0] = ids[0]
+	for i := 1; i < len(ids); i++ {
+		query += ",?"
+		args[i] = ids[i]
+	}
+	query += ")"
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
@@ -0,0 +1,101 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits on a user's highlight keywords
+const (
+	MaxHighlightKeywords   = 50
+	MaxHighlightKeywordLen = 64
+)
+
+var ErrInvalidKeyword = errors.New("invalid highlight keyword")
+
+// mentionMarkup matches the mrkdwn mention tokens, so keywords aren't matched
+// against user IDs
+var mentionMarkup = regexp.MustCompile(`<[@!][^>]*>`)
+
+// NormalizeKeywords trims keywords and drops blanks and case-insensitive
+// duplicates, keeping the first spelling.
+func NormalizeKeywords(keywords []string) ([]string, error) {
+	result := make([]string, 0, len(keywords))
+	seen := make(map[string]bool)
+	for _, kw := range keywords {
+		kw = strings.TrimSpace(kw)
+		if kw == "" {
+			continue
+		}
+		if utf8.RuneCountInString(kw) > MaxHighlightKeywordLen {
+			return nil, fmt.Errorf("%w: keywords can be at most %d characters", ErrInvalidKeyword, MaxHighlightKeywordLen)
+		}
+		key := strings.ToLower(kw)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, kw)
+	}
+	if len(result) > MaxHighlightKeywords {
+		return nil, fmt.Errorf("%w: at most %d keywords are allowed", ErrInvalidKeyword, MaxHighlightKeywords)
+	}
+	return result, nil
+}
+
+// keywordText is message content prepared for matching highlight keywords,
+// so a message is prepared once however many members have keywords.
+type keywordText string
+
+// newKeywordText prepares content for matching: mention markup is blanked
+// and the rest lower-cased.
+func newKeywordText(content string) keywordText {
+	return keywordText(strings.ToLower(mentionMarkup.ReplaceAllString(content, " ")))
+}
+
+// Matches reports whether any keyword appears in the text as a whole word or
+// phrase, ignoring case. Letters, digits and underscores of any script are
+// word characters.
+func (t keywordText) Matches(keywords []string) bool {
+	text := string(t)
+	for _, kw := range keywords {
+		kw = strings.ToLower(kw)
+		if kw == "" {
+			continue
+		}
+		for offset := 0; ; {
+			i := strings.Index(text[offset:], kw)
+			if i < 0 {
+				break
+			}
+			start, end := offset+i, offset+i+len(kw)
+			before, _ := utf8.DecodeLastRuneInString(text[:start])
+			after, _ := utf8.DecodeRuneInString(text[end:])
+			if !isWordRune(before) && !isWordRune(after) {
+				return true
+			}
+			_, size := utf8.DecodeRuneInString(text[start:])
+			offset = start + size
+		}
+	}
+	return false
+}
+
+// isWordRune reports whether r is part of a word; utf8.RuneError, returned at
+// either end of the text, is not.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// MatchesKeyword reports whether any keyword appears in content as a whole
+// word or phrase, ignoring case.
+func MatchesKeyword(content string, keywords []string) bool {
+	if len(keywords) == 0 || content == "" {
+		return false
+	}
+	return newKeywordText(content).Matches(keywords)
+}
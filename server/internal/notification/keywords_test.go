@@ -0,0 +1,61 @@
+package notification
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMatchesKeyword(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		keywords []string
+		want     bool
+	}{
+		{"exact word", "we have an outage", []string{"outage"}, true},
+		{"case insensitive", "OUTAGE in eu-west", []string{"outage"}, true},
+		{"punctuation boundary", "Is Enzyme down?", []string{"enzyme"}, true},
+		{"phrase", "the Big Launch is today", []string{"big launch"}, true},
+		{"inside a word", "the outages report", []string{"outage"}, false},
+		{"prefix of another keyword", "outages everywhere", []string{"outage", "outages"}, true},
+		{"regexp metacharacters", "we use C++ here", []string{"c++"}, true},
+		{"unicode boundary", "Straße gesperrt", []string{"straße"}, true},
+		{"unicode letters are word characters", "Hauptstraße gesperrt", []string{"straße"}, false},
+		{"not in mention markup", "hey <@outage>", []string{"outage"}, false},
+		{"later occurrence is a whole word", "outages, then an outage", []string{"outage"}, true},
+		{"at the end", "we have an outage", []string{"an outage"}, true},
+		{"underscore is a word character", "outage_report", []string{"outage"}, false},
+		{"digits are word characters", "outage2", []string{"outage"}, false},
+		{"no keywords", "outage", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchesKeyword(tt.content, tt.keywords); got != tt.want {
+				t.Errorf("MatchesKeyword(%q, %q) = %v, want %v", tt.content, tt.keywords, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeKeywords(t *testing.T) {
+	got, err := NormalizeKeywords([]string{" Outage ", "", "outage", "Enzyme"})
+	if err != nil {
+		t.Fatalf("NormalizeKeywords() error = %v", err)
+	}
+	if len(got) != 2 || got[0] != "Outage" || got[1] != "Enzyme" {
+		t.Errorf("NormalizeKeywords() = %q, want [Outage Enzyme]", got)
+	}
+
+	if _, err := NormalizeKeywords([]string{strings.Repeat("a", MaxHighlightKeywordLen+1)}); !errors.Is(err, ErrInvalidKeyword) {
+		t.Errorf("long keyword: error = %v, want ErrInvalidKeyword", err)
+	}
+
+	many := make([]string, MaxHighlightKeywords+1)
+	for i := range many {
+		many[i] = strings.Repeat("k", i+1)
+	}
+	if _, err := NormalizeKeywords(many); !errors.Is(err, ErrInvalidKeyword) {
+		t.Errorf("too many keywords: error = %v, want ErrInvalidKeyword", err)
+	}
+}
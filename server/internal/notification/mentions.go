@@ -139,6 +139,13 @@ func IsSpecialMention(mention string) bool {
 	return mention == MentionChannel || mention == MentionHere || mention == MentionEveryone
 }
 
+// EveryoneReachesWorkspace reports whether @everyone in a channel of the
+// given type notifies every workspace member. In private channels and DMs it
+// only reaches the channel's members, who are the only ones able to read it.
+func EveryoneReachesWorkspace(channelType string) bool {
+	return channelType == "public"
+}
+
 // ResolveHereMentions replaces @here in mentions with the IDs of currently online
 // channel members. Other mentions (including @channel and @everyone) pass through
 // unchanged. The sender is excluded, and user IDs already in the mentions list
@@ -176,3 +183,36 @@ func ResolveHereMentions(mentions []string, channelMemberIDs []string, senderID
 
 	return result
 }
+
+// mrkdwnLink matches <url|text> and <url> links from the rich text editor
+var mrkdwnLink = regexp.MustCompile(`<(https?://[^|>]+)(?:\|([^>]+))?>`)
+
+// mrkdwnChannelMention matches <#channelId> from the rich text editor
+var mrkdwnChannelMention = regexp.MustCompile(`<#([^>]+)>`)
+
+// RenderPlainText renders the mrkdwn mentions and links in message content
+// as they read in the app: <@userId> as @Display Name, <!here> as @here,
+// <#channelId> as #channel-name and links as their text. users and channels
+// map IDs to names; mentions of unknown IDs are rendered generically.
+func RenderPlainText(content string, users, channels map[string]string) string {
+	content = mrkdwnUserMention.ReplaceAllStringFunc(content, func(m string) string {
+		if name, ok := users[strings.TrimSpace(mrkdwnUserMention.FindStringSubmatch(m)[1])]; ok {
+			return "@" + name
+		}
+		return "@unknown-user"
+	})
+	content = mrkdwnChannelMention.ReplaceAllStringFunc(content, func(m string) string {
+		if name, ok := channels[strings.TrimSpace(mrkdwnChannelMention.FindStringSubmatch(m)[1])]; ok {
+			return "#" + name
+		}
+		return "#unknown-channel"
+	})
+	content = mrkdwnSpecialMention.ReplaceAllString(content, "@$1")
+	return mrkdwnLink.ReplaceAllStringFunc(content, func(m string) string {
+		match := mrkdwnLink.FindStringSubmatch(m)
+		if match[2] != "" {
+			return match[2]
+		}
+		return match[1]
+	})
+}
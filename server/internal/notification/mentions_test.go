@@ -264,3 +264,24 @@ func TestResolveHereMentions_PassesThroughOtherSpecialMentions(t *testing.T) {
 		t.Errorf("result[2] = %q, want %q", result[2], MentionEveryone)
 	}
 }
+
+func TestRenderPlainText(t *testing.T) {
+	users := map[string]string{"user123": "Ada Lovelace"}
+	channels := map[string]string{"ch1": "general"}
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"Hello <@user123>!", "Hello @Ada Lovelace!"},
+		{"<!here> and <!channel>, see <#ch1>", "@here and @channel, see #general"},
+		{"cc <@gone> in <#gone>", "cc @unknown-user in #unknown-channel"},
+		{"Docs: <https://example.com|the docs> and <https://example.org>", "Docs: the docs and https://example.org"},
+		{"plain @text stays", "plain @text stays"},
+	}
+	for _, tt := range tests {
+		if got := RenderPlainText(tt.content, users, channels); got != tt.want {
+			t.Errorf("RenderPlainText(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
@@ -16,6 +16,7 @@ const (
 	TypeHere        = "here"
 	TypeEveryone    = "everyone"
 	TypeThreadReply = "thread_reply"
+	TypeKeyword     = "keyword"
 )
 
 // PendingNotification represents a notification queued for email delivery
@@ -72,6 +73,15 @@ func (r *PendingRepository) DeleteForUserInWorkspace(ctx context.Context, userID
 	return err
 }
 
+// RescheduleForUser moves all of a user's pending notifications to be sent
+// at sendAfter
+func (r *PendingRepository) RescheduleForUser(ctx context.Context, userID string, sendAfter time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE pending_notifications SET send_after = ? WHERE user_id = ?
+	`, sendAfter.UTC().Format(time.RFC3339), userID)
+	return err
+}
+
 // GetReadyToSend returns all notifications that are ready to be sent (send_after <= now)
 func (r *PendingRepository) GetReadyToSend(ctx context.Context) ([]PendingNotification, error) {
 	now := time.Now().UTC()
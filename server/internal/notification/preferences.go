@@ -17,6 +17,11 @@ const (
 	NotifyNone     = "none"     // No notifications
 )
 
+// IsValidNotifyLevel reports whether level is one of the notification levels
+func IsValidNotifyLevel(level string) bool {
+	return level == NotifyAll || level == NotifyMentions || level == NotifyNone
+}
+
 // NotificationPreference represents a user's notification settings for a channel
 type NotificationPreference struct {
 	ID           string    `json:"id"`
@@ -24,6 +29,7 @@ type NotificationPreference struct {
 	ChannelID    string    `json:"channel_id"`
 	NotifyLevel  string    `json:"notify_level"`
 	EmailEnabled bool      `json:"email_enabled"`
+	PushEnabled  *bool     `json:"push_enabled"` // nil inherits from the defaults
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
@@ -43,14 +49,15 @@ func NewPreferencesRepository(db *sql.DB) *PreferencesRepository {
 // Get retrieves notification preferences for a user and channel
 func (r *PreferencesRepository) Get(ctx context.Context, userID, channelID string) (*NotificationPreference, error) {
 	var pref NotificationPreference
+	var pushEnabled sql.NullBool
 	var createdAt, updatedAt string
 
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, user_id, channel_id, notify_level, email_enabled, created_at, updated_at
+		SELECT id, user_id, channel_id, notify_level, email_enabled, push_enabled, created_at, updated_at
 		FROM notification_preferences
 		WHERE user_id = ? AND channel_id = ?
 	`, userID, channelID).Scan(
-		&pref.ID, &pref.UserID, &pref.ChannelID, &pref.NotifyLevel, &pref.EmailEnabled,
+		&pref.ID, &pref.UserID, &pref.ChannelID, &pref.NotifyLevel, &pref.EmailEnabled, &pushEnabled,
 		&createdAt, &updatedAt,
 	)
 
@@ -61,6 +68,9 @@ func (r *PreferencesRepository) Get(ctx context.Context, userID, channelID strin
 		return nil, err
 	}
 
+	if pushEnabled.Valid {
+		pref.PushEnabled = &pushEnabled.Bool
+	}
 	if pref.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
 		return nil, fmt.Errorf("parsing created_at: %w", err)
 	}
@@ -71,30 +81,6 @@ func (r *PreferencesRepository) Get(ctx context.Context, userID, channelID strin
 	return &pref, nil
 }
 
-// GetOrDefault retrieves preferences or returns defaults based on channel type
-func (r *PreferencesRepository) GetOrDefault(ctx context.Context, userID, channelID, channelType string) (*NotificationPreference, error) {
-	pref, err := r.Get(ctx, userID, channelID)
-	if err == nil {
-		return pref, nil
-	}
-	if !errors.Is(err, ErrPreferenceNotFound) {
-		return nil, err
-	}
-
-	// Return default preferences based on channel type
-	defaultLevel := NotifyMentions
-	if channelType == "dm" || channelType == "group_dm" {
-		defaultLevel = NotifyAll
-	}
-
-	return &NotificationPreference{
-		UserID:       userID,
-		ChannelID:    channelID,
-		NotifyLevel:  defaultLevel,
-		EmailEnabled: true,
-	}, nil
-}
-
 // Upsert creates or updates notification preferences
 func (r *PreferencesRepository) Upsert(ctx context.Context, pref *NotificationPreference) error {
 	now := time.Now().UTC().Format(time.RFC3339)
@@ -102,14 +88,15 @@ func (r *PreferencesRepository) Upsert(ctx context.Context, pref *NotificationPr
 
 	var createdAt, updatedAt string
 	err := r.db.QueryRowContext(ctx, `
-		INSERT INTO notification_preferences (id, user_id, channel_id, notify_level, email_enabled, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO notification_preferences (id, user_id, channel_id, notify_level, email_enabled, push_enabled, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(user_id, channel_id) DO UPDATE SET
 			notify_level = excluded.notify_level,
 			email_enabled = excluded.email_enabled,
+			push_enabled = excluded.push_enabled,
 			updated_at = excluded.updated_at
 		RETURNING id, user_id, channel_id, notify_level, email_enabled, created_at, updated_at
-	`, id, pref.UserID, pref.ChannelID, pref.NotifyLevel, pref.EmailEnabled, now, now).Scan(
+	`, id, pref.UserID, pref.ChannelID, pref.NotifyLevel, pref.EmailEnabled, pref.PushEnabled, now, now).Scan(
 		&pref.ID, &pref.UserID, &pref.ChannelID, &pref.NotifyLevel, &pref.EmailEnabled,
 		&createdAt, &updatedAt,
 	)
@@ -138,7 +125,7 @@ func (r *PreferencesRepository) Delete(ctx context.Context, userID, channelID st
 // ListForUser returns all notification preferences for a user
 func (r *PreferencesRepository) ListForUser(ctx context.Context, userID string) ([]NotificationPreference, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, user_id, channel_id, notify_level, email_enabled, created_at, updated_at
+		SELECT id, user_id, channel_id, notify_level, email_enabled, push_enabled, created_at, updated_at
 		FROM notification_preferences
 		WHERE user_id = ?
 	`, userID)
@@ -150,14 +137,19 @@ func (r *PreferencesRepository) ListForUser(ctx context.Context, userID string)
 	var prefs []NotificationPreference
 	for rows.Next() {
 		var pref NotificationPreference
+		var pushEnabled sql.NullBool
 		var createdAt, updatedAt string
 
 		err := rows.Scan(&pref.ID, &pref.UserID, &pref.ChannelID, &pref.NotifyLevel, &pref.EmailEnabled,
-			&createdAt, &updatedAt)
+			&pushEnabled, &createdAt, &updatedAt)
 		if err != nil {
 			return nil, err
 		}
 
+		if pushEnabled.Valid {
+			pref.PushEnabled = &pushEnabled.Bool
+		}
+
 		if pref.CreatedAt, err = time.Parse(time.RFC3339, createdAt); err != nil {
 			return nil, fmt.Errorf("parsing created_at: %w", err)
 		}
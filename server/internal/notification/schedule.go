@@ -0,0 +1,115 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata" // schedules name IANA zones; don't depend on the host's zoneinfo
+)
+
+// MaxScheduleWindows bounds the number of windows in a schedule.
+const MaxScheduleWindows = 28
+
+var ErrInvalidSchedule = errors.New("invalid notification schedule")
+
+// Schedule is a user's working hours. Push and email notifications that
+// arrive outside every window are held until the next one opens; in-app
+// notifications are unaffected.
+type Schedule struct {
+	TimeZone string           `json:"time_zone"`
+	Windows  []ScheduleWindow `json:"windows"`
+}
+
+// ScheduleWindow is a span of one weekday in the schedule's time zone. Start
+// and End are "HH:MM"; End may be "24:00" for the end of the day.
+type ScheduleWindow struct {
+	Day   time.Weekday `json:"day"`
+	Start string       `json:"start"`
+	End   string       `json:"end"`
+}
+
+// Validate checks the time zone is known and every window is a non-empty
+// span of a single day.
+func (s *Schedule) Validate() error {
+	// LoadLocation maps "" to UTC and "Local" to the server's zone
+	if _, err := time.LoadLocation(s.TimeZone); err != nil || s.TimeZone == "" || s.TimeZone == "Local" {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidSchedule, s.TimeZone)
+	}
+	if len(s.Windows) == 0 {
+		return fmt.Errorf("%w: at least one window is required", ErrInvalidSchedule)
+	}
+	if len(s.Windows) > MaxScheduleWindows {
+		return fmt.Errorf("%w: at most %d windows are allowed", ErrInvalidSchedule, MaxScheduleWindows)
+	}
+	for _, w := range s.Windows {
+		if w.Day < time.Sunday || w.Day > time.Saturday {
+			return fmt.Errorf("%w: day must be 0 (Sunday) to 6 (Saturday)", ErrInvalidSchedule)
+		}
+		start, ok := parseClock(w.Start)
+		if !ok || start == 24*60 {
+			return fmt.Errorf("%w: invalid start time %q", ErrInvalidSchedule, w.Start)
+		}
+		end, ok := parseClock(w.End)
+		if !ok {
+			return fmt.Errorf("%w: invalid end time %q", ErrInvalidSchedule, w.End)
+		}
+		if end <= start {
+			return fmt.Errorf("%w: window must end after it starts", ErrInvalidSchedule)
+		}
+	}
+	return nil
+}
+
+// NextOpen returns now if a window is open at now, and otherwise the time
+// the next one opens. Returns the zero time for an invalid schedule.
+func (s *Schedule) NextOpen(now time.Time) time.Time {
+	loc, err := time.LoadLocation(s.TimeZone)
+	if err != nil {
+		return time.Time{}
+	}
+	local := now.In(loc)
+
+	var next time.Time
+	// A week ahead always reaches a window, plus a day for windows earlier
+	// today than now
+	for i := 0; i <= 7; i++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+i, 0, 0, 0, 0, loc)
+		for _, w := range s.Windows {
+			if w.Day != day.Weekday() {
+				continue
+			}
+			startMin, _ := parseClock(w.Start)
+			endMin, _ := parseClock(w.End)
+			start := time.Date(day.Year(), day.Month(), day.Day(), 0, startMin, 0, 0, loc)
+			end := time.Date(day.Year(), day.Month(), day.Day(), 0, endMin, 0, 0, loc)
+			if !now.Before(start) && now.Before(end) {
+				return now
+			}
+			if start.After(now) && (next.IsZero() || start.Before(next)) {
+				next = start
+			}
+		}
+		if !next.IsZero() {
+			return next
+		}
+	}
+	return next
+}
+
+// parseClock parses "HH:MM" into minutes after midnight, allowing "24:00".
+func parseClock(s string) (int, bool) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, false
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+	}
+	h := int(s[0]-'0')*10 + int(s[1]-'0')
+	m := int(s[3]-'0')*10 + int(s[4]-'0')
+	if m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, false
+	}
+	return h*60 + m, true
+}
@@ -0,0 +1,85 @@
+package notification
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	window := ScheduleWindow{Day: time.Monday, Start: "09:00", End: "17:00"}
+	tests := []struct {
+		name     string
+		schedule Schedule
+		wantErr  bool
+	}{
+		{"valid", Schedule{TimeZone: "Europe/Berlin", Windows: []ScheduleWindow{window}}, false},
+		{"end of day", Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{{Day: time.Friday, Start: "20:00", End: "24:00"}}}, false},
+		{"unknown zone", Schedule{TimeZone: "Mars/Olympus", Windows: []ScheduleWindow{window}}, true},
+		{"empty zone", Schedule{TimeZone: "", Windows: []ScheduleWindow{window}}, true},
+		{"server zone", Schedule{TimeZone: "Local", Windows: []ScheduleWindow{window}}, true},
+		{"no windows", Schedule{TimeZone: "UTC"}, true},
+		{"bad day", Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{{Day: 7, Start: "09:00", End: "17:00"}}}, true},
+		{"bad start", Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{{Day: time.Monday, Start: "9:00", End: "17:00"}}}, true},
+		{"start at end of day", Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{{Day: time.Monday, Start: "24:00", End: "24:00"}}}, true},
+		{"bad minutes", Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{{Day: time.Monday, Start: "09:00", End: "17:60"}}}, true},
+		{"signed hour", Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{{Day: time.Monday, Start: "+9:00", End: "17:00"}}}, true},
+		{"ends before start", Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{{Day: time.Monday, Start: "17:00", End: "09:00"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSchedule) {
+				t.Errorf("error = %v, want ErrInvalidSchedule", err)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextOpen(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weekdays := &Schedule{TimeZone: "Europe/Berlin"}
+	for day := time.Monday; day <= time.Friday; day++ {
+		weekdays.Windows = append(weekdays.Windows, ScheduleWindow{Day: day, Start: "09:00", End: "17:30"})
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		// 2026-03-04 is a Wednesday
+		{"inside window", time.Date(2026, 3, 4, 12, 0, 0, 0, berlin), time.Date(2026, 3, 4, 12, 0, 0, 0, berlin)},
+		{"at opening", time.Date(2026, 3, 4, 9, 0, 0, 0, berlin), time.Date(2026, 3, 4, 9, 0, 0, 0, berlin)},
+		{"before opening", time.Date(2026, 3, 4, 7, 15, 0, 0, berlin), time.Date(2026, 3, 4, 9, 0, 0, 0, berlin)},
+		{"at closing", time.Date(2026, 3, 4, 17, 30, 0, 0, berlin), time.Date(2026, 3, 5, 9, 0, 0, 0, berlin)},
+		{"friday evening", time.Date(2026, 3, 6, 18, 0, 0, 0, berlin), time.Date(2026, 3, 9, 9, 0, 0, 0, berlin)},
+		// Other zones compare by instant: 06:00 UTC is 07:00 in Berlin
+		{"utc input", time.Date(2026, 3, 4, 6, 0, 0, 0, time.UTC), time.Date(2026, 3, 4, 9, 0, 0, 0, berlin)},
+		// Clocks go forward on 2026-03-29; opening is still 09:00 local
+		{"across dst change", time.Date(2026, 3, 27, 18, 0, 0, 0, berlin), time.Date(2026, 3, 30, 9, 0, 0, 0, berlin)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weekdays.NextOpen(tt.now); !got.Equal(tt.want) {
+				t.Errorf("NextOpen(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextOpen_SameWeekday(t *testing.T) {
+	// Only Wednesdays, checked on a Wednesday after the window: a week ahead
+	s := &Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{{Day: time.Wednesday, Start: "10:00", End: "11:00"}}}
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	want := time.Date(2026, 3, 11, 10, 0, 0, 0, time.UTC)
+	if got := s.NextOpen(now); !got.Equal(want) {
+		t.Errorf("NextOpen() = %v, want %v", got, want)
+	}
+}
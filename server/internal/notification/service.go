@@ -2,6 +2,8 @@ package notification
 
 import (
 	"context"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/enzyme/server/internal/openapi"
@@ -51,7 +53,8 @@ type ThreadSubscriptionProvider interface {
 	GetSubscribedUserIDs(ctx context.Context, threadParentID string) ([]string, error)
 }
 
-// PushSender sends push notifications to a user's devices
+// PushSender sends push notifications to a user's devices. Send reports
+// whether any device was reached.
 type PushSender interface {
 	Send(ctx context.Context, userID string, data pushnotification.NotificationData) bool
 }
@@ -60,25 +63,33 @@ type PushSender interface {
 type Service struct {
 	prefsRepo         *PreferencesRepository
 	pendingRepo       *PendingRepository
+	settingsRepo      *SettingsRepository
+	heldRepo          *HeldRepository
 	channelProvider   ChannelMemberProvider
+	workspaceProvider WorkspaceMemberProvider
 	threadSubProvider ThreadSubscriptionProvider
-	pushService       PushSender
+	pushSenders       []PushSender
+	mobileSenders     []PushSender
 	hub               *sse.Hub
 	emailDelay        time.Duration
 	publicURL         string
-	includePreview    bool
+	includePreview    atomic.Bool
 }
 
 // NewService creates a new notification service
 func NewService(
 	prefsRepo *PreferencesRepository,
 	pendingRepo *PendingRepository,
+	settingsRepo *SettingsRepository,
+	heldRepo *HeldRepository,
 	channelProvider ChannelMemberProvider,
 	hub *sse.Hub,
 ) *Service {
 	return &Service{
 		prefsRepo:         prefsRepo,
 		pendingRepo:       pendingRepo,
+		settingsRepo:      settingsRepo,
+		heldRepo:          heldRepo,
 		channelProvider:   channelProvider,
 		threadSubProvider: nil, // Set via SetThreadSubscriptionProvider
 		hub:               hub,
@@ -92,12 +103,39 @@ func (s *Service) SetThreadSubscriptionProvider(provider ThreadSubscriptionProvi
 	s.threadSubProvider = provider
 }
 
-// SetPushService sets the push notification sender.
+// SetWorkspaceMemberProvider sets the provider used to notify all workspace
+// members of @everyone. Without one, @everyone only reaches channel members.
+func (s *Service) SetWorkspaceMemberProvider(provider WorkspaceMemberProvider) {
+	s.workspaceProvider = provider
+}
+
+// AddPushSender adds a push notification sender. Offline users are notified
+// through every sender, and by email only if none reached a device.
+// Must be called before any Notify calls (during initialization only).
+func (s *Service) AddPushSender(sender PushSender) {
+	s.pushSenders = append(s.pushSenders, sender)
+}
+
+// AddMobilePushSender adds a sender for mobile devices. Besides notifying
+// offline users like other senders, it notifies online users who don't
+// want mobile notifications held back while they're connected.
+// Must be called before any Notify calls (during initialization only).
+func (s *Service) AddMobilePushSender(sender PushSender) {
+	s.mobileSenders = append(s.mobileSenders, sender)
+}
+
+// SetPushOptions sets the server URL included in push notifications for
+// deep linking, and whether they include the message text.
 // Must be called before any Notify calls (during initialization only).
-func (s *Service) SetPushService(sender PushSender, publicURL string, includePreview bool) {
-	s.pushService = sender
+func (s *Service) SetPushOptions(publicURL string, includePreview bool) {
 	s.publicURL = publicURL
-	s.includePreview = includePreview
+	s.includePreview.Store(includePreview)
+}
+
+// SetIncludePreview sets whether push notifications include the message
+// text. Safe to call while notifications are being sent.
+func (s *Service) SetIncludePreview(includePreview bool) {
+	s.includePreview.Store(includePreview)
 }
 
 // Notify processes a message and sends notifications to appropriate recipients
@@ -125,50 +163,148 @@ func (s *Service) Notify(ctx context.Context, channel *ChannelInfo, msg *Message
 			ThreadParentId: msg.ThreadParentID,
 		})
 
+		body := "New message"
+		if s.includePreview.Load() {
+			body = truncatePreview(msg.Content, 100)
+		}
+		threadParentID := ""
+		if msg.ThreadParentID != nil {
+			threadParentID = *msg.ThreadParentID
+		}
+		pushData := pushnotification.NotificationData{
+			Title:          buildTitle(channel, msg),
+			Body:           body,
+			ChannelID:      channel.ID,
+			MessageID:      msg.ID,
+			WorkspaceID:    channel.WorkspaceID,
+			ChannelName:    channel.Name,
+			ThreadParentID: threadParentID,
+			ServerURL:      s.publicURL,
+		}
+
 		if isOnline {
 			// Send real-time SSE notification
 			s.hub.BroadcastToUser(channel.WorkspaceID, userID, sseEvent)
-		} else {
-			// Try push notification first
-			pushedOK := false
-			if s.pushService != nil {
-				body := "New message"
-				if s.includePreview {
-					body = truncatePreview(msg.Content, 100)
-				}
-				threadParentID := ""
-				if msg.ThreadParentID != nil {
-					threadParentID = *msg.ThreadParentID
-				}
-				pushData := pushnotification.NotificationData{
-					Title:          buildTitle(channel, msg),
-					Body:           body,
-					ChannelID:      channel.ID,
-					MessageID:      msg.ID,
-					WorkspaceID:    channel.WorkspaceID,
-					ChannelName:    channel.Name,
-					ThreadParentID: threadParentID,
-					ServerURL:      s.publicURL,
-				}
-				pushedOK = s.pushService.Send(ctx, userID, pushData)
+			s.notifyMobileWhileOnline(ctx, userID, pushData)
+			continue
+		}
+
+		// Outside the user's working hours, hold push and email until the
+		// next window opens
+		if opensAt := s.scheduleOpensAt(ctx, userID); !opensAt.IsZero() {
+			held := &HeldNotification{
+				UserID:           userID,
+				ChannelType:      channel.Type,
+				NotificationType: notifType,
+				Push:             pushData,
+				ReleaseAt:        opensAt,
+			}
+			if err := s.heldRepo.Create(ctx, held); err != nil {
+				slog.Error("failed to hold notification", "component", "notification", "user_id", userID, "error", err)
 			}
+			continue
+		}
+
+		s.deliverOffline(ctx, userID, notifType, pushData)
+	}
+
+	return nil
+}
 
-			// Fall back to email only if push didn't fire
-			if !pushedOK && s.shouldSendEmail(ctx, userID, channel.ID, channel.Type) {
-				pending := &PendingNotification{
-					UserID:           userID,
-					WorkspaceID:      channel.WorkspaceID,
-					ChannelID:        channel.ID,
-					MessageID:        msg.ID,
-					NotificationType: notifType,
-					SendAfter:        time.Now().UTC().Add(s.emailDelay),
+// deliverOffline notifies a user who isn't connected: by push if enabled,
+// falling back to a queued email only if no device was reached.
+func (s *Service) deliverOffline(ctx context.Context, userID, notifType string, pushData pushnotification.NotificationData) {
+	pref := s.effectivePreference(ctx, userID, pushData.ChannelID)
+
+	pushedOK := false
+	if pref.PushEnabled {
+		for _, senders := range [][]PushSender{s.mobileSenders, s.pushSenders} {
+			for _, sender := range senders {
+				if sender.Send(ctx, userID, pushData) {
+					pushedOK = true
 				}
-				// Ignore error - email is best effort
-				_ = s.pendingRepo.Create(ctx, pending)
 			}
 		}
 	}
 
+	if !pushedOK && pref.EmailEnabled {
+		sendAfter := time.Now().UTC().Add(s.emailDelay)
+		if settings, err := s.settingsRepo.Get(ctx, userID); err == nil {
+			sendAfter = settings.NextDigest(time.Now().UTC(), s.emailDelay)
+		}
+		if sendAfter.IsZero() {
+			return
+		}
+		pending := &PendingNotification{
+			UserID:           userID,
+			WorkspaceID:      pushData.WorkspaceID,
+			ChannelID:        pushData.ChannelID,
+			MessageID:        pushData.MessageID,
+			NotificationType: notifType,
+			SendAfter:        sendAfter,
+		}
+		// Ignore error - email is best effort
+		_ = s.pendingRepo.Create(ctx, pending)
+	}
+}
+
+// notifyMobileWhileOnline pushes to the mobile devices of a connected user,
+// unless they only want mobile notifications while idle.
+func (s *Service) notifyMobileWhileOnline(ctx context.Context, userID string, pushData pushnotification.NotificationData) {
+	if len(s.mobileSenders) == 0 {
+		return
+	}
+	pref := s.effectivePreference(ctx, userID, pushData.ChannelID)
+	if !pref.PushEnabled || pref.MobileOnlyWhenIdle || !s.scheduleOpensAt(ctx, userID).IsZero() {
+		return
+	}
+	for _, sender := range s.mobileSenders {
+		sender.Send(ctx, userID, pushData)
+	}
+}
+
+// scheduleOpensAt returns when the user's next schedule window opens, or the
+// zero time if notifications may be delivered now.
+func (s *Service) scheduleOpensAt(ctx context.Context, userID string) time.Time {
+	settings, err := s.settingsRepo.Get(ctx, userID)
+	if err != nil || settings.Schedule == nil {
+		return time.Time{}
+	}
+	now := time.Now()
+	opensAt := settings.Schedule.NextOpen(now)
+	if !opensAt.After(now) {
+		return time.Time{}
+	}
+	return opensAt
+}
+
+// ReleaseHeld delivers held notifications whose schedule window has opened.
+// Users who came online in the workspace since have already seen them in-app.
+func (s *Service) ReleaseHeld(ctx context.Context) error {
+	due, err := s.heldRepo.ListDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	// Delete before delivering, so a failure can't cause repeat pushes
+	ids := make([]string, len(due))
+	for i, n := range due {
+		ids[i] = n.ID
+	}
+	if err := s.heldRepo.DeleteByIDs(ctx, ids); err != nil {
+		return err
+	}
+
+	for _, n := range due {
+		if s.hub.IsUserOnline(n.Push.WorkspaceID, n.UserID) {
+			continue
+		}
+		n.Push.ServerURL = s.publicURL
+		s.deliverOffline(ctx, n.UserID, n.NotificationType, n.Push)
+	}
 	return nil
 }
 
@@ -215,7 +351,7 @@ func (s *Service) determineRecipients(ctx context.Context, channel *ChannelInfo,
 	if channel.Type == "dm" || channel.Type == "group_dm" {
 		for _, userID := range memberIDs {
 			if userID != msg.SenderID {
-				if s.shouldNotify(ctx, userID, channel.ID, channel.Type, false) {
+				if s.shouldNotify(ctx, userID, channel.ID, false) {
 					notificationTypes[userID] = TypeDM
 				}
 			}
@@ -226,7 +362,7 @@ func (s *Service) determineRecipients(ctx context.Context, channel *ChannelInfo,
 	if hasChannelMention {
 		for _, userID := range memberIDs {
 			if userID != msg.SenderID && notificationTypes[userID] == "" {
-				if s.shouldNotify(ctx, userID, channel.ID, channel.Type, true) {
+				if s.shouldNotify(ctx, userID, channel.ID, true) {
 					notificationTypes[userID] = TypeChannel
 				}
 			}
@@ -238,7 +374,7 @@ func (s *Service) determineRecipients(ctx context.Context, channel *ChannelInfo,
 		for _, userID := range memberIDs {
 			if userID != msg.SenderID && notificationTypes[userID] == "" {
 				if s.hub.IsUserOnline(channel.WorkspaceID, userID) {
-					if s.shouldNotify(ctx, userID, channel.ID, channel.Type, true) {
+					if s.shouldNotify(ctx, userID, channel.ID, true) {
 						notificationTypes[userID] = TypeHere
 					}
 				}
@@ -246,12 +382,18 @@ func (s *Service) determineRecipients(ctx context.Context, channel *ChannelInfo,
 		}
 	}
 
-	// @everyone: notify all workspace members (would need workspace member provider)
-	// For now, treat same as @channel for simplicity
+	// @everyone: notify all workspace members in public channels, otherwise
+	// all channel members
 	if hasEveryoneMention {
-		for _, userID := range memberIDs {
+		everyoneIDs := memberIDs
+		if s.workspaceProvider != nil && EveryoneReachesWorkspace(channel.Type) {
+			if ids, err := s.workspaceProvider.GetMemberUserIDs(ctx, channel.WorkspaceID); err == nil {
+				everyoneIDs = ids
+			}
+		}
+		for _, userID := range everyoneIDs {
 			if userID != msg.SenderID && notificationTypes[userID] == "" {
-				if s.shouldNotify(ctx, userID, channel.ID, channel.Type, true) {
+				if s.shouldNotify(ctx, userID, channel.ID, true) {
 					notificationTypes[userID] = TypeEveryone
 				}
 			}
@@ -266,12 +408,25 @@ func (s *Service) determineRecipients(ctx context.Context, channel *ChannelInfo,
 		// mention is a user ID
 		userID := mention
 		if userID != msg.SenderID && notificationTypes[userID] == "" {
-			if s.shouldNotify(ctx, userID, channel.ID, channel.Type, true) {
+			if s.shouldNotify(ctx, userID, channel.ID, true) {
 				notificationTypes[userID] = TypeMention
 			}
 		}
 	}
 
+	// Highlight keywords count as mentions for the channel's notify level
+	keywords, err := s.settingsRepo.ListKeywordsForChannel(ctx, channel.ID)
+	if err == nil && len(keywords) > 0 && msg.Content != "" {
+		text := newKeywordText(msg.Content)
+		for userID, userKeywords := range keywords {
+			if userID != msg.SenderID && notificationTypes[userID] == "" && text.Matches(userKeywords) {
+				if s.shouldNotify(ctx, userID, channel.ID, true) {
+					notificationTypes[userID] = TypeKeyword
+				}
+			}
+		}
+	}
+
 	// Build recipient list
 	recipients := make([]string, 0, len(notificationTypes))
 	for userID := range notificationTypes {
@@ -282,8 +437,8 @@ func (s *Service) determineRecipients(ctx context.Context, channel *ChannelInfo,
 }
 
 // shouldNotify checks if a user should receive notifications based on preferences
-func (s *Service) shouldNotify(ctx context.Context, userID, channelID, channelType string, isMention bool) bool {
-	pref, err := s.prefsRepo.GetOrDefault(ctx, userID, channelID, channelType)
+func (s *Service) shouldNotify(ctx context.Context, userID, channelID string, isMention bool) bool {
+	pref, err := s.prefsRepo.GetEffective(ctx, userID, channelID)
 	if err != nil {
 		// Default to notify on mentions
 		return isMention
@@ -301,13 +456,15 @@ func (s *Service) shouldNotify(ctx context.Context, userID, channelID, channelTy
 	}
 }
 
-// shouldSendEmail checks if a user has email notifications enabled
-func (s *Service) shouldSendEmail(ctx context.Context, userID, channelID, channelType string) bool {
-	pref, err := s.prefsRepo.GetOrDefault(ctx, userID, channelID, channelType)
+// effectivePreference returns a user's preferences for a channel, or the
+// built-in defaults if they can't be loaded.
+func (s *Service) effectivePreference(ctx context.Context, userID, channelID string) EffectivePreference {
+	pref, err := s.prefsRepo.GetEffective(ctx, userID, channelID)
 	if err != nil {
-		return true // Default to email enabled
+		slog.Error("failed to load notification preferences", "component", "notification", "user_id", userID, "error", err)
+		return resolvePreference(channelID, "", nil, "", BuiltInDefaults)
 	}
-	return pref.EmailEnabled
+	return *pref
 }
 
 // CancelPendingForUser cancels all pending email notifications for a user
@@ -321,16 +478,114 @@ func (s *Service) CancelPendingForUserInWorkspace(ctx context.Context, userID, w
 	return s.pendingRepo.DeleteForUserInWorkspace(ctx, userID, workspaceID)
 }
 
-// GetPreferences returns notification preferences for a channel
-func (s *Service) GetPreferences(ctx context.Context, userID, channelID, channelType string) (*NotificationPreference, error) {
-	return s.prefsRepo.GetOrDefault(ctx, userID, channelID, channelType)
+// GetPreferences returns a user's effective notification preferences for a channel
+func (s *Service) GetPreferences(ctx context.Context, userID, channelID string) (*EffectivePreference, error) {
+	return s.prefsRepo.GetEffective(ctx, userID, channelID)
+}
+
+// ListPreferences returns a user's effective notification preferences for
+// each of their channels in a workspace
+func (s *Service) ListPreferences(ctx context.Context, userID, workspaceID string) ([]EffectivePreference, error) {
+	return s.prefsRepo.ListEffective(ctx, userID, workspaceID)
 }
 
-// SetPreferences updates notification preferences for a channel
+// SetPreferences overrides a user's notification preferences for a channel
 func (s *Service) SetPreferences(ctx context.Context, pref *NotificationPreference) error {
 	return s.prefsRepo.Upsert(ctx, pref)
 }
 
+// ResetPreferences removes a user's overrides for a channel, so it inherits
+// their defaults again
+func (s *Service) ResetPreferences(ctx context.Context, userID, channelID string) error {
+	return s.prefsRepo.Delete(ctx, userID, channelID)
+}
+
+// GetDefaults returns the defaults a member's channels inherit in a
+// workspace, and whether the member has set their own
+func (s *Service) GetDefaults(ctx context.Context, userID, workspaceID string) (Defaults, bool, error) {
+	return s.prefsRepo.GetMemberDefaults(ctx, userID, workspaceID)
+}
+
+// SetDefaults sets a member's own defaults for a workspace
+func (s *Service) SetDefaults(ctx context.Context, userID, workspaceID string, defaults Defaults) error {
+	return s.prefsRepo.SetMemberDefaults(ctx, userID, workspaceID, defaults)
+}
+
+// ResetDefaults removes a member's own defaults for a workspace
+func (s *Service) ResetDefaults(ctx context.Context, userID, workspaceID string) error {
+	return s.prefsRepo.DeleteMemberDefaults(ctx, userID, workspaceID)
+}
+
+// GetWorkspaceDefaults returns the defaults for members of a workspace
+func (s *Service) GetWorkspaceDefaults(ctx context.Context, workspaceID string) (Defaults, error) {
+	return s.prefsRepo.GetWorkspaceDefaults(ctx, workspaceID)
+}
+
+// SetWorkspaceDefaults sets the defaults for members of a workspace
+func (s *Service) SetWorkspaceDefaults(ctx context.Context, workspaceID string, defaults Defaults) error {
+	return s.prefsRepo.SetWorkspaceDefaults(ctx, workspaceID, defaults)
+}
+
+// GetChannelDefault returns the notify level members of a channel inherit,
+// or "" if it follows their defaults
+func (s *Service) GetChannelDefault(ctx context.Context, channelID string) (string, error) {
+	return s.prefsRepo.GetChannelDefault(ctx, channelID)
+}
+
+// SetChannelDefault sets the notify level members of a channel inherit
+func (s *Service) SetChannelDefault(ctx context.Context, channelID, notifyLevel string) error {
+	return s.prefsRepo.SetChannelDefault(ctx, channelID, notifyLevel)
+}
+
+// ResetChannelDefault removes a channel's default notify level
+func (s *Service) ResetChannelDefault(ctx context.Context, channelID string) error {
+	return s.prefsRepo.DeleteChannelDefault(ctx, channelID)
+}
+
+// GetSettings returns a user's notification settings
+func (s *Service) GetSettings(ctx context.Context, userID string) (*Settings, error) {
+	return s.settingsRepo.Get(ctx, userID)
+}
+
+// UpdateSettings replaces a user's notification settings. Notifications
+// already held are moved to the new schedule's next window, or released now
+// if the schedule was removed or is open. If the digest frequency changed,
+// queued emails move to the new digest's next send time.
+func (s *Service) UpdateSettings(ctx context.Context, settings *Settings) error {
+	previous, err := s.settingsRepo.Get(ctx, settings.UserID)
+	if err != nil {
+		return err
+	}
+	if err := s.settingsRepo.Upsert(ctx, settings); err != nil {
+		return err
+	}
+	releaseAt := time.Now()
+	if settings.Schedule != nil {
+		releaseAt = settings.Schedule.NextOpen(releaseAt)
+	}
+	if err := s.heldRepo.RescheduleForUser(ctx, settings.UserID, releaseAt); err != nil {
+		return err
+	}
+	if settings.EmailDigest == previous.EmailDigest {
+		return nil
+	}
+	sendAfter := settings.NextDigest(time.Now().UTC(), s.emailDelay)
+	if sendAfter.IsZero() {
+		return s.pendingRepo.DeleteForUser(ctx, settings.UserID)
+	}
+	return s.pendingRepo.RescheduleForUser(ctx, settings.UserID, sendAfter)
+}
+
+// UnsubscribeFromEmail stops a user's notification emails, as requested
+// through the unsubscribe link in one of them. Emails already queued are
+// dropped too.
+func (s *Service) UnsubscribeFromEmail(ctx context.Context, userID string) error {
+	if err := s.settingsRepo.SetEmailDigest(ctx, userID, DigestNever); err != nil {
+		return err
+	}
+	return s.pendingRepo.DeleteForUser(ctx, userID)
+}
+
 // buildTitle creates a push notification title based on the channel and message context
 func buildTitle(channel *ChannelInfo, msg *MessageInfo) string {
 	sender := "@" + msg.SenderName
@@ -0,0 +1,228 @@
+package notification
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/pushnotification"
+	"github.com/enzyme/server/internal/sse"
+	"github.com/enzyme/server/internal/testutil"
+	"github.com/oklog/ulid/v2"
+)
+
+type staticMembers []string
+
+func (m staticMembers) GetMemberUserIDs(ctx context.Context, channelID string) ([]string, error) {
+	return m, nil
+}
+
+type recordingSender struct {
+	mu   sync.Mutex
+	sent map[string][]pushnotification.NotificationData
+}
+
+func (r *recordingSender) Send(ctx context.Context, userID string, data pushnotification.NotificationData) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sent == nil {
+		r.sent = make(map[string][]pushnotification.NotificationData)
+	}
+	r.sent[userID] = append(r.sent[userID], data)
+	return true
+}
+
+func (r *recordingSender) sentTo(userID string) []pushnotification.NotificationData {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sent[userID]
+}
+
+func TestNotify_HighlightKeywordHeldUntilScheduleOpens(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+
+	sender := testutil.CreateTestUser(t, db, "sender@example.com", "Sender")
+	watcher := testutil.CreateTestUser(t, db, "watcher@example.com", "Watcher")
+	bystander := testutil.CreateTestUser(t, db, "bystander@example.com", "Bystander")
+	ws := testutil.CreateTestWorkspace(t, db, sender.ID, "Test WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, sender.ID, "ops", "public")
+	for _, userID := range []string{watcher.ID, bystander.ID} {
+		now := time.Now().UTC().Format(time.RFC3339)
+		if _, err := db.Exec(`
+			INSERT INTO channel_memberships (id, user_id, channel_id, channel_role, created_at, updated_at)
+			VALUES (?, ?, ?, 'poster', ?, ?)
+		`, ulid.Make().String(), userID, ch.ID, now, now); err != nil {
+			t.Fatalf("adding member: %v", err)
+		}
+	}
+
+	svc := NewService(NewPreferencesRepository(db), NewPendingRepository(db), NewSettingsRepository(db), NewHeldRepository(db),
+		staticMembers{sender.ID, watcher.ID, bystander.ID}, sse.NewHub(db, time.Hour))
+	push := &recordingSender{}
+	svc.AddPushSender(push)
+
+	// Only open on a day that isn't today, so the push is held
+	closedToday := &Schedule{TimeZone: "UTC", Windows: []ScheduleWindow{
+		{Day: (time.Now().UTC().Weekday() + 3) % 7, Start: "00:00", End: "24:00"},
+	}}
+	err := svc.UpdateSettings(ctx, &Settings{UserID: watcher.ID, HighlightKeywords: []string{"outage"}, Schedule: closedToday})
+	if err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+
+	channel := &ChannelInfo{ID: ch.ID, WorkspaceID: ws.ID, Name: ch.Name, Type: ch.Type}
+	msg := &MessageInfo{ID: ulid.Make().String(), ChannelID: ch.ID, SenderID: sender.ID, SenderName: "Sender", Content: "Possible Outage in eu-west"}
+	if err := svc.Notify(ctx, channel, msg); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if got := push.sentTo(watcher.ID); len(got) != 0 {
+		t.Fatalf("pushed %d notifications outside the schedule, want 0", len(got))
+	}
+	if got := push.sentTo(bystander.ID); len(got) != 0 {
+		t.Errorf("bystander without keywords got %d notifications, want 0", len(got))
+	}
+
+	// Nothing is due until the window opens
+	if err := svc.ReleaseHeld(ctx); err != nil {
+		t.Fatalf("ReleaseHeld() error = %v", err)
+	}
+	if got := push.sentTo(watcher.ID); len(got) != 0 {
+		t.Fatalf("released %d notifications early, want 0", len(got))
+	}
+
+	// Dropping the schedule releases held notifications on the next run
+	err = svc.UpdateSettings(ctx, &Settings{UserID: watcher.ID, HighlightKeywords: []string{"outage"}})
+	if err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+	if err := svc.ReleaseHeld(ctx); err != nil {
+		t.Fatalf("ReleaseHeld() error = %v", err)
+	}
+	got := push.sentTo(watcher.ID)
+	if len(got) != 1 {
+		t.Fatalf("released %d notifications, want 1", len(got))
+	}
+	if got[0].MessageID != msg.ID || got[0].ChannelID != ch.ID {
+		t.Errorf("released push = %+v, want message %s in channel %s", got[0], msg.ID, ch.ID)
+	}
+
+	// Released notifications are only delivered once
+	if err := svc.ReleaseHeld(ctx); err != nil {
+		t.Fatalf("ReleaseHeld() error = %v", err)
+	}
+	if got := push.sentTo(watcher.ID); len(got) != 1 {
+		t.Errorf("delivered %d notifications after a second release, want 1", len(got))
+	}
+}
+
+func TestNotify_HighlightKeywordRespectsMutedChannel(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+
+	user := testutil.CreateTestUser(t, db, "user@example.com", "User")
+	sender := testutil.CreateTestUser(t, db, "sender@example.com", "Sender")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "Test WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", "public")
+
+	prefs := NewPreferencesRepository(db)
+	svc := NewService(prefs, NewPendingRepository(db), NewSettingsRepository(db), NewHeldRepository(db),
+		staticMembers{user.ID, sender.ID}, sse.NewHub(db, time.Hour))
+	if err := svc.UpdateSettings(ctx, &Settings{UserID: user.ID, HighlightKeywords: []string{"release"}}); err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+
+	channel := &ChannelInfo{ID: ch.ID, WorkspaceID: ws.ID, Name: ch.Name, Type: ch.Type}
+	msg := &MessageInfo{ID: ulid.Make().String(), ChannelID: ch.ID, SenderID: sender.ID, Content: "release is out"}
+
+	_, types := svc.determineRecipients(ctx, channel, msg)
+	if types[user.ID] != TypeKeyword {
+		t.Fatalf("notification type = %q, want %q", types[user.ID], TypeKeyword)
+	}
+
+	if err := prefs.Upsert(ctx, &NotificationPreference{UserID: user.ID, ChannelID: ch.ID, NotifyLevel: NotifyNone}); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+	_, types = svc.determineRecipients(ctx, channel, msg)
+	if _, ok := types[user.ID]; ok {
+		t.Errorf("muted channel still notified with type %q", types[user.ID])
+	}
+}
+
+func TestNotify_PushDisabledByDefaultsFallsBackToEmail(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+
+	user := testutil.CreateTestUser(t, db, "user@example.com", "User")
+	sender := testutil.CreateTestUser(t, db, "sender@example.com", "Sender")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "Test WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", "public")
+
+	prefs := NewPreferencesRepository(db)
+	pending := NewPendingRepository(db)
+	svc := NewService(prefs, pending, NewSettingsRepository(db), NewHeldRepository(db),
+		staticMembers{user.ID, sender.ID}, sse.NewHub(db, time.Hour))
+	mobile := &recordingSender{}
+	svc.AddMobilePushSender(mobile)
+
+	err := prefs.SetMemberDefaults(ctx, user.ID, ws.ID, Defaults{NotifyLevel: NotifyMentions, EmailEnabled: true, PushEnabled: false})
+	if err != nil {
+		t.Fatalf("SetMemberDefaults() error = %v", err)
+	}
+
+	stored := testutil.CreateTestMessage(t, db, ch.ID, sender.ID, "hello @User")
+	channel := &ChannelInfo{ID: ch.ID, WorkspaceID: ws.ID, Name: ch.Name, Type: ch.Type}
+	msg := &MessageInfo{ID: stored.ID, ChannelID: ch.ID, SenderID: sender.ID, Content: stored.Content, Mentions: []string{user.ID}}
+	if err := svc.Notify(ctx, channel, msg); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+
+	if got := mobile.sentTo(user.ID); len(got) != 0 {
+		t.Errorf("pushed %d notifications with push disabled, want 0", len(got))
+	}
+	count, err := pending.CountForUser(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("CountForUser() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("queued %d emails, want 1", count)
+	}
+}
+
+func TestDetermineRecipients_EveryoneReachesWorkspaceInPublicChannels(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+
+	sender := testutil.CreateTestUser(t, db, "sender@example.com", "Sender")
+	member := testutil.CreateTestUser(t, db, "member@example.com", "Member")
+	outsider := testutil.CreateTestUser(t, db, "outsider@example.com", "Outsider")
+	ws := testutil.CreateTestWorkspace(t, db, sender.ID, "Test WS")
+	public := testutil.CreateTestChannel(t, db, ws.ID, sender.ID, "general", "public")
+	private := testutil.CreateTestChannel(t, db, ws.ID, sender.ID, "secret", "private")
+
+	svc := NewService(NewPreferencesRepository(db), NewPendingRepository(db), NewSettingsRepository(db), NewHeldRepository(db),
+		staticMembers{sender.ID, member.ID}, sse.NewHub(db, time.Hour))
+	svc.SetWorkspaceMemberProvider(staticMembers{sender.ID, member.ID, outsider.ID})
+
+	for _, tt := range []struct {
+		channel      *ChannelInfo
+		wantOutsider bool
+	}{
+		{&ChannelInfo{ID: public.ID, WorkspaceID: ws.ID, Name: public.Name, Type: public.Type}, true},
+		{&ChannelInfo{ID: private.ID, WorkspaceID: ws.ID, Name: private.Name, Type: private.Type}, false},
+	} {
+		msg := &MessageInfo{ID: ulid.Make().String(), ChannelID: tt.channel.ID, SenderID: sender.ID, Mentions: []string{MentionEveryone}}
+		_, types := svc.determineRecipients(ctx, tt.channel, msg)
+		if types[member.ID] != TypeEveryone {
+			t.Errorf("%s: member notification type = %q, want %q", tt.channel.Type, types[member.ID], TypeEveryone)
+		}
+		if _, ok := types[outsider.ID]; ok != tt.wantOutsider {
+			t.Errorf("%s: outsider notified = %v, want %v", tt.channel.Type, ok, tt.wantOutsider)
+		}
+		if _, ok := types[sender.ID]; ok {
+			t.Errorf("%s: sender was notified", tt.channel.Type)
+		}
+	}
+}
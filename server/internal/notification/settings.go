@@ -0,0 +1,186 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Email digest frequencies
+const (
+	DigestImmediate = "immediate" // batched a few minutes after the first notification
+	DigestHourly    = "hourly"
+	DigestDaily     = "daily"
+	DigestNever     = "never"
+)
+
+// DailyDigestHour is the local hour daily digests are sent at, in the time
+// zone of the user's schedule or UTC if they have none.
+const DailyDigestHour = 9
+
+// IsValidDigest checks if an email digest frequency is valid
+func IsValidDigest(digest string) bool {
+	switch digest {
+	case DigestImmediate, DigestHourly, DigestDaily, DigestNever:
+		return true
+	}
+	return false
+}
+
+// Settings are a user's notification settings that apply across channels.
+type Settings struct {
+	UserID            string
+	HighlightKeywords []string
+	Schedule          *Schedule // nil when push and email are always allowed
+	EmailDigest       string
+	UpdatedAt         time.Time
+}
+
+// NextDigest returns when an email notification queued at now should be
+// sent, given the user's digest frequency and a delay for immediate digests.
+// Returns the zero time if the user doesn't want notification emails.
+func (s *Settings) NextDigest(now time.Time, delay time.Duration) time.Time {
+	switch s.EmailDigest {
+	case DigestNever:
+		return time.Time{}
+	case DigestHourly:
+		return now.Truncate(time.Hour).Add(time.Hour)
+	case DigestDaily:
+		loc := time.UTC
+		if s.Schedule != nil {
+			if l, err := time.LoadLocation(s.Schedule.TimeZone); err == nil {
+				loc = l
+			}
+		}
+		local := now.In(loc)
+		next := time.Date(local.Year(), local.Month(), local.Day(), DailyDigestHour, 0, 0, 0, loc)
+		if !next.After(local) {
+			next = time.Date(local.Year(), local.Month(), local.Day()+1, DailyDigestHour, 0, 0, 0, loc)
+		}
+		return next.UTC()
+	default:
+		return now.Add(delay)
+	}
+}
+
+// SettingsRepository handles notification settings persistence
+type SettingsRepository struct {
+	db *sql.DB
+}
+
+// NewSettingsRepository creates a new settings repository
+func NewSettingsRepository(db *sql.DB) *SettingsRepository {
+	return &SettingsRepository{db: db}
+}
+
+// Get returns a user's settings, or empty settings if they have none.
+func (r *SettingsRepository) Get(ctx context.Context, userID string) (*Settings, error) {
+	var keywordsJSON, emailDigest, updatedAt string
+	var scheduleJSON sql.NullString
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT highlight_keywords, schedule, email_digest, updated_at
+		FROM notification_settings
+		WHERE user_id = ?
+	`, userID).Scan(&keywordsJSON, &scheduleJSON, &emailDigest, &updatedAt)
+	if err == sql.ErrNoRows {
+		return &Settings{UserID: userID, HighlightKeywords: []string{}, EmailDigest: DigestImmediate}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &Settings{UserID: userID, EmailDigest: emailDigest}
+	if err := json.Unmarshal([]byte(keywordsJSON), &settings.HighlightKeywords); err != nil {
+		return nil, fmt.Errorf("parsing highlight_keywords: %w", err)
+	}
+	if scheduleJSON.Valid {
+		settings.Schedule = &Schedule{}
+		if err := json.Unmarshal([]byte(scheduleJSON.String), settings.Schedule); err != nil {
+			return nil, fmt.Errorf("parsing schedule: %w", err)
+		}
+	}
+	if settings.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt); err != nil {
+		return nil, fmt.Errorf("parsing updated_at: %w", err)
+	}
+	return settings, nil
+}
+
+// Upsert creates or replaces a user's settings
+func (r *SettingsRepository) Upsert(ctx context.Context, settings *Settings) error {
+	if settings.HighlightKeywords == nil {
+		settings.HighlightKeywords = []string{}
+	}
+	if settings.EmailDigest == "" {
+		settings.EmailDigest = DigestImmediate
+	}
+	keywordsJSON, err := json.Marshal(settings.HighlightKeywords)
+	if err != nil {
+		return err
+	}
+	var scheduleJSON *string
+	if settings.Schedule != nil {
+		data, err := json.Marshal(settings.Schedule)
+		if err != nil {
+			return err
+		}
+		s := string(data)
+		scheduleJSON = &s
+	}
+
+	settings.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO notification_settings (user_id, highlight_keywords, schedule, email_digest, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			highlight_keywords = excluded.highlight_keywords,
+			schedule = excluded.schedule,
+			email_digest = excluded.email_digest,
+			updated_at = excluded.updated_at
+	`, settings.UserID, string(keywordsJSON), scheduleJSON, settings.EmailDigest, settings.UpdatedAt.Format(time.RFC3339))
+	return err
+}
+
+// SetEmailDigest changes a user's email digest frequency, leaving their
+// other settings as they are
+func (r *SettingsRepository) SetEmailDigest(ctx context.Context, userID, digest string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO notification_settings (user_id, email_digest, updated_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET
+			email_digest = excluded.email_digest,
+			updated_at = excluded.updated_at
+	`, userID, digest, time.Now().UTC().Format(time.RFC3339))
+	return err
+}
+
+// ListKeywordsForChannel returns the highlight keywords of each member of a
+// channel who has any, keyed by user ID.
+func (r *SettingsRepository) ListKeywordsForChannel(ctx context.Context, channelID string) (map[string][]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ns.user_id, ns.highlight_keywords
+		FROM notification_settings ns
+		JOIN channel_memberships cm ON cm.user_id = ns.user_id
+		WHERE cm.channel_id = ? AND ns.highlight_keywords != '[]'
+	`, channelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string][]string)
+	for rows.Next() {
+		var userID, keywordsJSON string
+		if err := rows.Scan(&userID, &keywordsJSON); err != nil {
+			return nil, err
+		}
+		var keywords []string
+		if err := json.Unmarshal([]byte(keywordsJSON), &keywords); err != nil {
+			return nil, fmt.Errorf("parsing highlight_keywords: %w", err)
+		}
+		result[userID] = keywords
+	}
+	return result, rows.Err()
+}
@@ -44,6 +44,30 @@ const (
 	ConvertGroupDMInputTypePublic  ConvertGroupDMInputType = "public"
 )
 
+// Defines values for EmailDigest.
+const (
+	Daily     EmailDigest = "daily"
+	Hourly    EmailDigest = "hourly"
+	Immediate EmailDigest = "immediate"
+	Never     EmailDigest = "never"
+)
+
+// Defines values for FileScanStatus.
+const (
+	FileScanStatusClean    FileScanStatus = "clean"
+	FileScanStatusError    FileScanStatus = "error"
+	FileScanStatusInfected FileScanStatus = "infected"
+	FileScanStatusPending  FileScanStatus = "pending"
+)
+
+// Defines values for LinkPreviewMediaType.
+const (
+	Article LinkPreviewMediaType = "article"
+	Image   LinkPreviewMediaType = "image"
+	Rich    LinkPreviewMediaType = "rich"
+	Video   LinkPreviewMediaType = "video"
+)
+
 // Defines values for LinkPreviewType.
 const (
 	LinkPreviewTypeExternal LinkPreviewType = "external"
@@ -57,8 +81,16 @@ const (
 	Before ListMessagesInputDirection = "before"
 )
 
+// Defines values for MentionConfirmationRequiredMention.
+const (
+	MentionConfirmationRequiredMentionChannel  MentionConfirmationRequiredMention = "@channel"
+	MentionConfirmationRequiredMentionEveryone MentionConfirmationRequiredMention = "@everyone"
+	MentionConfirmationRequiredMentionHere     MentionConfirmationRequiredMention = "@here"
+)
+
 // Defines values for MessageType.
 const (
+	MessageTypeBot    MessageType = "bot"
 	MessageTypeSystem MessageType = "system"
 	MessageTypeUser   MessageType = "user"
 )
@@ -69,6 +101,7 @@ const (
 	NotificationDataTypeDm          NotificationDataType = "dm"
 	NotificationDataTypeEveryone    NotificationDataType = "everyone"
 	NotificationDataTypeHere        NotificationDataType = "here"
+	NotificationDataTypeKeyword     NotificationDataType = "keyword"
 	NotificationDataTypeMention     NotificationDataType = "mention"
 	NotificationDataTypeThreadReply NotificationDataType = "thread_reply"
 )
@@ -93,10 +126,27 @@ const (
 	Online  PresenceStatus = "online"
 )
 
+// Defines values for QueuedEmailStatus.
+const (
+	QueuedEmailStatusFailed  QueuedEmailStatus = "failed"
+	QueuedEmailStatusPending QueuedEmailStatus = "pending"
+	QueuedEmailStatusSending QueuedEmailStatus = "sending"
+)
+
 // Defines values for RegisterDeviceTokenRequestPlatform.
 const (
-	Apns RegisterDeviceTokenRequestPlatform = "apns"
-	Fcm  RegisterDeviceTokenRequestPlatform = "fcm"
+	Apns        RegisterDeviceTokenRequestPlatform = "apns"
+	Fcm         RegisterDeviceTokenRequestPlatform = "fcm"
+	Unifiedpush RegisterDeviceTokenRequestPlatform = "unifiedpush"
+)
+
+// Defines values for RegistrationMode.
+const (
+	Approval        RegistrationMode = "approval"
+	Closed          RegistrationMode = "closed"
+	DomainAllowlist RegistrationMode = "domain_allowlist"
+	InviteOnly      RegistrationMode = "invite_only"
+	Open            RegistrationMode = "open"
 )
 
 // Defines values for SSEEventChannelArchivedType.
@@ -328,6 +378,12 @@ const (
 	ThreadSubscriptionStatusUnsubscribed ThreadSubscriptionStatus = "unsubscribed"
 )
 
+// Defines values for WorkspaceCreationPolicy.
+const (
+	WorkspaceCreationPolicyEveryone   WorkspaceCreationPolicy = "everyone"
+	WorkspaceCreationPolicySiteAdmins WorkspaceCreationPolicy = "site_admins"
+)
+
 // Defines values for WorkspaceRole.
 const (
 	WorkspaceRoleAdmin  WorkspaceRole = "admin"
@@ -336,6 +392,44 @@ const (
 	WorkspaceRoleOwner  WorkspaceRole = "owner"
 )
 
+// AdminListInput defines model for AdminListInput.
+type AdminListInput struct {
+	Cursor *string `json:"cursor,omitempty"`
+	Limit  *int    `json:"limit,omitempty"`
+
+	// Query Case-insensitive substring to match
+	Query *string `json:"query,omitempty"`
+}
+
+// AdminStats defines model for AdminStats.
+type AdminStats struct {
+	Channels         int `json:"channels"`
+	DeactivatedUsers int `json:"deactivated_users"`
+
+	// FileBytes Total size of all uploaded files
+	FileBytes int64 `json:"file_bytes"`
+	Files     int   `json:"files"`
+
+	// Messages Messages that have not been deleted
+	Messages int `json:"messages"`
+
+	// PendingUsers Accounts awaiting approval
+	PendingUsers int `json:"pending_users"`
+	SiteAdmins   int `json:"site_admins"`
+	Users        int `json:"users"`
+	Workspaces   int `json:"workspaces"`
+}
+
+// AdminWorkspace defines model for AdminWorkspace.
+type AdminWorkspace struct {
+	CreatedAt   time.Time `json:"created_at"`
+	IconUrl     *string   `json:"icon_url,omitempty"`
+	Id          string    `json:"id"`
+	MemberCount int       `json:"member_count"`
+	Name        string    `json:"name"`
+	OwnerCount  int       `json:"owner_count"`
+}
+
 // ApiError defines model for ApiError.
 type ApiError struct {
 	Code    string `json:"code"`
@@ -353,7 +447,10 @@ type Attachment struct {
 	CreatedAt   time.Time `json:"created_at"`
 	Filename    string    `json:"filename"`
 	Id          string    `json:"id"`
-	SizeBytes   int64     `json:"size_bytes"`
+
+	// ScanStatus Antivirus scan status. Omitted when the file was uploaded with scanning disabled.
+	ScanStatus *FileScanStatus `json:"scan_status,omitempty"`
+	SizeBytes  int64           `json:"size_bytes"`
 
 	// Url Download URL for the attachment
 	Url string `json:"url"`
@@ -435,6 +532,18 @@ type Channel struct {
 	WorkspaceId string      `json:"workspace_id"`
 }
 
+// ChannelEmailAddress defines model for ChannelEmailAddress.
+type ChannelEmailAddress struct {
+	// Address Secret address that posts mail sent to it into the channel
+	Address openapi_types.Email `json:"address"`
+
+	// AllowedSenders Addresses and domains mail is accepted from. Empty accepts mail from anyone who knows the address.
+	AllowedSenders []string  `json:"allowed_senders"`
+	CreatedAt      time.Time `json:"created_at"`
+	CreatedBy      *string   `json:"created_by,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
 // ChannelMember defines model for ChannelMember.
 type ChannelMember struct {
 	AvatarUrl   *string             `json:"avatar_url,omitempty"`
@@ -451,6 +560,34 @@ type ChannelMemberData struct {
 	UserId    string `json:"user_id"`
 }
 
+// ChannelMentionPermissions Overrides of the workspace's settings for who may use @here, @channel and @everyone in a channel. Omitted fields inherit the workspace setting.
+type ChannelMentionPermissions struct {
+	// WhoCanMentionChannel Controls which workspace roles can perform an action
+	WhoCanMentionChannel *PermissionLevel `json:"who_can_mention_channel,omitempty"`
+
+	// WhoCanMentionEveryone Controls which workspace roles can perform an action
+	WhoCanMentionEveryone *PermissionLevel `json:"who_can_mention_everyone,omitempty"`
+
+	// WhoCanMentionHere Controls which workspace roles can perform an action
+	WhoCanMentionHere *PermissionLevel `json:"who_can_mention_here,omitempty"`
+}
+
+// ChannelNotificationDefaults defines model for ChannelNotificationDefaults.
+type ChannelNotificationDefaults struct {
+	NotifyLevel *NotifyLevel `json:"notify_level,omitempty"`
+}
+
+// ChannelNotificationPreferences defines model for ChannelNotificationPreferences.
+type ChannelNotificationPreferences struct {
+	ChannelId    string `json:"channel_id"`
+	EmailEnabled bool   `json:"email_enabled"`
+
+	// Inherited True when the user has no preferences of their own for the channel
+	Inherited   bool        `json:"inherited"`
+	NotifyLevel NotifyLevel `json:"notify_level"`
+	PushEnabled bool        `json:"push_enabled"`
+}
+
 // ChannelReadEventData defines model for ChannelReadEventData.
 type ChannelReadEventData struct {
 	ChannelId         string `json:"channel_id"`
@@ -540,12 +677,18 @@ type CustomEmoji struct {
 	WorkspaceId string    `json:"workspace_id"`
 }
 
+// EmailDigest How often notification emails are sent. `immediate` batches notifications a few minutes after the first one, `hourly` sends a digest at the top of each hour, `daily` at 09:00 in the schedule's time zone (UTC without a schedule), and `never` sends none. Always set in responses; defaults to `immediate`.
+type EmailDigest string
+
 // EmojiDeletedData defines model for EmojiDeletedData.
 type EmojiDeletedData struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`
 }
 
+// FileScanStatus Antivirus scan status. Omitted when the file was uploaded with scanning disabled.
+type FileScanStatus string
+
 // HeartbeatData defines model for HeartbeatData.
 type HeartbeatData struct {
 	Timestamp int64 `json:"timestamp"`
@@ -567,27 +710,56 @@ type Invite struct {
 
 // LinkPreview defines model for LinkPreview.
 type LinkPreview struct {
-	Description              *string         `json:"description,omitempty"`
-	ImageUrl                 *string         `json:"image_url,omitempty"`
-	LinkedChannelId          *string         `json:"linked_channel_id,omitempty"`
-	LinkedChannelName        *string         `json:"linked_channel_name,omitempty"`
-	LinkedChannelType        *string         `json:"linked_channel_type,omitempty"`
-	LinkedMessageId          *string         `json:"linked_message_id,omitempty"`
-	MessageAuthorAvatarUrl   *string         `json:"message_author_avatar_url,omitempty"`
-	MessageAuthorGravatarUrl *string         `json:"message_author_gravatar_url,omitempty"`
-	MessageAuthorId          *string         `json:"message_author_id,omitempty"`
-	MessageAuthorName        *string         `json:"message_author_name,omitempty"`
-	MessageContent           *string         `json:"message_content,omitempty"`
-	MessageCreatedAt         *time.Time      `json:"message_created_at,omitempty"`
-	SiteName                 *string         `json:"site_name,omitempty"`
-	Title                    *string         `json:"title,omitempty"`
-	Type                     LinkPreviewType `json:"type"`
-	Url                      string          `json:"url"`
-}
+	AuthorName        *string `json:"author_name,omitempty"`
+	AuthorUrl         *string `json:"author_url,omitempty"`
+	Description       *string `json:"description,omitempty"`
+	FaviconUrl        *string `json:"favicon_url,omitempty"`
+	ImageHeight       *int    `json:"image_height,omitempty"`
+	ImageUrl          *string `json:"image_url,omitempty"`
+	ImageWidth        *int    `json:"image_width,omitempty"`
+	LinkedChannelId   *string `json:"linked_channel_id,omitempty"`
+	LinkedChannelName *string `json:"linked_channel_name,omitempty"`
+	LinkedChannelType *string `json:"linked_channel_type,omitempty"`
+	LinkedMessageId   *string `json:"linked_message_id,omitempty"`
+
+	// MediaType What an external preview links to
+	MediaType                *LinkPreviewMediaType `json:"media_type,omitempty"`
+	MessageAuthorAvatarUrl   *string               `json:"message_author_avatar_url,omitempty"`
+	MessageAuthorGravatarUrl *string               `json:"message_author_gravatar_url,omitempty"`
+	MessageAuthorId          *string               `json:"message_author_id,omitempty"`
+	MessageAuthorName        *string               `json:"message_author_name,omitempty"`
+	MessageContent           *string               `json:"message_content,omitempty"`
+	MessageCreatedAt         *time.Time            `json:"message_created_at,omitempty"`
+	PublishedAt              *time.Time            `json:"published_at,omitempty"`
+	SiteName                 *string               `json:"site_name,omitempty"`
+	Title                    *string               `json:"title,omitempty"`
+	Type                     LinkPreviewType       `json:"type"`
+	Url                      string                `json:"url"`
+	VideoHeight              *int                  `json:"video_height,omitempty"`
+
+	// VideoUrl Direct video file or embeddable player URL
+	VideoUrl   *string `json:"video_url,omitempty"`
+	VideoWidth *int    `json:"video_width,omitempty"`
+}
+
+// LinkPreviewMediaType What an external preview links to
+type LinkPreviewMediaType string
 
 // LinkPreviewType defines model for LinkPreview.Type.
 type LinkPreviewType string
 
+// ListAdminUsersInput defines model for ListAdminUsersInput.
+type ListAdminUsersInput struct {
+	Cursor *string `json:"cursor,omitempty"`
+	Limit  *int    `json:"limit,omitempty"`
+
+	// PendingApproval Only list accounts awaiting approval
+	PendingApproval *bool `json:"pending_approval,omitempty"`
+
+	// Query Case-insensitive substring to match
+	Query *string `json:"query,omitempty"`
+}
+
 // ListMessagesInput defines model for ListMessagesInput.
 type ListMessagesInput struct {
 	Cursor    *string                     `json:"cursor,omitempty"`
@@ -598,6 +770,15 @@ type ListMessagesInput struct {
 // ListMessagesInputDirection defines model for ListMessagesInput.Direction.
 type ListMessagesInputDirection string
 
+// ListQueuedEmailsInput defines model for ListQueuedEmailsInput.
+type ListQueuedEmailsInput struct {
+	Cursor *string `json:"cursor,omitempty"`
+	Limit  *int    `json:"limit,omitempty"`
+
+	// Status Delivery state. Pending emails wait for their next attempt, sending ones have an attempt in progress, and failed ones were given up on.
+	Status *QueuedEmailStatus `json:"status,omitempty"`
+}
+
 // LoginInput defines model for LoginInput.
 type LoginInput struct {
 	Email    openapi_types.Email `json:"email"`
@@ -622,6 +803,20 @@ type MemberRoleChangedData struct {
 	UserId  string `json:"user_id"`
 }
 
+// MentionConfirmationRequired defines model for MentionConfirmationRequired.
+type MentionConfirmationRequired struct {
+	Error ApiError `json:"error"`
+
+	// Mention The special mention that reaches the most people
+	Mention MentionConfirmationRequiredMention `json:"mention"`
+
+	// RecipientCount Number of people the message would notify
+	RecipientCount int `json:"recipient_count"`
+}
+
+// MentionConfirmationRequiredMention The special mention that reaches the most people
+type MentionConfirmationRequiredMention string
+
 // Message defines model for Message.
 type Message struct {
 	AlsoSendToChannel *bool            `json:"also_send_to_channel,omitempty"`
@@ -637,9 +832,11 @@ type Message struct {
 	ReplyCount        int              `json:"reply_count"`
 	SystemEvent       *SystemEventData `json:"system_event,omitempty"`
 	ThreadParentId    *string          `json:"thread_parent_id,omitempty"`
-	Type              *MessageType     `json:"type,omitempty"`
-	UpdatedAt         time.Time        `json:"updated_at"`
-	UserId            *string          `json:"user_id,omitempty"`
+
+	// Type `bot` messages are posted by an integration, such as mail sent to a channel's email address. They have no user; `user_display_name` holds the name of the integration.
+	Type      *MessageType `json:"type,omitempty"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	UserId    *string      `json:"user_id,omitempty"`
 }
 
 // MessageDeletedData defines model for MessageDeletedData.
@@ -656,7 +853,7 @@ type MessageListResult struct {
 	NextCursor *string           `json:"next_cursor,omitempty"`
 }
 
-// MessageType defines model for MessageType.
+// MessageType `bot` messages are posted by an integration, such as mail sent to a channel's email address. They have no user; `user_display_name` holds the name of the integration.
 type MessageType string
 
 // MessageWithUser defines model for MessageWithUser.
@@ -678,12 +875,14 @@ type MessageWithUser struct {
 	SystemEvent        *SystemEventData     `json:"system_event,omitempty"`
 	ThreadParentId     *string              `json:"thread_parent_id,omitempty"`
 	ThreadParticipants *[]ThreadParticipant `json:"thread_participants,omitempty"`
-	Type               *MessageType         `json:"type,omitempty"`
-	UpdatedAt          time.Time            `json:"updated_at"`
-	UserAvatarUrl      *string              `json:"user_avatar_url,omitempty"`
-	UserDisplayName    *string              `json:"user_display_name,omitempty"`
-	UserGravatarUrl    *string              `json:"user_gravatar_url,omitempty"`
-	UserId             *string              `json:"user_id,omitempty"`
+
+	// Type `bot` messages are posted by an integration, such as mail sent to a channel's email address. They have no user; `user_display_name` holds the name of the integration.
+	Type            *MessageType `json:"type,omitempty"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	UserAvatarUrl   *string      `json:"user_avatar_url,omitempty"`
+	UserDisplayName *string      `json:"user_display_name,omitempty"`
+	UserGravatarUrl *string      `json:"user_gravatar_url,omitempty"`
+	UserId          *string      `json:"user_id,omitempty"`
 }
 
 // ModerationLogEntryWithActor defines model for ModerationLogEntryWithActor.
@@ -715,10 +914,55 @@ type NotificationData struct {
 // NotificationDataType defines model for NotificationData.Type.
 type NotificationDataType string
 
+// NotificationDefaults defines model for NotificationDefaults.
+type NotificationDefaults struct {
+	EmailEnabled bool `json:"email_enabled"`
+
+	// MobileOnlyWhenIdle Only push to mobile devices while the user isn't connected from another client
+	MobileOnlyWhenIdle bool        `json:"mobile_only_when_idle"`
+	NotifyLevel        NotifyLevel `json:"notify_level"`
+	PushEnabled        bool        `json:"push_enabled"`
+}
+
 // NotificationPreferences defines model for NotificationPreferences.
 type NotificationPreferences struct {
-	EmailEnabled bool        `json:"email_enabled"`
-	NotifyLevel  NotifyLevel `json:"notify_level"`
+	EmailEnabled bool `json:"email_enabled"`
+
+	// Inherited True when the user has no preferences of their own for the channel
+	Inherited   *bool       `json:"inherited,omitempty"`
+	NotifyLevel NotifyLevel `json:"notify_level"`
+
+	// PushEnabled Omit when updating to inherit from the defaults
+	PushEnabled *bool `json:"push_enabled,omitempty"`
+}
+
+// NotificationSchedule defines model for NotificationSchedule.
+type NotificationSchedule struct {
+	// TimeZone IANA time zone the windows are in
+	TimeZone string                       `json:"time_zone"`
+	Windows  []NotificationScheduleWindow `json:"windows"`
+}
+
+// NotificationScheduleWindow defines model for NotificationScheduleWindow.
+type NotificationScheduleWindow struct {
+	// Day Day of the week, from 0 (Sunday) to 6 (Saturday)
+	Day int `json:"day"`
+
+	// End Local end time as HH:MM, after start; 24:00 is the end of the day
+	End string `json:"end"`
+
+	// Start Local start time as HH:MM
+	Start string `json:"start"`
+}
+
+// NotificationSettings defines model for NotificationSettings.
+type NotificationSettings struct {
+	// EmailDigest How often notification emails are sent. `immediate` batches notifications a few minutes after the first one, `hourly` sends a digest at the top of each hour, `daily` at 09:00 in the schedule's time zone (UTC without a schedule), and `never` sends none. Always set in responses; defaults to `immediate`.
+	EmailDigest *EmailDigest `json:"email_digest,omitempty"`
+
+	// HighlightKeywords Words or phrases matched case-insensitively on word boundaries
+	HighlightKeywords []string              `json:"highlight_keywords"`
+	Schedule          *NotificationSchedule `json:"schedule,omitempty"`
 }
 
 // NotifyLevel defines model for NotifyLevel.
@@ -742,6 +986,29 @@ type PresenceInitialData struct {
 // PresenceStatus defines model for PresenceStatus.
 type PresenceStatus string
 
+// QueuedEmail defines model for QueuedEmail.
+type QueuedEmail struct {
+	// Attempts Delivery attempts made so far
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        string    `json:"id"`
+
+	// LastError Why the most recent attempt failed
+	LastError *string `json:"last_error,omitempty"`
+
+	// NextAttemptAt When the email is next tried. Not meaningful for failed emails.
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+
+	// Status Delivery state. Pending emails wait for their next attempt, sending ones have an attempt in progress, and failed ones were given up on.
+	Status    QueuedEmailStatus `json:"status"`
+	Subject   string            `json:"subject"`
+	To        string            `json:"to"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// QueuedEmailStatus Delivery state. Pending emails wait for their next attempt, sending ones have an attempt in progress, and failed ones were given up on.
+type QueuedEmailStatus string
+
 // Reaction defines model for Reaction.
 type Reaction struct {
 	CreatedAt time.Time `json:"created_at"`
@@ -770,14 +1037,14 @@ type RegisterDeviceTokenRequest struct {
 	// DeviceId A unique identifier for the device
 	DeviceId string `json:"device_id"`
 
-	// Platform The push platform
+	// Platform The push platform. `fcm` and `apns` are delivered through the push relay; `unifiedpush` is posted directly to the endpoint.
 	Platform RegisterDeviceTokenRequestPlatform `json:"platform"`
 
-	// Token The push notification token (FCM or APNs)
+	// Token The push notification token (FCM or APNs), or for UnifiedPush the distributor's endpoint URL
 	Token string `json:"token"`
 }
 
-// RegisterDeviceTokenRequestPlatform The push platform
+// RegisterDeviceTokenRequestPlatform The push platform. `fcm` and `apns` are delivered through the push relay; `unifiedpush` is posted directly to the endpoint.
 type RegisterDeviceTokenRequestPlatform string
 
 // RegisterDeviceTokenResponse defines model for RegisterDeviceTokenResponse.
@@ -790,9 +1057,34 @@ type RegisterDeviceTokenResponse struct {
 type RegisterInput struct {
 	DisplayName string              `json:"display_name"`
 	Email       openapi_types.Email `json:"email"`
-	Password    string              `json:"password"`
+
+	// InviteCode Workspace invite code, required when registration is invite-only
+	InviteCode *string `json:"invite_code,omitempty"`
+	Password   string  `json:"password"`
+}
+
+// RegisterWebPushSubscriptionRequest defines model for RegisterWebPushSubscriptionRequest.
+type RegisterWebPushSubscriptionRequest struct {
+	// Endpoint The push service URL for this browser. Must use HTTPS.
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		// Auth The browser's 16-byte authentication secret, base64url encoded
+		Auth string `json:"auth"`
+
+		// P256dh The browser's P-256 public key, base64url encoded
+		P256dh string `json:"p256dh"`
+	} `json:"keys"`
+}
+
+// RegisterWebPushSubscriptionResponse defines model for RegisterWebPushSubscriptionResponse.
+type RegisterWebPushSubscriptionResponse struct {
+	// Id The subscription record ID
+	Id string `json:"id"`
 }
 
+// RegistrationMode Who may create an account. `invite_only` requires a workspace invite code, `domain_allowlist` requires an email address in one of the allowed domains, `approval` admits anyone but a site admin must approve the account before it can sign in, and `closed` admits nobody. A usable invite code bypasses every mode except `closed`. Clients should hide the sign-up form when closed.
+type RegistrationMode string
+
 // ReorderWorkspacesInput defines model for ReorderWorkspacesInput.
 type ReorderWorkspacesInput struct {
 	// WorkspaceIds Ordered list of workspace IDs representing the new order
@@ -1207,12 +1499,14 @@ type SearchMessage struct {
 	SystemEvent        *SystemEventData     `json:"system_event,omitempty"`
 	ThreadParentId     *string              `json:"thread_parent_id,omitempty"`
 	ThreadParticipants *[]ThreadParticipant `json:"thread_participants,omitempty"`
-	Type               *MessageType         `json:"type,omitempty"`
-	UpdatedAt          time.Time            `json:"updated_at"`
-	UserAvatarUrl      *string              `json:"user_avatar_url,omitempty"`
-	UserDisplayName    *string              `json:"user_display_name,omitempty"`
-	UserGravatarUrl    *string              `json:"user_gravatar_url,omitempty"`
-	UserId             *string              `json:"user_id,omitempty"`
+
+	// Type `bot` messages are posted by an integration, such as mail sent to a channel's email address. They have no user; `user_display_name` holds the name of the integration.
+	Type            *MessageType `json:"type,omitempty"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	UserAvatarUrl   *string      `json:"user_avatar_url,omitempty"`
+	UserDisplayName *string      `json:"user_display_name,omitempty"`
+	UserGravatarUrl *string      `json:"user_gravatar_url,omitempty"`
+	UserId          *string      `json:"user_id,omitempty"`
 }
 
 // SearchMessagesInput defines model for SearchMessagesInput.
@@ -1240,16 +1534,43 @@ type SendMessageInput struct {
 	AlsoSendToChannel *bool `json:"also_send_to_channel,omitempty"`
 
 	// AttachmentIds IDs of uploaded attachments to include with this message
-	AttachmentIds  *[]string `json:"attachment_ids,omitempty"`
-	Content        *string   `json:"content,omitempty"`
-	ThreadParentId *string   `json:"thread_parent_id,omitempty"`
+	AttachmentIds *[]string `json:"attachment_ids,omitempty"`
+
+	// ConfirmMentions Send even though @here, @channel or @everyone would notify more people than the workspace's confirmation threshold
+	ConfirmMentions *bool   `json:"confirm_mentions,omitempty"`
+	Content         *string `json:"content,omitempty"`
+	ThreadParentId  *string `json:"thread_parent_id,omitempty"`
 }
 
 // ServerInfo defines model for ServerInfo.
 type ServerInfo struct {
-	EmailEnabled *bool  `json:"email_enabled,omitempty"`
-	FilesEnabled *bool  `json:"files_enabled,omitempty"`
-	Version      string `json:"version"`
+	EmailEnabled *bool `json:"email_enabled,omitempty"`
+	FilesEnabled *bool `json:"files_enabled,omitempty"`
+
+	// InboundEmailEnabled Whether the server receives email, so channels can be given email addresses
+	InboundEmailEnabled *bool `json:"inbound_email_enabled,omitempty"`
+
+	// RegistrationMode Who may create an account. `invite_only` requires a workspace invite code, `domain_allowlist` requires an email address in one of the allowed domains, `approval` admits anyone but a site admin must approve the account before it can sign in, and `closed` admits nobody. A usable invite code bypasses every mode except `closed`. Clients should hide the sign-up form when closed.
+	RegistrationMode *RegistrationMode `json:"registration_mode,omitempty"`
+	Version          string            `json:"version"`
+
+	// WebPushPublicKey The server's VAPID public key, base64url encoded, for subscribing browsers to Web Push. Absent when Web Push is disabled.
+	WebPushPublicKey *string `json:"web_push_public_key,omitempty"`
+
+	// WorkspaceCreation Who may create workspaces
+	WorkspaceCreation *WorkspaceCreationPolicy `json:"workspace_creation,omitempty"`
+}
+
+// ServerSettings defines model for ServerSettings.
+type ServerSettings struct {
+	// AllowedEmailDomains Lower-case domains that may register in domain_allowlist mode. Subdomains must be listed separately.
+	AllowedEmailDomains []string `json:"allowed_email_domains"`
+
+	// RegistrationMode Who may create an account. `invite_only` requires a workspace invite code, `domain_allowlist` requires an email address in one of the allowed domains, `approval` admits anyone but a site admin must approve the account before it can sign in, and `closed` admits nobody. A usable invite code bypasses every mode except `closed`. Clients should hide the sign-up form when closed.
+	RegistrationMode RegistrationMode `json:"registration_mode"`
+
+	// WorkspaceCreation Who may create workspaces
+	WorkspaceCreation WorkspaceCreationPolicy `json:"workspace_creation"`
 }
 
 // SignedUrl defines model for SignedUrl.
@@ -1325,12 +1646,14 @@ type ThreadMessage struct {
 	SystemEvent        *SystemEventData     `json:"system_event,omitempty"`
 	ThreadParentId     *string              `json:"thread_parent_id,omitempty"`
 	ThreadParticipants *[]ThreadParticipant `json:"thread_participants,omitempty"`
-	Type               *MessageType         `json:"type,omitempty"`
-	UpdatedAt          time.Time            `json:"updated_at"`
-	UserAvatarUrl      *string              `json:"user_avatar_url,omitempty"`
-	UserDisplayName    *string              `json:"user_display_name,omitempty"`
-	UserGravatarUrl    *string              `json:"user_gravatar_url,omitempty"`
-	UserId             *string              `json:"user_id,omitempty"`
+
+	// Type `bot` messages are posted by an integration, such as mail sent to a channel's email address. They have no user; `user_display_name` holds the name of the integration.
+	Type            *MessageType `json:"type,omitempty"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	UserAvatarUrl   *string      `json:"user_avatar_url,omitempty"`
+	UserDisplayName *string      `json:"user_display_name,omitempty"`
+	UserGravatarUrl *string      `json:"user_gravatar_url,omitempty"`
+	UserId          *string      `json:"user_id,omitempty"`
 }
 
 // ThreadParticipant defines model for ThreadParticipant.
@@ -1372,12 +1695,14 @@ type UnreadMessage struct {
 	SystemEvent        *SystemEventData     `json:"system_event,omitempty"`
 	ThreadParentId     *string              `json:"thread_parent_id,omitempty"`
 	ThreadParticipants *[]ThreadParticipant `json:"thread_participants,omitempty"`
-	Type               *MessageType         `json:"type,omitempty"`
-	UpdatedAt          time.Time            `json:"updated_at"`
-	UserAvatarUrl      *string              `json:"user_avatar_url,omitempty"`
-	UserDisplayName    *string              `json:"user_display_name,omitempty"`
-	UserGravatarUrl    *string              `json:"user_gravatar_url,omitempty"`
-	UserId             *string              `json:"user_id,omitempty"`
+
+	// Type `bot` messages are posted by an integration, such as mail sent to a channel's email address. They have no user; `user_display_name` holds the name of the integration.
+	Type            *MessageType `json:"type,omitempty"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	UserAvatarUrl   *string      `json:"user_avatar_url,omitempty"`
+	UserDisplayName *string      `json:"user_display_name,omitempty"`
+	UserGravatarUrl *string      `json:"user_gravatar_url,omitempty"`
+	UserId          *string      `json:"user_id,omitempty"`
 }
 
 // UnreadMessagesResult defines model for UnreadMessagesResult.
@@ -1387,6 +1712,12 @@ type UnreadMessagesResult struct {
 	NextCursor *string         `json:"next_cursor,omitempty"`
 }
 
+// UpdateChannelEmailAddressInput defines model for UpdateChannelEmailAddressInput.
+type UpdateChannelEmailAddressInput struct {
+	// AllowedSenders Addresses such as `alerts@example.com` and domains such as `example.com` to accept mail from. Empty accepts mail from anyone.
+	AllowedSenders []string `json:"allowed_senders"`
+}
+
 // UpdateChannelInput defines model for UpdateChannelInput.
 type UpdateChannelInput struct {
 	Description *string      `json:"description,omitempty"`
@@ -1406,13 +1737,25 @@ type UpdateScheduledMessageInput struct {
 	ScheduledFor  *time.Time `json:"scheduled_for,omitempty"`
 }
 
+// UpdateServerSettingsInput defines model for UpdateServerSettingsInput.
+type UpdateServerSettingsInput struct {
+	AllowedEmailDomains *[]string `json:"allowed_email_domains,omitempty"`
+
+	// RegistrationMode Who may create an account. `invite_only` requires a workspace invite code, `domain_allowlist` requires an email address in one of the allowed domains, `approval` admits anyone but a site admin must approve the account before it can sign in, and `closed` admits nobody. A usable invite code bypasses every mode except `closed`. Clients should hide the sign-up form when closed.
+	RegistrationMode *RegistrationMode `json:"registration_mode,omitempty"`
+
+	// WorkspaceCreation Who may create workspaces
+	WorkspaceCreation *WorkspaceCreationPolicy `json:"workspace_creation,omitempty"`
+}
+
 // UpdateWorkspaceInput defines model for UpdateWorkspaceInput.
 type UpdateWorkspaceInput struct {
 	Name *string `json:"name,omitempty"`
 
 	// Settings Partial workspace settings to update. Only provided fields are changed.
 	Settings *struct {
-		ShowJoinLeaveMessages *bool `json:"show_join_leave_messages,omitempty"`
+		MentionConfirmThreshold *int  `json:"mention_confirm_threshold,omitempty"`
+		ShowJoinLeaveMessages   *bool `json:"show_join_leave_messages,omitempty"`
 
 		// WhoCanCreateChannels Controls which workspace roles can perform an action
 		WhoCanCreateChannels *PermissionLevel `json:"who_can_create_channels,omitempty"`
@@ -1423,6 +1766,15 @@ type UpdateWorkspaceInput struct {
 		// WhoCanManageCustomEmoji Controls which workspace roles can perform an action
 		WhoCanManageCustomEmoji *PermissionLevel `json:"who_can_manage_custom_emoji,omitempty"`
 
+		// WhoCanMentionChannel Controls which workspace roles can perform an action
+		WhoCanMentionChannel *PermissionLevel `json:"who_can_mention_channel,omitempty"`
+
+		// WhoCanMentionEveryone Controls which workspace roles can perform an action
+		WhoCanMentionEveryone *PermissionLevel `json:"who_can_mention_everyone,omitempty"`
+
+		// WhoCanMentionHere Controls which workspace roles can perform an action
+		WhoCanMentionHere *PermissionLevel `json:"who_can_mention_here,omitempty"`
+
 		// WhoCanPinMessages Controls which workspace roles can perform an action
 		WhoCanPinMessages *PermissionLevel `json:"who_can_pin_messages,omitempty"`
 	} `json:"settings,omitempty"`
@@ -1437,8 +1789,14 @@ type User struct {
 	EmailVerifiedAt *time.Time          `json:"email_verified_at,omitempty"`
 	GravatarUrl     *string             `json:"gravatar_url,omitempty"`
 	Id              string              `json:"id"`
-	Status          string              `json:"status"`
-	UpdatedAt       time.Time           `json:"updated_at"`
+
+	// IsSiteAdmin Whether the user can manage the whole instance through the admin endpoints
+	IsSiteAdmin *bool `json:"is_site_admin,omitempty"`
+
+	// PendingApproval Whether the account is waiting for a site admin's approval before it can sign in
+	PendingApproval *bool     `json:"pending_approval,omitempty"`
+	Status          string    `json:"status"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 // UserProfile defines model for UserProfile.
@@ -1464,6 +1822,9 @@ type Workspace struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// WorkspaceCreationPolicy Who may create workspaces
+type WorkspaceCreationPolicy string
+
 // WorkspaceIconUploadResponse defines model for WorkspaceIconUploadResponse.
 type WorkspaceIconUploadResponse struct {
 	IconUrl string `json:"icon_url"`
@@ -1516,6 +1877,9 @@ type WorkspaceRole string
 
 // WorkspaceSettings defines model for WorkspaceSettings.
 type WorkspaceSettings struct {
+	// MentionConfirmThreshold Number of people above which @here, @channel and @everyone must be confirmed before sending. 0 never asks.
+	MentionConfirmThreshold *int `json:"mention_confirm_threshold,omitempty"`
+
 	// ShowJoinLeaveMessages Whether to show system messages when users join or leave channels
 	ShowJoinLeaveMessages *bool `json:"show_join_leave_messages,omitempty"`
 
@@ -1528,6 +1892,15 @@ type WorkspaceSettings struct {
 	// WhoCanManageCustomEmoji Controls which workspace roles can perform an action
 	WhoCanManageCustomEmoji *PermissionLevel `json:"who_can_manage_custom_emoji,omitempty"`
 
+	// WhoCanMentionChannel Controls which workspace roles can perform an action
+	WhoCanMentionChannel *PermissionLevel `json:"who_can_mention_channel,omitempty"`
+
+	// WhoCanMentionEveryone Controls which workspace roles can perform an action
+	WhoCanMentionEveryone *PermissionLevel `json:"who_can_mention_everyone,omitempty"`
+
+	// WhoCanMentionHere Controls which workspace roles can perform an action
+	WhoCanMentionHere *PermissionLevel `json:"who_can_mention_here,omitempty"`
+
 	// WhoCanPinMessages Controls which workspace roles can perform an action
 	WhoCanPinMessages *PermissionLevel `json:"who_can_pin_messages,omitempty"`
 }
@@ -1572,6 +1945,11 @@ type NotFound = ApiErrorResponse
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = ApiErrorResponse
 
+// SetSiteAdminJSONBody defines parameters for SetSiteAdmin.
+type SetSiteAdminJSONBody struct {
+	IsSiteAdmin bool `json:"is_site_admin"`
+}
+
 // ForgotPasswordJSONBody defines parameters for ForgotPassword.
 type ForgotPasswordJSONBody struct {
 	Email openapi_types.Email `json:"email"`
@@ -1605,12 +1983,23 @@ type AddChannelMemberJSONBody struct {
 	UserId string       `json:"user_id"`
 }
 
+// UpdateChannelNotificationDefaultsJSONBody defines parameters for UpdateChannelNotificationDefaults.
+type UpdateChannelNotificationDefaultsJSONBody struct {
+	NotifyLevel NotifyLevel `json:"notify_level"`
+}
+
 // ListPinnedMessagesJSONBody defines parameters for ListPinnedMessages.
 type ListPinnedMessagesJSONBody struct {
 	Cursor *string `json:"cursor,omitempty"`
 	Limit  *int    `json:"limit,omitempty"`
 }
 
+// UnsubscribeFromEmailParams defines parameters for UnsubscribeFromEmail.
+type UnsubscribeFromEmailParams struct {
+	// Token Signed unsubscribe token from the email
+	Token string `form:"token" json:"token"`
+}
+
 // SignFileUrlsJSONBody defines parameters for SignFileUrls.
 type SignFileUrlsJSONBody struct {
 	FileIds []string `json:"file_ids"`
@@ -1715,6 +2104,21 @@ type ListAllUnreadsJSONBody struct {
 	Limit  *int    `json:"limit,omitempty"`
 }
 
+// ListQueuedEmailsJSONRequestBody defines body for ListQueuedEmails for application/json ContentType.
+type ListQueuedEmailsJSONRequestBody = ListQueuedEmailsInput
+
+// UpdateServerSettingsJSONRequestBody defines body for UpdateServerSettings for application/json ContentType.
+type UpdateServerSettingsJSONRequestBody = UpdateServerSettingsInput
+
+// ListAdminUsersJSONRequestBody defines body for ListAdminUsers for application/json ContentType.
+type ListAdminUsersJSONRequestBody = ListAdminUsersInput
+
+// SetSiteAdminJSONRequestBody defines body for SetSiteAdmin for application/json ContentType.
+type SetSiteAdminJSONRequestBody SetSiteAdminJSONBody
+
+// ListAdminWorkspacesJSONRequestBody defines body for ListAdminWorkspaces for application/json ContentType.
+type ListAdminWorkspacesJSONRequestBody = AdminListInput
+
 // RegisterDeviceTokenJSONRequestBody defines body for RegisterDeviceToken for application/json ContentType.
 type RegisterDeviceTokenJSONRequestBody = RegisterDeviceTokenRequest
 
@@ -1733,9 +2137,15 @@ type ResetPasswordJSONRequestBody ResetPasswordJSONBody
 // VerifyEmailJSONRequestBody defines body for VerifyEmail for application/json ContentType.
 type VerifyEmailJSONRequestBody VerifyEmailJSONBody
 
+// RegisterWebPushSubscriptionJSONRequestBody defines body for RegisterWebPushSubscription for application/json ContentType.
+type RegisterWebPushSubscriptionJSONRequestBody = RegisterWebPushSubscriptionRequest
+
 // ConvertGroupDMToChannelJSONRequestBody defines body for ConvertGroupDMToChannel for application/json ContentType.
 type ConvertGroupDMToChannelJSONRequestBody = ConvertGroupDMInput
 
+// UpdateChannelEmailAddressJSONRequestBody defines body for UpdateChannelEmailAddress for application/json ContentType.
+type UpdateChannelEmailAddressJSONRequestBody = UpdateChannelEmailAddressInput
+
 // UploadFileMultipartRequestBody defines body for UploadFile for multipart/form-data ContentType.
 type UploadFileMultipartRequestBody UploadFileMultipartBody
 
@@ -1745,6 +2155,9 @@ type MarkChannelReadJSONRequestBody MarkChannelReadJSONBody
 // AddChannelMemberJSONRequestBody defines body for AddChannelMember for application/json ContentType.
 type AddChannelMemberJSONRequestBody AddChannelMemberJSONBody
 
+// UpdateChannelMentionPermissionsJSONRequestBody defines body for UpdateChannelMentionPermissions for application/json ContentType.
+type UpdateChannelMentionPermissionsJSONRequestBody = ChannelMentionPermissions
+
 // ListMessagesJSONRequestBody defines body for ListMessages for application/json ContentType.
 type ListMessagesJSONRequestBody = ListMessagesInput
 
@@ -1754,6 +2167,9 @@ type ScheduleMessageJSONRequestBody = ScheduleMessageInput
 // SendMessageJSONRequestBody defines body for SendMessage for application/json ContentType.
 type SendMessageJSONRequestBody = SendMessageInput
 
+// UpdateChannelNotificationDefaultsJSONRequestBody defines body for UpdateChannelNotificationDefaults for application/json ContentType.
+type UpdateChannelNotificationDefaultsJSONRequestBody UpdateChannelNotificationDefaultsJSONBody
+
 // UpdateChannelNotificationsJSONRequestBody defines body for UpdateChannelNotifications for application/json ContentType.
 type UpdateChannelNotificationsJSONRequestBody = NotificationPreferences
 
@@ -1787,6 +2203,9 @@ type UpdateScheduledMessageJSONRequestBody = UpdateScheduledMessageInput
 // UploadAvatarMultipartRequestBody defines body for UploadAvatar for multipart/form-data ContentType.
 type UploadAvatarMultipartRequestBody UploadAvatarMultipartBody
 
+// UpdateNotificationSettingsJSONRequestBody defines body for UpdateNotificationSettings for application/json ContentType.
+type UpdateNotificationSettingsJSONRequestBody = NotificationSettings
+
 // UpdateProfileJSONRequestBody defines body for UpdateProfile for application/json ContentType.
 type UpdateProfileJSONRequestBody = UpdateProfileInput
 
@@ -1838,6 +2257,12 @@ type SearchMessagesJSONRequestBody = SearchMessagesInput
 // ListModerationLogJSONRequestBody defines body for ListModerationLog for application/json ContentType.
 type ListModerationLogJSONRequestBody ListModerationLogJSONBody
 
+// UpdateWorkspaceNotificationDefaultsJSONRequestBody defines body for UpdateWorkspaceNotificationDefaults for application/json ContentType.
+type UpdateWorkspaceNotificationDefaultsJSONRequestBody = NotificationDefaults
+
+// UpdateNotificationDefaultsJSONRequestBody defines body for UpdateNotificationDefaults for application/json ContentType.
+type UpdateNotificationDefaultsJSONRequestBody = NotificationDefaults
+
 // ListUserThreadsJSONRequestBody defines body for ListUserThreads for application/json ContentType.
 type ListUserThreadsJSONRequestBody ListUserThreadsJSONBody
 
@@ -2868,6 +3293,42 @@ func (t *SSEEvent) UnmarshalJSON(b []byte) error {
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List queued emails
+	// (POST /admin/emails/list)
+	ListQueuedEmails(w http.ResponseWriter, r *http.Request)
+	// Retry a failed email
+	// (POST /admin/emails/{id}/retry)
+	RetryQueuedEmail(w http.ResponseWriter, r *http.Request, id string)
+	// Get server settings
+	// (GET /admin/settings)
+	GetServerSettings(w http.ResponseWriter, r *http.Request)
+	// Update server settings
+	// (POST /admin/settings/update)
+	UpdateServerSettings(w http.ResponseWriter, r *http.Request)
+	// Get instance statistics
+	// (GET /admin/stats)
+	GetAdminStats(w http.ResponseWriter, r *http.Request)
+	// List users
+	// (POST /admin/users/list)
+	ListAdminUsers(w http.ResponseWriter, r *http.Request)
+	// Approve a pending account
+	// (POST /admin/users/{id}/approve)
+	ApproveUser(w http.ResponseWriter, r *http.Request, id string)
+	// Disable a user account
+	// (POST /admin/users/{id}/disable)
+	DisableUser(w http.ResponseWriter, r *http.Request, id string)
+	// Re-enable a user account
+	// (POST /admin/users/{id}/enable)
+	EnableUser(w http.ResponseWriter, r *http.Request, id string)
+	// Reject a pending account
+	// (POST /admin/users/{id}/reject)
+	RejectUser(w http.ResponseWriter, r *http.Request, id string)
+	// Grant or revoke site admin
+	// (POST /admin/users/{id}/site-admin)
+	SetSiteAdmin(w http.ResponseWriter, r *http.Request, id string)
+	// List workspaces
+	// (POST /admin/workspaces/list)
+	ListAdminWorkspaces(w http.ResponseWriter, r *http.Request)
 	// Register a device token for push notifications
 	// (POST /auth/device-tokens)
 	RegisterDeviceToken(w http.ResponseWriter, r *http.Request)
@@ -2898,12 +3359,30 @@ type ServerInterface interface {
 	// Verify email address with token
 	// (POST /auth/verify-email)
 	VerifyEmail(w http.ResponseWriter, r *http.Request)
+	// Register a browser for Web Push notifications
+	// (POST /auth/web-push-subscriptions)
+	RegisterWebPushSubscription(w http.ResponseWriter, r *http.Request)
+	// Unregister a browser from Web Push notifications
+	// (DELETE /auth/web-push-subscriptions/{id})
+	UnregisterWebPushSubscription(w http.ResponseWriter, r *http.Request, id string)
 	// Archive channel
 	// (POST /channels/{id}/archive)
 	ArchiveChannel(w http.ResponseWriter, r *http.Request, id ChannelId)
 	// Convert group DM to channel
 	// (POST /channels/{id}/convert)
 	ConvertGroupDMToChannel(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Remove channel email address
+	// (DELETE /channels/{id}/email-address)
+	DeleteChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Get channel email address
+	// (GET /channels/{id}/email-address)
+	GetChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Set channel email address
+	// (POST /channels/{id}/email-address)
+	UpdateChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Rotate channel email address
+	// (POST /channels/{id}/email-address/rotate)
+	RotateChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId)
 	// Upload a file
 	// (POST /channels/{id}/files/upload)
 	UploadFile(w http.ResponseWriter, r *http.Request, id ChannelId)
@@ -2922,6 +3401,12 @@ type ServerInterface interface {
 	// List channel members
 	// (POST /channels/{id}/members/list)
 	ListChannelMembers(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Get channel mention permissions
+	// (GET /channels/{id}/mention-permissions)
+	GetChannelMentionPermissions(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Set channel mention permissions
+	// (POST /channels/{id}/mention-permissions)
+	UpdateChannelMentionPermissions(w http.ResponseWriter, r *http.Request, id ChannelId)
 	// List messages in channel
 	// (POST /channels/{id}/messages/list)
 	ListMessages(w http.ResponseWriter, r *http.Request, id ChannelId)
@@ -2931,6 +3416,18 @@ type ServerInterface interface {
 	// Send a message
 	// (POST /channels/{id}/messages/send)
 	SendMessage(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Remove channel default notify level
+	// (DELETE /channels/{id}/notification-defaults)
+	DeleteChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Get channel default notify level
+	// (GET /channels/{id}/notification-defaults)
+	GetChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Set channel default notify level
+	// (POST /channels/{id}/notification-defaults)
+	UpdateChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Reset channel notification preferences
+	// (DELETE /channels/{id}/notifications)
+	ResetChannelNotifications(w http.ResponseWriter, r *http.Request, id ChannelId)
 	// Get channel notification preferences
 	// (GET /channels/{id}/notifications)
 	GetChannelNotifications(w http.ResponseWriter, r *http.Request, id ChannelId)
@@ -2949,6 +3446,9 @@ type ServerInterface interface {
 	// Update channel
 	// (POST /channels/{id}/update)
 	UpdateChannel(w http.ResponseWriter, r *http.Request, id ChannelId)
+	// Unsubscribe from notification emails
+	// (POST /email/unsubscribe)
+	UnsubscribeFromEmail(w http.ResponseWriter, r *http.Request, params UnsubscribeFromEmailParams)
 	// Delete a custom emoji
 	// (POST /emojis/{id}/delete)
 	DeleteCustomEmoji(w http.ResponseWriter, r *http.Request, id string)
@@ -3030,6 +3530,12 @@ type ServerInterface interface {
 	// Upload avatar image
 	// (POST /users/me/avatar)
 	UploadAvatar(w http.ResponseWriter, r *http.Request)
+	// Get notification settings
+	// (GET /users/me/notification-settings)
+	GetNotificationSettings(w http.ResponseWriter, r *http.Request)
+	// Update notification settings
+	// (POST /users/me/notification-settings)
+	UpdateNotificationSettings(w http.ResponseWriter, r *http.Request)
 	// Update own profile
 	// (POST /users/me/profile)
 	UpdateProfile(w http.ResponseWriter, r *http.Request)
@@ -3111,6 +3617,21 @@ type ServerInterface interface {
 	// List moderation audit log
 	// (POST /workspaces/{wid}/moderation-log/list)
 	ListModerationLog(w http.ResponseWriter, r *http.Request, wid WorkspaceId)
+	// Get workspace notification defaults
+	// (GET /workspaces/{wid}/notification-defaults)
+	GetWorkspaceNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId)
+	// Set workspace notification defaults
+	// (POST /workspaces/{wid}/notification-defaults)
+	UpdateWorkspaceNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId)
+	// Get notification preferences for all channels
+	// (GET /workspaces/{wid}/notification-preferences)
+	GetWorkspaceNotificationPreferences(w http.ResponseWriter, r *http.Request, wid WorkspaceId)
+	// Reset notification defaults
+	// (DELETE /workspaces/{wid}/notification-preferences/defaults)
+	ResetNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId)
+	// Set notification defaults
+	// (POST /workspaces/{wid}/notification-preferences/defaults)
+	UpdateNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId)
 	// List user's scheduled messages in a workspace
 	// (POST /workspaces/{wid}/scheduled-messages)
 	ListScheduledMessages(w http.ResponseWriter, r *http.Request, wid string)
@@ -3129,6 +3650,78 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// List queued emails
+// (POST /admin/emails/list)
+func (_ Unimplemented) ListQueuedEmails(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Retry a failed email
+// (POST /admin/emails/{id}/retry)
+func (_ Unimplemented) RetryQueuedEmail(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get server settings
+// (GET /admin/settings)
+func (_ Unimplemented) GetServerSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update server settings
+// (POST /admin/settings/update)
+func (_ Unimplemented) UpdateServerSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get instance statistics
+// (GET /admin/stats)
+func (_ Unimplemented) GetAdminStats(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List users
+// (POST /admin/users/list)
+func (_ Unimplemented) ListAdminUsers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve a pending account
+// (POST /admin/users/{id}/approve)
+func (_ Unimplemented) ApproveUser(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Disable a user account
+// (POST /admin/users/{id}/disable)
+func (_ Unimplemented) DisableUser(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Re-enable a user account
+// (POST /admin/users/{id}/enable)
+func (_ Unimplemented) EnableUser(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a pending account
+// (POST /admin/users/{id}/reject)
+func (_ Unimplemented) RejectUser(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Grant or revoke site admin
+// (POST /admin/users/{id}/site-admin)
+func (_ Unimplemented) SetSiteAdmin(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List workspaces
+// (POST /admin/workspaces/list)
+func (_ Unimplemented) ListAdminWorkspaces(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Register a device token for push notifications
 // (POST /auth/device-tokens)
 func (_ Unimplemented) RegisterDeviceToken(w http.ResponseWriter, r *http.Request) {
@@ -3189,6 +3782,18 @@ func (_ Unimplemented) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Register a browser for Web Push notifications
+// (POST /auth/web-push-subscriptions)
+func (_ Unimplemented) RegisterWebPushSubscription(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Unregister a browser from Web Push notifications
+// (DELETE /auth/web-push-subscriptions/{id})
+func (_ Unimplemented) UnregisterWebPushSubscription(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Archive channel
 // (POST /channels/{id}/archive)
 func (_ Unimplemented) ArchiveChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
@@ -3201,6 +3806,30 @@ func (_ Unimplemented) ConvertGroupDMToChannel(w http.ResponseWriter, r *http.Re
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Remove channel email address
+// (DELETE /channels/{id}/email-address)
+func (_ Unimplemented) DeleteChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get channel email address
+// (GET /channels/{id}/email-address)
+func (_ Unimplemented) GetChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set channel email address
+// (POST /channels/{id}/email-address)
+func (_ Unimplemented) UpdateChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Rotate channel email address
+// (POST /channels/{id}/email-address/rotate)
+func (_ Unimplemented) RotateChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Upload a file
 // (POST /channels/{id}/files/upload)
 func (_ Unimplemented) UploadFile(w http.ResponseWriter, r *http.Request, id ChannelId) {
@@ -3237,6 +3866,18 @@ func (_ Unimplemented) ListChannelMembers(w http.ResponseWriter, r *http.Request
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get channel mention permissions
+// (GET /channels/{id}/mention-permissions)
+func (_ Unimplemented) GetChannelMentionPermissions(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set channel mention permissions
+// (POST /channels/{id}/mention-permissions)
+func (_ Unimplemented) UpdateChannelMentionPermissions(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List messages in channel
 // (POST /channels/{id}/messages/list)
 func (_ Unimplemented) ListMessages(w http.ResponseWriter, r *http.Request, id ChannelId) {
@@ -3255,6 +3896,30 @@ func (_ Unimplemented) SendMessage(w http.ResponseWriter, r *http.Request, id Ch
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Remove channel default notify level
+// (DELETE /channels/{id}/notification-defaults)
+func (_ Unimplemented) DeleteChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get channel default notify level
+// (GET /channels/{id}/notification-defaults)
+func (_ Unimplemented) GetChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set channel default notify level
+// (POST /channels/{id}/notification-defaults)
+func (_ Unimplemented) UpdateChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset channel notification preferences
+// (DELETE /channels/{id}/notifications)
+func (_ Unimplemented) ResetChannelNotifications(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get channel notification preferences
 // (GET /channels/{id}/notifications)
 func (_ Unimplemented) GetChannelNotifications(w http.ResponseWriter, r *http.Request, id ChannelId) {
@@ -3291,6 +3956,12 @@ func (_ Unimplemented) UpdateChannel(w http.ResponseWriter, r *http.Request, id
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Unsubscribe from notification emails
+// (POST /email/unsubscribe)
+func (_ Unimplemented) UnsubscribeFromEmail(w http.ResponseWriter, r *http.Request, params UnsubscribeFromEmailParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Delete a custom emoji
 // (POST /emojis/{id}/delete)
 func (_ Unimplemented) DeleteCustomEmoji(w http.ResponseWriter, r *http.Request, id string) {
@@ -3453,6 +4124,18 @@ func (_ Unimplemented) UploadAvatar(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get notification settings
+// (GET /users/me/notification-settings)
+func (_ Unimplemented) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update notification settings
+// (POST /users/me/notification-settings)
+func (_ Unimplemented) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Update own profile
 // (POST /users/me/profile)
 func (_ Unimplemented) UpdateProfile(w http.ResponseWriter, r *http.Request) {
@@ -3615,6 +4298,36 @@ func (_ Unimplemented) ListModerationLog(w http.ResponseWriter, r *http.Request,
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get workspace notification defaults
+// (GET /workspaces/{wid}/notification-defaults)
+func (_ Unimplemented) GetWorkspaceNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set workspace notification defaults
+// (POST /workspaces/{wid}/notification-defaults)
+func (_ Unimplemented) UpdateWorkspaceNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get notification preferences for all channels
+// (GET /workspaces/{wid}/notification-preferences)
+func (_ Unimplemented) GetWorkspaceNotificationPreferences(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reset notification defaults
+// (DELETE /workspaces/{wid}/notification-preferences/defaults)
+func (_ Unimplemented) ResetNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set notification defaults
+// (POST /workspaces/{wid}/notification-preferences/defaults)
+func (_ Unimplemented) UpdateNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List user's scheduled messages in a workspace
 // (POST /workspaces/{wid}/scheduled-messages)
 func (_ Unimplemented) ListScheduledMessages(w http.ResponseWriter, r *http.Request, wid string) {
@@ -3648,8 +4361,8 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// RegisterDeviceToken operation middleware
-func (siw *ServerInterfaceWrapper) RegisterDeviceToken(w http.ResponseWriter, r *http.Request) {
+// ListQueuedEmails operation middleware
+func (siw *ServerInterfaceWrapper) ListQueuedEmails(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -3658,7 +4371,7 @@ func (siw *ServerInterfaceWrapper) RegisterDeviceToken(w http.ResponseWriter, r
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RegisterDeviceToken(w, r)
+		siw.Handler.ListQueuedEmails(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3668,8 +4381,8 @@ func (siw *ServerInterfaceWrapper) RegisterDeviceToken(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// UnregisterDeviceToken operation middleware
-func (siw *ServerInterfaceWrapper) UnregisterDeviceToken(w http.ResponseWriter, r *http.Request) {
+// RetryQueuedEmail operation middleware
+func (siw *ServerInterfaceWrapper) RetryQueuedEmail(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -3689,7 +4402,7 @@ func (siw *ServerInterfaceWrapper) UnregisterDeviceToken(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UnregisterDeviceToken(w, r, id)
+		siw.Handler.RetryQueuedEmail(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3699,25 +4412,17 @@ func (siw *ServerInterfaceWrapper) UnregisterDeviceToken(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// ForgotPassword operation middleware
-func (siw *ServerInterfaceWrapper) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+// GetServerSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetServerSettings(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ForgotPassword(w, r)
-	}))
+	ctx := r.Context()
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
-
-	handler.ServeHTTP(w, r)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// Login operation middleware
-func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Login(w, r)
+		siw.Handler.GetServerSettings(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3727,25 +4432,17 @@ func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request)
 	handler.ServeHTTP(w, r)
 }
 
-// Logout operation middleware
-func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request) {
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Logout(w, r)
-	}))
+// UpdateServerSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateServerSettings(w http.ResponseWriter, r *http.Request) {
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	ctx := r.Context()
 
-	handler.ServeHTTP(w, r)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// GetMe operation middleware
-func (siw *ServerInterfaceWrapper) GetMe(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetMe(w, r)
+		siw.Handler.UpdateServerSettings(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3755,11 +4452,17 @@ func (siw *ServerInterfaceWrapper) GetMe(w http.ResponseWriter, r *http.Request)
 	handler.ServeHTTP(w, r)
 }
 
-// Register operation middleware
-func (siw *ServerInterfaceWrapper) Register(w http.ResponseWriter, r *http.Request) {
+// GetAdminStats operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminStats(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Register(w, r)
+		siw.Handler.GetAdminStats(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3769,8 +4472,8 @@ func (siw *ServerInterfaceWrapper) Register(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// ResendVerification operation middleware
-func (siw *ServerInterfaceWrapper) ResendVerification(w http.ResponseWriter, r *http.Request) {
+// ListAdminUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListAdminUsers(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -3779,7 +4482,7 @@ func (siw *ServerInterfaceWrapper) ResendVerification(w http.ResponseWriter, r *
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ResendVerification(w, r)
+		siw.Handler.ListAdminUsers(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3789,25 +4492,28 @@ func (siw *ServerInterfaceWrapper) ResendVerification(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// ResetPassword operation middleware
-func (siw *ServerInterfaceWrapper) ResetPassword(w http.ResponseWriter, r *http.Request) {
+// ApproveUser operation middleware
+func (siw *ServerInterfaceWrapper) ApproveUser(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ResetPassword(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	ctx := r.Context()
 
-// VerifyEmail operation middleware
-func (siw *ServerInterfaceWrapper) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.VerifyEmail(w, r)
+		siw.Handler.ApproveUser(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3817,13 +4523,13 @@ func (siw *ServerInterfaceWrapper) VerifyEmail(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// ArchiveChannel operation middleware
-func (siw *ServerInterfaceWrapper) ArchiveChannel(w http.ResponseWriter, r *http.Request) {
+// DisableUser operation middleware
+func (siw *ServerInterfaceWrapper) DisableUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	var id string
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -3838,7 +4544,7 @@ func (siw *ServerInterfaceWrapper) ArchiveChannel(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ArchiveChannel(w, r, id)
+		siw.Handler.DisableUser(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3848,13 +4554,13 @@ func (siw *ServerInterfaceWrapper) ArchiveChannel(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// ConvertGroupDMToChannel operation middleware
-func (siw *ServerInterfaceWrapper) ConvertGroupDMToChannel(w http.ResponseWriter, r *http.Request) {
+// EnableUser operation middleware
+func (siw *ServerInterfaceWrapper) EnableUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	var id string
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -3869,7 +4575,7 @@ func (siw *ServerInterfaceWrapper) ConvertGroupDMToChannel(w http.ResponseWriter
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ConvertGroupDMToChannel(w, r, id)
+		siw.Handler.EnableUser(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3879,13 +4585,13 @@ func (siw *ServerInterfaceWrapper) ConvertGroupDMToChannel(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// UploadFile operation middleware
-func (siw *ServerInterfaceWrapper) UploadFile(w http.ResponseWriter, r *http.Request) {
+// RejectUser operation middleware
+func (siw *ServerInterfaceWrapper) RejectUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	var id string
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -3900,7 +4606,7 @@ func (siw *ServerInterfaceWrapper) UploadFile(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UploadFile(w, r, id)
+		siw.Handler.RejectUser(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3910,13 +4616,13 @@ func (siw *ServerInterfaceWrapper) UploadFile(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// JoinChannel operation middleware
-func (siw *ServerInterfaceWrapper) JoinChannel(w http.ResponseWriter, r *http.Request) {
+// SetSiteAdmin operation middleware
+func (siw *ServerInterfaceWrapper) SetSiteAdmin(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	var id string
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -3931,7 +4637,7 @@ func (siw *ServerInterfaceWrapper) JoinChannel(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.JoinChannel(w, r, id)
+		siw.Handler.SetSiteAdmin(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3941,20 +4647,29 @@ func (siw *ServerInterfaceWrapper) JoinChannel(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// LeaveChannel operation middleware
-func (siw *ServerInterfaceWrapper) LeaveChannel(w http.ResponseWriter, r *http.Request) {
+// ListAdminWorkspaces operation middleware
+func (siw *ServerInterfaceWrapper) ListAdminWorkspaces(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	ctx := r.Context()
 
-	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAdminWorkspaces(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// RegisterDeviceToken operation middleware
+func (siw *ServerInterfaceWrapper) RegisterDeviceToken(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -3962,7 +4677,7 @@ func (siw *ServerInterfaceWrapper) LeaveChannel(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LeaveChannel(w, r, id)
+		siw.Handler.RegisterDeviceToken(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -3972,13 +4687,13 @@ func (siw *ServerInterfaceWrapper) LeaveChannel(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// MarkChannelRead operation middleware
-func (siw *ServerInterfaceWrapper) MarkChannelRead(w http.ResponseWriter, r *http.Request) {
+// UnregisterDeviceToken operation middleware
+func (siw *ServerInterfaceWrapper) UnregisterDeviceToken(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	var id string
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -3993,7 +4708,7 @@ func (siw *ServerInterfaceWrapper) MarkChannelRead(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.MarkChannelRead(w, r, id)
+		siw.Handler.UnregisterDeviceToken(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4003,28 +4718,39 @@ func (siw *ServerInterfaceWrapper) MarkChannelRead(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// AddChannelMember operation middleware
-func (siw *ServerInterfaceWrapper) AddChannelMember(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// ForgotPassword operation middleware
+func (siw *ServerInterfaceWrapper) ForgotPassword(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ForgotPassword(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	ctx := r.Context()
+	handler.ServeHTTP(w, r)
+}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+// Login operation middleware
+func (siw *ServerInterfaceWrapper) Login(w http.ResponseWriter, r *http.Request) {
 
-	r = r.WithContext(ctx)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Login(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Logout operation middleware
+func (siw *ServerInterfaceWrapper) Logout(w http.ResponseWriter, r *http.Request) {
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AddChannelMember(w, r, id)
+		siw.Handler.Logout(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4034,20 +4760,37 @@ func (siw *ServerInterfaceWrapper) AddChannelMember(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// ListChannelMembers operation middleware
-func (siw *ServerInterfaceWrapper) ListChannelMembers(w http.ResponseWriter, r *http.Request) {
+// GetMe operation middleware
+func (siw *ServerInterfaceWrapper) GetMe(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMe(w, r)
+	}))
 
-	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+	handler.ServeHTTP(w, r)
+}
+
+// Register operation middleware
+func (siw *ServerInterfaceWrapper) Register(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Register(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// ResendVerification operation middleware
+func (siw *ServerInterfaceWrapper) ResendVerification(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -4055,7 +4798,7 @@ func (siw *ServerInterfaceWrapper) ListChannelMembers(w http.ResponseWriter, r *
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListChannelMembers(w, r, id)
+		siw.Handler.ResendVerification(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4065,20 +4808,37 @@ func (siw *ServerInterfaceWrapper) ListChannelMembers(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// ListMessages operation middleware
-func (siw *ServerInterfaceWrapper) ListMessages(w http.ResponseWriter, r *http.Request) {
+// ResetPassword operation middleware
+func (siw *ServerInterfaceWrapper) ResetPassword(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetPassword(w, r)
+	}))
 
-	// ------------- Path parameter "id" -------------
-	var id ChannelId
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
+	handler.ServeHTTP(w, r)
+}
+
+// VerifyEmail operation middleware
+func (siw *ServerInterfaceWrapper) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VerifyEmail(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// RegisterWebPushSubscription operation middleware
+func (siw *ServerInterfaceWrapper) RegisterWebPushSubscription(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -4086,7 +4846,7 @@ func (siw *ServerInterfaceWrapper) ListMessages(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListMessages(w, r, id)
+		siw.Handler.RegisterWebPushSubscription(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4096,8 +4856,8 @@ func (siw *ServerInterfaceWrapper) ListMessages(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// ScheduleMessage operation middleware
-func (siw *ServerInterfaceWrapper) ScheduleMessage(w http.ResponseWriter, r *http.Request) {
+// UnregisterWebPushSubscription operation middleware
+func (siw *ServerInterfaceWrapper) UnregisterWebPushSubscription(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -4117,7 +4877,7 @@ func (siw *ServerInterfaceWrapper) ScheduleMessage(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ScheduleMessage(w, r, id)
+		siw.Handler.UnregisterWebPushSubscription(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4127,8 +4887,8 @@ func (siw *ServerInterfaceWrapper) ScheduleMessage(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// SendMessage operation middleware
-func (siw *ServerInterfaceWrapper) SendMessage(w http.ResponseWriter, r *http.Request) {
+// ArchiveChannel operation middleware
+func (siw *ServerInterfaceWrapper) ArchiveChannel(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -4148,7 +4908,7 @@ func (siw *ServerInterfaceWrapper) SendMessage(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SendMessage(w, r, id)
+		siw.Handler.ArchiveChannel(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4158,8 +4918,8 @@ func (siw *ServerInterfaceWrapper) SendMessage(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// GetChannelNotifications operation middleware
-func (siw *ServerInterfaceWrapper) GetChannelNotifications(w http.ResponseWriter, r *http.Request) {
+// ConvertGroupDMToChannel operation middleware
+func (siw *ServerInterfaceWrapper) ConvertGroupDMToChannel(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -4179,7 +4939,7 @@ func (siw *ServerInterfaceWrapper) GetChannelNotifications(w http.ResponseWriter
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetChannelNotifications(w, r, id)
+		siw.Handler.ConvertGroupDMToChannel(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4189,8 +4949,8 @@ func (siw *ServerInterfaceWrapper) GetChannelNotifications(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateChannelNotifications operation middleware
-func (siw *ServerInterfaceWrapper) UpdateChannelNotifications(w http.ResponseWriter, r *http.Request) {
+// DeleteChannelEmailAddress operation middleware
+func (siw *ServerInterfaceWrapper) DeleteChannelEmailAddress(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -4210,7 +4970,7 @@ func (siw *ServerInterfaceWrapper) UpdateChannelNotifications(w http.ResponseWri
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateChannelNotifications(w, r, id)
+		siw.Handler.DeleteChannelEmailAddress(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4220,8 +4980,8 @@ func (siw *ServerInterfaceWrapper) UpdateChannelNotifications(w http.ResponseWri
 	handler.ServeHTTP(w, r)
 }
 
-// ListPinnedMessages operation middleware
-func (siw *ServerInterfaceWrapper) ListPinnedMessages(w http.ResponseWriter, r *http.Request) {
+// GetChannelEmailAddress operation middleware
+func (siw *ServerInterfaceWrapper) GetChannelEmailAddress(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -4241,7 +5001,7 @@ func (siw *ServerInterfaceWrapper) ListPinnedMessages(w http.ResponseWriter, r *
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListPinnedMessages(w, r, id)
+		siw.Handler.GetChannelEmailAddress(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4251,8 +5011,8 @@ func (siw *ServerInterfaceWrapper) ListPinnedMessages(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
-// UnstarChannel operation middleware
-func (siw *ServerInterfaceWrapper) UnstarChannel(w http.ResponseWriter, r *http.Request) {
+// UpdateChannelEmailAddress operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChannelEmailAddress(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -4272,7 +5032,7 @@ func (siw *ServerInterfaceWrapper) UnstarChannel(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UnstarChannel(w, r, id)
+		siw.Handler.UpdateChannelEmailAddress(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4282,8 +5042,8 @@ func (siw *ServerInterfaceWrapper) UnstarChannel(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// StarChannel operation middleware
-func (siw *ServerInterfaceWrapper) StarChannel(w http.ResponseWriter, r *http.Request) {
+// RotateChannelEmailAddress operation middleware
+func (siw *ServerInterfaceWrapper) RotateChannelEmailAddress(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -4303,7 +5063,7 @@ func (siw *ServerInterfaceWrapper) StarChannel(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.StarChannel(w, r, id)
+		siw.Handler.RotateChannelEmailAddress(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4313,8 +5073,8 @@ func (siw *ServerInterfaceWrapper) StarChannel(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateChannel operation middleware
-func (siw *ServerInterfaceWrapper) UpdateChannel(w http.ResponseWriter, r *http.Request) {
+// UploadFile operation middleware
+func (siw *ServerInterfaceWrapper) UploadFile(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -4334,7 +5094,7 @@ func (siw *ServerInterfaceWrapper) UpdateChannel(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateChannel(w, r, id)
+		siw.Handler.UploadFile(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4344,13 +5104,13 @@ func (siw *ServerInterfaceWrapper) UpdateChannel(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteCustomEmoji operation middleware
-func (siw *ServerInterfaceWrapper) DeleteCustomEmoji(w http.ResponseWriter, r *http.Request) {
+// JoinChannel operation middleware
+func (siw *ServerInterfaceWrapper) JoinChannel(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4365,7 +5125,7 @@ func (siw *ServerInterfaceWrapper) DeleteCustomEmoji(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteCustomEmoji(w, r, id)
+		siw.Handler.JoinChannel(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4375,8 +5135,19 @@ func (siw *ServerInterfaceWrapper) DeleteCustomEmoji(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// SignFileUrls operation middleware
-func (siw *ServerInterfaceWrapper) SignFileUrls(w http.ResponseWriter, r *http.Request) {
+// LeaveChannel operation middleware
+func (siw *ServerInterfaceWrapper) LeaveChannel(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id ChannelId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -4385,7 +5156,7 @@ func (siw *ServerInterfaceWrapper) SignFileUrls(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SignFileUrls(w, r)
+		siw.Handler.LeaveChannel(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4395,13 +5166,13 @@ func (siw *ServerInterfaceWrapper) SignFileUrls(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteFile operation middleware
-func (siw *ServerInterfaceWrapper) DeleteFile(w http.ResponseWriter, r *http.Request) {
+// MarkChannelRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkChannelRead(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4416,7 +5187,7 @@ func (siw *ServerInterfaceWrapper) DeleteFile(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteFile(w, r, id)
+		siw.Handler.MarkChannelRead(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4426,13 +5197,13 @@ func (siw *ServerInterfaceWrapper) DeleteFile(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DownloadFile operation middleware
-func (siw *ServerInterfaceWrapper) DownloadFile(w http.ResponseWriter, r *http.Request) {
+// AddChannelMember operation middleware
+func (siw *ServerInterfaceWrapper) AddChannelMember(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4440,35 +5211,14 @@ func (siw *ServerInterfaceWrapper) DownloadFile(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params DownloadFileParams
-
-	// ------------- Optional query parameter "expires" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "expires", r.URL.Query(), &params.Expires)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expires", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "uid" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "uid", r.URL.Query(), &params.Uid)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uid", Err: err})
-		return
-	}
+	ctx := r.Context()
 
-	// ------------- Optional query parameter "sig" -------------
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	err = runtime.BindQueryParameter("form", true, false, "sig", r.URL.Query(), &params.Sig)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sig", Err: err})
-		return
-	}
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DownloadFile(w, r, id, params)
+		siw.Handler.AddChannelMember(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4478,13 +5228,13 @@ func (siw *ServerInterfaceWrapper) DownloadFile(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// SignFileUrl operation middleware
-func (siw *ServerInterfaceWrapper) SignFileUrl(w http.ResponseWriter, r *http.Request) {
+// ListChannelMembers operation middleware
+func (siw *ServerInterfaceWrapper) ListChannelMembers(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4499,7 +5249,7 @@ func (siw *ServerInterfaceWrapper) SignFileUrl(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SignFileUrl(w, r, id)
+		siw.Handler.ListChannelMembers(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4509,17 +5259,17 @@ func (siw *ServerInterfaceWrapper) SignFileUrl(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// AcceptInvite operation middleware
-func (siw *ServerInterfaceWrapper) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+// GetChannelMentionPermissions operation middleware
+func (siw *ServerInterfaceWrapper) GetChannelMentionPermissions(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "code" -------------
-	var code string
+	// ------------- Path parameter "id" -------------
+	var id ChannelId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "code", chi.URLParam(r, "code"), &code, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -4530,7 +5280,7 @@ func (siw *ServerInterfaceWrapper) AcceptInvite(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AcceptInvite(w, r, code)
+		siw.Handler.GetChannelMentionPermissions(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4540,13 +5290,13 @@ func (siw *ServerInterfaceWrapper) AcceptInvite(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetMessage operation middleware
-func (siw *ServerInterfaceWrapper) GetMessage(w http.ResponseWriter, r *http.Request) {
+// UpdateChannelMentionPermissions operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChannelMentionPermissions(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4561,7 +5311,7 @@ func (siw *ServerInterfaceWrapper) GetMessage(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetMessage(w, r, id)
+		siw.Handler.UpdateChannelMentionPermissions(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4571,13 +5321,13 @@ func (siw *ServerInterfaceWrapper) GetMessage(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteMessage operation middleware
-func (siw *ServerInterfaceWrapper) DeleteMessage(w http.ResponseWriter, r *http.Request) {
+// ListMessages operation middleware
+func (siw *ServerInterfaceWrapper) ListMessages(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4592,7 +5342,7 @@ func (siw *ServerInterfaceWrapper) DeleteMessage(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteMessage(w, r, id)
+		siw.Handler.ListMessages(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4602,13 +5352,13 @@ func (siw *ServerInterfaceWrapper) DeleteMessage(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteLinkPreview operation middleware
-func (siw *ServerInterfaceWrapper) DeleteLinkPreview(w http.ResponseWriter, r *http.Request) {
+// ScheduleMessage operation middleware
+func (siw *ServerInterfaceWrapper) ScheduleMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id string
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4623,7 +5373,7 @@ func (siw *ServerInterfaceWrapper) DeleteLinkPreview(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteLinkPreview(w, r, id)
+		siw.Handler.ScheduleMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4633,13 +5383,13 @@ func (siw *ServerInterfaceWrapper) DeleteLinkPreview(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// MarkMessageUnread operation middleware
-func (siw *ServerInterfaceWrapper) MarkMessageUnread(w http.ResponseWriter, r *http.Request) {
+// SendMessage operation middleware
+func (siw *ServerInterfaceWrapper) SendMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4654,7 +5404,7 @@ func (siw *ServerInterfaceWrapper) MarkMessageUnread(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.MarkMessageUnread(w, r, id)
+		siw.Handler.SendMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4664,13 +5414,13 @@ func (siw *ServerInterfaceWrapper) MarkMessageUnread(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// PinMessage operation middleware
-func (siw *ServerInterfaceWrapper) PinMessage(w http.ResponseWriter, r *http.Request) {
+// DeleteChannelNotificationDefaults operation middleware
+func (siw *ServerInterfaceWrapper) DeleteChannelNotificationDefaults(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4685,7 +5435,7 @@ func (siw *ServerInterfaceWrapper) PinMessage(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PinMessage(w, r, id)
+		siw.Handler.DeleteChannelNotificationDefaults(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4695,13 +5445,13 @@ func (siw *ServerInterfaceWrapper) PinMessage(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// AddReaction operation middleware
-func (siw *ServerInterfaceWrapper) AddReaction(w http.ResponseWriter, r *http.Request) {
+// GetChannelNotificationDefaults operation middleware
+func (siw *ServerInterfaceWrapper) GetChannelNotificationDefaults(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4716,7 +5466,7 @@ func (siw *ServerInterfaceWrapper) AddReaction(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AddReaction(w, r, id)
+		siw.Handler.GetChannelNotificationDefaults(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4726,13 +5476,13 @@ func (siw *ServerInterfaceWrapper) AddReaction(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// RemoveReaction operation middleware
-func (siw *ServerInterfaceWrapper) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+// UpdateChannelNotificationDefaults operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChannelNotificationDefaults(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4747,7 +5497,7 @@ func (siw *ServerInterfaceWrapper) RemoveReaction(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemoveReaction(w, r, id)
+		siw.Handler.UpdateChannelNotificationDefaults(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4757,13 +5507,13 @@ func (siw *ServerInterfaceWrapper) RemoveReaction(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// SubscribeToThread operation middleware
-func (siw *ServerInterfaceWrapper) SubscribeToThread(w http.ResponseWriter, r *http.Request) {
+// ResetChannelNotifications operation middleware
+func (siw *ServerInterfaceWrapper) ResetChannelNotifications(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4778,7 +5528,7 @@ func (siw *ServerInterfaceWrapper) SubscribeToThread(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SubscribeToThread(w, r, id)
+		siw.Handler.ResetChannelNotifications(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4788,13 +5538,13 @@ func (siw *ServerInterfaceWrapper) SubscribeToThread(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetThreadSubscription operation middleware
-func (siw *ServerInterfaceWrapper) GetThreadSubscription(w http.ResponseWriter, r *http.Request) {
+// GetChannelNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetChannelNotifications(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4809,7 +5559,7 @@ func (siw *ServerInterfaceWrapper) GetThreadSubscription(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetThreadSubscription(w, r, id)
+		siw.Handler.GetChannelNotifications(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4819,13 +5569,13 @@ func (siw *ServerInterfaceWrapper) GetThreadSubscription(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// ListThread operation middleware
-func (siw *ServerInterfaceWrapper) ListThread(w http.ResponseWriter, r *http.Request) {
+// UpdateChannelNotifications operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChannelNotifications(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4840,7 +5590,7 @@ func (siw *ServerInterfaceWrapper) ListThread(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListThread(w, r, id)
+		siw.Handler.UpdateChannelNotifications(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4850,13 +5600,13 @@ func (siw *ServerInterfaceWrapper) ListThread(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// MarkThreadRead operation middleware
-func (siw *ServerInterfaceWrapper) MarkThreadRead(w http.ResponseWriter, r *http.Request) {
+// ListPinnedMessages operation middleware
+func (siw *ServerInterfaceWrapper) ListPinnedMessages(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4871,7 +5621,7 @@ func (siw *ServerInterfaceWrapper) MarkThreadRead(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.MarkThreadRead(w, r, id)
+		siw.Handler.ListPinnedMessages(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4881,13 +5631,13 @@ func (siw *ServerInterfaceWrapper) MarkThreadRead(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// UnpinMessage operation middleware
-func (siw *ServerInterfaceWrapper) UnpinMessage(w http.ResponseWriter, r *http.Request) {
+// UnstarChannel operation middleware
+func (siw *ServerInterfaceWrapper) UnstarChannel(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4902,7 +5652,7 @@ func (siw *ServerInterfaceWrapper) UnpinMessage(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UnpinMessage(w, r, id)
+		siw.Handler.UnstarChannel(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4912,13 +5662,13 @@ func (siw *ServerInterfaceWrapper) UnpinMessage(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// UnsubscribeFromThread operation middleware
-func (siw *ServerInterfaceWrapper) UnsubscribeFromThread(w http.ResponseWriter, r *http.Request) {
+// StarChannel operation middleware
+func (siw *ServerInterfaceWrapper) StarChannel(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4933,7 +5683,7 @@ func (siw *ServerInterfaceWrapper) UnsubscribeFromThread(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UnsubscribeFromThread(w, r, id)
+		siw.Handler.StarChannel(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4943,13 +5693,13 @@ func (siw *ServerInterfaceWrapper) UnsubscribeFromThread(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateMessage operation middleware
-func (siw *ServerInterfaceWrapper) UpdateMessage(w http.ResponseWriter, r *http.Request) {
+// UpdateChannel operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChannel(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id MessageId
+	var id ChannelId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -4964,7 +5714,7 @@ func (siw *ServerInterfaceWrapper) UpdateMessage(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateMessage(w, r, id)
+		siw.Handler.UpdateChannel(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -4974,28 +5724,31 @@ func (siw *ServerInterfaceWrapper) UpdateMessage(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetScheduledMessage operation middleware
-func (siw *ServerInterfaceWrapper) GetScheduledMessage(w http.ResponseWriter, r *http.Request) {
+// UnsubscribeFromEmail operation middleware
+func (siw *ServerInterfaceWrapper) UnsubscribeFromEmail(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UnsubscribeFromEmailParams
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+	// ------------- Required query parameter "token" -------------
 
-	ctx := r.Context()
+	if paramValue := r.URL.Query().Get("token"); paramValue != "" {
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "token"})
+		return
+	}
 
-	r = r.WithContext(ctx)
+	err = runtime.BindQueryParameter("form", true, true, "token", r.URL.Query(), &params.Token)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "token", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetScheduledMessage(w, r, id)
+		siw.Handler.UnsubscribeFromEmail(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5005,8 +5758,8 @@ func (siw *ServerInterfaceWrapper) GetScheduledMessage(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteScheduledMessage operation middleware
-func (siw *ServerInterfaceWrapper) DeleteScheduledMessage(w http.ResponseWriter, r *http.Request) {
+// DeleteCustomEmoji operation middleware
+func (siw *ServerInterfaceWrapper) DeleteCustomEmoji(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -5026,7 +5779,7 @@ func (siw *ServerInterfaceWrapper) DeleteScheduledMessage(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteScheduledMessage(w, r, id)
+		siw.Handler.DeleteCustomEmoji(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5036,19 +5789,8 @@ func (siw *ServerInterfaceWrapper) DeleteScheduledMessage(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// SendScheduledMessageNow operation middleware
-func (siw *ServerInterfaceWrapper) SendScheduledMessageNow(w http.ResponseWriter, r *http.Request) {
-
-	var err error
-
-	// ------------- Path parameter "id" -------------
-	var id string
-
-	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+// SignFileUrls operation middleware
+func (siw *ServerInterfaceWrapper) SignFileUrls(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
@@ -5057,7 +5799,7 @@ func (siw *ServerInterfaceWrapper) SendScheduledMessageNow(w http.ResponseWriter
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SendScheduledMessageNow(w, r, id)
+		siw.Handler.SignFileUrls(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5067,8 +5809,8 @@ func (siw *ServerInterfaceWrapper) SendScheduledMessageNow(w http.ResponseWriter
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateScheduledMessage operation middleware
-func (siw *ServerInterfaceWrapper) UpdateScheduledMessage(w http.ResponseWriter, r *http.Request) {
+// DeleteFile operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFile(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -5088,7 +5830,7 @@ func (siw *ServerInterfaceWrapper) UpdateScheduledMessage(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateScheduledMessage(w, r, id)
+		siw.Handler.DeleteFile(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5098,31 +5840,49 @@ func (siw *ServerInterfaceWrapper) UpdateScheduledMessage(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetServerInfo operation middleware
-func (siw *ServerInterfaceWrapper) GetServerInfo(w http.ResponseWriter, r *http.Request) {
+// DownloadFile operation middleware
+func (siw *ServerInterfaceWrapper) DownloadFile(w http.ResponseWriter, r *http.Request) {
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetServerInfo(w, r)
-	}))
+	var err error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DownloadFileParams
 
-// DeleteAvatar operation middleware
-func (siw *ServerInterfaceWrapper) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	// ------------- Optional query parameter "expires" -------------
 
-	ctx := r.Context()
+	err = runtime.BindQueryParameter("form", true, false, "expires", r.URL.Query(), &params.Expires)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "expires", Err: err})
+		return
+	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "uid" -------------
 
-	r = r.WithContext(ctx)
+	err = runtime.BindQueryParameter("form", true, false, "uid", r.URL.Query(), &params.Uid)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "uid", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sig" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sig", r.URL.Query(), &params.Sig)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sig", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteAvatar(w, r)
+		siw.Handler.DownloadFile(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5132,8 +5892,19 @@ func (siw *ServerInterfaceWrapper) DeleteAvatar(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// UploadAvatar operation middleware
-func (siw *ServerInterfaceWrapper) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+// SignFileUrl operation middleware
+func (siw *ServerInterfaceWrapper) SignFileUrl(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -5142,7 +5913,7 @@ func (siw *ServerInterfaceWrapper) UploadAvatar(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UploadAvatar(w, r)
+		siw.Handler.SignFileUrl(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5152,8 +5923,19 @@ func (siw *ServerInterfaceWrapper) UploadAvatar(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateProfile operation middleware
-func (siw *ServerInterfaceWrapper) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+// AcceptInvite operation middleware
+func (siw *ServerInterfaceWrapper) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", chi.URLParam(r, "code"), &code, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -5162,7 +5944,7 @@ func (siw *ServerInterfaceWrapper) UpdateProfile(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateProfile(w, r)
+		siw.Handler.AcceptInvite(w, r, code)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5172,13 +5954,13 @@ func (siw *ServerInterfaceWrapper) UpdateProfile(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetUser operation middleware
-func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
+// GetMessage operation middleware
+func (siw *ServerInterfaceWrapper) GetMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id MessageId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -5193,7 +5975,7 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetUser(w, r, id)
+		siw.Handler.GetMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5203,8 +5985,19 @@ func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// CreateWorkspace operation middleware
-func (siw *ServerInterfaceWrapper) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
+// DeleteMessage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMessage(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id MessageId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -5213,7 +6006,7 @@ func (siw *ServerInterfaceWrapper) CreateWorkspace(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateWorkspace(w, r)
+		siw.Handler.DeleteMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5223,8 +6016,19 @@ func (siw *ServerInterfaceWrapper) CreateWorkspace(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// GetWorkspaceNotifications operation middleware
-func (siw *ServerInterfaceWrapper) GetWorkspaceNotifications(w http.ResponseWriter, r *http.Request) {
+// DeleteLinkPreview operation middleware
+func (siw *ServerInterfaceWrapper) DeleteLinkPreview(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id MessageId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -5233,7 +6037,7 @@ func (siw *ServerInterfaceWrapper) GetWorkspaceNotifications(w http.ResponseWrit
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetWorkspaceNotifications(w, r)
+		siw.Handler.DeleteLinkPreview(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5243,8 +6047,19 @@ func (siw *ServerInterfaceWrapper) GetWorkspaceNotifications(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
-// ReorderWorkspaces operation middleware
-func (siw *ServerInterfaceWrapper) ReorderWorkspaces(w http.ResponseWriter, r *http.Request) {
+// MarkMessageUnread operation middleware
+func (siw *ServerInterfaceWrapper) MarkMessageUnread(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id MessageId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
 	ctx := r.Context()
 
@@ -5253,7 +6068,7 @@ func (siw *ServerInterfaceWrapper) ReorderWorkspaces(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ReorderWorkspaces(w, r)
+		siw.Handler.MarkMessageUnread(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5263,17 +6078,17 @@ func (siw *ServerInterfaceWrapper) ReorderWorkspaces(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetWorkspace operation middleware
-func (siw *ServerInterfaceWrapper) GetWorkspace(w http.ResponseWriter, r *http.Request) {
+// PinMessage operation middleware
+func (siw *ServerInterfaceWrapper) PinMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5284,7 +6099,7 @@ func (siw *ServerInterfaceWrapper) GetWorkspace(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetWorkspace(w, r, wid)
+		siw.Handler.PinMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5294,17 +6109,17 @@ func (siw *ServerInterfaceWrapper) GetWorkspace(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// BanUser operation middleware
-func (siw *ServerInterfaceWrapper) BanUser(w http.ResponseWriter, r *http.Request) {
+// AddReaction operation middleware
+func (siw *ServerInterfaceWrapper) AddReaction(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5315,7 +6130,7 @@ func (siw *ServerInterfaceWrapper) BanUser(w http.ResponseWriter, r *http.Reques
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.BanUser(w, r, wid)
+		siw.Handler.AddReaction(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5325,17 +6140,17 @@ func (siw *ServerInterfaceWrapper) BanUser(w http.ResponseWriter, r *http.Reques
 	handler.ServeHTTP(w, r)
 }
 
-// ListBans operation middleware
-func (siw *ServerInterfaceWrapper) ListBans(w http.ResponseWriter, r *http.Request) {
+// RemoveReaction operation middleware
+func (siw *ServerInterfaceWrapper) RemoveReaction(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5346,7 +6161,7 @@ func (siw *ServerInterfaceWrapper) ListBans(w http.ResponseWriter, r *http.Reque
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListBans(w, r, wid)
+		siw.Handler.RemoveReaction(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5356,17 +6171,17 @@ func (siw *ServerInterfaceWrapper) ListBans(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// UnbanUser operation middleware
-func (siw *ServerInterfaceWrapper) UnbanUser(w http.ResponseWriter, r *http.Request) {
+// SubscribeToThread operation middleware
+func (siw *ServerInterfaceWrapper) SubscribeToThread(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5377,7 +6192,7 @@ func (siw *ServerInterfaceWrapper) UnbanUser(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UnbanUser(w, r, wid)
+		siw.Handler.SubscribeToThread(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5387,17 +6202,17 @@ func (siw *ServerInterfaceWrapper) UnbanUser(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// BlockUser operation middleware
-func (siw *ServerInterfaceWrapper) BlockUser(w http.ResponseWriter, r *http.Request) {
+// GetThreadSubscription operation middleware
+func (siw *ServerInterfaceWrapper) GetThreadSubscription(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5408,7 +6223,7 @@ func (siw *ServerInterfaceWrapper) BlockUser(w http.ResponseWriter, r *http.Requ
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.BlockUser(w, r, wid)
+		siw.Handler.GetThreadSubscription(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5418,17 +6233,17 @@ func (siw *ServerInterfaceWrapper) BlockUser(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// ListBlocks operation middleware
-func (siw *ServerInterfaceWrapper) ListBlocks(w http.ResponseWriter, r *http.Request) {
+// ListThread operation middleware
+func (siw *ServerInterfaceWrapper) ListThread(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5439,7 +6254,7 @@ func (siw *ServerInterfaceWrapper) ListBlocks(w http.ResponseWriter, r *http.Req
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListBlocks(w, r, wid)
+		siw.Handler.ListThread(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5449,17 +6264,17 @@ func (siw *ServerInterfaceWrapper) ListBlocks(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// UnblockUser operation middleware
-func (siw *ServerInterfaceWrapper) UnblockUser(w http.ResponseWriter, r *http.Request) {
+// MarkThreadRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkThreadRead(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5470,7 +6285,7 @@ func (siw *ServerInterfaceWrapper) UnblockUser(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UnblockUser(w, r, wid)
+		siw.Handler.MarkThreadRead(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5480,17 +6295,17 @@ func (siw *ServerInterfaceWrapper) UnblockUser(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-// CreateChannel operation middleware
-func (siw *ServerInterfaceWrapper) CreateChannel(w http.ResponseWriter, r *http.Request) {
+// UnpinMessage operation middleware
+func (siw *ServerInterfaceWrapper) UnpinMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5501,7 +6316,7 @@ func (siw *ServerInterfaceWrapper) CreateChannel(w http.ResponseWriter, r *http.
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateChannel(w, r, wid)
+		siw.Handler.UnpinMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5511,17 +6326,17 @@ func (siw *ServerInterfaceWrapper) CreateChannel(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// CreateDM operation middleware
-func (siw *ServerInterfaceWrapper) CreateDM(w http.ResponseWriter, r *http.Request) {
+// UnsubscribeFromThread operation middleware
+func (siw *ServerInterfaceWrapper) UnsubscribeFromThread(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id MessageId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5532,7 +6347,7 @@ func (siw *ServerInterfaceWrapper) CreateDM(w http.ResponseWriter, r *http.Reque
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateDM(w, r, wid)
+		siw.Handler.UnsubscribeFromThread(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5542,17 +6357,17 @@ func (siw *ServerInterfaceWrapper) CreateDM(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// ListChannels operation middleware
-func (siw *ServerInterfaceWrapper) ListChannels(w http.ResponseWriter, r *http.Request) {
+// UpdateMessage operation middleware
+func (siw *ServerInterfaceWrapper) UpdateMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
-
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	// ------------- Path parameter "id" -------------
+	var id MessageId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5563,7 +6378,7 @@ func (siw *ServerInterfaceWrapper) ListChannels(w http.ResponseWriter, r *http.R
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListChannels(w, r, wid)
+		siw.Handler.UpdateMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5573,17 +6388,17 @@ func (siw *ServerInterfaceWrapper) ListChannels(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// MarkAllChannelsRead operation middleware
-func (siw *ServerInterfaceWrapper) MarkAllChannelsRead(w http.ResponseWriter, r *http.Request) {
+// GetScheduledMessage operation middleware
+func (siw *ServerInterfaceWrapper) GetScheduledMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5594,7 +6409,7 @@ func (siw *ServerInterfaceWrapper) MarkAllChannelsRead(w http.ResponseWriter, r
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.MarkAllChannelsRead(w, r, wid)
+		siw.Handler.GetScheduledMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5604,17 +6419,17 @@ func (siw *ServerInterfaceWrapper) MarkAllChannelsRead(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// ListCustomEmojis operation middleware
-func (siw *ServerInterfaceWrapper) ListCustomEmojis(w http.ResponseWriter, r *http.Request) {
+// DeleteScheduledMessage operation middleware
+func (siw *ServerInterfaceWrapper) DeleteScheduledMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5625,7 +6440,7 @@ func (siw *ServerInterfaceWrapper) ListCustomEmojis(w http.ResponseWriter, r *ht
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListCustomEmojis(w, r, wid)
+		siw.Handler.DeleteScheduledMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5635,17 +6450,17 @@ func (siw *ServerInterfaceWrapper) ListCustomEmojis(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// UploadCustomEmoji operation middleware
-func (siw *ServerInterfaceWrapper) UploadCustomEmoji(w http.ResponseWriter, r *http.Request) {
+// SendScheduledMessageNow operation middleware
+func (siw *ServerInterfaceWrapper) SendScheduledMessageNow(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid string
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5656,7 +6471,7 @@ func (siw *ServerInterfaceWrapper) UploadCustomEmoji(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UploadCustomEmoji(w, r, wid)
+		siw.Handler.SendScheduledMessageNow(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5666,17 +6481,17 @@ func (siw *ServerInterfaceWrapper) UploadCustomEmoji(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// DeleteWorkspaceIcon operation middleware
-func (siw *ServerInterfaceWrapper) DeleteWorkspaceIcon(w http.ResponseWriter, r *http.Request) {
+// UpdateScheduledMessage operation middleware
+func (siw *ServerInterfaceWrapper) UpdateScheduledMessage(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5687,7 +6502,7 @@ func (siw *ServerInterfaceWrapper) DeleteWorkspaceIcon(w http.ResponseWriter, r
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteWorkspaceIcon(w, r, wid)
+		siw.Handler.UpdateScheduledMessage(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5697,20 +6512,23 @@ func (siw *ServerInterfaceWrapper) DeleteWorkspaceIcon(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// UploadWorkspaceIcon operation middleware
-func (siw *ServerInterfaceWrapper) UploadWorkspaceIcon(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// GetServerInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetServerInfo(w http.ResponseWriter, r *http.Request) {
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetServerInfo(w, r)
+	}))
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteAvatar operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -5718,7 +6536,7 @@ func (siw *ServerInterfaceWrapper) UploadWorkspaceIcon(w http.ResponseWriter, r
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UploadWorkspaceIcon(w, r, wid)
+		siw.Handler.DeleteAvatar(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5728,20 +6546,29 @@ func (siw *ServerInterfaceWrapper) UploadWorkspaceIcon(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// CreateWorkspaceInvite operation middleware
-func (siw *ServerInterfaceWrapper) CreateWorkspaceInvite(w http.ResponseWriter, r *http.Request) {
+// UploadAvatar operation middleware
+func (siw *ServerInterfaceWrapper) UploadAvatar(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	ctx := r.Context()
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
-		return
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadAvatar(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// GetNotificationSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -5749,7 +6576,7 @@ func (siw *ServerInterfaceWrapper) CreateWorkspaceInvite(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateWorkspaceInvite(w, r, wid)
+		siw.Handler.GetNotificationSettings(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5759,20 +6586,29 @@ func (siw *ServerInterfaceWrapper) CreateWorkspaceInvite(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// LeaveWorkspace operation middleware
-func (siw *ServerInterfaceWrapper) LeaveWorkspace(w http.ResponseWriter, r *http.Request) {
+// UpdateNotificationSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	ctx := r.Context()
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
-		return
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateNotificationSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateProfile operation middleware
+func (siw *ServerInterfaceWrapper) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -5780,7 +6616,7 @@ func (siw *ServerInterfaceWrapper) LeaveWorkspace(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LeaveWorkspace(w, r, wid)
+		siw.Handler.UpdateProfile(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5790,17 +6626,17 @@ func (siw *ServerInterfaceWrapper) LeaveWorkspace(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// ListWorkspaceMembers operation middleware
-func (siw *ServerInterfaceWrapper) ListWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
+// GetUser operation middleware
+func (siw *ServerInterfaceWrapper) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
@@ -5811,7 +6647,7 @@ func (siw *ServerInterfaceWrapper) ListWorkspaceMembers(w http.ResponseWriter, r
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListWorkspaceMembers(w, r, wid)
+		siw.Handler.GetUser(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5821,20 +6657,29 @@ func (siw *ServerInterfaceWrapper) ListWorkspaceMembers(w http.ResponseWriter, r
 	handler.ServeHTTP(w, r)
 }
 
-// RemoveWorkspaceMember operation middleware
-func (siw *ServerInterfaceWrapper) RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request) {
+// CreateWorkspace operation middleware
+func (siw *ServerInterfaceWrapper) CreateWorkspace(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	ctx := r.Context()
 
-	// ------------- Path parameter "wid" -------------
-	var wid WorkspaceId
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
-		return
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWorkspace(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// GetWorkspaceNotifications operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkspaceNotifications(w http.ResponseWriter, r *http.Request) {
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
@@ -5842,7 +6687,7 @@ func (siw *ServerInterfaceWrapper) RemoveWorkspaceMember(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RemoveWorkspaceMember(w, r, wid)
+		siw.Handler.GetWorkspaceNotifications(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5852,8 +6697,28 @@ func (siw *ServerInterfaceWrapper) RemoveWorkspaceMember(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateWorkspaceMemberRole operation middleware
-func (siw *ServerInterfaceWrapper) UpdateWorkspaceMemberRole(w http.ResponseWriter, r *http.Request) {
+// ReorderWorkspaces operation middleware
+func (siw *ServerInterfaceWrapper) ReorderWorkspaces(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReorderWorkspaces(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetWorkspace operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkspace(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -5873,7 +6738,7 @@ func (siw *ServerInterfaceWrapper) UpdateWorkspaceMemberRole(w http.ResponseWrit
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateWorkspaceMemberRole(w, r, wid)
+		siw.Handler.GetWorkspace(w, r, wid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5883,8 +6748,8 @@ func (siw *ServerInterfaceWrapper) UpdateWorkspaceMemberRole(w http.ResponseWrit
 	handler.ServeHTTP(w, r)
 }
 
-// SearchMessages operation middleware
-func (siw *ServerInterfaceWrapper) SearchMessages(w http.ResponseWriter, r *http.Request) {
+// BanUser operation middleware
+func (siw *ServerInterfaceWrapper) BanUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -5904,7 +6769,7 @@ func (siw *ServerInterfaceWrapper) SearchMessages(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SearchMessages(w, r, wid)
+		siw.Handler.BanUser(w, r, wid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5914,8 +6779,8 @@ func (siw *ServerInterfaceWrapper) SearchMessages(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// ListModerationLog operation middleware
-func (siw *ServerInterfaceWrapper) ListModerationLog(w http.ResponseWriter, r *http.Request) {
+// ListBans operation middleware
+func (siw *ServerInterfaceWrapper) ListBans(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -5935,7 +6800,7 @@ func (siw *ServerInterfaceWrapper) ListModerationLog(w http.ResponseWriter, r *h
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListModerationLog(w, r, wid)
+		siw.Handler.ListBans(w, r, wid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5945,13 +6810,13 @@ func (siw *ServerInterfaceWrapper) ListModerationLog(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// ListScheduledMessages operation middleware
-func (siw *ServerInterfaceWrapper) ListScheduledMessages(w http.ResponseWriter, r *http.Request) {
+// UnbanUser operation middleware
+func (siw *ServerInterfaceWrapper) UnbanUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// ------------- Path parameter "wid" -------------
-	var wid string
+	var wid WorkspaceId
 
 	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
@@ -5966,7 +6831,7 @@ func (siw *ServerInterfaceWrapper) ListScheduledMessages(w http.ResponseWriter,
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListScheduledMessages(w, r, wid)
+		siw.Handler.UnbanUser(w, r, wid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -5976,8 +6841,8 @@ func (siw *ServerInterfaceWrapper) ListScheduledMessages(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// ListUserThreads operation middleware
-func (siw *ServerInterfaceWrapper) ListUserThreads(w http.ResponseWriter, r *http.Request) {
+// BlockUser operation middleware
+func (siw *ServerInterfaceWrapper) BlockUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -5997,7 +6862,7 @@ func (siw *ServerInterfaceWrapper) ListUserThreads(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListUserThreads(w, r, wid)
+		siw.Handler.BlockUser(w, r, wid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -6007,8 +6872,8 @@ func (siw *ServerInterfaceWrapper) ListUserThreads(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-// ListAllUnreads operation middleware
-func (siw *ServerInterfaceWrapper) ListAllUnreads(w http.ResponseWriter, r *http.Request) {
+// ListBlocks operation middleware
+func (siw *ServerInterfaceWrapper) ListBlocks(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -6028,7 +6893,7 @@ func (siw *ServerInterfaceWrapper) ListAllUnreads(w http.ResponseWriter, r *http
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListAllUnreads(w, r, wid)
+		siw.Handler.ListBlocks(w, r, wid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -6038,8 +6903,8 @@ func (siw *ServerInterfaceWrapper) ListAllUnreads(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// UpdateWorkspace operation middleware
-func (siw *ServerInterfaceWrapper) UpdateWorkspace(w http.ResponseWriter, r *http.Request) {
+// UnblockUser operation middleware
+func (siw *ServerInterfaceWrapper) UnblockUser(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -6059,7 +6924,7 @@ func (siw *ServerInterfaceWrapper) UpdateWorkspace(w http.ResponseWriter, r *htt
 	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateWorkspace(w, r, wid)
+		siw.Handler.UnblockUser(w, r, wid)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -6069,1218 +6934,3178 @@ func (siw *ServerInterfaceWrapper) UpdateWorkspace(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// CreateChannel operation middleware
+func (siw *ServerInterfaceWrapper) CreateChannel(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type RequiredParamError struct {
-	ParamName string
-}
+	r = r.WithContext(ctx)
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateChannel(w, r, wid)
+	}))
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+	handler.ServeHTTP(w, r)
 }
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+// CreateDM operation middleware
+func (siw *ServerInterfaceWrapper) CreateDM(w http.ResponseWriter, r *http.Request) {
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	var err error
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	ctx := r.Context()
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{})
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateDM(w, r, wid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type ChiServerOptions struct {
-	BaseURL          string
-	BaseRouter       chi.Router
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+// ListChannels operation middleware
+func (siw *ServerInterfaceWrapper) ListChannels(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListChannels(w, r, wid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseRouter: r,
-	})
+// MarkAllChannelsRead operation middleware
+func (siw *ServerInterfaceWrapper) MarkAllChannelsRead(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MarkAllChannelsRead(w, r, wid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
-	return HandlerWithOptions(si, ChiServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: r,
-	})
+// ListCustomEmojis operation middleware
+func (siw *ServerInterfaceWrapper) ListCustomEmojis(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "wid" -------------
+	var wid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListCustomEmojis(w, r, wid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
-	r := options.BaseRouter
+// UploadCustomEmoji operation middleware
+func (siw *ServerInterfaceWrapper) UploadCustomEmoji(w http.ResponseWriter, r *http.Request) {
 
-	if r == nil {
-		r = chi.NewRouter()
+	var err error
+
+	// ------------- Path parameter "wid" -------------
+	var wid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
 	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadCustomEmoji(w, r, wid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteWorkspaceIcon operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWorkspaceIcon(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
 	}
 
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/device-tokens", wrapper.RegisterDeviceToken)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/auth/device-tokens/{id}", wrapper.UnregisterDeviceToken)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/forgot-password", wrapper.ForgotPassword)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/logout", wrapper.Logout)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/auth/me", wrapper.GetMe)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/register", wrapper.Register)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/resend-verification", wrapper.ResendVerification)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/reset-password", wrapper.ResetPassword)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/auth/verify-email", wrapper.VerifyEmail)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/archive", wrapper.ArchiveChannel)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/convert", wrapper.ConvertGroupDMToChannel)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/files/upload", wrapper.UploadFile)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/join", wrapper.JoinChannel)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/leave", wrapper.LeaveChannel)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/mark-read", wrapper.MarkChannelRead)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/members/add", wrapper.AddChannelMember)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/members/list", wrapper.ListChannelMembers)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/messages/list", wrapper.ListMessages)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/messages/schedule", wrapper.ScheduleMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/messages/send", wrapper.SendMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/channels/{id}/notifications", wrapper.GetChannelNotifications)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/notifications", wrapper.UpdateChannelNotifications)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/pins/list", wrapper.ListPinnedMessages)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/channels/{id}/star", wrapper.UnstarChannel)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/star", wrapper.StarChannel)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/channels/{id}/update", wrapper.UpdateChannel)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/emojis/{id}/delete", wrapper.DeleteCustomEmoji)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/files/sign-urls", wrapper.SignFileUrls)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/files/{id}/delete", wrapper.DeleteFile)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/files/{id}/download", wrapper.DownloadFile)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/files/{id}/sign-url", wrapper.SignFileUrl)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/invites/{code}/accept", wrapper.AcceptInvite)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/messages/{id}", wrapper.GetMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/delete", wrapper.DeleteMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/link-preview/delete", wrapper.DeleteLinkPreview)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/mark-unread", wrapper.MarkMessageUnread)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/pin", wrapper.PinMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/reactions/add", wrapper.AddReaction)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/reactions/remove", wrapper.RemoveReaction)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/subscribe", wrapper.SubscribeToThread)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/messages/{id}/subscription", wrapper.GetThreadSubscription)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/thread/list", wrapper.ListThread)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/thread/mark-read", wrapper.MarkThreadRead)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/unpin", wrapper.UnpinMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/unsubscribe", wrapper.UnsubscribeFromThread)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/messages/{id}/update", wrapper.UpdateMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/scheduled-messages/{id}", wrapper.GetScheduledMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/scheduled-messages/{id}/delete", wrapper.DeleteScheduledMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/scheduled-messages/{id}/send-now", wrapper.SendScheduledMessageNow)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/scheduled-messages/{id}/update", wrapper.UpdateScheduledMessage)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/server-info", wrapper.GetServerInfo)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/users/me/avatar", wrapper.DeleteAvatar)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/users/me/avatar", wrapper.UploadAvatar)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/users/me/profile", wrapper.UpdateProfile)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/users/{id}", wrapper.GetUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/create", wrapper.CreateWorkspace)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/workspaces/notifications", wrapper.GetWorkspaceNotifications)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/reorder", wrapper.ReorderWorkspaces)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/workspaces/{wid}", wrapper.GetWorkspace)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/bans/create", wrapper.BanUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/bans/list", wrapper.ListBans)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/bans/remove", wrapper.UnbanUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/blocks/create", wrapper.BlockUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/workspaces/{wid}/blocks/list", wrapper.ListBlocks)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/blocks/remove", wrapper.UnblockUser)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/channels/create", wrapper.CreateChannel)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/channels/dm", wrapper.CreateDM)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/channels/list", wrapper.ListChannels)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/channels/mark-all-read", wrapper.MarkAllChannelsRead)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/emojis/list", wrapper.ListCustomEmojis)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/emojis/upload", wrapper.UploadCustomEmoji)
-	})
-	r.Group(func(r chi.Router) {
-		r.Delete(options.BaseURL+"/workspaces/{wid}/icon", wrapper.DeleteWorkspaceIcon)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/icon", wrapper.UploadWorkspaceIcon)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/invites/create", wrapper.CreateWorkspaceInvite)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/leave", wrapper.LeaveWorkspace)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/members/list", wrapper.ListWorkspaceMembers)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/members/remove", wrapper.RemoveWorkspaceMember)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/members/update-role", wrapper.UpdateWorkspaceMemberRole)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/messages/search", wrapper.SearchMessages)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/moderation-log/list", wrapper.ListModerationLog)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/scheduled-messages", wrapper.ListScheduledMessages)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/threads", wrapper.ListUserThreads)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/unreads", wrapper.ListAllUnreads)
-	})
-	r.Group(func(r chi.Router) {
-		r.Post(options.BaseURL+"/workspaces/{wid}/update", wrapper.UpdateWorkspace)
-	})
+	ctx := r.Context()
 
-	return r
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type BadRequestJSONResponse ApiErrorResponse
+	r = r.WithContext(ctx)
 
-type ConflictJSONResponse ApiErrorResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteWorkspaceIcon(w, r, wid)
+	}))
 
-type ForbiddenJSONResponse ApiErrorResponse
-
-type NotFoundJSONResponse ApiErrorResponse
-
-type UnauthorizedJSONResponse ApiErrorResponse
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RegisterDeviceTokenRequestObject struct {
-	Body *RegisterDeviceTokenJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type RegisterDeviceTokenResponseObject interface {
-	VisitRegisterDeviceTokenResponse(w http.ResponseWriter) error
-}
+// UploadWorkspaceIcon operation middleware
+func (siw *ServerInterfaceWrapper) UploadWorkspaceIcon(w http.ResponseWriter, r *http.Request) {
 
-type RegisterDeviceToken200JSONResponse RegisterDeviceTokenResponse
+	var err error
 
-func (response RegisterDeviceToken200JSONResponse) VisitRegisterDeviceTokenResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-type RegisterDeviceToken400JSONResponse struct{ BadRequestJSONResponse }
+	ctx := r.Context()
 
-func (response RegisterDeviceToken400JSONResponse) VisitRegisterDeviceTokenResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type RegisterDeviceToken401JSONResponse struct{ UnauthorizedJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UploadWorkspaceIcon(w, r, wid)
+	}))
 
-func (response RegisterDeviceToken401JSONResponse) VisitRegisterDeviceTokenResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type UnregisterDeviceTokenRequestObject struct {
-	Id string `json:"id"`
-}
+// CreateWorkspaceInvite operation middleware
+func (siw *ServerInterfaceWrapper) CreateWorkspaceInvite(w http.ResponseWriter, r *http.Request) {
 
-type UnregisterDeviceTokenResponseObject interface {
-	VisitUnregisterDeviceTokenResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type UnregisterDeviceToken204Response struct {
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (response UnregisterDeviceToken204Response) VisitUnregisterDeviceTokenResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-type UnregisterDeviceToken401JSONResponse struct{ UnauthorizedJSONResponse }
+	ctx := r.Context()
 
-func (response UnregisterDeviceToken401JSONResponse) VisitUnregisterDeviceTokenResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type UnregisterDeviceToken404JSONResponse struct{ NotFoundJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateWorkspaceInvite(w, r, wid)
+	}))
 
-func (response UnregisterDeviceToken404JSONResponse) VisitUnregisterDeviceTokenResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ForgotPasswordRequestObject struct {
-	Body *ForgotPasswordJSONRequestBody
-}
+// LeaveWorkspace operation middleware
+func (siw *ServerInterfaceWrapper) LeaveWorkspace(w http.ResponseWriter, r *http.Request) {
 
-type ForgotPasswordResponseObject interface {
-	VisitForgotPasswordResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type ForgotPassword200JSONResponse struct {
-	Message string `json:"message"`
-	Success bool   `json:"success"`
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (response ForgotPassword200JSONResponse) VisitForgotPasswordResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type ForgotPassword400JSONResponse struct{ BadRequestJSONResponse }
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-func (response ForgotPassword400JSONResponse) VisitForgotPasswordResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LeaveWorkspace(w, r, wid)
+	}))
 
-type LoginRequestObject struct {
-	Body *LoginJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type LoginResponseObject interface {
-	VisitLoginResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type Login200JSONResponse AuthResponse
-
-func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// ListWorkspaceMembers operation middleware
+func (siw *ServerInterfaceWrapper) ListWorkspaceMembers(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type Login401JSONResponse struct{ UnauthorizedJSONResponse }
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (response Login401JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type LogoutRequestObject struct {
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type LogoutResponseObject interface {
-	VisitLogoutResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type Logout200JSONResponse SuccessResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWorkspaceMembers(w, r, wid)
+	}))
 
-func (response Logout200JSONResponse) VisitLogoutResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetMeRequestObject struct {
-}
+// RemoveWorkspaceMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveWorkspaceMember(w http.ResponseWriter, r *http.Request) {
 
-type GetMeResponseObject interface {
-	VisitGetMeResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type GetMe200JSONResponse MeResponse
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (response GetMe200JSONResponse) VisitGetMeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type GetMe401JSONResponse struct{ UnauthorizedJSONResponse }
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-func (response GetMe401JSONResponse) VisitGetMeResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RemoveWorkspaceMember(w, r, wid)
+	}))
 
-type RegisterRequestObject struct {
-	Body *RegisterJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RegisterResponseObject interface {
-	VisitRegisterResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type Register200JSONResponse AuthResponse
-
-func (response Register200JSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// UpdateWorkspaceMemberRole operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWorkspaceMemberRole(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type Register400JSONResponse struct{ BadRequestJSONResponse }
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (response Register400JSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type ResendVerificationRequestObject struct {
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type ResendVerificationResponseObject interface {
-	VisitResendVerificationResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type ResendVerification200JSONResponse SuccessResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWorkspaceMemberRole(w, r, wid)
+	}))
 
-func (response ResendVerification200JSONResponse) VisitResendVerificationResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ResendVerification400JSONResponse struct{ BadRequestJSONResponse }
-
-func (response ResendVerification400JSONResponse) VisitResendVerificationResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// SearchMessages operation middleware
+func (siw *ServerInterfaceWrapper) SearchMessages(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type ResendVerification401JSONResponse struct{ UnauthorizedJSONResponse }
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (response ResendVerification401JSONResponse) VisitResendVerificationResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type ResetPasswordRequestObject struct {
-	Body *ResetPasswordJSONRequestBody
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type ResetPasswordResponseObject interface {
-	VisitResetPasswordResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type ResetPassword200JSONResponse SuccessResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SearchMessages(w, r, wid)
+	}))
 
-func (response ResetPassword200JSONResponse) VisitResetPasswordResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ResetPassword400JSONResponse struct{ BadRequestJSONResponse }
-
-func (response ResetPassword400JSONResponse) VisitResetPasswordResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// ListModerationLog operation middleware
+func (siw *ServerInterfaceWrapper) ListModerationLog(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type VerifyEmailRequestObject struct {
-	Body *VerifyEmailJSONRequestBody
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-type VerifyEmailResponseObject interface {
-	VisitVerifyEmailResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-type VerifyEmail200JSONResponse SuccessResponse
+	ctx := r.Context()
 
-func (response VerifyEmail200JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type VerifyEmail400JSONResponse struct{ BadRequestJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListModerationLog(w, r, wid)
+	}))
 
-func (response VerifyEmail400JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ArchiveChannelRequestObject struct {
-	Id ChannelId `json:"id"`
-}
+// GetWorkspaceNotificationDefaults operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkspaceNotificationDefaults(w http.ResponseWriter, r *http.Request) {
 
-type ArchiveChannelResponseObject interface {
-	VisitArchiveChannelResponse(w http.ResponseWriter) error
-}
+	var err error
 
-type ArchiveChannel200JSONResponse SuccessResponse
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (response ArchiveChannel200JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type ArchiveChannel400JSONResponse struct{ BadRequestJSONResponse }
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-func (response ArchiveChannel400JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWorkspaceNotificationDefaults(w, r, wid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type ArchiveChannel401JSONResponse struct{ UnauthorizedJSONResponse }
+// UpdateWorkspaceNotificationDefaults operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWorkspaceNotificationDefaults(w http.ResponseWriter, r *http.Request) {
 
-func (response ArchiveChannel401JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-type ArchiveChannel403JSONResponse struct{ ForbiddenJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-func (response ArchiveChannel403JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type ArchiveChannel404JSONResponse struct{ NotFoundJSONResponse }
+	r = r.WithContext(ctx)
 
-func (response ArchiveChannel404JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWorkspaceNotificationDefaults(w, r, wid)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type ConvertGroupDMToChannelRequestObject struct {
-	Id   ChannelId `json:"id"`
-	Body *ConvertGroupDMToChannelJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type ConvertGroupDMToChannelResponseObject interface {
-	VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error
-}
+// GetWorkspaceNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkspaceNotificationPreferences(w http.ResponseWriter, r *http.Request) {
 
-type ConvertGroupDMToChannel200JSONResponse struct {
-	Channel Channel `json:"channel"`
-}
+	var err error
 
-func (response ConvertGroupDMToChannel200JSONResponse) VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-type ConvertGroupDMToChannel400JSONResponse struct{ BadRequestJSONResponse }
+	ctx := r.Context()
 
-func (response ConvertGroupDMToChannel400JSONResponse) VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type ConvertGroupDMToChannel401JSONResponse struct{ UnauthorizedJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWorkspaceNotificationPreferences(w, r, wid)
+	}))
 
-func (response ConvertGroupDMToChannel401JSONResponse) VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type ConvertGroupDMToChannel403JSONResponse struct{ ForbiddenJSONResponse }
+// ResetNotificationDefaults operation middleware
+func (siw *ServerInterfaceWrapper) ResetNotificationDefaults(w http.ResponseWriter, r *http.Request) {
 
-func (response ConvertGroupDMToChannel403JSONResponse) VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-type UploadFileRequestObject struct {
-	Id   ChannelId `json:"id"`
-	Body *multipart.Reader
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-type UploadFileResponseObject interface {
-	VisitUploadFileResponse(w http.ResponseWriter) error
-}
+	ctx := r.Context()
 
-type UploadFile200JSONResponse struct {
-	File struct {
-		ContentType string `json:"content_type"`
-		Filename    string `json:"filename"`
-		Id          string `json:"id"`
-		Size        int    `json:"size"`
-	} `json:"file"`
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-func (response UploadFile200JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetNotificationDefaults(w, r, wid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type UploadFile400JSONResponse struct{ BadRequestJSONResponse }
+// UpdateNotificationDefaults operation middleware
+func (siw *ServerInterfaceWrapper) UpdateNotificationDefaults(w http.ResponseWriter, r *http.Request) {
 
-func (response UploadFile400JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-type UploadFile401JSONResponse struct{ UnauthorizedJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-func (response UploadFile401JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type UploadFile403JSONResponse struct{ ForbiddenJSONResponse }
+	r = r.WithContext(ctx)
 
-func (response UploadFile403JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateNotificationDefaults(w, r, wid)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type UploadFile404JSONResponse struct{ NotFoundJSONResponse }
+	handler.ServeHTTP(w, r)
+}
 
-func (response UploadFile404JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+// ListScheduledMessages operation middleware
+func (siw *ServerInterfaceWrapper) ListScheduledMessages(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type JoinChannelRequestObject struct {
-	Id ChannelId `json:"id"`
-}
+	// ------------- Path parameter "wid" -------------
+	var wid string
 
-type JoinChannelResponseObject interface {
-	VisitJoinChannelResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-type JoinChannel200JSONResponse SuccessResponse
+	ctx := r.Context()
 
-func (response JoinChannel200JSONResponse) VisitJoinChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type JoinChannel400JSONResponse struct{ BadRequestJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListScheduledMessages(w, r, wid)
+	}))
 
-func (response JoinChannel400JSONResponse) VisitJoinChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type JoinChannel401JSONResponse struct{ UnauthorizedJSONResponse }
-
-func (response JoinChannel401JSONResponse) VisitJoinChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+// ListUserThreads operation middleware
+func (siw *ServerInterfaceWrapper) ListUserThreads(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type JoinChannel404JSONResponse struct{ NotFoundJSONResponse }
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-func (response JoinChannel404JSONResponse) VisitJoinChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type LeaveChannelRequestObject struct {
-	Id ChannelId `json:"id"`
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type LeaveChannelResponseObject interface {
-	VisitLeaveChannelResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type LeaveChannel200JSONResponse SuccessResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUserThreads(w, r, wid)
+	}))
 
-func (response LeaveChannel200JSONResponse) VisitLeaveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type LeaveChannel400JSONResponse struct{ BadRequestJSONResponse }
+// ListAllUnreads operation middleware
+func (siw *ServerInterfaceWrapper) ListAllUnreads(w http.ResponseWriter, r *http.Request) {
 
-func (response LeaveChannel400JSONResponse) VisitLeaveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-type LeaveChannel401JSONResponse struct{ UnauthorizedJSONResponse }
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-func (response LeaveChannel401JSONResponse) VisitLeaveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-type LeaveChannel404JSONResponse struct{ NotFoundJSONResponse }
+	r = r.WithContext(ctx)
 
-func (response LeaveChannel404JSONResponse) VisitLeaveChannelResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAllUnreads(w, r, wid)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type MarkChannelReadRequestObject struct {
-	Id   ChannelId `json:"id"`
-	Body *MarkChannelReadJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type MarkChannelReadResponseObject interface {
-	VisitMarkChannelReadResponse(w http.ResponseWriter) error
-}
+// UpdateWorkspace operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWorkspace(w http.ResponseWriter, r *http.Request) {
 
-type MarkChannelRead200JSONResponse MarkReadResponse
+	var err error
 
-func (response MarkChannelRead200JSONResponse) VisitMarkChannelReadResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "wid" -------------
+	var wid WorkspaceId
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "wid", chi.URLParam(r, "wid"), &wid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wid", Err: err})
+		return
+	}
 
-type MarkChannelRead401JSONResponse struct{ UnauthorizedJSONResponse }
+	ctx := r.Context()
 
-func (response MarkChannelRead401JSONResponse) VisitMarkChannelReadResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type MarkChannelRead404JSONResponse struct{ NotFoundJSONResponse }
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateWorkspace(w, r, wid)
+	}))
 
-func (response MarkChannelRead404JSONResponse) VisitMarkChannelReadResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type AddChannelMemberRequestObject struct {
-	Id   ChannelId `json:"id"`
-	Body *AddChannelMemberJSONRequestBody
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
 }
 
-type AddChannelMemberResponseObject interface {
-	VisitAddChannelMemberResponse(w http.ResponseWriter) error
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
 }
 
-type AddChannelMember200JSONResponse SuccessResponse
-
-func (response AddChannelMember200JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
 }
 
-type AddChannelMember400JSONResponse struct{ BadRequestJSONResponse }
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
 
-func (response AddChannelMember400JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
 
-	return json.NewEncoder(w).Encode(response)
+type RequiredParamError struct {
+	ParamName string
 }
 
-type AddChannelMember401JSONResponse struct{ UnauthorizedJSONResponse }
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
 
-func (response AddChannelMember401JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
 
-	return json.NewEncoder(w).Encode(response)
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
 }
 
-type AddChannelMember403JSONResponse struct{ ForbiddenJSONResponse }
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
 
-func (response AddChannelMember403JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type AddChannelMember404JSONResponse struct{ NotFoundJSONResponse }
-
-func (response AddChannelMember404JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListChannelMembersRequestObject struct {
-	Id ChannelId `json:"id"`
-}
-
-type ListChannelMembersResponseObject interface {
-	VisitListChannelMembersResponse(w http.ResponseWriter) error
-}
-
-type ListChannelMembers200JSONResponse struct {
-	Members []ChannelMember `json:"members"`
-}
-
-func (response ListChannelMembers200JSONResponse) VisitListChannelMembersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListChannelMembers401JSONResponse struct{ UnauthorizedJSONResponse }
-
-func (response ListChannelMembers401JSONResponse) VisitListChannelMembersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListChannelMembers404JSONResponse struct{ NotFoundJSONResponse }
-
-func (response ListChannelMembers404JSONResponse) VisitListChannelMembersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListMessagesRequestObject struct {
-	Id   ChannelId `json:"id"`
-	Body *ListMessagesJSONRequestBody
-}
-
-type ListMessagesResponseObject interface {
-	VisitListMessagesResponse(w http.ResponseWriter) error
-}
-
-type ListMessages200JSONResponse MessageListResult
-
-func (response ListMessages200JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListMessages401JSONResponse struct{ UnauthorizedJSONResponse }
-
-func (response ListMessages401JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListMessages403JSONResponse struct{ ForbiddenJSONResponse }
-
-func (response ListMessages403JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ListMessages404JSONResponse struct{ NotFoundJSONResponse }
-
-func (response ListMessages404JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ScheduleMessageRequestObject struct {
-	Id   string `json:"id"`
-	Body *ScheduleMessageJSONRequestBody
-}
-
-type ScheduleMessageResponseObject interface {
-	VisitScheduleMessageResponse(w http.ResponseWriter) error
-}
-
-type ScheduleMessage200JSONResponse struct {
-	ScheduledMessage ScheduledMessage `json:"scheduled_message"`
-}
-
-func (response ScheduleMessage200JSONResponse) VisitScheduleMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ScheduleMessage400JSONResponse struct{ BadRequestJSONResponse }
-
-func (response ScheduleMessage400JSONResponse) VisitScheduleMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ScheduleMessage401JSONResponse struct{ UnauthorizedJSONResponse }
-
-func (response ScheduleMessage401JSONResponse) VisitScheduleMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type ScheduleMessage403JSONResponse struct{ ForbiddenJSONResponse }
-
-func (response ScheduleMessage403JSONResponse) VisitScheduleMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type SendMessageRequestObject struct {
-	Id   ChannelId `json:"id"`
-	Body *SendMessageJSONRequestBody
-}
-
-type SendMessageResponseObject interface {
-	VisitSendMessageResponse(w http.ResponseWriter) error
-}
-
-type SendMessage200JSONResponse struct {
-	Message MessageWithUser `json:"message"`
-}
-
-func (response SendMessage200JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
 }
 
-type SendMessage400JSONResponse struct{ BadRequestJSONResponse }
-
-func (response SendMessage400JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
 }
 
-type SendMessage401JSONResponse struct{ UnauthorizedJSONResponse }
-
-func (response SendMessage401JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
 }
 
-type SendMessage403JSONResponse struct{ ForbiddenJSONResponse }
-
-func (response SendMessage403JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
-
-	return json.NewEncoder(w).Encode(response)
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
 }
 
-type SendMessage404JSONResponse struct{ NotFoundJSONResponse }
-
-func (response SendMessage404JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
 }
 
-type GetChannelNotificationsRequestObject struct {
-	Id ChannelId `json:"id"`
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{})
 }
 
-type GetChannelNotificationsResponseObject interface {
-	VisitGetChannelNotificationsResponse(w http.ResponseWriter) error
+type ChiServerOptions struct {
+	BaseURL          string
+	BaseRouter       chi.Router
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
 }
 
-type GetChannelNotifications200JSONResponse struct {
-	Preferences NotificationPreferences `json:"preferences"`
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, r chi.Router) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseRouter: r,
+	})
 }
 
-func (response GetChannelNotifications200JSONResponse) VisitGetChannelNotificationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
-
-	return json.NewEncoder(w).Encode(response)
+func HandlerFromMuxWithBaseURL(si ServerInterface, r chi.Router, baseURL string) http.Handler {
+	return HandlerWithOptions(si, ChiServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: r,
+	})
 }
 
-type GetChannelNotifications401JSONResponse struct{ UnauthorizedJSONResponse }
-
-func (response GetChannelNotifications401JSONResponse) VisitGetChannelNotificationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handler {
+	r := options.BaseRouter
 
-	return json.NewEncoder(w).Encode(response)
-}
+	if r == nil {
+		r = chi.NewRouter()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
 
-type GetChannelNotifications404JSONResponse struct{ NotFoundJSONResponse }
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/emails/list", wrapper.ListQueuedEmails)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/emails/{id}/retry", wrapper.RetryQueuedEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/settings", wrapper.GetServerSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/settings/update", wrapper.UpdateServerSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/stats", wrapper.GetAdminStats)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/list", wrapper.ListAdminUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/approve", wrapper.ApproveUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/disable", wrapper.DisableUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/enable", wrapper.EnableUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/reject", wrapper.RejectUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/site-admin", wrapper.SetSiteAdmin)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/workspaces/list", wrapper.ListAdminWorkspaces)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/device-tokens", wrapper.RegisterDeviceToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/device-tokens/{id}", wrapper.UnregisterDeviceToken)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/forgot-password", wrapper.ForgotPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login", wrapper.Login)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/logout", wrapper.Logout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/me", wrapper.GetMe)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/register", wrapper.Register)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/resend-verification", wrapper.ResendVerification)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/reset-password", wrapper.ResetPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/verify-email", wrapper.VerifyEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/web-push-subscriptions", wrapper.RegisterWebPushSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/auth/web-push-subscriptions/{id}", wrapper.UnregisterWebPushSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/archive", wrapper.ArchiveChannel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/convert", wrapper.ConvertGroupDMToChannel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/channels/{id}/email-address", wrapper.DeleteChannelEmailAddress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/channels/{id}/email-address", wrapper.GetChannelEmailAddress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/email-address", wrapper.UpdateChannelEmailAddress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/email-address/rotate", wrapper.RotateChannelEmailAddress)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/files/upload", wrapper.UploadFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/join", wrapper.JoinChannel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/leave", wrapper.LeaveChannel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/mark-read", wrapper.MarkChannelRead)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/members/add", wrapper.AddChannelMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/members/list", wrapper.ListChannelMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/channels/{id}/mention-permissions", wrapper.GetChannelMentionPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/mention-permissions", wrapper.UpdateChannelMentionPermissions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/messages/list", wrapper.ListMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/messages/schedule", wrapper.ScheduleMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/messages/send", wrapper.SendMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/channels/{id}/notification-defaults", wrapper.DeleteChannelNotificationDefaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/channels/{id}/notification-defaults", wrapper.GetChannelNotificationDefaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/notification-defaults", wrapper.UpdateChannelNotificationDefaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/channels/{id}/notifications", wrapper.ResetChannelNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/channels/{id}/notifications", wrapper.GetChannelNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/notifications", wrapper.UpdateChannelNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/pins/list", wrapper.ListPinnedMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/channels/{id}/star", wrapper.UnstarChannel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/star", wrapper.StarChannel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/channels/{id}/update", wrapper.UpdateChannel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/email/unsubscribe", wrapper.UnsubscribeFromEmail)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/emojis/{id}/delete", wrapper.DeleteCustomEmoji)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/files/sign-urls", wrapper.SignFileUrls)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/files/{id}/delete", wrapper.DeleteFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/files/{id}/download", wrapper.DownloadFile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/files/{id}/sign-url", wrapper.SignFileUrl)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/invites/{code}/accept", wrapper.AcceptInvite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/messages/{id}", wrapper.GetMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/delete", wrapper.DeleteMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/link-preview/delete", wrapper.DeleteLinkPreview)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/mark-unread", wrapper.MarkMessageUnread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/pin", wrapper.PinMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/reactions/add", wrapper.AddReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/reactions/remove", wrapper.RemoveReaction)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/subscribe", wrapper.SubscribeToThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/messages/{id}/subscription", wrapper.GetThreadSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/thread/list", wrapper.ListThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/thread/mark-read", wrapper.MarkThreadRead)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/unpin", wrapper.UnpinMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/unsubscribe", wrapper.UnsubscribeFromThread)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/messages/{id}/update", wrapper.UpdateMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/scheduled-messages/{id}", wrapper.GetScheduledMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/scheduled-messages/{id}/delete", wrapper.DeleteScheduledMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/scheduled-messages/{id}/send-now", wrapper.SendScheduledMessageNow)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/scheduled-messages/{id}/update", wrapper.UpdateScheduledMessage)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/server-info", wrapper.GetServerInfo)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/me/avatar", wrapper.DeleteAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/avatar", wrapper.UploadAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/me/notification-settings", wrapper.GetNotificationSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/notification-settings", wrapper.UpdateNotificationSettings)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/me/profile", wrapper.UpdateProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/users/{id}", wrapper.GetUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/create", wrapper.CreateWorkspace)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/workspaces/notifications", wrapper.GetWorkspaceNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/reorder", wrapper.ReorderWorkspaces)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/workspaces/{wid}", wrapper.GetWorkspace)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/bans/create", wrapper.BanUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/bans/list", wrapper.ListBans)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/bans/remove", wrapper.UnbanUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/blocks/create", wrapper.BlockUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/workspaces/{wid}/blocks/list", wrapper.ListBlocks)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/blocks/remove", wrapper.UnblockUser)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/channels/create", wrapper.CreateChannel)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/channels/dm", wrapper.CreateDM)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/channels/list", wrapper.ListChannels)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/channels/mark-all-read", wrapper.MarkAllChannelsRead)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/emojis/list", wrapper.ListCustomEmojis)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/emojis/upload", wrapper.UploadCustomEmoji)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/workspaces/{wid}/icon", wrapper.DeleteWorkspaceIcon)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/icon", wrapper.UploadWorkspaceIcon)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/invites/create", wrapper.CreateWorkspaceInvite)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/leave", wrapper.LeaveWorkspace)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/members/list", wrapper.ListWorkspaceMembers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/members/remove", wrapper.RemoveWorkspaceMember)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/members/update-role", wrapper.UpdateWorkspaceMemberRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/messages/search", wrapper.SearchMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/moderation-log/list", wrapper.ListModerationLog)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/workspaces/{wid}/notification-defaults", wrapper.GetWorkspaceNotificationDefaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/notification-defaults", wrapper.UpdateWorkspaceNotificationDefaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/workspaces/{wid}/notification-preferences", wrapper.GetWorkspaceNotificationPreferences)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/workspaces/{wid}/notification-preferences/defaults", wrapper.ResetNotificationDefaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/notification-preferences/defaults", wrapper.UpdateNotificationDefaults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/scheduled-messages", wrapper.ListScheduledMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/threads", wrapper.ListUserThreads)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/unreads", wrapper.ListAllUnreads)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/workspaces/{wid}/update", wrapper.UpdateWorkspace)
+	})
+
+	return r
+}
+
+type BadRequestJSONResponse ApiErrorResponse
+
+type ConflictJSONResponse ApiErrorResponse
+
+type ForbiddenJSONResponse ApiErrorResponse
+
+type NotFoundJSONResponse ApiErrorResponse
+
+type UnauthorizedJSONResponse ApiErrorResponse
+
+type ListQueuedEmailsRequestObject struct {
+	Body *ListQueuedEmailsJSONRequestBody
+}
+
+type ListQueuedEmailsResponseObject interface {
+	VisitListQueuedEmailsResponse(w http.ResponseWriter) error
+}
+
+type ListQueuedEmails200JSONResponse struct {
+	Emails     []QueuedEmail `json:"emails"`
+	HasMore    bool          `json:"has_more"`
+	NextCursor *string       `json:"next_cursor,omitempty"`
+}
+
+func (response ListQueuedEmails200JSONResponse) VisitListQueuedEmailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListQueuedEmails401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListQueuedEmails401JSONResponse) VisitListQueuedEmailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListQueuedEmails403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListQueuedEmails403JSONResponse) VisitListQueuedEmailsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryQueuedEmailRequestObject struct {
+	Id string `json:"id"`
+}
+
+type RetryQueuedEmailResponseObject interface {
+	VisitRetryQueuedEmailResponse(w http.ResponseWriter) error
+}
+
+type RetryQueuedEmail200JSONResponse SuccessResponse
+
+func (response RetryQueuedEmail200JSONResponse) VisitRetryQueuedEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryQueuedEmail401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RetryQueuedEmail401JSONResponse) VisitRetryQueuedEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryQueuedEmail403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RetryQueuedEmail403JSONResponse) VisitRetryQueuedEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RetryQueuedEmail404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RetryQueuedEmail404JSONResponse) VisitRetryQueuedEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServerSettingsRequestObject struct {
+}
+
+type GetServerSettingsResponseObject interface {
+	VisitGetServerSettingsResponse(w http.ResponseWriter) error
+}
+
+type GetServerSettings200JSONResponse ServerSettings
+
+func (response GetServerSettings200JSONResponse) VisitGetServerSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServerSettings401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetServerSettings401JSONResponse) VisitGetServerSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServerSettings403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetServerSettings403JSONResponse) VisitGetServerSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateServerSettingsRequestObject struct {
+	Body *UpdateServerSettingsJSONRequestBody
+}
+
+type UpdateServerSettingsResponseObject interface {
+	VisitUpdateServerSettingsResponse(w http.ResponseWriter) error
+}
+
+type UpdateServerSettings200JSONResponse ServerSettings
+
+func (response UpdateServerSettings200JSONResponse) VisitUpdateServerSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateServerSettings400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateServerSettings400JSONResponse) VisitUpdateServerSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateServerSettings401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateServerSettings401JSONResponse) VisitUpdateServerSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateServerSettings403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateServerSettings403JSONResponse) VisitUpdateServerSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminStatsRequestObject struct {
+}
+
+type GetAdminStatsResponseObject interface {
+	VisitGetAdminStatsResponse(w http.ResponseWriter) error
+}
+
+type GetAdminStats200JSONResponse AdminStats
+
+func (response GetAdminStats200JSONResponse) VisitGetAdminStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminStats401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetAdminStats401JSONResponse) VisitGetAdminStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAdminStats403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetAdminStats403JSONResponse) VisitGetAdminStatsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAdminUsersRequestObject struct {
+	Body *ListAdminUsersJSONRequestBody
+}
+
+type ListAdminUsersResponseObject interface {
+	VisitListAdminUsersResponse(w http.ResponseWriter) error
+}
+
+type ListAdminUsers200JSONResponse struct {
+	HasMore    bool    `json:"has_more"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+	Users      []User  `json:"users"`
+}
+
+func (response ListAdminUsers200JSONResponse) VisitListAdminUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAdminUsers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListAdminUsers401JSONResponse) VisitListAdminUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAdminUsers403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListAdminUsers403JSONResponse) VisitListAdminUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveUserRequestObject struct {
+	Id string `json:"id"`
+}
+
+type ApproveUserResponseObject interface {
+	VisitApproveUserResponse(w http.ResponseWriter) error
+}
+
+type ApproveUser200JSONResponse struct {
+	User User `json:"user"`
+}
+
+func (response ApproveUser200JSONResponse) VisitApproveUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ApproveUser400JSONResponse) VisitApproveUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ApproveUser401JSONResponse) VisitApproveUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ApproveUser403JSONResponse) VisitApproveUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveUser404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ApproveUser404JSONResponse) VisitApproveUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DisableUserRequestObject struct {
+	Id string `json:"id"`
+}
+
+type DisableUserResponseObject interface {
+	VisitDisableUserResponse(w http.ResponseWriter) error
+}
+
+type DisableUser200JSONResponse struct {
+	User User `json:"user"`
+}
+
+func (response DisableUser200JSONResponse) VisitDisableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DisableUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response DisableUser400JSONResponse) VisitDisableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DisableUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DisableUser401JSONResponse) VisitDisableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DisableUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DisableUser403JSONResponse) VisitDisableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DisableUser404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DisableUser404JSONResponse) VisitDisableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnableUserRequestObject struct {
+	Id string `json:"id"`
+}
+
+type EnableUserResponseObject interface {
+	VisitEnableUserResponse(w http.ResponseWriter) error
+}
+
+type EnableUser200JSONResponse struct {
+	User User `json:"user"`
+}
+
+func (response EnableUser200JSONResponse) VisitEnableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnableUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response EnableUser400JSONResponse) VisitEnableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnableUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response EnableUser401JSONResponse) VisitEnableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnableUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response EnableUser403JSONResponse) VisitEnableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EnableUser404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response EnableUser404JSONResponse) VisitEnableUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectUserRequestObject struct {
+	Id string `json:"id"`
+}
+
+type RejectUserResponseObject interface {
+	VisitRejectUserResponse(w http.ResponseWriter) error
+}
+
+type RejectUser200JSONResponse SuccessResponse
+
+func (response RejectUser200JSONResponse) VisitRejectUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectUser400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RejectUser400JSONResponse) VisitRejectUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectUser401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RejectUser401JSONResponse) VisitRejectUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectUser403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RejectUser403JSONResponse) VisitRejectUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectUser404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RejectUser404JSONResponse) VisitRejectUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSiteAdminRequestObject struct {
+	Id   string `json:"id"`
+	Body *SetSiteAdminJSONRequestBody
+}
+
+type SetSiteAdminResponseObject interface {
+	VisitSetSiteAdminResponse(w http.ResponseWriter) error
+}
+
+type SetSiteAdmin200JSONResponse struct {
+	User User `json:"user"`
+}
+
+func (response SetSiteAdmin200JSONResponse) VisitSetSiteAdminResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSiteAdmin400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response SetSiteAdmin400JSONResponse) VisitSetSiteAdminResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSiteAdmin401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response SetSiteAdmin401JSONResponse) VisitSetSiteAdminResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSiteAdmin403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response SetSiteAdmin403JSONResponse) VisitSetSiteAdminResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetSiteAdmin404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response SetSiteAdmin404JSONResponse) VisitSetSiteAdminResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAdminWorkspacesRequestObject struct {
+	Body *ListAdminWorkspacesJSONRequestBody
+}
+
+type ListAdminWorkspacesResponseObject interface {
+	VisitListAdminWorkspacesResponse(w http.ResponseWriter) error
+}
+
+type ListAdminWorkspaces200JSONResponse struct {
+	HasMore    bool             `json:"has_more"`
+	NextCursor *string          `json:"next_cursor,omitempty"`
+	Workspaces []AdminWorkspace `json:"workspaces"`
+}
+
+func (response ListAdminWorkspaces200JSONResponse) VisitListAdminWorkspacesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAdminWorkspaces401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListAdminWorkspaces401JSONResponse) VisitListAdminWorkspacesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAdminWorkspaces403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListAdminWorkspaces403JSONResponse) VisitListAdminWorkspacesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterDeviceTokenRequestObject struct {
+	Body *RegisterDeviceTokenJSONRequestBody
+}
+
+type RegisterDeviceTokenResponseObject interface {
+	VisitRegisterDeviceTokenResponse(w http.ResponseWriter) error
+}
+
+type RegisterDeviceToken200JSONResponse RegisterDeviceTokenResponse
+
+func (response RegisterDeviceToken200JSONResponse) VisitRegisterDeviceTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterDeviceToken400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RegisterDeviceToken400JSONResponse) VisitRegisterDeviceTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterDeviceToken401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RegisterDeviceToken401JSONResponse) VisitRegisterDeviceTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnregisterDeviceTokenRequestObject struct {
+	Id string `json:"id"`
+}
+
+type UnregisterDeviceTokenResponseObject interface {
+	VisitUnregisterDeviceTokenResponse(w http.ResponseWriter) error
+}
+
+type UnregisterDeviceToken204Response struct {
+}
+
+func (response UnregisterDeviceToken204Response) VisitUnregisterDeviceTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UnregisterDeviceToken401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UnregisterDeviceToken401JSONResponse) VisitUnregisterDeviceTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnregisterDeviceToken404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UnregisterDeviceToken404JSONResponse) VisitUnregisterDeviceTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForgotPasswordRequestObject struct {
+	Body *ForgotPasswordJSONRequestBody
+}
+
+type ForgotPasswordResponseObject interface {
+	VisitForgotPasswordResponse(w http.ResponseWriter) error
+}
+
+type ForgotPassword200JSONResponse struct {
+	Message string `json:"message"`
+	Success bool   `json:"success"`
+}
+
+func (response ForgotPassword200JSONResponse) VisitForgotPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ForgotPassword400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ForgotPassword400JSONResponse) VisitForgotPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LoginRequestObject struct {
+	Body *LoginJSONRequestBody
+}
+
+type LoginResponseObject interface {
+	VisitLoginResponse(w http.ResponseWriter) error
+}
+
+type Login200JSONResponse AuthResponse
+
+func (response Login200JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Login401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response Login401JSONResponse) VisitLoginResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LogoutRequestObject struct {
+}
+
+type LogoutResponseObject interface {
+	VisitLogoutResponse(w http.ResponseWriter) error
+}
+
+type Logout200JSONResponse SuccessResponse
+
+func (response Logout200JSONResponse) VisitLogoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMeRequestObject struct {
+}
+
+type GetMeResponseObject interface {
+	VisitGetMeResponse(w http.ResponseWriter) error
+}
+
+type GetMe200JSONResponse MeResponse
+
+func (response GetMe200JSONResponse) VisitGetMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMe401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetMe401JSONResponse) VisitGetMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterRequestObject struct {
+	Body *RegisterJSONRequestBody
+}
+
+type RegisterResponseObject interface {
+	VisitRegisterResponse(w http.ResponseWriter) error
+}
+
+type Register200JSONResponse AuthResponse
+
+func (response Register200JSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Register202JSONResponse struct {
+	User User `json:"user"`
+}
+
+func (response Register202JSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Register400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response Register400JSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Register403JSONResponse ApiErrorResponse
+
+func (response Register403JSONResponse) VisitRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResendVerificationRequestObject struct {
+}
+
+type ResendVerificationResponseObject interface {
+	VisitResendVerificationResponse(w http.ResponseWriter) error
+}
+
+type ResendVerification200JSONResponse SuccessResponse
+
+func (response ResendVerification200JSONResponse) VisitResendVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResendVerification400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ResendVerification400JSONResponse) VisitResendVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResendVerification401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ResendVerification401JSONResponse) VisitResendVerificationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetPasswordRequestObject struct {
+	Body *ResetPasswordJSONRequestBody
+}
+
+type ResetPasswordResponseObject interface {
+	VisitResetPasswordResponse(w http.ResponseWriter) error
+}
+
+type ResetPassword200JSONResponse SuccessResponse
+
+func (response ResetPassword200JSONResponse) VisitResetPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetPassword400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ResetPassword400JSONResponse) VisitResetPasswordResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyEmailRequestObject struct {
+	Body *VerifyEmailJSONRequestBody
+}
+
+type VerifyEmailResponseObject interface {
+	VisitVerifyEmailResponse(w http.ResponseWriter) error
+}
+
+type VerifyEmail200JSONResponse SuccessResponse
+
+func (response VerifyEmail200JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VerifyEmail400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response VerifyEmail400JSONResponse) VisitVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterWebPushSubscriptionRequestObject struct {
+	Body *RegisterWebPushSubscriptionJSONRequestBody
+}
+
+type RegisterWebPushSubscriptionResponseObject interface {
+	VisitRegisterWebPushSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type RegisterWebPushSubscription200JSONResponse RegisterWebPushSubscriptionResponse
+
+func (response RegisterWebPushSubscription200JSONResponse) VisitRegisterWebPushSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterWebPushSubscription400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RegisterWebPushSubscription400JSONResponse) VisitRegisterWebPushSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RegisterWebPushSubscription401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RegisterWebPushSubscription401JSONResponse) VisitRegisterWebPushSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnregisterWebPushSubscriptionRequestObject struct {
+	Id string `json:"id"`
+}
+
+type UnregisterWebPushSubscriptionResponseObject interface {
+	VisitUnregisterWebPushSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type UnregisterWebPushSubscription204Response struct {
+}
+
+func (response UnregisterWebPushSubscription204Response) VisitUnregisterWebPushSubscriptionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UnregisterWebPushSubscription401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UnregisterWebPushSubscription401JSONResponse) VisitUnregisterWebPushSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnregisterWebPushSubscription404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UnregisterWebPushSubscription404JSONResponse) VisitUnregisterWebPushSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveChannelRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type ArchiveChannelResponseObject interface {
+	VisitArchiveChannelResponse(w http.ResponseWriter) error
+}
+
+type ArchiveChannel200JSONResponse SuccessResponse
+
+func (response ArchiveChannel200JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveChannel400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ArchiveChannel400JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveChannel401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ArchiveChannel401JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveChannel403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ArchiveChannel403JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ArchiveChannel404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ArchiveChannel404JSONResponse) VisitArchiveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConvertGroupDMToChannelRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *ConvertGroupDMToChannelJSONRequestBody
+}
+
+type ConvertGroupDMToChannelResponseObject interface {
+	VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error
+}
+
+type ConvertGroupDMToChannel200JSONResponse struct {
+	Channel Channel `json:"channel"`
+}
+
+func (response ConvertGroupDMToChannel200JSONResponse) VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConvertGroupDMToChannel400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ConvertGroupDMToChannel400JSONResponse) VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConvertGroupDMToChannel401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ConvertGroupDMToChannel401JSONResponse) VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConvertGroupDMToChannel403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ConvertGroupDMToChannel403JSONResponse) VisitConvertGroupDMToChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelEmailAddressRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type DeleteChannelEmailAddressResponseObject interface {
+	VisitDeleteChannelEmailAddressResponse(w http.ResponseWriter) error
+}
+
+type DeleteChannelEmailAddress200JSONResponse SuccessResponse
+
+func (response DeleteChannelEmailAddress200JSONResponse) VisitDeleteChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelEmailAddress401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteChannelEmailAddress401JSONResponse) VisitDeleteChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelEmailAddress403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteChannelEmailAddress403JSONResponse) VisitDeleteChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelEmailAddress404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteChannelEmailAddress404JSONResponse) VisitDeleteChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelEmailAddressRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type GetChannelEmailAddressResponseObject interface {
+	VisitGetChannelEmailAddressResponse(w http.ResponseWriter) error
+}
+
+type GetChannelEmailAddress200JSONResponse ChannelEmailAddress
+
+func (response GetChannelEmailAddress200JSONResponse) VisitGetChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelEmailAddress401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetChannelEmailAddress401JSONResponse) VisitGetChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelEmailAddress403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetChannelEmailAddress403JSONResponse) VisitGetChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelEmailAddress404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetChannelEmailAddress404JSONResponse) VisitGetChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelEmailAddressRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *UpdateChannelEmailAddressJSONRequestBody
+}
+
+type UpdateChannelEmailAddressResponseObject interface {
+	VisitUpdateChannelEmailAddressResponse(w http.ResponseWriter) error
+}
+
+type UpdateChannelEmailAddress200JSONResponse ChannelEmailAddress
+
+func (response UpdateChannelEmailAddress200JSONResponse) VisitUpdateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelEmailAddress400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateChannelEmailAddress400JSONResponse) VisitUpdateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelEmailAddress401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateChannelEmailAddress401JSONResponse) VisitUpdateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelEmailAddress403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateChannelEmailAddress403JSONResponse) VisitUpdateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelEmailAddress404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateChannelEmailAddress404JSONResponse) VisitUpdateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateChannelEmailAddressRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type RotateChannelEmailAddressResponseObject interface {
+	VisitRotateChannelEmailAddressResponse(w http.ResponseWriter) error
+}
+
+type RotateChannelEmailAddress200JSONResponse ChannelEmailAddress
+
+func (response RotateChannelEmailAddress200JSONResponse) VisitRotateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateChannelEmailAddress400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response RotateChannelEmailAddress400JSONResponse) VisitRotateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateChannelEmailAddress401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response RotateChannelEmailAddress401JSONResponse) VisitRotateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateChannelEmailAddress403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response RotateChannelEmailAddress403JSONResponse) VisitRotateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateChannelEmailAddress404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response RotateChannelEmailAddress404JSONResponse) VisitRotateChannelEmailAddressResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadFileRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *multipart.Reader
+}
+
+type UploadFileResponseObject interface {
+	VisitUploadFileResponse(w http.ResponseWriter) error
+}
+
+type UploadFile200JSONResponse struct {
+	File struct {
+		ContentType string `json:"content_type"`
+		Filename    string `json:"filename"`
+		Id          string `json:"id"`
+
+		// ScanStatus Antivirus scan status. Omitted when the file was uploaded with scanning disabled.
+		ScanStatus *FileScanStatus `json:"scan_status,omitempty"`
+		Size       int             `json:"size"`
+	} `json:"file"`
+}
+
+func (response UploadFile200JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadFile400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UploadFile400JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadFile401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UploadFile401JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadFile403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UploadFile403JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UploadFile404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UploadFile404JSONResponse) VisitUploadFileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JoinChannelRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type JoinChannelResponseObject interface {
+	VisitJoinChannelResponse(w http.ResponseWriter) error
+}
+
+type JoinChannel200JSONResponse SuccessResponse
+
+func (response JoinChannel200JSONResponse) VisitJoinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JoinChannel400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response JoinChannel400JSONResponse) VisitJoinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JoinChannel401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response JoinChannel401JSONResponse) VisitJoinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JoinChannel404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response JoinChannel404JSONResponse) VisitJoinChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LeaveChannelRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type LeaveChannelResponseObject interface {
+	VisitLeaveChannelResponse(w http.ResponseWriter) error
+}
+
+type LeaveChannel200JSONResponse SuccessResponse
+
+func (response LeaveChannel200JSONResponse) VisitLeaveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LeaveChannel400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response LeaveChannel400JSONResponse) VisitLeaveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LeaveChannel401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response LeaveChannel401JSONResponse) VisitLeaveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type LeaveChannel404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response LeaveChannel404JSONResponse) VisitLeaveChannelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MarkChannelReadRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *MarkChannelReadJSONRequestBody
+}
+
+type MarkChannelReadResponseObject interface {
+	VisitMarkChannelReadResponse(w http.ResponseWriter) error
+}
+
+type MarkChannelRead200JSONResponse MarkReadResponse
+
+func (response MarkChannelRead200JSONResponse) VisitMarkChannelReadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MarkChannelRead401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response MarkChannelRead401JSONResponse) VisitMarkChannelReadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type MarkChannelRead404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response MarkChannelRead404JSONResponse) VisitMarkChannelReadResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddChannelMemberRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *AddChannelMemberJSONRequestBody
+}
+
+type AddChannelMemberResponseObject interface {
+	VisitAddChannelMemberResponse(w http.ResponseWriter) error
+}
+
+type AddChannelMember200JSONResponse SuccessResponse
+
+func (response AddChannelMember200JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddChannelMember400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response AddChannelMember400JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddChannelMember401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response AddChannelMember401JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddChannelMember403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response AddChannelMember403JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AddChannelMember404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response AddChannelMember404JSONResponse) VisitAddChannelMemberResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListChannelMembersRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type ListChannelMembersResponseObject interface {
+	VisitListChannelMembersResponse(w http.ResponseWriter) error
+}
+
+type ListChannelMembers200JSONResponse struct {
+	Members []ChannelMember `json:"members"`
+}
+
+func (response ListChannelMembers200JSONResponse) VisitListChannelMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListChannelMembers401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListChannelMembers401JSONResponse) VisitListChannelMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListChannelMembers404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ListChannelMembers404JSONResponse) VisitListChannelMembersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelMentionPermissionsRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type GetChannelMentionPermissionsResponseObject interface {
+	VisitGetChannelMentionPermissionsResponse(w http.ResponseWriter) error
+}
+
+type GetChannelMentionPermissions200JSONResponse ChannelMentionPermissions
+
+func (response GetChannelMentionPermissions200JSONResponse) VisitGetChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelMentionPermissions401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetChannelMentionPermissions401JSONResponse) VisitGetChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelMentionPermissions403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetChannelMentionPermissions403JSONResponse) VisitGetChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelMentionPermissions404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetChannelMentionPermissions404JSONResponse) VisitGetChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelMentionPermissionsRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *UpdateChannelMentionPermissionsJSONRequestBody
+}
+
+type UpdateChannelMentionPermissionsResponseObject interface {
+	VisitUpdateChannelMentionPermissionsResponse(w http.ResponseWriter) error
+}
+
+type UpdateChannelMentionPermissions200JSONResponse ChannelMentionPermissions
+
+func (response UpdateChannelMentionPermissions200JSONResponse) VisitUpdateChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelMentionPermissions400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateChannelMentionPermissions400JSONResponse) VisitUpdateChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelMentionPermissions401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateChannelMentionPermissions401JSONResponse) VisitUpdateChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelMentionPermissions403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateChannelMentionPermissions403JSONResponse) VisitUpdateChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelMentionPermissions404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateChannelMentionPermissions404JSONResponse) VisitUpdateChannelMentionPermissionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMessagesRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *ListMessagesJSONRequestBody
+}
+
+type ListMessagesResponseObject interface {
+	VisitListMessagesResponse(w http.ResponseWriter) error
+}
+
+type ListMessages200JSONResponse MessageListResult
+
+func (response ListMessages200JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMessages401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListMessages401JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMessages403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListMessages403JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListMessages404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ListMessages404JSONResponse) VisitListMessagesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleMessageRequestObject struct {
+	Id   string `json:"id"`
+	Body *ScheduleMessageJSONRequestBody
+}
+
+type ScheduleMessageResponseObject interface {
+	VisitScheduleMessageResponse(w http.ResponseWriter) error
+}
+
+type ScheduleMessage200JSONResponse struct {
+	ScheduledMessage ScheduledMessage `json:"scheduled_message"`
+}
+
+func (response ScheduleMessage200JSONResponse) VisitScheduleMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleMessage400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ScheduleMessage400JSONResponse) VisitScheduleMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleMessage401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ScheduleMessage401JSONResponse) VisitScheduleMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleMessage403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ScheduleMessage403JSONResponse) VisitScheduleMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendMessageRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *SendMessageJSONRequestBody
+}
+
+type SendMessageResponseObject interface {
+	VisitSendMessageResponse(w http.ResponseWriter) error
+}
+
+type SendMessage200JSONResponse struct {
+	Message MessageWithUser `json:"message"`
+}
+
+func (response SendMessage200JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendMessage400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response SendMessage400JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendMessage401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response SendMessage401JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendMessage403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response SendMessage403JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendMessage404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response SendMessage404JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SendMessage409JSONResponse MentionConfirmationRequired
+
+func (response SendMessage409JSONResponse) VisitSendMessageResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelNotificationDefaultsRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type DeleteChannelNotificationDefaultsResponseObject interface {
+	VisitDeleteChannelNotificationDefaultsResponse(w http.ResponseWriter) error
+}
+
+type DeleteChannelNotificationDefaults200JSONResponse SuccessResponse
+
+func (response DeleteChannelNotificationDefaults200JSONResponse) VisitDeleteChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelNotificationDefaults401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteChannelNotificationDefaults401JSONResponse) VisitDeleteChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelNotificationDefaults403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteChannelNotificationDefaults403JSONResponse) VisitDeleteChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteChannelNotificationDefaults404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteChannelNotificationDefaults404JSONResponse) VisitDeleteChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelNotificationDefaultsRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type GetChannelNotificationDefaultsResponseObject interface {
+	VisitGetChannelNotificationDefaultsResponse(w http.ResponseWriter) error
+}
+
+type GetChannelNotificationDefaults200JSONResponse ChannelNotificationDefaults
+
+func (response GetChannelNotificationDefaults200JSONResponse) VisitGetChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelNotificationDefaults401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetChannelNotificationDefaults401JSONResponse) VisitGetChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelNotificationDefaults403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetChannelNotificationDefaults403JSONResponse) VisitGetChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelNotificationDefaults404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetChannelNotificationDefaults404JSONResponse) VisitGetChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelNotificationDefaultsRequestObject struct {
+	Id   ChannelId `json:"id"`
+	Body *UpdateChannelNotificationDefaultsJSONRequestBody
+}
+
+type UpdateChannelNotificationDefaultsResponseObject interface {
+	VisitUpdateChannelNotificationDefaultsResponse(w http.ResponseWriter) error
+}
+
+type UpdateChannelNotificationDefaults200JSONResponse ChannelNotificationDefaults
+
+func (response UpdateChannelNotificationDefaults200JSONResponse) VisitUpdateChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelNotificationDefaults400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateChannelNotificationDefaults400JSONResponse) VisitUpdateChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelNotificationDefaults401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateChannelNotificationDefaults401JSONResponse) VisitUpdateChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelNotificationDefaults403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateChannelNotificationDefaults403JSONResponse) VisitUpdateChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChannelNotificationDefaults404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response UpdateChannelNotificationDefaults404JSONResponse) VisitUpdateChannelNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetChannelNotificationsRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type ResetChannelNotificationsResponseObject interface {
+	VisitResetChannelNotificationsResponse(w http.ResponseWriter) error
+}
+
+type ResetChannelNotifications200JSONResponse struct {
+	Preferences NotificationPreferences `json:"preferences"`
+}
+
+func (response ResetChannelNotifications200JSONResponse) VisitResetChannelNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetChannelNotifications401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ResetChannelNotifications401JSONResponse) VisitResetChannelNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetChannelNotifications404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response ResetChannelNotifications404JSONResponse) VisitResetChannelNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelNotificationsRequestObject struct {
+	Id ChannelId `json:"id"`
+}
+
+type GetChannelNotificationsResponseObject interface {
+	VisitGetChannelNotificationsResponse(w http.ResponseWriter) error
+}
+
+type GetChannelNotifications200JSONResponse struct {
+	Preferences NotificationPreferences `json:"preferences"`
+}
+
+func (response GetChannelNotifications200JSONResponse) VisitGetChannelNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelNotifications401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetChannelNotifications401JSONResponse) VisitGetChannelNotificationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChannelNotifications404JSONResponse struct{ NotFoundJSONResponse }
 
 func (response GetChannelNotifications404JSONResponse) VisitGetChannelNotificationsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -7511,6 +10336,32 @@ func (response UpdateChannel404JSONResponse) VisitUpdateChannelResponse(w http.R
 	return json.NewEncoder(w).Encode(response)
 }
 
+type UnsubscribeFromEmailRequestObject struct {
+	Params UnsubscribeFromEmailParams
+}
+
+type UnsubscribeFromEmailResponseObject interface {
+	VisitUnsubscribeFromEmailResponse(w http.ResponseWriter) error
+}
+
+type UnsubscribeFromEmail200JSONResponse SuccessResponse
+
+func (response UnsubscribeFromEmail200JSONResponse) VisitUnsubscribeFromEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnsubscribeFromEmail400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UnsubscribeFromEmail400JSONResponse) VisitUnsubscribeFromEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteCustomEmojiRequestObject struct {
 	Id string `json:"id"`
 }
@@ -8695,6 +11546,70 @@ func (response UploadAvatar403JSONResponse) VisitUploadAvatarResponse(w http.Res
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetNotificationSettingsRequestObject struct {
+}
+
+type GetNotificationSettingsResponseObject interface {
+	VisitGetNotificationSettingsResponse(w http.ResponseWriter) error
+}
+
+type GetNotificationSettings200JSONResponse struct {
+	Settings NotificationSettings `json:"settings"`
+}
+
+func (response GetNotificationSettings200JSONResponse) VisitGetNotificationSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetNotificationSettings401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetNotificationSettings401JSONResponse) VisitGetNotificationSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateNotificationSettingsRequestObject struct {
+	Body *UpdateNotificationSettingsJSONRequestBody
+}
+
+type UpdateNotificationSettingsResponseObject interface {
+	VisitUpdateNotificationSettingsResponse(w http.ResponseWriter) error
+}
+
+type UpdateNotificationSettings200JSONResponse struct {
+	Settings NotificationSettings `json:"settings"`
+}
+
+func (response UpdateNotificationSettings200JSONResponse) VisitUpdateNotificationSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateNotificationSettings400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateNotificationSettings400JSONResponse) VisitUpdateNotificationSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateNotificationSettings401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateNotificationSettings401JSONResponse) VisitUpdateNotificationSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type UpdateProfileRequestObject struct {
 	Body *UpdateProfileJSONRequestBody
 }
@@ -8806,6 +11721,15 @@ func (response CreateWorkspace401JSONResponse) VisitCreateWorkspaceResponse(w ht
 	return json.NewEncoder(w).Encode(response)
 }
 
+type CreateWorkspace403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateWorkspace403JSONResponse) VisitCreateWorkspaceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type GetWorkspaceNotificationsRequestObject struct {
 }
 
@@ -9788,6 +12712,215 @@ func (response ListModerationLog403JSONResponse) VisitListModerationLogResponse(
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetWorkspaceNotificationDefaultsRequestObject struct {
+	Wid WorkspaceId `json:"wid"`
+}
+
+type GetWorkspaceNotificationDefaultsResponseObject interface {
+	VisitGetWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error
+}
+
+type GetWorkspaceNotificationDefaults200JSONResponse struct {
+	Defaults NotificationDefaults `json:"defaults"`
+}
+
+func (response GetWorkspaceNotificationDefaults200JSONResponse) VisitGetWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWorkspaceNotificationDefaults401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetWorkspaceNotificationDefaults401JSONResponse) VisitGetWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWorkspaceNotificationDefaults403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetWorkspaceNotificationDefaults403JSONResponse) VisitGetWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWorkspaceNotificationDefaultsRequestObject struct {
+	Wid  WorkspaceId `json:"wid"`
+	Body *UpdateWorkspaceNotificationDefaultsJSONRequestBody
+}
+
+type UpdateWorkspaceNotificationDefaultsResponseObject interface {
+	VisitUpdateWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error
+}
+
+type UpdateWorkspaceNotificationDefaults200JSONResponse struct {
+	Defaults NotificationDefaults `json:"defaults"`
+}
+
+func (response UpdateWorkspaceNotificationDefaults200JSONResponse) VisitUpdateWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWorkspaceNotificationDefaults400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateWorkspaceNotificationDefaults400JSONResponse) VisitUpdateWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWorkspaceNotificationDefaults401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateWorkspaceNotificationDefaults401JSONResponse) VisitUpdateWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateWorkspaceNotificationDefaults403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateWorkspaceNotificationDefaults403JSONResponse) VisitUpdateWorkspaceNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWorkspaceNotificationPreferencesRequestObject struct {
+	Wid WorkspaceId `json:"wid"`
+}
+
+type GetWorkspaceNotificationPreferencesResponseObject interface {
+	VisitGetWorkspaceNotificationPreferencesResponse(w http.ResponseWriter) error
+}
+
+type GetWorkspaceNotificationPreferences200JSONResponse struct {
+	Channels []ChannelNotificationPreferences `json:"channels"`
+	Defaults NotificationDefaults             `json:"defaults"`
+
+	// DefaultsCustomized Whether the defaults are the user's own rather than the workspace's
+	DefaultsCustomized bool `json:"defaults_customized"`
+}
+
+func (response GetWorkspaceNotificationPreferences200JSONResponse) VisitGetWorkspaceNotificationPreferencesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWorkspaceNotificationPreferences401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetWorkspaceNotificationPreferences401JSONResponse) VisitGetWorkspaceNotificationPreferencesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWorkspaceNotificationPreferences403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetWorkspaceNotificationPreferences403JSONResponse) VisitGetWorkspaceNotificationPreferencesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetNotificationDefaultsRequestObject struct {
+	Wid WorkspaceId `json:"wid"`
+}
+
+type ResetNotificationDefaultsResponseObject interface {
+	VisitResetNotificationDefaultsResponse(w http.ResponseWriter) error
+}
+
+type ResetNotificationDefaults200JSONResponse struct {
+	Defaults NotificationDefaults `json:"defaults"`
+}
+
+func (response ResetNotificationDefaults200JSONResponse) VisitResetNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetNotificationDefaults401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ResetNotificationDefaults401JSONResponse) VisitResetNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetNotificationDefaults403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ResetNotificationDefaults403JSONResponse) VisitResetNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateNotificationDefaultsRequestObject struct {
+	Wid  WorkspaceId `json:"wid"`
+	Body *UpdateNotificationDefaultsJSONRequestBody
+}
+
+type UpdateNotificationDefaultsResponseObject interface {
+	VisitUpdateNotificationDefaultsResponse(w http.ResponseWriter) error
+}
+
+type UpdateNotificationDefaults200JSONResponse struct {
+	Defaults NotificationDefaults `json:"defaults"`
+}
+
+func (response UpdateNotificationDefaults200JSONResponse) VisitUpdateNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateNotificationDefaults400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateNotificationDefaults400JSONResponse) VisitUpdateNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateNotificationDefaults401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response UpdateNotificationDefaults401JSONResponse) VisitUpdateNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateNotificationDefaults403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response UpdateNotificationDefaults403JSONResponse) VisitUpdateNotificationDefaultsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ListScheduledMessagesRequestObject struct {
 	Wid string `json:"wid"`
 }
@@ -9929,6 +13062,42 @@ func (response UpdateWorkspace404JSONResponse) VisitUpdateWorkspaceResponse(w ht
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// List queued emails
+	// (POST /admin/emails/list)
+	ListQueuedEmails(ctx context.Context, request ListQueuedEmailsRequestObject) (ListQueuedEmailsResponseObject, error)
+	// Retry a failed email
+	// (POST /admin/emails/{id}/retry)
+	RetryQueuedEmail(ctx context.Context, request RetryQueuedEmailRequestObject) (RetryQueuedEmailResponseObject, error)
+	// Get server settings
+	// (GET /admin/settings)
+	GetServerSettings(ctx context.Context, request GetServerSettingsRequestObject) (GetServerSettingsResponseObject, error)
+	// Update server settings
+	// (POST /admin/settings/update)
+	UpdateServerSettings(ctx context.Context, request UpdateServerSettingsRequestObject) (UpdateServerSettingsResponseObject, error)
+	// Get instance statistics
+	// (GET /admin/stats)
+	GetAdminStats(ctx context.Context, request GetAdminStatsRequestObject) (GetAdminStatsResponseObject, error)
+	// List users
+	// (POST /admin/users/list)
+	ListAdminUsers(ctx context.Context, request ListAdminUsersRequestObject) (ListAdminUsersResponseObject, error)
+	// Approve a pending account
+	// (POST /admin/users/{id}/approve)
+	ApproveUser(ctx context.Context, request ApproveUserRequestObject) (ApproveUserResponseObject, error)
+	// Disable a user account
+	// (POST /admin/users/{id}/disable)
+	DisableUser(ctx context.Context, request DisableUserRequestObject) (DisableUserResponseObject, error)
+	// Re-enable a user account
+	// (POST /admin/users/{id}/enable)
+	EnableUser(ctx context.Context, request EnableUserRequestObject) (EnableUserResponseObject, error)
+	// Reject a pending account
+	// (POST /admin/users/{id}/reject)
+	RejectUser(ctx context.Context, request RejectUserRequestObject) (RejectUserResponseObject, error)
+	// Grant or revoke site admin
+	// (POST /admin/users/{id}/site-admin)
+	SetSiteAdmin(ctx context.Context, request SetSiteAdminRequestObject) (SetSiteAdminResponseObject, error)
+	// List workspaces
+	// (POST /admin/workspaces/list)
+	ListAdminWorkspaces(ctx context.Context, request ListAdminWorkspacesRequestObject) (ListAdminWorkspacesResponseObject, error)
 	// Register a device token for push notifications
 	// (POST /auth/device-tokens)
 	RegisterDeviceToken(ctx context.Context, request RegisterDeviceTokenRequestObject) (RegisterDeviceTokenResponseObject, error)
@@ -9959,12 +13128,30 @@ type StrictServerInterface interface {
 	// Verify email address with token
 	// (POST /auth/verify-email)
 	VerifyEmail(ctx context.Context, request VerifyEmailRequestObject) (VerifyEmailResponseObject, error)
+	// Register a browser for Web Push notifications
+	// (POST /auth/web-push-subscriptions)
+	RegisterWebPushSubscription(ctx context.Context, request RegisterWebPushSubscriptionRequestObject) (RegisterWebPushSubscriptionResponseObject, error)
+	// Unregister a browser from Web Push notifications
+	// (DELETE /auth/web-push-subscriptions/{id})
+	UnregisterWebPushSubscription(ctx context.Context, request UnregisterWebPushSubscriptionRequestObject) (UnregisterWebPushSubscriptionResponseObject, error)
 	// Archive channel
 	// (POST /channels/{id}/archive)
 	ArchiveChannel(ctx context.Context, request ArchiveChannelRequestObject) (ArchiveChannelResponseObject, error)
 	// Convert group DM to channel
 	// (POST /channels/{id}/convert)
 	ConvertGroupDMToChannel(ctx context.Context, request ConvertGroupDMToChannelRequestObject) (ConvertGroupDMToChannelResponseObject, error)
+	// Remove channel email address
+	// (DELETE /channels/{id}/email-address)
+	DeleteChannelEmailAddress(ctx context.Context, request DeleteChannelEmailAddressRequestObject) (DeleteChannelEmailAddressResponseObject, error)
+	// Get channel email address
+	// (GET /channels/{id}/email-address)
+	GetChannelEmailAddress(ctx context.Context, request GetChannelEmailAddressRequestObject) (GetChannelEmailAddressResponseObject, error)
+	// Set channel email address
+	// (POST /channels/{id}/email-address)
+	UpdateChannelEmailAddress(ctx context.Context, request UpdateChannelEmailAddressRequestObject) (UpdateChannelEmailAddressResponseObject, error)
+	// Rotate channel email address
+	// (POST /channels/{id}/email-address/rotate)
+	RotateChannelEmailAddress(ctx context.Context, request RotateChannelEmailAddressRequestObject) (RotateChannelEmailAddressResponseObject, error)
 	// Upload a file
 	// (POST /channels/{id}/files/upload)
 	UploadFile(ctx context.Context, request UploadFileRequestObject) (UploadFileResponseObject, error)
@@ -9983,6 +13170,12 @@ type StrictServerInterface interface {
 	// List channel members
 	// (POST /channels/{id}/members/list)
 	ListChannelMembers(ctx context.Context, request ListChannelMembersRequestObject) (ListChannelMembersResponseObject, error)
+	// Get channel mention permissions
+	// (GET /channels/{id}/mention-permissions)
+	GetChannelMentionPermissions(ctx context.Context, request GetChannelMentionPermissionsRequestObject) (GetChannelMentionPermissionsResponseObject, error)
+	// Set channel mention permissions
+	// (POST /channels/{id}/mention-permissions)
+	UpdateChannelMentionPermissions(ctx context.Context, request UpdateChannelMentionPermissionsRequestObject) (UpdateChannelMentionPermissionsResponseObject, error)
 	// List messages in channel
 	// (POST /channels/{id}/messages/list)
 	ListMessages(ctx context.Context, request ListMessagesRequestObject) (ListMessagesResponseObject, error)
@@ -9992,6 +13185,18 @@ type StrictServerInterface interface {
 	// Send a message
 	// (POST /channels/{id}/messages/send)
 	SendMessage(ctx context.Context, request SendMessageRequestObject) (SendMessageResponseObject, error)
+	// Remove channel default notify level
+	// (DELETE /channels/{id}/notification-defaults)
+	DeleteChannelNotificationDefaults(ctx context.Context, request DeleteChannelNotificationDefaultsRequestObject) (DeleteChannelNotificationDefaultsResponseObject, error)
+	// Get channel default notify level
+	// (GET /channels/{id}/notification-defaults)
+	GetChannelNotificationDefaults(ctx context.Context, request GetChannelNotificationDefaultsRequestObject) (GetChannelNotificationDefaultsResponseObject, error)
+	// Set channel default notify level
+	// (POST /channels/{id}/notification-defaults)
+	UpdateChannelNotificationDefaults(ctx context.Context, request UpdateChannelNotificationDefaultsRequestObject) (UpdateChannelNotificationDefaultsResponseObject, error)
+	// Reset channel notification preferences
+	// (DELETE /channels/{id}/notifications)
+	ResetChannelNotifications(ctx context.Context, request ResetChannelNotificationsRequestObject) (ResetChannelNotificationsResponseObject, error)
 	// Get channel notification preferences
 	// (GET /channels/{id}/notifications)
 	GetChannelNotifications(ctx context.Context, request GetChannelNotificationsRequestObject) (GetChannelNotificationsResponseObject, error)
@@ -10010,6 +13215,9 @@ type StrictServerInterface interface {
 	// Update channel
 	// (POST /channels/{id}/update)
 	UpdateChannel(ctx context.Context, request UpdateChannelRequestObject) (UpdateChannelResponseObject, error)
+	// Unsubscribe from notification emails
+	// (POST /email/unsubscribe)
+	UnsubscribeFromEmail(ctx context.Context, request UnsubscribeFromEmailRequestObject) (UnsubscribeFromEmailResponseObject, error)
 	// Delete a custom emoji
 	// (POST /emojis/{id}/delete)
 	DeleteCustomEmoji(ctx context.Context, request DeleteCustomEmojiRequestObject) (DeleteCustomEmojiResponseObject, error)
@@ -10091,6 +13299,12 @@ type StrictServerInterface interface {
 	// Upload avatar image
 	// (POST /users/me/avatar)
 	UploadAvatar(ctx context.Context, request UploadAvatarRequestObject) (UploadAvatarResponseObject, error)
+	// Get notification settings
+	// (GET /users/me/notification-settings)
+	GetNotificationSettings(ctx context.Context, request GetNotificationSettingsRequestObject) (GetNotificationSettingsResponseObject, error)
+	// Update notification settings
+	// (POST /users/me/notification-settings)
+	UpdateNotificationSettings(ctx context.Context, request UpdateNotificationSettingsRequestObject) (UpdateNotificationSettingsResponseObject, error)
 	// Update own profile
 	// (POST /users/me/profile)
 	UpdateProfile(ctx context.Context, request UpdateProfileRequestObject) (UpdateProfileResponseObject, error)
@@ -10172,6 +13386,21 @@ type StrictServerInterface interface {
 	// List moderation audit log
 	// (POST /workspaces/{wid}/moderation-log/list)
 	ListModerationLog(ctx context.Context, request ListModerationLogRequestObject) (ListModerationLogResponseObject, error)
+	// Get workspace notification defaults
+	// (GET /workspaces/{wid}/notification-defaults)
+	GetWorkspaceNotificationDefaults(ctx context.Context, request GetWorkspaceNotificationDefaultsRequestObject) (GetWorkspaceNotificationDefaultsResponseObject, error)
+	// Set workspace notification defaults
+	// (POST /workspaces/{wid}/notification-defaults)
+	UpdateWorkspaceNotificationDefaults(ctx context.Context, request UpdateWorkspaceNotificationDefaultsRequestObject) (UpdateWorkspaceNotificationDefaultsResponseObject, error)
+	// Get notification preferences for all channels
+	// (GET /workspaces/{wid}/notification-preferences)
+	GetWorkspaceNotificationPreferences(ctx context.Context, request GetWorkspaceNotificationPreferencesRequestObject) (GetWorkspaceNotificationPreferencesResponseObject, error)
+	// Reset notification defaults
+	// (DELETE /workspaces/{wid}/notification-preferences/defaults)
+	ResetNotificationDefaults(ctx context.Context, request ResetNotificationDefaultsRequestObject) (ResetNotificationDefaultsResponseObject, error)
+	// Set notification defaults
+	// (POST /workspaces/{wid}/notification-preferences/defaults)
+	UpdateNotificationDefaults(ctx context.Context, request UpdateNotificationDefaultsRequestObject) (UpdateNotificationDefaultsResponseObject, error)
 	// List user's scheduled messages in a workspace
 	// (POST /workspaces/{wid}/scheduled-messages)
 	ListScheduledMessages(ctx context.Context, request ListScheduledMessagesRequestObject) (ListScheduledMessagesResponseObject, error)
@@ -10186,40 +13415,659 @@ type StrictServerInterface interface {
 	UpdateWorkspace(ctx context.Context, request UpdateWorkspaceRequestObject) (UpdateWorkspaceResponseObject, error)
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListQueuedEmails operation middleware
+func (sh *strictHandler) ListQueuedEmails(w http.ResponseWriter, r *http.Request) {
+	var request ListQueuedEmailsRequestObject
+
+	var body ListQueuedEmailsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListQueuedEmails(ctx, request.(ListQueuedEmailsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListQueuedEmails")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListQueuedEmailsResponseObject); ok {
+		if err := validResponse.VisitListQueuedEmailsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RetryQueuedEmail operation middleware
+func (sh *strictHandler) RetryQueuedEmail(w http.ResponseWriter, r *http.Request, id string) {
+	var request RetryQueuedEmailRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RetryQueuedEmail(ctx, request.(RetryQueuedEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RetryQueuedEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RetryQueuedEmailResponseObject); ok {
+		if err := validResponse.VisitRetryQueuedEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetServerSettings operation middleware
+func (sh *strictHandler) GetServerSettings(w http.ResponseWriter, r *http.Request) {
+	var request GetServerSettingsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetServerSettings(ctx, request.(GetServerSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetServerSettings")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetServerSettingsResponseObject); ok {
+		if err := validResponse.VisitGetServerSettingsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateServerSettings operation middleware
+func (sh *strictHandler) UpdateServerSettings(w http.ResponseWriter, r *http.Request) {
+	var request UpdateServerSettingsRequestObject
+
+	var body UpdateServerSettingsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateServerSettings(ctx, request.(UpdateServerSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateServerSettings")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateServerSettingsResponseObject); ok {
+		if err := validResponse.VisitUpdateServerSettingsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetAdminStats operation middleware
+func (sh *strictHandler) GetAdminStats(w http.ResponseWriter, r *http.Request) {
+	var request GetAdminStatsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAdminStats(ctx, request.(GetAdminStatsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAdminStats")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAdminStatsResponseObject); ok {
+		if err := validResponse.VisitGetAdminStatsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAdminUsers operation middleware
+func (sh *strictHandler) ListAdminUsers(w http.ResponseWriter, r *http.Request) {
+	var request ListAdminUsersRequestObject
+
+	var body ListAdminUsersJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAdminUsers(ctx, request.(ListAdminUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAdminUsers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAdminUsersResponseObject); ok {
+		if err := validResponse.VisitListAdminUsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ApproveUser operation middleware
+func (sh *strictHandler) ApproveUser(w http.ResponseWriter, r *http.Request, id string) {
+	var request ApproveUserRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveUser(ctx, request.(ApproveUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveUserResponseObject); ok {
+		if err := validResponse.VisitApproveUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DisableUser operation middleware
+func (sh *strictHandler) DisableUser(w http.ResponseWriter, r *http.Request, id string) {
+	var request DisableUserRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DisableUser(ctx, request.(DisableUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DisableUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DisableUserResponseObject); ok {
+		if err := validResponse.VisitDisableUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// EnableUser operation middleware
+func (sh *strictHandler) EnableUser(w http.ResponseWriter, r *http.Request, id string) {
+	var request EnableUserRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.EnableUser(ctx, request.(EnableUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EnableUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EnableUserResponseObject); ok {
+		if err := validResponse.VisitEnableUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RejectUser operation middleware
+func (sh *strictHandler) RejectUser(w http.ResponseWriter, r *http.Request, id string) {
+	var request RejectUserRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RejectUser(ctx, request.(RejectUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RejectUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RejectUserResponseObject); ok {
+		if err := validResponse.VisitRejectUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetSiteAdmin operation middleware
+func (sh *strictHandler) SetSiteAdmin(w http.ResponseWriter, r *http.Request, id string) {
+	var request SetSiteAdminRequestObject
+
+	request.Id = id
+
+	var body SetSiteAdminJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetSiteAdmin(ctx, request.(SetSiteAdminRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetSiteAdmin")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetSiteAdminResponseObject); ok {
+		if err := validResponse.VisitSetSiteAdminResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListAdminWorkspaces operation middleware
+func (sh *strictHandler) ListAdminWorkspaces(w http.ResponseWriter, r *http.Request) {
+	var request ListAdminWorkspacesRequestObject
+
+	var body ListAdminWorkspacesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAdminWorkspaces(ctx, request.(ListAdminWorkspacesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAdminWorkspaces")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAdminWorkspacesResponseObject); ok {
+		if err := validResponse.VisitListAdminWorkspacesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RegisterDeviceToken operation middleware
+func (sh *strictHandler) RegisterDeviceToken(w http.ResponseWriter, r *http.Request) {
+	var request RegisterDeviceTokenRequestObject
+
+	var body RegisterDeviceTokenJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RegisterDeviceToken(ctx, request.(RegisterDeviceTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RegisterDeviceToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RegisterDeviceTokenResponseObject); ok {
+		if err := validResponse.VisitRegisterDeviceTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UnregisterDeviceToken operation middleware
+func (sh *strictHandler) UnregisterDeviceToken(w http.ResponseWriter, r *http.Request, id string) {
+	var request UnregisterDeviceTokenRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UnregisterDeviceToken(ctx, request.(UnregisterDeviceTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnregisterDeviceToken")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UnregisterDeviceTokenResponseObject); ok {
+		if err := validResponse.VisitUnregisterDeviceTokenResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ForgotPassword operation middleware
+func (sh *strictHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var request ForgotPasswordRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	var body ForgotPasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ForgotPassword(ctx, request.(ForgotPasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ForgotPassword")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ForgotPasswordResponseObject); ok {
+		if err := validResponse.VisitForgotPasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
+// Login operation middleware
+func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var request LoginRequestObject
+
+	var body LoginJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Login(ctx, request.(LoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Login")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(LoginResponseObject); ok {
+		if err := validResponse.VisitLoginResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+// Logout operation middleware
+func (sh *strictHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var request LogoutRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Logout(ctx, request.(LogoutRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Logout")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(LogoutResponseObject); ok {
+		if err := validResponse.VisitLogoutResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+// GetMe operation middleware
+func (sh *strictHandler) GetMe(w http.ResponseWriter, r *http.Request) {
+	var request GetMeRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMe(ctx, request.(GetMeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMe")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMeResponseObject); ok {
+		if err := validResponse.VisitGetMeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Register operation middleware
+func (sh *strictHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var request RegisterRequestObject
+
+	var body RegisterJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Register(ctx, request.(RegisterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Register")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RegisterResponseObject); ok {
+		if err := validResponse.VisitRegisterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResendVerification operation middleware
+func (sh *strictHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var request ResendVerificationRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResendVerification(ctx, request.(ResendVerificationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResendVerification")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResendVerificationResponseObject); ok {
+		if err := validResponse.VisitResendVerificationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResetPassword operation middleware
+func (sh *strictHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var request ResetPasswordRequestObject
+
+	var body ResetPasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResetPassword(ctx, request.(ResetPasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResetPassword")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResetPasswordResponseObject); ok {
+		if err := validResponse.VisitResetPasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// VerifyEmail operation middleware
+func (sh *strictHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var request VerifyEmailRequestObject
+
+	var body VerifyEmailJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.VerifyEmail(ctx, request.(VerifyEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "VerifyEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(VerifyEmailResponseObject); ok {
+		if err := validResponse.VisitVerifyEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// RegisterDeviceToken operation middleware
-func (sh *strictHandler) RegisterDeviceToken(w http.ResponseWriter, r *http.Request) {
-	var request RegisterDeviceTokenRequestObject
+// RegisterWebPushSubscription operation middleware
+func (sh *strictHandler) RegisterWebPushSubscription(w http.ResponseWriter, r *http.Request) {
+	var request RegisterWebPushSubscriptionRequestObject
 
-	var body RegisterDeviceTokenJSONRequestBody
+	var body RegisterWebPushSubscriptionJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -10227,18 +14075,18 @@ func (sh *strictHandler) RegisterDeviceToken(w http.ResponseWriter, r *http.Requ
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RegisterDeviceToken(ctx, request.(RegisterDeviceTokenRequestObject))
+		return sh.ssi.RegisterWebPushSubscription(ctx, request.(RegisterWebPushSubscriptionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RegisterDeviceToken")
+		handler = middleware(handler, "RegisterWebPushSubscription")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RegisterDeviceTokenResponseObject); ok {
-		if err := validResponse.VisitRegisterDeviceTokenResponse(w); err != nil {
+	} else if validResponse, ok := response.(RegisterWebPushSubscriptionResponseObject); ok {
+		if err := validResponse.VisitRegisterWebPushSubscriptionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10246,25 +14094,25 @@ func (sh *strictHandler) RegisterDeviceToken(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// UnregisterDeviceToken operation middleware
-func (sh *strictHandler) UnregisterDeviceToken(w http.ResponseWriter, r *http.Request, id string) {
-	var request UnregisterDeviceTokenRequestObject
+// UnregisterWebPushSubscription operation middleware
+func (sh *strictHandler) UnregisterWebPushSubscription(w http.ResponseWriter, r *http.Request, id string) {
+	var request UnregisterWebPushSubscriptionRequestObject
 
 	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UnregisterDeviceToken(ctx, request.(UnregisterDeviceTokenRequestObject))
+		return sh.ssi.UnregisterWebPushSubscription(ctx, request.(UnregisterWebPushSubscriptionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UnregisterDeviceToken")
+		handler = middleware(handler, "UnregisterWebPushSubscription")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UnregisterDeviceTokenResponseObject); ok {
-		if err := validResponse.VisitUnregisterDeviceTokenResponse(w); err != nil {
+	} else if validResponse, ok := response.(UnregisterWebPushSubscriptionResponseObject); ok {
+		if err := validResponse.VisitUnregisterWebPushSubscriptionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10272,30 +14120,25 @@ func (sh *strictHandler) UnregisterDeviceToken(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// ForgotPassword operation middleware
-func (sh *strictHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
-	var request ForgotPasswordRequestObject
+// ArchiveChannel operation middleware
+func (sh *strictHandler) ArchiveChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request ArchiveChannelRequestObject
 
-	var body ForgotPasswordJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ForgotPassword(ctx, request.(ForgotPasswordRequestObject))
+		return sh.ssi.ArchiveChannel(ctx, request.(ArchiveChannelRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ForgotPassword")
+		handler = middleware(handler, "ArchiveChannel")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ForgotPasswordResponseObject); ok {
-		if err := validResponse.VisitForgotPasswordResponse(w); err != nil {
+	} else if validResponse, ok := response.(ArchiveChannelResponseObject); ok {
+		if err := validResponse.VisitArchiveChannelResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10303,11 +14146,13 @@ func (sh *strictHandler) ForgotPassword(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-// Login operation middleware
-func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
-	var request LoginRequestObject
+// ConvertGroupDMToChannel operation middleware
+func (sh *strictHandler) ConvertGroupDMToChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request ConvertGroupDMToChannelRequestObject
 
-	var body LoginJSONRequestBody
+	request.Id = id
+
+	var body ConvertGroupDMToChannelJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -10315,18 +14160,18 @@ func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Login(ctx, request.(LoginRequestObject))
+		return sh.ssi.ConvertGroupDMToChannel(ctx, request.(ConvertGroupDMToChannelRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Login")
+		handler = middleware(handler, "ConvertGroupDMToChannel")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(LoginResponseObject); ok {
-		if err := validResponse.VisitLoginResponse(w); err != nil {
+	} else if validResponse, ok := response.(ConvertGroupDMToChannelResponseObject); ok {
+		if err := validResponse.VisitConvertGroupDMToChannelResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10334,23 +14179,25 @@ func (sh *strictHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Logout operation middleware
-func (sh *strictHandler) Logout(w http.ResponseWriter, r *http.Request) {
-	var request LogoutRequestObject
+// DeleteChannelEmailAddress operation middleware
+func (sh *strictHandler) DeleteChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request DeleteChannelEmailAddressRequestObject
+
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Logout(ctx, request.(LogoutRequestObject))
+		return sh.ssi.DeleteChannelEmailAddress(ctx, request.(DeleteChannelEmailAddressRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Logout")
+		handler = middleware(handler, "DeleteChannelEmailAddress")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(LogoutResponseObject); ok {
-		if err := validResponse.VisitLogoutResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteChannelEmailAddressResponseObject); ok {
+		if err := validResponse.VisitDeleteChannelEmailAddressResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10358,23 +14205,25 @@ func (sh *strictHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetMe operation middleware
-func (sh *strictHandler) GetMe(w http.ResponseWriter, r *http.Request) {
-	var request GetMeRequestObject
+// GetChannelEmailAddress operation middleware
+func (sh *strictHandler) GetChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request GetChannelEmailAddressRequestObject
+
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetMe(ctx, request.(GetMeRequestObject))
+		return sh.ssi.GetChannelEmailAddress(ctx, request.(GetChannelEmailAddressRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetMe")
+		handler = middleware(handler, "GetChannelEmailAddress")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetMeResponseObject); ok {
-		if err := validResponse.VisitGetMeResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetChannelEmailAddressResponseObject); ok {
+		if err := validResponse.VisitGetChannelEmailAddressResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10382,11 +14231,13 @@ func (sh *strictHandler) GetMe(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Register operation middleware
-func (sh *strictHandler) Register(w http.ResponseWriter, r *http.Request) {
-	var request RegisterRequestObject
+// UpdateChannelEmailAddress operation middleware
+func (sh *strictHandler) UpdateChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request UpdateChannelEmailAddressRequestObject
 
-	var body RegisterJSONRequestBody
+	request.Id = id
+
+	var body UpdateChannelEmailAddressJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -10394,18 +14245,18 @@ func (sh *strictHandler) Register(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.Register(ctx, request.(RegisterRequestObject))
+		return sh.ssi.UpdateChannelEmailAddress(ctx, request.(UpdateChannelEmailAddressRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Register")
+		handler = middleware(handler, "UpdateChannelEmailAddress")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RegisterResponseObject); ok {
-		if err := validResponse.VisitRegisterResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateChannelEmailAddressResponseObject); ok {
+		if err := validResponse.VisitUpdateChannelEmailAddressResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10413,23 +14264,25 @@ func (sh *strictHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// ResendVerification operation middleware
-func (sh *strictHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
-	var request ResendVerificationRequestObject
+// RotateChannelEmailAddress operation middleware
+func (sh *strictHandler) RotateChannelEmailAddress(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request RotateChannelEmailAddressRequestObject
+
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ResendVerification(ctx, request.(ResendVerificationRequestObject))
+		return sh.ssi.RotateChannelEmailAddress(ctx, request.(RotateChannelEmailAddressRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ResendVerification")
+		handler = middleware(handler, "RotateChannelEmailAddress")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ResendVerificationResponseObject); ok {
-		if err := validResponse.VisitResendVerificationResponse(w); err != nil {
+	} else if validResponse, ok := response.(RotateChannelEmailAddressResponseObject); ok {
+		if err := validResponse.VisitRotateChannelEmailAddressResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10437,30 +14290,32 @@ func (sh *strictHandler) ResendVerification(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// ResetPassword operation middleware
-func (sh *strictHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
-	var request ResetPasswordRequestObject
+// UploadFile operation middleware
+func (sh *strictHandler) UploadFile(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request UploadFileRequestObject
 
-	var body ResetPasswordJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+	request.Id = id
+
+	if reader, err := r.MultipartReader(); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
 		return
+	} else {
+		request.Body = reader
 	}
-	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ResetPassword(ctx, request.(ResetPasswordRequestObject))
+		return sh.ssi.UploadFile(ctx, request.(UploadFileRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ResetPassword")
+		handler = middleware(handler, "UploadFile")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ResetPasswordResponseObject); ok {
-		if err := validResponse.VisitResetPasswordResponse(w); err != nil {
+	} else if validResponse, ok := response.(UploadFileResponseObject); ok {
+		if err := validResponse.VisitUploadFileResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10468,30 +14323,25 @@ func (sh *strictHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// VerifyEmail operation middleware
-func (sh *strictHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
-	var request VerifyEmailRequestObject
+// JoinChannel operation middleware
+func (sh *strictHandler) JoinChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request JoinChannelRequestObject
 
-	var body VerifyEmailJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.VerifyEmail(ctx, request.(VerifyEmailRequestObject))
+		return sh.ssi.JoinChannel(ctx, request.(JoinChannelRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "VerifyEmail")
+		handler = middleware(handler, "JoinChannel")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(VerifyEmailResponseObject); ok {
-		if err := validResponse.VisitVerifyEmailResponse(w); err != nil {
+	} else if validResponse, ok := response.(JoinChannelResponseObject); ok {
+		if err := validResponse.VisitJoinChannelResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10499,25 +14349,25 @@ func (sh *strictHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// ArchiveChannel operation middleware
-func (sh *strictHandler) ArchiveChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request ArchiveChannelRequestObject
+// LeaveChannel operation middleware
+func (sh *strictHandler) LeaveChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request LeaveChannelRequestObject
 
 	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ArchiveChannel(ctx, request.(ArchiveChannelRequestObject))
+		return sh.ssi.LeaveChannel(ctx, request.(LeaveChannelRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ArchiveChannel")
+		handler = middleware(handler, "LeaveChannel")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ArchiveChannelResponseObject); ok {
-		if err := validResponse.VisitArchiveChannelResponse(w); err != nil {
+	} else if validResponse, ok := response.(LeaveChannelResponseObject); ok {
+		if err := validResponse.VisitLeaveChannelResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10525,13 +14375,13 @@ func (sh *strictHandler) ArchiveChannel(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// ConvertGroupDMToChannel operation middleware
-func (sh *strictHandler) ConvertGroupDMToChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request ConvertGroupDMToChannelRequestObject
+// MarkChannelRead operation middleware
+func (sh *strictHandler) MarkChannelRead(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request MarkChannelReadRequestObject
 
 	request.Id = id
 
-	var body ConvertGroupDMToChannelJSONRequestBody
+	var body MarkChannelReadJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -10539,18 +14389,18 @@ func (sh *strictHandler) ConvertGroupDMToChannel(w http.ResponseWriter, r *http.
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ConvertGroupDMToChannel(ctx, request.(ConvertGroupDMToChannelRequestObject))
+		return sh.ssi.MarkChannelRead(ctx, request.(MarkChannelReadRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ConvertGroupDMToChannel")
+		handler = middleware(handler, "MarkChannelRead")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ConvertGroupDMToChannelResponseObject); ok {
-		if err := validResponse.VisitConvertGroupDMToChannelResponse(w); err != nil {
+	} else if validResponse, ok := response.(MarkChannelReadResponseObject); ok {
+		if err := validResponse.VisitMarkChannelReadResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10558,32 +14408,32 @@ func (sh *strictHandler) ConvertGroupDMToChannel(w http.ResponseWriter, r *http.
 	}
 }
 
-// UploadFile operation middleware
-func (sh *strictHandler) UploadFile(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request UploadFileRequestObject
+// AddChannelMember operation middleware
+func (sh *strictHandler) AddChannelMember(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request AddChannelMemberRequestObject
 
 	request.Id = id
 
-	if reader, err := r.MultipartReader(); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode multipart body: %w", err))
+	var body AddChannelMemberJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
-	} else {
-		request.Body = reader
 	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.UploadFile(ctx, request.(UploadFileRequestObject))
+		return sh.ssi.AddChannelMember(ctx, request.(AddChannelMemberRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UploadFile")
+		handler = middleware(handler, "AddChannelMember")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(UploadFileResponseObject); ok {
-		if err := validResponse.VisitUploadFileResponse(w); err != nil {
+	} else if validResponse, ok := response.(AddChannelMemberResponseObject); ok {
+		if err := validResponse.VisitAddChannelMemberResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10591,25 +14441,25 @@ func (sh *strictHandler) UploadFile(w http.ResponseWriter, r *http.Request, id C
 	}
 }
 
-// JoinChannel operation middleware
-func (sh *strictHandler) JoinChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request JoinChannelRequestObject
+// ListChannelMembers operation middleware
+func (sh *strictHandler) ListChannelMembers(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request ListChannelMembersRequestObject
 
 	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.JoinChannel(ctx, request.(JoinChannelRequestObject))
+		return sh.ssi.ListChannelMembers(ctx, request.(ListChannelMembersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "JoinChannel")
+		handler = middleware(handler, "ListChannelMembers")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(JoinChannelResponseObject); ok {
-		if err := validResponse.VisitJoinChannelResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListChannelMembersResponseObject); ok {
+		if err := validResponse.VisitListChannelMembersResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10617,25 +14467,91 @@ func (sh *strictHandler) JoinChannel(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
-// LeaveChannel operation middleware
-func (sh *strictHandler) LeaveChannel(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request LeaveChannelRequestObject
+// GetChannelMentionPermissions operation middleware
+func (sh *strictHandler) GetChannelMentionPermissions(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request GetChannelMentionPermissionsRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetChannelMentionPermissions(ctx, request.(GetChannelMentionPermissionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetChannelMentionPermissions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetChannelMentionPermissionsResponseObject); ok {
+		if err := validResponse.VisitGetChannelMentionPermissionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateChannelMentionPermissions operation middleware
+func (sh *strictHandler) UpdateChannelMentionPermissions(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request UpdateChannelMentionPermissionsRequestObject
+
+	request.Id = id
+
+	var body UpdateChannelMentionPermissionsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateChannelMentionPermissions(ctx, request.(UpdateChannelMentionPermissionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateChannelMentionPermissions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateChannelMentionPermissionsResponseObject); ok {
+		if err := validResponse.VisitUpdateChannelMentionPermissionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListMessages operation middleware
+func (sh *strictHandler) ListMessages(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request ListMessagesRequestObject
 
 	request.Id = id
 
+	var body ListMessagesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.LeaveChannel(ctx, request.(LeaveChannelRequestObject))
+		return sh.ssi.ListMessages(ctx, request.(ListMessagesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "LeaveChannel")
+		handler = middleware(handler, "ListMessages")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(LeaveChannelResponseObject); ok {
-		if err := validResponse.VisitLeaveChannelResponse(w); err != nil {
+	} else if validResponse, ok := response.(ListMessagesResponseObject); ok {
+		if err := validResponse.VisitListMessagesResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10643,13 +14559,13 @@ func (sh *strictHandler) LeaveChannel(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
-// MarkChannelRead operation middleware
-func (sh *strictHandler) MarkChannelRead(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request MarkChannelReadRequestObject
+// ScheduleMessage operation middleware
+func (sh *strictHandler) ScheduleMessage(w http.ResponseWriter, r *http.Request, id string) {
+	var request ScheduleMessageRequestObject
 
 	request.Id = id
 
-	var body MarkChannelReadJSONRequestBody
+	var body ScheduleMessageJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -10657,18 +14573,18 @@ func (sh *strictHandler) MarkChannelRead(w http.ResponseWriter, r *http.Request,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.MarkChannelRead(ctx, request.(MarkChannelReadRequestObject))
+		return sh.ssi.ScheduleMessage(ctx, request.(ScheduleMessageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "MarkChannelRead")
+		handler = middleware(handler, "ScheduleMessage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(MarkChannelReadResponseObject); ok {
-		if err := validResponse.VisitMarkChannelReadResponse(w); err != nil {
+	} else if validResponse, ok := response.(ScheduleMessageResponseObject); ok {
+		if err := validResponse.VisitScheduleMessageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10676,13 +14592,13 @@ func (sh *strictHandler) MarkChannelRead(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// AddChannelMember operation middleware
-func (sh *strictHandler) AddChannelMember(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request AddChannelMemberRequestObject
+// SendMessage operation middleware
+func (sh *strictHandler) SendMessage(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request SendMessageRequestObject
 
 	request.Id = id
 
-	var body AddChannelMemberJSONRequestBody
+	var body SendMessageJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -10690,18 +14606,18 @@ func (sh *strictHandler) AddChannelMember(w http.ResponseWriter, r *http.Request
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AddChannelMember(ctx, request.(AddChannelMemberRequestObject))
+		return sh.ssi.SendMessage(ctx, request.(SendMessageRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AddChannelMember")
+		handler = middleware(handler, "SendMessage")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AddChannelMemberResponseObject); ok {
-		if err := validResponse.VisitAddChannelMemberResponse(w); err != nil {
+	} else if validResponse, ok := response.(SendMessageResponseObject); ok {
+		if err := validResponse.VisitSendMessageResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10709,25 +14625,25 @@ func (sh *strictHandler) AddChannelMember(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// ListChannelMembers operation middleware
-func (sh *strictHandler) ListChannelMembers(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request ListChannelMembersRequestObject
+// DeleteChannelNotificationDefaults operation middleware
+func (sh *strictHandler) DeleteChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request DeleteChannelNotificationDefaultsRequestObject
 
 	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListChannelMembers(ctx, request.(ListChannelMembersRequestObject))
+		return sh.ssi.DeleteChannelNotificationDefaults(ctx, request.(DeleteChannelNotificationDefaultsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListChannelMembers")
+		handler = middleware(handler, "DeleteChannelNotificationDefaults")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListChannelMembersResponseObject); ok {
-		if err := validResponse.VisitListChannelMembersResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteChannelNotificationDefaultsResponseObject); ok {
+		if err := validResponse.VisitDeleteChannelNotificationDefaultsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10735,32 +14651,25 @@ func (sh *strictHandler) ListChannelMembers(w http.ResponseWriter, r *http.Reque
 	}
 }
 
-// ListMessages operation middleware
-func (sh *strictHandler) ListMessages(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request ListMessagesRequestObject
+// GetChannelNotificationDefaults operation middleware
+func (sh *strictHandler) GetChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request GetChannelNotificationDefaultsRequestObject
 
 	request.Id = id
 
-	var body ListMessagesJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ListMessages(ctx, request.(ListMessagesRequestObject))
+		return sh.ssi.GetChannelNotificationDefaults(ctx, request.(GetChannelNotificationDefaultsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ListMessages")
+		handler = middleware(handler, "GetChannelNotificationDefaults")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ListMessagesResponseObject); ok {
-		if err := validResponse.VisitListMessagesResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetChannelNotificationDefaultsResponseObject); ok {
+		if err := validResponse.VisitGetChannelNotificationDefaultsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10768,13 +14677,13 @@ func (sh *strictHandler) ListMessages(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
-// ScheduleMessage operation middleware
-func (sh *strictHandler) ScheduleMessage(w http.ResponseWriter, r *http.Request, id string) {
-	var request ScheduleMessageRequestObject
+// UpdateChannelNotificationDefaults operation middleware
+func (sh *strictHandler) UpdateChannelNotificationDefaults(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request UpdateChannelNotificationDefaultsRequestObject
 
 	request.Id = id
 
-	var body ScheduleMessageJSONRequestBody
+	var body UpdateChannelNotificationDefaultsJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -10782,18 +14691,18 @@ func (sh *strictHandler) ScheduleMessage(w http.ResponseWriter, r *http.Request,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ScheduleMessage(ctx, request.(ScheduleMessageRequestObject))
+		return sh.ssi.UpdateChannelNotificationDefaults(ctx, request.(UpdateChannelNotificationDefaultsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ScheduleMessage")
+		handler = middleware(handler, "UpdateChannelNotificationDefaults")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ScheduleMessageResponseObject); ok {
-		if err := validResponse.VisitScheduleMessageResponse(w); err != nil {
+	} else if validResponse, ok := response.(UpdateChannelNotificationDefaultsResponseObject); ok {
+		if err := validResponse.VisitUpdateChannelNotificationDefaultsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -10801,32 +14710,25 @@ func (sh *strictHandler) ScheduleMessage(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// SendMessage operation middleware
-func (sh *strictHandler) SendMessage(w http.ResponseWriter, r *http.Request, id ChannelId) {
-	var request SendMessageRequestObject
+// ResetChannelNotifications operation middleware
+func (sh *strictHandler) ResetChannelNotifications(w http.ResponseWriter, r *http.Request, id ChannelId) {
+	var request ResetChannelNotificationsRequestObject
 
 	request.Id = id
 
-	var body SendMessageJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
-	}
-	request.Body = &body
-
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.SendMessage(ctx, request.(SendMessageRequestObject))
+		return sh.ssi.ResetChannelNotifications(ctx, request.(ResetChannelNotificationsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "SendMessage")
+		handler = middleware(handler, "ResetChannelNotifications")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(SendMessageResponseObject); ok {
-		if err := validResponse.VisitSendMessageResponse(w); err != nil {
+	} else if validResponse, ok := response.(ResetChannelNotificationsResponseObject); ok {
+		if err := validResponse.VisitResetChannelNotificationsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -11011,6 +14913,32 @@ func (sh *strictHandler) UpdateChannel(w http.ResponseWriter, r *http.Request, i
 	}
 }
 
+// UnsubscribeFromEmail operation middleware
+func (sh *strictHandler) UnsubscribeFromEmail(w http.ResponseWriter, r *http.Request, params UnsubscribeFromEmailParams) {
+	var request UnsubscribeFromEmailRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UnsubscribeFromEmail(ctx, request.(UnsubscribeFromEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnsubscribeFromEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UnsubscribeFromEmailResponseObject); ok {
+		if err := validResponse.VisitUnsubscribeFromEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // DeleteCustomEmoji operation middleware
 func (sh *strictHandler) DeleteCustomEmoji(w http.ResponseWriter, r *http.Request, id string) {
 	var request DeleteCustomEmojiRequestObject
@@ -11762,6 +15690,61 @@ func (sh *strictHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetNotificationSettings operation middleware
+func (sh *strictHandler) GetNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	var request GetNotificationSettingsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetNotificationSettings(ctx, request.(GetNotificationSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetNotificationSettings")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetNotificationSettingsResponseObject); ok {
+		if err := validResponse.VisitGetNotificationSettingsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateNotificationSettings operation middleware
+func (sh *strictHandler) UpdateNotificationSettings(w http.ResponseWriter, r *http.Request) {
+	var request UpdateNotificationSettingsRequestObject
+
+	var body UpdateNotificationSettingsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateNotificationSettings(ctx, request.(UpdateNotificationSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateNotificationSettings")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateNotificationSettingsResponseObject); ok {
+		if err := validResponse.VisitUpdateNotificationSettingsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // UpdateProfile operation middleware
 func (sh *strictHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	var request UpdateProfileRequestObject
@@ -12575,6 +16558,150 @@ func (sh *strictHandler) ListModerationLog(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// GetWorkspaceNotificationDefaults operation middleware
+func (sh *strictHandler) GetWorkspaceNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	var request GetWorkspaceNotificationDefaultsRequestObject
+
+	request.Wid = wid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWorkspaceNotificationDefaults(ctx, request.(GetWorkspaceNotificationDefaultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWorkspaceNotificationDefaults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWorkspaceNotificationDefaultsResponseObject); ok {
+		if err := validResponse.VisitGetWorkspaceNotificationDefaultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateWorkspaceNotificationDefaults operation middleware
+func (sh *strictHandler) UpdateWorkspaceNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	var request UpdateWorkspaceNotificationDefaultsRequestObject
+
+	request.Wid = wid
+
+	var body UpdateWorkspaceNotificationDefaultsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateWorkspaceNotificationDefaults(ctx, request.(UpdateWorkspaceNotificationDefaultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateWorkspaceNotificationDefaults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateWorkspaceNotificationDefaultsResponseObject); ok {
+		if err := validResponse.VisitUpdateWorkspaceNotificationDefaultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetWorkspaceNotificationPreferences operation middleware
+func (sh *strictHandler) GetWorkspaceNotificationPreferences(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	var request GetWorkspaceNotificationPreferencesRequestObject
+
+	request.Wid = wid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWorkspaceNotificationPreferences(ctx, request.(GetWorkspaceNotificationPreferencesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWorkspaceNotificationPreferences")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetWorkspaceNotificationPreferencesResponseObject); ok {
+		if err := validResponse.VisitGetWorkspaceNotificationPreferencesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResetNotificationDefaults operation middleware
+func (sh *strictHandler) ResetNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	var request ResetNotificationDefaultsRequestObject
+
+	request.Wid = wid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResetNotificationDefaults(ctx, request.(ResetNotificationDefaultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResetNotificationDefaults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResetNotificationDefaultsResponseObject); ok {
+		if err := validResponse.VisitResetNotificationDefaultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateNotificationDefaults operation middleware
+func (sh *strictHandler) UpdateNotificationDefaults(w http.ResponseWriter, r *http.Request, wid WorkspaceId) {
+	var request UpdateNotificationDefaultsRequestObject
+
+	request.Wid = wid
+
+	var body UpdateNotificationDefaultsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateNotificationDefaults(ctx, request.(UpdateNotificationDefaultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateNotificationDefaults")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateNotificationDefaultsResponseObject); ok {
+		if err := validResponse.VisitUpdateNotificationDefaultsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListScheduledMessages operation middleware
 func (sh *strictHandler) ListScheduledMessages(w http.ResponseWriter, r *http.Request, wid string) {
 	var request ListScheduledMessagesRequestObject
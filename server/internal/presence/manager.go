@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/enzyme/server/internal/openapi"
@@ -34,6 +35,10 @@ type Manager struct {
 
 	db  *sql.DB
 	hub *sse.Hub
+
+	// clustered is set when other instances share the database, whose
+	// presence changes are only seen by reloading it
+	clustered atomic.Bool
 }
 
 func NewManager(db *sql.DB, hub *sse.Hub) *Manager {
@@ -49,12 +54,24 @@ func (m *Manager) Init() {
 	m.loadFromDB()
 }
 
+// SetClustered records that other instances share the database, e.g. when
+// a cluster broker is configured.
+func (m *Manager) SetClustered(clustered bool) {
+	m.clustered.Store(clustered)
+}
+
 // CheckPresence marks users as offline if they've been disconnected beyond the timeout.
+// When clustered, state is reloaded from the database first, since other instances
+// update it too; a single instance's memory already holds every change.
 func (m *Manager) CheckPresence(ctx context.Context) error {
+	if m.clustered.Load() {
+		m.loadFromDB()
+	}
 	m.checkPresence(ctx)
 	return nil
 }
 
+// loadFromDB replaces the in-memory state with the persisted one.
 func (m *Manager) loadFromDB() {
 	if m.db == nil {
 		return
@@ -69,9 +86,7 @@ func (m *Manager) loadFromDB() {
 	}
 	defer rows.Close()
 
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
+	presence := make(map[string]map[string]*UserPresence)
 	for rows.Next() {
 		var p UserPresence
 		var lastSeenAt string
@@ -80,14 +95,19 @@ func (m *Manager) loadFromDB() {
 		}
 		p.LastSeenAt, _ = time.Parse(time.RFC3339, lastSeenAt)
 
-		if m.presence[p.WorkspaceID] == nil {
-			m.presence[p.WorkspaceID] = make(map[string]*UserPresence)
+		if presence[p.WorkspaceID] == nil {
+			presence[p.WorkspaceID] = make(map[string]*UserPresence)
 		}
-		m.presence[p.WorkspaceID][p.UserID] = &p
+		presence[p.WorkspaceID][p.UserID] = &p
 	}
 	if err := rows.Err(); err != nil {
 		slog.Error("error iterating presence rows", "error", err)
+		return
 	}
+
+	m.mu.Lock()
+	m.presence = presence
+	m.mu.Unlock()
 }
 
 func (m *Manager) SetOnline(workspaceID, userID string) {
@@ -255,9 +275,31 @@ func (m *Manager) checkPresence(ctx context.Context) {
 	m.mu.Unlock()
 
 	for _, c := range offlineChanges {
-		m.persistPresence(ctx, c.workspaceID, c.userID, StatusOffline, now)
-		m.broadcastPresenceChange(c.workspaceID, c.userID, openapi.Offline)
+		if m.markOffline(ctx, c.workspaceID, c.userID, now) {
+			m.broadcastPresenceChange(c.workspaceID, c.userID, openapi.Offline)
+		}
+	}
+}
+
+// markOffline persists an offline transition found by checkPresence. Every
+// instance runs the check, so the update only applies if the user is still
+// online and hasn't been seen since the timeout; the instance whose update
+// applies is the one that broadcasts the change.
+func (m *Manager) markOffline(ctx context.Context, workspaceID, userID string, now time.Time) bool {
+	if m.db == nil {
+		return true
+	}
+
+	result, err := m.db.ExecContext(ctx, `
+		UPDATE user_presence SET status = ?
+		WHERE user_id = ? AND workspace_id = ? AND status != ? AND last_seen_at <= ?
+	`, StatusOffline, userID, workspaceID, StatusOffline, now.Add(-OfflineTimeout).Format(time.RFC3339))
+	if err != nil {
+		slog.Error("failed to persist offline presence", "user_id", userID, "error", err)
+		return false
 	}
+	n, err := result.RowsAffected()
+	return err == nil && n > 0
 }
 
 func (m *Manager) persistPresence(ctx context.Context, workspaceID, userID, status string, lastSeen time.Time) {
@@ -0,0 +1,101 @@
+package presence
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/sse"
+	"github.com/enzyme/server/internal/testutil"
+)
+
+func TestCheckPresence_OneInstanceBroadcastsOffline(t *testing.T) {
+	db := testutil.TestDB(t)
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	away := testutil.CreateTestUser(t, db, "away@example.com", "Away")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+
+	// Two instances sharing a database and a broker
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	broker := sse.NewLocalBroker()
+	var managers []*Manager
+	var hubs []*sse.Hub
+	for i := 0; i < 2; i++ {
+		hub := sse.NewHub(db, 0)
+		hub.SetBroker(broker)
+		go hub.Run(ctx)
+		hubs = append(hubs, hub)
+		m := NewManager(db, hub)
+		m.SetClustered(true)
+		managers = append(managers, m)
+	}
+
+	watcher := &sse.Client{ID: "watcher", UserID: owner.ID, WorkspaceID: ws.ID, Send: make(chan sse.SerializedEvent, 16), Done: make(chan struct{})}
+	hubs[0].Register(watcher)
+	select {
+	case <-watcher.Send: // the watcher's own online event
+	case <-time.After(time.Second):
+		t.Fatal("watcher was not registered")
+	}
+
+	// The user was last seen by another instance, long enough ago to time out
+	lastSeen := time.Now().UTC().Add(-2 * OfflineTimeout)
+	managers[1].persistPresence(ctx, ws.ID, away.ID, StatusOnline, lastSeen)
+
+	for _, m := range managers {
+		if err := m.CheckPresence(ctx); err != nil {
+			t.Fatalf("CheckPresence: %v", err)
+		}
+		if got := m.GetPresence(ws.ID, away.ID); got != StatusOffline {
+			t.Fatalf("presence = %q, want offline", got)
+		}
+	}
+
+	offline := 0
+	timeout := time.After(200 * time.Millisecond)
+	for done := false; !done; {
+		select {
+		case e := <-watcher.Send:
+			if strings.Contains(string(e.Frame), `"status":"offline","user_id":"`+away.ID+`"`) {
+				offline++
+			}
+		case <-timeout:
+			done = true
+		}
+	}
+	if offline != 1 {
+		t.Fatalf("offline events = %d, want exactly 1", offline)
+	}
+}
+
+func TestCheckPresence_ReloadsOnlyWhenClustered(t *testing.T) {
+	db := testutil.TestDB(t)
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	ws := testutil.CreateTestWorkspace(t, db, owner.ID, "Test Workspace")
+	ctx := context.Background()
+
+	single := NewManager(db, nil)
+	clustered := NewManager(db, nil)
+	clustered.SetClustered(true)
+
+	// Another instance sees the user
+	NewManager(db, nil).persistPresence(ctx, ws.ID, owner.ID, StatusOnline, time.Now().UTC())
+
+	for _, tt := range []struct {
+		name string
+		m    *Manager
+		want string
+	}{
+		{"single", single, StatusOffline},
+		{"clustered", clustered, StatusOnline},
+	} {
+		if err := tt.m.CheckPresence(ctx); err != nil {
+			t.Fatalf("%s: CheckPresence: %v", tt.name, err)
+		}
+		if got := tt.m.GetPresence(ws.ID, owner.ID); got != tt.want {
+			t.Errorf("%s: presence = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
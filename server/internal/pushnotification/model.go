@@ -7,7 +7,7 @@ type DeviceToken struct {
 	ID        string
 	UserID    string
 	Token     string
-	Platform  string // "fcm" or "apns"
+	Platform  string // "fcm", "apns" or "unifiedpush"
 	DeviceID  string
 	CreatedAt time.Time
 	UpdatedAt time.Time
@@ -53,3 +53,35 @@ type RelayResponse struct {
 	Status string `json:"status"` // "sent", "invalid_token", "error"
 	Error  string `json:"error,omitempty"`
 }
+
+// WebPushSubscription is a browser's Web Push subscription (RFC 8030),
+// delivered to directly rather than through the relay.
+type WebPushSubscription struct {
+	ID        string
+	UserID    string
+	Endpoint  string // push service URL for this browser
+	P256DH    string // browser's public key, base64url
+	Auth      string // browser's authentication secret, base64url
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DirectPayload is the JSON body of notifications the server delivers itself
+// rather than through the relay: encrypted into Web Push messages, or posted
+// as is to UnifiedPush distributors.
+type DirectPayload struct {
+	Title string           `json:"title"`
+	Body  string           `json:"body"`
+	Data  RelayRequestData `json:"data"`
+}
+
+func newRelayRequestData(data NotificationData) RelayRequestData {
+	return RelayRequestData{
+		ChannelID:      data.ChannelID,
+		MessageID:      data.MessageID,
+		WorkspaceID:    data.WorkspaceID,
+		ChannelName:    data.ChannelName,
+		ThreadParentID: data.ThreadParentID,
+		ServerURL:      data.ServerURL,
+	}
+}
@@ -38,12 +38,12 @@ func (r *Repository) Upsert(ctx context.Context, token *DeviceToken) error {
 
 	// Evict oldest token if at limit (only matters for new inserts, not upsert updates)
 	_, err = tx.ExecContext(ctx, `
-		DELETE FROM device_tokens WHERE id IN (
+		DELETE FROM device_tokens WHERE user_id = ? AND id NOT IN (
 			SELECT id FROM device_tokens WHERE user_id = ?
 			ORDER BY updated_at DESC
-			LIMIT -1 OFFSET ?
+			LIMIT ?
 		)
-	`, token.UserID, MaxTokensPerUser-1)
+	`, token.UserID, token.UserID, MaxTokensPerUser-1)
 	if err != nil {
 		return fmt.Errorf("evicting oldest token: %w", err)
 	}
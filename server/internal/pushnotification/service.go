@@ -11,21 +11,51 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/enzyme/server/internal/relayauth"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"golang.org/x/sync/errgroup"
 )
 
-// Service handles sending push notifications via the relay.
+// Pre-computed metric attribute sets, one per relay outcome.
+var (
+	relayAttrsSent         = metric.WithAttributes(attribute.String("outcome", "sent"))
+	relayAttrsInvalidToken = metric.WithAttributes(attribute.String("outcome", "invalid_token"))
+	relayAttrsRelayError   = metric.WithAttributes(attribute.String("outcome", "relay_error"))
+	relayAttrsFailed       = metric.WithAttributes(attribute.String("outcome", "request_failed"))
+)
+
+// Service handles sending push notifications to registered devices: via the
+// relay for FCM and APNs, and directly for UnifiedPush.
 type Service struct {
 	repo     *Repository
-	relayURL string
+	relayURL string // empty when the relay is disabled
+	relayKey *relayauth.Credential
 	client   *http.Client
+
+	// UnifiedPush delivery; nil client until EnableUnifiedPush
+	unifiedPushClient *http.Client
+	retryBackoff      time.Duration
+
+	// OTel metrics (no-op when telemetry is disabled)
+	relayOutcomes       metric.Int64Counter
+	unifiedPushOutcomes metric.Int64Counter
 }
 
-// NewService creates a new push notification service.
+// NewService creates a new push notification service. Pass an empty relayURL
+// to deliver only to UnifiedPush devices.
 func NewService(repo *Repository, relayURL string) *Service {
+	relayOutcomes, err := otel.Meter("enzyme.push").Int64Counter("push.relay.requests",
+		metric.WithDescription("Push relay requests by outcome"),
+	)
+	if err != nil {
+		slog.Error("failed to create push.relay.requests metric", "error", err)
+	}
 	return &Service{
-		repo:     repo,
-		relayURL: relayURL,
+		relayOutcomes: relayOutcomes,
+		repo:          repo,
+		relayURL:      relayURL,
 		client: &http.Client{
 			Timeout: 5 * time.Second,
 			Transport: &http.Transport{
@@ -37,6 +67,13 @@ func NewService(repo *Repository, relayURL string) *Service {
 	}
 }
 
+// SetRelayKey makes the service sign relay requests with the key the relay
+// issued for this server. Without one, requests are sent unsigned, which
+// relays that require authentication refuse.
+func (s *Service) SetRelayKey(key relayauth.Credential) {
+	s.relayKey = &key
+}
+
 // Send dispatches push notifications for a user. Returns true if at least one
 // notification was successfully dispatched (meaning we should suppress email fallback).
 func (s *Service) Send(ctx context.Context, userID string, data NotificationData) bool {
@@ -49,19 +86,34 @@ func (s *Service) Send(ctx context.Context, userID string, data NotificationData
 		return false
 	}
 
-	relayData := RelayRequestData{
-		ChannelID:      data.ChannelID,
-		MessageID:      data.MessageID,
-		WorkspaceID:    data.WorkspaceID,
-		ChannelName:    data.ChannelName,
-		ThreadParentID: data.ThreadParentID,
-		ServerURL:      data.ServerURL,
+	relayData := newRelayRequestData(data)
+
+	var directPayload []byte
+	if s.unifiedPushClient != nil {
+		directPayload, err = json.Marshal(DirectPayload{Title: data.Title, Body: data.Body, Data: relayData})
+		if err != nil {
+			slog.Error("push: failed to encode payload", "error", err)
+			return false
+		}
 	}
 
 	var dispatched atomic.Bool
 
 	g, gCtx := errgroup.WithContext(ctx)
 	for _, t := range tokens {
+		if !s.SupportsPlatform(t.Platform) {
+			continue
+		}
+		if t.Platform == "unifiedpush" {
+			g.Go(func() error {
+				if s.sendUnifiedPush(gCtx, userID, t, directPayload) {
+					dispatched.Store(true)
+				}
+				return nil
+			})
+			continue
+		}
+
 		g.Go(func() error {
 			req := RelayRequest{
 				DeviceToken: t.Token,
@@ -74,19 +126,23 @@ func (s *Service) Send(ctx context.Context, userID string, data NotificationData
 			resp, err := s.sendToRelay(gCtx, req)
 			if err != nil {
 				slog.Error("push: relay request failed", "token_id", t.ID, "error", err)
+				s.recordOutcome(gCtx, relayAttrsFailed)
 				return nil // don't abort other sends
 			}
 
 			switch resp.Status {
 			case "sent":
 				dispatched.Store(true)
+				s.recordOutcome(gCtx, relayAttrsSent)
 			case "invalid_token":
+				s.recordOutcome(gCtx, relayAttrsInvalidToken)
 				slog.Info("push: removing invalid token", "token_id", t.ID)
 				if err := s.repo.Delete(gCtx, userID, t.Token); err != nil {
 					slog.Error("push: failed to delete invalid token", "token_id", t.ID, "error", err)
 				}
 			default:
 				slog.Error("push: relay returned error", "token_id", t.ID, "status", resp.Status, "error", resp.Error)
+				s.recordOutcome(gCtx, relayAttrsRelayError)
 			}
 			return nil
 		})
@@ -96,6 +152,12 @@ func (s *Service) Send(ctx context.Context, userID string, data NotificationData
 	return dispatched.Load()
 }
 
+func (s *Service) recordOutcome(ctx context.Context, attrs metric.MeasurementOption) {
+	if s.relayOutcomes != nil {
+		s.relayOutcomes.Add(ctx, 1, attrs)
+	}
+}
+
 func (s *Service) sendToRelay(ctx context.Context, payload RelayRequest) (*RelayResponse, error) {
 	body, err := json.Marshal(payload)
 	if err != nil {
@@ -107,6 +169,9 @@ func (s *Service) sendToRelay(ctx context.Context, payload RelayRequest) (*Relay
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	if s.relayKey != nil {
+		s.relayKey.SignRequest(req, body, time.Now())
+	}
 
 	resp, err := s.client.Do(req)
 	if err != nil {
@@ -114,6 +179,9 @@ func (s *Service) sendToRelay(ctx context.Context, payload RelayRequest) (*Relay
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, fmt.Errorf("relay rejected this server's credentials; check push_notifications.relay_key")
+	}
 	if resp.StatusCode >= 400 {
 		return nil, fmt.Errorf("relay returned HTTP %d", resp.StatusCode)
 	}
@@ -9,7 +9,9 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/enzyme/server/internal/relayauth"
 	"github.com/enzyme/server/internal/testutil"
 )
 
@@ -220,3 +222,37 @@ func TestSendOmitsEmptyOptionalFields(t *testing.T) {
 		t.Errorf("expected channel_name to be omitted from JSON, got: %s", bodyStr)
 	}
 }
+
+func TestSendSignsRelayRequests(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewRepository(db)
+	user := testutil.CreateTestUser(t, db, "test@example.com", "Test")
+	ctx := context.Background()
+
+	if err := repo.Upsert(ctx, &DeviceToken{
+		UserID: user.ID, Token: "token-1", Platform: "fcm", DeviceID: "device-1",
+	}); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	key := relayauth.Credential{KeyID: "rk_test", Secret: "s3cret"}
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(relayauth.HeaderKeyID) != key.KeyID {
+			t.Errorf("expected key ID %q, got %q", key.KeyID, r.Header.Get(relayauth.HeaderKeyID))
+		}
+		if err := relayauth.Verify(key.Secret, r.Header, body, time.Now()); err != nil {
+			t.Errorf("signature did not verify: %v", err)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(RelayResponse{Status: "sent"})
+	}))
+	defer relay.Close()
+
+	svc := NewService(repo, relay.URL)
+	svc.SetRelayKey(key)
+	if !svc.Send(ctx, user.ID, NotificationData{Title: "test", Body: "test"}) {
+		t.Fatal("expected Send to return true")
+	}
+}
@@ -0,0 +1,132 @@
+package pushnotification
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+var ErrSubscriptionNotFound = errors.New("web push subscription not found")
+
+// SubscriptionRepository stores browser Web Push subscriptions.
+type SubscriptionRepository struct {
+	db *sql.DB
+}
+
+func NewSubscriptionRepository(db *sql.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+// Upsert inserts a subscription or updates the existing one for the same
+// endpoint, moving it to sub.UserID. If the user already has MaxTokensPerUser
+// subscriptions, the least-recently-updated one is evicted.
+func (r *SubscriptionRepository) Upsert(ctx context.Context, sub *WebPushSubscription) error {
+	now := time.Now().UTC()
+	if sub.ID == "" {
+		sub.ID = ulid.Make().String()
+	}
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM web_push_subscriptions WHERE user_id = ? AND endpoint != ? AND id NOT IN (
+			SELECT id FROM web_push_subscriptions WHERE user_id = ?
+			ORDER BY updated_at DESC
+			LIMIT ?
+		)
+	`, sub.UserID, sub.Endpoint, sub.UserID, MaxTokensPerUser-1)
+	if err != nil {
+		return fmt.Errorf("evicting oldest subscription: %w", err)
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO web_push_subscriptions (id, user_id, endpoint, p256dh, auth, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(endpoint) DO UPDATE SET
+			user_id = excluded.user_id,
+			p256dh = excluded.p256dh,
+			auth = excluded.auth,
+			updated_at = excluded.updated_at
+		RETURNING id
+	`, sub.ID, sub.UserID, sub.Endpoint, sub.P256DH, sub.Auth,
+		now.Format(time.RFC3339), now.Format(time.RFC3339)).Scan(&sub.ID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteByID removes a subscription by its record ID, scoped to a user.
+func (r *SubscriptionRepository) DeleteByID(ctx context.Context, userID, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM web_push_subscriptions WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("checking rows affected: %w", err)
+	}
+	if n == 0 {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// DeleteByEndpoint removes the subscription for a push service endpoint.
+func (r *SubscriptionRepository) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM web_push_subscriptions WHERE endpoint = ?`, endpoint)
+	return err
+}
+
+// ListByUserID returns all Web Push subscriptions for a user.
+func (r *SubscriptionRepository) ListByUserID(ctx context.Context, userID string) ([]*WebPushSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, endpoint, p256dh, auth, created_at, updated_at
+		FROM web_push_subscriptions WHERE user_id = ?
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*WebPushSubscription
+	for rows.Next() {
+		var s WebPushSubscription
+		var createdAt, updatedAt string
+
+		if err := rows.Scan(&s.ID, &s.UserID, &s.Endpoint, &s.P256DH, &s.Auth, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+
+		s.CreatedAt, err = time.Parse(time.RFC3339, createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("parsing created_at: %w", err)
+		}
+		s.UpdatedAt, err = time.Parse(time.RFC3339, updatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parsing updated_at: %w", err)
+		}
+		subs = append(subs, &s)
+	}
+	return subs, rows.Err()
+}
+
+// CleanupStale removes subscriptions that haven't been renewed since the given time.
+func (r *SubscriptionRepository) CleanupStale(ctx context.Context, olderThan time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM web_push_subscriptions WHERE updated_at < ?`, olderThan.Format(time.RFC3339))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,139 @@
+package pushnotification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/enzyme/server/internal/linkpreview"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// unifiedPushAttempts is how many times a message is offered to a
+	// distributor that fails with a network error, 429 or 5xx.
+	unifiedPushAttempts = 3
+
+	unifiedPushRetryBackoff = 500 * time.Millisecond
+	unifiedPushTimeout      = 5 * time.Second
+)
+
+// EnableUnifiedPush turns on delivery to "unifiedpush" device tokens, whose
+// token is the endpoint URL of the device's distributor (e.g. an ntfy topic).
+// Messages are posted to it directly, keeping Google and Apple out of the
+// loop. If client is nil, an SSRF-safe client is used, since endpoints come
+// from clients.
+func (s *Service) EnableUnifiedPush(client *http.Client) {
+	outcomes, err := otel.Meter("enzyme.push").Int64Counter("push.unifiedpush.requests",
+		metric.WithDescription("UnifiedPush deliveries by outcome"),
+	)
+	if err != nil {
+		slog.Error("failed to create push.unifiedpush.requests metric", "error", err)
+	}
+	if client == nil {
+		client = linkpreview.NewSafeClient(unifiedPushTimeout)
+	}
+	s.unifiedPushClient = client
+	s.unifiedPushOutcomes = outcomes
+	s.retryBackoff = unifiedPushRetryBackoff
+}
+
+// SupportsPlatform reports whether device tokens for the platform can be
+// delivered: "fcm" and "apns" need the relay, "unifiedpush" needs
+// EnableUnifiedPush.
+func (s *Service) SupportsPlatform(platform string) bool {
+	switch platform {
+	case "fcm", "apns":
+		return s.relayURL != ""
+	case "unifiedpush":
+		return s.unifiedPushClient != nil
+	default:
+		return false
+	}
+}
+
+// ValidateUnifiedPushEndpoint checks a distributor endpoint registered as a
+// "unifiedpush" device token. Whether it may be reached is decided at
+// delivery, by the SSRF-safe client.
+func ValidateUnifiedPushEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User != nil {
+		return fmt.Errorf("endpoint must be an http or https URL")
+	}
+	return nil
+}
+
+// sendUnifiedPush delivers a message to one distributor, removing the token
+// if the distributor reports the endpoint gone. Returns true if it was
+// accepted.
+func (s *Service) sendUnifiedPush(ctx context.Context, userID string, t *DeviceToken, payload []byte) bool {
+	status, err := s.postToDistributor(ctx, t.Token, payload)
+	switch {
+	case err != nil:
+		slog.Error("push: unifiedpush request failed", "token_id", t.ID, "error", err)
+		s.recordUnifiedPushOutcome(ctx, directAttrsFailed)
+	case status >= 200 && status < 300:
+		s.recordUnifiedPushOutcome(ctx, directAttrsSent)
+		return true
+	case status == http.StatusNotFound || status == http.StatusGone:
+		s.recordUnifiedPushOutcome(ctx, directAttrsExpired)
+		slog.Info("push: removing expired unifiedpush endpoint", "token_id", t.ID)
+		if err := s.repo.Delete(ctx, userID, t.Token); err != nil {
+			slog.Error("push: failed to delete expired unifiedpush endpoint", "token_id", t.ID, "error", err)
+		}
+	default:
+		slog.Error("push: distributor rejected message", "token_id", t.ID, "status", status)
+		s.recordUnifiedPushOutcome(ctx, directAttrsRejected)
+	}
+	return false
+}
+
+// postToDistributor posts the message, retrying network errors, 429s and
+// 5xxs with exponential backoff. Returns the final response status.
+func (s *Service) postToDistributor(ctx context.Context, endpoint string, payload []byte) (int, error) {
+	backoff := s.retryBackoff
+	for attempt := 1; ; attempt++ {
+		status, err := s.postOnce(ctx, endpoint, payload)
+		retryable := err != nil || status == http.StatusTooManyRequests || status >= 500
+		if !retryable || attempt == unifiedPushAttempts {
+			return status, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (s *Service) postOnce(ctx context.Context, endpoint string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("TTL", "86400")
+	req.Header.Set("Urgency", "high")
+
+	resp, err := s.unifiedPushClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return resp.StatusCode, nil
+}
+
+func (s *Service) recordUnifiedPushOutcome(ctx context.Context, attrs metric.MeasurementOption) {
+	if s.unifiedPushOutcomes != nil {
+		s.unifiedPushOutcomes.Add(ctx, 1, attrs)
+	}
+}
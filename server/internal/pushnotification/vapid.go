@@ -0,0 +1,126 @@
+package pushnotification
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// vapidTokenTTL is how long a VAPID token is valid. Push services reject
+// tokens that expire more than 24 hours ahead.
+const vapidTokenTTL = 12 * time.Hour
+
+// VAPIDKeys is the P-256 key pair identifying this server to Web Push
+// services (RFC 8292).
+type VAPIDKeys struct {
+	// PublicKey is the uncompressed public key, base64url encoded, as passed
+	// to the browser's PushManager.subscribe as applicationServerKey.
+	PublicKey  string
+	privateKey *ecdsa.PrivateKey
+}
+
+// GenerateVAPIDKeys creates a new key pair.
+func GenerateVAPIDKeys() (*VAPIDKeys, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newVAPIDKeys(priv)
+}
+
+func newVAPIDKeys(priv *ecdsa.PrivateKey) (*VAPIDKeys, error) {
+	pub, err := priv.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &VAPIDKeys{PublicKey: base64.RawURLEncoding.EncodeToString(pub), privateKey: priv}, nil
+}
+
+// LoadVAPIDKeys returns the server's key pair, generating and storing one on
+// first use. Instances starting at the same time agree on a single pair.
+func LoadVAPIDKeys(ctx context.Context, db *sql.DB) (*VAPIDKeys, error) {
+	keys, err := readVAPIDKeys(ctx, db)
+	if err == nil || !errors.Is(err, sql.ErrNoRows) {
+		return keys, err
+	}
+
+	keys, err = GenerateVAPIDKeys()
+	if err != nil {
+		return nil, fmt.Errorf("generating VAPID keys: %w", err)
+	}
+	priv, err := keys.privateKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO vapid_keys (id, public_key, private_key, created_at)
+		VALUES (1, ?, ?, ?)
+		ON CONFLICT(id) DO NOTHING
+	`, keys.PublicKey, base64.RawURLEncoding.EncodeToString(priv), time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, fmt.Errorf("storing VAPID keys: %w", err)
+	}
+
+	// Another instance may have won the insert
+	return readVAPIDKeys(ctx, db)
+}
+
+func readVAPIDKeys(ctx context.Context, db *sql.DB) (*VAPIDKeys, error) {
+	var encoded string
+	err := db.QueryRowContext(ctx, `SELECT private_key FROM vapid_keys WHERE id = 1`).Scan(&encoded)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding VAPID private key: %w", err)
+	}
+	priv, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing VAPID private key: %w", err)
+	}
+	return newVAPIDKeys(priv)
+}
+
+// authorization returns the Authorization header value for a request to a
+// push service endpoint: a signed ES256 JWT whose audience is the endpoint's
+// origin, plus the public key.
+func (k *VAPIDKeys) authorization(endpoint, subject string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	header, _ := json.Marshal(map[string]string{"typ": "JWT", "alg": "ES256"})
+	claims, err := json.Marshal(map[string]any{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, k.privateKey, digest[:])
+	if err != nil {
+		return "", err
+	}
+	// JWS wants the fixed-width r || s form rather than ASN.1
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(sig)
+	return "vapid t=" + token + ", k=" + k.PublicKey, nil
+}
@@ -0,0 +1,274 @@
+package pushnotification
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/enzyme/server/internal/linkpreview"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// webPushTTL is how long a push service keeps a message for an offline
+	// browser.
+	webPushTTL = 24 * time.Hour
+
+	// webPushRecordSize is the aes128gcm record size. Messages are sent as a
+	// single record, so it only needs to exceed the largest payload.
+	webPushRecordSize = 4096
+
+	webPushTimeout = 5 * time.Second
+)
+
+// Pre-computed metric attribute sets, one per outcome of a delivery made
+// without the relay (Web Push and UnifiedPush).
+var (
+	directAttrsSent     = metric.WithAttributes(attribute.String("outcome", "sent"))
+	directAttrsExpired  = metric.WithAttributes(attribute.String("outcome", "expired"))
+	directAttrsRejected = metric.WithAttributes(attribute.String("outcome", "rejected"))
+	directAttrsFailed   = metric.WithAttributes(attribute.String("outcome", "request_failed"))
+)
+
+var ErrInvalidSubscription = errors.New("invalid web push subscription")
+
+// WebPushSender delivers notifications straight to the push services of
+// subscribed browsers, encrypted per RFC 8291 and signed with the server's
+// VAPID keys. Unlike Service it needs no relay.
+type WebPushSender struct {
+	repo    *SubscriptionRepository
+	keys    *VAPIDKeys
+	subject string
+	client  *http.Client
+
+	// OTel metrics (no-op when telemetry is disabled)
+	outcomes metric.Int64Counter
+}
+
+// NewWebPushSender creates a sender. The subject is the contact push
+// services can use to reach the operator, as a mailto: or https: URL. If
+// client is nil, an SSRF-safe client is used, since endpoints come from
+// clients.
+func NewWebPushSender(repo *SubscriptionRepository, keys *VAPIDKeys, subject string, client *http.Client) *WebPushSender {
+	outcomes, err := otel.Meter("enzyme.push").Int64Counter("push.webpush.requests",
+		metric.WithDescription("Web Push deliveries by outcome"),
+	)
+	if err != nil {
+		slog.Error("failed to create push.webpush.requests metric", "error", err)
+	}
+	if client == nil {
+		client = linkpreview.NewSafeClient(webPushTimeout)
+	}
+	return &WebPushSender{
+		repo:     repo,
+		keys:     keys,
+		subject:  subject,
+		client:   client,
+		outcomes: outcomes,
+	}
+}
+
+// Send delivers a notification to each of the user's browsers. Returns true
+// if at least one push service accepted it. Subscriptions the push service
+// reports as gone are removed.
+func (s *WebPushSender) Send(ctx context.Context, userID string, data NotificationData) bool {
+	subs, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		slog.Error("web push: failed to list subscriptions", "user_id", userID, "error", err)
+		return false
+	}
+	if len(subs) == 0 {
+		return false
+	}
+
+	payload, err := json.Marshal(DirectPayload{Title: data.Title, Body: data.Body, Data: newRelayRequestData(data)})
+	if err != nil {
+		slog.Error("web push: failed to encode payload", "error", err)
+		return false
+	}
+
+	var dispatched atomic.Bool
+
+	g, gCtx := errgroup.WithContext(ctx)
+	for _, sub := range subs {
+		g.Go(func() error {
+			status, err := s.deliver(gCtx, sub, payload)
+			switch {
+			case err != nil:
+				slog.Error("web push: request failed", "subscription_id", sub.ID, "error", err)
+				s.recordOutcome(gCtx, directAttrsFailed)
+			case status == http.StatusCreated || status == http.StatusOK || status == http.StatusAccepted:
+				dispatched.Store(true)
+				s.recordOutcome(gCtx, directAttrsSent)
+			case status == http.StatusNotFound || status == http.StatusGone:
+				s.recordOutcome(gCtx, directAttrsExpired)
+				slog.Info("web push: removing expired subscription", "subscription_id", sub.ID)
+				if err := s.repo.DeleteByEndpoint(gCtx, sub.Endpoint); err != nil {
+					slog.Error("web push: failed to delete expired subscription", "subscription_id", sub.ID, "error", err)
+				}
+			default:
+				slog.Error("web push: push service rejected message", "subscription_id", sub.ID, "status", status)
+				s.recordOutcome(gCtx, directAttrsRejected)
+			}
+			return nil // don't abort other sends
+		})
+	}
+	_ = g.Wait() // errors are handled per-goroutine above
+
+	return dispatched.Load()
+}
+
+func (s *WebPushSender) recordOutcome(ctx context.Context, attrs metric.MeasurementOption) {
+	if s.outcomes != nil {
+		s.outcomes.Add(ctx, 1, attrs)
+	}
+}
+
+// deliver encrypts the payload for one subscription and posts it to the
+// push service (RFC 8030), returning the response status.
+func (s *WebPushSender) deliver(ctx context.Context, sub *WebPushSubscription, payload []byte) (int, error) {
+	uaPublic, authSecret, err := decodeSubscriptionKeys(sub.P256DH, sub.Auth)
+	if err != nil {
+		return 0, err
+	}
+	body, err := encryptWebPush(payload, uaPublic, authSecret, nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("encrypting payload: %w", err)
+	}
+	authorization, err := s.keys.authorization(sub.Endpoint, s.subject, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("signing VAPID token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(webPushTTL.Seconds())))
+	req.Header.Set("Urgency", "high")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return resp.StatusCode, nil
+}
+
+// ValidateSubscription checks a subscription as registered by a browser: the
+// endpoint must be an https URL and the keys must decode to a P-256 public
+// key and a 16-byte authentication secret.
+func ValidateSubscription(endpoint, p256dh, auth string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%w: endpoint must be an https URL", ErrInvalidSubscription)
+	}
+	if _, _, err := decodeSubscriptionKeys(p256dh, auth); err != nil {
+		return err
+	}
+	return nil
+}
+
+func decodeSubscriptionKeys(p256dh, auth string) (*ecdh.PublicKey, []byte, error) {
+	// Browsers use unpadded base64url, but some libraries pad it
+	pub, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(p256dh, "="))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: p256dh is not base64url", ErrInvalidSubscription)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(pub)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: p256dh is not a P-256 public key", ErrInvalidSubscription)
+	}
+	authSecret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(auth, "="))
+	if err != nil || len(authSecret) != 16 {
+		return nil, nil, fmt.Errorf("%w: auth must be 16 bytes of base64url", ErrInvalidSubscription)
+	}
+	return uaPublic, authSecret, nil
+}
+
+// encryptWebPush encrypts a message for a browser per RFC 8291, producing a
+// single aes128gcm record (RFC 8188). The ephemeral key and salt are
+// generated when nil; tests pass fixed ones.
+func encryptWebPush(plaintext []byte, uaPublic *ecdh.PublicKey, authSecret []byte, asPrivate *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if asPrivate == nil {
+		var err error
+		asPrivate, err = ecdh.P256().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if salt == nil {
+		salt = make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+	}
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	keyInfo := "WebPush: info\x00" + string(uaPublic.Bytes()) + string(asPublic)
+	ikm, err := hkdf.Key(sha256.New, ecdhSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// The record is the plaintext followed by the last-record delimiter
+	record := append(append([]byte{}, plaintext...), 0x02)
+	if len(record)+gcm.Overhead() > webPushRecordSize {
+		return nil, fmt.Errorf("payload of %d bytes is too large", len(plaintext))
+	}
+
+	// Header: salt, record size, key ID length, key ID (the ephemeral public key)
+	out := make([]byte, 0, 16+4+1+len(asPublic)+len(record)+gcm.Overhead())
+	out = append(out, salt...)
+	out = binary.BigEndian.AppendUint32(out, webPushRecordSize)
+	out = append(out, byte(len(asPublic)))
+	out = append(out, asPublic...)
+	return gcm.Seal(out, nonce, record, nil), nil
+}
@@ -0,0 +1,320 @@
+package pushnotification
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/testutil"
+)
+
+func b64(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatalf("decoding %q: %v", s, err)
+	}
+	return b
+}
+
+// TestEncryptWebPush_RFC8291Vector checks the encryption against the example
+// in RFC 8291 appendix A.
+func TestEncryptWebPush_RFC8291Vector(t *testing.T) {
+	asPrivate, err := ecdh.P256().NewPrivateKey(b64(t, "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(b64(t, "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := encryptWebPush([]byte("When I grow up, I want to be a watermelon"), uaPublic,
+		b64(t, "BTBZMqHH6r4Tts7J_aSIgg"), asPrivate, b64(t, "DGv6ra1nlYgDCS1FRnbzlw"))
+	if err != nil {
+		t.Fatalf("encryptWebPush: %v", err)
+	}
+
+	want := "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	if enc := base64.RawURLEncoding.EncodeToString(got); enc != want {
+		t.Errorf("ciphertext = %s\nwant %s", enc, want)
+	}
+}
+
+// testBrowser is the receiving end of a subscription.
+type testBrowser struct {
+	private *ecdh.PrivateKey
+	auth    []byte
+}
+
+func newTestBrowser(t *testing.T) *testBrowser {
+	t.Helper()
+	private, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	_, _ = rand.Read(auth)
+	return &testBrowser{private: private, auth: auth}
+}
+
+func (b *testBrowser) subscription(userID, endpoint string) *WebPushSubscription {
+	return &WebPushSubscription{
+		UserID:   userID,
+		Endpoint: endpoint,
+		P256DH:   base64.RawURLEncoding.EncodeToString(b.private.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(b.auth),
+	}
+}
+
+// decrypt reverses encryptWebPush, as the browser would.
+func (b *testBrowser) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	salt, keyID := body[:16], body[21:21+int(body[20])]
+	ciphertext := body[21+len(keyID):]
+
+	asPublic, err := ecdh.P256().NewPublicKey(keyID)
+	if err != nil {
+		t.Fatalf("parsing key ID: %v", err)
+	}
+	secret, err := b.private.ECDH(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyInfo := "WebPush: info\x00" + string(b.private.PublicKey().Bytes()) + string(keyID)
+	ikm, _ := hkdf.Key(sha256.New, secret, b.auth, keyInfo, 32)
+	cek, _ := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("decrypting: %v", err)
+	}
+	if !bytes.HasSuffix(record, []byte{0x02}) {
+		t.Fatalf("record has no last-record delimiter")
+	}
+	return record[:len(record)-1]
+}
+
+func TestVAPIDAuthorization(t *testing.T) {
+	keys, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	header, err := keys.authorization("https://push.example.com/send/abc?x=1", "mailto:ops@example.com", now)
+	if err != nil {
+		t.Fatalf("authorization: %v", err)
+	}
+
+	token, key, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	if !ok || !strings.HasPrefix(header, "vapid t=") {
+		t.Fatalf("header = %q, want vapid t=..., k=...", header)
+	}
+	if key != keys.PublicKey {
+		t.Errorf("k = %q, want the public key", key)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d parts", len(parts))
+	}
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	if err := json.Unmarshal(b64(t, parts[1]), &claims); err != nil {
+		t.Fatalf("decoding claims: %v", err)
+	}
+	if claims.Aud != "https://push.example.com" || claims.Sub != "mailto:ops@example.com" {
+		t.Errorf("claims = %+v", claims)
+	}
+	if exp := time.Unix(claims.Exp, 0); exp.Before(now) || exp.After(now.Add(24*time.Hour)) {
+		t.Errorf("exp = %v, want within 24h", exp)
+	}
+
+	// Verify the signature with the advertised public key
+	pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), b64(t, key))
+	if err != nil {
+		t.Fatalf("parsing public key: %v", err)
+	}
+	sig := b64(t, parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	if !ecdsa.Verify(pub, digest[:], r, s) {
+		t.Error("signature does not verify")
+	}
+}
+
+func TestLoadVAPIDKeys(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+
+	first, err := LoadVAPIDKeys(ctx, db)
+	if err != nil {
+		t.Fatalf("first load: %v", err)
+	}
+	second, err := LoadVAPIDKeys(ctx, db)
+	if err != nil {
+		t.Fatalf("second load: %v", err)
+	}
+	if first.PublicKey != second.PublicKey {
+		t.Error("expected the stored keys to be reused")
+	}
+	if len(b64(t, first.PublicKey)) != 65 {
+		t.Errorf("public key is not an uncompressed P-256 point")
+	}
+}
+
+func TestValidateSubscription(t *testing.T) {
+	sub := newTestBrowser(t).subscription("u", "https://push.example.com/abc")
+
+	if err := ValidateSubscription(sub.Endpoint, sub.P256DH, sub.Auth); err != nil {
+		t.Errorf("valid subscription: %v", err)
+	}
+	// Padded base64url is accepted too
+	if err := ValidateSubscription(sub.Endpoint, sub.P256DH+"=", sub.Auth+"=="); err != nil {
+		t.Errorf("padded keys: %v", err)
+	}
+
+	for name, tc := range map[string][3]string{
+		"http endpoint":  {"http://push.example.com/abc", sub.P256DH, sub.Auth},
+		"not a URL":      {"push", sub.P256DH, sub.Auth},
+		"bad p256dh":     {sub.Endpoint, "AAAA", sub.Auth},
+		"short auth":     {sub.Endpoint, sub.P256DH, "AAAA"},
+		"invalid base64": {sub.Endpoint, sub.P256DH, "!!!"},
+	} {
+		if err := ValidateSubscription(tc[0], tc[1], tc[2]); !errors.Is(err, ErrInvalidSubscription) {
+			t.Errorf("%s: err = %v, want ErrInvalidSubscription", name, err)
+		}
+	}
+}
+
+func TestWebPushSender_Send(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewSubscriptionRepository(db)
+	user := testutil.CreateTestUser(t, db, "test@example.com", "Test")
+	ctx := context.Background()
+
+	keys, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var received *http.Request
+	var receivedBody []byte
+	pushService := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusGone)
+			return
+		}
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer pushService.Close()
+
+	browser := newTestBrowser(t)
+	if err := repo.Upsert(ctx, browser.subscription(user.ID, pushService.URL+"/live")); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if err := repo.Upsert(ctx, newTestBrowser(t).subscription(user.ID, pushService.URL+"/gone")); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	sender := NewWebPushSender(repo, keys, "mailto:ops@example.com", pushService.Client())
+	ok := sender.Send(ctx, user.ID, NotificationData{
+		Title:     "@alice in #general",
+		Body:      "Hello world",
+		ChannelID: "ch-1",
+		ServerURL: "https://chat.example.com",
+	})
+	if !ok {
+		t.Fatal("expected Send to return true")
+	}
+
+	if received == nil {
+		t.Fatal("push service received nothing")
+	}
+	if got := received.Header.Get("Content-Encoding"); got != "aes128gcm" {
+		t.Errorf("Content-Encoding = %q", got)
+	}
+	if got := received.Header.Get("TTL"); got == "" {
+		t.Error("missing TTL header")
+	}
+	if got := received.Header.Get("Authorization"); !strings.HasSuffix(got, ", k="+keys.PublicKey) {
+		t.Errorf("Authorization = %q", got)
+	}
+
+	var payload DirectPayload
+	if err := json.Unmarshal(browser.decrypt(t, receivedBody), &payload); err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	if payload.Title != "@alice in #general" || payload.Body != "Hello world" || payload.Data.ChannelID != "ch-1" {
+		t.Errorf("payload = %+v", payload)
+	}
+
+	// The subscription the push service reported gone was removed
+	subs, err := repo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 1 || !strings.HasSuffix(subs[0].Endpoint, "/live") {
+		t.Errorf("subscriptions = %v, want only the live one", subs)
+	}
+}
+
+func TestWebPushSender_NoSubscriptions(t *testing.T) {
+	db := testutil.TestDB(t)
+	user := testutil.CreateTestUser(t, db, "test@example.com", "Test")
+	keys, _ := GenerateVAPIDKeys()
+
+	sender := NewWebPushSender(NewSubscriptionRepository(db), keys, "mailto:ops@example.com", nil)
+	if sender.Send(context.Background(), user.ID, NotificationData{Title: "t"}) {
+		t.Error("expected Send to return false without subscriptions")
+	}
+}
+
+func TestSubscriptionRepository_EndpointMovesToNewUser(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewSubscriptionRepository(db)
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	bob := testutil.CreateTestUser(t, db, "bob@example.com", "Bob")
+	ctx := context.Background()
+
+	browser := newTestBrowser(t)
+	if err := repo.Upsert(ctx, browser.subscription(alice.ID, "https://push.example.com/abc")); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Upsert(ctx, browser.subscription(bob.ID, "https://push.example.com/abc")); err != nil {
+		t.Fatal(err)
+	}
+
+	if subs, _ := repo.ListByUserID(ctx, alice.ID); len(subs) != 0 {
+		t.Errorf("alice still has %d subscriptions", len(subs))
+	}
+	if subs, _ := repo.ListByUserID(ctx, bob.ID); len(subs) != 1 {
+		t.Errorf("bob has %d subscriptions, want 1", len(subs))
+	}
+}
@@ -3,10 +3,15 @@ package ratelimit
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"math"
 	"net"
 	"net/http"
 	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type errorResponse struct {
@@ -25,6 +30,12 @@ func Middleware(limiter *Limiter) func(http.Handler) http.Handler {
 		if limiter == nil {
 			return next
 		}
+		rejections, err := otel.Meter("enzyme.ratelimit").Int64Counter("ratelimit.rejections",
+			metric.WithDescription("Requests rejected by the rate limiter"),
+		)
+		if err != nil {
+			slog.Error("failed to create ratelimit.rejections metric", "error", err)
+		}
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ip := stripPort(r.RemoteAddr)
 			result, allowed := limiter.Allow(ip, r.Method, r.URL.Path)
@@ -40,6 +51,13 @@ func Middleware(limiter *Limiter) func(http.Handler) http.Handler {
 			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(result.ResetAt.Unix(), 10))
 
 			if !allowed {
+				// Only paths with a rule get here, so the path label stays bounded
+				if rejections != nil {
+					rejections.Add(r.Context(), 1, metric.WithAttributes(
+						attribute.String("http.request.method", r.Method),
+						attribute.String("url.path", r.URL.Path),
+					))
+				}
 				retryAfter := int(math.Ceil(result.RetryIn.Seconds()))
 				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
 				w.Header().Set("Content-Type", "application/json")
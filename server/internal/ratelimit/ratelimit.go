@@ -46,32 +46,48 @@ type Limiter struct {
 
 // NewLimiter creates a Limiter with the given rules.
 func NewLimiter(rules []Rule) *Limiter {
-	ruleMap := make(map[string]Rule, len(rules))
-	for _, r := range rules {
-		ruleMap[r.Method+":"+r.Path] = r
-	}
 	return &Limiter{
-		rules:   ruleMap,
+		rules:   ruleMap(rules),
 		entries: make(map[string]*entry),
 		clock:   realClock{},
 	}
 }
 
+// SetRules replaces the rules while the limiter is in use. Counts for
+// method+path combinations that still have a rule are kept, so tightening a
+// limit applies to the current window.
+func (l *Limiter) SetRules(rules []Rule) {
+	m := ruleMap(rules)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rules = m
+}
+
+func ruleMap(rules []Rule) map[string]Rule {
+	m := make(map[string]Rule, len(rules))
+	for _, r := range rules {
+		m[r.Method+":"+r.Path] = r
+	}
+	return m
+}
+
 // Allow checks whether a request from ip to method+path is allowed.
 // If no rule matches the method+path, it returns (Result{}, true).
 func (l *Limiter) Allow(ip, method, path string) (Result, bool) {
 	ruleKey := method + ":" + path
-	rule, ok := l.rules[ruleKey]
-	if !ok {
-		return Result{}, true
-	}
-
 	now := l.clock.Now()
 	key := ip + ":" + ruleKey
 
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
+	rule, ok := l.rules[ruleKey]
+	if !ok {
+		return Result{}, true
+	}
+
 	e, exists := l.entries[key]
 	if !exists || now.Sub(e.windowAt) >= rule.Window {
 		// New window
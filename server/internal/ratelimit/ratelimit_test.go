@@ -220,3 +220,30 @@ func TestAllow_ConcurrentAccess(t *testing.T) {
 		t.Fatalf("expected exactly 100 allowed, got %d", allowedCount)
 	}
 }
+
+func TestSetRules(t *testing.T) {
+	l := NewLimiter([]Rule{
+		{Method: "POST", Path: "/api/auth/login", Limit: 5, Window: time.Minute},
+	})
+
+	l.Allow("1.2.3.4", "POST", "/api/auth/login")
+	l.Allow("1.2.3.4", "POST", "/api/auth/login")
+
+	// Tightening the limit applies to the current window
+	l.SetRules([]Rule{
+		{Method: "POST", Path: "/api/auth/login", Limit: 2, Window: time.Minute},
+		{Method: "POST", Path: "/api/auth/register", Limit: 1, Window: time.Hour},
+	})
+	if _, allowed := l.Allow("1.2.3.4", "POST", "/api/auth/login"); allowed {
+		t.Fatal("request over the new limit should be blocked")
+	}
+	if result, _ := l.Allow("1.2.3.4", "POST", "/api/auth/register"); result.Limit != 1 {
+		t.Fatalf("expected the new register rule to apply, got limit %d", result.Limit)
+	}
+
+	// Removing every rule lets everything through
+	l.SetRules(nil)
+	if result, allowed := l.Allow("1.2.3.4", "POST", "/api/auth/login"); !allowed || result.Limit != 0 {
+		t.Fatalf("expected no rate limit, got %+v allowed=%v", result, allowed)
+	}
+}
@@ -0,0 +1,107 @@
+// Package relayauth signs requests from Enzyme servers to the push relay and
+// verifies them on the relay side.
+//
+// Each server registered with a relay holds a credential: a key ID naming the
+// server and a secret shared with the relay. A request carries the key ID, a
+// Unix timestamp and an HMAC-SHA256 of the timestamp and body, so a captured
+// request can't be altered or replayed outside a short window.
+package relayauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+)
+
+const (
+	HeaderKeyID     = "X-Relay-Key-Id"
+	HeaderTimestamp = "X-Relay-Timestamp"
+	HeaderSignature = "X-Relay-Signature"
+
+	// MaxClockSkew is how far a request's timestamp may be from the relay's
+	// clock, in either direction.
+	MaxClockSkew = 5 * time.Minute
+
+	keyIDPrefix = "rk_"
+)
+
+var (
+	ErrMalformedCredential = errors.New("relay credential must be of the form <key id>.<secret>")
+	ErrExpired             = errors.New("request timestamp outside the allowed window")
+	ErrInvalidSignature    = errors.New("invalid signature")
+)
+
+// Credential is a server's relay API key.
+type Credential struct {
+	KeyID  string
+	Secret string
+}
+
+// Generate creates a new credential with a random key ID and secret.
+func Generate() (Credential, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return Credential{}, err
+	}
+	return Credential{
+		KeyID:  keyIDPrefix + strings.ToLower(ulid.Make().String()),
+		Secret: base64.RawURLEncoding.EncodeToString(secret),
+	}, nil
+}
+
+// ParseCredential parses the "<key id>.<secret>" form printed when a key is
+// issued, as set in the server's config.
+func ParseCredential(s string) (Credential, error) {
+	keyID, secret, ok := strings.Cut(strings.TrimSpace(s), ".")
+	if !ok || !strings.HasPrefix(keyID, keyIDPrefix) || secret == "" {
+		return Credential{}, ErrMalformedCredential
+	}
+	return Credential{KeyID: keyID, Secret: secret}, nil
+}
+
+// String returns the credential in the form accepted by ParseCredential.
+func (c Credential) String() string {
+	return c.KeyID + "." + c.Secret
+}
+
+// SignRequest sets the authentication headers on a request with the given body.
+func (c Credential) SignRequest(req *http.Request, body []byte, now time.Time) {
+	ts := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set(HeaderKeyID, c.KeyID)
+	req.Header.Set(HeaderTimestamp, ts)
+	req.Header.Set(HeaderSignature, sign(c.Secret, ts, body))
+}
+
+// Verify checks the timestamp and signature headers of a request against the
+// secret of the key it names.
+func Verify(secret string, header http.Header, body []byte, now time.Time) error {
+	ts := header.Get(HeaderTimestamp)
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrExpired
+	}
+	if d := now.Sub(time.Unix(unix, 0)); d > MaxClockSkew || d < -MaxClockSkew {
+		return ErrExpired
+	}
+	if !hmac.Equal([]byte(sign(secret, ts, body)), []byte(header.Get(HeaderSignature))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func sign(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
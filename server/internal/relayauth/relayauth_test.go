@@ -0,0 +1,64 @@
+package relayauth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGenerateAndParse(t *testing.T) {
+	cred, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+
+	parsed, err := ParseCredential(cred.String())
+	if err != nil {
+		t.Fatalf("ParseCredential: %v", err)
+	}
+	if parsed != cred {
+		t.Errorf("expected %+v, got %+v", cred, parsed)
+	}
+}
+
+func TestParseCredential_Malformed(t *testing.T) {
+	for _, s := range []string{"", "rk_abc", "rk_abc.", "abc.secret", ".secret"} {
+		if _, err := ParseCredential(s); !errors.Is(err, ErrMalformedCredential) {
+			t.Errorf("ParseCredential(%q): expected ErrMalformedCredential, got %v", s, err)
+		}
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	cred := Credential{KeyID: "rk_test", Secret: "s3cret"}
+	body := []byte(`{"device_token":"tok"}`)
+	now := time.Now()
+
+	signed := func(at time.Time) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/notify", nil)
+		cred.SignRequest(req, body, at)
+		return req
+	}
+
+	if err := Verify(cred.Secret, signed(now).Header, body, now); err != nil {
+		t.Fatalf("expected valid signature, got %v", err)
+	}
+	if got := signed(now).Header.Get(HeaderKeyID); got != "rk_test" {
+		t.Errorf("expected key ID header rk_test, got %q", got)
+	}
+
+	if err := Verify("other", signed(now).Header, body, now); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong secret: expected ErrInvalidSignature, got %v", err)
+	}
+	if err := Verify(cred.Secret, signed(now).Header, []byte(`{"device_token":"other"}`), now); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("altered body: expected ErrInvalidSignature, got %v", err)
+	}
+	if err := Verify(cred.Secret, signed(now.Add(-10*time.Minute)).Header, body, now); !errors.Is(err, ErrExpired) {
+		t.Errorf("old timestamp: expected ErrExpired, got %v", err)
+	}
+	if err := Verify(cred.Secret, http.Header{}, body, now); !errors.Is(err, ErrExpired) {
+		t.Errorf("missing headers: expected ErrExpired, got %v", err)
+	}
+}
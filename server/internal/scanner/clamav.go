@@ -0,0 +1,149 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize is the size of each INSTREAM chunk. clamd's default
+// StreamMaxLength is 25MB, which applies to the total, not the chunk.
+const clamdChunkSize = 32 * 1024
+
+// ClamAV implements Scanner by streaming files to a clamd daemon using the
+// INSTREAM command.
+type ClamAV struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAV creates a clamd client. address is either "host:port" or
+// "unix:/path/to/clamd.sock".
+func NewClamAV(address string, timeout time.Duration) *ClamAV {
+	network := "tcp"
+	if path, ok := strings.CutPrefix(address, "unix:"); ok {
+		network = "unix"
+		address = path
+	}
+	return &ClamAV{network: network, address: address, timeout: timeout}
+}
+
+func (c *ClamAV) dial(ctx context.Context) (net.Conn, error) {
+	d := net.Dialer{Timeout: 5 * time.Second}
+	conn, err := d.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to clamd: %w", err)
+	}
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+	return conn, nil
+}
+
+// Ping verifies that clamd is reachable and responding.
+func (c *ClamAV) Ping(ctx context.Context) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zPING\x00")); err != nil {
+		return fmt.Errorf("sending PING: %w", err)
+	}
+	reply, err := readReply(conn)
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("unexpected clamd reply to PING: %q", reply)
+	}
+	return nil
+}
+
+// Scan streams r to clamd and parses the verdict.
+func (c *ClamAV) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	w := bufio.NewWriterSize(conn, clamdChunkSize+4)
+	if _, err := w.WriteString("zINSTREAM\x00"); err != nil {
+		return nil, fmt.Errorf("sending INSTREAM: %w", err)
+	}
+
+	buf := make([]byte, clamdChunkSize)
+	var size [4]byte
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			binary.BigEndian.PutUint32(size[:], uint32(n))
+			if _, err := w.Write(size[:]); err != nil {
+				return nil, fmt.Errorf("sending chunk: %w", err)
+			}
+			if _, err := w.Write(buf[:n]); err != nil {
+				return nil, fmt.Errorf("sending chunk: %w", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("reading file: %w", readErr)
+		}
+	}
+
+	// A zero-length chunk terminates the stream
+	binary.BigEndian.PutUint32(size[:], 0)
+	if _, err := w.Write(size[:]); err != nil {
+		return nil, fmt.Errorf("terminating stream: %w", err)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, fmt.Errorf("sending stream: %w", err)
+	}
+
+	reply, err := readReply(conn)
+	if err != nil {
+		return nil, err
+	}
+	return parseClamdReply(reply)
+}
+
+// readReply reads a single NUL-terminated clamd reply.
+func readReply(conn net.Conn) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil && !(err == io.EOF && len(reply) > 0) {
+		return "", fmt.Errorf("reading clamd reply: %w", err)
+	}
+	return string(bytes.TrimRight(reply, "\x00\n")), nil
+}
+
+// parseClamdReply interprets replies of the form "stream: OK",
+// "stream: <signature> FOUND" and "<message> ERROR".
+func parseClamdReply(reply string) (*Result, error) {
+	verdict := reply
+	if _, rest, ok := strings.Cut(reply, ": "); ok {
+		verdict = rest
+	}
+	switch {
+	case verdict == "OK":
+		return &Result{}, nil
+	case strings.HasSuffix(verdict, " FOUND"):
+		return &Result{Infected: true, Signature: strings.TrimSuffix(verdict, " FOUND")}, nil
+	case strings.HasSuffix(verdict, " ERROR"):
+		return nil, fmt.Errorf("clamd error: %s", strings.TrimSuffix(verdict, " ERROR"))
+	default:
+		return nil, fmt.Errorf("unexpected clamd reply: %q", reply)
+	}
+}
@@ -0,0 +1,175 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// fakeClamd is a minimal clamd stand-in that understands zPING and zINSTREAM.
+// It reports any stream containing the EICAR string as infected.
+type fakeClamd struct {
+	ln       net.Listener
+	received chan []byte
+}
+
+func newFakeClamd(t *testing.T) *fakeClamd {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	f := &fakeClamd{ln: ln, received: make(chan []byte, 8)}
+	t.Cleanup(func() { ln.Close() })
+	go f.serve()
+	return f
+}
+
+func (f *fakeClamd) addr() string { return f.ln.Addr().String() }
+
+func (f *fakeClamd) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeClamd) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	cmd, err := r.ReadString(0)
+	if err != nil {
+		return
+	}
+	switch cmd {
+	case "zPING\x00":
+		_, _ = conn.Write([]byte("PONG\x00"))
+	case "zINSTREAM\x00":
+		var data bytes.Buffer
+		for {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				return
+			}
+			if size == 0 {
+				break
+			}
+			if _, err := io.CopyN(&data, r, int64(size)); err != nil {
+				return
+			}
+		}
+		f.received <- data.Bytes()
+		if strings.Contains(data.String(), "EICAR-STANDARD-ANTIVIRUS-TEST-FILE") {
+			_, _ = conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+		} else {
+			_, _ = conn.Write([]byte("stream: OK\x00"))
+		}
+	default:
+		_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+	}
+}
+
+func TestClamAV_Ping(t *testing.T) {
+	f := newFakeClamd(t)
+	c := NewClamAV(f.addr(), 5*time.Second)
+	if err := c.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+}
+
+func TestClamAV_ScanClean(t *testing.T) {
+	f := newFakeClamd(t)
+	c := NewClamAV(f.addr(), 5*time.Second)
+
+	result, err := c.Scan(context.Background(), strings.NewReader("hello world"))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if result.Infected {
+		t.Fatalf("expected clean result, got signature %q", result.Signature)
+	}
+	if got := <-f.received; string(got) != "hello world" {
+		t.Fatalf("clamd received %q, want %q", got, "hello world")
+	}
+}
+
+func TestClamAV_ScanInfected(t *testing.T) {
+	f := newFakeClamd(t)
+	c := NewClamAV(f.addr(), 5*time.Second)
+
+	result, err := c.Scan(context.Background(), strings.NewReader(eicar))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if !result.Infected {
+		t.Fatal("expected infected result")
+	}
+	if result.Signature != "Eicar-Test-Signature" {
+		t.Fatalf("Signature = %q, want %q", result.Signature, "Eicar-Test-Signature")
+	}
+}
+
+func TestClamAV_ScanMultipleChunks(t *testing.T) {
+	f := newFakeClamd(t)
+	c := NewClamAV(f.addr(), 5*time.Second)
+
+	data := bytes.Repeat([]byte("a"), clamdChunkSize*3+17)
+	if _, err := c.Scan(context.Background(), bytes.NewReader(data)); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if got := <-f.received; !bytes.Equal(got, data) {
+		t.Fatalf("clamd received %d bytes, want %d", len(got), len(data))
+	}
+}
+
+func TestClamAV_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	c := NewClamAV(addr, time.Second)
+	if _, err := c.Scan(context.Background(), strings.NewReader("data")); err == nil {
+		t.Fatal("expected error for unreachable clamd")
+	}
+}
+
+func TestParseClamdReply(t *testing.T) {
+	tests := []struct {
+		reply     string
+		infected  bool
+		signature string
+		wantErr   bool
+	}{
+		{"stream: OK", false, "", false},
+		{"stream: Win.Test.EICAR_HDB-1 FOUND", true, "Win.Test.EICAR_HDB-1", false},
+		{"INSTREAM size limit exceeded. ERROR", false, "", true},
+		{"garbage", false, "", true},
+	}
+	for _, tt := range tests {
+		result, err := parseClamdReply(tt.reply)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseClamdReply(%q) error = %v, wantErr %v", tt.reply, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if result.Infected != tt.infected || result.Signature != tt.signature {
+			t.Errorf("parseClamdReply(%q) = %+v, want infected=%v signature=%q", tt.reply, result, tt.infected, tt.signature)
+		}
+	}
+}
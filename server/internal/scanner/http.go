@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTP implements Scanner by POSTing the raw file body to an external
+// scanning service. The service must respond with 200 and a JSON body of
+// the form {"status": "clean"|"infected", "signature": "..."}.
+type HTTP struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// httpScanResponse is the JSON contract expected from the scanning service.
+type httpScanResponse struct {
+	Status    string `json:"status"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// NewHTTP creates an HTTP scanner. If token is non-empty it is sent as a
+// bearer token.
+func NewHTTP(url, token string, timeout time.Duration) *HTTP {
+	return &HTTP{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Scan uploads r to the scanning service and parses the verdict.
+func (h *HTTP) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scanner request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scanner returned HTTP %d", resp.StatusCode)
+	}
+
+	var body httpScanResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding scanner response: %w", err)
+	}
+
+	switch body.Status {
+	case "clean":
+		return &Result{}, nil
+	case "infected":
+		return &Result{Infected: true, Signature: body.Signature}, nil
+	default:
+		return nil, fmt.Errorf("scanner returned unknown status %q", body.Status)
+	}
+}
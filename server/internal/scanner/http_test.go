@@ -0,0 +1,79 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTP_Scan(t *testing.T) {
+	var gotAuth, gotBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		if strings.Contains(gotBody, "virus") {
+			json.NewEncoder(w).Encode(httpScanResponse{Status: "infected", Signature: "Test.Virus"})
+			return
+		}
+		json.NewEncoder(w).Encode(httpScanResponse{Status: "clean"})
+	}))
+	defer srv.Close()
+
+	s := NewHTTP(srv.URL, "secret", 5*time.Second)
+
+	result, err := s.Scan(context.Background(), strings.NewReader("harmless"))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if result.Infected {
+		t.Fatal("expected clean result")
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer secret")
+	}
+	if gotBody != "harmless" {
+		t.Errorf("body = %q, want %q", gotBody, "harmless")
+	}
+
+	result, err = s.Scan(context.Background(), strings.NewReader("a virus"))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if !result.Infected || result.Signature != "Test.Virus" {
+		t.Fatalf("expected infected with Test.Virus, got %+v", result)
+	}
+}
+
+func TestHTTP_ScanErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}},
+		{"invalid json", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("not json"))
+		}},
+		{"unknown status", func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(httpScanResponse{Status: "maybe"})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(tt.handler)
+			defer srv.Close()
+
+			s := NewHTTP(srv.URL, "", 5*time.Second)
+			if _, err := s.Scan(context.Background(), strings.NewReader("data")); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
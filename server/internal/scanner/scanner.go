@@ -0,0 +1,53 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/enzyme/server/internal/file"
+)
+
+// Scanner inspects file contents for malware.
+type Scanner interface {
+	// Scan reads r to completion and reports whether it contains malware.
+	// A non-nil error means the scan could not be completed.
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// Result is the outcome of a completed scan.
+type Result struct {
+	Infected  bool
+	Signature string // name of the detected threat, empty when clean
+}
+
+var (
+	ErrInfected    = errors.New("file is infected")
+	ErrScanPending = errors.New("file scan is pending")
+	ErrScanFailed  = errors.New("file scan failed")
+)
+
+// Policy decides whether a file may be downloaded based on its scan status.
+// Infected files are always refused.
+type Policy struct {
+	BlockPending bool // refuse files whose scan has not finished
+	BlockOnError bool // refuse files whose scan could not be completed
+}
+
+// Check returns nil if a file with the given scan status may be downloaded,
+// or one of ErrInfected, ErrScanPending, ErrScanFailed.
+func (p Policy) Check(status string) error {
+	switch status {
+	case file.ScanStatusInfected:
+		return ErrInfected
+	case file.ScanStatusPending:
+		if p.BlockPending {
+			return ErrScanPending
+		}
+	case file.ScanStatusError:
+		if p.BlockOnError {
+			return ErrScanFailed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/file"
+	"github.com/enzyme/server/internal/moderation"
+	"github.com/enzyme/server/internal/storage"
+)
+
+// QuarantinePrefix is the storage key prefix infected files are moved under.
+const QuarantinePrefix = "quarantine/"
+
+const (
+	// maxScanAttempts is how many times a file is scanned before an error
+	// is left standing.
+	maxScanAttempts = 10
+	// errorRetryDelay is the least time between scans of a file whose scan
+	// errored, so a scanner outage of about an hour is ridden out.
+	errorRetryDelay = 5 * time.Minute
+)
+
+// Service scans uploaded attachments, records the verdict, and quarantines
+// infected files.
+type Service struct {
+	scanner        Scanner
+	storage        storage.Storage
+	fileRepo       *file.Repository
+	channelRepo    *channel.Repository
+	moderationRepo *moderation.Repository
+	timeout        time.Duration
+}
+
+// NewService creates a new attachment scanning service.
+func NewService(scanner Scanner, store storage.Storage, fileRepo *file.Repository, channelRepo *channel.Repository, moderationRepo *moderation.Repository, timeout time.Duration) *Service {
+	return &Service{
+		scanner:        scanner,
+		storage:        store,
+		fileRepo:       fileRepo,
+		channelRepo:    channelRepo,
+		moderationRepo: moderationRepo,
+		timeout:        timeout,
+	}
+}
+
+// ScanAsync scans the attachment in the background. Failures are logged and
+// left for ProcessPending to retry.
+func (s *Service) ScanAsync(a *file.Attachment) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+		if err := s.ScanAttachment(ctx, a); err != nil {
+			slog.Error("attachment scan failed", "component", "scanner", "attachment_id", a.ID, "error", err)
+		}
+	}()
+}
+
+// ScanAttachment scans a stored attachment and records the result. Infected
+// files are moved to quarantine and reported in the workspace audit log.
+// Scanner failures are recorded as ScanStatusError rather than returned.
+func (s *Service) ScanAttachment(ctx context.Context, a *file.Attachment) error {
+	rc, err := s.storage.Get(ctx, a.StoragePath)
+	if err != nil {
+		return fmt.Errorf("reading attachment: %w", err)
+	}
+	result, scanErr := s.scanner.Scan(ctx, rc)
+	_ = rc.Close()
+
+	if scanErr != nil {
+		slog.Warn("attachment scan error", "component", "scanner", "attachment_id", a.ID, "error", scanErr)
+		return s.fileRepo.UpdateScanResult(ctx, a.ID, file.ScanStatusError, nil)
+	}
+
+	if !result.Infected {
+		return s.fileRepo.UpdateScanResult(ctx, a.ID, file.ScanStatusClean, nil)
+	}
+
+	slog.Warn("infected attachment detected",
+		"component", "scanner",
+		"attachment_id", a.ID,
+		"channel_id", a.ChannelID,
+		"signature", result.Signature,
+	)
+
+	// Record the verdict before moving the file so downloads are refused
+	// even if quarantine fails part-way.
+	if err := s.fileRepo.UpdateScanResult(ctx, a.ID, file.ScanStatusInfected, &result.Signature); err != nil {
+		return err
+	}
+	if err := s.quarantine(ctx, a); err != nil {
+		slog.Error("failed to quarantine attachment", "component", "scanner", "attachment_id", a.ID, "error", err)
+	}
+	s.logQuarantine(ctx, a, result.Signature)
+	return nil
+}
+
+// ProcessPending rescans attachments stuck in the pending state, e.g. after a
+// restart interrupted an in-flight background scan, and retries errored scans
+// up to maxScanAttempts times.
+func (s *Service) ProcessPending(ctx context.Context) error {
+	now := time.Now()
+	pending, err := s.fileRepo.ListPendingScan(ctx, now.Add(-2*s.timeout), now.Add(-max(errorRetryDelay, 2*s.timeout)), maxScanAttempts, 50)
+	if err != nil {
+		return err
+	}
+	for i := range pending {
+		scanCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		err := s.ScanAttachment(scanCtx, &pending[i])
+		cancel()
+		if err != nil {
+			slog.Error("attachment rescan failed", "component", "scanner", "attachment_id", pending[i].ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// quarantine moves the file under QuarantinePrefix so it is no longer served
+// from its original key.
+func (s *Service) quarantine(ctx context.Context, a *file.Attachment) error {
+	dst := QuarantinePrefix + a.StoragePath
+	rc, err := s.storage.Get(ctx, a.StoragePath)
+	if err != nil {
+		return err
+	}
+	err = s.storage.Put(ctx, dst, rc, a.SizeBytes, "application/octet-stream")
+	_ = rc.Close()
+	if err != nil {
+		return err
+	}
+	if err := s.fileRepo.UpdateStoragePath(ctx, a.ID, dst); err != nil {
+		_ = s.storage.Delete(ctx, dst)
+		return err
+	}
+	if err := s.storage.Delete(ctx, a.StoragePath); err != nil {
+		slog.Error("failed to delete quarantined original", "component", "scanner", "attachment_id", a.ID, "error", err)
+	}
+	a.StoragePath = dst
+	return nil
+}
+
+func (s *Service) logQuarantine(ctx context.Context, a *file.Attachment, signature string) {
+	ch, err := s.channelRepo.GetByID(ctx, a.ChannelID)
+	if err != nil {
+		slog.Error("failed to look up channel for quarantine audit", "component", "scanner", "attachment_id", a.ID, "error", err)
+		return
+	}
+	metadata := map[string]interface{}{
+		"filename":   a.Filename,
+		"channel_id": a.ChannelID,
+		"signature":  signature,
+	}
+	if a.UserID != nil {
+		metadata["uploader_id"] = *a.UserID
+	}
+	if err := s.moderationRepo.CreateAuditLogEntryWithMetadata(ctx, ch.WorkspaceID, moderation.SystemActorID,
+		moderation.ActionFileQuarantined, moderation.TargetTypeFile, a.ID, metadata); err != nil {
+		slog.Error("failed to write quarantine audit entry", "component", "scanner", "attachment_id", a.ID, "error", err)
+	}
+}
@@ -0,0 +1,209 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/file"
+	"github.com/enzyme/server/internal/moderation"
+	"github.com/enzyme/server/internal/storage"
+	"github.com/enzyme/server/internal/testutil"
+)
+
+type stubScanner struct {
+	result *Result
+	err    error
+}
+
+func (s *stubScanner) Scan(_ context.Context, r io.Reader) (*Result, error) {
+	_, _ = io.Copy(io.Discard, r)
+	return s.result, s.err
+}
+
+type serviceFixture struct {
+	svc            *Service
+	store          storage.Storage
+	fileRepo       *file.Repository
+	moderationRepo *moderation.Repository
+	workspaceID    string
+	attachment     *file.Attachment
+}
+
+func newServiceFixture(t *testing.T, sc Scanner) *serviceFixture {
+	t.Helper()
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+
+	user := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "Test WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", channel.TypePublic)
+
+	store := storage.NewLocal(t.TempDir())
+	fileRepo := file.NewRepository(db)
+	moderationRepo := moderation.NewRepository(db)
+
+	data := []byte("file contents")
+	key := ws.ID + "/" + ch.ID + "/file.txt"
+	if err := store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	a := &file.Attachment{
+		ChannelID:   ch.ID,
+		UserID:      &user.ID,
+		Filename:    "file.txt",
+		ContentType: "text/plain",
+		SizeBytes:   int64(len(data)),
+		StoragePath: key,
+		ScanStatus:  file.ScanStatusPending,
+	}
+	if err := fileRepo.Create(ctx, a); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	return &serviceFixture{
+		svc:            NewService(sc, store, fileRepo, channel.NewRepository(db), moderationRepo, 5*time.Second),
+		store:          store,
+		fileRepo:       fileRepo,
+		moderationRepo: moderationRepo,
+		workspaceID:    ws.ID,
+		attachment:     a,
+	}
+}
+
+func TestService_ScanAttachment_Clean(t *testing.T) {
+	f := newServiceFixture(t, &stubScanner{result: &Result{}})
+	ctx := context.Background()
+
+	if err := f.svc.ScanAttachment(ctx, f.attachment); err != nil {
+		t.Fatalf("ScanAttachment: %v", err)
+	}
+
+	got, err := f.fileRepo.GetByID(ctx, f.attachment.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.ScanStatus != file.ScanStatusClean {
+		t.Errorf("ScanStatus = %q, want %q", got.ScanStatus, file.ScanStatusClean)
+	}
+	if got.ScannedAt == nil {
+		t.Error("expected ScannedAt to be set")
+	}
+	if got.StoragePath != f.attachment.StoragePath {
+		t.Errorf("clean file should not move, got %q", got.StoragePath)
+	}
+}
+
+func TestService_ScanAttachment_InfectedQuarantines(t *testing.T) {
+	f := newServiceFixture(t, &stubScanner{result: &Result{Infected: true, Signature: "Eicar-Test-Signature"}})
+	ctx := context.Background()
+	originalKey := f.attachment.StoragePath
+
+	if err := f.svc.ScanAttachment(ctx, f.attachment); err != nil {
+		t.Fatalf("ScanAttachment: %v", err)
+	}
+
+	got, err := f.fileRepo.GetByID(ctx, f.attachment.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.ScanStatus != file.ScanStatusInfected {
+		t.Errorf("ScanStatus = %q, want %q", got.ScanStatus, file.ScanStatusInfected)
+	}
+	if got.ScanSignature == nil || *got.ScanSignature != "Eicar-Test-Signature" {
+		t.Errorf("ScanSignature = %v, want Eicar-Test-Signature", got.ScanSignature)
+	}
+	if got.StoragePath != QuarantinePrefix+originalKey {
+		t.Errorf("StoragePath = %q, want quarantined key", got.StoragePath)
+	}
+
+	// Original key is gone, quarantined copy exists
+	if _, err := f.store.Get(ctx, originalKey); err == nil {
+		t.Error("expected original object to be deleted")
+	}
+	rc, err := f.store.Get(ctx, got.StoragePath)
+	if err != nil {
+		t.Fatalf("quarantined object missing: %v", err)
+	}
+	rc.Close()
+
+	entries, _, _, err := f.moderationRepo.ListAuditLog(ctx, f.workspaceID, "", 50)
+	if err != nil {
+		t.Fatalf("ListAuditLog: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("len(entries) = %d, want 1", len(entries))
+	}
+	if entries[0].Action != moderation.ActionFileQuarantined || entries[0].TargetID != f.attachment.ID {
+		t.Errorf("unexpected audit entry: %+v", entries[0])
+	}
+	if entries[0].ActorID != moderation.SystemActorID {
+		t.Errorf("ActorID = %q, want %q", entries[0].ActorID, moderation.SystemActorID)
+	}
+}
+
+func TestService_ScanAttachment_ScannerError(t *testing.T) {
+	f := newServiceFixture(t, &stubScanner{err: errors.New("clamd down")})
+	ctx := context.Background()
+
+	if err := f.svc.ScanAttachment(ctx, f.attachment); err != nil {
+		t.Fatalf("ScanAttachment: %v", err)
+	}
+
+	got, _ := f.fileRepo.GetByID(ctx, f.attachment.ID)
+	if got.ScanStatus != file.ScanStatusError {
+		t.Errorf("ScanStatus = %q, want %q", got.ScanStatus, file.ScanStatusError)
+	}
+}
+
+func TestService_ErroredScansAreRetried(t *testing.T) {
+	f := newServiceFixture(t, &stubScanner{err: errors.New("clamd down")})
+	ctx := context.Background()
+	later := time.Now().Add(time.Minute)
+
+	due := func() bool {
+		t.Helper()
+		list, err := f.fileRepo.ListPendingScan(ctx, time.Now().Add(-time.Hour), later, maxScanAttempts, 10)
+		if err != nil {
+			t.Fatalf("ListPendingScan: %v", err)
+		}
+		return len(list) == 1 && list[0].ID == f.attachment.ID
+	}
+
+	for i := 0; i < maxScanAttempts; i++ {
+		if err := f.svc.ScanAttachment(ctx, f.attachment); err != nil {
+			t.Fatalf("ScanAttachment: %v", err)
+		}
+		if i < maxScanAttempts-1 && !due() {
+			t.Fatalf("errored scan not due for retry after %d attempts", i+1)
+		}
+	}
+	if due() {
+		t.Fatalf("errored scan still due after %d attempts", maxScanAttempts)
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		status string
+		want   error
+	}{
+		{Policy{}, "", nil},
+		{Policy{}, file.ScanStatusClean, nil},
+		{Policy{}, file.ScanStatusInfected, ErrInfected},
+		{Policy{}, file.ScanStatusPending, nil},
+		{Policy{BlockPending: true}, file.ScanStatusPending, ErrScanPending},
+		{Policy{}, file.ScanStatusError, nil},
+		{Policy{BlockOnError: true}, file.ScanStatusError, ErrScanFailed},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.Check(tt.status); got != tt.want {
+			t.Errorf("%+v.Check(%q) = %v, want %v", tt.policy, tt.status, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	// leaseGrace is how long a lease outlives the task's interval. The owner
+	// renews well within it on its next tick; if the owner dies, another
+	// instance takes the task over once the lease lapses.
+	leaseGrace = 30 * time.Second
+
+	// heartbeatInterval is how often a running task extends its lease, so a
+	// run that outlasts the lease is not taken over while still in progress.
+	heartbeatInterval = 10 * time.Second
+)
+
+// Leases coordinates tasks between instances sharing a database, so each
+// task runs on exactly one of them at a time. Ownership is a row per task in
+// task_leases that the owner renews on every run.
+type Leases struct {
+	db    *sql.DB
+	owner string
+}
+
+// NewLeases returns leases held on behalf of owner, an ID unique to this
+// instance.
+func NewLeases(db *sql.DB, owner string) *Leases {
+	return &Leases{db: db, owner: owner}
+}
+
+// TaskStatus is the cluster-wide state of a leased task.
+type TaskStatus struct {
+	Name           string
+	Owner          string // instance holding the lease, empty if released
+	LeaseExpiresAt *time.Time
+	LastRunAt      *time.Time
+	LastRunBy      string
+	LastDuration   time.Duration
+	LastError      string // empty if the last run succeeded
+}
+
+// acquire claims the lease for a task until now+ttl. It succeeds when the
+// lease is free, expired or already ours.
+func (l *Leases) acquire(ctx context.Context, name string, now time.Time, ttl time.Duration) (bool, error) {
+	ts := now.UTC().Format(time.RFC3339)
+	result, err := l.db.ExecContext(ctx, `
+		INSERT INTO task_leases (name, owner, heartbeat_at, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET
+			owner = excluded.owner,
+			heartbeat_at = excluded.heartbeat_at,
+			expires_at = excluded.expires_at
+		WHERE task_leases.owner = excluded.owner OR task_leases.owner IS NULL OR task_leases.expires_at <= ?
+	`, name, l.owner, ts, now.Add(ttl).UTC().Format(time.RFC3339), ts)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// heartbeat records that a run is still in progress, pushing the lease's
+// expiry out to at least now+leaseGrace.
+func (l *Leases) heartbeat(ctx context.Context, name string, now time.Time) error {
+	minExpiry := now.Add(leaseGrace).UTC().Format(time.RFC3339)
+	_, err := l.db.ExecContext(ctx, `
+		UPDATE task_leases SET heartbeat_at = ?,
+			expires_at = CASE WHEN expires_at < ? THEN ? ELSE expires_at END
+		WHERE name = ? AND owner = ?
+	`, now.UTC().Format(time.RFC3339), minExpiry, minExpiry, name, l.owner)
+	return err
+}
+
+// finish records the outcome of a run.
+func (l *Leases) finish(ctx context.Context, name string, started time.Time, runErr error) error {
+	var lastError sql.NullString
+	if runErr != nil {
+		lastError = sql.NullString{String: runErr.Error(), Valid: true}
+	}
+	_, err := l.db.ExecContext(ctx, `
+		UPDATE task_leases SET last_run_at = ?, last_run_by = ?, last_duration_ms = ?, last_error = ?, heartbeat_at = ?
+		WHERE name = ? AND owner = ?
+	`, started.UTC().Format(time.RFC3339), l.owner, time.Since(started).Milliseconds(), lastError,
+		time.Now().UTC().Format(time.RFC3339), name, l.owner)
+	return err
+}
+
+// releaseAll gives up every lease this instance holds so that other
+// instances can take its tasks over without waiting for them to expire.
+func (l *Leases) releaseAll(ctx context.Context) error {
+	_, err := l.db.ExecContext(ctx, `
+		UPDATE task_leases SET owner = NULL, expires_at = NULL WHERE owner = ?
+	`, l.owner)
+	return err
+}
+
+// List returns the status of every task that has held a lease, by name.
+func (l *Leases) List(ctx context.Context) ([]TaskStatus, error) {
+	rows, err := l.db.QueryContext(ctx, `
+		SELECT name, owner, expires_at, last_run_at, last_run_by, last_duration_ms, last_error
+		FROM task_leases
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statuses []TaskStatus
+	for rows.Next() {
+		var s TaskStatus
+		var owner, expiresAt, lastRunAt, lastRunBy, lastError sql.NullString
+		var durationMS sql.NullInt64
+		if err := rows.Scan(&s.Name, &owner, &expiresAt, &lastRunAt, &lastRunBy, &durationMS, &lastError); err != nil {
+			return nil, err
+		}
+		s.Owner = owner.String
+		s.LeaseExpiresAt = parseTime(expiresAt)
+		s.LastRunAt = parseTime(lastRunAt)
+		s.LastRunBy = lastRunBy.String
+		s.LastDuration = time.Duration(durationMS.Int64) * time.Millisecond
+		s.LastError = lastError.String
+		statuses = append(statuses, s)
+	}
+	return statuses, rows.Err()
+}
+
+func parseTime(s sql.NullString) *time.Time {
+	if !s.Valid {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s.String)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
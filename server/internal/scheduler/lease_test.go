@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/testutil"
+)
+
+func TestLeasedTaskRunsOnOneInstance(t *testing.T) {
+	db := testutil.TestDB(t)
+
+	var counts [2]atomic.Int32
+	var schedulers []*Scheduler
+	for i := range counts {
+		s := New()
+		s.SetLeases(NewLeases(db, []string{"node-a", "node-b"}[i]))
+		s.Register(Task{
+			Name:       "shared",
+			Interval:   10 * time.Millisecond,
+			RunOnStart: true,
+			Fn: func(ctx context.Context) error {
+				counts[i].Add(1)
+				return nil
+			},
+		})
+		schedulers = append(schedulers, s)
+	}
+
+	for _, s := range schedulers {
+		s.Start(context.Background())
+	}
+	time.Sleep(60 * time.Millisecond)
+	for _, s := range schedulers {
+		s.Stop(context.Background())
+	}
+
+	a, b := counts[0].Load(), counts[1].Load()
+	if a > 0 && b > 0 || a+b == 0 {
+		t.Fatalf("runs = %d and %d, want all runs on one instance", a, b)
+	}
+}
+
+func TestLocalTaskRunsOnEveryInstance(t *testing.T) {
+	db := testutil.TestDB(t)
+
+	var count atomic.Int32
+	for _, owner := range []string{"node-a", "node-b"} {
+		s := New()
+		s.SetLeases(NewLeases(db, owner))
+		s.Register(Task{
+			Name:       "local",
+			Interval:   time.Hour,
+			RunOnStart: true,
+			Local:      true,
+			Fn: func(ctx context.Context) error {
+				count.Add(1)
+				return nil
+			},
+		})
+		s.Start(context.Background())
+		s.Stop(context.Background())
+	}
+
+	if got := count.Load(); got != 2 {
+		t.Fatalf("runs = %d, want 2", got)
+	}
+}
+
+func TestLeaseTakeover(t *testing.T) {
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	a, b := NewLeases(db, "node-a"), NewLeases(db, "node-b")
+	now := time.Now()
+
+	if ok, err := a.acquire(ctx, "task", now, time.Minute); err != nil || !ok {
+		t.Fatalf("a.acquire = %v, %v; want true", ok, err)
+	}
+	if ok, _ := b.acquire(ctx, "task", now, time.Minute); ok {
+		t.Fatal("b acquired a lease held by a")
+	}
+	if ok, _ := a.acquire(ctx, "task", now, time.Minute); !ok {
+		t.Fatal("a could not renew its own lease")
+	}
+
+	// a stops renewing; once the lease lapses b takes over
+	later := now.Add(2 * time.Minute)
+	if ok, err := b.acquire(ctx, "task", later, time.Minute); err != nil || !ok {
+		t.Fatalf("b.acquire after expiry = %v, %v; want true", ok, err)
+	}
+	if ok, _ := a.acquire(ctx, "task", later, time.Minute); ok {
+		t.Fatal("a reacquired a lease taken over by b")
+	}
+}
+
+func TestLeaseStatus(t *testing.T) {
+	db := testutil.TestDB(t)
+	leases := NewLeases(db, "node-a")
+
+	ran := make(chan struct{})
+	s := New()
+	s.SetLeases(leases)
+	s.Register(Task{
+		Name:       "failing",
+		Interval:   time.Hour,
+		RunOnStart: true,
+		Fn: func(ctx context.Context) error {
+			close(ran)
+			return errors.New("boom")
+		},
+	})
+	s.Start(context.Background())
+	<-ran
+	s.Stop(context.Background())
+
+	statuses, err := leases.List(context.Background())
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("statuses = %+v, want one", statuses)
+	}
+	st := statuses[0]
+	if st.Name != "failing" || st.LastRunBy != "node-a" || st.LastError != "boom" || st.LastRunAt == nil {
+		t.Errorf("status = %+v", st)
+	}
+	// Stop releases the lease so another instance can take over immediately
+	if st.Owner != "" || st.LeaseExpiresAt != nil {
+		t.Errorf("lease not released: owner=%q expires=%v", st.Owner, st.LeaseExpiresAt)
+	}
+}
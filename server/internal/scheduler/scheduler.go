@@ -2,10 +2,15 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"runtime/debug"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 // Task defines a periodic background task.
@@ -14,20 +19,49 @@ type Task struct {
 	Interval   time.Duration
 	Fn         func(ctx context.Context) error
 	RunOnStart bool // run immediately on Start(), before the first tick
+	Local      bool // run on every instance, without taking a lease
 }
 
 // Scheduler manages periodic background tasks with consistent logging
 // and graceful shutdown.
 type Scheduler struct {
 	tasks   []Task
+	leases  *Leases
 	cancel  context.CancelFunc
 	wg      sync.WaitGroup
 	started bool
+
+	// OTel metrics (no-op when telemetry is disabled)
+	taskDuration metric.Float64Histogram
+	taskFailures metric.Int64Counter
 }
 
 // New creates a new Scheduler.
 func New() *Scheduler {
-	return &Scheduler{}
+	meter := otel.Meter("enzyme.scheduler")
+	taskDuration, err := meter.Float64Histogram("scheduler.task.duration",
+		metric.WithDescription("Duration of scheduler task runs"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		slog.Error("failed to create scheduler.task.duration metric", "error", err)
+	}
+	taskFailures, err := meter.Int64Counter("scheduler.task.failures",
+		metric.WithDescription("Scheduler task runs that returned an error or panicked"),
+	)
+	if err != nil {
+		slog.Error("failed to create scheduler.task.failures metric", "error", err)
+	}
+	return &Scheduler{taskDuration: taskDuration, taskFailures: taskFailures}
+}
+
+// SetLeases makes tasks run on only one instance at a time, for deployments
+// where several instances share a database. Must be called before Start.
+func (s *Scheduler) SetLeases(l *Leases) {
+	if s.started {
+		panic("scheduler: SetLeases called after Start")
+	}
+	s.leases = l
 }
 
 // Register adds a task to the scheduler. Must be called before Start.
@@ -79,6 +113,12 @@ func (s *Scheduler) Stop(ctx context.Context) {
 	case <-ctx.Done():
 		slog.Warn("scheduler stop timed out, some tasks may still be running")
 	}
+
+	if s.leases != nil {
+		if err := s.leases.releaseAll(ctx); err != nil {
+			slog.Warn("failed to release task leases", "error", err)
+		}
+	}
 }
 
 func (s *Scheduler) run(ctx context.Context, task Task) {
@@ -102,14 +142,75 @@ func (s *Scheduler) run(ctx context.Context, task Task) {
 }
 
 func (s *Scheduler) execute(ctx context.Context, task Task) {
+	if s.leases == nil || task.Local {
+		_ = s.call(ctx, task)
+		return
+	}
+
+	started := time.Now()
+	ok, err := s.leases.acquire(ctx, task.Name, started, task.Interval+leaseGrace)
+	if err != nil {
+		slog.Error("failed to acquire task lease", "task", task.Name, "error", err)
+		return
+	}
+	if !ok {
+		// Another instance holds the lease
+		return
+	}
+
+	stop := make(chan struct{})
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				if err := s.leases.heartbeat(ctx, task.Name, now); err != nil {
+					slog.Warn("failed to renew task lease", "task", task.Name, "error", err)
+				}
+			}
+		}
+	}()
+
+	runErr := s.call(ctx, task)
+	close(stop)
+	<-heartbeatDone
+
+	// Record the outcome even if shutdown cancelled ctx mid-run
+	if err := s.leases.finish(context.WithoutCancel(ctx), task.Name, started, runErr); err != nil {
+		slog.Warn("failed to record task status", "task", task.Name, "error", err)
+	}
+}
+
+// call runs the task function, logging and returning its error. A panic is
+// recovered and reported as an error.
+func (s *Scheduler) call(ctx context.Context, task Task) (err error) {
+	start := time.Now()
 	defer func() {
 		if r := recover(); r != nil {
 			slog.Error("scheduler task panicked", "task", task.Name, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", r)
 		}
+		s.recordRun(ctx, task.Name, time.Since(start), err)
 	}()
 
-	err := task.Fn(ctx)
+	err = task.Fn(ctx)
 	if err != nil {
 		slog.Error("scheduler task failed", "task", task.Name, "error", err)
 	}
+	return err
+}
+
+func (s *Scheduler) recordRun(ctx context.Context, name string, d time.Duration, err error) {
+	attrs := metric.WithAttributes(attribute.String("task", name))
+	if s.taskDuration != nil {
+		s.taskDuration.Record(ctx, d.Seconds(), attrs)
+	}
+	if err != nil && s.taskFailures != nil {
+		s.taskFailures.Add(ctx, 1, attrs)
+	}
 }
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/go-chi/cors"
+)
+
+// CORS is the cross-origin policy for the API. The allowed origins can be
+// replaced while the server is running; with no origins, no CORS headers are
+// sent.
+type CORS struct {
+	allowedHeaders []string
+	cors           atomic.Pointer[cors.Cors]
+}
+
+// NewCORS creates a policy allowing the given origins. Trace context headers
+// are allowed when telemetry is enabled so browsers can propagate traces.
+func NewCORS(allowedOrigins []string, telemetryEnabled bool) *CORS {
+	c := &CORS{allowedHeaders: []string{"Content-Type", "Authorization"}}
+	if telemetryEnabled {
+		c.allowedHeaders = append(c.allowedHeaders, "traceparent", "tracestate")
+	}
+	c.SetAllowedOrigins(allowedOrigins)
+	return c
+}
+
+// SetAllowedOrigins replaces the allowed origins for subsequent requests.
+func (c *CORS) SetAllowedOrigins(allowedOrigins []string) {
+	if len(allowedOrigins) == 0 {
+		c.cors.Store(nil)
+		return
+	}
+	c.cors.Store(cors.New(cors.Options{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: c.allowedHeaders,
+		ExposedHeaders: []string{"X-Request-Id"},
+		MaxAge:         86400,
+	}))
+}
+
+// Handler is the middleware applying the current policy.
+func (c *CORS) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if policy := c.cors.Load(); policy != nil {
+			policy.Handler(next).ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -5,22 +5,17 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/enzyme/server/internal/server"
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/cors"
 )
 
 func newTestRouter(allowedOrigins []string) http.Handler {
-	r := chi.NewRouter()
+	return newTestRouterWithCORS(server.NewCORS(allowedOrigins, false))
+}
 
-	if len(allowedOrigins) > 0 {
-		r.Use(cors.Handler(cors.Options{
-			AllowedOrigins: allowedOrigins,
-			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-			AllowedHeaders: []string{"Content-Type", "Authorization"},
-			ExposedHeaders: []string{"X-Request-Id"},
-			MaxAge:         86400,
-		}))
-	}
+func newTestRouterWithCORS(c *server.CORS) http.Handler {
+	r := chi.NewRouter()
+	r.Use(c.Handler)
 
 	r.Get("/api/test", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -97,3 +92,30 @@ func TestCORS_NoMiddlewareWhenEmpty(t *testing.T) {
 		t.Fatalf("expected no CORS headers when origins empty, got %q", got)
 	}
 }
+
+func TestCORS_SetAllowedOrigins(t *testing.T) {
+	c := server.NewCORS(nil, false)
+	router := newTestRouterWithCORS(c)
+
+	get := func(origin string) string {
+		req := httptest.NewRequest("GET", "/api/test", nil)
+		req.Header.Set("Origin", origin)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	if got := get("https://chat.example.com"); got != "" {
+		t.Fatalf("expected no CORS headers before origins are set, got %q", got)
+	}
+
+	c.SetAllowedOrigins([]string{"https://chat.example.com"})
+	if got := get("https://chat.example.com"); got != "https://chat.example.com" {
+		t.Fatalf("expected the new origin to be allowed, got %q", got)
+	}
+
+	c.SetAllowedOrigins([]string{"https://other.example.com"})
+	if got := get("https://chat.example.com"); got != "" {
+		t.Fatalf("expected the removed origin to be refused, got %q", got)
+	}
+}
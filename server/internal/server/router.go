@@ -18,7 +18,6 @@ import (
 	"github.com/enzyme/server/internal/telemetry"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
@@ -54,32 +53,24 @@ func InvalidateBanCacheByWorkspace(workspaceID string) {
 
 // NewRouter creates a new HTTP router with all routes registered.
 // If spaHandler is non-nil, it is mounted as a fallback for unmatched routes
-// to serve the embedded web client.
-func NewRouter(h *handler.Handler, sseHandler *sse.Handler, sessionStore *auth.SessionStore, moderationRepo *moderation.Repository, limiter *ratelimit.Limiter, allowedOrigins []string, telemetryEnabled bool, spaHandler http.Handler, otlpProxy http.Handler) http.Handler {
+// to serve the embedded web client. If metricsHandler is non-nil, it is
+// mounted at /metrics and must do its own authentication. If inboundEmail is
+// non-nil, it receives mail piped in by an MTA at /api/email/inbound and must
+// also do its own authentication.
+func NewRouter(h *handler.Handler, sseHandler *sse.Handler, sessionStore *auth.SessionStore, moderationRepo *moderation.Repository, limiter *ratelimit.Limiter, corsPolicy *CORS, telemetryEnabled bool, spaHandler http.Handler, otlpProxy http.Handler, metricsHandler http.Handler, inboundEmail http.Handler) http.Handler {
 	r := chi.NewRouter()
 
 	// Middleware
 	r.Use(RequestLogger)
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RealIP)
+	r.Use(telemetry.MetricsMiddleware())
 
 	if telemetryEnabled {
 		r.Use(telemetry.Middleware())
 	}
 
-	if len(allowedOrigins) > 0 {
-		allowedHeaders := []string{"Content-Type", "Authorization"}
-		if telemetryEnabled {
-			allowedHeaders = append(allowedHeaders, "traceparent", "tracestate")
-		}
-		r.Use(cors.Handler(cors.Options{
-			AllowedOrigins: allowedOrigins,
-			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-			AllowedHeaders: allowedHeaders,
-			ExposedHeaders: []string{"X-Request-Id"},
-			MaxAge:         86400,
-		}))
-	}
+	r.Use(corsPolicy.Handler)
 
 	r.Use(ratelimit.Middleware(limiter))
 	r.Use(auth.TokenMiddleware(sessionStore))
@@ -144,6 +135,11 @@ func NewRouter(h *handler.Handler, sseHandler *sse.Handler, sessionStore *auth.S
 		r.Get("/avatars/{filename}", h.ServeAvatar)
 		r.Get("/workspace-icons/{workspaceId}/{filename}", h.ServeWorkspaceIcon)
 		r.Get("/emojis/{workspaceId}/{filename}", h.ServeEmoji)
+		r.Get("/image-proxy", h.ServeImageProxy)
+
+		// Browsers can't send an Authorization header on a WebSocket, so the
+		// socket authenticates, and checks bans, in its first frame
+		r.Get("/workspaces/{wid}/ws", sseHandler.WebSocket)
 
 		r.Group(func(r chi.Router) {
 			r.Use(auth.RequireAuth())
@@ -154,6 +150,16 @@ func NewRouter(h *handler.Handler, sseHandler *sse.Handler, sessionStore *auth.S
 		})
 	})
 
+	// Mount Prometheus metrics (token-protected by the caller)
+	if metricsHandler != nil {
+		r.Get("/metrics", metricsHandler.ServeHTTP)
+	}
+
+	// Mount inbound email endpoint (token-protected by the caller)
+	if inboundEmail != nil {
+		r.Post("/api/email/inbound", inboundEmail.ServeHTTP)
+	}
+
 	// Mount OTLP trace proxy for frontend telemetry
 	if otlpProxy != nil {
 		r.Post("/api/telemetry/traces", otlpProxy.ServeHTTP)
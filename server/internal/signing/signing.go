@@ -3,11 +3,13 @@ package signing
 import (
 	"crypto/hmac"
 	"crypto/sha256"
+	"encoding/base32"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -64,3 +66,69 @@ func (s *Signer) SignedURL(baseURL, fileID, userID string, ttl time.Duration) (s
 
 	return u.String(), expires, nil
 }
+
+// SignURL computes a signature for an arbitrary URL, such as an external
+// image fetched through the image proxy. It is domain-separated from file
+// download signatures so one can never be replayed as the other.
+func (s *Signer) SignURL(rawURL string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("url:"))
+	mac.Write([]byte(rawURL))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyURL checks a signature produced by SignURL.
+func (s *Signer) VerifyURL(rawURL, sig string) error {
+	if !hmac.Equal([]byte(s.SignURL(rawURL)), []byte(sig)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// UnsubscribeToken returns a token for the one-click unsubscribe links in a
+// user's notification emails. It doesn't expire, since mail clients may act
+// on it long after the email was sent.
+func (s *Signer) UnsubscribeToken(userID string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("unsubscribe:"))
+	mac.Write([]byte(userID))
+	return userID + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyUnsubscribeToken returns the user ID of a token produced by
+// UnsubscribeToken.
+func (s *Signer) VerifyUnsubscribeToken(token string) (string, error) {
+	userID, _, ok := strings.Cut(token, ".")
+	if !ok || userID == "" || !hmac.Equal([]byte(s.UnsubscribeToken(userID)), []byte(token)) {
+		return "", ErrInvalidSignature
+	}
+	return userID, nil
+}
+
+// replyEncoding encodes reply token MACs in lowercase, since mail servers
+// may not preserve the case of an address's local part
+var replyEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// ReplyToken returns a token for the reply address of a notification email
+// about a message. It is short enough to fit an address's 64 character local
+// part, and is only valid for replies sent by the user it was made for.
+func (s *Signer) ReplyToken(userID, messageID string) string {
+	messageID = strings.ToUpper(messageID)
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte("reply:"))
+	mac.Write([]byte(userID))
+	mac.Write([]byte(":"))
+	mac.Write([]byte(messageID))
+	return strings.ToLower(messageID) + "-" + replyEncoding.EncodeToString(mac.Sum(nil)[:10])
+}
+
+// VerifyReplyToken returns the message ID of a token produced by ReplyToken
+// for the given user.
+func (s *Signer) VerifyReplyToken(token, userID string) (string, error) {
+	token = strings.ToLower(token)
+	messageID, _, ok := strings.Cut(token, "-")
+	if !ok || messageID == "" || !hmac.Equal([]byte(s.ReplyToken(userID, messageID)), []byte(token)) {
+		return "", ErrInvalidSignature
+	}
+	return strings.ToUpper(messageID), nil
+}
@@ -98,3 +98,67 @@ func TestSignedURLInvalidBaseURL(t *testing.T) {
 		t.Fatal("expected error for invalid base URL")
 	}
 }
+
+func TestSignVerifyURL(t *testing.T) {
+	s := NewSigner("test-secret-key")
+	rawURL := "https://example.com/image.png"
+
+	sig := s.SignURL(rawURL)
+	if err := s.VerifyURL(rawURL, sig); err != nil {
+		t.Fatalf("valid URL signature should verify: %v", err)
+	}
+	if err := s.VerifyURL("https://example.com/other.png", sig); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for different URL, got %v", err)
+	}
+	if err := NewSigner("other-secret").VerifyURL(rawURL, sig); err != ErrInvalidSignature {
+		t.Fatalf("expected ErrInvalidSignature for different secret, got %v", err)
+	}
+}
+
+func TestUnsubscribeToken(t *testing.T) {
+	s := NewSigner("test-secret-key")
+
+	token := s.UnsubscribeToken("user456")
+	userID, err := s.VerifyUnsubscribeToken(token)
+	if err != nil {
+		t.Fatalf("valid token should verify: %v", err)
+	}
+	if userID != "user456" {
+		t.Fatalf("userID = %q, want user456", userID)
+	}
+
+	_, sig, _ := strings.Cut(token, ".")
+	for _, tampered := range []string{"", "user456", "other." + sig, token + "0", NewSigner("other-secret").UnsubscribeToken("user456")} {
+		if _, err := s.VerifyUnsubscribeToken(tampered); err != ErrInvalidSignature {
+			t.Errorf("VerifyUnsubscribeToken(%q) = %v, want ErrInvalidSignature", tampered, err)
+		}
+	}
+}
+
+func TestReplyToken(t *testing.T) {
+	s := NewSigner("test-secret-key")
+	messageID := "01HZX3K7M2Q9V8W4T6Y5R1N0PB"
+
+	token := s.ReplyToken("user456", messageID)
+	if len("reply+"+token) > 64 {
+		t.Fatalf("reply address local part is %d characters, want at most 64", len("reply+"+token))
+	}
+	for _, tok := range []string{token, strings.ToUpper(token)} {
+		got, err := s.VerifyReplyToken(tok, "user456")
+		if err != nil {
+			t.Fatalf("valid token %q should verify: %v", tok, err)
+		}
+		if got != messageID {
+			t.Fatalf("messageID = %q, want %q", got, messageID)
+		}
+	}
+
+	if _, err := s.VerifyReplyToken(token, "other-user"); err != ErrInvalidSignature {
+		t.Errorf("token of another user: err = %v, want ErrInvalidSignature", err)
+	}
+	for _, tampered := range []string{"", strings.ToLower(messageID), token + "a", "01hzx3k7m2q9v8w4t6y5r1n0pc" + token[26:], NewSigner("other-secret").ReplyToken("user456", messageID)} {
+		if _, err := s.VerifyReplyToken(tampered, "user456"); err != ErrInvalidSignature {
+			t.Errorf("VerifyReplyToken(%q) = %v, want ErrInvalidSignature", tampered, err)
+		}
+	}
+}
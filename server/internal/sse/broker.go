@@ -0,0 +1,123 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Broker fans hub messages out to every Enzyme instance sharing it. Messages
+// are opaque to the broker; each hub ignores the ones it published itself.
+type Broker interface {
+	// Publish sends msg to every subscriber, including the publisher's own.
+	Publish(ctx context.Context, msg []byte) error
+	// Subscribe calls fn for every published message until ctx is done. It
+	// returns once the subscription is active.
+	Subscribe(ctx context.Context, fn func(msg []byte)) error
+	Close() error
+}
+
+// LocalBroker is an in-process Broker. It lets tests run several hubs
+// against each other; a single instance needs no broker at all.
+type LocalBroker struct {
+	mu   sync.RWMutex
+	subs map[int]func([]byte)
+	next int
+}
+
+func NewLocalBroker() *LocalBroker {
+	return &LocalBroker{subs: make(map[int]func([]byte))}
+}
+
+// Publish delivers msg synchronously, outside the lock so that subscribers
+// may publish in turn.
+func (b *LocalBroker) Publish(_ context.Context, msg []byte) error {
+	b.mu.RLock()
+	subs := make([]func([]byte), 0, len(b.subs))
+	for _, fn := range b.subs {
+		subs = append(subs, fn)
+	}
+	b.mu.RUnlock()
+
+	for _, fn := range subs {
+		fn(msg)
+	}
+	return nil
+}
+
+func (b *LocalBroker) Subscribe(ctx context.Context, fn func([]byte)) error {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.subs[id] = fn
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subs, id)
+		b.mu.Unlock()
+	}()
+	return nil
+}
+
+func (b *LocalBroker) Close() error { return nil }
+
+// RedisBroker fans messages out over a Redis pub/sub channel.
+type RedisBroker struct {
+	client  *redis.Client
+	channel string
+}
+
+// NewRedisBroker connects to the Redis server at url
+// (redis://[:password@]host:port[/db]) and checks that it is reachable.
+func NewRedisBroker(ctx context.Context, url, channel string) (*RedisBroker, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("parsing redis url: %w", err)
+	}
+	client := redis.NewClient(opts)
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("connecting to redis: %w", err)
+	}
+	return &RedisBroker{client: client, channel: channel}, nil
+}
+
+func (b *RedisBroker) Publish(ctx context.Context, msg []byte) error {
+	return b.client.Publish(ctx, b.channel, msg).Err()
+}
+
+// Subscribe waits for Redis to confirm the subscription before returning.
+// The client reconnects and resubscribes on its own if the connection drops;
+// messages published while disconnected are lost, as with any pub/sub.
+func (b *RedisBroker) Subscribe(ctx context.Context, fn func([]byte)) error {
+	ps := b.client.Subscribe(ctx, b.channel)
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return fmt.Errorf("subscribing to %s: %w", b.channel, err)
+	}
+
+	go func() {
+		defer ps.Close()
+		ch := ps.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m, ok := <-ch:
+				if !ok {
+					return
+				}
+				fn([]byte(m.Payload))
+			}
+		}
+	}()
+	return nil
+}
+
+func (b *RedisBroker) Close() error {
+	return b.client.Close()
+}
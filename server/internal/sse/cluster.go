@@ -0,0 +1,288 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/enzyme/server/internal/openapi"
+)
+
+const (
+	// How often each node publishes the users connected to it. Nodes that
+	// stay silent for nodeTimeout are treated as gone.
+	nodeSyncInterval = 10 * time.Second
+	nodeTimeout      = 3 * nodeSyncInterval
+
+	publishTimeout = 5 * time.Second
+	// publishQueueSize bounds the messages waiting for the broker; more are
+	// dropped rather than blocking the hub
+	publishQueueSize = 1024
+)
+
+// Cluster message kinds
+const (
+	clusterEvent      = "event"      // an event to deliver to local clients
+	clusterMembers    = "members"    // channel membership changed; drop the cached member set
+	clusterPresence   = "presence"   // a user's first connection opened or last one closed
+	clusterSnapshot   = "snapshot"   // every user connected to the sending node
+	clusterLeave      = "leave"      // the sending node is shutting down
+	clusterDisconnect = "disconnect" // close a user's connections
+)
+
+// Event scopes
+const (
+	scopeWorkspace = "workspace"
+	scopeChannel   = "channel"
+	scopeUser      = "user"
+)
+
+type clusterMessage struct {
+	Node        string              `json:"node"`
+	Kind        string              `json:"kind"`
+	Scope       string              `json:"scope,omitempty"`
+	WorkspaceID string              `json:"workspace_id,omitempty"`
+	ChannelID   string              `json:"channel_id,omitempty"`
+	UserID      string              `json:"user_id,omitempty"`
+	Online      bool                `json:"online,omitempty"`
+	Frame       []byte              `json:"frame,omitempty"`
+	Users       map[string][]string `json:"users,omitempty"` // workspaceID -> userIDs
+}
+
+// remoteNode is what this hub knows about another node's connections.
+type remoteNode struct {
+	seen time.Time
+	// workspaceID -> set of userIDs
+	users map[string]map[string]bool
+}
+
+func (n *remoteNode) has(workspaceID, userID string) bool {
+	return n.users[workspaceID][userID]
+}
+
+func (n *remoteNode) set(workspaceID, userID string, online bool) {
+	if !online {
+		delete(n.users[workspaceID], userID)
+		if len(n.users[workspaceID]) == 0 {
+			delete(n.users, workspaceID)
+		}
+		return
+	}
+	if n.users[workspaceID] == nil {
+		n.users[workspaceID] = make(map[string]bool)
+	}
+	n.users[workspaceID][userID] = true
+}
+
+// NodeID returns the identifier this hub uses on the broker.
+func (h *Hub) NodeID() string {
+	return h.nodeID
+}
+
+// publish queues msg for the other nodes. It does nothing without a broker,
+// and drops msg if the broker has fallen too far behind; local delivery has
+// already happened either way.
+func (h *Hub) publish(msg clusterMessage) {
+	if h.broker == nil {
+		return
+	}
+	data := h.encode(msg)
+	if data == nil {
+		return
+	}
+	select {
+	case h.publishQueue <- data:
+	default:
+		if h.publishDropped != nil {
+			h.publishDropped.Add(context.Background(), 1)
+		}
+		slog.Warn("sse broker is falling behind; dropped cluster message", "kind", msg.Kind)
+	}
+}
+
+// publishLeave tells the other nodes this one is shutting down. It is sent
+// directly, since the publish loop stops with the hub.
+func (h *Hub) publishLeave() {
+	if h.broker == nil {
+		return
+	}
+	if data := h.encode(clusterMessage{Kind: clusterLeave}); data != nil {
+		h.send(data)
+	}
+}
+
+// runPublishLoop hands queued messages to the broker, in order, until ctx is
+// done.
+func (h *Hub) runPublishLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case data := <-h.publishQueue:
+			h.send(data)
+		}
+	}
+}
+
+func (h *Hub) encode(msg clusterMessage) []byte {
+	msg.Node = h.nodeID
+	data, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("failed to marshal cluster message", "kind", msg.Kind, "error", err)
+		return nil
+	}
+	return data
+}
+
+// send publishes data, logging failures rather than returning them.
+func (h *Hub) send(data []byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := h.broker.Publish(ctx, data); err != nil {
+		slog.Error("failed to publish cluster message", "error", err)
+	}
+}
+
+func (h *Hub) handleClusterMessage(data []byte) {
+	var msg clusterMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		slog.Error("failed to decode cluster message", "error", err)
+		return
+	}
+	if msg.Node == h.nodeID {
+		return
+	}
+
+	switch msg.Kind {
+	case clusterEvent:
+		serialized := SerializedEvent{Frame: msg.Frame}
+		switch msg.Scope {
+		case scopeWorkspace:
+			h.deliverToWorkspace(msg.WorkspaceID, serialized)
+		case scopeChannel:
+			h.deliverToChannel(msg.WorkspaceID, msg.ChannelID, serialized)
+		case scopeUser:
+			h.deliverToUser(msg.WorkspaceID, msg.UserID, serialized)
+		}
+	case clusterMembers:
+		h.mu.Lock()
+		delete(h.channelMembers, msg.ChannelID)
+		h.mu.Unlock()
+	case clusterPresence:
+		if h.touchNode(msg.Node, func(n *remoteNode) { n.set(msg.WorkspaceID, msg.UserID, msg.Online) }) {
+			h.publishSnapshot()
+		}
+	case clusterSnapshot:
+		users := make(map[string]map[string]bool, len(msg.Users))
+		for workspaceID, userIDs := range msg.Users {
+			users[workspaceID] = make(map[string]bool, len(userIDs))
+			for _, id := range userIDs {
+				users[workspaceID][id] = true
+			}
+		}
+		if h.touchNode(msg.Node, func(n *remoteNode) { n.users = users }) {
+			h.publishSnapshot()
+		}
+	case clusterLeave:
+		h.clusterMu.Lock()
+		delete(h.nodes, msg.Node)
+		h.clusterMu.Unlock()
+	case clusterDisconnect:
+		h.disconnectLocalClients(msg.WorkspaceID, msg.UserID)
+	}
+}
+
+// touchNode applies fn to the named node's state and marks it as seen. It
+// reports whether the node was new, in which case the caller answers with a
+// snapshot so the newcomer learns about this node without waiting.
+func (h *Hub) touchNode(nodeID string, fn func(n *remoteNode)) bool {
+	h.clusterMu.Lock()
+	defer h.clusterMu.Unlock()
+
+	n, ok := h.nodes[nodeID]
+	if !ok {
+		n = &remoteNode{users: make(map[string]map[string]bool)}
+		h.nodes[nodeID] = n
+	}
+	n.seen = time.Now()
+	fn(n)
+	return !ok
+}
+
+func (h *Hub) publishSnapshot() {
+	h.mu.RLock()
+	users := make(map[string][]string, len(h.workspaces))
+	for workspaceID, workspace := range h.workspaces {
+		for userID := range workspace {
+			users[workspaceID] = append(users[workspaceID], userID)
+		}
+	}
+	h.mu.RUnlock()
+
+	h.publish(clusterMessage{Kind: clusterSnapshot, Users: users})
+}
+
+// isRemoteConnected reports whether any live remote node has a connection
+// for the user.
+func (h *Hub) isRemoteConnected(workspaceID, userID string) bool {
+	h.clusterMu.RLock()
+	defer h.clusterMu.RUnlock()
+
+	cutoff := time.Now().Add(-nodeTimeout)
+	for _, n := range h.nodes {
+		if n.seen.After(cutoff) && n.has(workspaceID, userID) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteUserIDs adds the users connected to live remote nodes to set.
+func (h *Hub) remoteUserIDs(workspaceID string, set map[string]bool) {
+	h.clusterMu.RLock()
+	defer h.clusterMu.RUnlock()
+
+	cutoff := time.Now().Add(-nodeTimeout)
+	for _, n := range h.nodes {
+		if !n.seen.After(cutoff) {
+			continue
+		}
+		for userID := range n.users[workspaceID] {
+			set[userID] = true
+		}
+	}
+}
+
+// pruneNodes forgets nodes that have stopped syncing, e.g. because they
+// crashed. Users who were only connected to them are reported offline to
+// local clients; every surviving node does the same for its own clients.
+func (h *Hub) pruneNodes() {
+	cutoff := time.Now().Add(-nodeTimeout)
+
+	h.clusterMu.Lock()
+	var gone []*remoteNode
+	for id, n := range h.nodes {
+		if !n.seen.After(cutoff) {
+			gone = append(gone, n)
+			delete(h.nodes, id)
+		}
+	}
+	h.clusterMu.Unlock()
+
+	for _, n := range gone {
+		for workspaceID, users := range n.users {
+			for userID := range users {
+				if h.IsUserConnected(workspaceID, userID) {
+					continue
+				}
+				event := NewPresenceChangedEvent(openapi.PresenceData{UserId: userID, Status: openapi.Offline})
+				serialized, err := event.Serialize()
+				if err != nil {
+					continue
+				}
+				h.deliverToWorkspace(workspaceID, serialized)
+			}
+		}
+	}
+}
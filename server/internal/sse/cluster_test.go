@@ -0,0 +1,258 @@
+package sse
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// startHub runs a hub on broker until the test ends.
+func startHub(t *testing.T, broker Broker) *Hub {
+	t.Helper()
+	hub := NewHub(nil, 0)
+	hub.SetBroker(broker)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go hub.Run(ctx)
+	return hub
+}
+
+func connect(hub *Hub, workspaceID, userID string) *Client {
+	client := &Client{
+		ID:          workspaceID + "/" + userID,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Send:        make(chan SerializedEvent, 16),
+		Done:        make(chan struct{}),
+	}
+	hub.Register(client)
+	return client
+}
+
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// receive waits for an event whose frame contains substr, skipping others.
+func receive(t *testing.T, client *Client, substr string) {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case e := <-client.Send:
+			if strings.Contains(string(e.Frame), substr) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("client %s did not receive %q", client.ID, substr)
+		}
+	}
+}
+
+func assertNoEvent(t *testing.T, client *Client, substr string) {
+	t.Helper()
+	timeout := time.After(100 * time.Millisecond)
+	for {
+		select {
+		case e := <-client.Send:
+			if strings.Contains(string(e.Frame), substr) {
+				t.Fatalf("client %s unexpectedly received %s", client.ID, e.Frame)
+			}
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func TestCluster_BroadcastReachesOtherHub(t *testing.T) {
+	broker := NewLocalBroker()
+	a, b := startHub(t, broker), startHub(t, broker)
+
+	client := connect(b, "ws1", "u1")
+	eventually(t, "remote connection", func() bool { return a.IsUserOnline("ws1", "u1") })
+
+	a.BroadcastToWorkspace("ws1", Event{Type: EventMessageNew, Data: "to-workspace"})
+	receive(t, client, "to-workspace")
+
+	a.BroadcastToUser("ws1", "u1", Event{Type: EventNotification, Data: "to-user"})
+	receive(t, client, "to-user")
+
+	a.BroadcastToUser("ws1", "u2", Event{Type: EventNotification, Data: "other-user"})
+	a.BroadcastToWorkspace("ws2", Event{Type: EventMessageNew, Data: "other-workspace"})
+	assertNoEvent(t, client, "other-")
+}
+
+// stalledBroker accepts a subscription but blocks every Publish until its
+// context expires, like an unresponsive Redis.
+type stalledBroker struct {
+	published atomic.Int64
+}
+
+func (b *stalledBroker) Publish(ctx context.Context, _ []byte) error {
+	b.published.Add(1)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (b *stalledBroker) Subscribe(context.Context, func([]byte)) error { return nil }
+func (b *stalledBroker) Close() error                                  { return nil }
+
+func TestCluster_StalledBrokerDoesNotBlock(t *testing.T) {
+	broker := &stalledBroker{}
+	hub := startHub(t, broker)
+	client := connect(hub, "ws1", "u1")
+	receive(t, client, `"status":"online"`)
+
+	// Many more messages than the queue holds return at once and still
+	// reach local clients
+	start := time.Now()
+	for i := 0; i < publishQueueSize*2; i++ {
+		hub.BroadcastToUser("ws1", "u1", Event{Type: EventNotification, Data: "queued"})
+		if i%16 == 0 {
+			<-client.Send
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("broadcasting took %v with a stalled broker", elapsed)
+	}
+	eventually(t, "publish attempt", func() bool { return broker.published.Load() > 0 })
+	if got := broker.published.Load(); got != 1 {
+		t.Fatalf("broker received %d messages, want only the one being published", got)
+	}
+
+	// Connections are still handled
+	connect(hub, "ws1", "u2")
+	eventually(t, "second connection", func() bool { return hub.IsUserConnected("ws1", "u2") })
+}
+
+func TestHub_NoBrokerDoesNotPublish(t *testing.T) {
+	hub := NewHub(nil, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go hub.Run(ctx)
+
+	client := connect(hub, "ws1", "u1")
+	eventually(t, "connection", func() bool { return hub.IsUserConnected("ws1", "u1") })
+	hub.BroadcastToWorkspace("ws1", Event{Type: EventMessageNew, Data: "local"})
+	receive(t, client, "local")
+	if hub.publishQueue != nil {
+		t.Fatal("hub without a broker has a publish queue")
+	}
+}
+
+func TestCluster_ChannelMembershipInvalidation(t *testing.T) {
+	broker := NewLocalBroker()
+	a, b := startHub(t, broker), startHub(t, broker)
+
+	client := connect(b, "ws1", "u1")
+	eventually(t, "remote connection", func() bool { return a.IsUserOnline("ws1", "u1") })
+
+	b.UpdateChannelMembers("ch1", []string{"u1"})
+	a.BroadcastToChannel("ws1", "ch1", Event{Type: EventMessageNew, Data: "member"})
+	receive(t, client, "member")
+
+	// Removing on a drops b's cached member set; with no database b then
+	// finds no members, so the event is not delivered
+	a.RemoveChannelMember("ch1", "u1")
+	a.BroadcastToChannel("ws1", "ch1", Event{Type: EventMessageNew, Data: "after-removal"})
+	assertNoEvent(t, client, "after-removal")
+}
+
+func TestCluster_Presence(t *testing.T) {
+	broker := NewLocalBroker()
+	a, b := startHub(t, broker), startHub(t, broker)
+
+	watcher := connect(a, "ws1", "watcher")
+	receive(t, watcher, `"user_id":"watcher"`)
+
+	onB := connect(b, "ws1", "u1")
+	receive(t, watcher, `"status":"online","user_id":"u1"`)
+	if ids := a.GetConnectedUserIDs("ws1"); len(ids) != 2 {
+		t.Fatalf("connected users on a = %v, want watcher and u1", ids)
+	}
+
+	// A second connection on another node is not a presence change
+	onA := connect(a, "ws1", "u1")
+	assertNoEvent(t, watcher, `"user_id":"u1"`)
+
+	// Neither is closing one of the two
+	b.Unregister(onB)
+	assertNoEvent(t, watcher, `"user_id":"u1"`)
+
+	a.Unregister(onA)
+	receive(t, watcher, `"status":"offline","user_id":"u1"`)
+	eventually(t, "user offline on b", func() bool { return !b.IsUserOnline("ws1", "u1") })
+}
+
+func TestCluster_DisconnectUserClients(t *testing.T) {
+	broker := NewLocalBroker()
+	a, b := startHub(t, broker), startHub(t, broker)
+
+	client := connect(b, "ws1", "u1")
+	eventually(t, "remote connection", func() bool { return a.IsUserOnline("ws1", "u1") })
+
+	a.DisconnectUserClients("ws1", "u1")
+	select {
+	case <-client.Done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("remote client was not disconnected")
+	}
+}
+
+func TestCluster_PruneStaleNode(t *testing.T) {
+	hub := NewHub(nil, 0)
+	watcher := &Client{ID: "w", UserID: "watcher", WorkspaceID: "ws1", Send: make(chan SerializedEvent, 16), Done: make(chan struct{})}
+	hub.addClient(watcher)
+
+	hub.touchNode("crashed", func(n *remoteNode) { n.set("ws1", "u1", true) })
+	if !hub.IsUserOnline("ws1", "u1") {
+		t.Fatal("user on remote node should be online")
+	}
+
+	hub.nodes["crashed"].seen = time.Now().Add(-2 * nodeTimeout)
+	hub.pruneNodes()
+	if hub.IsUserOnline("ws1", "u1") {
+		t.Fatal("user on a stale node should be offline")
+	}
+	receive(t, watcher, `"status":"offline","user_id":"u1"`)
+}
+
+func TestRedisBroker(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx := context.Background()
+
+	newBroker := func() Broker {
+		broker, err := NewRedisBroker(ctx, "redis://"+mr.Addr(), "enzyme:sse")
+		if err != nil {
+			t.Fatalf("NewRedisBroker: %v", err)
+		}
+		t.Cleanup(func() { _ = broker.Close() })
+		return broker
+	}
+	a, b := startHub(t, newBroker()), startHub(t, newBroker())
+
+	client := connect(b, "ws1", "u1")
+	eventually(t, "remote connection", func() bool { return a.IsUserOnline("ws1", "u1") })
+
+	a.BroadcastToWorkspace("ws1", Event{Type: EventMessageNew, Data: "over-redis"})
+	receive(t, client, "over-redis")
+}
+
+func TestNewRedisBroker_Unreachable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := NewRedisBroker(ctx, "redis://127.0.0.1:1", "enzyme:sse"); err == nil {
+		t.Fatal("expected error for unreachable redis")
+	}
+}
@@ -1,6 +1,7 @@
 package sse
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -80,3 +81,13 @@ func (e *Event) Serialize() (SerializedEvent, error) {
 	frame := fmt.Appendf(nil, "id: %s\ndata: %s\n\n", e.ID, data)
 	return SerializedEvent{Frame: frame}, nil
 }
+
+// Data returns the JSON payload of the frame, for transports that don't use
+// SSE framing.
+func (s SerializedEvent) Data() []byte {
+	_, data, ok := bytes.Cut(s.Frame, []byte("\ndata: "))
+	if !ok {
+		return nil
+	}
+	return bytes.TrimSuffix(data, []byte("\n\n"))
+}
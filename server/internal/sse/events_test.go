@@ -59,6 +59,18 @@ func TestSerialize_FrameFormat(t *testing.T) {
 	}
 }
 
+func TestSerializedEvent_Data(t *testing.T) {
+	e := Event{ID: "ABC", Type: "test.event", Data: map[string]string{"key": "value"}}
+	serialized, err := e.Serialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"id":"ABC","type":"test.event","data":{"key":"value"}}`
+	if got := string(serialized.Data()); got != want {
+		t.Errorf("Data() = %q, want %q", got, want)
+	}
+}
+
 func TestSerialize_RejectsInvalidIDCharacters(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1,14 +1,17 @@
 package sse
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/enzyme/server/internal/auth"
 	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/moderation"
 	"github.com/enzyme/server/internal/openapi"
 	"github.com/enzyme/server/internal/workspace"
 	"github.com/go-chi/chi/v5"
@@ -19,18 +22,57 @@ type Handler struct {
 	hub               *Hub
 	workspaceRepo     *workspace.Repository
 	channelRepo       *channel.Repository
-	heartbeatInterval time.Duration
+	moderationRepo    *moderation.Repository
+	sessions          SessionValidator
+	presence          PresenceTracker
+	heartbeatInterval atomic.Int64 // time.Duration
+	allowedOrigins    atomic.Pointer[[]string]
 	clientBufferSize  int
 }
 
-func NewHandler(hub *Hub, workspaceRepo *workspace.Repository, channelRepo *channel.Repository, heartbeatInterval time.Duration, clientBufferSize int) *Handler {
-	return &Handler{
-		hub:               hub,
-		workspaceRepo:     workspaceRepo,
-		channelRepo:       channelRepo,
-		heartbeatInterval: heartbeatInterval,
-		clientBufferSize:  clientBufferSize,
+// SessionValidator resolves the session tokens WebSocket clients send in
+// their hello frame to a user ID.
+type SessionValidator interface {
+	Validate(token string) (string, error)
+}
+
+// PresenceTracker records the presence pings clients send over WebSocket
+// connections.
+type PresenceTracker interface {
+	SetOnline(workspaceID, userID string)
+}
+
+func NewHandler(hub *Hub, workspaceRepo *workspace.Repository, channelRepo *channel.Repository, moderationRepo *moderation.Repository, sessions SessionValidator, presence PresenceTracker, heartbeatInterval time.Duration, allowedOrigins []string, clientBufferSize int) *Handler {
+	h := &Handler{
+		hub:              hub,
+		workspaceRepo:    workspaceRepo,
+		channelRepo:      channelRepo,
+		moderationRepo:   moderationRepo,
+		sessions:         sessions,
+		presence:         presence,
+		clientBufferSize: clientBufferSize,
 	}
+	h.SetHeartbeatInterval(heartbeatInterval)
+	h.SetAllowedOrigins(allowedOrigins)
+	return h
+}
+
+// SetAllowedOrigins replaces the cross-origin pages, besides the server's
+// own, that may open WebSocket connections. They are the CORS policy's
+// origins, such as "https://app.example.com", and may contain wildcards.
+func (h *Handler) SetAllowedOrigins(origins []string) {
+	origins = append([]string(nil), origins...)
+	h.allowedOrigins.Store(&origins)
+}
+
+func (h *Handler) originPatterns() []string {
+	return *h.allowedOrigins.Load()
+}
+
+// SetHeartbeatInterval changes the heartbeat interval. Open streams pick it
+// up after their next heartbeat.
+func (h *Handler) SetHeartbeatInterval(d time.Duration) {
+	h.heartbeatInterval.Store(int64(d))
 }
 
 func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
@@ -98,7 +140,8 @@ func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Start heartbeat
-	heartbeat := time.NewTicker(h.heartbeatInterval)
+	interval := time.Duration(h.heartbeatInterval.Load())
+	heartbeat := time.NewTicker(interval)
 	defer heartbeat.Stop()
 
 	for {
@@ -115,6 +158,10 @@ func (h *Handler) Events(w http.ResponseWriter, r *http.Request) {
 			h.drainAndFlush(w, flusher, client)
 		case <-heartbeat.C:
 			h.writeLocalEvent(w, flusher, NewHeartbeatEvent(openapi.HeartbeatData{Timestamp: time.Now().Unix()}))
+			if d := time.Duration(h.heartbeatInterval.Load()); d != interval {
+				interval = d
+				heartbeat.Reset(interval)
+			}
 		}
 	}
 }
@@ -160,61 +207,77 @@ type TypingInput struct {
 	ChannelID string `json:"channel_id"`
 }
 
-// checkTypingAccess verifies workspace membership and channel access for typing endpoints.
-// Returns the decoded input and true if access is granted; writes an error response and returns false otherwise.
-func (h *Handler) checkTypingAccess(w http.ResponseWriter, r *http.Request, workspaceID, userID string) (TypingInput, bool) {
-	var input TypingInput
-	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
-		return input, false
-	}
-
-	if input.ChannelID == "" {
-		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "channel_id is required")
-		return input, false
-	}
+var (
+	errNotWorkspaceMember = errors.New("not a member of this workspace")
+	errChannelNotFound    = errors.New("channel not found")
+	errNotChannelMember   = errors.New("not a member of this channel")
+)
 
+// checkChannelAccess verifies workspace membership and that the user can see
+// the channel (public channels allow any workspace member).
+func (h *Handler) checkChannelAccess(ctx context.Context, workspaceID, userID, channelID string) error {
 	// Check workspace membership
-	_, err := h.workspaceRepo.GetMembership(r.Context(), userID, workspaceID)
+	_, err := h.workspaceRepo.GetMembership(ctx, userID, workspaceID)
 	if err != nil {
 		if errors.Is(err, workspace.ErrNotAMember) {
-			writeError(w, http.StatusForbidden, "NOT_A_MEMBER", "Not a member of this workspace")
-			return input, false
+			return errNotWorkspaceMember
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal error")
-		return input, false
+		return err
 	}
 
 	// Verify channel belongs to this workspace
-	ch, err := h.channelRepo.GetByID(r.Context(), input.ChannelID)
+	ch, err := h.channelRepo.GetByID(ctx, channelID)
 	if err != nil {
 		if errors.Is(err, channel.ErrChannelNotFound) {
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "Channel not found")
-			return input, false
+			return errChannelNotFound
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal error")
-		return input, false
+		return err
 	}
 	if ch.WorkspaceID != workspaceID {
-		writeError(w, http.StatusNotFound, "NOT_FOUND", "Channel not found")
-		return input, false
+		return errChannelNotFound
 	}
 
 	// Check channel membership (public channels allow any workspace member)
-	_, err = h.channelRepo.GetMembership(r.Context(), userID, input.ChannelID)
+	_, err = h.channelRepo.GetMembership(ctx, userID, channelID)
 	if err != nil {
-		if errors.Is(err, channel.ErrNotChannelMember) {
-			if ch.Type != channel.TypePublic {
-				writeError(w, http.StatusForbidden, "NOT_A_MEMBER", "Not a member of this channel")
-				return input, false
-			}
-		} else {
-			writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal error")
-			return input, false
+		if !errors.Is(err, channel.ErrNotChannelMember) {
+			return err
+		}
+		if ch.Type != channel.TypePublic {
+			return errNotChannelMember
 		}
 	}
 
-	return input, true
+	return nil
+}
+
+// checkTypingAccess verifies workspace membership and channel access for typing endpoints.
+// Returns the decoded input and true if access is granted; writes an error response and returns false otherwise.
+func (h *Handler) checkTypingAccess(w http.ResponseWriter, r *http.Request, workspaceID, userID string) (TypingInput, bool) {
+	var input TypingInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return input, false
+	}
+
+	if input.ChannelID == "" {
+		writeError(w, http.StatusBadRequest, "INVALID_REQUEST", "channel_id is required")
+		return input, false
+	}
+
+	switch err := h.checkChannelAccess(r.Context(), workspaceID, userID, input.ChannelID); {
+	case err == nil:
+		return input, true
+	case errors.Is(err, errNotWorkspaceMember):
+		writeError(w, http.StatusForbidden, "NOT_A_MEMBER", "Not a member of this workspace")
+	case errors.Is(err, errChannelNotFound):
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Channel not found")
+	case errors.Is(err, errNotChannelMember):
+		writeError(w, http.StatusForbidden, "NOT_A_MEMBER", "Not a member of this channel")
+	default:
+		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Internal error")
+	}
+	return input, false
 }
 
 func (h *Handler) StartTyping(w http.ResponseWriter, r *http.Request) {
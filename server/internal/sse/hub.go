@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/enzyme/server/internal/openapi"
+	"github.com/oklog/ulid/v2"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
@@ -35,6 +36,16 @@ type Hub struct {
 
 	retention time.Duration
 
+	// Cross-instance fan-out, when a broker is set. nodes tracks which
+	// users are connected to the other instances sharing the broker.
+	// Messages are published from publishQueue by a single goroutine, so a
+	// slow broker never blocks the hub or its callers.
+	nodeID       string
+	broker       Broker
+	publishQueue chan []byte
+	clusterMu    sync.RWMutex
+	nodes        map[string]*remoteNode
+
 	register   chan *Client
 	unregister chan *Client
 
@@ -45,6 +56,7 @@ type Hub struct {
 	// OTel metrics (no-op when telemetry is disabled)
 	connectionsActive metric.Int64UpDownCounter
 	eventsBroadcast   metric.Int64Counter
+	publishDropped    metric.Int64Counter
 }
 
 type storeRequest struct {
@@ -74,44 +86,85 @@ func NewHub(db *sql.DB, retention time.Duration) *Hub {
 	if err != nil {
 		slog.Error("failed to create sse.events.broadcast metric", "error", err)
 	}
+	publishDropped, err := meter.Int64Counter("sse.cluster.publish.dropped",
+		metric.WithDescription("Cluster messages dropped because the broker fell behind"),
+	)
+	if err != nil {
+		slog.Error("failed to create sse.cluster.publish.dropped metric", "error", err)
+	}
 
 	return &Hub{
 		workspaces:        make(map[string]map[string][]*Client),
 		channelMembers:    make(map[string]map[string]bool),
 		db:                db,
 		retention:         retention,
+		nodeID:            ulid.Make().String(),
+		nodes:             make(map[string]*remoteNode),
 		register:          make(chan *Client, 256),
 		unregister:        make(chan *Client, 256),
 		storeQueue:        make(chan storeRequest, 1024),
 		connectionsActive: connectionsActive,
 		eventsBroadcast:   eventsBroadcast,
+		publishDropped:    publishDropped,
 	}
 }
 
+// SetBroker sets the broker through which broadcasts reach clients connected
+// to other instances. Without one the hub only serves its own clients. Call
+// before Run.
+func (h *Hub) SetBroker(broker Broker) {
+	h.broker = broker
+	h.publishQueue = make(chan []byte, publishQueueSize)
+}
+
 func (h *Hub) Run(ctx context.Context) {
 	go h.runStoreLoop(ctx)
 
+	if h.broker != nil {
+		if err := h.broker.Subscribe(ctx, h.handleClusterMessage); err != nil {
+			slog.Error("failed to subscribe to sse broker; events will not reach other instances", "error", err)
+		}
+		go h.runPublishLoop(ctx)
+		// Announce this node; the others answer with their own snapshots
+		h.publishSnapshot()
+	}
+
+	nodeSync := time.NewTicker(nodeSyncInterval)
+	defer nodeSync.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
+			h.publishLeave()
 			return
+		case <-nodeSync.C:
+			h.publishSnapshot()
+			h.pruneNodes()
 		case client := <-h.register:
 			isFirstConnection := h.addClient(client)
 			if isFirstConnection {
-				// User just came online - broadcast to workspace
-				h.BroadcastToWorkspace(client.WorkspaceID, NewPresenceChangedEvent(openapi.PresenceData{
-					UserId: client.UserID,
-					Status: openapi.Online,
-				}))
+				h.publish(clusterMessage{Kind: clusterPresence, WorkspaceID: client.WorkspaceID, UserID: client.UserID, Online: true})
+				// User just came online - broadcast to workspace, unless
+				// they were already connected to another instance
+				if !h.isRemoteConnected(client.WorkspaceID, client.UserID) {
+					h.BroadcastToWorkspace(client.WorkspaceID, NewPresenceChangedEvent(openapi.PresenceData{
+						UserId: client.UserID,
+						Status: openapi.Online,
+					}))
+				}
 			}
 		case client := <-h.unregister:
 			isLastConnection := h.removeClient(client)
 			if isLastConnection {
-				// User just went offline - broadcast to workspace
-				h.BroadcastToWorkspace(client.WorkspaceID, NewPresenceChangedEvent(openapi.PresenceData{
-					UserId: client.UserID,
-					Status: openapi.Offline,
-				}))
+				h.publish(clusterMessage{Kind: clusterPresence, WorkspaceID: client.WorkspaceID, UserID: client.UserID})
+				// User just went offline - broadcast to workspace, unless
+				// they are still connected to another instance
+				if !h.isRemoteConnected(client.WorkspaceID, client.UserID) {
+					h.BroadcastToWorkspace(client.WorkspaceID, NewPresenceChangedEvent(openapi.PresenceData{
+						UserId: client.UserID,
+						Status: openapi.Offline,
+					}))
+				}
 			}
 		}
 	}
@@ -179,6 +232,12 @@ func (h *Hub) BroadcastToWorkspace(workspaceID string, event Event) {
 	// Queue event storage asynchronously (no DB I/O on this goroutine)
 	h.enqueueStoreEvent(workspaceID, "", event)
 
+	h.deliverToWorkspace(workspaceID, serialized)
+	h.publish(clusterMessage{Kind: clusterEvent, Scope: scopeWorkspace, WorkspaceID: workspaceID, Frame: serialized.Frame})
+}
+
+// deliverToWorkspace sends an event to every client of this instance in the workspace.
+func (h *Hub) deliverToWorkspace(workspaceID string, serialized SerializedEvent) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -208,6 +267,12 @@ func (h *Hub) BroadcastToChannel(workspaceID, channelID string, event Event) {
 	// Queue event storage asynchronously (no DB I/O on this goroutine)
 	h.enqueueStoreEvent(workspaceID, channelID, event)
 
+	h.deliverToChannel(workspaceID, channelID, serialized)
+	h.publish(clusterMessage{Kind: clusterEvent, Scope: scopeChannel, WorkspaceID: workspaceID, ChannelID: channelID, Frame: serialized.Frame})
+}
+
+// deliverToChannel sends an event to the channel members connected to this instance.
+func (h *Hub) deliverToChannel(workspaceID, channelID string, serialized SerializedEvent) {
 	// Resolve channel members before taking the broadcast lock.
 	// getChannelMembers manages its own locking internally.
 	members := h.getChannelMembers(channelID)
@@ -240,6 +305,12 @@ func (h *Hub) BroadcastToUser(workspaceID, userID string, event Event) {
 		return
 	}
 
+	h.deliverToUser(workspaceID, userID, serialized)
+	h.publish(clusterMessage{Kind: clusterEvent, Scope: scopeUser, WorkspaceID: workspaceID, UserID: userID, Frame: serialized.Frame})
+}
+
+// deliverToUser sends an event to the user's connections on this instance.
+func (h *Hub) deliverToUser(workspaceID, userID string, serialized SerializedEvent) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -255,34 +326,39 @@ func (h *Hub) BroadcastToUser(workspaceID, userID string, event Event) {
 	}
 }
 
+// UpdateChannelMembers, AddChannelMember and RemoveChannelMember update this
+// instance's member cache and tell the others to reload theirs from the database.
 func (h *Hub) UpdateChannelMembers(channelID string, userIDs []string) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	members := make(map[string]bool)
 	for _, id := range userIDs {
 		members[id] = true
 	}
 	h.channelMembers[channelID] = members
+	h.mu.Unlock()
+
+	h.publish(clusterMessage{Kind: clusterMembers, ChannelID: channelID})
 }
 
 func (h *Hub) AddChannelMember(channelID, userID string) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	if h.channelMembers[channelID] == nil {
 		h.channelMembers[channelID] = make(map[string]bool)
 	}
 	h.channelMembers[channelID][userID] = true
+	h.mu.Unlock()
+
+	h.publish(clusterMessage{Kind: clusterMembers, ChannelID: channelID})
 }
 
 func (h *Hub) RemoveChannelMember(channelID, userID string) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
 	if h.channelMembers[channelID] != nil {
 		delete(h.channelMembers[channelID], userID)
 	}
+	h.mu.Unlock()
+
+	h.publish(clusterMessage{Kind: clusterMembers, ChannelID: channelID})
 }
 
 func (h *Hub) getChannelMembers(channelID string) map[string]bool {
@@ -465,28 +541,30 @@ func (h *Hub) GetEventsSince(workspaceID, lastEventID string, memberChannelIDs [
 	return events, rows.Err()
 }
 
+// GetConnectedUserIDs returns the users connected to any instance.
 func (h *Hub) GetConnectedUserIDs(workspaceID string) []string {
+	set := make(map[string]bool)
 	h.mu.RLock()
-	defer h.mu.RUnlock()
+	for userID := range h.workspaces[workspaceID] {
+		set[userID] = true
+	}
+	h.mu.RUnlock()
+	h.remoteUserIDs(workspaceID, set)
 
-	userIDs := []string{}
-	if workspace, ok := h.workspaces[workspaceID]; ok {
-		for userID := range workspace {
-			userIDs = append(userIDs, userID)
-		}
+	userIDs := make([]string, 0, len(set))
+	for userID := range set {
+		userIDs = append(userIDs, userID)
 	}
 	return userIDs
 }
 
+// IsUserConnected reports whether the user has a connection to any instance.
 func (h *Hub) IsUserConnected(workspaceID, userID string) bool {
 	h.mu.RLock()
-	defer h.mu.RUnlock()
+	_, connected := h.workspaces[workspaceID][userID]
+	h.mu.RUnlock()
 
-	if workspace, ok := h.workspaces[workspaceID]; ok {
-		_, connected := workspace[userID]
-		return connected
-	}
-	return false
+	return connected || h.isRemoteConnected(workspaceID, userID)
 }
 
 // IsUserOnline is an alias for IsUserConnected
@@ -494,9 +572,14 @@ func (h *Hub) IsUserOnline(workspaceID, userID string) bool {
 	return h.IsUserConnected(workspaceID, userID)
 }
 
-// DisconnectUserClients forcefully disconnects all SSE clients for a user in a workspace.
-// Used when a user is banned to immediately terminate their connections.
+// DisconnectUserClients forcefully disconnects all SSE clients for a user in a workspace,
+// on every instance. Used when a user is banned to immediately terminate their connections.
 func (h *Hub) DisconnectUserClients(workspaceID, userID string) {
+	h.disconnectLocalClients(workspaceID, userID)
+	h.publish(clusterMessage{Kind: clusterDisconnect, WorkspaceID: workspaceID, UserID: userID})
+}
+
+func (h *Hub) disconnectLocalClients(workspaceID, userID string) {
 	h.mu.RLock()
 	var clientsToClose []*Client
 	if workspace, ok := h.workspaces[workspaceID]; ok {
@@ -0,0 +1,355 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/enzyme/server/internal/auth"
+	"github.com/enzyme/server/internal/openapi"
+	"github.com/enzyme/server/internal/workspace"
+	"github.com/go-chi/chi/v5"
+	"github.com/oklog/ulid/v2"
+)
+
+// Frame types clients send over a WebSocket connection. The first frame must
+// be a hello; the rest may come in any order.
+const (
+	WSHello        = "hello"
+	WSTypingStart  = EventTypingStart
+	WSTypingStop   = EventTypingStop
+	WSChannelRead  = EventChannelRead
+	WSPresencePing = "presence.ping"
+
+	// wsError is sent to the client when one of its frames can't be handled.
+	wsError = "error"
+)
+
+const (
+	wsHelloTimeout = 10 * time.Second
+	wsWriteTimeout = 10 * time.Second
+	wsReadLimit    = 4096
+)
+
+var (
+	errUnknownFrame     = errors.New("unknown frame type")
+	errMissingChannelID = errors.New("channel_id is required")
+	errBanned           = errors.New("banned from workspace")
+)
+
+// wsClientFrame is a frame sent by the client. Fields not used by the frame's
+// type are ignored.
+type wsClientFrame struct {
+	Type        string `json:"type"`
+	Token       string `json:"token,omitempty"`
+	LastEventID string `json:"last_event_id,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	MessageID   string `json:"message_id,omitempty"`
+}
+
+type wsErrorData struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// WebSocket streams the same events as Events over a WebSocket, and accepts
+// typing, read and presence updates from the client on the same connection.
+//
+// The client opens with a hello frame carrying its session token, since
+// browsers can't set an Authorization header on a WebSocket, and optionally
+// the ID of the last event it saw (the equivalent of SSE's Last-Event-ID
+// header). The server answers with the connected and presence.initial
+// events, replays anything missed, and from then on sends one event per text
+// frame. Clients that can send an Authorization header may do so instead of
+// putting the token in the hello frame.
+func (h *Handler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	workspaceID := chi.URLParam(r, "wid")
+	userID := auth.GetUserID(r.Context())
+
+	// With a header the handshake itself can be refused, as for SSE
+	if userID != "" {
+		if err := h.checkWSAccess(r.Context(), workspaceID, userID); err != nil {
+			switch {
+			case errors.Is(err, workspace.ErrNotAMember):
+				http.Error(w, "Not a member of this workspace", http.StatusForbidden)
+			case errors.Is(err, errBanned):
+				http.Error(w, "You are banned from this workspace", http.StatusForbidden)
+			default:
+				http.Error(w, "Internal error", http.StatusInternalServerError)
+			}
+			return
+		}
+	}
+
+	// The server's timeouts would otherwise carry over to the hijacked connection
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	// Browsers are held to the same origins as the CORS policy
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: h.originPatterns(),
+	})
+	if err != nil {
+		// Accept has already written the error response
+		return
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(wsReadLimit)
+
+	// The request context isn't tied to the hijacked connection, so the
+	// connection's lifetime is tracked with our own
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	hello, err := readHello(ctx, conn)
+	if err != nil {
+		conn.Close(websocket.StatusPolicyViolation, "expected a hello frame")
+		return
+	}
+	if userID == "" {
+		userID, err = h.sessions.Validate(hello.Token)
+		if hello.Token == "" || err != nil || userID == "" {
+			conn.Close(websocket.StatusPolicyViolation, "authentication required")
+			return
+		}
+		if err := h.checkWSAccess(ctx, workspaceID, userID); err != nil {
+			switch {
+			case errors.Is(err, workspace.ErrNotAMember):
+				conn.Close(websocket.StatusPolicyViolation, "not a member of this workspace")
+			case errors.Is(err, errBanned):
+				conn.Close(websocket.StatusPolicyViolation, "banned from this workspace")
+			default:
+				conn.Close(websocket.StatusInternalError, "internal error")
+			}
+			return
+		}
+	}
+
+	client := &Client{
+		ID:          ulid.Make().String(),
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		Send:        make(chan SerializedEvent, h.clientBufferSize),
+		Done:        make(chan struct{}),
+	}
+
+	h.hub.Register(client)
+	defer h.hub.Unregister(client)
+
+	if err := h.writeLocalWSEvent(ctx, conn, NewConnectedEvent(openapi.ConnectedData{ClientId: client.ID})); err != nil {
+		return
+	}
+
+	onlineUserIDs := h.hub.GetConnectedUserIDs(workspaceID)
+	if err := h.writeLocalWSEvent(ctx, conn, NewPresenceInitialEvent(openapi.PresenceInitialData{
+		OnlineUserIds: onlineUserIDs,
+	})); err != nil {
+		return
+	}
+
+	// Handle reconnection - replay missed events
+	if hello.LastEventID != "" {
+		channelIDs, _ := h.channelRepo.ListMemberChannelIDs(ctx, workspaceID, userID)
+		events, err := h.hub.GetEventsSince(workspaceID, hello.LastEventID, channelIDs)
+		if err == nil {
+			for _, event := range events {
+				if err := h.writeLocalWSEvent(ctx, conn, event); err != nil {
+					return
+				}
+			}
+		}
+	}
+
+	go func() {
+		defer cancel()
+		h.readWebSocket(ctx, conn, client)
+	}()
+
+	interval := time.Duration(h.heartbeatInterval.Load())
+	heartbeat := time.NewTicker(interval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-client.Done:
+			conn.Close(websocket.StatusPolicyViolation, "disconnected by server")
+			return
+		case event := <-client.Send:
+			if err := writeWS(ctx, conn, event.Data()); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := h.writeLocalWSEvent(ctx, conn, NewHeartbeatEvent(openapi.HeartbeatData{Timestamp: time.Now().Unix()})); err != nil {
+				return
+			}
+			if d := time.Duration(h.heartbeatInterval.Load()); d != interval {
+				interval = d
+				heartbeat.Reset(interval)
+			}
+		}
+	}
+}
+
+// checkWSAccess returns workspace.ErrNotAMember or errBanned unless the user
+// may connect to the workspace. Like the ban check middleware, it fails open
+// if bans can't be looked up.
+func (h *Handler) checkWSAccess(ctx context.Context, workspaceID, userID string) error {
+	if _, err := h.workspaceRepo.GetMembership(ctx, userID, workspaceID); err != nil {
+		return err
+	}
+	ban, err := h.moderationRepo.GetActiveBan(ctx, workspaceID, userID)
+	if err != nil {
+		slog.Error("ban check failed", "error", err, "workspace", workspaceID, "user", userID)
+		return nil
+	}
+	if ban != nil {
+		return errBanned
+	}
+	return nil
+}
+
+func readHello(ctx context.Context, conn *websocket.Conn) (wsClientFrame, error) {
+	ctx, cancel := context.WithTimeout(ctx, wsHelloTimeout)
+	defer cancel()
+
+	var frame wsClientFrame
+	_, data, err := conn.Read(ctx)
+	if err != nil {
+		return frame, err
+	}
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return frame, err
+	}
+	if frame.Type != WSHello {
+		return frame, errUnknownFrame
+	}
+	return frame, nil
+}
+
+// readWebSocket handles frames from the client until the connection closes.
+// Frames that can't be handled are answered with an error frame rather than
+// closing the connection.
+func (h *Handler) readWebSocket(ctx context.Context, conn *websocket.Conn, client *Client) {
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		var frame wsClientFrame
+		if err := json.Unmarshal(data, &frame); err != nil {
+			h.writeWSError(ctx, conn, "INVALID_JSON", "Invalid frame")
+			continue
+		}
+		if err := h.handleClientFrame(ctx, client, frame); err != nil {
+			switch {
+			case errors.Is(err, errUnknownFrame):
+				h.writeWSError(ctx, conn, "INVALID_REQUEST", "Unknown frame type")
+			case errors.Is(err, errMissingChannelID):
+				h.writeWSError(ctx, conn, "INVALID_REQUEST", "channel_id is required")
+			case errors.Is(err, errNotWorkspaceMember):
+				h.writeWSError(ctx, conn, "NOT_A_MEMBER", "Not a member of this workspace")
+			case errors.Is(err, errChannelNotFound):
+				h.writeWSError(ctx, conn, "NOT_FOUND", "Channel not found")
+			case errors.Is(err, errNotChannelMember):
+				h.writeWSError(ctx, conn, "NOT_A_MEMBER", "Not a member of this channel")
+			default:
+				slog.Error("failed to handle websocket frame", "type", frame.Type, "error", err)
+				h.writeWSError(ctx, conn, "INTERNAL_ERROR", "Internal error")
+			}
+		}
+	}
+}
+
+func (h *Handler) handleClientFrame(ctx context.Context, client *Client, frame wsClientFrame) error {
+	switch frame.Type {
+	case WSTypingStart, WSTypingStop:
+		if frame.ChannelID == "" {
+			return errMissingChannelID
+		}
+		if err := h.checkChannelAccess(ctx, client.WorkspaceID, client.UserID, frame.ChannelID); err != nil {
+			return err
+		}
+		data := openapi.TypingEventData{UserId: client.UserID, ChannelId: frame.ChannelID}
+		if frame.Type == WSTypingStart {
+			h.hub.BroadcastToChannel(client.WorkspaceID, frame.ChannelID, NewTypingStartEvent(data))
+		} else {
+			h.hub.BroadcastToChannel(client.WorkspaceID, frame.ChannelID, NewTypingStopEvent(data))
+		}
+		return nil
+
+	case WSChannelRead:
+		if frame.ChannelID == "" {
+			return errMissingChannelID
+		}
+		if err := h.checkChannelAccess(ctx, client.WorkspaceID, client.UserID, frame.ChannelID); err != nil {
+			return err
+		}
+		return h.markRead(ctx, client, frame.ChannelID, frame.MessageID)
+
+	case WSPresencePing:
+		if h.presence != nil {
+			h.presence.SetOnline(client.WorkspaceID, client.UserID)
+		}
+		return nil
+	}
+	return errUnknownFrame
+}
+
+// markRead moves the user's read marker in a channel to the given message,
+// or to the latest one if none is given, like the mark-read endpoint.
+func (h *Handler) markRead(ctx context.Context, client *Client, channelID, messageID string) error {
+	if messageID == "" {
+		var err error
+		messageID, err = h.channelRepo.GetLatestMessageID(ctx, channelID)
+		if err != nil {
+			return err
+		}
+		// No messages to mark as read
+		if messageID == "" {
+			return nil
+		}
+	}
+
+	if err := h.channelRepo.UpdateLastRead(ctx, client.UserID, channelID, messageID); err != nil {
+		return err
+	}
+
+	// Broadcast to user's clients, including this one
+	h.hub.BroadcastToUser(client.WorkspaceID, client.UserID, NewChannelReadEvent(openapi.ChannelReadEventData{
+		ChannelId:         channelID,
+		LastReadMessageId: messageID,
+	}))
+	return nil
+}
+
+// writeLocalWSEvent serializes and writes an event generated locally (not from broadcast).
+func (h *Handler) writeLocalWSEvent(ctx context.Context, conn *websocket.Conn, event Event) error {
+	serialized, err := event.Serialize()
+	if err != nil {
+		slog.Error("failed to serialize local websocket event", "type", event.Type, "error", err)
+		return nil
+	}
+	return writeWS(ctx, conn, serialized.Data())
+}
+
+func (h *Handler) writeWSError(ctx context.Context, conn *websocket.Conn, code, message string) {
+	data, err := json.Marshal(Event{Type: wsError, Data: wsErrorData{Code: code, Message: message}})
+	if err != nil {
+		return
+	}
+	_ = writeWS(ctx, conn, data)
+}
+
+func writeWS(ctx context.Context, conn *websocket.Conn, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, wsWriteTimeout)
+	defer cancel()
+	return conn.Write(ctx, websocket.MessageText, data)
+}
@@ -0,0 +1,473 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/enzyme/server/internal/auth"
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/moderation"
+	"github.com/enzyme/server/internal/testutil"
+	"github.com/enzyme/server/internal/workspace"
+	"github.com/go-chi/chi/v5"
+)
+
+type fakePresence struct {
+	mu    sync.Mutex
+	pings []string
+}
+
+func (p *fakePresence) SetOnline(workspaceID, userID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pings = append(p.pings, workspaceID+"/"+userID)
+}
+
+func (p *fakePresence) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.pings)
+}
+
+// startWSServer serves the WebSocket endpoint, authenticating requests as
+// the user named in the X-User-ID header.
+func startWSServer(t *testing.T, h *Handler) *httptest.Server {
+	t.Helper()
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.WithUserID(r.Context(), r.Header.Get("X-User-ID"))))
+		})
+	})
+	r.Get("/workspaces/{wid}/ws", h.WebSocket)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func dialWS(t *testing.T, srv *httptest.Server, workspaceID, userID string) *websocket.Conn {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	conn, _, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/workspaces/"+workspaceID+"/ws", &websocket.DialOptions{
+		HTTPHeader: http.Header{"X-User-ID": {userID}},
+	})
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+	return conn
+}
+
+func sendWS(t *testing.T, conn *websocket.Conn, frame wsClientFrame) {
+	t.Helper()
+	data, _ := json.Marshal(frame)
+	if err := conn.Write(context.Background(), websocket.MessageText, data); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+type wsTestEvent struct {
+	ID   string          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// receiveWS waits for a frame of the given type, skipping others.
+func receiveWS(t *testing.T, conn *websocket.Conn, eventType string) wsTestEvent {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			t.Fatalf("waiting for %s: %v", eventType, err)
+		}
+		var event wsTestEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			t.Fatalf("decoding %s: %v", data, err)
+		}
+		if event.Type == eventType {
+			return event
+		}
+	}
+}
+
+// helloWS completes the handshake and waits until the hub has registered the
+// connection, so that broadcasts reach it.
+func helloWS(t *testing.T, h *Handler, conn *websocket.Conn) {
+	t.Helper()
+	sendWS(t, conn, wsClientFrame{Type: WSHello})
+	connected := receiveWS(t, conn, EventConnected)
+	var data struct {
+		ClientID string `json:"client_id"`
+	}
+	_ = json.Unmarshal(connected.Data, &data)
+	receiveWS(t, conn, EventPresenceInitial)
+	eventually(t, "client registration", func() bool {
+		h.hub.mu.RLock()
+		defer h.hub.mu.RUnlock()
+		for _, users := range h.hub.workspaces {
+			for _, clients := range users {
+				for _, c := range clients {
+					if c.ID == data.ClientID {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	})
+}
+
+func newWSTestHandler(t *testing.T) (*Handler, *fakePresence) {
+	t.Helper()
+	db := testutil.TestDB(t)
+	hub := NewHub(db, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go hub.Run(ctx)
+
+	presence := &fakePresence{}
+	h := NewHandler(hub, workspace.NewRepository(db), channel.NewRepository(db), moderation.NewRepository(db),
+		auth.NewSessionStore(db, time.Hour), presence, time.Hour, []string{"https://app.example.com"}, 16)
+	return h, presence
+}
+
+// dialBrowserWS connects the way a browser does: without an Authorization
+// header (or X-User-ID), from the given origin.
+func dialBrowserWS(t *testing.T, srv *httptest.Server, workspaceID, origin string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	conn, resp, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/workspaces/"+workspaceID+"/ws", &websocket.DialOptions{
+		HTTPHeader: http.Header{"Origin": {origin}},
+	})
+	if err == nil {
+		t.Cleanup(func() { conn.CloseNow() })
+	}
+	return conn, resp, err
+}
+
+func sessionToken(t *testing.T, h *Handler, userID string) string {
+	t.Helper()
+	token, err := h.sessions.(*auth.SessionStore).Create(userID)
+	if err != nil {
+		t.Fatalf("creating session: %v", err)
+	}
+	return token
+}
+
+// expectClose waits for the server to close the connection with a policy
+// violation.
+func expectClose(t *testing.T, conn *websocket.Conn) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for {
+		_, _, err := conn.Read(ctx)
+		if err == nil {
+			continue
+		}
+		if websocket.CloseStatus(err) != websocket.StatusPolicyViolation {
+			t.Fatalf("err = %v, want a policy violation close", err)
+		}
+		return
+	}
+}
+
+func TestWebSocket_Handshake(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	conn := dialWS(t, srv, ws.ID, alice.ID)
+	sendWS(t, conn, wsClientFrame{Type: WSHello})
+
+	connected := receiveWS(t, conn, EventConnected)
+	if !strings.Contains(string(connected.Data), "client_id") {
+		t.Errorf("connected = %s, want a client_id", connected.Data)
+	}
+	receiveWS(t, conn, EventPresenceInitial)
+}
+
+func TestWebSocket_RequiresHello(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	conn := dialWS(t, srv, ws.ID, alice.ID)
+	sendWS(t, conn, wsClientFrame{Type: WSPresencePing})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, _, err := conn.Read(ctx)
+	if websocket.CloseStatus(err) != websocket.StatusPolicyViolation {
+		t.Fatalf("err = %v, want a policy violation close", err)
+	}
+}
+
+func TestWebSocket_NotAMember(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	bob := testutil.CreateTestUser(t, db, "bob@example.com", "Bob")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, resp, err := websocket.Dial(ctx, "ws"+strings.TrimPrefix(srv.URL, "http")+"/workspaces/"+ws.ID+"/ws", &websocket.DialOptions{
+		HTTPHeader: http.Header{"X-User-ID": {bob.ID}},
+	})
+	if err == nil {
+		t.Fatal("expected dial to fail")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("resp = %v, want 403", resp)
+	}
+}
+
+func TestWebSocket_HelloToken(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	conn, _, err := dialBrowserWS(t, srv, ws.ID, srv.URL)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	sendWS(t, conn, wsClientFrame{Type: WSHello, Token: sessionToken(t, h, alice.ID)})
+	receiveWS(t, conn, EventConnected)
+	receiveWS(t, conn, EventPresenceInitial)
+
+	h.hub.BroadcastToUser(ws.ID, alice.ID, NewChannelsInvalidateEvent())
+	receiveWS(t, conn, EventChannelsInvalidate)
+}
+
+func TestWebSocket_HelloTokenRejected(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	bob := testutil.CreateTestUser(t, db, "bob@example.com", "Bob")
+	carol := testutil.CreateTestUser(t, db, "carol@example.com", "Carol")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := db.Exec(`
+		INSERT INTO workspace_memberships (id, user_id, workspace_id, role, created_at, updated_at)
+		VALUES ('m-carol', ?, ?, 'member', ?, ?)
+	`, carol.ID, ws.ID, now, now); err != nil {
+		t.Fatalf("adding carol to workspace: %v", err)
+	}
+	if err := h.moderationRepo.CreateBan(context.Background(), &moderation.Ban{WorkspaceID: ws.ID, UserID: carol.ID}); err != nil {
+		t.Fatalf("CreateBan: %v", err)
+	}
+	srv := startWSServer(t, h)
+
+	for name, token := range map[string]string{
+		"missing token": "",
+		"invalid token": "bogus",
+		"not a member":  sessionToken(t, h, bob.ID),
+		"banned":        sessionToken(t, h, carol.ID),
+	} {
+		t.Run(name, func(t *testing.T) {
+			conn, _, err := dialBrowserWS(t, srv, ws.ID, srv.URL)
+			if err != nil {
+				t.Fatalf("dial: %v", err)
+			}
+			sendWS(t, conn, wsClientFrame{Type: WSHello, Token: token})
+			expectClose(t, conn)
+		})
+	}
+}
+
+func TestWebSocket_Origin(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	if _, _, err := dialBrowserWS(t, srv, ws.ID, "https://app.example.com"); err != nil {
+		t.Fatalf("allowed origin: %v", err)
+	}
+	_, resp, err := dialBrowserWS(t, srv, ws.ID, "https://evil.example")
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("other origin: resp = %v, err = %v, want 403", resp, err)
+	}
+
+	// Reloaded origins apply to new connections
+	h.SetAllowedOrigins([]string{"https://*.example.org"})
+	if _, _, err := dialBrowserWS(t, srv, ws.ID, "https://app.example.com"); err == nil {
+		t.Fatal("removed origin still allowed")
+	}
+	if _, _, err := dialBrowserWS(t, srv, ws.ID, "https://chat.example.org"); err != nil {
+		t.Fatalf("wildcard origin: %v", err)
+	}
+}
+
+func TestWebSocket_Resume(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, id := range []string{"01-seen", "02-missed"} {
+		_, err := db.Exec(`
+			INSERT INTO workspace_events (id, workspace_id, event_type, payload, created_at)
+			VALUES (?, ?, ?, ?, ?)
+		`, id, ws.ID, EventWorkspaceUpdated, `{}`, now)
+		if err != nil {
+			t.Fatalf("inserting event %s: %v", id, err)
+		}
+	}
+
+	conn := dialWS(t, srv, ws.ID, alice.ID)
+	sendWS(t, conn, wsClientFrame{Type: WSHello, LastEventID: "01-seen"})
+
+	replayed := receiveWS(t, conn, EventWorkspaceUpdated)
+	if replayed.ID != "02-missed" {
+		t.Errorf("replayed event %s, want 02-missed", replayed.ID)
+	}
+}
+
+func TestWebSocket_ReceivesBroadcasts(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	conn := dialWS(t, srv, ws.ID, alice.ID)
+	helloWS(t, h, conn)
+
+	h.hub.BroadcastToUser(ws.ID, alice.ID, NewChannelsInvalidateEvent())
+	receiveWS(t, conn, EventChannelsInvalidate)
+}
+
+func TestWebSocket_Typing(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, alice.ID, "general", "public")
+	srv := startWSServer(t, h)
+
+	conn := dialWS(t, srv, ws.ID, alice.ID)
+	helloWS(t, h, conn)
+
+	sendWS(t, conn, wsClientFrame{Type: WSTypingStart, ChannelID: ch.ID})
+	typing := receiveWS(t, conn, EventTypingStart)
+	if !strings.Contains(string(typing.Data), ch.ID) {
+		t.Errorf("typing.start = %s, want channel %s", typing.Data, ch.ID)
+	}
+
+	sendWS(t, conn, wsClientFrame{Type: WSTypingStop, ChannelID: ch.ID})
+	receiveWS(t, conn, EventTypingStop)
+}
+
+func TestWebSocket_TypingInPrivateChannel(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	bob := testutil.CreateTestUser(t, db, "bob@example.com", "Bob")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	_, err := db.Exec(`
+		INSERT INTO workspace_memberships (id, user_id, workspace_id, role, created_at, updated_at)
+		VALUES ('m-bob', ?, ?, 'member', ?, ?)
+	`, bob.ID, ws.ID, time.Now().UTC().Format(time.RFC3339), time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		t.Fatalf("adding bob to workspace: %v", err)
+	}
+	secret := testutil.CreateTestChannel(t, db, ws.ID, alice.ID, "secret", "private")
+	srv := startWSServer(t, h)
+
+	conn := dialWS(t, srv, ws.ID, bob.ID)
+	helloWS(t, h, conn)
+
+	sendWS(t, conn, wsClientFrame{Type: WSTypingStart, ChannelID: secret.ID})
+	frame := receiveWS(t, conn, wsError)
+	if !strings.Contains(string(frame.Data), "NOT_A_MEMBER") {
+		t.Errorf("error = %s, want NOT_A_MEMBER", frame.Data)
+	}
+}
+
+func TestWebSocket_MarkRead(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, alice.ID, "general", "public")
+	msg := testutil.CreateTestMessage(t, db, ch.ID, alice.ID, "hello")
+	srv := startWSServer(t, h)
+
+	conn := dialWS(t, srv, ws.ID, alice.ID)
+	helloWS(t, h, conn)
+
+	sendWS(t, conn, wsClientFrame{Type: WSChannelRead, ChannelID: ch.ID})
+	read := receiveWS(t, conn, EventChannelRead)
+	if !strings.Contains(string(read.Data), msg.ID) {
+		t.Errorf("channel.read = %s, want message %s", read.Data, msg.ID)
+	}
+
+	var lastRead string
+	if err := db.QueryRow(`
+		SELECT last_read_message_id FROM channel_memberships WHERE user_id = ? AND channel_id = ?
+	`, alice.ID, ch.ID).Scan(&lastRead); err != nil {
+		t.Fatalf("querying last read: %v", err)
+	}
+	if lastRead != msg.ID {
+		t.Errorf("last_read_message_id = %q, want %q", lastRead, msg.ID)
+	}
+}
+
+func TestWebSocket_PresencePing(t *testing.T) {
+	h, presence := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	conn := dialWS(t, srv, ws.ID, alice.ID)
+	helloWS(t, h, conn)
+
+	sendWS(t, conn, wsClientFrame{Type: WSPresencePing})
+	eventually(t, "presence ping", func() bool { return presence.count() == 1 })
+}
+
+func TestWebSocket_UnknownFrame(t *testing.T) {
+	h, _ := newWSTestHandler(t)
+	db := h.hub.db
+	alice := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, alice.ID, "Test Workspace")
+	srv := startWSServer(t, h)
+
+	conn := dialWS(t, srv, ws.ID, alice.ID)
+	helloWS(t, h, conn)
+
+	sendWS(t, conn, wsClientFrame{Type: "bogus"})
+	frame := receiveWS(t, conn, wsError)
+	if !strings.Contains(string(frame.Data), "INVALID_REQUEST") {
+		t.Errorf("error = %s, want INVALID_REQUEST", frame.Data)
+	}
+
+	// The connection stays usable
+	sendWS(t, conn, wsClientFrame{Type: WSPresencePing})
+	h.hub.BroadcastToUser(ws.ID, alice.ID, NewChannelsInvalidateEvent())
+	receiveWS(t, conn, EventChannelsInvalidate)
+}
@@ -0,0 +1,764 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enzyme/server/internal/config"
+)
+
+// Encrypted objects are laid out as a fixed-size header followed by a
+// sequence of AES-GCM sealed chunks:
+//
+//	magic "ENZE" | version | master key ID (8) | wrapped data key (60)
+//	| nonce prefix (7) | chunk size (4) | plaintext size (8) | chunks...
+//
+// Each object gets a random 256-bit data key, sealed ("wrapped") with the
+// master key. Chunk i is sealed with nonce = prefix || i (4 bytes) || last
+// flag (1 byte), so reordering, truncation and extension are all detected.
+// Because the wrapped key has a fixed size, rotating the master key rewrites
+// only the header and leaves the ciphertext untouched.
+//
+// The master key ID is authenticated as additional data of the wrapped key.
+// Every chunk is sealed with the rest of the header (magic, version, nonce
+// prefix, chunk size and plaintext size) as additional data, so none of it
+// can be altered either. Version 1 objects, whose chunks carry no additional
+// data, are still read.
+//
+// Objects without the header, such as files stored before encryption was
+// enabled, are read back unchanged; "enzyme storage encrypt-existing"
+// encrypts them in place.
+const (
+	encMagic          = "ENZE"
+	encVersion        = 2
+	encLegacyVersion  = 1
+	encKeyIDSize      = 8
+	encWrappedKeySize = 12 + 32 + 16 // nonce + data key + GCM tag
+	encNoncePrefix    = 7
+	encHeaderSize     = len(encMagic) + 1 + encKeyIDSize + encWrappedKeySize + encNoncePrefix + 4 + 8
+	encTagSize        = 16
+
+	// DefaultEncryptionChunkSize is the plaintext size of each sealed chunk.
+	DefaultEncryptionChunkSize = 64 * 1024
+
+	// unknownSize marks objects written without a known plaintext size.
+	unknownSize = ^uint64(0)
+)
+
+var (
+	// ErrUnknownMasterKey is returned when an object was encrypted with a
+	// master key that is neither the current nor a previous key.
+	ErrUnknownMasterKey = errors.New("object encrypted with unknown master key")
+	// ErrCorruptObject is returned when an object fails authentication.
+	ErrCorruptObject = errors.New("encrypted object is corrupt or has been tampered with")
+	// ErrNotEncrypted is returned by Rewrap for objects stored in plaintext.
+	ErrNotEncrypted = errors.New("object is not encrypted")
+)
+
+// Encrypted is a Storage decorator that encrypts objects at rest with
+// per-object data keys wrapped by a master key.
+//
+// Objects are never presigned: SignedURL returns "" so callers fall back to
+// HMAC-signed server URLs, and Serve decrypts through the server regardless
+// of the underlying backend.
+type Encrypted struct {
+	inner     Storage
+	keyID     [encKeyIDSize]byte
+	master    cipher.AEAD
+	keys      map[[encKeyIDSize]byte]cipher.AEAD // current and previous master keys
+	chunkSize int
+}
+
+// NewEncrypted wraps inner with envelope encryption. masterKey must be 32
+// bytes. previousKeys are accepted for decryption only, so objects written
+// before a key rotation stay readable until they are re-wrapped.
+func NewEncrypted(inner Storage, masterKey []byte, previousKeys ...[]byte) (*Encrypted, error) {
+	e := &Encrypted{
+		inner:     inner,
+		keys:      make(map[[encKeyIDSize]byte]cipher.AEAD),
+		chunkSize: DefaultEncryptionChunkSize,
+	}
+	for i, k := range append([][]byte{masterKey}, previousKeys...) {
+		aead, err := newGCM(k)
+		if err != nil {
+			return nil, fmt.Errorf("master key %d: %w", i, err)
+		}
+		id := MasterKeyID(k)
+		e.keys[id] = aead
+		if i == 0 {
+			e.keyID = id
+			e.master = aead
+		}
+	}
+	return e, nil
+}
+
+// ParseMasterKey decodes a base64-encoded 32-byte master key.
+func ParseMasterKey(s string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("master key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("master key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// LoadMasterKeys returns the current master key followed by any previous keys
+// from cfg. The current key is read from cfg.KeyFile when cfg.Key is empty.
+func LoadMasterKeys(cfg config.EncryptionConfig) (current []byte, previous [][]byte, err error) {
+	encoded := cfg.Key
+	if encoded == "" && cfg.KeyFile != "" {
+		data, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading master key file: %w", err)
+		}
+		encoded = string(data)
+	}
+	if current, err = ParseMasterKey(encoded); err != nil {
+		return nil, nil, err
+	}
+	for i, k := range cfg.PreviousKeys {
+		key, err := ParseMasterKey(k)
+		if err != nil {
+			return nil, nil, fmt.Errorf("previous key %d: %w", i, err)
+		}
+		previous = append(previous, key)
+	}
+	return current, previous, nil
+}
+
+// GenerateMasterKey returns a new random base64-encoded master key.
+func GenerateMasterKey() string {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// MasterKeyID returns the identifier stored in object headers for a master key.
+func MasterKeyID(key []byte) [encKeyIDSize]byte {
+	sum := sha256.Sum256(append([]byte("enzyme-storage-key-id:"), key...))
+	var id [encKeyIDSize]byte
+	copy(id[:], sum[:])
+	return id
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptedSize returns the stored size of a plaintext of the given size.
+func (e *Encrypted) EncryptedSize(size int64) int64 {
+	return encryptedSize(size, e.chunkSize)
+}
+
+func (e *Encrypted) Put(ctx context.Context, key string, r io.Reader, size int64, _ string) error {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return err
+	}
+
+	header, err := e.newHeader(dataKey, size)
+	if err != nil {
+		return err
+	}
+	var prefix [encNoncePrefix]byte
+	copy(prefix[:], header[encHeaderSize-8-4-encNoncePrefix:])
+
+	storedSize := int64(-1)
+	if size >= 0 {
+		storedSize = e.EncryptedSize(size)
+	}
+	body := io.MultiReader(bytes.NewReader(header), &encryptReader{
+		src:       r,
+		aead:      aead,
+		prefix:    prefix,
+		aad:       chunkAAD(header),
+		chunkSize: e.chunkSize,
+	})
+	// The stored bytes are ciphertext; the real content type is derived from
+	// the key's extension when serving.
+	return e.inner.Put(ctx, key, body, storedSize, "application/octet-stream")
+}
+
+func (e *Encrypted) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	rc, err := e.inner.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	dr, _, err := e.openObject(rc)
+	if err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{dr, rc}, nil
+}
+
+func (e *Encrypted) Delete(ctx context.Context, key string) error {
+	return e.inner.Delete(ctx, key)
+}
+
+// Serve decrypts the object and streams it to the client. A single byte
+// range is served by seeking to the first chunk that covers it, so only the
+// chunks the client asked for are read and decrypted. Plaintext objects and
+// objects of unknown size are always sent whole.
+func (e *Encrypted) Serve(w http.ResponseWriter, r *http.Request, key string) {
+	rc, err := e.inner.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			http.NotFound(w, r)
+			return
+		}
+		slog.Error("failed to read encrypted object", "key", key, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	body, size, err := e.openObject(rc)
+	if err != nil {
+		slog.Error("failed to decrypt object", "key", key, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	dr, seekable := body.(*decryptReader)
+	seekable = seekable && size != unknownSize
+	if seekable {
+		w.Header().Set("Accept-Ranges", "bytes")
+	}
+	// Objects are immutable, so If-Range can never fail to match; ranges of
+	// an empty object are ignored as net/http does.
+	if ra := r.Header.Get("Range"); seekable && ra != "" && size > 0 {
+		start, length, ok, err := parseByteRange(ra, size)
+		if err != nil {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+			http.Error(w, "Requested range not satisfiable", http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		if ok {
+			if err := dr.seek(start); err != nil {
+				slog.Error("failed to seek encrypted object", "key", key, "error", err)
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size))
+			body = io.LimitReader(dr, int64(length))
+			size = length
+			status = http.StatusPartialContent
+		}
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	if size != unknownSize {
+		w.Header().Set("Content-Length", strconv.FormatUint(size, 10))
+	}
+	w.WriteHeader(status)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		// Headers are already sent; the client sees a truncated body.
+		slog.Error("failed to stream decrypted object", "key", key, "error", err)
+	}
+}
+
+// errInvalidRange is returned by parseByteRange for malformed or
+// unsatisfiable ranges.
+var errInvalidRange = errors.New("invalid range")
+
+// parseByteRange parses a Range header against an object of the given size.
+// It reports false, without an error, for ranges it does not serve (other
+// units, or several ranges at once), which are answered with the whole
+// object instead.
+func parseByteRange(header string, size uint64) (start, length uint64, ok bool, err error) {
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false, nil
+	}
+	first, last, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return 0, 0, false, errInvalidRange
+	}
+	if first == "" {
+		// Suffix range: the last n bytes
+		n, err := strconv.ParseUint(last, 10, 64)
+		if err != nil || n == 0 {
+			return 0, 0, false, errInvalidRange
+		}
+		n = min(n, size)
+		return size - n, n, true, nil
+	}
+	start, err = strconv.ParseUint(first, 10, 64)
+	if err != nil || start >= size {
+		return 0, 0, false, errInvalidRange
+	}
+	end := size - 1
+	if last != "" {
+		if end, err = strconv.ParseUint(last, 10, 64); err != nil || end < start {
+			return 0, 0, false, errInvalidRange
+		}
+		end = min(end, size-1)
+	}
+	return start, end - start + 1, true, nil
+}
+
+// SignedURL always returns "" so downloads are proxied through the server,
+// which is the only place the data can be decrypted.
+func (e *Encrypted) SignedURL(_ context.Context, _ string, _ time.Duration) (string, error) {
+	return "", nil
+}
+
+// List delegates to the underlying backend if it supports listing.
+func (e *Encrypted) List(ctx context.Context, prefix string, fn func(key string, size int64, modTime time.Time) error) error {
+	l, ok := e.inner.(Lister)
+	if !ok {
+		return ErrListNotSupported
+	}
+	return l.List(ctx, prefix, fn)
+}
+
+// Rewrap re-seals the object's data key with the current master key. The
+// ciphertext body is copied verbatim. It reports false if the object already
+// uses the current key, and ErrNotEncrypted for plaintext objects.
+func (e *Encrypted) Rewrap(ctx context.Context, key string) (bool, error) {
+	rc, err := e.inner.Get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	header, err := readHeader(rc)
+	if err != nil {
+		return false, err
+	}
+	if !isEncrypted(header) {
+		return false, ErrNotEncrypted
+	}
+	keyID, dataKey, err := e.unwrapHeader(header)
+	if err != nil {
+		return false, err
+	}
+	if keyID == e.keyID {
+		return false, nil
+	}
+
+	newHeader := make([]byte, encHeaderSize)
+	copy(newHeader, header)
+	if err := e.sealDataKey(newHeader, dataKey); err != nil {
+		return false, err
+	}
+
+	size := int64(-1)
+	if s := binary.BigEndian.Uint64(header[encHeaderSize-8:]); s != unknownSize {
+		size = encryptedSize(int64(s), int(binary.BigEndian.Uint32(header[encHeaderSize-12:])))
+	}
+	body := io.MultiReader(bytes.NewReader(newHeader), rc)
+	if err := e.inner.Put(ctx, key, body, size, "application/octet-stream"); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RotateAll re-wraps every object in the underlying backend that is not yet
+// sealed with the current master key. Plaintext objects count as unchanged.
+// Objects that fail are logged and counted, so a partial run can simply be
+// repeated.
+func (e *Encrypted) RotateAll(ctx context.Context) (rewrapped, unchanged, failed int, err error) {
+	plaintext := 0
+	err = e.List(ctx, "", func(key string, _ int64, _ time.Time) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		changed, err := e.Rewrap(ctx, key)
+		switch {
+		case errors.Is(err, ErrNotEncrypted):
+			plaintext++
+			unchanged++
+		case err != nil:
+			slog.Error("failed to re-wrap object", "key", key, "error", err)
+			failed++
+		case changed:
+			rewrapped++
+		default:
+			unchanged++
+		}
+		return nil
+	})
+	if plaintext > 0 {
+		slog.Warn("objects are stored unencrypted; run \"enzyme storage encrypt-existing\" to encrypt them", "count", plaintext)
+	}
+	return rewrapped, unchanged, failed, err
+}
+
+// EncryptExisting encrypts, in place, every object in the underlying backend
+// that is still stored in plaintext. Like RotateAll it logs and counts
+// failures, so a partial run can simply be repeated.
+func (e *Encrypted) EncryptExisting(ctx context.Context) (encrypted, skipped, failed int, err error) {
+	err = e.List(ctx, "", func(key string, size int64, _ time.Time) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		changed, err := e.encryptObject(ctx, key, size)
+		switch {
+		case err != nil:
+			slog.Error("failed to encrypt object", "key", key, "error", err)
+			failed++
+		case changed:
+			encrypted++
+		default:
+			skipped++
+		}
+		return nil
+	})
+	return encrypted, skipped, failed, err
+}
+
+// encryptObject replaces a plaintext object with its encryption, reporting
+// false if it is already encrypted. Backends never expose a partially
+// written object, so the plaintext can be streamed into its own replacement.
+func (e *Encrypted) encryptObject(ctx context.Context, key string, size int64) (bool, error) {
+	rc, err := e.inner.Get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	defer rc.Close()
+
+	header, err := readHeader(rc)
+	if err != nil {
+		return false, err
+	}
+	if isEncrypted(header) {
+		return false, nil
+	}
+	if err := e.Put(ctx, key, io.MultiReader(bytes.NewReader(header), rc), size, ""); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func encryptedSize(size int64, chunkSize int) int64 {
+	chunks := (size + int64(chunkSize) - 1) / int64(chunkSize)
+	if chunks == 0 {
+		chunks = 1 // empty objects still carry one (empty) final chunk
+	}
+	return int64(encHeaderSize) + size + chunks*encTagSize
+}
+
+// newHeader builds an object header for a fresh data key.
+func (e *Encrypted) newHeader(dataKey []byte, size int64) ([]byte, error) {
+	h := make([]byte, encHeaderSize)
+	copy(h, encMagic)
+	h[len(encMagic)] = encVersion
+	if err := e.sealDataKey(h, dataKey); err != nil {
+		return nil, err
+	}
+	off := len(encMagic) + 1 + encKeyIDSize + encWrappedKeySize
+	if _, err := rand.Read(h[off : off+encNoncePrefix]); err != nil {
+		return nil, err
+	}
+	off += encNoncePrefix
+	binary.BigEndian.PutUint32(h[off:], uint32(e.chunkSize))
+	off += 4
+	plainSize := unknownSize
+	if size >= 0 {
+		plainSize = uint64(size)
+	}
+	binary.BigEndian.PutUint64(h[off:], plainSize)
+	return h, nil
+}
+
+// sealDataKey writes the current master key ID and the wrapped data key into h.
+func (e *Encrypted) sealDataKey(h, dataKey []byte) error {
+	off := len(encMagic) + 1
+	copy(h[off:], e.keyID[:])
+	off += encKeyIDSize
+	nonce := h[off : off+12]
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	sealed := e.master.Seal(nil, nonce, dataKey, e.keyID[:])
+	copy(h[off+12:], sealed)
+	return nil
+}
+
+// readHeader reads up to a header's worth of bytes from the start of an
+// object. Shorter objects are returned whole.
+func readHeader(r io.Reader) ([]byte, error) {
+	header := make([]byte, encHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	return header[:n], nil
+}
+
+// isEncrypted reports whether h, as returned by readHeader, is the header of
+// an encrypted object.
+func isEncrypted(h []byte) bool {
+	return len(h) == encHeaderSize && string(h[:len(encMagic)]) == encMagic &&
+		(h[len(encMagic)] == encVersion || h[len(encMagic)] == encLegacyVersion)
+}
+
+// chunkAAD returns the additional data every chunk of the object with header
+// h is sealed with: the header minus the master key ID and wrapped key,
+// which change on rotation and are authenticated by the key wrap instead.
+func chunkAAD(h []byte) []byte {
+	if h[len(encMagic)] == encLegacyVersion {
+		return nil
+	}
+	off := len(encMagic) + 1 + encKeyIDSize + encWrappedKeySize
+	aad := make([]byte, 0, len(encMagic)+1+encHeaderSize-off)
+	aad = append(aad, h[:len(encMagic)+1]...)
+	return append(aad, h[off:]...)
+}
+
+// unwrapHeader returns the master key ID and the unwrapped data key of an
+// encrypted object's header.
+func (e *Encrypted) unwrapHeader(h []byte) ([encKeyIDSize]byte, []byte, error) {
+	var keyID [encKeyIDSize]byte
+	off := len(encMagic) + 1
+	copy(keyID[:], h[off:])
+	master, ok := e.keys[keyID]
+	if !ok {
+		return keyID, nil, ErrUnknownMasterKey
+	}
+	off += encKeyIDSize
+	dataKey, err := master.Open(nil, h[off:off+12], h[off+12:off+encWrappedKeySize], keyID[:])
+	if err != nil {
+		return keyID, nil, ErrCorruptObject
+	}
+	return keyID, dataKey, nil
+}
+
+// openObject reads the header from r and returns a reader yielding the
+// plaintext, along with the recorded plaintext size. Plaintext objects are
+// passed through with an unknown size.
+func (e *Encrypted) openObject(r io.Reader) (io.Reader, uint64, error) {
+	header, err := readHeader(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !isEncrypted(header) {
+		return io.MultiReader(bytes.NewReader(header), r), unknownSize, nil
+	}
+	_, dataKey, err := e.unwrapHeader(header)
+	if err != nil {
+		return nil, 0, err
+	}
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	off := len(encMagic) + 1 + encKeyIDSize + encWrappedKeySize
+	dr := &decryptReader{src: r, aead: aead, aad: chunkAAD(header)}
+	copy(dr.prefix[:], header[off:off+encNoncePrefix])
+	off += encNoncePrefix
+	dr.chunkSize = int(binary.BigEndian.Uint32(header[off:]))
+	if dr.chunkSize <= 0 || dr.chunkSize > 16*1024*1024 {
+		return nil, 0, ErrCorruptObject
+	}
+	off += 4
+	return dr, binary.BigEndian.Uint64(header[off:]), nil
+}
+
+func chunkNonce(prefix [encNoncePrefix]byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	copy(nonce, prefix[:])
+	binary.BigEndian.PutUint32(nonce[encNoncePrefix:], counter)
+	if last {
+		nonce[11] = 1
+	}
+	return nonce
+}
+
+// encryptReader seals src chunk by chunk as it is read, so large objects are
+// never held in memory.
+type encryptReader struct {
+	src       io.Reader
+	aead      cipher.AEAD
+	prefix    [encNoncePrefix]byte
+	aad       []byte
+	chunkSize int
+	counter   uint32
+
+	buf     []byte // sealed output not yet returned
+	next    []byte // read-ahead plaintext for the next chunk
+	started bool
+	done    bool
+}
+
+func (er *encryptReader) Read(p []byte) (int, error) {
+	for len(er.buf) == 0 {
+		if er.done {
+			return 0, io.EOF
+		}
+		if err := er.sealNext(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, er.buf)
+	er.buf = er.buf[n:]
+	return n, nil
+}
+
+// sealNext seals the next chunk. One chunk of read-ahead tells us whether the
+// current chunk is the last.
+func (er *encryptReader) sealNext() error {
+	if !er.started {
+		er.started = true
+		chunk, err := er.readChunk()
+		if err != nil {
+			return err
+		}
+		er.next = chunk
+	}
+	current := er.next
+	last := len(current) < er.chunkSize
+	if !last {
+		chunk, err := er.readChunk()
+		if err != nil {
+			return err
+		}
+		er.next = chunk
+		last = len(chunk) == 0
+	}
+	er.buf = er.aead.Seal(er.buf[:0], chunkNonce(er.prefix, er.counter, last), current, er.aad)
+	er.counter++
+	er.done = last
+	return nil
+}
+
+func (er *encryptReader) readChunk() ([]byte, error) {
+	chunk := make([]byte, er.chunkSize)
+	n, err := io.ReadFull(er.src, chunk)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return chunk[:n], nil
+}
+
+// decryptReader authenticates and opens chunks as they are read.
+type decryptReader struct {
+	src       io.Reader
+	aead      cipher.AEAD
+	prefix    [encNoncePrefix]byte
+	aad       []byte
+	chunkSize int
+	counter   uint32
+
+	buf  []byte
+	done bool
+}
+
+func (dr *decryptReader) Read(p []byte) (int, error) {
+	for len(dr.buf) == 0 {
+		if dr.done {
+			return 0, io.EOF
+		}
+		if err := dr.openNext(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, dr.buf)
+	dr.buf = dr.buf[n:]
+	return n, nil
+}
+
+// seek positions a freshly opened reader at plaintext offset off. Whole
+// chunks before off are skipped without being decrypted, seeking the
+// underlying object when it supports it.
+func (dr *decryptReader) seek(off uint64) error {
+	chunk := off / uint64(dr.chunkSize)
+	if chunk > 0 {
+		skip := int64(chunk) * int64(dr.chunkSize+encTagSize)
+		if s, ok := dr.src.(io.Seeker); ok {
+			if _, err := s.Seek(skip, io.SeekCurrent); err != nil {
+				return err
+			}
+		} else if _, err := io.CopyN(io.Discard, dr.src, skip); err != nil {
+			return err
+		}
+		dr.counter = uint32(chunk)
+	}
+	_, err := io.CopyN(io.Discard, dr, int64(off%uint64(dr.chunkSize)))
+	return err
+}
+
+func (dr *decryptReader) openNext() error {
+	sealed := make([]byte, dr.chunkSize+encTagSize)
+	n, err := io.ReadFull(dr.src, sealed)
+	switch {
+	case err == io.EOF:
+		// Stream ended without a final chunk: truncated
+		return ErrCorruptObject
+	case err == io.ErrUnexpectedEOF:
+		// A short frame can only be the final chunk
+		plain, openErr := dr.aead.Open(sealed[:0], chunkNonce(dr.prefix, dr.counter, true), sealed[:n], dr.aad)
+		if openErr != nil {
+			return ErrCorruptObject
+		}
+		dr.buf, dr.done = plain, true
+		return nil
+	case err != nil:
+		return err
+	}
+
+	// A full frame is either an intermediate chunk or a full-sized final chunk
+	if plain, openErr := dr.aead.Open(nil, chunkNonce(dr.prefix, dr.counter, false), sealed, dr.aad); openErr == nil {
+		dr.buf = plain
+		dr.counter++
+		return nil
+	}
+	plain, openErr := dr.aead.Open(nil, chunkNonce(dr.prefix, dr.counter, true), sealed, dr.aad)
+	if openErr != nil {
+		return ErrCorruptObject
+	}
+	// Nothing may follow the final chunk
+	var extra [1]byte
+	if m, _ := dr.src.Read(extra[:]); m > 0 {
+		return ErrCorruptObject
+	}
+	dr.buf, dr.done = plain, true
+	return nil
+}
@@ -0,0 +1,390 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var _ Storage = (*Encrypted)(nil)
+
+func testKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := ParseMasterKey(GenerateMasterKey())
+	if err != nil {
+		t.Fatalf("ParseMasterKey: %v", err)
+	}
+	return key
+}
+
+func newTestEncrypted(t *testing.T, dir string, key []byte, previous ...[]byte) *Encrypted {
+	t.Helper()
+	e, err := NewEncrypted(NewLocal(dir), key, previous...)
+	if err != nil {
+		t.Fatalf("NewEncrypted: %v", err)
+	}
+	e.chunkSize = 64
+	return e
+}
+
+func readAll(t *testing.T, s Storage, key string) ([]byte, error) {
+	t.Helper()
+	rc, err := s.Get(context.Background(), key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+func TestEncrypted_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	e := newTestEncrypted(t, dir, testKey(t))
+	ctx := context.Background()
+
+	for _, size := range []int{0, 1, 63, 64, 65, 128, 1000} {
+		data := make([]byte, size)
+		_, _ = rand.Read(data)
+
+		for _, knownSize := range []bool{true, false} {
+			putSize := int64(-1)
+			if knownSize {
+				putSize = int64(size)
+			}
+			if err := e.Put(ctx, "obj.bin", bytes.NewReader(data), putSize, "application/octet-stream"); err != nil {
+				t.Fatalf("Put(%d): %v", size, err)
+			}
+			raw, err := os.ReadFile(filepath.Join(dir, "obj.bin"))
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if want := e.EncryptedSize(int64(size)); int64(len(raw)) != want {
+				t.Errorf("size %d: stored %d bytes, want %d", size, len(raw), want)
+			}
+			if size >= 16 && bytes.Contains(raw, data) {
+				t.Errorf("size %d: plaintext found on disk", size)
+			}
+
+			got, err := readAll(t, e, "obj.bin")
+			if err != nil {
+				t.Fatalf("Get(%d): %v", size, err)
+			}
+			if !bytes.Equal(got, data) {
+				t.Fatalf("size %d: round trip mismatch", size)
+			}
+		}
+	}
+}
+
+func TestEncrypted_DetectsTampering(t *testing.T) {
+	data := bytes.Repeat([]byte("secret "), 40)
+
+	tests := []struct {
+		name   string
+		mangle func([]byte) []byte
+	}{
+		{"flipped body byte", func(b []byte) []byte { b[encHeaderSize+10] ^= 1; return b }},
+		{"flipped wrapped key", func(b []byte) []byte { b[20] ^= 1; return b }},
+		{"altered plaintext size", func(b []byte) []byte { b[encHeaderSize-1]++; return b }},
+		{"altered nonce prefix", func(b []byte) []byte { b[encHeaderSize-12-1] ^= 1; return b }},
+		{"truncated at chunk boundary", func(b []byte) []byte { return b[:encHeaderSize+64+encTagSize] }},
+		{"truncated mid chunk", func(b []byte) []byte { return b[:len(b)-5] }},
+		{"trailing data", func(b []byte) []byte { return append(b, make([]byte, 64+encTagSize)...) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			e := newTestEncrypted(t, dir, testKey(t))
+			if err := e.Put(context.Background(), "f.txt", bytes.NewReader(data), int64(len(data)), "text/plain"); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			p := filepath.Join(dir, "f.txt")
+			raw, _ := os.ReadFile(p)
+			if err := os.WriteFile(p, tt.mangle(raw), 0644); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+			if _, err := readAll(t, e, "f.txt"); !errors.Is(err, ErrCorruptObject) {
+				t.Fatalf("expected ErrCorruptObject, got %v", err)
+			}
+		})
+	}
+}
+
+func TestEncrypted_WrongKey(t *testing.T) {
+	dir := t.TempDir()
+	e := newTestEncrypted(t, dir, testKey(t))
+	if err := e.Put(context.Background(), "f.txt", bytes.NewReader([]byte("hi")), 2, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	other := newTestEncrypted(t, dir, testKey(t))
+	if _, err := readAll(t, other, "f.txt"); !errors.Is(err, ErrUnknownMasterKey) {
+		t.Fatalf("expected ErrUnknownMasterKey, got %v", err)
+	}
+}
+
+func TestEncrypted_KeyRotation(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	oldKey, newKey := testKey(t), testKey(t)
+
+	old := newTestEncrypted(t, dir, oldKey)
+	data := bytes.Repeat([]byte("rotate me "), 30)
+	for _, key := range []string{"a/one.txt", "b/two.txt"} {
+		if err := old.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "text/plain"); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+
+	// New key with the old one retired: still readable
+	rotated := newTestEncrypted(t, dir, newKey, oldKey)
+	if got, err := readAll(t, rotated, "a/one.txt"); err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("read with previous key: %v", err)
+	}
+
+	before, _ := os.ReadFile(filepath.Join(dir, "a", "one.txt"))
+	rewrapped, unchanged, failed, err := rotated.RotateAll(ctx)
+	if err != nil {
+		t.Fatalf("RotateAll: %v", err)
+	}
+	if rewrapped != 2 || unchanged != 0 || failed != 0 {
+		t.Fatalf("RotateAll = (%d, %d, %d), want (2, 0, 0)", rewrapped, unchanged, failed)
+	}
+	after, _ := os.ReadFile(filepath.Join(dir, "a", "one.txt"))
+	if !bytes.Equal(before[encHeaderSize:], after[encHeaderSize:]) {
+		t.Error("rewrap should leave the ciphertext body untouched")
+	}
+
+	// Old key can be dropped entirely
+	current := newTestEncrypted(t, dir, newKey)
+	for _, key := range []string{"a/one.txt", "b/two.txt"} {
+		if got, err := readAll(t, current, key); err != nil || !bytes.Equal(got, data) {
+			t.Fatalf("read %s after rotation: %v", key, err)
+		}
+	}
+
+	// Second run is a no-op
+	rewrapped, unchanged, _, _ = current.RotateAll(ctx)
+	if rewrapped != 0 || unchanged != 2 {
+		t.Fatalf("second RotateAll = (%d, %d), want (0, 2)", rewrapped, unchanged)
+	}
+}
+
+func TestEncrypted_LegacyVersion(t *testing.T) {
+	dir := t.TempDir()
+	e := newTestEncrypted(t, dir, testKey(t))
+	data := bytes.Repeat([]byte("legacy "), 30)
+
+	// Version 1 objects sealed their chunks without additional data
+	dataKey := make([]byte, 32)
+	_, _ = rand.Read(dataKey)
+	aead, _ := newGCM(dataKey)
+	header, err := e.newHeader(dataKey, int64(len(data)))
+	if err != nil {
+		t.Fatalf("newHeader: %v", err)
+	}
+	header[len(encMagic)] = encLegacyVersion
+	er := &encryptReader{src: bytes.NewReader(data), aead: aead, chunkSize: e.chunkSize}
+	copy(er.prefix[:], header[encHeaderSize-8-4-encNoncePrefix:])
+	body, _ := io.ReadAll(er)
+	if err := os.WriteFile(filepath.Join(dir, "old.txt"), append(header, body...), 0644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if got, err := readAll(t, e, "old.txt"); err != nil || !bytes.Equal(got, data) {
+		t.Fatalf("read version 1 object: %v", err)
+	}
+}
+
+func TestEncrypted_MixedPlaintext(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	plain := NewLocal(dir)
+	e := newTestEncrypted(t, dir, testKey(t))
+
+	// Objects stored before encryption was enabled, including ones shorter
+	// than a header and one that merely starts with the magic bytes
+	objects := map[string][]byte{
+		"old/photo.png": bytes.Repeat([]byte("plaintext "), 30),
+		"old/tiny.txt":  []byte("hi"),
+		"old/magic.txt": append([]byte(encMagic), bytes.Repeat([]byte("x"), 200)...),
+		"old/empty.txt": {},
+	}
+	for key, data := range objects {
+		if err := plain.Put(ctx, key, bytes.NewReader(data), int64(len(data)), ""); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	newData := bytes.Repeat([]byte("encrypted "), 30)
+	if err := e.Put(ctx, "new/doc.txt", bytes.NewReader(newData), int64(len(newData)), "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	objects["new/doc.txt"] = newData
+
+	check := func() {
+		t.Helper()
+		for key, data := range objects {
+			if got, err := readAll(t, e, key); err != nil || !bytes.Equal(got, data) {
+				t.Fatalf("Get %s = %q, %v", key, got, err)
+			}
+		}
+		w := httptest.NewRecorder()
+		e.Serve(w, httptest.NewRequest(http.MethodGet, "/old/photo.png", nil), "old/photo.png")
+		if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), objects["old/photo.png"]) {
+			t.Fatalf("Serve plaintext object: status %d, body %q", w.Code, w.Body.Bytes())
+		}
+	}
+	check()
+
+	rewrapped, unchanged, failed, err := e.RotateAll(ctx)
+	if err != nil || rewrapped != 0 || unchanged != 5 || failed != 0 {
+		t.Fatalf("RotateAll = (%d, %d, %d), %v, want (0, 5, 0)", rewrapped, unchanged, failed, err)
+	}
+
+	encrypted, skipped, failed, err := e.EncryptExisting(ctx)
+	if err != nil || encrypted != 4 || skipped != 1 || failed != 0 {
+		t.Fatalf("EncryptExisting = (%d, %d, %d), %v, want (4, 1, 0)", encrypted, skipped, failed, err)
+	}
+	for key := range objects {
+		raw, _ := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+		if !isEncrypted(raw[:min(len(raw), encHeaderSize)]) {
+			t.Errorf("%s is still stored in plaintext", key)
+		}
+	}
+	check()
+
+	if encrypted, skipped, _, _ := e.EncryptExisting(ctx); encrypted != 0 || skipped != 5 {
+		t.Fatalf("second EncryptExisting = (%d, %d), want (0, 5)", encrypted, skipped)
+	}
+}
+
+func TestEncrypted_Serve(t *testing.T) {
+	dir := t.TempDir()
+	e := newTestEncrypted(t, dir, testKey(t))
+	data := []byte("<svg>icon</svg>")
+	if err := e.Put(context.Background(), "icons/logo.svg", bytes.NewReader(data), int64(len(data)), "image/svg+xml"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/icons/logo.svg", nil)
+	w := httptest.NewRecorder()
+	e.Serve(w, req, "icons/logo.svg")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if !bytes.Equal(w.Body.Bytes(), data) {
+		t.Fatalf("body = %q, want %q", w.Body.Bytes(), data)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "image/svg+xml" {
+		t.Errorf("Content-Type = %q, want image/svg+xml", ct)
+	}
+	if cl := w.Header().Get("Content-Length"); cl != "15" {
+		t.Errorf("Content-Length = %q, want 15", cl)
+	}
+
+	w = httptest.NewRecorder()
+	e.Serve(w, httptest.NewRequest(http.MethodGet, "/missing.png", nil), "missing.png")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("missing object status = %d, want 404", w.Code)
+	}
+}
+
+func TestEncrypted_ServeRange(t *testing.T) {
+	dir := t.TempDir()
+	e := newTestEncrypted(t, dir, testKey(t))
+	data := make([]byte, 300) // four full 64-byte chunks and a short one
+	_, _ = rand.Read(data)
+	if err := e.Put(context.Background(), "video.mp4", bytes.NewReader(data), int64(len(data)), "video/mp4"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	tests := []struct {
+		header     string
+		status     int
+		start, end int
+	}{
+		{"bytes=0-9", http.StatusPartialContent, 0, 9},
+		{"bytes=100-199", http.StatusPartialContent, 100, 199},
+		{"bytes=128-", http.StatusPartialContent, 128, 299},
+		{"bytes=250-1000", http.StatusPartialContent, 250, 299},
+		{"bytes=-20", http.StatusPartialContent, 280, 299},
+		{"bytes=0-9,20-29", http.StatusOK, 0, 299},
+		{"bytes=300-", http.StatusRequestedRangeNotSatisfiable, 0, 0},
+		{"bytes=20-10", http.StatusRequestedRangeNotSatisfiable, 0, 0},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/video.mp4", nil)
+		req.Header.Set("Range", tt.header)
+		w := httptest.NewRecorder()
+		e.Serve(w, req, "video.mp4")
+
+		if w.Code != tt.status {
+			t.Errorf("%s: status = %d, want %d", tt.header, w.Code, tt.status)
+			continue
+		}
+		if w.Header().Get("Accept-Ranges") != "bytes" {
+			t.Errorf("%s: missing Accept-Ranges", tt.header)
+		}
+		if tt.status == http.StatusRequestedRangeNotSatisfiable {
+			if cr := w.Header().Get("Content-Range"); cr != "bytes */300" {
+				t.Errorf("%s: Content-Range = %q, want bytes */300", tt.header, cr)
+			}
+			continue
+		}
+		if !bytes.Equal(w.Body.Bytes(), data[tt.start:tt.end+1]) {
+			t.Errorf("%s: body does not match bytes %d-%d", tt.header, tt.start, tt.end)
+		}
+		if tt.status == http.StatusPartialContent {
+			want := fmt.Sprintf("bytes %d-%d/300", tt.start, tt.end)
+			if cr := w.Header().Get("Content-Range"); cr != want {
+				t.Errorf("%s: Content-Range = %q, want %q", tt.header, cr, want)
+			}
+		}
+	}
+
+	// Backends whose readers cannot seek skip chunks by reading past them
+	raw, err := os.ReadFile(filepath.Join(dir, "video.mp4"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	body, _, err := e.openObject(io.MultiReader(bytes.NewReader(raw)))
+	if err != nil {
+		t.Fatalf("openObject: %v", err)
+	}
+	if err := body.(*decryptReader).seek(200); err != nil {
+		t.Fatalf("seek: %v", err)
+	}
+	if got, err := io.ReadAll(body); err != nil || !bytes.Equal(got, data[200:]) {
+		t.Errorf("after seek got %d bytes, err %v; want bytes 200-299", len(got), err)
+	}
+
+	// Objects written without a known size cannot be ranged
+	if err := e.Put(context.Background(), "stream.bin", bytes.NewReader(data), -1, ""); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/stream.bin", nil)
+	req.Header.Set("Range", "bytes=0-9")
+	w := httptest.NewRecorder()
+	e.Serve(w, req, "stream.bin")
+	if w.Code != http.StatusOK || w.Header().Get("Accept-Ranges") != "" || !bytes.Equal(w.Body.Bytes(), data) {
+		t.Errorf("unknown size: status = %d, Accept-Ranges = %q, want the whole object", w.Code, w.Header().Get("Accept-Ranges"))
+	}
+}
+
+func TestEncrypted_SignedURL(t *testing.T) {
+	// Even over S3, encrypted objects must be proxied rather than presigned
+	e := newTestEncrypted(t, t.TempDir(), testKey(t))
+	url, err := e.SignedURL(context.Background(), "any", 0)
+	if err != nil || url != "" {
+		t.Fatalf("SignedURL = (%q, %v), want empty", url, err)
+	}
+}
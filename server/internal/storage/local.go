@@ -2,7 +2,9 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
+	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -43,22 +45,33 @@ func (l *Local) Put(_ context.Context, key string, r io.Reader, _ int64, _ strin
 	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
 		return err
 	}
-	f, err := os.Create(p)
+	// Write to a temp file and rename so readers never see a partial object,
+	// and so r may safely be reading the object being replaced.
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-"+filepath.Base(p)+"-*")
 	if err != nil {
 		return err
 	}
+	tmp := f.Name()
 	if _, err := io.Copy(f, r); err != nil {
 		_ = f.Close()
-		_ = os.Remove(p)
+		_ = os.Remove(tmp)
 		return err
 	}
 	if err := f.Sync(); err != nil {
 		_ = f.Close()
-		_ = os.Remove(p)
+		_ = os.Remove(tmp)
 		return err
 	}
 	if err := f.Close(); err != nil {
-		_ = os.Remove(p)
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Chmod(tmp, 0644); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		_ = os.Remove(tmp)
 		return err
 	}
 	return nil
@@ -84,3 +97,34 @@ func (l *Local) Serve(w http.ResponseWriter, r *http.Request, key string) {
 func (l *Local) SignedURL(_ context.Context, _ string, _ time.Duration) (string, error) {
 	return "", nil
 }
+
+func (l *Local) List(ctx context.Context, prefix string, fn func(key string, size int64, modTime time.Time) error) error {
+	err := filepath.WalkDir(l.absBase, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.absBase, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(key, info.Size(), info.ModTime())
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
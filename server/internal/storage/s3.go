@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"net/http"
 	"time"
 
@@ -68,6 +69,9 @@ func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
 	// for missing keys; the error only appears on Read/Stat.
 	if _, err := obj.Stat(); err != nil {
 		_ = obj.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("getting object %q: %w", key, fs.ErrNotExist)
+		}
 		return nil, fmt.Errorf("getting object %q: %w", key, err)
 	}
 	return obj, nil
@@ -80,6 +84,18 @@ func (s *S3) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+func (s *S3) List(ctx context.Context, prefix string, fn func(key string, size int64, modTime time.Time) error) error {
+	for obj := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return fmt.Errorf("listing objects: %w", obj.Err)
+		}
+		if err := fn(obj.Key, obj.Size, obj.LastModified); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *S3) Serve(w http.ResponseWriter, r *http.Request, key string) {
 	url, err := s.SignedURL(r.Context(), key, time.Hour)
 	if err != nil {
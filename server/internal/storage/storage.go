@@ -2,11 +2,20 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"time"
+
+	"github.com/enzyme/server/internal/config"
+	"github.com/minio/minio-go/v7"
 )
 
+// ErrListNotSupported is returned by List on backends that cannot enumerate objects.
+var ErrListNotSupported = errors.New("storage backend does not support listing")
+
 // Storage abstracts file storage operations for uploads, avatars, icons, and emoji.
 type Storage interface {
 	// Put stores data under the given key.
@@ -26,3 +35,57 @@ type Storage interface {
 	// Local storage returns ("", nil) so callers fall back to HMAC-signed server URLs.
 	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
 }
+
+// Lister is implemented by backends that can enumerate stored objects.
+type Lister interface {
+	// List calls fn for every object whose key starts with prefix, with its
+	// size and last modification time. Returning an error from fn stops the
+	// walk and is returned from List.
+	List(ctx context.Context, prefix string, fn func(key string, size int64, modTime time.Time) error) error
+}
+
+// New creates the storage backend described by cfg, wrapped with encryption
+// when enabled. It returns (nil, nil) when storage is off.
+func New(ctx context.Context, cfg config.StorageConfig) (Storage, error) {
+	var store Storage
+	switch cfg.Type {
+	case "local":
+		store = NewLocal(cfg.Local.Path)
+	case "s3":
+		s3Store, err := NewS3(cfg.S3)
+		if err != nil {
+			return nil, fmt.Errorf("initializing S3 storage: %w", err)
+		}
+		if err := s3Store.CheckConnectivity(ctx); err != nil {
+			return nil, fmt.Errorf("S3 connectivity check: %w", err)
+		}
+		store = s3Store
+	default:
+		return nil, nil
+	}
+
+	if !cfg.Encryption.Enabled {
+		return store, nil
+	}
+	current, previous, err := LoadMasterKeys(cfg.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("loading storage encryption key: %w", err)
+	}
+	return NewEncrypted(store, current, previous...)
+}
+
+// ObjectSize returns the size of an object returned by Get, or -1 if the
+// backend cannot report it without reading the whole object.
+func ObjectSize(rc io.ReadCloser) int64 {
+	switch r := rc.(type) {
+	case *os.File:
+		if info, err := r.Stat(); err == nil {
+			return info.Size()
+		}
+	case *minio.Object:
+		if info, err := r.Stat(); err == nil {
+			return info.Size
+		}
+	}
+	return -1
+}
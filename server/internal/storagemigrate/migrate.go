@@ -0,0 +1,359 @@
+// Package storagemigrate copies stored objects between storage backends.
+package storagemigrate
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/enzyme/server/internal/storage"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultConcurrency is the number of objects copied in parallel.
+const DefaultConcurrency = 4
+
+// OrphanGracePeriod is how old an unreferenced object must be before it
+// counts as orphaned. Uploads are stored before their database row is
+// committed, so newer objects may still be about to be referenced.
+const OrphanGracePeriod = time.Hour
+
+// ErrChecksumMismatch is returned when the copied object does not match the source.
+var ErrChecksumMismatch = errors.New("checksum mismatch after copy")
+
+// Object is a storage key referenced by a database row.
+type Object struct {
+	Key  string
+	Size int64 // -1 when the database does not record a size
+}
+
+// referenceQueries return (key, size) for every object the database points
+// at. Avatars and workspace icons are stored as API URLs and mapped back to
+// their storage keys.
+var referenceQueries = []string{
+	`SELECT storage_path, size_bytes FROM attachments`,
+	`SELECT storage_path, size_bytes FROM custom_emojis`,
+	`SELECT storage_key, size_bytes FROM image_proxy_cache`,
+	`SELECT 'avatars/' || substr(avatar_url, length('/api/avatars/') + 1), -1
+	 FROM users WHERE avatar_url LIKE '/api/avatars/%'`,
+	`SELECT 'workspace-icons/' || substr(icon_url, length('/api/workspace-icons/') + 1), -1
+	 FROM workspaces WHERE icon_url LIKE '/api/workspace-icons/%'`,
+}
+
+// ReferencedObjects returns every storage key referenced by the database,
+// sorted by key.
+func ReferencedObjects(ctx context.Context, db *sql.DB) ([]Object, error) {
+	seen := make(map[string]bool)
+	var objects []Object
+	for _, q := range referenceQueries {
+		rows, err := db.QueryContext(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var o Object
+			if err := rows.Scan(&o.Key, &o.Size); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if o.Key == "" || seen[o.Key] {
+				continue
+			}
+			seen[o.Key] = true
+			objects = append(objects, o)
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rows.Close()
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Options controls a migration run.
+type Options struct {
+	Concurrency  int
+	DryRun       bool   // report what would be copied without writing anything
+	DeleteSource bool   // delete source objects once every object has been copied and verified
+	StatePath    string // journal of completed keys, so interrupted runs can resume; empty disables
+}
+
+// KeyError records a per-object failure.
+type KeyError struct {
+	Key string
+	Err error
+}
+
+// Report summarizes a migration run.
+type Report struct {
+	Referenced int
+	Copied     int
+	Resumed    int      // already copied by a previous run
+	Bytes      int64    // bytes copied in this run (or that would be, for dry runs)
+	Missing    []string // referenced but absent from the source
+	Failed     []KeyError
+	Deleted    int
+}
+
+// Migrator copies objects from one backend to another. Both backends should
+// be raw (unencrypted) stores: encrypted objects are copied byte for byte, so
+// they stay readable with the same master key.
+type Migrator struct {
+	db  *sql.DB
+	src storage.Storage
+	dst storage.Storage
+}
+
+// New creates a Migrator.
+func New(db *sql.DB, src, dst storage.Storage) *Migrator {
+	return &Migrator{db: db, src: src, dst: dst}
+}
+
+// Run copies every referenced object to the destination, verifying each copy
+// by SHA-256. Objects recorded in the state journal are skipped.
+func (m *Migrator) Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = DefaultConcurrency
+	}
+
+	objects, err := ReferencedObjects(ctx, m.db)
+	if err != nil {
+		return nil, fmt.Errorf("listing referenced objects: %w", err)
+	}
+
+	var journal *journal
+	done := map[string]bool{}
+	if opts.StatePath != "" {
+		if done, err = loadJournal(opts.StatePath); err != nil {
+			return nil, err
+		}
+		if !opts.DryRun {
+			if journal, err = openJournal(opts.StatePath); err != nil {
+				return nil, err
+			}
+			defer journal.Close()
+		}
+	}
+
+	report := &Report{Referenced: len(objects)}
+	var mu sync.Mutex
+	var copied atomic.Int64
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(opts.Concurrency)
+	for _, o := range objects {
+		if done[o.Key] {
+			report.Resumed++
+			continue
+		}
+		if gCtx.Err() != nil {
+			break
+		}
+		g.Go(func() error {
+			n, sum, err := m.copyObject(gCtx, o.Key, opts.DryRun)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				report.Missing = append(report.Missing, o.Key)
+			case err != nil:
+				if gCtx.Err() != nil {
+					return gCtx.Err()
+				}
+				slog.Error("failed to migrate object", "key", o.Key, "error", err)
+				report.Failed = append(report.Failed, KeyError{Key: o.Key, Err: err})
+			default:
+				report.Copied++
+				report.Bytes += n
+				if journal != nil {
+					if err := journal.Record(o.Key, sum); err != nil {
+						return err
+					}
+				}
+				if c := copied.Add(1); c%100 == 0 {
+					slog.Info("migration progress", "copied", c, "total", len(objects))
+				}
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return report, err
+	}
+	if err := ctx.Err(); err != nil {
+		return report, err
+	}
+	sort.Strings(report.Missing)
+
+	if opts.DeleteSource && !opts.DryRun {
+		if len(report.Failed) > 0 {
+			return report, fmt.Errorf("not deleting source: %d objects failed to copy", len(report.Failed))
+		}
+		for _, o := range objects {
+			if err := m.src.Delete(ctx, o.Key); err != nil {
+				return report, fmt.Errorf("deleting source object %q: %w", o.Key, err)
+			}
+			report.Deleted++
+		}
+	}
+	return report, nil
+}
+
+// copyObject streams key from src to dst and re-reads the destination to
+// verify its checksum. In dry-run mode it only checks the source exists.
+func (m *Migrator) copyObject(ctx context.Context, key string, dryRun bool) (int64, string, error) {
+	rc, err := m.src.Get(ctx, key)
+	if err != nil {
+		return 0, "", err
+	}
+	defer rc.Close()
+	size := storage.ObjectSize(rc)
+	if dryRun {
+		return max(size, 0), "", nil
+	}
+
+	h := sha256.New()
+	cr := &countingReader{r: io.TeeReader(rc, h)}
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if err := m.dst.Put(ctx, key, cr, size, contentType); err != nil {
+		return 0, "", fmt.Errorf("writing destination: %w", err)
+	}
+	want := hex.EncodeToString(h.Sum(nil))
+
+	got, err := checksum(ctx, m.dst, key)
+	if err != nil {
+		return 0, "", fmt.Errorf("verifying destination: %w", err)
+	}
+	if got != want {
+		return 0, "", ErrChecksumMismatch
+	}
+	return cr.n, want, nil
+}
+
+// Orphans returns keys in the source backend that no database row references
+// and that were last modified more than grace ago. Quarantined files are
+// included in references through their attachment rows.
+func (m *Migrator) Orphans(ctx context.Context, grace time.Duration) ([]string, error) {
+	lister, ok := m.src.(storage.Lister)
+	if !ok {
+		return nil, storage.ErrListNotSupported
+	}
+	objects, err := ReferencedObjects(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]bool, len(objects))
+	for _, o := range objects {
+		referenced[o.Key] = true
+	}
+
+	cutoff := time.Now().Add(-grace)
+	var orphans []string
+	err = lister.List(ctx, "", func(key string, _ int64, modTime time.Time) error {
+		if !referenced[key] && !modTime.After(cutoff) {
+			orphans = append(orphans, key)
+		}
+		return nil
+	})
+	return orphans, err
+}
+
+// DeleteOrphans removes the given keys from the source backend.
+func (m *Migrator) DeleteOrphans(ctx context.Context, keys []string) (int, error) {
+	for i, key := range keys {
+		if err := m.src.Delete(ctx, key); err != nil {
+			return i, fmt.Errorf("deleting %q: %w", key, err)
+		}
+	}
+	return len(keys), nil
+}
+
+func checksum(ctx context.Context, s storage.Storage, key string) (string, error) {
+	rc, err := s.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// journal is an append-only log of "key<TAB>sha256" lines for completed copies.
+type journal struct {
+	f *os.File
+}
+
+func loadJournal(p string) (map[string]bool, error) {
+	done := make(map[string]bool)
+	f, err := os.Open(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening state file: %w", err)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		// A torn final line from a crash has no tab and is ignored
+		if key, _, ok := strings.Cut(sc.Text(), "\t"); ok {
+			done[key] = true
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading state file: %w", err)
+	}
+	return done, nil
+}
+
+func openJournal(p string) (*journal, error) {
+	f, err := os.OpenFile(p, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening state file: %w", err)
+	}
+	return &journal{f: f}, nil
+}
+
+func (j *journal) Record(key, sum string) error {
+	_, err := fmt.Fprintf(j.f, "%s\t%s\n", key, sum)
+	return err
+}
+
+func (j *journal) Close() error {
+	return j.f.Close()
+}
@@ -0,0 +1,208 @@
+package storagemigrate
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/enzyme/server/internal/channel"
+	"github.com/enzyme/server/internal/file"
+	"github.com/enzyme/server/internal/storage"
+	"github.com/enzyme/server/internal/testutil"
+)
+
+type fixture struct {
+	db       *sql.DB
+	src, dst *storage.Local
+	srcDir   string
+	keys     []string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	db := testutil.TestDB(t)
+	ctx := context.Background()
+	srcDir := t.TempDir()
+	f := &fixture{db: db, src: storage.NewLocal(srcDir), dst: storage.NewLocal(t.TempDir()), srcDir: srcDir}
+
+	user := testutil.CreateTestUser(t, db, "alice@example.com", "Alice")
+	ws := testutil.CreateTestWorkspace(t, db, user.ID, "Test WS")
+	ch := testutil.CreateTestChannel(t, db, ws.ID, user.ID, "general", channel.TypePublic)
+
+	put := func(key, data string) {
+		if err := f.src.Put(ctx, key, bytes.NewReader([]byte(data)), int64(len(data)), ""); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+		f.keys = append(f.keys, key)
+	}
+
+	attachKey := ws.ID + "/" + ch.ID + "/doc.txt"
+	put(attachKey, "attachment body")
+	if err := file.NewRepository(db).Create(ctx, &file.Attachment{
+		ChannelID: ch.ID, UserID: &user.ID, Filename: "doc.txt", ContentType: "text/plain",
+		SizeBytes: 15, StoragePath: attachKey,
+	}); err != nil {
+		t.Fatalf("Create attachment: %v", err)
+	}
+
+	put("avatars/"+user.ID+".png", "avatar")
+	mustExec(t, db, `UPDATE users SET avatar_url = ? WHERE id = ?`, "/api/avatars/"+user.ID+".png", user.ID)
+
+	put("workspace-icons/"+ws.ID+"/icon.png", "icon")
+	mustExec(t, db, `UPDATE workspaces SET icon_url = ? WHERE id = ?`, "/api/workspace-icons/"+ws.ID+"/icon.png", ws.ID)
+
+	put("image-proxy/abc123", "image")
+	mustExec(t, db, `INSERT INTO image_proxy_cache (url_hash, url, content_type, size_bytes, storage_key, fetched_at, expires_at)
+		VALUES ('abc123', 'https://example.com/a.png', 'image/png', 5, 'image-proxy/abc123', '2026-01-01T00:00:00Z', '2099-01-01T00:00:00Z')`)
+
+	emoji := testutil.CreateTestEmoji(t, db, ws.ID, user.ID, "party")
+	emojiKey := "emojis/" + ws.ID + "/" + emoji.ID + ".png"
+	put(emojiKey, "emoji")
+	mustExec(t, db, `UPDATE custom_emojis SET storage_path = ? WHERE id = ?`, emojiKey, emoji.ID)
+
+	return f
+}
+
+func mustExec(t *testing.T, db *sql.DB, query string, args ...any) {
+	t.Helper()
+	if _, err := db.Exec(query, args...); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+}
+
+func readKey(t *testing.T, s storage.Storage, key string) string {
+	t.Helper()
+	rc, err := s.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%s): %v", key, err)
+	}
+	defer rc.Close()
+	data, _ := io.ReadAll(rc)
+	return string(data)
+}
+
+func TestReferencedObjects(t *testing.T) {
+	f := newFixture(t)
+	objects, err := ReferencedObjects(context.Background(), f.db)
+	if err != nil {
+		t.Fatalf("ReferencedObjects: %v", err)
+	}
+	got := map[string]bool{}
+	for _, o := range objects {
+		got[o.Key] = true
+	}
+	for _, key := range f.keys {
+		if !got[key] {
+			t.Errorf("missing reference to %q", key)
+		}
+	}
+	if len(objects) != len(f.keys) {
+		t.Errorf("len(objects) = %d, want %d", len(objects), len(f.keys))
+	}
+}
+
+func TestRun_CopiesAndVerifies(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	report, err := New(f.db, f.src, f.dst).Run(ctx, Options{})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Copied != len(f.keys) || len(report.Failed) != 0 || len(report.Missing) != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	for _, key := range f.keys {
+		if readKey(t, f.dst, key) != readKey(t, f.src, key) {
+			t.Errorf("%s: destination differs from source", key)
+		}
+	}
+}
+
+func TestRun_DryRun(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	report, err := New(f.db, f.src, f.dst).Run(ctx, Options{DryRun: true, DeleteSource: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Copied != len(f.keys) || report.Deleted != 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if _, err := f.dst.Get(ctx, f.keys[0]); err == nil {
+		t.Fatal("dry run should not write to the destination")
+	}
+}
+
+func TestRun_ResumesAndDeletesSource(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	state := filepath.Join(t.TempDir(), "migrate.state")
+
+	// Reference a key that doesn't exist in the source
+	mustExec(t, f.db, `UPDATE custom_emojis SET storage_path = 'emojis/missing.png'`)
+	f.keys = f.keys[:len(f.keys)-1]
+
+	m := New(f.db, f.src, f.dst)
+	report, err := m.Run(ctx, Options{StatePath: state})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if report.Copied != len(f.keys) || len(report.Missing) != 1 || report.Missing[0] != "emojis/missing.png" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	report, err = m.Run(ctx, Options{StatePath: state, DeleteSource: true})
+	if err != nil {
+		t.Fatalf("second Run: %v", err)
+	}
+	if report.Copied != 0 || report.Resumed != len(f.keys) {
+		t.Fatalf("expected all objects resumed, got %+v", report)
+	}
+	for _, key := range f.keys {
+		if _, err := f.src.Get(ctx, key); err == nil {
+			t.Errorf("%s: source should have been deleted", key)
+		}
+		readKey(t, f.dst, key)
+	}
+}
+
+func TestOrphans(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	for _, key := range []string{"avatars/stale.png", "avatars/uploading.png"} {
+		if err := f.src.Put(ctx, key, bytes.NewReader([]byte("x")), 1, ""); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	old := time.Now().Add(-2 * OrphanGracePeriod)
+	if err := os.Chtimes(filepath.Join(f.srcDir, "avatars", "stale.png"), old, old); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+
+	// An upload whose database row is not committed yet is left alone
+	m := New(f.db, f.src, f.dst)
+	orphans, err := m.Orphans(ctx, OrphanGracePeriod)
+	if err != nil {
+		t.Fatalf("Orphans: %v", err)
+	}
+	if len(orphans) != 1 || orphans[0] != "avatars/stale.png" {
+		t.Fatalf("orphans = %v, want [avatars/stale.png]", orphans)
+	}
+
+	if n, err := m.DeleteOrphans(ctx, orphans); err != nil || n != 1 {
+		t.Fatalf("DeleteOrphans = (%d, %v)", n, err)
+	}
+	if orphans, _ := m.Orphans(ctx, OrphanGracePeriod); len(orphans) != 0 {
+		t.Fatalf("orphans after GC = %v", orphans)
+	}
+	if orphans, _ := m.Orphans(ctx, 0); len(orphans) != 1 || orphans[0] != "avatars/uploading.png" {
+		t.Fatalf("orphans without a grace period = %v, want [avatars/uploading.png]", orphans)
+	}
+}
@@ -0,0 +1,125 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RequireToken wraps a metrics handler so that it only answers requests
+// carrying "Authorization: Bearer <token>". An empty token disables the check.
+func RequireToken(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, want) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="metrics"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MetricsMiddleware records the duration of every HTTP request, labelled by
+// chi route pattern rather than raw path to keep cardinality bounded.
+// Requests that match no route (scanners, SPA paths) share the "other" label.
+func MetricsMiddleware() func(http.Handler) http.Handler {
+	duration, err := otel.Meter("enzyme.http").Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of HTTP requests"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		slog.Error("failed to create http.server.request.duration metric", "error", err)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			if duration == nil {
+				return
+			}
+			route := "other"
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				// Fallback routes such as the SPA's catch-all keep the
+				// wildcard pattern, which is as good as unmatched
+				if p := rctx.RoutePattern(); p != "" && !strings.HasSuffix(p, "/*") {
+					route = p
+				}
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			duration.Record(r.Context(), time.Since(start).Seconds(), metric.WithAttributes(
+				attribute.String("http.route", route),
+				attribute.String("http.request.method", r.Method),
+				attribute.String("http.response.status_code", strconv.Itoa(status)),
+			))
+		})
+	}
+}
+
+// RegisterDBStats reports the connection pool statistics of db as
+// observable metrics, sampled whenever metrics are collected.
+func RegisterDBStats(db *sql.DB) {
+	meter := otel.Meter("enzyme.database")
+	open, err := meter.Int64ObservableGauge("db.client.connections.open",
+		metric.WithDescription("Open database connections"),
+	)
+	if err != nil {
+		slog.Error("failed to create db.client.connections.open metric", "error", err)
+		return
+	}
+	inUse, err := meter.Int64ObservableGauge("db.client.connections.in_use",
+		metric.WithDescription("Database connections currently in use"),
+	)
+	if err != nil {
+		slog.Error("failed to create db.client.connections.in_use metric", "error", err)
+		return
+	}
+	waitCount, err := meter.Int64ObservableCounter("db.client.connections.wait_count",
+		metric.WithDescription("Total times a query waited for a free connection"),
+	)
+	if err != nil {
+		slog.Error("failed to create db.client.connections.wait_count metric", "error", err)
+		return
+	}
+	waitTime, err := meter.Float64ObservableCounter("db.client.connections.wait_time",
+		metric.WithDescription("Total time spent waiting for a free connection"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		slog.Error("failed to create db.client.connections.wait_time metric", "error", err)
+		return
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := db.Stats()
+		o.ObserveInt64(open, int64(stats.OpenConnections))
+		o.ObserveInt64(inUse, int64(stats.InUse))
+		o.ObserveInt64(waitCount, stats.WaitCount)
+		o.ObserveFloat64(waitTime, stats.WaitDuration.Seconds())
+		return nil
+	}, open, inUse, waitCount, waitTime)
+	if err != nil {
+		slog.Error("failed to register database pool metrics", "error", err)
+	}
+}
@@ -0,0 +1,123 @@
+package telemetry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/enzyme/server/internal/config"
+	"github.com/go-chi/chi/v5"
+)
+
+func TestInit_PrometheusOnly(t *testing.T) {
+	restore := saveGlobalProviders(t)
+	defer restore()
+
+	tel, err := Init(config.TelemetryConfig{ServiceName: "test-prom"}, config.MetricsConfig{Enabled: true, Token: "secret"}, "test-version")
+	if err != nil {
+		t.Fatalf("Init() with only prometheus should not fail: %v", err)
+	}
+	defer tel.Shutdown(context.Background())
+
+	if tel.tracerProvider != nil || tel.logProvider != nil {
+		t.Fatal("OTLP providers should be nil when telemetry is disabled")
+	}
+	if tel.meterProvider == nil {
+		t.Fatal("meter provider should not be nil when metrics are enabled")
+	}
+	if tel.MetricsHandler() == nil {
+		t.Fatal("MetricsHandler() should not be nil when metrics are enabled")
+	}
+
+	// Record a request through the global meter and check it is scraped
+	r := chi.NewRouter()
+	r.Use(MetricsMiddleware())
+	r.Get("/api/workspaces/{wid}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/workspaces/W1", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/wp-login.php", nil))
+
+	rec := httptest.NewRecorder()
+	tel.MetricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	body, _ := io.ReadAll(rec.Body)
+	for _, want := range []string{
+		`http_route="/api/workspaces/{wid}"`,
+		`http_route="other"`,
+		`http_response_status_code="204"`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output missing %s", want)
+		}
+	}
+	if strings.Contains(string(body), "W1") {
+		t.Error("metrics output should use the route pattern, not the raw path")
+	}
+}
+
+func TestInit_MetricsDisabledHasNoHandler(t *testing.T) {
+	restore := saveGlobalProviders(t)
+	defer restore()
+
+	cfg := config.TelemetryConfig{
+		Enabled:  true,
+		Endpoint: "localhost:4318",
+		Protocol: "http",
+		Insecure: true,
+		Traces:   true,
+		Metrics:  true,
+	}
+	tel, err := Init(cfg, config.MetricsConfig{}, "test-version")
+	if err != nil {
+		t.Fatalf("Init() should not fail: %v", err)
+	}
+	defer tel.Shutdown(context.Background())
+
+	if tel.MetricsHandler() != nil {
+		t.Fatal("MetricsHandler() should be nil when metrics are disabled")
+	}
+}
+
+func TestRequireToken(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := RequireToken("secret", inner)
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong", "Bearer nope", http.StatusUnauthorized},
+		{"not bearer", "secret", http.StatusUnauthorized},
+		{"valid", "Bearer secret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/metrics", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+
+	// An empty token leaves the handler open, for a private listen address
+	rec := httptest.NewRecorder()
+	RequireToken("", inner).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 without a token, got %d", rec.Code)
+	}
+}
@@ -2,25 +2,54 @@ package telemetry
 
 import (
 	"context"
+	"log/slog"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // Pre-computed span start option to avoid per-call allocation.
 var dbSpanAttrs = trace.WithAttributes(attribute.String("db.system", "sqlite"))
 
+var (
+	dbSystem                           = attribute.String("db.system", "sqlite")
+	dbDuration metric.Float64Histogram = noop.Float64Histogram{}
+)
+
+// SetDBSystem sets the db.system attribute recorded on database spans and
+// creates the operation duration metric. It must be called after Init and
+// before any spans are started.
+func SetDBSystem(system string) {
+	dbSystem = attribute.String("db.system", system)
+	dbSpanAttrs = trace.WithAttributes(dbSystem)
+
+	h, err := otel.Meter("enzyme.database").Float64Histogram("db.client.operation.duration",
+		metric.WithDescription("Duration of database operations"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		slog.Error("failed to create db.client.operation.duration metric", "error", err)
+		return
+	}
+	dbDuration = h
+}
+
 // StartDBSpan starts a new span for a database operation. The caller must
 // call the returned end function when the operation completes, passing the
-// error (if any) so the span records failures.
+// error (if any) so the span records failures. The operation's duration is
+// also recorded as a metric.
 //
 //	ctx, end := telemetry.StartDBSpan(ctx, "message.Create")
 //	result, err := doQuery(ctx)
 //	end(err)
 //	return result, err
 func StartDBSpan(ctx context.Context, operation string) (context.Context, func(error)) {
+	start := time.Now()
 	ctx, span := otel.Tracer("enzyme.database").Start(ctx, operation, dbSpanAttrs)
 	return ctx, func(err error) {
 		if err != nil {
@@ -28,5 +57,10 @@ func StartDBSpan(ctx context.Context, operation string) (context.Context, func(e
 			span.SetStatus(codes.Error, err.Error())
 		}
 		span.End()
+		dbDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+			dbSystem,
+			attribute.String("db.operation.name", operation),
+			attribute.Bool("error", err != nil),
+		))
 	}
 }
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/enzyme/server/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
@@ -15,6 +18,7 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
 	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/propagation"
 	sdklog "go.opentelemetry.io/otel/sdk/log"
@@ -29,10 +33,13 @@ type Telemetry struct {
 	tracerProvider *sdktrace.TracerProvider
 	meterProvider  *sdkmetric.MeterProvider
 	logProvider    *sdklog.LoggerProvider
+	metricsHandler http.Handler
 }
 
-// Init initializes OpenTelemetry with OTLP exporters based on config.
-func Init(cfg config.TelemetryConfig, version string) (*Telemetry, error) {
+// Init initializes OpenTelemetry based on config. OTLP exporters are set up
+// when cfg.Enabled is set; metrics.Enabled adds a Prometheus exporter served
+// by MetricsHandler. Both can be enabled at once.
+func Init(cfg config.TelemetryConfig, metrics config.MetricsConfig, version string) (*Telemetry, error) {
 	ctx := context.Background()
 
 	res, err := resource.New(ctx,
@@ -54,7 +61,7 @@ func Init(cfg config.TelemetryConfig, version string) (*Telemetry, error) {
 	}
 
 	// Traces
-	if cfg.Traces {
+	if cfg.Enabled && cfg.Traces {
 		traceExporter, err := newTraceExporter(ctx, cfg)
 		if err != nil {
 			return nil, fmt.Errorf("creating trace exporter: %w", err)
@@ -80,17 +87,29 @@ func Init(cfg config.TelemetryConfig, version string) (*Telemetry, error) {
 	}
 
 	// Metrics
-	if cfg.Metrics {
+	var readers []sdkmetric.Option
+	if cfg.Enabled && cfg.Metrics {
 		metricExporter, err := newMetricExporter(ctx, cfg)
 		if err != nil {
 			shutdown()
 			return nil, fmt.Errorf("creating metric exporter: %w", err)
 		}
-
-		tel.meterProvider = sdkmetric.NewMeterProvider(
-			sdkmetric.WithResource(res),
-			sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(60*time.Second))),
-		)
+		readers = append(readers, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(60*time.Second))))
+	}
+	if metrics.Enabled {
+		// A dedicated registry keeps /metrics to OTel instruments instead of
+		// whatever else registered with the client library's global one
+		registry := prometheus.NewRegistry()
+		promExporter, err := otelprom.New(otelprom.WithRegisterer(registry))
+		if err != nil {
+			shutdown()
+			return nil, fmt.Errorf("creating prometheus exporter: %w", err)
+		}
+		readers = append(readers, sdkmetric.WithReader(promExporter))
+		tel.metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+	}
+	if len(readers) > 0 {
+		tel.meterProvider = sdkmetric.NewMeterProvider(append(readers, sdkmetric.WithResource(res))...)
 		otel.SetMeterProvider(tel.meterProvider)
 
 		if err := runtime.Start(runtime.WithMeterProvider(tel.meterProvider)); err != nil {
@@ -100,7 +119,7 @@ func Init(cfg config.TelemetryConfig, version string) (*Telemetry, error) {
 	}
 
 	// Logs
-	if cfg.Logs {
+	if cfg.Enabled && cfg.Logs {
 		logExporter, err := newLogExporter(ctx, cfg)
 		if err != nil {
 			shutdown()
@@ -117,6 +136,12 @@ func Init(cfg config.TelemetryConfig, version string) (*Telemetry, error) {
 	return tel, nil
 }
 
+// MetricsHandler returns the handler serving metrics in the Prometheus text
+// format, or nil if the Prometheus exporter is not enabled.
+func (t *Telemetry) MetricsHandler() http.Handler {
+	return t.metricsHandler
+}
+
 // Noop returns a Telemetry instance that does nothing on Shutdown.
 func Noop() *Telemetry {
 	return &Telemetry{}
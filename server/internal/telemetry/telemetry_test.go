@@ -64,7 +64,7 @@ func TestInit_GRPC(t *testing.T) {
 		Metrics:     true,
 		Logs:        true,
 	}
-	tel, err := Init(cfg, "test-version")
+	tel, err := Init(cfg, config.MetricsConfig{}, "test-version")
 	if err != nil {
 		t.Fatalf("Init() with grpc should not fail eagerly: %v", err)
 	}
@@ -96,7 +96,7 @@ func TestInit_HTTP(t *testing.T) {
 		Metrics:     true,
 		Logs:        true,
 	}
-	tel, err := Init(cfg, "test-version")
+	tel, err := Init(cfg, config.MetricsConfig{}, "test-version")
 	if err != nil {
 		t.Fatalf("Init() with http should not fail eagerly: %v", err)
 	}
@@ -122,7 +122,7 @@ func TestInit_WithHeaders(t *testing.T) {
 			"authorization":    "Bearer token",
 		},
 	}
-	tel, err := Init(cfg, "test-version")
+	tel, err := Init(cfg, config.MetricsConfig{}, "test-version")
 	if err != nil {
 		t.Fatalf("Init() with headers should not fail: %v", err)
 	}
@@ -157,7 +157,7 @@ func TestInit_WithHeaders_HTTP(t *testing.T) {
 			"x-api-key": "test-key",
 		},
 	}
-	tel, err := Init(cfg, "test-version")
+	tel, err := Init(cfg, config.MetricsConfig{}, "test-version")
 	if err != nil {
 		t.Fatalf("Init() with http headers should not fail: %v", err)
 	}
@@ -179,7 +179,7 @@ func TestInit_SamplerZero(t *testing.T) {
 		Metrics:     true,
 		Logs:        true,
 	}
-	tel, err := Init(cfg, "test-version")
+	tel, err := Init(cfg, config.MetricsConfig{}, "test-version")
 	if err != nil {
 		t.Fatalf("Init() with zero sample rate should not fail: %v", err)
 	}
@@ -201,7 +201,7 @@ func TestInit_PerSignalDisable(t *testing.T) {
 		Metrics:     false,
 		Logs:        false,
 	}
-	tel, err := Init(cfg, "test-version")
+	tel, err := Init(cfg, config.MetricsConfig{}, "test-version")
 	if err != nil {
 		t.Fatalf("Init() with partial signals should not fail: %v", err)
 	}
@@ -3,6 +3,9 @@ package testutil
 import (
 	"context"
 	"database/sql"
+	"net/url"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,11 +14,21 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-// TestDB creates an in-memory SQLite database with migrations applied.
+// PostgresURLEnv names the environment variable that switches TestDB to
+// PostgreSQL. It must hold a connection URL for a database the tests may
+// create schemas in.
+const PostgresURLEnv = "ENZYME_TEST_POSTGRES_URL"
+
+// TestDB creates a database with migrations applied: in-memory SQLite by
+// default, or a fresh schema in PostgreSQL when PostgresURLEnv is set.
 // The database is automatically closed when the test completes.
 func TestDB(t *testing.T) *sql.DB {
 	t.Helper()
 
+	if pgURL := os.Getenv(PostgresURLEnv); pgURL != "" {
+		return postgresTestDB(t, pgURL)
+	}
+
 	db, err := database.Open(":memory:", database.Options{
 		MaxOpenConns: 1, // single connection for test determinism
 		BusyTimeout:  5000,
@@ -37,6 +50,49 @@ func TestDB(t *testing.T) *sql.DB {
 	return db.DB
 }
 
+// postgresTestDB migrates a uniquely named schema so tests can run in
+// parallel against one server, and drops it on cleanup.
+func postgresTestDB(t *testing.T, pgURL string) *sql.DB {
+	t.Helper()
+
+	opts := database.Options{Driver: database.DriverPostgres, MaxOpenConns: 4}
+	admin, err := database.Open(pgURL, opts)
+	if err != nil {
+		t.Fatalf("opening test database: %v", err)
+	}
+	schema := "test_" + strings.ToLower(ulid.Make().String())
+	if _, err := admin.Exec(`CREATE SCHEMA ` + schema); err != nil {
+		_ = admin.Close()
+		t.Fatalf("creating test schema: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE`)
+		_ = admin.Close()
+	})
+
+	u, err := url.Parse(pgURL)
+	if err != nil {
+		t.Fatalf("parsing %s: %v", PostgresURLEnv, err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	db, err := database.Open(u.String(), opts)
+	if err != nil {
+		t.Fatalf("opening test schema: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("running migrations: %v", err)
+	}
+
+	return db.DB
+}
+
 // hashPassword creates a bcrypt hash with low cost for tests
 func hashPassword(password string) string {
 	hash, _ := bcrypt.GenerateFromPassword([]byte(password), 4)
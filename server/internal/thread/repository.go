@@ -127,10 +127,11 @@ func (r *Repository) AutoSubscribe(ctx context.Context, threadParentID, userID s
 	now := time.Now().UTC().Format(time.RFC3339)
 	id := ulid.Make().String()
 
-	// INSERT OR IGNORE - only creates row if no subscription exists
+	// ON CONFLICT DO NOTHING - only creates row if no subscription exists
 	query := `
-		INSERT OR IGNORE INTO thread_subscriptions (id, thread_parent_id, user_id, status, created_at, updated_at)
+		INSERT INTO thread_subscriptions (id, thread_parent_id, user_id, status, created_at, updated_at)
 		VALUES (?, ?, ?, 'subscribed', ?, ?)
+		ON CONFLICT DO NOTHING
 	`
 
 	_, err := r.db.ExecContext(ctx, query, id, threadParentID, userID, now, now)
@@ -1,9 +1,17 @@
 package user
 
 import (
+	"context"
+	"database/sql"
 	"time"
 )
 
+// Account statuses. Deactivated users cannot log in.
+const (
+	StatusActive      = "active"
+	StatusDeactivated = "deactivated"
+)
+
 type User struct {
 	ID              string     `json:"id"`
 	Email           string     `json:"email"`
@@ -12,13 +20,19 @@ type User struct {
 	DisplayName     string     `json:"display_name"`
 	AvatarURL       *string    `json:"avatar_url,omitempty"`
 	Status          string     `json:"status"`
+	IsSiteAdmin     bool       `json:"is_site_admin"`
+	PendingApproval bool       `json:"pending_approval"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 }
 
 type CreateUserInput struct {
-	Email        string
-	Password     string
-	DisplayName  string
-	PasswordHash string
+	Email           string
+	Password        string
+	DisplayName     string
+	PasswordHash    string
+	PendingApproval bool
+	// InTx, if set, runs in the transaction that inserts the user; an error
+	// rolls the new account back.
+	InTx func(ctx context.Context, tx *sql.Tx, u *User) error
 }
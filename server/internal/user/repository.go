@@ -27,10 +27,21 @@ func (r *Repository) Create(ctx context.Context, input CreateUserInput) (*User,
 	id := ulid.Make().String()
 	now := time.Now().UTC()
 
-	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO users (id, email, password_hash, display_name, status, created_at, updated_at)
-		VALUES (?, ?, ?, ?, 'active', ?, ?)
-	`, id, input.Email, input.PasswordHash, input.DisplayName, now.Format(time.RFC3339), now.Format(time.RFC3339))
+	pending := 0
+	if input.PendingApproval {
+		pending = 1
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users (id, email, password_hash, display_name, status, pending_approval, created_at, updated_at)
+		VALUES (?, ?, ?, ?, 'active', ?, ?, ?)
+	`, id, input.Email, input.PasswordHash, input.DisplayName, pending, now.Format(time.RFC3339), now.Format(time.RFC3339))
 	if err != nil {
 		if isUniqueConstraintError(err) {
 			return nil, ErrEmailAlreadyInUse
@@ -38,26 +49,36 @@ func (r *Repository) Create(ctx context.Context, input CreateUserInput) (*User,
 		return nil, err
 	}
 
-	return &User{
-		ID:          id,
-		Email:       input.Email,
-		DisplayName: input.DisplayName,
-		Status:      "active",
-		CreatedAt:   now,
-		UpdatedAt:   now,
-	}, nil
+	u := &User{
+		ID:              id,
+		Email:           input.Email,
+		DisplayName:     input.DisplayName,
+		Status:          "active",
+		PendingApproval: input.PendingApproval,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if input.InTx != nil {
+		if err := input.InTx(ctx, tx, u); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return u, nil
 }
 
 func (r *Repository) GetByID(ctx context.Context, id string) (*User, error) {
 	return r.scanUser(r.db.QueryRowContext(ctx, `
-		SELECT id, email, email_verified_at, password_hash, display_name, avatar_url, status, created_at, updated_at
+		SELECT id, email, email_verified_at, password_hash, display_name, avatar_url, status, is_site_admin, pending_approval, created_at, updated_at
 		FROM users WHERE id = ?
 	`, id))
 }
 
 func (r *Repository) GetByEmail(ctx context.Context, email string) (*User, error) {
 	return r.scanUser(r.db.QueryRowContext(ctx, `
-		SELECT id, email, email_verified_at, password_hash, display_name, avatar_url, status, created_at, updated_at
+		SELECT id, email, email_verified_at, password_hash, display_name, avatar_url, status, is_site_admin, pending_approval, created_at, updated_at
 		FROM users WHERE email = ?
 	`, email))
 }
@@ -87,9 +108,135 @@ func (r *Repository) VerifyEmail(ctx context.Context, userID string) error {
 	return err
 }
 
-func (r *Repository) scanUser(row *sql.Row) (*User, error) {
+// SetSiteAdmin grants or revokes the site admin flag. Update leaves the flag
+// alone so that profile edits can never change it.
+func (r *Repository) SetSiteAdmin(ctx context.Context, userID string, isSiteAdmin bool) error {
+	value := 0
+	if isSiteAdmin {
+		value = 1
+	}
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE users SET is_site_admin = ?, updated_at = ? WHERE id = ?
+	`, value, time.Now().UTC().Format(time.RFC3339), userID)
+	return err
+}
+
+// Approve lets an account that was awaiting approval log in.
+func (r *Repository) Approve(ctx context.Context, userID string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE users SET pending_approval = 0, updated_at = ? WHERE id = ?
+	`, time.Now().UTC().Format(time.RFC3339), userID)
+	return err
+}
+
+// DeletePending removes an account that is still awaiting approval, freeing
+// its email address. Approved accounts are never deleted.
+func (r *Repository) DeletePending(ctx context.Context, userID string) error {
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM users WHERE id = ? AND pending_approval = 1
+	`, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// ListSiteAdmins returns the active site admins, who approve registrations.
+func (r *Repository) ListSiteAdmins(ctx context.Context) ([]User, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, email, email_verified_at, password_hash, display_name, avatar_url, status, is_site_admin, pending_approval, created_at, updated_at
+		FROM users WHERE is_site_admin = 1 AND status = ?
+		ORDER BY id
+	`, StatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		u, err := r.scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *u)
+	}
+	return users, rows.Err()
+}
+
+// List returns users newest first, optionally filtered by a case-insensitive
+// match on email or display name, or to accounts awaiting approval. The
+// cursor is the last user ID of the previous page.
+func (r *Repository) List(ctx context.Context, query string, pendingOnly bool, cursor string, limit int) ([]User, bool, string, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	where := "WHERE 1 = 1"
+	var args []interface{}
+	if query != "" {
+		pattern := "%" + strings.ToLower(query) + "%"
+		where += " AND (LOWER(email) LIKE ? OR LOWER(display_name) LIKE ?)"
+		args = append(args, pattern, pattern)
+	}
+	if pendingOnly {
+		where += " AND pending_approval = 1"
+	}
+	if cursor != "" {
+		where += " AND id < ?"
+		args = append(args, cursor)
+	}
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, email, email_verified_at, password_hash, display_name, avatar_url, status, is_site_admin, pending_approval, created_at, updated_at
+		FROM users `+where+`
+		ORDER BY id DESC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, false, "", err
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		u, err := r.scanUser(rows)
+		if err != nil {
+			return nil, false, "", err
+		}
+		users = append(users, *u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(users) > limit
+	nextCursor := ""
+	if hasMore {
+		users = users[:limit]
+		nextCursor = users[len(users)-1].ID
+	}
+
+	return users, hasMore, nextCursor, nil
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (r *Repository) scanUser(row rowScanner) (*User, error) {
 	var user User
 	var emailVerifiedAt, avatarURL sql.NullString
+	var isSiteAdmin, pendingApproval int
 	var createdAt, updatedAt string
 
 	err := row.Scan(
@@ -100,6 +247,8 @@ func (r *Repository) scanUser(row *sql.Row) (*User, error) {
 		&user.DisplayName,
 		&avatarURL,
 		&user.Status,
+		&isSiteAdmin,
+		&pendingApproval,
 		&createdAt,
 		&updatedAt,
 	)
@@ -117,6 +266,8 @@ func (r *Repository) scanUser(row *sql.Row) (*User, error) {
 	if avatarURL.Valid {
 		user.AvatarURL = &avatarURL.String
 	}
+	user.IsSiteAdmin = isSiteAdmin == 1
+	user.PendingApproval = pendingApproval == 1
 	user.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
 	user.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
 
@@ -12,8 +12,18 @@ type WorkspaceSettings struct {
 	WhoCanCreateInvites     PermissionLevel `json:"who_can_create_invites"`
 	WhoCanPinMessages       PermissionLevel `json:"who_can_pin_messages"`
 	WhoCanManageCustomEmoji PermissionLevel `json:"who_can_manage_custom_emoji"`
+	WhoCanMentionHere       PermissionLevel `json:"who_can_mention_here"`
+	WhoCanMentionChannel    PermissionLevel `json:"who_can_mention_channel"`
+	WhoCanMentionEveryone   PermissionLevel `json:"who_can_mention_everyone"`
+	// MentionConfirmThreshold is the number of people above which @here,
+	// @channel and @everyone must be confirmed before sending. 0 disables it.
+	MentionConfirmThreshold int `json:"mention_confirm_threshold"`
 }
 
+// DefaultMentionConfirmThreshold is the default number of people above which
+// @here, @channel and @everyone must be confirmed
+const DefaultMentionConfirmThreshold = 50
+
 // DefaultSettings returns the default workspace settings
 func DefaultSettings() WorkspaceSettings {
 	return WorkspaceSettings{
@@ -22,6 +32,10 @@ func DefaultSettings() WorkspaceSettings {
 		WhoCanCreateInvites:     PermissionAdmins,
 		WhoCanPinMessages:       PermissionMembers,
 		WhoCanManageCustomEmoji: PermissionMembers,
+		WhoCanMentionHere:       PermissionMembers,
+		WhoCanMentionChannel:    PermissionMembers,
+		WhoCanMentionEveryone:   PermissionAdmins,
+		MentionConfirmThreshold: DefaultMentionConfirmThreshold,
 	}
 }
 
@@ -45,6 +59,18 @@ func ParseSettings(settingsJSON string) WorkspaceSettings {
 	if !IsValidPermissionLevel(settings.WhoCanManageCustomEmoji) {
 		settings.WhoCanManageCustomEmoji = defaults.WhoCanManageCustomEmoji
 	}
+	if !IsValidPermissionLevel(settings.WhoCanMentionHere) {
+		settings.WhoCanMentionHere = defaults.WhoCanMentionHere
+	}
+	if !IsValidPermissionLevel(settings.WhoCanMentionChannel) {
+		settings.WhoCanMentionChannel = defaults.WhoCanMentionChannel
+	}
+	if !IsValidPermissionLevel(settings.WhoCanMentionEveryone) {
+		settings.WhoCanMentionEveryone = defaults.WhoCanMentionEveryone
+	}
+	if settings.MentionConfirmThreshold < 0 {
+		settings.MentionConfirmThreshold = defaults.MentionConfirmThreshold
+	}
 	return settings
 }
 
@@ -90,6 +116,14 @@ type MemberWithUser struct {
 	IsBanned    bool    `json:"is_banned"`
 }
 
+// WorkspaceWithCounts is a workspace with its membership totals, for
+// instance-wide listings.
+type WorkspaceWithCounts struct {
+	Workspace
+	MemberCount int `json:"member_count"`
+	OwnerCount  int `json:"owner_count"`
+}
+
 type Invite struct {
 	ID           string     `json:"id"`
 	WorkspaceID  string     `json:"workspace_id"`
@@ -103,6 +137,18 @@ type Invite struct {
 	CreatedAt    time.Time  `json:"created_at"`
 }
 
+// Usable returns ErrInviteExpired or ErrInviteMaxUsed if the invite can no
+// longer be accepted.
+func (i *Invite) Usable(now time.Time) error {
+	if i.ExpiresAt != nil && now.After(*i.ExpiresAt) {
+		return ErrInviteExpired
+	}
+	if i.MaxUses != nil && i.UseCount >= *i.MaxUses {
+		return ErrInviteMaxUsed
+	}
+	return nil
+}
+
 // PermissionLevel controls which roles can perform a given action
 type PermissionLevel string
 
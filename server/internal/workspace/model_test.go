@@ -177,6 +177,10 @@ func TestParseSettings(t *testing.T) {
 				WhoCanCreateInvites:     PermissionAdmins,
 				WhoCanPinMessages:       PermissionMembers,
 				WhoCanManageCustomEmoji: PermissionMembers,
+				WhoCanMentionHere:       PermissionMembers,
+				WhoCanMentionChannel:    PermissionMembers,
+				WhoCanMentionEveryone:   PermissionAdmins,
+				MentionConfirmThreshold: DefaultMentionConfirmThreshold,
 			},
 		},
 		{
@@ -193,8 +197,32 @@ func TestParseSettings(t *testing.T) {
 				WhoCanCreateInvites:     PermissionMembers,
 				WhoCanPinMessages:       PermissionEveryone,
 				WhoCanManageCustomEmoji: PermissionAdmins,
+				WhoCanMentionHere:       PermissionMembers,
+				WhoCanMentionChannel:    PermissionMembers,
+				WhoCanMentionEveryone:   PermissionAdmins,
+				MentionConfirmThreshold: DefaultMentionConfirmThreshold,
 			},
 		},
+		{
+			name: "mention permissions override defaults",
+			json: `{"who_can_mention_here":"everyone","who_can_mention_channel":"admins","who_can_mention_everyone":"members","mention_confirm_threshold":0}`,
+			expected: WorkspaceSettings{
+				ShowJoinLeaveMessages:   true,
+				WhoCanCreateChannels:    PermissionMembers,
+				WhoCanCreateInvites:     PermissionAdmins,
+				WhoCanPinMessages:       PermissionMembers,
+				WhoCanManageCustomEmoji: PermissionMembers,
+				WhoCanMentionHere:       PermissionEveryone,
+				WhoCanMentionChannel:    PermissionAdmins,
+				WhoCanMentionEveryone:   PermissionMembers,
+				MentionConfirmThreshold: 0,
+			},
+		},
+		{
+			name:     "invalid mention settings get defaults",
+			json:     `{"who_can_mention_everyone":"nobody","mention_confirm_threshold":-1}`,
+			expected: DefaultSettings(),
+		},
 		{
 			name:     "backward compat: missing permission fields get defaults",
 			json:     `{"show_join_leave_messages":true,"who_can_create_channels":"members"}`,
@@ -219,6 +247,10 @@ func TestWorkspaceSettings_ToJSON(t *testing.T) {
 		WhoCanCreateInvites:     PermissionMembers,
 		WhoCanPinMessages:       PermissionEveryone,
 		WhoCanManageCustomEmoji: PermissionAdmins,
+		WhoCanMentionHere:       PermissionAdmins,
+		WhoCanMentionChannel:    PermissionAdmins,
+		WhoCanMentionEveryone:   PermissionAdmins,
+		MentionConfirmThreshold: 10,
 	}
 	jsonStr := settings.ToJSON()
 
@@ -246,6 +278,9 @@ func TestDefaultSettings(t *testing.T) {
 	if defaults.WhoCanManageCustomEmoji != PermissionMembers {
 		t.Errorf("default WhoCanManageCustomEmoji should be %q, got %q", PermissionMembers, defaults.WhoCanManageCustomEmoji)
 	}
+	if defaults.WhoCanMentionEveryone != PermissionAdmins {
+		t.Errorf("default WhoCanMentionEveryone should be %q, got %q", PermissionAdmins, defaults.WhoCanMentionEveryone)
+	}
 }
 
 func TestWorkspace_ParsedSettings(t *testing.T) {
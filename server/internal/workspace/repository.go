@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/enzyme/server/internal/auth"
@@ -228,10 +229,10 @@ func (r *Repository) ListMembers(ctx context.Context, workspaceID string) ([]Mem
 		FROM workspace_memberships wm
 		JOIN users u ON u.id = wm.user_id
 		LEFT JOIN workspace_bans wb ON wb.workspace_id = wm.workspace_id AND wb.user_id = wm.user_id
-			AND (wb.expires_at IS NULL OR wb.expires_at > strftime('%Y-%m-%dT%H:%M:%SZ', 'now'))
+			AND (wb.expires_at IS NULL OR wb.expires_at > ?)
 		WHERE wm.workspace_id = ?
 		ORDER BY wm.created_at
-	`, workspaceID)
+	`, time.Now().UTC().Format(time.RFC3339), workspaceID)
 	if err != nil {
 		return nil, err
 	}
@@ -301,6 +302,140 @@ func (r *Repository) GetWorkspacesForUser(req *http.Request, userID string) ([]a
 	return workspaces, rows.Err()
 }
 
+// ListAll returns every workspace on the server with its member and owner
+// counts, ordered by name.
+func (r *Repository) ListAll(ctx context.Context) ([]WorkspaceWithCounts, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT w.id, w.name, w.icon_url, w.settings, w.created_at, w.updated_at,
+		       COUNT(wm.id),
+		       COALESCE(SUM(CASE WHEN wm.role = ? THEN 1 ELSE 0 END), 0)
+		FROM workspaces w
+		LEFT JOIN workspace_memberships wm ON wm.workspace_id = w.id
+		GROUP BY w.id, w.name, w.icon_url, w.settings, w.created_at, w.updated_at
+		ORDER BY w.name, w.id
+	`, RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanWorkspacesWithCounts(rows)
+}
+
+// Search returns workspaces newest first with their member and owner counts,
+// optionally filtered by a case-insensitive match on the name. The cursor is
+// the last workspace ID of the previous page.
+func (r *Repository) Search(ctx context.Context, query, cursor string, limit int) ([]WorkspaceWithCounts, bool, string, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	args := []interface{}{RoleOwner}
+	where := "WHERE 1 = 1"
+	if query != "" {
+		where += " AND LOWER(w.name) LIKE ?"
+		args = append(args, "%"+strings.ToLower(query)+"%")
+	}
+	if cursor != "" {
+		where += " AND w.id < ?"
+		args = append(args, cursor)
+	}
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT w.id, w.name, w.icon_url, w.settings, w.created_at, w.updated_at,
+		       COUNT(wm.id),
+		       COALESCE(SUM(CASE WHEN wm.role = ? THEN 1 ELSE 0 END), 0)
+		FROM workspaces w
+		LEFT JOIN workspace_memberships wm ON wm.workspace_id = w.id
+		`+where+`
+		GROUP BY w.id, w.name, w.icon_url, w.settings, w.created_at, w.updated_at
+		ORDER BY w.id DESC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, false, "", err
+	}
+	defer rows.Close()
+
+	workspaces, err := scanWorkspacesWithCounts(rows)
+	if err != nil {
+		return nil, false, "", err
+	}
+
+	hasMore := len(workspaces) > limit
+	nextCursor := ""
+	if hasMore {
+		workspaces = workspaces[:limit]
+		nextCursor = workspaces[len(workspaces)-1].ID
+	}
+
+	return workspaces, hasMore, nextCursor, nil
+}
+
+func scanWorkspacesWithCounts(rows *sql.Rows) ([]WorkspaceWithCounts, error) {
+	var workspaces []WorkspaceWithCounts
+	for rows.Next() {
+		var w WorkspaceWithCounts
+		var iconURL sql.NullString
+		var createdAt, updatedAt string
+
+		if err := rows.Scan(&w.ID, &w.Name, &iconURL, &w.Settings, &createdAt, &updatedAt, &w.MemberCount, &w.OwnerCount); err != nil {
+			return nil, err
+		}
+		if iconURL.Valid {
+			w.IconURL = &iconURL.String
+		}
+		w.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+		w.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+		workspaces = append(workspaces, w)
+	}
+
+	return workspaces, rows.Err()
+}
+
+// ListWorkspaceIDsForUser returns the IDs of the workspaces a user belongs to.
+func (r *Repository) ListWorkspaceIDsForUser(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT workspace_id FROM workspace_memberships WHERE user_id = ? ORDER BY workspace_id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetMemberUserIDs returns the IDs of a workspace's members
+func (r *Repository) GetMemberUserIDs(ctx context.Context, workspaceID string) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT user_id FROM workspace_memberships WHERE workspace_id = ?
+	`, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
+
 // ReorderWorkspaces updates the sort order of workspaces for a user
 func (r *Repository) ReorderWorkspaces(ctx context.Context, userID string, workspaceIDs []string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -395,14 +530,8 @@ func (r *Repository) AcceptInvite(ctx context.Context, code string, userID strin
 		return nil, err
 	}
 
-	// Check expiry
-	if invite.ExpiresAt != nil && time.Now().After(*invite.ExpiresAt) {
-		return nil, ErrInviteExpired
-	}
-
-	// Check max uses
-	if invite.MaxUses != nil && invite.UseCount >= *invite.MaxUses {
-		return nil, ErrInviteMaxUsed
+	if err := invite.Usable(time.Now()); err != nil {
+		return nil, err
 	}
 
 	// Add member
@@ -419,6 +548,64 @@ func (r *Repository) AcceptInvite(ctx context.Context, code string, userID strin
 	return r.GetByID(ctx, invite.WorkspaceID)
 }
 
+// RedeemInviteTx uses up one use of an invite and adds userID to its
+// workspace within tx. The use count is checked and incremented in a single
+// statement, so concurrent redemptions can't exceed max_uses.
+func (r *Repository) RedeemInviteTx(ctx context.Context, tx *sql.Tx, code, userID string) (*Invite, error) {
+	now := time.Now().UTC()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE workspace_invites SET use_count = use_count + 1
+		WHERE code = ? AND (max_uses IS NULL OR use_count < max_uses) AND (expires_at IS NULL OR expires_at > ?)
+	`, code, now.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	var invite Invite
+	var maxUses sql.NullInt64
+	var expiresAt sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, workspace_id, role, max_uses, use_count, expires_at FROM workspace_invites WHERE code = ?
+	`, code).Scan(&invite.ID, &invite.WorkspaceID, &invite.Role, &maxUses, &invite.UseCount, &expiresAt)
+	if err == sql.ErrNoRows {
+		return nil, ErrInviteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	invite.Code = code
+	if maxUses.Valid {
+		v := int(maxUses.Int64)
+		invite.MaxUses = &v
+	}
+	if expiresAt.Valid {
+		t, _ := time.Parse(time.RFC3339, expiresAt.String)
+		invite.ExpiresAt = &t
+	}
+	if n == 0 {
+		if err := invite.Usable(now); err != nil {
+			return nil, err
+		}
+		return nil, ErrInviteMaxUsed
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO workspace_memberships (id, user_id, workspace_id, role, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, ulid.Make().String(), userID, invite.WorkspaceID, invite.Role, now.Format(time.RFC3339), now.Format(time.RFC3339))
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return nil, ErrMembershipExists
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
 func (r *Repository) scanWorkspace(row *sql.Row) (*Workspace, error) {
 	var w Workspace
 	var iconURL sql.NullString
@@ -424,3 +424,58 @@ func TestRepository_AcceptInvite_MaxUsed(t *testing.T) {
 		t.Errorf("second AcceptInvite() error = %v, want %v", err, ErrInviteMaxUsed)
 	}
 }
+
+func TestRepository_RedeemInviteTx(t *testing.T) {
+	db := testutil.TestDB(t)
+	repo := NewRepository(db)
+	ctx := context.Background()
+
+	owner := testutil.CreateTestUser(t, db, "owner@example.com", "Owner")
+	member1 := testutil.CreateTestUser(t, db, "member1@example.com", "Member 1")
+	member2 := testutil.CreateTestUser(t, db, "member2@example.com", "Member 2")
+
+	ws := &Workspace{Name: "Test WS", Settings: "{}"}
+	repo.Create(ctx, ws, owner.ID)
+
+	maxUses := 1
+	invite := &Invite{WorkspaceID: ws.ID, Role: RoleMember, MaxUses: &maxUses}
+	repo.CreateInvite(ctx, invite)
+
+	redeem := func(userID string, commit bool) error {
+		tx, err := repo.BeginTx(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tx.Rollback()
+		if _, err := repo.RedeemInviteTx(ctx, tx, invite.Code, userID); err != nil {
+			return err
+		}
+		if commit {
+			return tx.Commit()
+		}
+		return nil
+	}
+
+	// A rolled-back redemption doesn't use up the invite
+	if err := redeem(member1.ID, false); err != nil {
+		t.Fatalf("RedeemInviteTx() error = %v", err)
+	}
+	if err := redeem(member1.ID, true); err != nil {
+		t.Fatalf("RedeemInviteTx() error = %v", err)
+	}
+	if _, err := repo.GetMembership(ctx, member1.ID, ws.ID); err != nil {
+		t.Errorf("GetMembership() error = %v", err)
+	}
+
+	if err := redeem(member2.ID, true); !errors.Is(err, ErrInviteMaxUsed) {
+		t.Errorf("second RedeemInviteTx() error = %v, want %v", err, ErrInviteMaxUsed)
+	}
+	if err := func() error {
+		tx, _ := repo.BeginTx(ctx)
+		defer tx.Rollback()
+		_, err := repo.RedeemInviteTx(ctx, tx, "bogus", member2.ID)
+		return err
+	}(); !errors.Is(err, ErrInviteNotFound) {
+		t.Errorf("RedeemInviteTx(bogus) error = %v, want %v", err, ErrInviteNotFound)
+	}
+}